@@ -15,16 +15,38 @@ import (
 	"github.com/audetv/urms/internal/core/domain"
 	"github.com/audetv/urms/internal/core/ports"
 	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/auth"
 	"github.com/audetv/urms/internal/infrastructure/common/id"
 	"github.com/audetv/urms/internal/infrastructure/email"
 	imapclient "github.com/audetv/urms/internal/infrastructure/email/imap"
+	"github.com/audetv/urms/internal/infrastructure/events"
+	"github.com/audetv/urms/internal/infrastructure/github"
 	"github.com/audetv/urms/internal/infrastructure/health"
 	"github.com/audetv/urms/internal/infrastructure/http/handlers"
 	"github.com/audetv/urms/internal/infrastructure/http/middleware"
+	"github.com/audetv/urms/internal/infrastructure/http/openapi"
+	"github.com/audetv/urms/internal/infrastructure/idempotency"
 	"github.com/audetv/urms/internal/infrastructure/logging"
+	authinmemory "github.com/audetv/urms/internal/infrastructure/persistence/auth/inmemory"
+	authpostgres "github.com/audetv/urms/internal/infrastructure/persistence/auth/postgres"
+	channelinmemory "github.com/audetv/urms/internal/infrastructure/persistence/channel/inmemory"
+	channelpostgres "github.com/audetv/urms/internal/infrastructure/persistence/channel/postgres"
 	persistence "github.com/audetv/urms/internal/infrastructure/persistence/email"
 	"github.com/audetv/urms/internal/infrastructure/persistence/email/postgres"
+	eventsinmemory "github.com/audetv/urms/internal/infrastructure/persistence/events/inmemory"
+	eventspostgres "github.com/audetv/urms/internal/infrastructure/persistence/events/postgres"
+	idempotencyinmemory "github.com/audetv/urms/internal/infrastructure/persistence/idempotency/inmemory"
+	idempotencypostgres "github.com/audetv/urms/internal/infrastructure/persistence/idempotency/postgres"
+	searchinmemory "github.com/audetv/urms/internal/infrastructure/persistence/search/inmemory"
+	searchpostgres "github.com/audetv/urms/internal/infrastructure/persistence/search/postgres"
 	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	taskpostgres "github.com/audetv/urms/internal/infrastructure/persistence/task/postgres"
+	"github.com/audetv/urms/internal/infrastructure/persistence/transaction"
+	webhookinmemory "github.com/audetv/urms/internal/infrastructure/persistence/webhook/inmemory"
+	webhookpostgres "github.com/audetv/urms/internal/infrastructure/persistence/webhook/postgres"
+	"github.com/audetv/urms/internal/infrastructure/scheduler"
+	"github.com/audetv/urms/internal/infrastructure/telegram"
+	"github.com/audetv/urms/internal/infrastructure/webhook"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -88,6 +110,67 @@ func main() {
 		backgroundManager.RegisterTask(emailPollerTask)
 	}
 
+	if cfg.Scheduler.Interval > 0 {
+		schedulerTask := scheduler.NewSchedulerTask(
+			dependencies.SchedulerService,
+			cfg.Scheduler.Interval,
+			cfg.Scheduler.OperationTimeout,
+			logger,
+		)
+		backgroundManager.RegisterTask(schedulerTask)
+	}
+
+	if cfg.Outbox.RelayInterval > 0 {
+		outboxRelayTask := events.NewOutboxRelayTask(
+			dependencies.OutboxRelay,
+			cfg.Outbox.RelayInterval,
+			cfg.Outbox.OperationTimeout,
+			logger,
+		)
+		backgroundManager.RegisterTask(outboxRelayTask)
+	}
+
+	if cfg.Webhooks.DeliveryInterval > 0 {
+		webhookDeliveryTask := webhook.NewDeliveryTask(
+			dependencies.WebhookService,
+			cfg.Webhooks.DeliveryInterval,
+			cfg.Webhooks.OperationTimeout,
+			logger,
+		)
+		backgroundManager.RegisterTask(webhookDeliveryTask)
+	}
+
+	if cfg.Idempotency.CleanupInterval > 0 {
+		idempotencyCleanupTask := idempotency.NewCleanupTask(
+			dependencies.IdempotencyService,
+			dependencies.InboundDeliveryService,
+			cfg.Idempotency.CleanupInterval,
+			cfg.Idempotency.OperationTimeout,
+			logger,
+		)
+		backgroundManager.RegisterTask(idempotencyCleanupTask)
+	}
+
+	if dependencies.TelegramService != nil && cfg.Telegram.Mode == "polling" {
+		telegramPollerTask := telegram.NewPollerTask(
+			dependencies.TelegramService,
+			cfg.Telegram.RetryInterval,
+			cfg.Telegram.PollTimeout+cfg.Telegram.RequestTimeout,
+			logger,
+		)
+		backgroundManager.RegisterTask(telegramPollerTask)
+	}
+
+	if dependencies.GitHubService != nil && cfg.GitHub.SyncInterval > 0 {
+		githubSyncTask := github.NewSyncTask(
+			dependencies.GitHubService,
+			cfg.GitHub.SyncInterval,
+			cfg.GitHub.OperationTimeout,
+			logger,
+		)
+		backgroundManager.RegisterTask(githubSyncTask)
+	}
+
 	// Запускаем фоновые задачи
 	if err := backgroundManager.StartAll(ctx); err != nil {
 		logger.Error(ctx, "❌ CRITICAL: Failed to start background tasks - email processing unavailable",
@@ -112,10 +195,46 @@ type Dependencies struct {
 	EmailGateway     ports.EmailGateway
 	Logger           ports.Logger
 	// ✅ ДОБАВЛЯЕМ Task Management сервисы
-	TaskService     ports.TaskService
-	CustomerService ports.CustomerService
+	TaskService          ports.TaskService
+	CustomerService      ports.CustomerService
+	CustomFieldService   ports.CustomFieldService
+	ReplyTemplateService ports.ReplyTemplateService
+	SchedulerService     ports.SchedulerService
+	SatisfactionService  ports.SatisfactionService
+	AuditService         ports.AuditService
+	UserService          ports.UserService
+	SearchService        ports.SearchService
+	// Просмотр и исправление сохраненных писем через API
+	EmailMessageService ports.EmailMessageService
+	// Доменные события: подписчики регистрируются в EventBus, доставку выполняет OutboxRelay
+	EventBus    ports.EventBus
+	OutboxRelay ports.OutboxRelay
+	// Исходящие вебхуки подписаны на все события шины
+	WebhookService ports.WebhookService
+	// Поток изменений задач (SSE) и его параметры
+	TaskStreamService       ports.TaskStreamService
+	StreamHeartbeatInterval time.Duration
+	StreamRetryInterval     time.Duration
+	// Повтор ответов на запросы создания с заголовком Idempotency-Key
+	IdempotencyService ports.IdempotencyService
+	// Защита входящих каналов от повторной доставки одной записи
+	InboundDeliveryService ports.InboundDeliveryService
+	// Входящие каналы создают задачи из записей внешних систем
+	InboundChannelService ports.InboundChannelService
+	// Telegram-бот (nil, если токен не задан); секрет вебхука задан только в режиме webhook
+	TelegramService       ports.TelegramService
+	TelegramWebhookSecret string
+
+	// Синхронизация с GitHub issues (nil, если токен или репозитории не заданы)
+	GitHubService       ports.GitHubService
+	GitHubWebhookSecret string
+
+	// Аутентификация REST API (nil, если отключена в конфигурации)
+	AuthService ports.AuthService
 	// ✅ ДОБАВЛЯЕМ конфигурационный провайдер
 	SearchConfigProvider ports.EmailSearchConfigProvider
+	// Каналы, политика обработки и окна поиска email, изменяемые через API
+	EmailSettingsService ports.EmailSettingsService
 }
 
 // setupDependencies инициализирует все зависимости приложения
@@ -127,7 +246,7 @@ func setupDependencies(cfg *config.Config, logger ports.Logger) (*Dependencies,
 	logger.Info(context.Background(), "🛠️ Initializing dependencies")
 
 	// ✅ ИНИЦИАЛИЗИРУЕМ КОНФИГУРАЦИОННЫЙ ПРОВАЙДЕР ПЕРВЫМ
-	deps.SearchConfigProvider = setupSearchConfig(cfg, logger)
+	fileSearchConfig := setupSearchConfig(cfg, logger)
 
 	// Инициализируем базу данных если используется PostgreSQL
 	if cfg.Database.Provider == "postgres" {
@@ -140,8 +259,30 @@ func setupDependencies(cfg *config.Config, logger ports.Logger) (*Dependencies,
 		logger.Info(context.Background(), "✅ Connected to PostgreSQL database")
 	}
 
-	// Инициализируем IMAP адаптер с новой конфигурацией таймаутов
-	deps.EmailGateway = setupIMAPAdapter(cfg, logger, deps.SearchConfigProvider) // ✅ ПЕРЕДАЕМ конфигурацию
+	// Настройки email хранятся в базе и применяются без перезапуска; файловая
+	// конфигурация поиска задает начальные окна и настройки провайдеров
+	emailSettingsRepo, err := persistence.NewEmailSettingsRepository(
+		persistence.RepositoryType(cfg.Database.Provider),
+		deps.DB,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email settings repository: %w", err)
+	}
+	emailSettings := services.NewEmailSettingsService(emailSettingsRepo, fileSearchConfig, logger)
+	deps.SearchConfigProvider = emailSettings.SearchConfigProvider()
+
+	// Инициализируем IMAP адаптер с новой конфигурацией таймаутов; активный канал
+	// из настроек переключает шлюз без перезапуска
+	gatewayFactory := func(channel *domain.EmailChannelConfig) (ports.EmailGateway, error) {
+		return setupIMAPAdapter(cfg, channel, logger, deps.SearchConfigProvider), nil
+	}
+	emailGateway := email.NewReloadableGateway(
+		setupIMAPAdapter(cfg, imapChannelFromConfig(cfg), logger, deps.SearchConfigProvider),
+		gatewayFactory,
+		logger,
+	)
+	emailSettings.SetGateway(emailGateway, gatewayFactory)
+	deps.EmailGateway = emailGateway
 
 	// Инициализируем email репозиторий
 	emailRepo, err := persistence.NewEmailRepository(
@@ -153,13 +294,224 @@ func setupDependencies(cfg *config.Config, logger ports.Logger) (*Dependencies,
 		return nil, fmt.Errorf("failed to create email repository: %w", err)
 	}
 
+	// Полнотекстовый индекс обновляется обертками репозиториев при каждом сохранении
+	var searchIndex ports.SearchIndex = searchinmemory.NewSearchIndex(logger)
+	if deps.DB != nil {
+		searchIndex = searchpostgres.NewPostgresSearchIndex(deps.DB)
+	}
+	emailRepo = services.NewIndexedEmailRepository(emailRepo, searchIndex, logger)
+
 	// ✅ ПЕРВОЕ: Инициализация Task Management сервисов
-	taskRepo := inmemory.NewTaskRepository(logger)
-	customerRepo := inmemory.NewCustomerRepository(logger)
-	userRepo := inmemory.NewUserRepository(logger)
+	customerRepo := services.NewIndexedCustomerRepository(inmemory.NewCustomerRepository(logger), searchIndex, logger)
+	// Без PostgreSQL пользователи хранятся в памяти с демонстрационными учетными записями
+	var userRepo ports.UserRepository = inmemory.NewUserRepository(logger)
+	if deps.DB != nil {
+		userRepo = taskpostgres.NewPostgresUserRepository(deps.DB)
+	}
 
-	deps.TaskService = services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
-	deps.CustomerService = services.NewCustomerService(customerRepo, taskRepo, logger)
+	// Журнал аудита хранится в PostgreSQL, если он подключен; все сохранения задач
+	// проходят через него, поэтому история не зависит от того, какой сервис изменил задачу
+	var auditRepo ports.AuditRepository = inmemory.NewAuditRepository(logger)
+	if deps.DB != nil {
+		auditRepo = taskpostgres.NewPostgresAuditRepository(deps.DB)
+	}
+	auditedTaskRepo := services.NewAuditedTaskRepository(inmemory.NewTaskRepository(logger), auditRepo, logger)
+
+	// Изменения задач передаются подключенным клиентам сразу, минуя outbox;
+	// поток охватывает изменения, сделанные этим экземпляром API
+	taskStream := services.NewTaskStreamBroker(services.TaskStreamConfig{
+		BufferSize:       cfg.Stream.BufferSize,
+		SubscriberBuffer: cfg.Stream.SubscriberBuffer,
+	}, logger)
+	taskRepo := services.NewStreamingTaskRepository(
+		services.NewIndexedTaskRepository(auditedTaskRepo, searchIndex, logger), taskStream)
+
+	// Доменные события пишутся в outbox в той же единице работы, что и изменение,
+	// и доставляются подписчикам шины фоновым ретранслятором
+	var outboxRepo ports.OutboxRepository = eventsinmemory.NewOutboxRepository(logger)
+	var unitOfWork ports.UnitOfWork = services.NoopUnitOfWork{}
+	if deps.DB != nil {
+		outboxRepo = eventspostgres.NewPostgresOutboxRepository(deps.DB)
+		unitOfWork = transaction.NewPostgresUnitOfWork(deps.DB)
+	}
+	eventPublisher := services.NewOutboxPublisher(outboxRepo)
+	eventBus := services.NewEventBus(logger)
+	deps.EventBus = eventBus
+	deps.OutboxRelay = services.NewOutboxRelayService(outboxRepo, eventBus, services.OutboxRelayConfig{
+		BatchSize:     cfg.Outbox.BatchSize,
+		MaxAttempts:   cfg.Outbox.MaxAttempts,
+		BaseDelay:     cfg.Outbox.RetryBaseDelay,
+		MaxDelay:      cfg.Outbox.RetryMaxDelay,
+		BackoffFactor: 2,
+		Lease:         time.Minute,
+		Retention:     cfg.Outbox.Retention,
+	}, logger)
+	auditedTaskRepo.SetEventPublisher(eventPublisher, unitOfWork)
+	deps.AuditService = services.NewAuditService(auditRepo, taskRepo, logger)
+
+	// Вебхуки получают события из шины и доставляются отдельной фоновой задачей,
+	// поэтому медленный получатель не задерживает ретранслятор outbox
+	var webhookRepo ports.WebhookRepository = webhookinmemory.NewWebhookRepository(logger)
+	var webhookDeliveryRepo ports.WebhookDeliveryRepository = webhookinmemory.NewWebhookDeliveryRepository(logger)
+	if deps.DB != nil {
+		webhookRepo = webhookpostgres.NewPostgresWebhookRepository(deps.DB)
+		webhookDeliveryRepo = webhookpostgres.NewPostgresWebhookDeliveryRepository(deps.DB)
+	}
+	webhookService := services.NewWebhookService(
+		webhookRepo,
+		webhookDeliveryRepo,
+		webhook.NewHTTPSender(cfg.Webhooks.RequestTimeout),
+		services.WebhookConfig{
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			BaseDelay:   cfg.Webhooks.RetryBaseDelay,
+			MaxDelay:    cfg.Webhooks.RetryMaxDelay,
+			Timeout:     cfg.Webhooks.RequestTimeout,
+		},
+		logger,
+	)
+	eventBus.Subscribe(domain.DomainEventTypeAll, "webhooks", webhookService.HandleEvent)
+
+	// Описания пользовательских полей хранятся в PostgreSQL, если он подключен
+	var customFieldRepo ports.CustomFieldRepository = inmemory.NewCustomFieldRepository(logger)
+	if deps.DB != nil {
+		customFieldRepo = taskpostgres.NewPostgresCustomFieldRepository(deps.DB)
+	}
+
+	// Права пользователя проверяются обертками сервисов, поэтому действуют для любого
+	// вызывающего кода; фоновые задачи работают в системном context без ограничений
+	authorizer := services.NewRoleAuthorizer(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	taskService.SetCustomFieldRepository(customFieldRepo)
+
+	deps.TaskStreamService = services.NewTaskStreamService(taskStream, authorizer, logger)
+	deps.StreamHeartbeatInterval = cfg.Stream.HeartbeatInterval
+	deps.StreamRetryInterval = cfg.Stream.RetryInterval
+	deps.TaskService = services.NewAuthorizedTaskService(taskService, authorizer)
+	deps.CustomFieldService = services.NewAuthorizedCustomFieldService(services.NewCustomFieldService(customFieldRepo, logger), authorizer)
+	deps.CustomerService = services.NewAuthorizedCustomerService(services.NewCustomerService(customerRepo, taskRepo, logger), authorizer)
+	deps.AuditService = services.NewAuthorizedAuditService(deps.AuditService, taskService, authorizer)
+	deps.WebhookService = services.NewAuthorizedWebhookService(webhookService, authorizer)
+	deps.SearchService = services.NewAuthorizedSearchService(
+		services.NewSearchService(searchIndex, taskRepo, customerRepo, emailRepo, logger),
+		authorizer,
+	)
+
+	// Ключи идемпотентности в PostgreSQL общие для всех экземпляров API
+	var idempotencyStore ports.IdempotencyStore = idempotencyinmemory.NewIdempotencyStore(logger)
+	if deps.DB != nil {
+		idempotencyStore = idempotencypostgres.NewPostgresIdempotencyStore(deps.DB)
+	}
+	deps.IdempotencyService = services.NewIdempotencyService(idempotencyStore, services.IdempotencyConfig{
+		TTL:         cfg.Idempotency.TTL,
+		LockTimeout: cfg.Idempotency.LockTimeout,
+	}, logger)
+
+	var channelRepo ports.InboundChannelRepository = channelinmemory.NewInboundChannelRepository(logger)
+	var deliveryStore ports.InboundDeliveryStore = channelinmemory.NewInboundDeliveryStore(logger)
+	if deps.DB != nil {
+		channelRepo = channelpostgres.NewPostgresInboundChannelRepository(deps.DB)
+		deliveryStore = channelpostgres.NewPostgresInboundDeliveryStore(deps.DB)
+	}
+	deps.InboundDeliveryService = services.NewInboundDeliveryService(deliveryStore, services.InboundDeliveryConfig{
+		TTL:         cfg.Idempotency.TTL,
+		LockTimeout: cfg.Idempotency.LockTimeout,
+	}, logger)
+	inboundChannelService := services.NewInboundChannelService(channelRepo, taskRepo, deps.TaskService, deps.CustomerService, logger)
+	inboundChannelService.SetDeliveryService(deps.InboundDeliveryService)
+	deps.InboundChannelService = services.NewAuthorizedInboundChannelService(inboundChannelService, authorizer)
+
+	// Ответы операторов уходят в чат Telegram через подписку на события задач
+	if cfg.Telegram.Enabled() {
+		telegramService := services.NewTelegramService(
+			telegram.NewClient(cfg.Telegram.APIBaseURL, cfg.Telegram.BotToken, cfg.Telegram.RequestTimeout),
+			taskRepo,
+			deps.TaskService,
+			deps.CustomerService,
+			cfg.Telegram.PollTimeout,
+			logger,
+		)
+		telegramService.SetDeliveryService(deps.InboundDeliveryService)
+		eventBus.Subscribe(domain.DomainEventTaskMessageAdded, "telegram", telegramService.HandleTaskEvent)
+		deps.TelegramService = telegramService
+		if cfg.Telegram.Mode == "webhook" {
+			deps.TelegramWebhookSecret = cfg.Telegram.WebhookSecret
+		}
+	}
+
+	// Комментарии и закрытие связанных задач уходят в GitHub через подписку на события задач
+	if cfg.GitHub.Enabled() {
+		githubService := services.NewGitHubService(
+			github.NewClient(cfg.GitHub.APIBaseURL, cfg.GitHub.Token, cfg.GitHub.RequestTimeout),
+			taskRepo,
+			deps.TaskService,
+			deps.CustomerService,
+			services.GitHubConfig{
+				Repositories:    cfg.GitHub.Repositories,
+				InitialLookback: cfg.GitHub.InitialLookback,
+			},
+			logger,
+		)
+		eventBus.Subscribe(domain.DomainEventTaskMessageAdded, "github", githubService.HandleTaskEvent)
+		eventBus.Subscribe(domain.DomainEventTaskStatusChanged, "github", githubService.HandleTaskEvent)
+		deps.GitHubService = services.NewAuthorizedGitHubService(githubService, taskService, authorizer)
+		deps.GitHubWebhookSecret = cfg.GitHub.WebhookSecret
+	}
+
+	// Пароли приглашенных пользователей и вход по паролю используют один алгоритм хеширования
+	passwordHasher := auth.NewBcryptHasher(0)
+	deps.UserService = services.NewAuthorizedUserService(services.NewUserService(userRepo, passwordHasher, logger), authorizer)
+
+	if cfg.Auth.Enabled {
+		authService, err := setupAuthService(cfg.Auth, userRepo, passwordHasher, deps.DB, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup authentication: %w", err)
+		}
+		deps.AuthService = authService
+	} else {
+		logger.Warn(context.Background(), "⚠️ REST API authentication is disabled")
+	}
+
+	replyTemplateService := services.NewReplyTemplateService(
+		inmemory.NewReplyTemplateRepository(logger),
+		deps.TaskService,
+		customerRepo,
+		userRepo,
+		logger,
+	)
+	deps.ReplyTemplateService = services.NewAuthorizedReplyTemplateService(replyTemplateService, authorizer)
+
+	// Правила повторяющихся задач и запланированные ответы переживают перезапуск в PostgreSQL
+	var recurringTaskRepo ports.RecurringTaskRepository = inmemory.NewRecurringTaskRepository(logger)
+	var scheduledReplyRepo ports.ScheduledReplyRepository = inmemory.NewScheduledReplyRepository(logger)
+	if deps.DB != nil {
+		recurringTaskRepo = taskpostgres.NewPostgresRecurringTaskRepository(deps.DB)
+		scheduledReplyRepo = taskpostgres.NewPostgresScheduledReplyRepository(deps.DB)
+	}
+
+	schedulerService := services.NewSchedulerService(
+		recurringTaskRepo,
+		scheduledReplyRepo,
+		deps.TaskService,
+		customerRepo,
+		logger,
+	)
+	deps.SchedulerService = services.NewAuthorizedSchedulerService(schedulerService, taskService, authorizer)
+
+	// Опросы удовлетворенности отправляются при решении задач поддержки
+	satisfactionService := services.NewSatisfactionService(
+		taskRepo,
+		customerRepo,
+		userRepo,
+		services.SurveyConfig{
+			Secret:        cfg.Survey.Secret,
+			PublicBaseURL: cfg.Survey.PublicBaseURL,
+			TokenTTL:      cfg.Survey.TokenTTL,
+		},
+		logger,
+	)
+	eventBus.Subscribe(domain.DomainEventTaskStatusChanged, "satisfaction", satisfactionService.HandleTaskEvent)
+	deps.SatisfactionService = services.NewAuthorizedSatisfactionService(satisfactionService, taskService, authorizer)
 
 	logger.Info(context.Background(), "✅ Task Management services initialized")
 
@@ -173,6 +525,26 @@ func setupDependencies(cfg *config.Config, logger ports.Logger) (*Dependencies,
 		logger,
 	)
 
+	deps.EmailService.SetEventPublisher(eventPublisher, unitOfWork)
+	deps.EmailService.SetTaskService(deps.TaskService)
+	deps.EmailMessageService = services.NewAuthorizedEmailMessageService(deps.EmailService, authorizer)
+
+	// Сохраненные настройки заменяют значения из конфигурации приложения
+	emailSettings.SetEmailService(deps.EmailService)
+	var defaultChannel *domain.EmailChannelConfig
+	if cfg.Email.IMAP.Username != "" {
+		defaultChannel = imapChannelFromConfig(cfg)
+	}
+	if err := emailSettings.Load(context.Background(), defaultChannel, defaultEmailPolicy()); err != nil {
+		return nil, fmt.Errorf("failed to load email settings: %w", err)
+	}
+	deps.EmailSettingsService = services.NewAuthorizedEmailSettingsService(emailSettings, authorizer)
+
+	// Ответы по шаблонам для email-задач отправляются клиенту от имени почтового ящика
+	replyTemplateService.SetEmailSender(deps.EmailService, domain.EmailAddress(cfg.Email.IMAP.Username))
+	schedulerService.SetEmailSender(deps.EmailService, domain.EmailAddress(cfg.Email.IMAP.Username))
+	satisfactionService.SetEmailSender(deps.EmailService, domain.EmailAddress(cfg.Email.IMAP.Username))
+
 	// Инициализируем health checks
 	deps.HealthAggregator = setupHealthChecks(deps.EmailGateway, deps.DB)
 
@@ -181,62 +553,77 @@ func setupDependencies(cfg *config.Config, logger ports.Logger) (*Dependencies,
 	return deps, nil
 }
 
-// setupSearchConfig настраивает конфигурационную систему для email поиска
-func setupSearchConfig(cfg *config.Config, logger ports.Logger) ports.EmailSearchConfigProvider {
-	// ✅ СОЗДАЕМ КОНФИГУРАЦИЮ ДЛЯ EMAIL ПОИСКА
-	searchConfig := &email.EmailSearchConfig{
-		ThreadSearch: email.ThreadSearchConfig{
-			DefaultDaysBack:     180, // 6 месяцев
-			ExtendedDaysBack:    365, // 1 год
-			MaxDaysBack:         730, // 2 года
-			FetchTimeout:        120 * time.Second,
-			IncludeSeenMessages: true,
-			SubjectPrefixes: []string{
-				"Re:", "RE:", "Fwd:", "FW:", "Ответ:", "FWD:",
-			},
-		},
-		ProviderConfig: map[string]email.ProviderSearchConfig{
-			"gmail": {
-				MaxDaysBack:   365,
-				SearchTimeout: 180 * time.Second,
-				SupportedFlags: []string{
-					"X-GM-RAW", "X-GM-THRID",
-				},
-				Optimizations: []string{
-					"gmail_thread_id", "extended_history", "label_support",
-				},
-			},
-			"yandex": {
-				MaxDaysBack:    90,
-				SearchTimeout:  90 * time.Second,
-				SupportedFlags: []string{},
-				Optimizations: []string{
-					"russian_subject_support", "cyrillic_encoding",
-				},
-			},
-			"outlook": {
-				MaxDaysBack:    180,
-				SearchTimeout:  120 * time.Second,
-				SupportedFlags: []string{},
-				Optimizations: []string{
-					"exchange_support", "conversation_id",
-				},
-			},
-			"generic": {
-				MaxDaysBack:    180,
-				SearchTimeout:  120 * time.Second,
-				SupportedFlags: []string{},
-				Optimizations: []string{
-					"standard_search",
-				},
-			},
+// setupAuthService создает сервис аутентификации и задает начальный пароль администратора,
+// если он указан в конфигурации и у пользователя еще нет пароля; отсутствующий администратор создается
+func setupAuthService(cfg config.AuthConfig, userRepo ports.UserRepository, hasher ports.PasswordHasher, db *sqlx.DB, logger ports.Logger) (ports.AuthService, error) {
+	signer, err := auth.NewJWTSigner(cfg.JWTSecret, "urms")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiKeyRepo ports.APIKeyRepository = authinmemory.NewAPIKeyRepository(logger)
+	if db != nil {
+		apiKeyRepo = authpostgres.NewPostgresAPIKeyRepository(db)
+	}
+
+	authService := services.NewAuthService(
+		userRepo,
+		apiKeyRepo,
+		hasher,
+		signer,
+		services.AuthConfig{
+			AccessTokenTTL:  cfg.AccessTokenTTL,
+			RefreshTokenTTL: cfg.RefreshTokenTTL,
 		},
+		logger,
+	)
+
+	if cfg.BootstrapAdminPassword != "" {
+		ctx := context.Background()
+		admin, err := userRepo.FindByEmail(ctx, cfg.BootstrapAdminEmail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find bootstrap admin: %w", err)
+		}
+		if admin == nil {
+			// В новой базе PostgreSQL пользователей нет: первый администратор создается здесь
+			admin, err = domain.NewUser(cfg.BootstrapAdminEmail, "Administrator", domain.UserRoleAdmin)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bootstrap admin: %w", err)
+			}
+			admin.Activate(time.Now())
+			if err := userRepo.Save(ctx, admin); err != nil {
+				return nil, fmt.Errorf("failed to create bootstrap admin: %w", err)
+			}
+			logger.Info(ctx, "🔑 Bootstrap admin created", "email", cfg.BootstrapAdminEmail)
+		}
+		if admin.PasswordHash == "" {
+			if err := authService.SetPassword(ctx, admin.ID, cfg.BootstrapAdminPassword); err != nil {
+				return nil, fmt.Errorf("failed to set bootstrap admin password: %w", err)
+			}
+			logger.Info(ctx, "🔑 Bootstrap admin password set", "email", cfg.BootstrapAdminEmail)
+		}
+	}
+
+	logger.Info(context.Background(), "✅ REST API authentication enabled")
+	return authService, nil
+}
+
+// setupSearchConfig загружает файловую конфигурацию email поиска (config/email_search.yaml).
+// Окна поиска из файла - начальные значения для настроек, изменяемых через API
+func setupSearchConfig(cfg *config.Config, logger ports.Logger) ports.EmailSearchConfigProvider {
+	ctx := context.Background()
+
+	searchConfig, err := email.LoadSearchConfig(cfg.Email.SearchConfigPath)
+	if err != nil {
+		logger.Warn(ctx, "Email search config file not loaded, using defaults",
+			"path", cfg.Email.SearchConfigPath,
+			"error", err.Error())
+		searchConfig = email.DefaultSearchConfig()
 	}
 
 	adapter := email.NewSearchConfigAdapter(searchConfig, logger)
 
 	// ✅ ВАЛИДИРУЕМ КОНФИГУРАЦИЮ
-	ctx := context.Background()
 	if err := adapter.ValidateConfig(ctx); err != nil {
 		logger.Warn(ctx, "Search configuration validation warning",
 			"error", err.Error())
@@ -269,17 +656,43 @@ func setupDatabase(cfg config.PostgresConfig) (*sqlx.DB, error) {
 	return db, nil
 }
 
-// setupIMAPAdapter настраивает IMAP адаптер с поддержкой таймаутов
-func setupIMAPAdapter(cfg *config.Config, logger ports.Logger, searchConfig ports.EmailSearchConfigProvider) ports.EmailGateway {
+// imapChannelFromConfig канал из переменных окружения URMS_IMAP_*
+func imapChannelFromConfig(cfg *config.Config) *domain.EmailChannelConfig {
+	return &domain.EmailChannelConfig{
+		ID:           "default",
+		Name:         "Основной почтовый ящик",
+		Provider:     "imap",
+		Server:       cfg.Email.IMAP.Server,
+		Port:         cfg.Email.IMAP.Port,
+		Username:     cfg.Email.IMAP.Username,
+		Password:     cfg.Email.IMAP.Password,
+		Mailbox:      cfg.Email.IMAP.Mailbox,
+		SSL:          cfg.Email.IMAP.SSL,
+		PollInterval: cfg.Email.IMAP.PollInterval,
+	}
+}
+
+// defaultEmailPolicy политика обработки до первого изменения через API
+func defaultEmailPolicy() domain.EmailProcessingPolicy {
+	return domain.EmailProcessingPolicy{
+		ReadOnlyMode:   true, // Для начала используем read-only режим
+		AutoReply:      false,
+		SpamFilter:     true,
+		MaxMessageSize: 10 * 1024 * 1024, // 10MB
+	}
+}
+
+// setupIMAPAdapter настраивает IMAP адаптер канала с таймаутами из конфигурации приложения
+func setupIMAPAdapter(cfg *config.Config, channel *domain.EmailChannelConfig, logger ports.Logger, searchConfig ports.EmailSearchConfigProvider) ports.EmailGateway {
 	// Создаем конфигурацию IMAP клиента
 	imapConfig := &imapclient.Config{
-		Server:   cfg.Email.IMAP.Server,
-		Port:     cfg.Email.IMAP.Port,
-		Username: cfg.Email.IMAP.Username,
-		Password: cfg.Email.IMAP.Password,
-		Mailbox:  cfg.Email.IMAP.Mailbox,
-		SSL:      cfg.Email.IMAP.SSL,
-		Interval: cfg.Email.IMAP.PollInterval,
+		Server:   channel.Server,
+		Port:     channel.Port,
+		Username: channel.Username,
+		Password: channel.Password,
+		Mailbox:  channel.Mailbox,
+		SSL:      channel.SSL,
+		Interval: channel.PollInterval,
 		ReadOnly: cfg.Email.IMAP.ReadOnly,
 		Timeout:  cfg.Email.IMAP.OperationTimeout,
 
@@ -325,13 +738,8 @@ func setupEmailServiceWithTaskServices(
 	searchConfig ports.EmailSearchConfigProvider, // ✅ ДОБАВЛЯЕМ параметр
 	logger ports.Logger,
 ) *services.EmailService {
-	// Создаем политику обработки email
-	policy := domain.EmailProcessingPolicy{
-		ReadOnlyMode:   true, // Для начала используем read-only режим
-		AutoReply:      false,
-		SpamFilter:     true,
-		MaxMessageSize: 10 * 1024 * 1024, // 10MB
-	}
+	// Политика заменяется сохраненной при загрузке настроек email
+	policy := defaultEmailPolicy()
 
 	// Используем существующую реализацию из infrastructure
 	idGenerator := id.NewUUIDGenerator()
@@ -381,6 +789,14 @@ func setupHealthChecks(imapAdapter ports.EmailGateway, db *sqlx.DB) ports.Health
 }
 
 // setupGinRouter настраивает роутинг с Gin
+// apiSpec описание операций REST API для документа OpenAPI
+func apiSpec() *openapi.Spec {
+	return openapi.NewSpec(openapi.Info{
+		Title:   "URMS-OS API",
+		Version: "1.0.0",
+	}, openapi.Operations())
+}
+
 func setupGinRouter(deps *Dependencies, logger ports.Logger) *gin.Engine {
 	router := gin.Default()
 
@@ -391,23 +807,254 @@ func setupGinRouter(deps *Dependencies, logger ports.Logger) *gin.Engine {
 	taskHandler := handlers.NewTaskHandler(deps.TaskService, logger)
 	customerHandler := handlers.NewCustomerHandler(deps.CustomerService, deps.TaskService, logger)
 	healthHandler := handlers.NewHealthHandler(deps.HealthAggregator)
+	customFieldHandler := handlers.NewCustomFieldHandler(deps.CustomFieldService, logger)
+	replyTemplateHandler := handlers.NewReplyTemplateHandler(deps.ReplyTemplateService, logger)
+	schedulerHandler := handlers.NewSchedulerHandler(deps.SchedulerService, logger)
+	satisfactionHandler := handlers.NewSatisfactionHandler(deps.SatisfactionService, logger)
+	auditHandler := handlers.NewAuditHandler(deps.AuditService, logger)
+	webhookHandler := handlers.NewWebhookHandler(deps.WebhookService, logger)
+	channelHandler := handlers.NewChannelHandler(deps.InboundChannelService, logger)
+	emailSettingsHandler := handlers.NewEmailSettingsHandler(deps.EmailSettingsService, logger)
+	userHandler := handlers.NewUserHandler(deps.UserService, logger)
+	searchHandler := handlers.NewSearchHandler(deps.SearchService, logger)
+	emailHandler := handlers.NewEmailHandler(deps.EmailMessageService, logger)
+	streamHandler := handlers.NewStreamHandler(deps.TaskStreamService, deps.StreamHeartbeatInterval, deps.StreamRetryInterval, logger)
+	var githubHandler *handlers.GitHubHandler
+	if deps.GitHubService != nil {
+		githubHandler = handlers.NewGitHubHandler(deps.GitHubService, taskHandler, deps.GitHubWebhookSecret, logger)
+	}
+	// Интеграции повторяют создание задач и сообщений по таймауту
+	idempotent := middleware.Idempotency(deps.IdempotencyService, logger)
 
 	// API Routes v1
 	api := router.Group("/api/v1")
+	if deps.AuthService != nil {
+		// Входящие каналы, вебхуки и публичные ссылки проверяют подпись или секрет сами,
+		// поток изменений аутентифицируется в StreamAuthMiddleware
+		api.Use(middleware.AuthMiddleware(deps.AuthService, logger,
+			"/api/v1/auth/login",
+			"/api/v1/auth/refresh",
+			"/api/v1/openapi.json",
+			"/api/v1/users/invitations/accept",
+			"/api/v1/public/",
+			"/api/v1/channels/:channelKey/inbound",
+			"/api/v1/telegram/webhook",
+			"/api/v1/github/webhook",
+			"/api/v1/stream",
+		))
+	} else {
+		// Без аутентификации права не проверяются: запросы выполняются от имени системы
+		api.Use(middleware.SystemPrincipal())
+	}
 	{
+		// Спецификация OpenAPI строится по зарегистрированным маршрутам и описаниям операций
+		api.GET("/openapi.json", apiSpec().Handler(router))
+
+		// Authentication
+		if deps.AuthService != nil {
+			authHandler := handlers.NewAuthHandler(deps.AuthService, logger)
+			authGroup := api.Group("/auth")
+			{
+				authGroup.POST("/login", authHandler.Login)
+				authGroup.POST("/refresh", authHandler.Refresh)
+				authGroup.GET("/me", authHandler.Me)
+				authGroup.PUT("/password", authHandler.ChangePassword)
+				authGroup.POST("/stream-token", authHandler.IssueStreamToken)
+				authGroup.GET("/api-keys", authHandler.ListAPIKeys)
+				authGroup.POST("/api-keys", authHandler.CreateAPIKey)
+				authGroup.DELETE("/api-keys/:id", authHandler.RevokeAPIKey)
+			}
+		}
+
+		// Users
+		users := api.Group("/users")
+		{
+			users.GET("", userHandler.ListUsers)
+			users.POST("", userHandler.CreateUser)
+			users.POST("/invitations/accept", userHandler.AcceptInvitation)
+			users.GET("/:id", userHandler.GetUser)
+			users.PUT("/:id", userHandler.UpdateProfile)
+			users.PUT("/:id/role", userHandler.ChangeRole)
+			users.PUT("/:id/routing", userHandler.SetRouting)
+			users.PUT("/:id/out-of-office", userHandler.SetOutOfOffice)
+			users.DELETE("/:id/out-of-office", userHandler.ClearOutOfOffice)
+			users.POST("/:id/deactivate", userHandler.Deactivate)
+			users.POST("/:id/reactivate", userHandler.Reactivate)
+			users.POST("/:id/invitation", userHandler.ResendInvitation)
+		}
+
+		// Поток изменений задач (Server-Sent Events)
+		if deps.AuthService != nil {
+			api.GET("/stream", middleware.StreamAuthMiddleware(deps.AuthService, logger), streamHandler.Stream)
+		} else {
+			api.GET("/stream", streamHandler.Stream)
+		}
+
 		// Tasks
 		tasks := api.Group("/tasks")
+		tasks.Use(taskHandler.VersionPrecondition())
 		{
 			tasks.GET("", taskHandler.ListTasks)
-			tasks.POST("", taskHandler.CreateTask)
-			tasks.POST("/support", taskHandler.CreateSupportTask)
+			tasks.POST("", idempotent, taskHandler.CreateTask)
+			tasks.POST("/support", idempotent, taskHandler.CreateSupportTask)
 			tasks.GET("/:id", taskHandler.GetTask)
 			tasks.PUT("/:id", taskHandler.UpdateTask)
 			tasks.DELETE("/:id", taskHandler.DeleteTask)
 			tasks.PUT("/:id/status", taskHandler.ChangeStatus)
 			tasks.PUT("/:id/assign", taskHandler.AssignTask)
 			tasks.GET("/:id/messages", taskHandler.GetTaskMessages)
-			tasks.POST("/:id/messages", taskHandler.AddMessage)
+			tasks.POST("/:id/messages", idempotent, taskHandler.AddMessage)
+			tasks.GET("/:id/participants", taskHandler.GetParticipants)
+			tasks.POST("/:id/participants", taskHandler.AddParticipant)
+			tasks.PUT("/:id/participants/:userId", taskHandler.ChangeParticipantRole)
+			tasks.DELETE("/:id/participants/:userId", taskHandler.RemoveParticipant)
+			tasks.POST("/:id/watch", taskHandler.WatchTask)
+			tasks.DELETE("/:id/watch", taskHandler.UnwatchTask)
+			tasks.GET("/:id/links", taskHandler.GetLinks)
+			tasks.POST("/:id/links", taskHandler.LinkTask)
+			tasks.DELETE("/:id/links/:targetId", taskHandler.UnlinkTask)
+			tasks.GET("/:id/subtasks", taskHandler.GetSubtasks)
+			tasks.GET("/:id/worklogs", taskHandler.GetWorkLogs)
+			tasks.POST("/:id/worklogs", taskHandler.LogWork)
+			tasks.DELETE("/:id/worklogs/:logId", taskHandler.DeleteWorkLog)
+			tasks.POST("/:id/timer/start", taskHandler.StartTimer)
+			tasks.POST("/:id/timer/stop", taskHandler.StopTimer)
+			tasks.POST("/:id/reply-templates/:templateId", replyTemplateHandler.ApplyTemplate)
+			tasks.POST("/:id/snooze", taskHandler.SnoozeTask)
+			tasks.DELETE("/:id/snooze", taskHandler.UnsnoozeTask)
+			tasks.GET("/:id/scheduled-replies", schedulerHandler.GetScheduledReplies)
+			tasks.POST("/:id/scheduled-replies", schedulerHandler.ScheduleReply)
+			tasks.POST("/:id/survey", satisfactionHandler.SendSurvey)
+			tasks.GET("/:id/history", auditHandler.GetTaskHistory)
+			if githubHandler != nil {
+				tasks.POST("/:id/github-issue", githubHandler.CreateLinkedIssue)
+			}
+		}
+
+		// Audit log
+		api.GET("/audit", auditHandler.QueryEvents)
+
+		// Stored emails
+		emails := api.Group("/emails")
+		{
+			emails.GET("", emailHandler.ListEmails)
+			emails.GET("/statistics", emailHandler.GetEmailStatistics)
+			emails.GET("/:id", emailHandler.GetEmail)
+			emails.GET("/:id/raw", emailHandler.DownloadEmail)
+			emails.POST("/:id/reprocess", emailHandler.ReprocessEmail)
+			emails.PUT("/:id/task", emailHandler.LinkEmail)
+			emails.DELETE("/:id/task", emailHandler.UnlinkEmail)
+		}
+
+		// Full-text search
+		api.GET("/search", searchHandler.Search)
+		api.POST("/search/reindex", searchHandler.Reindex)
+
+		// Recurring tasks
+		recurringTasks := api.Group("/recurring-tasks")
+		{
+			recurringTasks.GET("", schedulerHandler.ListRecurringTasks)
+			recurringTasks.POST("", schedulerHandler.CreateRecurringTask)
+			recurringTasks.GET("/:id", schedulerHandler.GetRecurringTask)
+			recurringTasks.PUT("/:id", schedulerHandler.UpdateRecurringTask)
+			recurringTasks.DELETE("/:id", schedulerHandler.DeleteRecurringTask)
+		}
+
+		// Scheduled replies
+		scheduledReplies := api.Group("/scheduled-replies")
+		{
+			scheduledReplies.DELETE("/:id", schedulerHandler.CancelScheduledReply)
+		}
+
+		// Current user
+		me := api.Group("/me")
+		{
+			me.GET("/tasks", taskHandler.GetMyTasks)
+			me.GET("/dashboard", taskHandler.GetMyDashboard)
+		}
+
+		// Reports
+		reports := api.Group("/reports")
+		{
+			reports.GET("/time", taskHandler.GetTimeReport)
+			reports.GET("/satisfaction", satisfactionHandler.GetSatisfactionReport)
+		}
+
+		// Custom fields
+		customFields := api.Group("/custom-fields")
+		{
+			customFields.GET("", customFieldHandler.ListFields)
+			customFields.POST("", customFieldHandler.CreateField)
+			customFields.GET("/:key", customFieldHandler.GetField)
+			customFields.PUT("/:key", customFieldHandler.UpdateField)
+			customFields.DELETE("/:key", customFieldHandler.DeleteField)
+		}
+
+		// Reply templates
+		replyTemplates := api.Group("/reply-templates")
+		{
+			replyTemplates.GET("", replyTemplateHandler.ListTemplates)
+			replyTemplates.POST("", replyTemplateHandler.CreateTemplate)
+			replyTemplates.GET("/:id", replyTemplateHandler.GetTemplate)
+			replyTemplates.PUT("/:id", replyTemplateHandler.UpdateTemplate)
+			replyTemplates.DELETE("/:id", replyTemplateHandler.DeleteTemplate)
+			replyTemplates.GET("/:id/preview", replyTemplateHandler.PreviewTemplate)
+		}
+
+		// Webhooks
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.GET("", webhookHandler.ListWebhooks)
+			webhooks.POST("", webhookHandler.CreateWebhook)
+			webhooks.GET("/:id", webhookHandler.GetWebhook)
+			webhooks.PUT("/:id", webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhooks.POST("/:id/test", webhookHandler.SendTestEvent)
+			webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/retry", webhookHandler.RetryDelivery)
+		}
+
+		// Inbound channels
+		channels := api.Group("/channels")
+		{
+			channels.GET("", channelHandler.ListChannels)
+			channels.POST("", channelHandler.CreateChannel)
+			channels.GET("/:channelKey", channelHandler.GetChannel)
+			channels.PUT("/:channelKey", channelHandler.UpdateChannel)
+			channels.DELETE("/:channelKey", channelHandler.DeleteChannel)
+			channels.POST("/:channelKey/inbound", channelHandler.ReceiveInbound)
+		}
+
+		// Email settings: каналы, политика обработки и окна поиска тредов
+		emailSettings := api.Group("/settings/email")
+		{
+			emailSettings.GET("/channels", emailSettingsHandler.ListChannels)
+			emailSettings.GET("/channels/:id", emailSettingsHandler.GetChannel)
+			emailSettings.PUT("/channels/:id", emailSettingsHandler.SaveChannel)
+			emailSettings.DELETE("/channels/:id", emailSettingsHandler.DeleteChannel)
+			emailSettings.POST("/channels/:id/test", emailSettingsHandler.TestChannel)
+			emailSettings.GET("/policy", emailSettingsHandler.GetPolicy)
+			emailSettings.PUT("/policy", emailSettingsHandler.UpdatePolicy)
+			emailSettings.GET("/thread-search", emailSettingsHandler.GetThreadSearchConfig)
+			emailSettings.PUT("/thread-search", emailSettingsHandler.UpdateThreadSearchConfig)
+		}
+
+		// Telegram Bot API webhook
+		if deps.TelegramService != nil && deps.TelegramWebhookSecret != "" {
+			telegramHandler := handlers.NewTelegramHandler(deps.TelegramService, deps.TelegramWebhookSecret, logger)
+			api.POST("/telegram/webhook", telegramHandler.ReceiveUpdate)
+		}
+
+		// GitHub webhook - только с секретом подписи
+		if githubHandler != nil && deps.GitHubWebhookSecret != "" {
+			api.POST("/github/webhook", githubHandler.ReceiveWebhook)
+		}
+
+		// Public endpoints (доступ по подписанным ссылкам, без авторизации)
+		public := api.Group("/public")
+		{
+			public.GET("/surveys/:token", satisfactionHandler.GetSurvey)
+			public.POST("/surveys/:token", satisfactionHandler.SubmitSurveyResponse)
 		}
 
 		// Customers
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Сервисы не вызываются: маршрутизатору достаточно непустых значений,
+// чтобы зарегистрировать маршруты интеграций
+type (
+	stubAuthService     struct{ ports.AuthService }
+	stubTelegramService struct{ ports.TelegramService }
+	stubGitHubService   struct{ ports.GitHubService }
+)
+
+// newTestRouter маршрутизатор со всеми маршрутами, включая маршруты необязательных интеграций
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	deps := &Dependencies{
+		AuthService:           stubAuthService{},
+		TelegramService:       stubTelegramService{},
+		TelegramWebhookSecret: "telegram-secret",
+		GitHubService:         stubGitHubService{},
+		GitHubWebhookSecret:   "github-secret",
+	}
+	return setupGinRouter(deps, &services.MockLogger{})
+}
+
+func TestAPISpecCoversAllRoutes(t *testing.T) {
+	routes := newTestRouter().Routes()
+	spec := apiSpec()
+
+	assert.Empty(t, spec.Missing(routes), "routes registered in setupGinRouter must be described in openapi.Operations")
+	assert.Empty(t, spec.Unused(routes), "openapi.Operations describes routes that are not registered")
+}
+
+func TestOpenAPIDocumentEndpoint(t *testing.T) {
+	router := newTestRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var doc struct {
+		OpenAPI    string                            `json:"openapi"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+
+	operations := 0
+	for _, methods := range doc.Paths {
+		operations += len(methods)
+	}
+	assert.Equal(t, len(router.Routes()), operations)
+	assert.Contains(t, doc.Paths, "/api/v1/tasks/{id}/participants/{userId}")
+
+	// Все ссылки документа указывают на существующие схемы
+	refs := regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`).FindAllStringSubmatch(recorder.Body.String(), -1)
+	require.NotEmpty(t, refs)
+	for _, ref := range refs {
+		assert.Contains(t, doc.Components.Schemas, ref[1])
+	}
+}
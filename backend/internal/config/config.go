@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/infrastructure/auth"
 )
 
 // Config представляет основную конфигурацию приложения
@@ -21,6 +25,127 @@ type Config struct {
 
 	// Logging configuration
 	Logging LoggingConfig `yaml:"logging"`
+
+	// Scheduler configuration
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+
+	// Survey configuration
+	Survey SurveyConfig `yaml:"survey"`
+
+	// Outbox configuration
+	Outbox OutboxConfig `yaml:"outbox"`
+
+	// Webhooks configuration
+	Webhooks WebhooksConfig `yaml:"webhooks"`
+
+	// Idempotency-Key configuration
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+
+	// Task stream (SSE) configuration
+	Stream StreamConfig `yaml:"stream"`
+
+	// Telegram bot configuration
+	Telegram TelegramConfig `yaml:"telegram"`
+
+	// GitHub issues configuration
+	GitHub GitHubConfig `yaml:"github"`
+
+	// REST API authentication configuration
+	Auth AuthConfig `yaml:"auth"`
+}
+
+// AuthConfig конфигурация аутентификации REST API
+type AuthConfig struct {
+	Enabled                bool          `yaml:"enabled"`                  // false - API доступен без аутентификации (только для разработки)
+	JWTSecret              string        `yaml:"jwt_secret"`               // Секрет подписи HS256
+	AccessTokenTTL         time.Duration `yaml:"access_token_ttl"`         // Срок действия access-токена
+	RefreshTokenTTL        time.Duration `yaml:"refresh_token_ttl"`        // Срок действия refresh-токена
+	BootstrapAdminEmail    string        `yaml:"bootstrap_admin_email"`    // Администратор, которому задается начальный пароль
+	BootstrapAdminPassword string        `yaml:"bootstrap_admin_password"` // Начальный пароль; применяется, если пароль не задан
+}
+
+// GitHubConfig конфигурация синхронизации задач с GitHub issues
+type GitHubConfig struct {
+	Token            string        `yaml:"token"`             // Токен доступа; пустой - синхронизация отключена
+	APIBaseURL       string        `yaml:"api_base_url"`      // Адрес REST API (GitHub Enterprise или заглушка в тестах)
+	Repositories     []string      `yaml:"repositories"`      // Отслеживаемые репозитории owner/repo
+	WebhookSecret    string        `yaml:"webhook_secret"`    // Секрет подписи X-Hub-Signature-256; пустой - webhook отключен
+	SyncInterval     time.Duration `yaml:"sync_interval"`     // Период опроса репозиториев; 0 - только webhook
+	OperationTimeout time.Duration `yaml:"operation_timeout"` // Максимальная длительность одного прохода
+	RequestTimeout   time.Duration `yaml:"request_timeout"`   // Ожидание ответа API
+	InitialLookback  time.Duration `yaml:"initial_lookback"`  // Период изменений при первой синхронизации
+}
+
+// Enabled проверяет, настроена ли синхронизация
+func (c GitHubConfig) Enabled() bool {
+	return c.Token != "" && len(c.Repositories) > 0
+}
+
+// TelegramConfig конфигурация канала поддержки через Telegram-бота
+type TelegramConfig struct {
+	BotToken       string        `yaml:"bot_token"`       // Токен бота; пустой - канал отключен
+	APIBaseURL     string        `yaml:"api_base_url"`    // Адрес Bot API (локальный сервер или заглушка в тестах)
+	Mode           string        `yaml:"mode"`            // polling или webhook
+	PollTimeout    time.Duration `yaml:"poll_timeout"`    // Ожидание новых сообщений в getUpdates
+	RequestTimeout time.Duration `yaml:"request_timeout"` // Ожидание ответа Bot API
+	RetryInterval  time.Duration `yaml:"retry_interval"`  // Пауза после ошибки получения обновлений
+	WebhookSecret  string        `yaml:"webhook_secret"`  // Значение X-Telegram-Bot-Api-Secret-Token
+}
+
+// Enabled проверяет, настроен ли бот
+func (c TelegramConfig) Enabled() bool {
+	return c.BotToken != ""
+}
+
+// WebhooksConfig конфигурация доставки исходящих вебхуков
+type WebhooksConfig struct {
+	DeliveryInterval time.Duration `yaml:"delivery_interval"` // Период доставки; 0 - доставка отключена
+	OperationTimeout time.Duration `yaml:"operation_timeout"` // Максимальная длительность одного прохода
+	RequestTimeout   time.Duration `yaml:"request_timeout"`   // Ожидание ответа получателя
+	MaxAttempts      int           `yaml:"max_attempts"`      // Попыток до перевода в dead letter
+	RetryBaseDelay   time.Duration `yaml:"retry_base_delay"`  // Задержка перед второй попыткой
+	RetryMaxDelay    time.Duration `yaml:"retry_max_delay"`   // Верхняя граница задержки
+}
+
+// StreamConfig конфигурация потока изменений задач GET /api/v1/stream
+type StreamConfig struct {
+	BufferSize        int           `yaml:"buffer_size"`        // Последних событий для повтора по Last-Event-ID
+	SubscriberBuffer  int           `yaml:"subscriber_buffer"`  // Очередь клиента; при переполнении клиент отключается
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"` // Период пульса, удерживающего соединение
+	RetryInterval     time.Duration `yaml:"retry_interval"`     // Задержка переподключения, рекомендуемая клиенту
+}
+
+// IdempotencyConfig конфигурация повтора ответов на запросы с заголовком Idempotency-Key
+// и защиты входящих каналов от повторной доставки одной записи
+type IdempotencyConfig struct {
+	TTL              time.Duration `yaml:"ttl"`               // Сколько хранить ответ для повторов
+	LockTimeout      time.Duration `yaml:"lock_timeout"`      // Через сколько освобождается ключ запроса, блокировку которого перестали продлевать
+	CleanupInterval  time.Duration `yaml:"cleanup_interval"`  // Период удаления истекших записей; 0 - очистка отключена
+	OperationTimeout time.Duration `yaml:"operation_timeout"` // Максимальная длительность одной очистки
+}
+
+// OutboxConfig конфигурация доставки доменных событий из outbox
+type OutboxConfig struct {
+	RelayInterval    time.Duration `yaml:"relay_interval"`    // Период доставки; 0 - ретранслятор отключен
+	OperationTimeout time.Duration `yaml:"operation_timeout"` // Максимальная длительность одного прохода
+	BatchSize        int           `yaml:"batch_size"`        // Событий за один проход
+	MaxAttempts      int           `yaml:"max_attempts"`      // Попыток до пометки события failed
+	RetryBaseDelay   time.Duration `yaml:"retry_base_delay"`  // Задержка перед второй попыткой (далее x2)
+	RetryMaxDelay    time.Duration `yaml:"retry_max_delay"`   // Верхняя граница задержки
+	Retention        time.Duration `yaml:"retention"`         // Срок хранения доставленных событий
+}
+
+// SurveyConfig конфигурация опросов удовлетворенности клиентов (CSAT)
+type SurveyConfig struct {
+	Secret        string        `yaml:"secret"`          // Ключ подписи ссылок; пусто - опросы не отправляются
+	PublicBaseURL string        `yaml:"public_base_url"` // Внешний адрес API для ссылок в письмах
+	TokenTTL      time.Duration `yaml:"token_ttl"`       // Срок действия ссылок
+}
+
+// SchedulerConfig конфигурация планировщика (отложенные, повторяющиеся задачи, запланированные ответы)
+type SchedulerConfig struct {
+	Interval         time.Duration `yaml:"interval"`          // Период проверки; 0 - планировщик отключен
+	OperationTimeout time.Duration `yaml:"operation_timeout"` // Максимальная длительность одного прохода
 }
 
 // DatabaseConfig конфигурация базы данных
@@ -40,6 +165,9 @@ type PostgresConfig struct {
 // EmailConfig конфигурация email модуля
 type EmailConfig struct {
 	IMAP IMAPConfig `yaml:"imap"`
+	// Файл настроек поиска тредов и провайдеров (config/email_search.yaml).
+	// Окна поиска из файла - начальные значения; далее они изменяются через API
+	SearchConfigPath string `yaml:"search_config_path"`
 }
 
 // IMAPConfig конфигурация IMAP
@@ -93,6 +221,7 @@ func LoadConfig() (*Config, error) {
 			},
 		},
 		Email: EmailConfig{
+			SearchConfigPath: getEnv("URMS_EMAIL_SEARCH_CONFIG", "config/email_search.yaml"),
 			IMAP: IMAPConfig{
 				Server:       getEnv("URMS_IMAP_SERVER", "outlook.office365.com"),
 				Port:         getEnvAsInt("URMS_IMAP_PORT", 993),
@@ -123,6 +252,71 @@ func LoadConfig() (*Config, error) {
 			Level:  getEnv("URMS_LOGGING_LEVEL", "info"),
 			Format: getEnv("URMS_LOGGING_FORMAT", "json"),
 		},
+		Scheduler: SchedulerConfig{
+			Interval:         getEnvAsDuration("URMS_SCHEDULER_INTERVAL", time.Minute),
+			OperationTimeout: getEnvAsDuration("URMS_SCHEDULER_OPERATION_TIMEOUT", 30*time.Second),
+		},
+		Survey: SurveyConfig{
+			Secret:        getEnv("URMS_SURVEY_SECRET", ""),
+			PublicBaseURL: getEnv("URMS_PUBLIC_BASE_URL", "http://localhost:8080"),
+			TokenTTL:      getEnvAsDuration("URMS_SURVEY_TOKEN_TTL", 30*24*time.Hour),
+		},
+		Outbox: OutboxConfig{
+			RelayInterval:    getEnvAsDuration("URMS_OUTBOX_RELAY_INTERVAL", 2*time.Second),
+			OperationTimeout: getEnvAsDuration("URMS_OUTBOX_OPERATION_TIMEOUT", 30*time.Second),
+			BatchSize:        getEnvAsInt("URMS_OUTBOX_BATCH_SIZE", 100),
+			MaxAttempts:      getEnvAsInt("URMS_OUTBOX_MAX_ATTEMPTS", 10),
+			RetryBaseDelay:   getEnvAsDuration("URMS_OUTBOX_RETRY_BASE_DELAY", 5*time.Second),
+			RetryMaxDelay:    getEnvAsDuration("URMS_OUTBOX_RETRY_MAX_DELAY", time.Hour),
+			Retention:        getEnvAsDuration("URMS_OUTBOX_RETENTION", 7*24*time.Hour),
+		},
+		Webhooks: WebhooksConfig{
+			DeliveryInterval: getEnvAsDuration("URMS_WEBHOOKS_DELIVERY_INTERVAL", 5*time.Second),
+			OperationTimeout: getEnvAsDuration("URMS_WEBHOOKS_OPERATION_TIMEOUT", 2*time.Minute),
+			RequestTimeout:   getEnvAsDuration("URMS_WEBHOOKS_REQUEST_TIMEOUT", 10*time.Second),
+			MaxAttempts:      getEnvAsInt("URMS_WEBHOOKS_MAX_ATTEMPTS", 8),
+			RetryBaseDelay:   getEnvAsDuration("URMS_WEBHOOKS_RETRY_BASE_DELAY", 10*time.Second),
+			RetryMaxDelay:    getEnvAsDuration("URMS_WEBHOOKS_RETRY_MAX_DELAY", time.Hour),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:              getEnvAsDuration("URMS_IDEMPOTENCY_TTL", 24*time.Hour),
+			LockTimeout:      getEnvAsDuration("URMS_IDEMPOTENCY_LOCK_TIMEOUT", time.Minute),
+			CleanupInterval:  getEnvAsDuration("URMS_IDEMPOTENCY_CLEANUP_INTERVAL", time.Hour),
+			OperationTimeout: getEnvAsDuration("URMS_IDEMPOTENCY_OPERATION_TIMEOUT", time.Minute),
+		},
+		Stream: StreamConfig{
+			BufferSize:        getEnvAsInt("URMS_STREAM_BUFFER_SIZE", 1000),
+			SubscriberBuffer:  getEnvAsInt("URMS_STREAM_SUBSCRIBER_BUFFER", 64),
+			HeartbeatInterval: getEnvAsDuration("URMS_STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+			RetryInterval:     getEnvAsDuration("URMS_STREAM_RETRY_INTERVAL", 3*time.Second),
+		},
+		Telegram: TelegramConfig{
+			BotToken:       getEnv("URMS_TELEGRAM_BOT_TOKEN", ""),
+			APIBaseURL:     getEnv("URMS_TELEGRAM_API_BASE_URL", "https://api.telegram.org"),
+			Mode:           getEnv("URMS_TELEGRAM_MODE", "polling"),
+			PollTimeout:    getEnvAsDuration("URMS_TELEGRAM_POLL_TIMEOUT", 30*time.Second),
+			RequestTimeout: getEnvAsDuration("URMS_TELEGRAM_REQUEST_TIMEOUT", 10*time.Second),
+			RetryInterval:  getEnvAsDuration("URMS_TELEGRAM_RETRY_INTERVAL", 5*time.Second),
+			WebhookSecret:  getEnv("URMS_TELEGRAM_WEBHOOK_SECRET", ""),
+		},
+		GitHub: GitHubConfig{
+			Token:            getEnv("URMS_GITHUB_TOKEN", ""),
+			APIBaseURL:       getEnv("URMS_GITHUB_API_BASE_URL", "https://api.github.com"),
+			Repositories:     getEnvAsList("URMS_GITHUB_REPOSITORIES"),
+			WebhookSecret:    getEnv("URMS_GITHUB_WEBHOOK_SECRET", ""),
+			SyncInterval:     getEnvAsDuration("URMS_GITHUB_SYNC_INTERVAL", 5*time.Minute),
+			OperationTimeout: getEnvAsDuration("URMS_GITHUB_OPERATION_TIMEOUT", 2*time.Minute),
+			RequestTimeout:   getEnvAsDuration("URMS_GITHUB_REQUEST_TIMEOUT", 15*time.Second),
+			InitialLookback:  getEnvAsDuration("URMS_GITHUB_INITIAL_LOOKBACK", 24*time.Hour),
+		},
+		Auth: AuthConfig{
+			Enabled:                getEnvAsBool("URMS_AUTH_ENABLED", true),
+			JWTSecret:              getEnv("URMS_AUTH_JWT_SECRET", ""),
+			AccessTokenTTL:         getEnvAsDuration("URMS_AUTH_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:        getEnvAsDuration("URMS_AUTH_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			BootstrapAdminEmail:    getEnv("URMS_AUTH_BOOTSTRAP_ADMIN_EMAIL", "admin@company.com"),
+			BootstrapAdminPassword: getEnv("URMS_AUTH_BOOTSTRAP_ADMIN_PASSWORD", ""),
+		},
 	}
 
 	// Валидация конфигурации
@@ -164,6 +358,30 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("IMAP max retries cannot be negative")
 	}
 
+	if c.Telegram.Enabled() && c.Telegram.Mode != "polling" && c.Telegram.Mode != "webhook" {
+		return fmt.Errorf("invalid telegram mode: %s", c.Telegram.Mode)
+	}
+	if c.Telegram.Enabled() && c.Telegram.Mode == "webhook" && c.Telegram.WebhookSecret == "" {
+		return fmt.Errorf("telegram webhook secret is required in webhook mode")
+	}
+	for _, repo := range c.GitHub.Repositories {
+		if !domain.IsValidGitHubRepo(repo) {
+			return fmt.Errorf("invalid github repository: %s", repo)
+		}
+	}
+
+	if c.Auth.Enabled {
+		if len(c.Auth.JWTSecret) < auth.MinJWTSecretLength {
+			return fmt.Errorf("auth jwt secret must be at least %d characters", auth.MinJWTSecretLength)
+		}
+		if c.Auth.AccessTokenTTL <= 0 || c.Auth.RefreshTokenTTL <= 0 {
+			return fmt.Errorf("auth token TTLs must be positive")
+		}
+		if c.Auth.BootstrapAdminPassword != "" && len(c.Auth.BootstrapAdminPassword) < domain.MinPasswordLength {
+			return fmt.Errorf("auth bootstrap admin password must be at least %d characters", domain.MinPasswordLength)
+		}
+	}
+
 	return nil
 }
 
@@ -210,6 +428,17 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsList разбирает список значений, разделенных запятыми
+func getEnvAsList(key string) []string {
+	var result []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
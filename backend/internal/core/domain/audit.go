@@ -0,0 +1,296 @@
+// internal/core/domain/audit.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Типы событий истории задачи
+const (
+	TaskEventCreated                = "created"
+	TaskEventUpdated                = "updated"
+	TaskEventDeleted                = "deleted"
+	TaskEventStatusChanged          = "status_changed"
+	TaskEventAssigneeChanged        = "assignee_changed"
+	TaskEventMessageAdded           = "message_added"
+	TaskEventParticipantAdded       = "participant_added"
+	TaskEventParticipantRemoved     = "participant_removed"
+	TaskEventParticipantRoleChanged = "participant_role_changed"
+	TaskEventLinkAdded              = "link_added"
+	TaskEventLinkRemoved            = "link_removed"
+	TaskEventCustomFieldChanged     = "custom_field_changed"
+	TaskEventSnoozed                = "snoozed"
+	TaskEventUnsnoozed              = "unsnoozed"
+	TaskEventWorkLogged             = "work_logged"
+	TaskEventWorkLogRemoved         = "work_log_removed"
+	TaskEventTimerStarted           = "timer_started"
+	TaskEventTimerStopped           = "timer_stopped"
+	TaskEventSurveySent             = "survey_sent"
+	TaskEventSurveyFailed           = "survey_failed"
+	TaskEventSatisfactionRated      = "satisfaction_rated"
+)
+
+// AuditSource источник изменения
+type AuditSource string
+
+const (
+	AuditSourceAPI        AuditSource = "api"        // Запрос через REST API
+	AuditSourceEmail      AuditSource = "email"      // Обработка входящей почты
+	AuditSourceAutomation AuditSource = "automation" // Планировщик и автоматические правила
+	AuditSourceSystem     AuditSource = "system"     // Источник не определен
+)
+
+// IsValid проверяет допустимость источника
+func (s AuditSource) IsValid() bool {
+	switch s {
+	case AuditSourceAPI, AuditSourceEmail, AuditSourceAutomation, AuditSourceSystem:
+		return true
+	}
+	return false
+}
+
+// AuditEntityType тип сущности журнала аудита
+type AuditEntityType string
+
+const (
+	AuditEntityTask AuditEntityType = "task"
+)
+
+// FieldChange изменение одного поля; значения приведены к строкам для хранения и отображения
+type FieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// AuditEvent запись журнала аудита. Записи только добавляются и никогда не изменяются
+type AuditEvent struct {
+	ID            string
+	EntityType    AuditEntityType
+	EntityID      string
+	Type          string
+	ActorID       string
+	Source        AuditSource
+	CorrelationID string
+	Changes       []FieldChange
+	Message       string
+	Timestamp     time.Time
+}
+
+// NewTaskAuditEvent создает запись журнала из события истории задачи
+func NewTaskAuditEvent(taskID string, event TaskEvent, source AuditSource, correlationID string) AuditEvent {
+	if !source.IsValid() {
+		source = AuditSourceSystem
+	}
+	return AuditEvent{
+		ID:            event.ID,
+		EntityType:    AuditEntityTask,
+		EntityID:      taskID,
+		Type:          event.Type,
+		ActorID:       event.UserID,
+		Source:        source,
+		CorrelationID: correlationID,
+		Changes:       event.Changes,
+		Message:       event.Message,
+		Timestamp:     event.Timestamp,
+	}
+}
+
+// historyEventFields поле задачи, которое изменяет событие истории
+var historyEventFields = map[string]string{
+	TaskEventStatusChanged:          "status",
+	TaskEventAssigneeChanged:        "assignee_id",
+	TaskEventMessageAdded:           "messages",
+	TaskEventParticipantAdded:       "participants",
+	TaskEventParticipantRemoved:     "participants",
+	TaskEventParticipantRoleChanged: "participant_role",
+	TaskEventLinkAdded:              "links",
+	TaskEventLinkRemoved:            "links",
+	TaskEventSnoozed:                "snoozed_until",
+	TaskEventUnsnoozed:              "snoozed_until",
+	TaskEventWorkLogged:             "work_logs",
+	TaskEventWorkLogRemoved:         "work_logs",
+	TaskEventTimerStarted:           "timer",
+	TaskEventTimerStopped:           "timer",
+	TaskEventSatisfactionRated:      "satisfaction_rating",
+}
+
+// CustomFieldChangeField имя поля изменения для пользовательского поля задачи
+func CustomFieldChangeField(key string) string {
+	return "custom_fields." + key
+}
+
+// FormatAuditValue приводит значение поля к строковому представлению журнала
+func FormatAuditValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case time.Duration:
+		return v.String()
+	case []string:
+		return strings.Join(v, ", ")
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Локализованные заголовки событий
+var auditEventTitles = map[TemplateLanguage]map[string]string{
+	TemplateLanguageRU: {
+		TaskEventCreated:                "Задача создана",
+		TaskEventUpdated:                "Задача изменена",
+		TaskEventDeleted:                "Задача удалена",
+		TaskEventStatusChanged:          "Статус изменен",
+		TaskEventAssigneeChanged:        "Исполнитель изменен",
+		TaskEventMessageAdded:           "Добавлено сообщение",
+		TaskEventParticipantAdded:       "Добавлен участник",
+		TaskEventParticipantRemoved:     "Удален участник",
+		TaskEventParticipantRoleChanged: "Изменена роль участника",
+		TaskEventLinkAdded:              "Добавлена связь",
+		TaskEventLinkRemoved:            "Удалена связь",
+		TaskEventCustomFieldChanged:     "Изменено пользовательское поле",
+		TaskEventSnoozed:                "Задача отложена",
+		TaskEventUnsnoozed:              "Задача возвращена в работу",
+		TaskEventWorkLogged:             "Списано время",
+		TaskEventWorkLogRemoved:         "Удалена запись о затраченном времени",
+		TaskEventTimerStarted:           "Запущен таймер",
+		TaskEventTimerStopped:           "Остановлен таймер",
+		TaskEventSurveySent:             "Отправлен опрос удовлетворенности",
+		TaskEventSurveyFailed:           "Не удалось отправить опрос удовлетворенности",
+		TaskEventSatisfactionRated:      "Клиент оценил решение",
+	},
+	TemplateLanguageEN: {
+		TaskEventCreated:                "Task created",
+		TaskEventUpdated:                "Task updated",
+		TaskEventDeleted:                "Task deleted",
+		TaskEventStatusChanged:          "Status changed",
+		TaskEventAssigneeChanged:        "Assignee changed",
+		TaskEventMessageAdded:           "Message added",
+		TaskEventParticipantAdded:       "Participant added",
+		TaskEventParticipantRemoved:     "Participant removed",
+		TaskEventParticipantRoleChanged: "Participant role changed",
+		TaskEventLinkAdded:              "Link added",
+		TaskEventLinkRemoved:            "Link removed",
+		TaskEventCustomFieldChanged:     "Custom field changed",
+		TaskEventSnoozed:                "Task snoozed",
+		TaskEventUnsnoozed:              "Task returned to queue",
+		TaskEventWorkLogged:             "Work logged",
+		TaskEventWorkLogRemoved:         "Work log removed",
+		TaskEventTimerStarted:           "Timer started",
+		TaskEventTimerStopped:           "Timer stopped",
+		TaskEventSurveySent:             "Satisfaction survey sent",
+		TaskEventSurveyFailed:           "Satisfaction survey could not be sent",
+		TaskEventSatisfactionRated:      "Customer rated the resolution",
+	},
+}
+
+// Локализованные названия полей
+var auditFieldLabels = map[TemplateLanguage]map[string]string{
+	TemplateLanguageRU: {
+		"subject":             "тема",
+		"description":         "описание",
+		"priority":            "приоритет",
+		"category":            "категория",
+		"tags":                "теги",
+		"due_date":            "срок",
+		"status":              "статус",
+		"assignee_id":         "исполнитель",
+		"participant_role":    "роль",
+		"snoozed_until":       "отложена до",
+		"satisfaction_rating": "оценка",
+	},
+	TemplateLanguageEN: {
+		"subject":             "subject",
+		"description":         "description",
+		"priority":            "priority",
+		"category":            "category",
+		"tags":                "tags",
+		"due_date":            "due date",
+		"status":              "status",
+		"assignee_id":         "assignee",
+		"participant_role":    "role",
+		"snoozed_until":       "snoozed until",
+		"satisfaction_rating": "rating",
+	},
+}
+
+// RenderAuditEvent возвращает описание события на указанном языке.
+// Неподдерживаемый язык заменяется языком по умолчанию
+func RenderAuditEvent(event AuditEvent, lang TemplateLanguage) string {
+	if !lang.IsValid() {
+		lang = DefaultTemplateLanguage
+	}
+
+	title, ok := auditEventTitles[lang][event.Type]
+	if !ok {
+		title = event.Type
+	}
+	if len(event.Changes) == 0 {
+		return title
+	}
+
+	// Для событий одного поля название поля избыточно
+	single := len(event.Changes) == 1 && historyEventFields[event.Type] == event.Changes[0].Field
+
+	parts := make([]string, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		if single {
+			parts = append(parts, renderSingleChange(change))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s → %s",
+			auditFieldLabel(change.Field, lang), orDash(change.OldValue), orDash(change.NewValue)))
+	}
+
+	return fmt.Sprintf("%s: %s", title, strings.Join(parts, "; "))
+}
+
+// renderSingleChange для добавления и удаления показывает только значимое значение
+func renderSingleChange(change FieldChange) string {
+	switch {
+	case change.OldValue == "":
+		return orDash(change.NewValue)
+	case change.NewValue == "":
+		return change.OldValue
+	default:
+		return fmt.Sprintf("%s → %s", change.OldValue, change.NewValue)
+	}
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "—"
+	}
+	return value
+}
+
+func auditFieldLabel(field string, lang TemplateLanguage) string {
+	if key, ok := strings.CutPrefix(field, "custom_fields."); ok {
+		if lang == TemplateLanguageEN {
+			return "field " + key
+		}
+		return "поле " + key
+	}
+	if label, ok := auditFieldLabels[lang][field]; ok {
+		return label
+	}
+	return field
+}
@@ -0,0 +1,97 @@
+// internal/core/domain/audit_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_UpdateDetails(t *testing.T) {
+	task, err := NewTask(TaskTypeInternal, "Тема", "Описание", "user-1", nil)
+	require.NoError(t, err)
+	task.PullEvents()
+
+	t.Run("records changed fields only", func(t *testing.T) {
+		subject := "Новая тема"
+		description := "Описание"
+		priority := PriorityHigh
+		due := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+		diff := task.UpdateDetails(TaskChanges{
+			Subject:     &subject,
+			Description: &description,
+			Priority:    &priority,
+			DueDate:     &due,
+		}, "user-2")
+
+		require.Len(t, diff, 3)
+		assert.Equal(t, FieldChange{Field: "subject", OldValue: "Тема", NewValue: "Новая тема"}, diff[0])
+		assert.Equal(t, "priority", diff[1].Field)
+		assert.Equal(t, FieldChange{Field: "due_date", OldValue: "", NewValue: "2026-01-10T12:00:00Z"}, diff[2])
+
+		last := task.History[len(task.History)-1]
+		assert.Equal(t, TaskEventUpdated, last.Type)
+		assert.Equal(t, "user-2", last.UserID)
+		assert.Equal(t, diff, last.Changes)
+		assert.Equal(t, subject, task.Subject)
+	})
+
+	t.Run("no changes no event", func(t *testing.T) {
+		task.PullEvents()
+		historyLen := len(task.History)
+		subject := task.Subject
+
+		assert.Nil(t, task.UpdateDetails(TaskChanges{Subject: &subject}, "user-2"))
+		assert.Len(t, task.History, historyLen)
+		assert.Empty(t, task.PullEvents())
+	})
+}
+
+func TestTask_PullEvents(t *testing.T) {
+	task, err := NewTask(TaskTypeInternal, "Тема", "Описание", "user-1", nil)
+	require.NoError(t, err)
+	require.NoError(t, task.ChangeStatus(TaskStatusInProgress, "user-1"))
+
+	events := task.PullEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, TaskEventCreated, events[0].Type)
+	assert.Equal(t, []FieldChange{{Field: "status", OldValue: "open", NewValue: "in_progress"}}, events[1].Changes)
+	assert.Empty(t, task.PullEvents())
+	assert.Len(t, task.History, 2)
+}
+
+func TestRenderAuditEvent(t *testing.T) {
+	statusEvent := AuditEvent{
+		Type:    TaskEventStatusChanged,
+		Changes: []FieldChange{{Field: "status", OldValue: "open", NewValue: "resolved"}},
+	}
+	assert.Equal(t, "Статус изменен: open → resolved", RenderAuditEvent(statusEvent, TemplateLanguageRU))
+	assert.Equal(t, "Status changed: open → resolved", RenderAuditEvent(statusEvent, TemplateLanguageEN))
+	assert.Equal(t, "Статус изменен: open → resolved", RenderAuditEvent(statusEvent, "de"))
+
+	updated := AuditEvent{
+		Type: TaskEventUpdated,
+		Changes: []FieldChange{
+			{Field: "subject", OldValue: "A", NewValue: "B"},
+			{Field: "due_date", NewValue: "2026-01-10T12:00:00Z"},
+		},
+	}
+	assert.Equal(t, "Task updated: subject: A → B; due date: — → 2026-01-10T12:00:00Z", RenderAuditEvent(updated, TemplateLanguageEN))
+
+	participant := AuditEvent{
+		Type:    TaskEventParticipantRemoved,
+		Changes: []FieldChange{{Field: "participants", OldValue: "user-2"}},
+	}
+	assert.Equal(t, "Удален участник: user-2", RenderAuditEvent(participant, TemplateLanguageRU))
+
+	customField := AuditEvent{
+		Type:    TaskEventCustomFieldChanged,
+		Changes: []FieldChange{{Field: CustomFieldChangeField("contract"), OldValue: "A-1", NewValue: "A-2"}},
+	}
+	assert.Equal(t, "Custom field changed: field contract: A-1 → A-2", RenderAuditEvent(customField, TemplateLanguageEN))
+
+	assert.Equal(t, "Задача создана", RenderAuditEvent(AuditEvent{Type: TaskEventCreated}, TemplateLanguageRU))
+}
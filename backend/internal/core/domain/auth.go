@@ -0,0 +1,121 @@
+// internal/core/domain/auth.go
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Ошибки аутентификации
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrPasswordTooShort   = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+)
+
+// MinPasswordLength минимальная длина пароля
+const MinPasswordLength = 8
+
+// APIKeyPrefix префикс ключей API: по нему ключ отличается от JWT в заголовке Authorization
+const APIKeyPrefix = "urms_"
+
+// apiKeyDisplayLength длина видимой части ключа в списках ключей
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// AuthTokenType тип JWT
+type AuthTokenType string
+
+const (
+	AuthTokenAccess  AuthTokenType = "access"  // Доступ к API, короткий срок жизни
+	AuthTokenRefresh AuthTokenType = "refresh" // Получение новой пары токенов
+	AuthTokenStream  AuthTokenType = "stream"  // Подключение к потоку изменений: EventSource передает токен в адресе
+)
+
+// AuthClaims содержимое подписанного токена
+type AuthClaims struct {
+	TokenID   string
+	UserID    string
+	Role      UserRole
+	Type      AuthTokenType
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// IsExpired проверяет срок действия токена
+func (c *AuthClaims) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// APIKey долгоживущий ключ интеграции. Хранится только хеш ключа:
+// сам ключ показывается один раз при создании
+type APIKey struct {
+	ID         string
+	UserID     string // Пользователь, от имени которого действует интеграция
+	Name       string
+	Prefix     string // Начало ключа для распознавания в списках
+	KeyHash    string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// NewAPIKey создает ключ для пользователя по сгенерированному значению
+func NewAPIKey(userID, name, key string, expiresAt *time.Time) (*APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("api key name is required")
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) || len(key) <= apiKeyDisplayLength {
+		return nil, errors.New("invalid api key value")
+	}
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.New("api key expiration must be in the future")
+	}
+	return &APIKey{
+		ID:        GenerateAPIKeyID(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   HashAPIKey(key),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// IsActive проверяет, что ключ не отозван и не истек
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Revoke отзывает ключ
+func (k *APIKey) Revoke(now time.Time) {
+	if k.RevokedAt == nil {
+		k.RevokedAt = &now
+	}
+}
+
+// HashAPIKey хеш ключа для поиска. Ключ содержит 256 бит случайных данных,
+// поэтому медленный хеш, как для паролей, не нужен
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+var apiKeySeq atomic.Uint64
+
+// GenerateAPIKeyID генерирует ID ключа API
+func GenerateAPIKeyID() string {
+	return fmt.Sprintf("KEY-%d-%d", time.Now().UnixNano(), apiKeySeq.Add(1))
+}
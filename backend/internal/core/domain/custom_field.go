@@ -0,0 +1,228 @@
+// internal/core/domain/custom_field.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldType тип значения пользовательского поля
+type CustomFieldType string
+
+const (
+	CustomFieldText     CustomFieldType = "text"     // Произвольный текст
+	CustomFieldNumber   CustomFieldType = "number"   // Число
+	CustomFieldEnum     CustomFieldType = "enum"     // Значение из списка
+	CustomFieldDate     CustomFieldType = "date"     // Дата (YYYY-MM-DD)
+	CustomFieldUser     CustomFieldType = "user"     // Ссылка на пользователя
+	CustomFieldCustomer CustomFieldType = "customer" // Ссылка на клиента
+)
+
+// CustomFieldDateLayout формат хранения значений типа date
+const CustomFieldDateLayout = "2006-01-02"
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// IsValid проверяет, что тип поля известен
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldText, CustomFieldNumber, CustomFieldEnum, CustomFieldDate, CustomFieldUser, CustomFieldCustomer:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReference проверяет, ссылается ли поле на другую сущность
+func (t CustomFieldType) IsReference() bool {
+	return t == CustomFieldUser || t == CustomFieldCustomer
+}
+
+// CustomFieldDefinition описывает пользовательское поле задач, заданное администратором
+type CustomFieldDefinition struct {
+	Key          string // Уникальный ключ поля (используется в Task.CustomFields)
+	Name         string
+	Type         CustomFieldType
+	TaskTypes    []TaskType // Пусто - поле применимо ко всем типам задач
+	Categories   []string   // Пусто - поле применимо ко всем категориям
+	Required     bool
+	DefaultValue interface{}
+	Options      []string // Допустимые значения для enum
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewCustomFieldDefinition создает описание пользовательского поля
+func NewCustomFieldDefinition(key, name string, fieldType CustomFieldType, options []string) (*CustomFieldDefinition, error) {
+	now := time.Now()
+	def := &CustomFieldDefinition{
+		Key:       key,
+		Name:      name,
+		Type:      fieldType,
+		Options:   options,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// Validate проверяет корректность описания поля
+func (d *CustomFieldDefinition) Validate() error {
+	if !customFieldKeyPattern.MatchString(d.Key) {
+		return fmt.Errorf("invalid custom field key %q: must match %s", d.Key, customFieldKeyPattern.String())
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("custom field name is required")
+	}
+	if !d.Type.IsValid() {
+		return fmt.Errorf("invalid custom field type: %s", d.Type)
+	}
+	if d.Type == CustomFieldEnum && len(d.Options) == 0 {
+		return errors.New("enum custom field requires options")
+	}
+	if d.Type != CustomFieldEnum && len(d.Options) > 0 {
+		return errors.New("options are allowed only for enum custom fields")
+	}
+
+	if d.DefaultValue != nil {
+		normalized, err := d.NormalizeValue(d.DefaultValue)
+		if err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+		d.DefaultValue = normalized
+	}
+
+	return nil
+}
+
+// AppliesTo проверяет, применимо ли поле к задаче с указанными типом и категорией
+func (d *CustomFieldDefinition) AppliesTo(taskType TaskType, category string) bool {
+	if len(d.TaskTypes) > 0 {
+		found := false
+		for _, t := range d.TaskTypes {
+			if t == taskType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(d.Categories) > 0 {
+		for _, c := range d.Categories {
+			if c == category {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// NormalizeValue проверяет значение и приводит его к формату хранения:
+// text/enum/user/customer - string, number - float64, date - string в формате YYYY-MM-DD
+func (d *CustomFieldDefinition) NormalizeValue(value interface{}) (interface{}, error) {
+	switch d.Type {
+	case CustomFieldText, CustomFieldUser, CustomFieldCustomer:
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("field %s expects string, got %T", d.Key, value)
+		}
+		if d.Type.IsReference() && str == "" {
+			return nil, fmt.Errorf("field %s expects non-empty reference", d.Key)
+		}
+		return str, nil
+
+	case CustomFieldNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			number, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("field %s expects number, got %q", d.Key, v)
+			}
+			return number, nil
+		default:
+			return nil, fmt.Errorf("field %s expects number, got %T", d.Key, value)
+		}
+
+	case CustomFieldEnum:
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("field %s expects string, got %T", d.Key, value)
+		}
+		for _, option := range d.Options {
+			if option == str {
+				return str, nil
+			}
+		}
+		return nil, fmt.Errorf("field %s: value %q is not one of %v", d.Key, str, d.Options)
+
+	case CustomFieldDate:
+		var date time.Time
+		switch v := value.(type) {
+		case time.Time:
+			date = v
+		case string:
+			parsed, err := time.Parse(CustomFieldDateLayout, v)
+			if err != nil {
+				parsed, err = time.Parse(time.RFC3339, v)
+				if err != nil {
+					return nil, fmt.Errorf("field %s expects date (YYYY-MM-DD), got %q", d.Key, v)
+				}
+			}
+			date = parsed
+		default:
+			return nil, fmt.Errorf("field %s expects date, got %T", d.Key, value)
+		}
+		return date.Format(CustomFieldDateLayout), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported custom field type: %s", d.Type)
+	}
+}
+
+// SetCustomField устанавливает значение пользовательского поля задачи.
+// Значение должно быть предварительно нормализовано; nil удаляет поле
+func (t *Task) SetCustomField(key string, value interface{}, userID string) {
+	if t.CustomFields == nil {
+		t.CustomFields = make(map[string]interface{})
+	}
+
+	oldValue, exists := t.CustomFields[key]
+	if exists && oldValue == value {
+		return
+	}
+
+	if value == nil {
+		if !exists {
+			return
+		}
+		delete(t.CustomFields, key)
+	} else {
+		t.CustomFields[key] = value
+	}
+	t.UpdatedAt = time.Now()
+
+	message := fmt.Sprintf("Поле %s изменено: %v → %v", key, oldValue, value)
+	t.addFieldHistoryEvent(TaskEventCustomFieldChanged, CustomFieldChangeField(key), userID, oldValue, value, message)
+}
@@ -0,0 +1,93 @@
+// internal/core/domain/custom_field_test.go
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomFieldDefinition(t *testing.T) {
+	def, err := NewCustomFieldDefinition("contract_number", "Номер договора", CustomFieldText, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "contract_number", def.Key)
+
+	_, err = NewCustomFieldDefinition("Bad Key", "Name", CustomFieldText, nil)
+	assert.Error(t, err)
+
+	_, err = NewCustomFieldDefinition("severity", "Severity", CustomFieldEnum, nil)
+	assert.Error(t, err, "enum requires options")
+
+	def, err = NewCustomFieldDefinition("severity", "Severity", CustomFieldEnum, []string{"minor", "major"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"minor", "major"}, def.Options)
+
+	_, err = NewCustomFieldDefinition("field", "Field", "unknown", nil)
+	assert.Error(t, err)
+}
+
+func TestCustomFieldDefinition_NormalizeValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		def       CustomFieldDefinition
+		value     interface{}
+		expected  interface{}
+		expectErr bool
+	}{
+		{"text", CustomFieldDefinition{Key: "f", Type: CustomFieldText}, "abc", "abc", false},
+		{"text wrong type", CustomFieldDefinition{Key: "f", Type: CustomFieldText}, 42, nil, true},
+		{"number from int", CustomFieldDefinition{Key: "f", Type: CustomFieldNumber}, 42, 42.0, false},
+		{"number from string", CustomFieldDefinition{Key: "f", Type: CustomFieldNumber}, "4.5", 4.5, false},
+		{"number invalid", CustomFieldDefinition{Key: "f", Type: CustomFieldNumber}, "abc", nil, true},
+		{"enum valid", CustomFieldDefinition{Key: "f", Type: CustomFieldEnum, Options: []string{"a", "b"}}, "b", "b", false},
+		{"enum invalid", CustomFieldDefinition{Key: "f", Type: CustomFieldEnum, Options: []string{"a", "b"}}, "c", nil, true},
+		{"date", CustomFieldDefinition{Key: "f", Type: CustomFieldDate}, "2025-03-01", "2025-03-01", false},
+		{"date from RFC3339", CustomFieldDefinition{Key: "f", Type: CustomFieldDate}, "2025-03-01T10:00:00Z", "2025-03-01", false},
+		{"date invalid", CustomFieldDefinition{Key: "f", Type: CustomFieldDate}, "01.03.2025", nil, true},
+		{"user empty", CustomFieldDefinition{Key: "f", Type: CustomFieldUser}, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.def.NormalizeValue(tt.value)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestCustomFieldDefinition_AppliesTo(t *testing.T) {
+	def := CustomFieldDefinition{
+		Key:        "f",
+		TaskTypes:  []TaskType{TaskTypeSupport},
+		Categories: []string{"billing"},
+	}
+
+	assert.True(t, def.AppliesTo(TaskTypeSupport, "billing"))
+	assert.False(t, def.AppliesTo(TaskTypeSupport, "technical"))
+	assert.False(t, def.AppliesTo(TaskTypeInternal, "billing"))
+
+	global := CustomFieldDefinition{Key: "g"}
+	assert.True(t, global.AppliesTo(TaskTypeInternal, ""))
+}
+
+func TestTask_SetCustomField(t *testing.T) {
+	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
+
+	task.SetCustomField("severity", "high", "user-1")
+	assert.Equal(t, "high", task.CustomFields["severity"])
+	assert.Equal(t, "custom_field_changed", task.History[len(task.History)-1].Type)
+
+	historyLen := len(task.History)
+	task.SetCustomField("severity", "high", "user-1") // Без изменений
+	assert.Len(t, task.History, historyLen)
+
+	task.SetCustomField("severity", nil, "user-1")
+	_, exists := task.CustomFields["severity"]
+	assert.False(t, exists)
+}
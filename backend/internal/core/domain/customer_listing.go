@@ -0,0 +1,40 @@
+// internal/core/domain/customer_listing.go
+package domain
+
+import "strings"
+
+// CustomerSortFields поля, по которым можно сортировать клиентов
+var CustomerSortFields = []string{"name", "email", "organization", "created_at", "updated_at"}
+
+// DefaultCustomerSort сортировка клиентов по умолчанию: по имени
+var DefaultCustomerSort = []SortField{{Field: "name"}}
+
+// CustomerListing сортировка и постраничная выдача клиентов
+func CustomerListing(sort []SortField) Listing[Customer] {
+	return Listing[Customer]{
+		Sort: sort,
+		Key:  CustomerSortKey,
+		ID:   func(customer *Customer) string { return customer.ID },
+	}
+}
+
+// CustomerSortKey ключ сортировки клиента по полю; false - у клиента нет значения
+func CustomerSortKey(customer *Customer, field string) (string, bool) {
+	switch field {
+	case "name":
+		return strings.ToLower(customer.Name), true
+	case "email":
+		return strings.ToLower(customer.Email), true
+	case "organization":
+		if customer.Organization == nil || customer.Organization.Name == "" {
+			return "", false
+		}
+		return strings.ToLower(customer.Organization.Name), true
+	case "created_at":
+		return SortKeyTime(customer.CreatedAt), true
+	case "updated_at":
+		return SortKeyTime(customer.UpdatedAt), true
+	default:
+		return "", false
+	}
+}
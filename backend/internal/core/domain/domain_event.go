@@ -0,0 +1,201 @@
+// internal/core/domain/domain_event.go
+package domain
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// DomainEventType тип доменного события
+type DomainEventType string
+
+const (
+	DomainEventTaskCreated       DomainEventType = "task.created"
+	DomainEventTaskAssigned      DomainEventType = "task.assigned"
+	DomainEventTaskStatusChanged DomainEventType = "task.status_changed"
+	DomainEventTaskMessageAdded  DomainEventType = "task.message_added"
+	DomainEventEmailReceived     DomainEventType = "email.received"
+	DomainEventEmailSent         DomainEventType = "email.sent"
+)
+
+// DomainEventTypeAll подписка на все типы событий
+const DomainEventTypeAll DomainEventType = "*"
+
+// DomainEventTypes все публикуемые типы событий
+var DomainEventTypes = []DomainEventType{
+	DomainEventTaskCreated,
+	DomainEventTaskAssigned,
+	DomainEventTaskStatusChanged,
+	DomainEventTaskMessageAdded,
+	DomainEventEmailReceived,
+	DomainEventEmailSent,
+}
+
+// IsValid проверяет, что тип события известен
+func (t DomainEventType) IsValid() bool {
+	for _, known := range DomainEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Типы агрегатов, к которым относятся события
+const (
+	AggregateTask  = "task"
+	AggregateEmail = "email"
+)
+
+// DomainEvent факт, произошедший в системе, для реакции других подсистем.
+// Data содержит строковые атрибуты события, чтобы они одинаково переживали
+// сериализацию в outbox и обратно
+type DomainEvent struct {
+	ID            string
+	Type          DomainEventType
+	AggregateType string
+	AggregateID   string
+	ActorID       string
+	CorrelationID string
+	Data          map[string]string
+	OccurredAt    time.Time
+}
+
+var domainEventSeq atomic.Uint64
+
+// GenerateDomainEventID генерирует уникальный в пределах процесса ID события
+func GenerateDomainEventID() string {
+	return fmt.Sprintf("DEV-%d-%d", time.Now().UnixNano(), domainEventSeq.Add(1))
+}
+
+// NewDomainEvent создает событие с новым ID
+func NewDomainEvent(eventType DomainEventType, aggregateType, aggregateID, actorID string, data map[string]string) DomainEvent {
+	if data == nil {
+		data = map[string]string{}
+	}
+	return DomainEvent{
+		ID:            GenerateDomainEventID(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		ActorID:       actorID,
+		Data:          data,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// TaskDomainEvents переводит события истории задачи в доменные события.
+// События истории, не имеющие доменного аналога, пропускаются
+func TaskDomainEvents(task *Task, events []TaskEvent) []DomainEvent {
+	var result []DomainEvent
+	for _, event := range events {
+		var eventType DomainEventType
+		data := taskEventData(task)
+
+		switch event.Type {
+		case TaskEventCreated:
+			eventType = DomainEventTaskCreated
+			data["subject"] = task.Subject
+			data["priority"] = string(task.Priority)
+			data["source"] = string(task.Source)
+			data["reporter_id"] = task.ReporterID
+		case TaskEventAssigneeChanged:
+			eventType = DomainEventTaskAssigned
+			data["old_assignee_id"] = FormatAuditValue(event.OldValue)
+		case TaskEventStatusChanged:
+			eventType = DomainEventTaskStatusChanged
+			data["old_status"] = FormatAuditValue(event.OldValue)
+		case TaskEventMessageAdded:
+			eventType = DomainEventTaskMessageAdded
+			messageID := FormatAuditValue(event.NewValue)
+			data["message_id"] = messageID
+			for _, message := range task.Messages {
+				if message.ID == messageID {
+					data["message_type"] = string(message.Type)
+					data["author_id"] = message.AuthorID
+					break
+				}
+			}
+		default:
+			continue
+		}
+
+		result = append(result, DomainEvent{
+			ID:            GenerateDomainEventID(),
+			Type:          eventType,
+			AggregateType: AggregateTask,
+			AggregateID:   task.ID,
+			ActorID:       event.UserID,
+			Data:          data,
+			OccurredAt:    event.Timestamp,
+		})
+	}
+	return result
+}
+
+// taskEventData общие атрибуты событий задачи на момент изменения
+func taskEventData(task *Task) map[string]string {
+	data := map[string]string{
+		"task_id":     task.ID,
+		"task_type":   string(task.Type),
+		"status":      string(task.Status),
+		"assignee_id": task.AssigneeID,
+	}
+	if task.CustomerID != nil {
+		data["customer_id"] = *task.CustomerID
+	}
+	return data
+}
+
+// NewEmailDomainEvent создает событие о полученном или отправленном письме
+func NewEmailDomainEvent(eventType DomainEventType, msg EmailMessage) DomainEvent {
+	to := make([]string, len(msg.To))
+	for i, address := range msg.To {
+		to[i] = string(address)
+	}
+	data := map[string]string{
+		"message_id": msg.MessageID,
+		"from":       string(msg.From),
+		"to":         FormatAuditValue(to),
+		"subject":    msg.Subject,
+	}
+	if msg.InReplyTo != "" {
+		data["in_reply_to"] = msg.InReplyTo
+	}
+	if msg.RelatedTicketID != nil {
+		data["task_id"] = *msg.RelatedTicketID
+	}
+	return NewDomainEvent(eventType, AggregateEmail, string(msg.ID), string(msg.From), data)
+}
+
+// OutboxStatus состояние события в outbox
+type OutboxStatus string
+
+const (
+	OutboxStatusPending    OutboxStatus = "pending"    // Ожидает доставки подписчикам
+	OutboxStatusDispatched OutboxStatus = "dispatched" // Доставлено всем подписчикам
+	OutboxStatusFailed     OutboxStatus = "failed"     // Попытки исчерпаны
+)
+
+// OutboxMessage событие, сохраненное вместе с изменением и ожидающее доставки
+type OutboxMessage struct {
+	Event         DomainEvent
+	Status        OutboxStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	DispatchedAt  *time.Time
+	CreatedAt     time.Time
+}
+
+// NewOutboxMessage помещает событие в outbox для немедленной доставки
+func NewOutboxMessage(event DomainEvent) OutboxMessage {
+	now := time.Now()
+	return OutboxMessage{
+		Event:         event,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
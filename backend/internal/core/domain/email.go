@@ -44,6 +44,10 @@ type EmailMessage struct {
 
 	Headers map[string][]string
 
+	// RawMessage исходный текст письма (RFC 5322), как он получен с сервера;
+	// пустой у писем, созданных системой
+	RawMessage []byte
+
 	// Metadata
 	Processed   bool      `json:"processed"`
 	ProcessedAt time.Time `json:"processed_at"`
@@ -74,13 +78,18 @@ type EmailProcessingPolicy struct {
 
 // EmailChannelConfig - конфигурация email канала
 type EmailChannelConfig struct {
+	ID           string
+	Name         string
 	Provider     string // imap, smtp, api
 	Server       string
 	Port         int
 	Username     string
+	Password     string
 	Mailbox      string
 	SSL          bool
 	PollInterval time.Duration
+	Active       bool // Активный канал используется шлюзом; активен не более одного канала
+	UpdatedAt    time.Time
 }
 
 // Domain Methods
@@ -156,6 +165,12 @@ func (m *EmailMessage) LinkToTicket(ticketID string) {
 	m.UpdatedAt = time.Now()
 }
 
+// UnlinkFromTicket убирает связь email с заявкой
+func (m *EmailMessage) UnlinkFromTicket() {
+	m.RelatedTicketID = nil
+	m.UpdatedAt = time.Now()
+}
+
 // Validate проверяет валидность email сообщения
 func (m *EmailMessage) Validate() error {
 	if err := validateEmailAddress(m.From); err != nil {
@@ -273,6 +288,23 @@ func (m *EmailMessage) IsFromAllowedSender(policy EmailProcessingPolicy) bool {
 	return false
 }
 
+// Size возвращает размер письма: исходный текст, если сохранен, иначе тела и вложения
+func (m *EmailMessage) Size() int64 {
+	if len(m.RawMessage) > 0 {
+		return int64(len(m.RawMessage))
+	}
+	size := int64(len(m.BodyText) + len(m.BodyHTML))
+	for _, attachment := range m.Attachments {
+		size += attachment.Size
+	}
+	return size
+}
+
+// ExceedsSizeLimit проверяет лимит размера политики; нулевой лимит не ограничивает
+func (m *EmailMessage) ExceedsSizeLimit(policy EmailProcessingPolicy) bool {
+	return policy.MaxMessageSize > 0 && m.Size() > policy.MaxMessageSize
+}
+
 // Helper functions
 func validateEmailAddress(addr EmailAddress) error {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
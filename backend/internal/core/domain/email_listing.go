@@ -0,0 +1,40 @@
+// internal/core/domain/email_listing.go
+package domain
+
+import "strings"
+
+// EmailSortFields поля, по которым можно сортировать письма
+var EmailSortFields = []string{"created_at", "updated_at", "processed_at", "subject", "from"}
+
+// DefaultEmailSort сортировка писем по умолчанию: сначала новые
+var DefaultEmailSort = []SortField{{Field: "created_at", Desc: true}}
+
+// EmailListing сортировка и постраничная выдача писем
+func EmailListing(sort []SortField) Listing[EmailMessage] {
+	return Listing[EmailMessage]{
+		Sort: sort,
+		Key:  EmailSortKey,
+		ID:   func(msg *EmailMessage) string { return string(msg.ID) },
+	}
+}
+
+// EmailSortKey ключ сортировки письма по полю; false - у письма нет значения
+func EmailSortKey(msg *EmailMessage, field string) (string, bool) {
+	switch field {
+	case "created_at":
+		return SortKeyTime(msg.CreatedAt), true
+	case "updated_at":
+		return SortKeyTime(msg.UpdatedAt), true
+	case "processed_at":
+		if msg.ProcessedAt.IsZero() {
+			return "", false
+		}
+		return SortKeyTime(msg.ProcessedAt), true
+	case "subject":
+		return strings.ToLower(msg.Subject), true
+	case "from":
+		return strings.ToLower(string(msg.From)), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,168 @@
+// internal/core/domain/email_rfc822.go
+package domain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// RFC822 возвращает письмо в формате .eml. Если сохранен исходный текст письма,
+// возвращается он без изменений; иначе письмо собирается из сохраненных полей
+// (заголовки адресации и треда, текстовая и HTML-часть, вложения с содержимым)
+func (m *EmailMessage) RFC822() []byte {
+	if len(m.RawMessage) > 0 {
+		return m.RawMessage
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+		}
+	}
+
+	date := m.CreatedAt
+	if date.IsZero() {
+		date = time.Now()
+	}
+	writeHeader("From", string(m.From))
+	writeHeader("To", joinAddresses(m.To))
+	writeHeader("Cc", joinAddresses(m.CC))
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader("Date", date.Format(time.RFC1123Z))
+	writeHeader("Message-ID", angleID(m.MessageID))
+	writeHeader("In-Reply-To", angleID(m.InReplyTo))
+	references := make([]string, 0, len(m.References))
+	for _, ref := range m.References {
+		references = append(references, angleID(ref))
+	}
+	writeHeader("References", strings.Join(references, " "))
+	writeHeader("MIME-Version", "1.0")
+
+	var attachments []Attachment
+	for _, attachment := range m.Attachments {
+		if len(attachment.Data) > 0 {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	if len(attachments) == 0 {
+		m.writeBody(&buf, textproto.MIMEHeader{}, true)
+		return buf.Bytes()
+	}
+
+	mixed := multipart.NewWriter(&buf)
+	writeHeader("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mixed.Boundary()}))
+	buf.WriteString("\r\n")
+
+	var body bytes.Buffer
+	bodyHeader := textproto.MIMEHeader{}
+	m.writeBody(&body, bodyHeader, false)
+	part, _ := mixed.CreatePart(bodyHeader)
+	part.Write(body.Bytes())
+
+	for _, attachment := range attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType)
+		header.Set("Content-Transfer-Encoding", "base64")
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+		if attachment.ContentID != "" {
+			header.Set("Content-ID", angleID(attachment.ContentID))
+		}
+		part, _ := mixed.CreatePart(header)
+		writeBase64Lines(part, attachment.Data)
+	}
+	mixed.Close()
+	return buf.Bytes()
+}
+
+// writeBody записывает текстовую и HTML-части. Заголовки части пишутся в buf
+// (inline) или в header для вложенной части multipart
+func (m *EmailMessage) writeBody(buf *bytes.Buffer, header textproto.MIMEHeader, inline bool) {
+	setHeader := func(key, value string) {
+		if inline {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		} else {
+			header.Set(key, value)
+		}
+	}
+
+	if m.BodyText == "" || m.BodyHTML == "" {
+		body, contentType := m.BodyText, "text/plain; charset=utf-8"
+		if body == "" && m.BodyHTML != "" {
+			body, contentType = m.BodyHTML, "text/html; charset=utf-8"
+		}
+		setHeader("Content-Type", contentType)
+		setHeader("Content-Transfer-Encoding", "quoted-printable")
+		if inline {
+			buf.WriteString("\r\n")
+		}
+		writeQuotedPrintable(buf, body)
+		return
+	}
+
+	var parts bytes.Buffer
+	alternative := multipart.NewWriter(&parts)
+	for _, body := range []struct{ contentType, text string }{
+		{"text/plain; charset=utf-8", m.BodyText},
+		{"text/html; charset=utf-8", m.BodyHTML},
+	} {
+		partHeader := textproto.MIMEHeader{}
+		partHeader.Set("Content-Type", body.contentType)
+		partHeader.Set("Content-Transfer-Encoding", "quoted-printable")
+		part, _ := alternative.CreatePart(partHeader)
+		var encoded bytes.Buffer
+		writeQuotedPrintable(&encoded, body.text)
+		part.Write(encoded.Bytes())
+	}
+	alternative.Close()
+
+	setHeader("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": alternative.Boundary()}))
+	if inline {
+		buf.WriteString("\r\n")
+	}
+	buf.Write(parts.Bytes())
+}
+
+func writeQuotedPrintable(buf *bytes.Buffer, text string) {
+	w := quotedprintable.NewWriter(buf)
+	w.Write([]byte(text))
+	w.Close()
+}
+
+func writeBase64Lines(w interface{ Write([]byte) (int, error) }, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
+
+func joinAddresses(addresses []EmailAddress) string {
+	values := make([]string, len(addresses))
+	for i, address := range addresses {
+		values[i] = string(address)
+	}
+	return strings.Join(values, ", ")
+}
+
+// angleID заключает идентификатор сообщения в угловые скобки, если их нет
+func angleID(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.HasPrefix(id, "<") {
+		return id
+	}
+	return "<" + id + ">"
+}
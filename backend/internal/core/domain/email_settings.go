@@ -0,0 +1,94 @@
+// internal/core/domain/email_settings.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Ошибки настроек email
+var (
+	ErrEmailChannelNotFound  = errors.New("email channel config not found")
+	ErrEmailSettingsNotFound = errors.New("email settings not found")
+	ErrEmailChannelActive    = errors.New("active email channel cannot be deleted")
+)
+
+// MaxEmailMessageSizeLimit верхняя граница лимита размера письма в политике
+const MaxEmailMessageSizeLimit int64 = 100 * 1024 * 1024
+
+var emailChannelIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Validate проверяет идентификатор, провайдера и параметры подключения канала
+func (c *EmailChannelConfig) Validate() error {
+	if !emailChannelIDPattern.MatchString(c.ID) {
+		return fmt.Errorf("invalid channel id %q: expected lowercase letters, digits, '-' or '_'", c.ID)
+	}
+	if c.Provider != "imap" {
+		return fmt.Errorf("unsupported email provider: %s", c.Provider)
+	}
+	if strings.TrimSpace(c.Server) == "" {
+		return errors.New("server is required")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", c.Port)
+	}
+	if strings.TrimSpace(c.Username) == "" {
+		return errors.New("username is required")
+	}
+	if strings.TrimSpace(c.Mailbox) == "" {
+		return errors.New("mailbox is required")
+	}
+	if c.PollInterval < 0 {
+		return fmt.Errorf("poll interval cannot be negative, got %v", c.PollInterval)
+	}
+	return nil
+}
+
+// Validate проверяет лимит размера, адреса отправителей и текст авто-ответа
+func (p *EmailProcessingPolicy) Validate() error {
+	if p.MaxMessageSize <= 0 || p.MaxMessageSize > MaxEmailMessageSizeLimit {
+		return fmt.Errorf("max message size must be between 1 and %d bytes, got %d", MaxEmailMessageSizeLimit, p.MaxMessageSize)
+	}
+	if p.AutoReply && strings.TrimSpace(p.AutoReplyText) == "" {
+		return errors.New("auto reply text is required when auto reply is enabled")
+	}
+
+	blocked := make(map[EmailAddress]bool, len(p.BlockedSenders))
+	for _, sender := range p.BlockedSenders {
+		if err := validateEmailAddress(sender); err != nil {
+			return fmt.Errorf("invalid blocked sender %q: %w", sender, err)
+		}
+		blocked[sender] = true
+	}
+	for _, sender := range p.AllowedSenders {
+		if err := validateEmailAddress(sender); err != nil {
+			return fmt.Errorf("invalid allowed sender %q: %w", sender, err)
+		}
+		if blocked[sender] {
+			return fmt.Errorf("sender %q cannot be both allowed and blocked", sender)
+		}
+	}
+	return nil
+}
+
+// NormalizeSenders убирает пробелы, пустые адреса и повторы в списках отправителей
+func (p *EmailProcessingPolicy) NormalizeSenders() {
+	p.AllowedSenders = normalizeSenders(p.AllowedSenders)
+	p.BlockedSenders = normalizeSenders(p.BlockedSenders)
+}
+
+func normalizeSenders(senders []EmailAddress) []EmailAddress {
+	seen := make(map[EmailAddress]bool, len(senders))
+	result := make([]EmailAddress, 0, len(senders))
+	for _, sender := range senders {
+		normalized := EmailAddress(strings.TrimSpace(string(sender)))
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+	return result
+}
@@ -1,6 +1,11 @@
 package domain_test
 
 import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
 	"testing"
 
 	"github.com/audetv/urms/internal/core/domain"
@@ -185,3 +190,70 @@ func TestEmailMessage_MarkAsProcessed(t *testing.T) {
 	assert.NotZero(t, email.ProcessedAt)
 	assert.Equal(t, &ticketID, email.RelatedTicketID)
 }
+
+func TestEmailMessage_RFC822(t *testing.T) {
+	t.Run("raw message is returned as is", func(t *testing.T) {
+		raw := []byte("From: a@example.com\r\nSubject: Raw\r\n\r\nBody")
+		msg := &domain.EmailMessage{RawMessage: raw, Subject: "Other"}
+		assert.Equal(t, raw, msg.RFC822())
+	})
+
+	t.Run("message is rebuilt from stored fields", func(t *testing.T) {
+		msg := &domain.EmailMessage{
+			MessageID:  "abc@example.com",
+			InReplyTo:  "<prev@example.com>",
+			References: []string{"root@example.com", "<prev@example.com>"},
+			From:       "customer@example.com",
+			To:         []domain.EmailAddress{"support@example.com"},
+			Subject:    "Не работает принтер",
+			BodyText:   "Текст письма",
+			BodyHTML:   "<p>Текст письма</p>",
+			Attachments: []domain.Attachment{
+				{Name: "log.txt", ContentType: "text/plain", Data: []byte("error log")},
+				{Name: "missing.bin"}, // Без содержимого не выгружается
+			},
+		}
+
+		parsed, err := mail.ReadMessage(bytes.NewReader(msg.RFC822()))
+		require.NoError(t, err)
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Не работает принтер", subject)
+		assert.Equal(t, "<abc@example.com>", parsed.Header.Get("Message-ID"))
+		assert.Equal(t, "<prev@example.com>", parsed.Header.Get("In-Reply-To"))
+		assert.Equal(t, "<root@example.com> <prev@example.com>", parsed.Header.Get("References"))
+
+		mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		require.Equal(t, "multipart/mixed", mediaType)
+
+		reader := multipart.NewReader(parsed.Body, params["boundary"])
+		body, err := reader.NextPart()
+		require.NoError(t, err)
+		bodyType, _, _ := mime.ParseMediaType(body.Header.Get("Content-Type"))
+		assert.Equal(t, "multipart/alternative", bodyType)
+
+		attachment, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "log.txt", attachment.FileName())
+
+		_, err = reader.NextPart()
+		assert.Equal(t, io.EOF, err, "attachments without data are skipped")
+	})
+}
+
+func TestEmailMessage_ExceedsSizeLimit(t *testing.T) {
+	msg := &domain.EmailMessage{
+		BodyText:    string(bytes.Repeat([]byte("a"), 100)),
+		Attachments: []domain.Attachment{{Name: "report.pdf", Size: 900}},
+	}
+
+	assert.Equal(t, int64(1000), msg.Size())
+	assert.False(t, msg.ExceedsSizeLimit(domain.EmailProcessingPolicy{}), "zero limit means no limit")
+	assert.False(t, msg.ExceedsSizeLimit(domain.EmailProcessingPolicy{MaxMessageSize: 1000}))
+	assert.True(t, msg.ExceedsSizeLimit(domain.EmailProcessingPolicy{MaxMessageSize: 999}))
+
+	msg.RawMessage = make([]byte, 500)
+	assert.Equal(t, int64(500), msg.Size(), "raw source is the actual size")
+}
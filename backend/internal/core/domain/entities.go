@@ -10,15 +10,41 @@ type Participant struct {
 	JoinedAt time.Time
 }
 
+// TaskLink представляет типизированную связь с другой задачей
+type TaskLink struct {
+	Type      TaskLinkType
+	TaskID    string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
 // Message представляет сообщение в задаче
 type Message struct {
+	ID          string
+	Content     string
+	AuthorID    string
+	Type        MessageType
+	Attachments []Attachment
+	CreatedAt   time.Time
+}
+
+// WorkLog представляет запись о затраченном на задачу времени
+type WorkLog struct {
 	ID        string
-	Content   string
-	AuthorID  string
-	Type      MessageType
+	UserID    string
+	Duration  time.Duration
+	Date      time.Time // День, к которому относится работа
+	Note      string
+	Billable  bool
 	CreatedAt time.Time
 }
 
+// TaskTimer представляет запущенный пользователем таймер учета времени
+type TaskTimer struct {
+	UserID    string
+	StartedAt time.Time
+}
+
 // Customer представляет клиента/организацию
 type Customer struct {
 	ID           string
@@ -43,15 +69,36 @@ type ProjectMembership struct {
 	Role      string
 }
 
-// User представляет пользователя системы (заглушка для RBAC)
+// User представляет пользователя системы
 type User struct {
 	ID    string
 	Email string
 	Name  string
-	Role  UserRole // Заглушка для RBAC
+	Role  UserRole // Права пользователя, см. RolePermissions
+	// Categories категории задач, с которыми работает оператор; пусто - все категории
+	Categories []string
+	// Signature подпись оператора в ответах клиентам; пусто - подпись по умолчанию
+	Signature string
+	// PasswordHash хеш пароля для входа в API; пусто - вход по паролю недоступен
+	PasswordHash string
+
+	Status   UserStatus       // Назначать задачи и входить в API может только активный пользователь
+	Timezone string           // Часовой пояс IANA, например Europe/Moscow; пусто - часовой пояс сервера
+	Language TemplateLanguage // Язык интерфейса и шаблонов ответов
+	// Skills навыки для распределения задач (например, языки клиентов или продукты)
+	Skills      []string
+	OutOfOffice *OutOfOffice // Период отсутствия; nil - пользователь на месте
+
+	// InvitationHash хеш токена приглашения; токен показывается один раз при создании пользователя
+	InvitationHash      string
+	InvitationExpiresAt *time.Time
+
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeactivatedAt *time.Time
 }
 
-// UserRole роль пользователя (заглушка для RBAC)
+// UserRole роль пользователя
 type UserRole string
 
 const (
@@ -0,0 +1,237 @@
+// internal/core/domain/github.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ошибки интеграции с GitHub
+var (
+	ErrGitHubIssueAlreadyLinked = errors.New("task is already linked to a github issue")
+	ErrGitHubRepoNotWatched     = errors.New("github repository is not configured")
+)
+
+// Ключи SourceMeta задач, связанных с задачей (issue) GitHub.
+// Связь хранится отдельно от ключей канала, поэтому задача из email или
+// Telegram может одновременно иметь связанную задачу GitHub
+const (
+	SourceMetaGitHubIssue      = "github_issue"       // "owner/repo#12"
+	SourceMetaGitHubIssueURL   = "github_issue_url"   // Адрес issue для операторов
+	SourceMetaGitHubCommentIDs = "github_comment_ids" // Комментарии, уже отраженные в переписке
+	SourceMetaGitHubMessageIDs = "github_message_ids" // Сообщения задачи, уже отправленные комментариями
+)
+
+// GitHubAuthorID автор изменений, пришедших из GitHub.
+// Изменения этого автора не отправляются обратно, что исключает зацикливание
+const GitHubAuthorID = "github"
+
+// GitHubCustomerEmailDomain домен служебных адресов авторов issue
+const GitHubCustomerEmailDomain = "github.invalid"
+
+// Состояния issue
+const (
+	GitHubIssueOpen   = "open"
+	GitHubIssueClosed = "closed"
+)
+
+// Действия webhook-событий GitHub, влияющие на задачу
+const (
+	GitHubActionOpened   = "opened"
+	GitHubActionClosed   = "closed"
+	GitHubActionReopened = "reopened"
+	GitHubActionCreated  = "created"
+)
+
+var (
+	gitHubRepoPattern   = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+	gitHubMarkerPattern = regexp.MustCompile(`<!-- urms-(task|message):(\S+) -->`)
+)
+
+// GitHubIssueRef ссылка на issue репозитория
+type GitHubIssueRef struct {
+	Repo   string // owner/repo
+	Number int
+}
+
+func (r GitHubIssueRef) String() string {
+	return fmt.Sprintf("%s#%d", r.Repo, r.Number)
+}
+
+// ParseGitHubIssueRef разбирает ссылку вида owner/repo#12
+func ParseGitHubIssueRef(value string) (GitHubIssueRef, error) {
+	repo, number, found := strings.Cut(value, "#")
+	if !found || !IsValidGitHubRepo(repo) {
+		return GitHubIssueRef{}, fmt.Errorf("invalid github issue reference: %q", value)
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return GitHubIssueRef{}, fmt.Errorf("invalid github issue number: %q", value)
+	}
+	return GitHubIssueRef{Repo: repo, Number: n}, nil
+}
+
+// IsValidGitHubRepo проверяет имя репозитория вида owner/repo
+func IsValidGitHubRepo(repo string) bool {
+	return gitHubRepoPattern.MatchString(repo)
+}
+
+// GitHubIssue issue репозитория (pull request'ы GitHub тоже отдает как issue)
+type GitHubIssue struct {
+	Repo          string
+	Number        int
+	Title         string
+	Body          string
+	State         string
+	URL           string
+	AuthorLogin   string
+	IsPullRequest bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Ref ссылка на issue
+func (i *GitHubIssue) Ref() GitHubIssueRef {
+	return GitHubIssueRef{Repo: i.Repo, Number: i.Number}
+}
+
+// LinkedTaskID ID задачи, для которой issue создан из URMS.
+// Маркер в теле issue позволяет не принять такой issue как новую задачу
+func (i *GitHubIssue) LinkedTaskID() string {
+	return findGitHubMarker(i.Body, "task")
+}
+
+// GitHubTaskMarker маркер задачи в теле созданного issue
+func GitHubTaskMarker(taskID string) string {
+	return fmt.Sprintf("<!-- urms-task:%s -->", taskID)
+}
+
+// GitHubMessageMarker маркер сообщения задачи в теле отправленного комментария
+func GitHubMessageMarker(messageID string) string {
+	return fmt.Sprintf("<!-- urms-message:%s -->", messageID)
+}
+
+// findGitHubMarker возвращает ID из скрытого маркера URMS в тексте
+func findGitHubMarker(body, kind string) string {
+	for _, match := range gitHubMarkerPattern.FindAllStringSubmatch(body, -1) {
+		if match[1] == kind {
+			return match[2]
+		}
+	}
+	return ""
+}
+
+// GitHubComment комментарий к issue
+type GitHubComment struct {
+	ID          int64
+	Repo        string
+	IssueNumber int
+	Body        string
+	AuthorLogin string
+	URL         string
+	CreatedAt   time.Time
+}
+
+// ExternalID ID комментария в SourceMeta задачи
+func (c *GitHubComment) ExternalID() string {
+	return strconv.FormatInt(c.ID, 10)
+}
+
+// LinkedMessageID ID сообщения задачи, отправленного этим комментарием из URMS
+func (c *GitHubComment) LinkedMessageID() string {
+	return findGitHubMarker(c.Body, "message")
+}
+
+// GitHubWebhookEvent событие webhook GitHub, приведенное к issue и комментарию
+type GitHubWebhookEvent struct {
+	Action  string
+	Issue   *GitHubIssue
+	Comment *GitHubComment // Только для issue_comment
+}
+
+// GitHubCustomerEmail служебный адрес клиента - автора issue
+func GitHubCustomerEmail(login string) string {
+	return fmt.Sprintf("%s@%s", strings.ToLower(login), GitHubCustomerEmailDomain)
+}
+
+// GitHubIssueCriteria критерии поиска задачи, связанной с issue
+func GitHubIssueCriteria(ref GitHubIssueRef) map[string]interface{} {
+	return map[string]interface{}{SourceMetaGitHubIssue: ref.String()}
+}
+
+// MatchesGitHubSourceMeta проверяет связь задачи с issue из критериев
+func MatchesGitHubSourceMeta(taskMeta, criteria map[string]interface{}) bool {
+	issue, _ := criteria[SourceMetaGitHubIssue].(string)
+	return issue != "" && taskMeta[SourceMetaGitHubIssue] == issue
+}
+
+// GitHubIssue возвращает связанный issue задачи
+func (t *Task) GitHubIssue() (GitHubIssueRef, bool) {
+	value, _ := t.SourceMeta[SourceMetaGitHubIssue].(string)
+	if value == "" {
+		return GitHubIssueRef{}, false
+	}
+	ref, err := ParseGitHubIssueRef(value)
+	if err != nil {
+		return GitHubIssueRef{}, false
+	}
+	return ref, true
+}
+
+// LinkGitHubIssue связывает задачу с issue
+func (t *Task) LinkGitHubIssue(ref GitHubIssueRef, url, userID string) error {
+	if _, linked := t.GitHubIssue(); linked {
+		return ErrGitHubIssueAlreadyLinked
+	}
+	if t.SourceMeta == nil {
+		t.SourceMeta = make(map[string]interface{})
+	}
+	t.SourceMeta[SourceMetaGitHubIssue] = ref.String()
+	t.SourceMeta[SourceMetaGitHubIssueURL] = url
+	t.UpdatedAt = time.Now()
+	t.addFieldHistoryEvent(TaskEventUpdated, SourceMetaGitHubIssue, userID, nil, ref.String(), "Связана задача GitHub "+ref.String())
+	return nil
+}
+
+// GitHubMirrorMessageType тип сообщений, синхронизируемых с комментариями issue.
+// Для задач из GitHub переписка с клиентом и есть обсуждение issue; для связанных
+// задач issue - внутреннее обсуждение, и переписка с клиентом в него не попадает
+func (t *Task) GitHubMirrorMessageType() MessageType {
+	if t.Source == SourceGitHub {
+		return MessageTypeCustomer
+	}
+	return MessageTypeInternal
+}
+
+// HasGitHubComment проверяет, отражен ли комментарий в задаче
+func (t *Task) HasGitHubComment(commentID string) bool {
+	return slices.Contains(sourceMetaStrings(t.SourceMeta[SourceMetaGitHubCommentIDs]), commentID)
+}
+
+// HasGitHubMessage проверяет, отправлено ли сообщение комментарием
+func (t *Task) HasGitHubMessage(messageID string) bool {
+	return slices.Contains(sourceMetaStrings(t.SourceMeta[SourceMetaGitHubMessageIDs]), messageID)
+}
+
+// RecordGitHubComment запоминает соответствие комментария и сообщения задачи.
+// messageID пуст, если комментарий пропущен без сообщения
+func (t *Task) RecordGitHubComment(commentID, messageID string) {
+	if t.SourceMeta == nil {
+		t.SourceMeta = make(map[string]interface{})
+	}
+	appendSourceMetaString(t.SourceMeta, SourceMetaGitHubCommentIDs, commentID)
+	if messageID != "" {
+		appendSourceMetaString(t.SourceMeta, SourceMetaGitHubMessageIDs, messageID)
+	}
+	t.UpdatedAt = time.Now()
+}
+
+// IsGitHubResolvedStatus статусы, при которых связанный issue закрыт
+func IsGitHubResolvedStatus(status TaskStatus) bool {
+	return status == TaskStatusResolved || status == TaskStatusClosed || status == TaskStatusCancelled
+}
@@ -0,0 +1,59 @@
+// internal/core/domain/idempotency.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode"
+)
+
+// MaxIdempotencyKeyLength максимальная длина заголовка Idempotency-Key
+const MaxIdempotencyKeyLength = 255
+
+var (
+	ErrIdempotencyKeyInvalid = errors.New("invalid idempotency key")
+	// ErrIdempotencyKeyReused ключ уже использован с другим телом запроса
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with different payload")
+	// ErrIdempotencyRequestInProgress запрос с этим ключом еще выполняется
+	ErrIdempotencyRequestInProgress = errors.New("request with this idempotency key is in progress")
+)
+
+// IdempotencyRecord запрос с ключом идемпотентности и его первый ответ.
+// Пока запрос выполняется, StatusCode равен 0, и запись служит блокировкой ключа
+type IdempotencyRecord struct {
+	Key             string // Ключ в области вызывающего: пользователь, метод, путь и Idempotency-Key
+	RequestHash     string // SHA-256 тела запроса
+	StatusCode      int
+	ResponseBody    []byte
+	ResponseHeaders map[string]string
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+// Completed сообщает, сохранен ли ответ
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Expired сообщает, истек ли срок хранения записи (или блокировки выполняющегося запроса)
+func (r *IdempotencyRecord) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// ValidateIdempotencyKey проверяет значение заголовка Idempotency-Key:
+// непустая строка печатных ASCII-символов не длиннее MaxIdempotencyKeyLength
+func ValidateIdempotencyKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: key is empty", ErrIdempotencyKeyInvalid)
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("%w: key is longer than %d characters", ErrIdempotencyKeyInvalid, MaxIdempotencyKeyLength)
+	}
+	for _, r := range key {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return fmt.Errorf("%w: key must contain printable ASCII characters only", ErrIdempotencyKeyInvalid)
+		}
+	}
+	return nil
+}
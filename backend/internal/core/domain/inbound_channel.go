@@ -0,0 +1,329 @@
+// internal/core/domain/inbound_channel.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Ошибки входящих каналов
+var (
+	ErrInboundChannelNotFound  = errors.New("inbound channel not found")
+	ErrInboundChannelExists    = errors.New("inbound channel already exists")
+	ErrInboundChannelDisabled  = errors.New("inbound channel is disabled")
+	ErrInboundSignatureInvalid = errors.New("invalid inbound payload signature")
+	ErrInboundPayloadInvalid   = errors.New("invalid inbound payload")
+	// ErrInboundDuplicate внешняя запись уже принята задачей
+	ErrInboundDuplicate = errors.New("inbound record already accepted")
+)
+
+// Ключи SourceMeta задач, созданных через входящие каналы
+const (
+	SourceMetaChannel          = "channel"            // Ключ канала
+	SourceMetaExternalID       = "external_id"        // Внешний ID записи, создавшей задачу
+	SourceMetaExternalThreadID = "external_thread_id" // Внешний ID обсуждения
+	SourceMetaExternalIDs      = "external_ids"       // Все принятые внешние ID, включая дополнения
+)
+
+var inboundChannelKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// InboundMapping выражения JSONPath, по которым из payload извлекаются поля задачи.
+// Обязательны Subject, CustomerEmail и ExternalID
+type InboundMapping struct {
+	Subject          string
+	Description      string
+	CustomerEmail    string
+	CustomerName     string
+	Priority         string
+	Tags             string
+	ExternalID       string
+	ExternalThreadID string
+}
+
+// InboundChannel входящий канал, через который внешняя система создает задачи поддержки
+type InboundChannel struct {
+	Key             string // Используется в адресе /channels/:channelKey/inbound
+	Name            string
+	Source          TaskSource // api или web_form
+	Secret          string     // Обязателен: payload подписывается этим секретом (X-URMS-Signature)
+	Mapping         InboundMapping
+	PriorityMap     map[string]Priority // Внешнее значение → приоритет; без учета регистра
+	DefaultPriority Priority
+	DefaultTags     []string
+	Active          bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// InboundItem поля задачи, извлеченные из payload
+type InboundItem struct {
+	ExternalID       string
+	ExternalThreadID string
+	Subject          string
+	Description      string
+	CustomerEmail    string
+	CustomerName     string
+	Priority         Priority
+	Tags             []string
+}
+
+// NewInboundChannel создает активный канал
+func NewInboundChannel(key, name string, source TaskSource, secret string, mapping InboundMapping) (*InboundChannel, error) {
+	now := time.Now()
+	channel := &InboundChannel{
+		Key:             strings.TrimSpace(key),
+		Name:            strings.TrimSpace(name),
+		Source:          source,
+		Secret:          secret,
+		Mapping:         mapping,
+		DefaultPriority: PriorityMedium,
+		Active:          true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if channel.Source == "" {
+		channel.Source = SourceAPI
+	}
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// Validate проверяет ключ, источник, секрет и выражения сопоставления
+func (c *InboundChannel) Validate() error {
+	if !inboundChannelKeyPattern.MatchString(c.Key) {
+		return fmt.Errorf("invalid channel key %q: expected lowercase letters, digits, '-' or '_'", c.Key)
+	}
+	if c.Key == TelegramChannelKey {
+		return fmt.Errorf("channel key %q is reserved", c.Key)
+	}
+	if c.Name == "" {
+		return errors.New("channel name is required")
+	}
+	if c.Source != SourceAPI && c.Source != SourceWebForm {
+		return fmt.Errorf("unsupported channel source: %s", c.Source)
+	}
+	// Прием записей публичный, поэтому подпись обязательна для каждого канала
+	if len(c.Secret) < minWebhookSecretLength {
+		return fmt.Errorf("channel secret must be at least %d characters", minWebhookSecretLength)
+	}
+	if !c.DefaultPriority.IsValid() {
+		return fmt.Errorf("invalid default priority: %s", c.DefaultPriority)
+	}
+	for value, priority := range c.PriorityMap {
+		if !priority.IsValid() {
+			return fmt.Errorf("invalid priority %q for value %q", priority, value)
+		}
+	}
+
+	required := map[string]string{
+		"subject":        c.Mapping.Subject,
+		"customer_email": c.Mapping.CustomerEmail,
+		"external_id":    c.Mapping.ExternalID,
+	}
+	for field, expr := range required {
+		if strings.TrimSpace(expr) == "" {
+			return fmt.Errorf("mapping for %s is required", field)
+		}
+	}
+	for _, expr := range c.mappingExpressions() {
+		if expr == "" {
+			continue
+		}
+		if _, err := ParseJSONPath(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *InboundChannel) mappingExpressions() []string {
+	m := c.Mapping
+	return []string{m.Subject, m.Description, m.CustomerEmail, m.CustomerName, m.Priority, m.Tags, m.ExternalID, m.ExternalThreadID}
+}
+
+// Extract извлекает поля задачи из payload по выражениям канала
+func (c *InboundChannel) Extract(document interface{}) (*InboundItem, error) {
+	item := &InboundItem{
+		Priority: c.DefaultPriority,
+		Tags:     append([]string(nil), c.DefaultTags...),
+	}
+
+	var err error
+	if item.ExternalID, err = c.extractString(c.Mapping.ExternalID, document, true); err != nil {
+		return nil, err
+	}
+	if item.Subject, err = c.extractString(c.Mapping.Subject, document, true); err != nil {
+		return nil, err
+	}
+	if item.CustomerEmail, err = c.extractString(c.Mapping.CustomerEmail, document, true); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(item.CustomerEmail, "@") {
+		return nil, fmt.Errorf("%w: invalid customer email %q", ErrInboundPayloadInvalid, item.CustomerEmail)
+	}
+	if item.ExternalThreadID, err = c.extractString(c.Mapping.ExternalThreadID, document, false); err != nil {
+		return nil, err
+	}
+	if item.Description, err = c.extractString(c.Mapping.Description, document, false); err != nil {
+		return nil, err
+	}
+	if item.CustomerName, err = c.extractString(c.Mapping.CustomerName, document, false); err != nil {
+		return nil, err
+	}
+
+	rawPriority, err := c.extractString(c.Mapping.Priority, document, false)
+	if err != nil {
+		return nil, err
+	}
+	if rawPriority != "" {
+		item.Priority = c.resolvePriority(rawPriority)
+	}
+
+	if c.Mapping.Tags != "" {
+		path, err := ParseJSONPath(c.Mapping.Tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range path.Strings(document) {
+			// Строка может содержать несколько меток через запятую
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(item.Tags, tag) {
+					item.Tags = append(item.Tags, tag)
+				}
+			}
+		}
+	}
+
+	return item, nil
+}
+
+func (c *InboundChannel) extractString(expr string, document interface{}, required bool) (string, error) {
+	if expr == "" {
+		return "", nil
+	}
+	path, err := ParseJSONPath(expr)
+	if err != nil {
+		return "", err
+	}
+	value, found := path.FirstString(document)
+	value = strings.TrimSpace(value)
+	if required && (!found || value == "") {
+		return "", fmt.Errorf("%w: no value at %s", ErrInboundPayloadInvalid, expr)
+	}
+	return value, nil
+}
+
+// resolvePriority сопоставляет внешнее значение приоритету канала;
+// неизвестные значения получают приоритет по умолчанию
+func (c *InboundChannel) resolvePriority(value string) Priority {
+	for external, priority := range c.PriorityMap {
+		if strings.EqualFold(external, value) {
+			return priority
+		}
+	}
+	if priority := Priority(strings.ToLower(value)); priority.IsValid() {
+		return priority
+	}
+	return c.DefaultPriority
+}
+
+// SourceMeta мета-данные источника для задачи, созданной из записи
+func (c *InboundChannel) SourceMeta(item *InboundItem) map[string]interface{} {
+	meta := map[string]interface{}{
+		SourceMetaChannel:     c.Key,
+		SourceMetaExternalID:  item.ExternalID,
+		SourceMetaExternalIDs: []string{item.ExternalID},
+	}
+	if item.ExternalThreadID != "" {
+		meta[SourceMetaExternalThreadID] = item.ExternalThreadID
+	}
+	return meta
+}
+
+// InboundExternalIDCriteria критерии поиска задачи, уже принявшей внешнюю запись
+func InboundExternalIDCriteria(channelKey, externalID string) map[string]interface{} {
+	return map[string]interface{}{
+		SourceMetaChannel:    channelKey,
+		SourceMetaExternalID: externalID,
+	}
+}
+
+// InboundThreadCriteria критерии поиска задачи по внешнему обсуждению
+func InboundThreadCriteria(channelKey, threadID string) map[string]interface{} {
+	return map[string]interface{}{
+		SourceMetaChannel:          channelKey,
+		SourceMetaExternalThreadID: threadID,
+	}
+}
+
+// MatchesInboundSourceMeta проверяет мета-данные задачи по критериям входящего канала.
+// Внешний ID сравнивается со всеми принятыми задачей записями
+func MatchesInboundSourceMeta(taskMeta, criteria map[string]interface{}) bool {
+	channel, _ := criteria[SourceMetaChannel].(string)
+	if channel == "" || taskMeta[SourceMetaChannel] != channel {
+		return false
+	}
+
+	if externalID, _ := criteria[SourceMetaExternalID].(string); externalID != "" {
+		if taskMeta[SourceMetaExternalID] == externalID {
+			return true
+		}
+		return slices.Contains(sourceMetaStrings(taskMeta[SourceMetaExternalIDs]), externalID)
+	}
+
+	if threadID, _ := criteria[SourceMetaExternalThreadID].(string); threadID != "" {
+		return taskMeta[SourceMetaExternalThreadID] == threadID
+	}
+	return false
+}
+
+// HasExternalID проверяет, принимала ли задача внешнюю запись
+func (t *Task) HasExternalID(externalID string) bool {
+	return t.SourceMeta[SourceMetaExternalID] == externalID ||
+		slices.Contains(sourceMetaStrings(t.SourceMeta[SourceMetaExternalIDs]), externalID)
+}
+
+// AddExternalID запоминает внешний ID записи, дополнившей задачу
+func (t *Task) AddExternalID(externalID string) {
+	if t.HasExternalID(externalID) {
+		return
+	}
+
+	if t.SourceMeta == nil {
+		t.SourceMeta = make(map[string]interface{})
+	}
+	appendSourceMetaString(t.SourceMeta, SourceMetaExternalIDs, externalID)
+	t.UpdatedAt = time.Now()
+}
+
+// sourceMetaStrings читает список строк SourceMeta, в том числе прочитанный из JSON
+func sourceMetaStrings(value interface{}) []string {
+	switch values := value.(type) {
+	case []string:
+		return values
+	case []interface{}:
+		result := make([]string, 0, len(values))
+		for _, item := range values {
+			if text, ok := item.(string); ok {
+				result = append(result, text)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// appendSourceMetaString добавляет значение в список, всегда создавая новый срез
+func appendSourceMetaString(meta map[string]interface{}, key, value string) {
+	values := sourceMetaStrings(meta[key])
+	if slices.Contains(values, value) {
+		return
+	}
+	meta[key] = append(slices.Clone(values), value)
+}
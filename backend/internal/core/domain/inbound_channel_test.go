@@ -0,0 +1,167 @@
+// internal/core/domain/inbound_channel_test.go
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeTestPayload(t *testing.T, payload string) interface{} {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(payload)))
+	decoder.UseNumber()
+	var document interface{}
+	require.NoError(t, decoder.Decode(&document))
+	return document
+}
+
+func TestJSONPath(t *testing.T) {
+	document := decodeTestPayload(t, `{
+		"ticket": {"id": 9007199254740993, "title": "Не работает вход"},
+		"requester": {"emails": ["first@example.com", "second@example.com"]},
+		"labels": [{"name": "vip"}, {"name": "login"}],
+		"fields": {"Severity Level": "P1", "urgent": true}
+	}`)
+
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"$.ticket.id", []string{"9007199254740993"}},
+		{"$['ticket']['title']", []string{"Не работает вход"}},
+		{"$.requester.emails[0]", []string{"first@example.com"}},
+		{"$.requester.emails[-1]", []string{"second@example.com"}},
+		{"$.labels[*].name", []string{"vip", "login"}},
+		{`$.fields["Severity Level"]`, []string{"P1"}},
+		{"$.fields.urgent", []string{"true"}},
+		{"$.missing.key", nil},
+	}
+
+	for _, tc := range cases {
+		path, err := ParseJSONPath(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, path.Strings(document), tc.expr)
+	}
+
+	path, err := ParseJSONPath("$.requester.emails")
+	require.NoError(t, err)
+	first, found := path.FirstString(document)
+	assert.False(t, found, "array is not a scalar")
+	assert.Empty(t, first)
+	assert.Equal(t, []string{"first@example.com", "second@example.com"}, path.Strings(document))
+
+	for _, invalid := range []string{"ticket.id", "$..id", "$.a[", "$.a[?(@.x)]", "$.", "$a"} {
+		_, err := ParseJSONPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// testSecret секрет подписи тестовых каналов
+const testSecret = "channel-secret-0123456789"
+
+func TestInboundChannel_Extract(t *testing.T) {
+	channel, err := NewInboundChannel("crm", "CRM", SourceAPI, testSecret, InboundMapping{
+		Subject:          "$.ticket.title",
+		Description:      "$.ticket.body",
+		CustomerEmail:    "$.requester.email",
+		CustomerName:     "$.requester.name",
+		Priority:         "$.ticket.severity",
+		Tags:             "$.ticket.labels",
+		ExternalID:       "$.event_id",
+		ExternalThreadID: "$.ticket.id",
+	})
+	require.NoError(t, err)
+	channel.PriorityMap = map[string]Priority{"p1": PriorityCritical}
+	channel.DefaultTags = []string{"crm"}
+
+	item, err := channel.Extract(decodeTestPayload(t, `{
+		"event_id": 42,
+		"ticket": {"id": "T-7", "title": " Не работает вход ", "body": "Ошибка 500", "severity": "P1", "labels": ["vip", "crm"]},
+		"requester": {"email": "client@example.com", "name": "Иван"}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "42", item.ExternalID)
+	assert.Equal(t, "T-7", item.ExternalThreadID)
+	assert.Equal(t, "Не работает вход", item.Subject)
+	assert.Equal(t, "Ошибка 500", item.Description)
+	assert.Equal(t, "client@example.com", item.CustomerEmail)
+	assert.Equal(t, "Иван", item.CustomerName)
+	assert.Equal(t, PriorityCritical, item.Priority)
+	assert.Equal(t, []string{"crm", "vip"}, item.Tags)
+
+	// Известный приоритет без таблицы, неизвестный - по умолчанию, метки через запятую
+	item, err = channel.Extract(decodeTestPayload(t, `{
+		"event_id": "e-2", "ticket": {"title": "Вопрос", "severity": "HIGH", "labels": "billing, crm"},
+		"requester": {"email": "client@example.com"}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, PriorityHigh, item.Priority)
+	assert.Equal(t, []string{"crm", "billing"}, item.Tags)
+
+	item, err = channel.Extract(decodeTestPayload(t, `{
+		"event_id": "e-3", "ticket": {"title": "Вопрос", "severity": "whenever"},
+		"requester": {"email": "client@example.com"}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, PriorityMedium, item.Priority)
+
+	_, err = channel.Extract(decodeTestPayload(t, `{"ticket": {"title": "Без ID"}, "requester": {"email": "client@example.com"}}`))
+	assert.ErrorIs(t, err, ErrInboundPayloadInvalid)
+	_, err = channel.Extract(decodeTestPayload(t, `{"event_id": "e-4", "ticket": {"title": "Тема"}, "requester": {"email": "not-an-email"}}`))
+	assert.ErrorIs(t, err, ErrInboundPayloadInvalid)
+}
+
+func TestInboundChannel_Validate(t *testing.T) {
+	mapping := InboundMapping{Subject: "$.subject", CustomerEmail: "$.email", ExternalID: "$.id"}
+
+	_, err := NewInboundChannel("Bad Key", "Канал", SourceAPI, testSecret, mapping)
+	assert.Error(t, err)
+	_, err = NewInboundChannel(TelegramChannelKey, "Telegram", SourceAPI, testSecret, mapping)
+	assert.Error(t, err, "key is reserved for the Telegram channel")
+	_, err = NewInboundChannel("forms", "Формы", SourceEmail, testSecret, mapping)
+	assert.Error(t, err)
+	_, err = NewInboundChannel("forms", "Формы", SourceWebForm, testSecret, InboundMapping{Subject: "$.subject", CustomerEmail: "$.email"})
+	assert.Error(t, err, "external ID mapping is required")
+	_, err = NewInboundChannel("forms", "Формы", SourceWebForm, testSecret, InboundMapping{Subject: "subject", CustomerEmail: "$.email", ExternalID: "$.id"})
+	assert.Error(t, err)
+
+	channel, err := NewInboundChannel("forms", "Формы", "", testSecret, mapping)
+	require.NoError(t, err)
+	assert.Equal(t, SourceAPI, channel.Source)
+
+	channel.Secret = "short"
+	assert.Error(t, channel.Validate())
+	channel.Secret = ""
+	assert.Error(t, channel.Validate(), "secret is required")
+	channel.Secret = testSecret
+	channel.PriorityMap = map[string]Priority{"p1": "urgent"}
+	assert.Error(t, channel.Validate())
+}
+
+func TestMatchesInboundSourceMeta(t *testing.T) {
+	channel, err := NewInboundChannel("crm", "CRM", SourceAPI, testSecret, InboundMapping{Subject: "$.s", CustomerEmail: "$.e", ExternalID: "$.id"})
+	require.NoError(t, err)
+
+	task := &Task{SourceMeta: channel.SourceMeta(&InboundItem{ExternalID: "e-1", ExternalThreadID: "T-7"})}
+	task.AddExternalID("e-2")
+	task.AddExternalID("e-2")
+	assert.Equal(t, []string{"e-1", "e-2"}, task.SourceMeta[SourceMetaExternalIDs])
+
+	assert.True(t, MatchesInboundSourceMeta(task.SourceMeta, InboundExternalIDCriteria("crm", "e-1")))
+	assert.True(t, MatchesInboundSourceMeta(task.SourceMeta, InboundExternalIDCriteria("crm", "e-2")))
+	assert.False(t, MatchesInboundSourceMeta(task.SourceMeta, InboundExternalIDCriteria("crm", "e-3")))
+	assert.False(t, MatchesInboundSourceMeta(task.SourceMeta, InboundExternalIDCriteria("forms", "e-1")))
+	assert.True(t, MatchesInboundSourceMeta(task.SourceMeta, InboundThreadCriteria("crm", "T-7")))
+	assert.False(t, MatchesInboundSourceMeta(task.SourceMeta, InboundThreadCriteria("crm", "T-8")))
+
+	// Мета-данные, прочитанные из JSON
+	decoded := map[string]interface{}{
+		SourceMetaChannel:     "crm",
+		SourceMetaExternalID:  "e-1",
+		SourceMetaExternalIDs: []interface{}{"e-1", "e-5"},
+	}
+	assert.True(t, MatchesInboundSourceMeta(decoded, InboundExternalIDCriteria("crm", "e-5")))
+}
@@ -0,0 +1,29 @@
+// internal/core/domain/inbound_delivery.go
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInboundDeliveryInProgress та же внешняя запись обрабатывается параллельной доставкой
+var ErrInboundDeliveryInProgress = errors.New("inbound record is being processed")
+
+// InboundDelivery принятая внешняя запись входящего канала.
+// Пока запись обрабатывается, TaskID пуст, и запись служит блокировкой ключа
+type InboundDelivery struct {
+	Key       string // SHA-256 канала и внешнего ID записи
+	TaskID    string // Задача, принявшая запись
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Accepted сообщает, принята ли запись задачей
+func (d *InboundDelivery) Accepted() bool {
+	return d.TaskID != ""
+}
+
+// Expired сообщает, истек ли срок хранения записи (или блокировки обработки)
+func (d *InboundDelivery) Expired(now time.Time) bool {
+	return !now.Before(d.ExpiresAt)
+}
@@ -0,0 +1,187 @@
+// internal/core/domain/jsonpath.go
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath скомпилированное выражение JSONPath для извлечения значений из входящих payload.
+// Поддерживается подмножество: $, .key, ['key'], [0], [-1] (с конца), [*] и .*
+type JSONPath struct {
+	expr  string
+	steps []jsonPathStep
+}
+
+type jsonPathStepKind int
+
+const (
+	jsonPathKey jsonPathStepKind = iota
+	jsonPathIndex
+	jsonPathWildcard
+)
+
+type jsonPathStep struct {
+	kind  jsonPathStepKind
+	key   string
+	index int
+}
+
+// ParseJSONPath разбирает выражение JSONPath
+func ParseJSONPath(expr string) (JSONPath, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "$") {
+		return JSONPath{}, fmt.Errorf("invalid JSONPath %q: must start with $", expr)
+	}
+
+	path := JSONPath{expr: expr}
+	for i := 1; i < len(expr); {
+		switch expr[i] {
+		case '.':
+			i++
+			if i < len(expr) && expr[i] == '.' {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: recursive descent is not supported", expr)
+			}
+			if i < len(expr) && expr[i] == '*' {
+				path.steps = append(path.steps, jsonPathStep{kind: jsonPathWildcard})
+				i++
+				continue
+			}
+			start := i
+			for i < len(expr) && expr[i] != '.' && expr[i] != '[' {
+				i++
+			}
+			if start == i {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: empty key at position %d", expr, start)
+			}
+			path.steps = append(path.steps, jsonPathStep{kind: jsonPathKey, key: expr[start:i]})
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: unclosed bracket", expr)
+			}
+			step, err := parseJSONPathBracket(expr[i+1 : i+end])
+			if err != nil {
+				return JSONPath{}, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+			}
+			path.steps = append(path.steps, step)
+			i += end + 1
+		default:
+			return JSONPath{}, fmt.Errorf("invalid JSONPath %q: unexpected %q at position %d", expr, expr[i], i)
+		}
+	}
+
+	return path, nil
+}
+
+func parseJSONPathBracket(content string) (jsonPathStep, error) {
+	content = strings.TrimSpace(content)
+	if content == "*" {
+		return jsonPathStep{kind: jsonPathWildcard}, nil
+	}
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		return jsonPathStep{kind: jsonPathKey, key: content[1 : len(content)-1]}, nil
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return jsonPathStep{}, fmt.Errorf("unsupported selector [%s]", content)
+	}
+	return jsonPathStep{kind: jsonPathIndex, index: index}, nil
+}
+
+// String возвращает исходное выражение
+func (p JSONPath) String() string {
+	return p.expr
+}
+
+// Select возвращает все значения документа, найденные по выражению.
+// Документ ожидается в виде результата json.Unmarshal в interface{}
+func (p JSONPath) Select(document interface{}) []interface{} {
+	nodes := []interface{}{document}
+	for _, step := range p.steps {
+		var next []interface{}
+		for _, node := range nodes {
+			switch value := node.(type) {
+			case map[string]interface{}:
+				switch step.kind {
+				case jsonPathKey:
+					if child, exists := value[step.key]; exists {
+						next = append(next, child)
+					}
+				case jsonPathWildcard:
+					keys := make([]string, 0, len(value))
+					for key := range value {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, value[key])
+					}
+				}
+			case []interface{}:
+				switch step.kind {
+				case jsonPathIndex:
+					index := step.index
+					if index < 0 {
+						index += len(value)
+					}
+					if index >= 0 && index < len(value) {
+						next = append(next, value[index])
+					}
+				case jsonPathWildcard:
+					next = append(next, value...)
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// FirstString возвращает первое скалярное значение как строку
+func (p JSONPath) FirstString(document interface{}) (string, bool) {
+	for _, value := range p.Select(document) {
+		if text, ok := jsonScalarString(value); ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// Strings возвращает все скалярные значения как строки; массивы разворачиваются на один уровень
+func (p JSONPath) Strings(document interface{}) []string {
+	var result []string
+	for _, value := range p.Select(document) {
+		if items, ok := value.([]interface{}); ok {
+			for _, item := range items {
+				if text, ok := jsonScalarString(item); ok {
+					result = append(result, text)
+				}
+			}
+			continue
+		}
+		if text, ok := jsonScalarString(value); ok {
+			result = append(result, text)
+		}
+	}
+	return result
+}
+
+// jsonScalarString приводит скалярное JSON-значение к строке; объекты, массивы и null не приводятся
+func jsonScalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,284 @@
+// internal/core/domain/listing.go
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ошибки параметров списков
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("invalid sort")
+)
+
+// SortField поле сортировки списка
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort разбирает сортировку вида "-priority,created_at": минус - по убыванию.
+// Допустимые поля перечислены в allowed; значение с точкой на конце ("custom_fields.")
+// допускает любое поле с этим префиксом
+func ParseSort(expr string, allowed ...string) ([]SortField, error) {
+	var fields []SortField
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field := SortField{Field: strings.TrimPrefix(part, "+")}
+		if name, ok := strings.CutPrefix(part, "-"); ok {
+			field = SortField{Field: name, Desc: true}
+		}
+		if !sortFieldAllowed(field.Field, allowed) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSort, field.Field)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func sortFieldAllowed(field string, allowed []string) bool {
+	for _, name := range allowed {
+		if prefix, ok := strings.CutSuffix(name, "."); ok {
+			if key, found := strings.CutPrefix(field, prefix+"."); found && key != "" {
+				return true
+			}
+			continue
+		}
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatSort записывает сортировку в виде, который принимает ParseSort
+func FormatSort(fields []SortField) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Field
+		if field.Desc {
+			parts[i] = "-" + field.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// TimeRange интервал времени с включенными границами; nil - без ограничения
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// IsZero проверяет, что интервал не ограничен
+func (r TimeRange) IsZero() bool {
+	return r.From == nil && r.To == nil
+}
+
+// Includes проверяет, что момент попадает в интервал. Пустой момент
+// (например, у нерешенной задачи нет времени решения) не попадает в ограниченный интервал
+func (r TimeRange) Includes(t *time.Time) bool {
+	if r.IsZero() {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	if r.From != nil && t.Before(*r.From) {
+		return false
+	}
+	return r.To == nil || !t.After(*r.To)
+}
+
+// PageCursor позиция в списке: ключи сортировки и ID последнего элемента страницы.
+// Клиенту передается в виде непрозрачной строки
+type PageCursor struct {
+	Sort string    `json:"s"`
+	Keys []*string `json:"k"`
+	ID   string    `json:"id"`
+}
+
+// Encode кодирует курсор в строку для ответа API
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor разбирает курсор и проверяет, что он получен для той же сортировки
+func DecodePageCursor(value string, sortFields []SortField) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != FormatSort(sortFields) || len(cursor.Keys) != len(sortFields) {
+		return nil, fmt.Errorf("%w: cursor was issued for another sort order", ErrInvalidCursor)
+	}
+	return &cursor, nil
+}
+
+// sortKeyTimeLayout формат ключа сортировки времени
+const sortKeyTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// SortKeyTime ключ сортировки времени: строки сравниваются в том же порядке, что и моменты
+func SortKeyTime(t time.Time) string {
+	return t.UTC().Format(sortKeyTimeLayout)
+}
+
+// ParseSortKeyTime восстанавливает момент времени из ключа курсора (см. SortKeyTime)
+func ParseSortKeyTime(key string) (time.Time, error) {
+	t, err := time.Parse(sortKeyTimeLayout, key)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+// optionalTimeKey ключ сортировки необязательного момента времени
+func optionalTimeKey(t *time.Time) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	return SortKeyTime(*t), true
+}
+
+// SortKeyNumber ключ сортировки числа: строки сравниваются в том же порядке, что и числа
+func SortKeyNumber(n float64) string {
+	bits := math.Float64bits(n)
+	if n < 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	return fmt.Sprintf("%016x", bits)
+}
+
+// Listing сортировка и постраничная выдача списка в памяти.
+// Key возвращает ключ сортировки поля (см. SortKeyTime, SortKeyNumber); элементы без
+// значения всегда идут в конце списка. При равных ключах порядок определяет ID
+type Listing[T any] struct {
+	Sort []SortField
+	Key  func(item *T, field string) (string, bool)
+	ID   func(item *T) string
+}
+
+// listingPosition ключи сортировки элемента
+type listingPosition struct {
+	keys []*string
+	id   string
+}
+
+func (l Listing[T]) position(item *T) listingPosition {
+	keys := make([]*string, len(l.Sort))
+	for i, field := range l.Sort {
+		if key, ok := l.Key(item, field.Field); ok {
+			keys[i] = &key
+		}
+	}
+	return listingPosition{keys: keys, id: l.ID(item)}
+}
+
+func (l Listing[T]) compare(a, b listingPosition) int {
+	for i, field := range l.Sort {
+		ka, kb := a.keys[i], b.keys[i]
+		switch {
+		case ka == nil && kb == nil:
+			continue
+		case ka == nil:
+			return 1
+		case kb == nil:
+			return -1
+		}
+		if c := strings.Compare(*ka, *kb); c != 0 {
+			if field.Desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return strings.Compare(a.id, b.id)
+}
+
+// SortItems сортирует элементы на месте
+func (l Listing[T]) SortItems(items []T) {
+	positions := make([]listingPosition, len(items))
+	for i := range items {
+		positions[i] = l.position(&items[i])
+	}
+	sort.Sort(listingSorter[T]{items: items, positions: positions, listing: l})
+}
+
+type listingSorter[T any] struct {
+	items     []T
+	positions []listingPosition
+	listing   Listing[T]
+}
+
+func (s listingSorter[T]) Len() int { return len(s.items) }
+func (s listingSorter[T]) Less(i, j int) bool {
+	return s.listing.compare(s.positions[i], s.positions[j]) < 0
+}
+func (s listingSorter[T]) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.positions[i], s.positions[j] = s.positions[j], s.positions[i]
+}
+
+// Seek возвращает индекс первого элемента отсортированного списка после курсора
+func (l Listing[T]) Seek(items []T, cursor string) (int, error) {
+	after, err := DecodePageCursor(cursor, l.Sort)
+	if err != nil {
+		return 0, err
+	}
+	position := listingPosition{keys: after.Keys, id: after.ID}
+	return sort.Search(len(items), func(i int) bool {
+		return l.compare(l.position(&items[i]), position) > 0
+	}), nil
+}
+
+// Page возвращает страницу отсортированного списка, начиная с индекса start;
+// limit <= 0 - до конца списка. Курсор следующей страницы пустой, если страница последняя
+func (l Listing[T]) Page(items []T, start, limit int) ([]T, string) {
+	start = max(0, min(start, len(items)))
+	end := len(items)
+	if limit > 0 {
+		end = min(start+limit, len(items))
+	}
+
+	page := items[start:end]
+	if end >= len(items) || len(page) == 0 {
+		return page, ""
+	}
+	return page, l.Cursor(&page[len(page)-1])
+}
+
+// Window возвращает страницу отсортированного списка после курсора, а без курсора -
+// со смещения offset, и индекс ее первого элемента
+func (l Listing[T]) Window(items []T, cursor string, offset, limit int) ([]T, int, error) {
+	start := max(0, min(offset, len(items)))
+	if cursor != "" {
+		var err error
+		if start, err = l.Seek(items, cursor); err != nil {
+			return nil, 0, err
+		}
+	}
+	page, _ := l.Page(items, start, limit)
+	return page, start, nil
+}
+
+// Cursor возвращает курсор страницы, следующей за элементом
+func (l Listing[T]) Cursor(item *T) string {
+	position := l.position(item)
+	return PageCursor{Sort: FormatSort(l.Sort), Keys: position.keys, ID: position.id}.Encode()
+}
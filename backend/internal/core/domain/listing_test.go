@@ -0,0 +1,59 @@
+// internal/core/domain/listing_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	fields, err := ParseSort("-priority, created_at,custom_fields.estimate", TaskSortFields...)
+	require.NoError(t, err)
+	assert.Equal(t, []SortField{
+		{Field: "priority", Desc: true},
+		{Field: "created_at"},
+		{Field: "custom_fields.estimate"},
+	}, fields)
+	assert.Equal(t, "-priority,created_at,custom_fields.estimate", FormatSort(fields))
+
+	_, err = ParseSort("password_hash", TaskSortFields...)
+	assert.ErrorIs(t, err, ErrInvalidSort)
+	_, err = ParseSort("custom_fields.", TaskSortFields...)
+	assert.ErrorIs(t, err, ErrInvalidSort)
+}
+
+func TestSortKeyNumber_PreservesOrder(t *testing.T) {
+	values := []float64{-100, -1.5, 0, 0.25, 3, 1e9}
+	for i := 1; i < len(values); i++ {
+		assert.Less(t, SortKeyNumber(values[i-1]), SortKeyNumber(values[i]))
+	}
+}
+
+func TestListing_PageMissingValuesLast(t *testing.T) {
+	due := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	tasks := []Task{
+		{ID: "t-1"},
+		{ID: "t-2", DueDate: &due},
+		{ID: "t-3"},
+	}
+	listing := TaskListing([]SortField{{Field: "due_date", Desc: true}})
+	listing.SortItems(tasks)
+	assert.Equal(t, "t-2", tasks[0].ID)
+
+	page, next := listing.Page(tasks, 0, 2)
+	require.Len(t, page, 2)
+	require.NotEmpty(t, next)
+
+	start, err := listing.Seek(tasks, next)
+	require.NoError(t, err)
+	page, next = listing.Page(tasks, start, 2)
+	require.Len(t, page, 1)
+	assert.Equal(t, "t-3", page[0].ID)
+	assert.Empty(t, next)
+
+	_, err = TaskListing(DefaultTaskSort).Seek(tasks, PageCursor{Sort: "due_date", ID: "t-1"}.Encode())
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,139 @@
+// internal/core/domain/permission.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrForbidden у пользователя нет права на операцию
+var ErrForbidden = errors.New("forbidden")
+
+// NewForbiddenError отказ в доступе с указанием недостающего права
+func NewForbiddenError(permission Permission) error {
+	return fmt.Errorf("%w: %s required", ErrForbidden, permission)
+}
+
+// Permission право на группу операций
+type Permission string
+
+const (
+	PermissionTasksRead       Permission = "tasks:read"       // Просмотр задач
+	PermissionTasksWrite      Permission = "tasks:write"      // Создание задач, ответы, смена статуса и назначение
+	PermissionTasksDelete     Permission = "tasks:delete"     // Удаление задач
+	PermissionTasksBulk       Permission = "tasks:bulk"       // Массовые операции и автоматизация
+	PermissionTasksAll        Permission = "tasks:all"        // Доступ к задачам всех категорий
+	PermissionMessagesPrivate Permission = "messages:private" // Просмотр внутренних заметок
+	PermissionCustomersRead   Permission = "customers:read"
+	PermissionCustomersWrite  Permission = "customers:write"
+	PermissionCustomersDelete Permission = "customers:delete"
+	PermissionReportsRead     Permission = "reports:read"    // Отчеты, статистика и журнал аудита
+	PermissionSettingsManage  Permission = "settings:manage" // Пользовательские поля, шаблоны ответов, повторяющиеся задачи
+	PermissionChannelsManage  Permission = "channels:manage" // Входящие каналы, вебхуки и интеграции
+	PermissionUsersManage     Permission = "users:manage"    // Управление пользователями
+)
+
+// RolePermissions права ролей. Каждая следующая роль включает права предыдущей,
+// кроме viewer: наблюдатель видит все задачи, но не внутренние заметки
+var RolePermissions = map[UserRole][]Permission{
+	UserRoleViewer: {
+		PermissionTasksRead,
+		PermissionTasksAll,
+		PermissionCustomersRead,
+	},
+	UserRoleOperator: {
+		PermissionTasksRead,
+		PermissionTasksWrite,
+		PermissionMessagesPrivate,
+		PermissionCustomersRead,
+		PermissionCustomersWrite,
+	},
+	UserRoleManager: {
+		PermissionTasksRead,
+		PermissionTasksWrite,
+		PermissionTasksDelete,
+		PermissionTasksBulk,
+		PermissionTasksAll,
+		PermissionMessagesPrivate,
+		PermissionCustomersRead,
+		PermissionCustomersWrite,
+		PermissionCustomersDelete,
+		PermissionReportsRead,
+		PermissionSettingsManage,
+	},
+	UserRoleAdmin: {
+		PermissionTasksRead,
+		PermissionTasksWrite,
+		PermissionTasksDelete,
+		PermissionTasksBulk,
+		PermissionTasksAll,
+		PermissionMessagesPrivate,
+		PermissionCustomersRead,
+		PermissionCustomersWrite,
+		PermissionCustomersDelete,
+		PermissionReportsRead,
+		PermissionSettingsManage,
+		PermissionChannelsManage,
+		PermissionUsersManage,
+	},
+}
+
+// IsValid проверяет, что роль известна
+func (r UserRole) IsValid() bool {
+	_, ok := RolePermissions[r]
+	return ok
+}
+
+// Has проверяет наличие права у роли
+func (r UserRole) Has(permission Permission) bool {
+	return slices.Contains(RolePermissions[r], permission)
+}
+
+// TaskScope ограничение видимости задач оператора: задачи его категорий
+// и задачи, в которых он назначен, является автором или участником
+type TaskScope struct {
+	UserID     string
+	Categories []string
+}
+
+// TaskScopeFor возвращает ограничение видимости для пользователя; nil - без ограничений
+func TaskScopeFor(user *User) *TaskScope {
+	if user.Role.Has(PermissionTasksAll) || len(user.Categories) == 0 {
+		return nil
+	}
+	return &TaskScope{UserID: user.ID, Categories: user.Categories}
+}
+
+// Includes проверяет, видна ли задача в пределах ограничения
+func (s *TaskScope) Includes(task *Task) bool {
+	if s == nil {
+		return true
+	}
+	if slices.Contains(s.Categories, task.Category) {
+		return true
+	}
+	// Пустое ограничение (анонимный контекст) не включает даже задачи без исполнителя
+	if s.UserID == "" {
+		return false
+	}
+	return task.AssigneeID == s.UserID ||
+		task.ReporterID == s.UserID ||
+		task.FindParticipant(s.UserID) != nil
+}
+
+// IsPrivate внутренняя заметка, не предназначенная клиенту
+func (m Message) IsPrivate() bool {
+	return m.Type == MessageTypeInternal
+}
+
+// CustomerVisibleMessages сообщения задачи без внутренних заметок
+func (t *Task) CustomerVisibleMessages() []Message {
+	messages := make([]Message, 0, len(t.Messages))
+	for _, message := range t.Messages {
+		if !message.IsPrivate() {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,135 @@
+// internal/core/domain/recurring_task.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RecurringTask правило периодического создания внутренних задач
+// (например, ежемесячная проверка резервных копий)
+type RecurringTask struct {
+	ID          string
+	Name        string
+	Schedule    string // Cron (5 полей, @daily, ...) или RRULE
+	Timezone    string // IANA часовой пояс расписания; пусто - UTC
+	Subject     string
+	Description string
+	Priority    Priority
+	Category    string
+	Tags        []string
+	AssigneeID  string // Пусто - задача создается неназначенной
+	ReporterID  string
+	Enabled     bool
+	NextRunAt   *time.Time // Пусто - у расписания больше нет срабатываний
+	LastRunAt   *time.Time
+	LastTaskID  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewRecurringTask создает правило и вычисляет первое срабатывание
+func NewRecurringTask(name, schedule, timezone, subject, description, reporterID string) (*RecurringTask, error) {
+	now := time.Now()
+	rt := &RecurringTask{
+		ID:          GenerateRecurringTaskID(),
+		Name:        name,
+		Schedule:    schedule,
+		Timezone:    timezone,
+		Subject:     subject,
+		Description: description,
+		Priority:    PriorityMedium,
+		Tags:        []string{},
+		ReporterID:  reporterID,
+		Enabled:     true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := rt.Validate(); err != nil {
+		return nil, err
+	}
+	if err := rt.Reschedule(now); err != nil {
+		return nil, err
+	}
+
+	return rt, nil
+}
+
+// Validate проверяет корректность правила и его расписания
+func (r *RecurringTask) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("recurring task name is required")
+	}
+	if strings.TrimSpace(r.Subject) == "" {
+		return errors.New("recurring task subject is required")
+	}
+	if strings.TrimSpace(r.Description) == "" {
+		return errors.New("recurring task description is required")
+	}
+	if r.ReporterID == "" {
+		return errors.New("recurring task reporter is required")
+	}
+	if _, err := r.ParsedSchedule(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParsedSchedule разбирает расписание в часовом поясе правила.
+// Точка отсчета RRULE - момент создания правила
+func (r *RecurringTask) ParsedSchedule() (Schedule, error) {
+	loc := time.UTC
+	if r.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(r.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", r.Timezone, err)
+		}
+	}
+
+	schedule, err := ParseSchedule(r.Schedule, r.CreatedAt.In(loc))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+// Reschedule вычисляет следующее срабатывание после момента after
+func (r *RecurringTask) Reschedule(after time.Time) error {
+	schedule, err := r.ParsedSchedule()
+	if err != nil {
+		return err
+	}
+
+	next := schedule.Next(after)
+	if next.IsZero() {
+		r.NextRunAt = nil
+	} else {
+		next = next.UTC()
+		r.NextRunAt = &next
+	}
+	return nil
+}
+
+// IsDue проверяет, пора ли создавать задачу по правилу
+func (r *RecurringTask) IsDue(now time.Time) bool {
+	return r.Enabled && r.NextRunAt != nil && !r.NextRunAt.After(now)
+}
+
+// MarkRun фиксирует созданную задачу и переносит следующее срабатывание.
+// Пропущенные за время простоя срабатывания не догоняются: создается одна задача,
+// а следующее срабатывание вычисляется от текущего момента
+func (r *RecurringTask) MarkRun(taskID string, now time.Time) error {
+	r.LastRunAt = &now
+	r.LastTaskID = taskID
+	r.UpdatedAt = now
+	return r.Reschedule(now)
+}
+
+// GenerateRecurringTaskID генерирует ID для правила повторяющейся задачи
+func GenerateRecurringTaskID() string {
+	return fmt.Sprintf("RT-%d", time.Now().UnixNano())
+}
@@ -0,0 +1,223 @@
+// internal/core/domain/reply_template.go
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateLanguage язык варианта шаблона ответа
+type TemplateLanguage string
+
+const (
+	TemplateLanguageRU TemplateLanguage = "ru" // Русский (язык по умолчанию)
+	TemplateLanguageEN TemplateLanguage = "en" // Английский
+)
+
+// DefaultTemplateLanguage язык, используемый при отсутствии запрошенного варианта
+const DefaultTemplateLanguage = TemplateLanguageRU
+
+// ReplyTemplateDueLayout формат срока SLA в тексте ответа
+const ReplyTemplateDueLayout = "02.01.2006 15:04"
+
+// IsValid проверяет, что язык поддерживается
+func (l TemplateLanguage) IsValid() bool {
+	return l == TemplateLanguageRU || l == TemplateLanguageEN
+}
+
+// MacroActions действия, выполняемые вместе с отправкой ответа по шаблону
+type MacroActions struct {
+	Status     TaskStatus // Пусто - статус не меняется
+	Tags       []string   // Теги, добавляемые к задаче
+	AssigneeID string     // Пусто - исполнитель не меняется
+}
+
+// IsEmpty проверяет, что макрос не содержит действий
+func (a MacroActions) IsEmpty() bool {
+	return a.Status == "" && len(a.Tags) == 0 && a.AssigneeID == ""
+}
+
+// ReplyTemplate шаблон ответа оператора (canned response).
+// Тело шаблона записывается в синтаксисе text/template, например:
+// "Здравствуйте, {{.CustomerName}}! Заявка {{.TaskID}} ..."
+type ReplyTemplate struct {
+	ID        string
+	Name      string
+	Category  string                      // Библиотека шаблонов; пусто - общий шаблон для всех категорий
+	Variants  map[TemplateLanguage]string // Тело шаблона на разных языках
+	Actions   MacroActions                // Действия макроса (применяются в режиме macro)
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReplyTemplateData переменные, доступные в теле шаблона
+type ReplyTemplateData struct {
+	TaskID        string
+	Subject       string
+	Status        string
+	Priority      string
+	Category      string
+	CustomerName  string
+	CustomerEmail string
+	SLADue        string // Срок выполнения задачи в формате ReplyTemplateDueLayout; пусто, если срок не задан
+	OperatorName  string
+	OperatorEmail string
+	Signature     string // Подпись оператора
+}
+
+// NewReplyTemplate создает шаблон ответа
+func NewReplyTemplate(name, category string, variants map[TemplateLanguage]string, createdBy string) (*ReplyTemplate, error) {
+	now := time.Now()
+	tpl := &ReplyTemplate{
+		ID:        GenerateReplyTemplateID(),
+		Name:      name,
+		Category:  category,
+		Variants:  variants,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := tpl.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tpl, nil
+}
+
+// Validate проверяет корректность шаблона и синтаксис всех языковых вариантов
+func (t *ReplyTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("template name is required")
+	}
+	if len(t.Variants) == 0 {
+		return errors.New("template must have at least one language variant")
+	}
+
+	for lang, body := range t.Variants {
+		if !lang.IsValid() {
+			return fmt.Errorf("unsupported template language: %s", lang)
+		}
+		if strings.TrimSpace(body) == "" {
+			return fmt.Errorf("template body for language %s is empty", lang)
+		}
+		tmpl, err := parseReplyTemplate(t.Name, body)
+		if err != nil {
+			return fmt.Errorf("invalid template body for language %s: %w", lang, err)
+		}
+		// Пробный рендеринг выявляет обращения к несуществующим переменным
+		if err := tmpl.Execute(io.Discard, ReplyTemplateData{}); err != nil {
+			return fmt.Errorf("invalid template body for language %s: %w", lang, err)
+		}
+	}
+
+	if t.Actions.Status != "" && t.Actions.Status.DisplayName() == "" {
+		return fmt.Errorf("invalid macro status: %s", t.Actions.Status)
+	}
+
+	return nil
+}
+
+// Languages возвращает языки, для которых есть варианты шаблона
+func (t *ReplyTemplate) Languages() []TemplateLanguage {
+	languages := make([]TemplateLanguage, 0, len(t.Variants))
+	for lang := range t.Variants {
+		languages = append(languages, lang)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i] < languages[j]
+	})
+	return languages
+}
+
+// Variant выбирает вариант шаблона: запрошенный язык, затем язык по умолчанию,
+// затем любой доступный
+func (t *ReplyTemplate) Variant(lang TemplateLanguage) (TemplateLanguage, string) {
+	if body, ok := t.Variants[lang]; ok {
+		return lang, body
+	}
+	if body, ok := t.Variants[DefaultTemplateLanguage]; ok {
+		return DefaultTemplateLanguage, body
+	}
+	if languages := t.Languages(); len(languages) > 0 {
+		return languages[0], t.Variants[languages[0]]
+	}
+	return "", ""
+}
+
+// Render подставляет переменные в вариант шаблона для указанного языка
+func (t *ReplyTemplate) Render(lang TemplateLanguage, data ReplyTemplateData) (string, TemplateLanguage, error) {
+	usedLang, body := t.Variant(lang)
+	if body == "" {
+		return "", "", errors.New("template has no variants")
+	}
+
+	tmpl, err := parseReplyTemplate(t.Name, body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if data.Signature == "" {
+		data.Signature = DefaultSignature(usedLang, data.OperatorName)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return strings.TrimSpace(buf.String()), usedLang, nil
+}
+
+// DefaultSignature формирует подпись оператора, если она не задана в профиле
+func DefaultSignature(lang TemplateLanguage, operatorName string) string {
+	if lang == TemplateLanguageEN {
+		return fmt.Sprintf("Best regards,\n%s\nSupport team", operatorName)
+	}
+	return fmt.Sprintf("С уважением,\n%s\nСлужба поддержки", operatorName)
+}
+
+// NewReplyTemplateData собирает переменные шаблона из задачи, клиента и оператора.
+// Клиент и оператор могут отсутствовать
+func NewReplyTemplateData(task *Task, customer *Customer, operator *User) ReplyTemplateData {
+	data := ReplyTemplateData{
+		TaskID:   task.ID,
+		Subject:  task.Subject,
+		Status:   task.Status.DisplayName(),
+		Priority: string(task.Priority),
+		Category: task.Category,
+	}
+
+	if task.DueDate != nil {
+		data.SLADue = task.DueDate.Format(ReplyTemplateDueLayout)
+	}
+	if customer != nil {
+		data.CustomerName = customer.Name
+		data.CustomerEmail = customer.Email
+	}
+	if operator != nil {
+		data.OperatorName = operator.Name
+		data.OperatorEmail = operator.Email
+		data.Signature = operator.Signature
+	}
+
+	return data
+}
+
+// parseReplyTemplate разбирает тело шаблона; обращение к неизвестной переменной
+// приводит к ошибке при рендеринге
+func parseReplyTemplate(name, body string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(body)
+}
+
+// GenerateReplyTemplateID генерирует ID для шаблона ответа
+func GenerateReplyTemplateID() string {
+	return fmt.Sprintf("TPL-%d", time.Now().UnixNano())
+}
@@ -0,0 +1,82 @@
+// internal/core/domain/reply_template_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReplyTemplate(t *testing.T) {
+	tpl, err := NewReplyTemplate("Приветствие", "billing", map[TemplateLanguage]string{
+		TemplateLanguageRU: "Здравствуйте, {{.CustomerName}}!",
+	}, "user-1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, tpl.ID)
+	assert.Equal(t, []TemplateLanguage{TemplateLanguageRU}, tpl.Languages())
+
+	_, err = NewReplyTemplate("", "", map[TemplateLanguage]string{TemplateLanguageRU: "text"}, "user-1")
+	assert.Error(t, err, "name is required")
+
+	_, err = NewReplyTemplate("Empty", "", nil, "user-1")
+	assert.Error(t, err, "at least one variant is required")
+
+	_, err = NewReplyTemplate("German", "", map[TemplateLanguage]string{"de": "Hallo"}, "user-1")
+	assert.Error(t, err, "unsupported language")
+
+	_, err = NewReplyTemplate("Broken", "", map[TemplateLanguage]string{TemplateLanguageRU: "{{.CustomerName"}, "user-1")
+	assert.Error(t, err, "syntax error")
+
+	_, err = NewReplyTemplate("Unknown", "", map[TemplateLanguage]string{TemplateLanguageRU: "{{.Unknown}}"}, "user-1")
+	assert.Error(t, err, "unknown variable")
+
+	tpl, err = NewReplyTemplate("Macro", "", map[TemplateLanguage]string{TemplateLanguageRU: "text"}, "user-1")
+	require.NoError(t, err)
+	tpl.Actions.Status = "unknown"
+	assert.Error(t, tpl.Validate(), "invalid macro status")
+}
+
+func TestReplyTemplate_Render(t *testing.T) {
+	tpl, err := NewReplyTemplate("Решено", "", map[TemplateLanguage]string{
+		TemplateLanguageRU: "Здравствуйте, {{.CustomerName}}! Заявка {{.TaskID}} решена до {{.SLADue}}.\n{{.Signature}}",
+		TemplateLanguageEN: "Hello {{.CustomerName}}, ticket {{.TaskID}} is resolved.\n{{.Signature}}",
+	}, "user-1")
+	require.NoError(t, err)
+
+	task, err := NewTask(TaskTypeInternal, "Subject", "Description", "user-1", nil)
+	require.NoError(t, err)
+	due := time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC)
+	task.DueDate = &due
+
+	customer := &Customer{Name: "Иван", Email: "ivan@example.com"}
+	operator := &User{Name: "Анна", Email: "anna@company.com"}
+	data := NewReplyTemplateData(task, customer, operator)
+
+	content, lang, err := tpl.Render(TemplateLanguageRU, data)
+	require.NoError(t, err)
+	assert.Equal(t, TemplateLanguageRU, lang)
+	assert.Contains(t, content, "Здравствуйте, Иван! Заявка "+task.ID+" решена до 15.03.2026 18:00.")
+	assert.Contains(t, content, "С уважением,\nАнна")
+
+	content, lang, err = tpl.Render(TemplateLanguageEN, data)
+	require.NoError(t, err)
+	assert.Equal(t, TemplateLanguageEN, lang)
+	assert.Contains(t, content, "Best regards,\nАнна")
+
+	// Подпись из профиля оператора имеет приоритет над подписью по умолчанию
+	operator.Signature = "Анна, 2-я линия"
+	content, _, err = tpl.Render(TemplateLanguageRU, NewReplyTemplateData(task, customer, operator))
+	require.NoError(t, err)
+	assert.Contains(t, content, "Анна, 2-я линия")
+
+	// Без варианта на запрошенном языке используется язык по умолчанию
+	ruOnly, err := NewReplyTemplate("Только ru", "", map[TemplateLanguage]string{
+		TemplateLanguageRU: "Заявка {{.TaskID}}",
+	}, "user-1")
+	require.NoError(t, err)
+	_, lang, err = ruOnly.Render(TemplateLanguageEN, data)
+	require.NoError(t, err)
+	assert.Equal(t, TemplateLanguageRU, lang)
+}
@@ -0,0 +1,105 @@
+// internal/core/domain/satisfaction.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// MinSatisfactionRating минимальная оценка опроса удовлетворенности
+	MinSatisfactionRating = 1
+	// MaxSatisfactionRating максимальная оценка опроса удовлетворенности
+	MaxSatisfactionRating = 5
+	// PositiveSatisfactionRating оценка, начиная с которой клиент считается удовлетворенным (CSAT)
+	PositiveSatisfactionRating = 4
+	// MaxSatisfactionCommentLength максимальная длина комментария к оценке
+	MaxSatisfactionCommentLength = 2000
+)
+
+var (
+	// ErrSurveyNotSent возвращается при попытке оценить задачу, по которой опрос не отправлялся
+	ErrSurveyNotSent = errors.New("satisfaction survey was not sent for this task")
+	// ErrInvalidSurveyToken возвращается для поддельной или просроченной ссылки опроса
+	ErrInvalidSurveyToken = errors.New("invalid or expired survey token")
+)
+
+// SatisfactionSurvey опрос удовлетворенности клиента после решения задачи
+type SatisfactionSurvey struct {
+	SentAt      time.Time
+	Rating      int    // 0 - клиент еще не ответил
+	Comment     string // Необязательный комментарий клиента
+	OperatorID  string // Исполнитель задачи на момент ответа
+	RespondedAt *time.Time
+}
+
+// IsAnswered проверяет, ответил ли клиент на опрос
+func (s *SatisfactionSurvey) IsAnswered() bool {
+	return s != nil && s.Rating > 0
+}
+
+// IsPositive проверяет, что клиент удовлетворен решением
+func (s *SatisfactionSurvey) IsPositive() bool {
+	return s.IsAnswered() && s.Rating >= PositiveSatisfactionRating
+}
+
+// ValidateSatisfactionRating проверяет оценку опроса
+func ValidateSatisfactionRating(rating int) error {
+	if rating < MinSatisfactionRating || rating > MaxSatisfactionRating {
+		return fmt.Errorf("rating must be between %d and %d", MinSatisfactionRating, MaxSatisfactionRating)
+	}
+	return nil
+}
+
+// MarkSurveySent фиксирует отправку опроса клиенту
+func (t *Task) MarkSurveySent(now time.Time) {
+	t.Satisfaction = &SatisfactionSurvey{SentAt: now}
+	t.UpdatedAt = now
+	t.addHistoryEvent(TaskEventSurveySent, "system", nil, nil, "Клиенту отправлен опрос удовлетворенности")
+}
+
+// RevertSurveySent отменяет отметку об отправке опроса sentAt, если письмо не ушло:
+// восстанавливается предыдущий опрос (при повторной отправке) или его отсутствие.
+// Отметка другой отправки и ответ клиента не затрагиваются
+func (t *Task) RevertSurveySent(sentAt time.Time, previous *SatisfactionSurvey) bool {
+	if t.Satisfaction == nil || !t.Satisfaction.SentAt.Equal(sentAt) || t.Satisfaction.IsAnswered() {
+		return false
+	}
+	t.Satisfaction = previous
+	t.UpdatedAt = time.Now()
+	t.addHistoryEvent(TaskEventSurveyFailed, "system", nil, nil, "Не удалось отправить клиенту опрос удовлетворенности")
+	return true
+}
+
+// RateSatisfaction записывает ответ клиента на опрос. Повторный ответ заменяет
+// предыдущий: клиент может сначала оценить в один клик, а затем добавить комментарий.
+// Пустой комментарий не затирает ранее оставленный
+func (t *Task) RateSatisfaction(rating int, comment string, customerID string) error {
+	if t.Satisfaction == nil {
+		return ErrSurveyNotSent
+	}
+	if err := ValidateSatisfactionRating(rating); err != nil {
+		return err
+	}
+
+	comment = strings.TrimSpace(comment)
+	if len(comment) > MaxSatisfactionCommentLength {
+		return fmt.Errorf("comment must not exceed %d characters", MaxSatisfactionCommentLength)
+	}
+
+	now := time.Now()
+	oldRating := t.Satisfaction.Rating
+	t.Satisfaction.Rating = rating
+	if comment != "" {
+		t.Satisfaction.Comment = comment
+	}
+	t.Satisfaction.OperatorID = t.AssigneeID
+	t.Satisfaction.RespondedAt = &now
+	t.UpdatedAt = now
+
+	message := fmt.Sprintf("Клиент оценил решение: %d из %d", rating, MaxSatisfactionRating)
+	t.addHistoryEvent(TaskEventSatisfactionRated, customerID, oldRating, rating, message)
+	return nil
+}
@@ -0,0 +1,61 @@
+// internal/core/domain/satisfaction_test.go
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSatisfaction(t *testing.T) {
+	newTask := func(t *testing.T) *Task {
+		task, err := NewSupportTask("Вопрос", "Описание", "customer-1", "user-1", SourceEmail, nil)
+		require.NoError(t, err)
+		require.NoError(t, task.Assign("user-3", "user-1"))
+		return task
+	}
+
+	t.Run("rating requires sent survey", func(t *testing.T) {
+		task := newTask(t)
+		assert.ErrorIs(t, task.RateSatisfaction(5, "", "customer-1"), ErrSurveyNotSent)
+	})
+
+	t.Run("rating is recorded with operator", func(t *testing.T) {
+		task := newTask(t)
+		task.MarkSurveySent(time.Now())
+		assert.False(t, task.Satisfaction.IsAnswered())
+
+		require.NoError(t, task.RateSatisfaction(4, "Быстро помогли", "customer-1"))
+		assert.True(t, task.Satisfaction.IsAnswered())
+		assert.True(t, task.Satisfaction.IsPositive())
+		assert.Equal(t, "user-3", task.Satisfaction.OperatorID)
+		assert.NotNil(t, task.Satisfaction.RespondedAt)
+
+		last := task.History[len(task.History)-1]
+		assert.Equal(t, "satisfaction_rated", last.Type)
+		assert.Equal(t, "customer-1", last.UserID)
+	})
+
+	t.Run("repeated answer keeps comment", func(t *testing.T) {
+		task := newTask(t)
+		task.MarkSurveySent(time.Now())
+
+		require.NoError(t, task.RateSatisfaction(5, "Спасибо", "customer-1"))
+		require.NoError(t, task.RateSatisfaction(2, "", "customer-1"))
+		assert.Equal(t, 2, task.Satisfaction.Rating)
+		assert.Equal(t, "Спасибо", task.Satisfaction.Comment)
+		assert.False(t, task.Satisfaction.IsPositive())
+	})
+
+	t.Run("invalid rating and comment", func(t *testing.T) {
+		task := newTask(t)
+		task.MarkSurveySent(time.Now())
+
+		assert.Error(t, task.RateSatisfaction(0, "", "customer-1"))
+		assert.Error(t, task.RateSatisfaction(6, "", "customer-1"))
+		assert.Error(t, task.RateSatisfaction(3, strings.Repeat("a", MaxSatisfactionCommentLength+1), "customer-1"))
+	})
+}
@@ -0,0 +1,385 @@
+// internal/core/domain/schedule.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleSearchYears горизонт поиска следующего срабатывания расписания
+const scheduleSearchYears = 5
+
+// Schedule расписание повторяющегося события
+type Schedule interface {
+	// Next возвращает ближайшее срабатывание строго после after;
+	// нулевое время, если срабатываний больше нет
+	Next(after time.Time) time.Time
+}
+
+// ParseSchedule разбирает расписание в формате cron (5 полей или @daily/@weekly/...)
+// либо RRULE (RFC 5545, подмножество: FREQ, INTERVAL, BYDAY, BYMONTHDAY, BYHOUR, BYMINUTE).
+// start задает часовой пояс и точку отсчета для RRULE
+func ParseSchedule(expr string, start time.Time) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errors.New("schedule expression is required")
+	}
+
+	upper := strings.ToUpper(expr)
+	if strings.HasPrefix(upper, "RRULE:") || strings.Contains(upper, "FREQ=") {
+		return parseRRule(expr, start)
+	}
+	return parseCron(expr, start.Location())
+}
+
+// cronSchedule расписание в формате cron: минута час день месяц день_недели
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+	loc                           *time.Location
+}
+
+var cronAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+func parseCron(expr string, loc *time.Location) (*cronSchedule, error) {
+	if alias, ok := cronAliases[strings.ToLower(expr)]; ok {
+		expr = alias
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields", expr)
+	}
+
+	var (
+		schedule = &cronSchedule{loc: loc}
+		err      error
+	)
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid cron minute: %w", err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid cron hour: %w", err)
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid cron day of month: %w", err)
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid cron month: %w", err)
+	}
+	if schedule.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid cron day of week: %w", err)
+	}
+	// 7 - тоже воскресенье
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domAny = fields[2] == "*"
+	schedule.dowAny = fields[4] == "*"
+
+	return schedule, nil
+}
+
+// parseCronField разбирает поле cron: *, списки (1,2), диапазоны (1-5) и шаги (*/15, 1-10/2)
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			value, err := strconv.Atoi(part[idx+1:])
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			step = value
+			part = part[:idx]
+		}
+
+		from, to := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+			if to, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			value, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			from, to = value, value
+		}
+
+		if from < min || to > max || from > to {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next возвращает ближайшую минуту после after, подходящую под расписание
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(scheduleSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchesDay проверяет день: если заданы и день месяца, и день недели,
+// достаточно совпадения любого из них (как в классическом cron)
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// RRuleFrequency частота повторения RRULE
+type RRuleFrequency string
+
+const (
+	RRuleDaily   RRuleFrequency = "DAILY"
+	RRuleWeekly  RRuleFrequency = "WEEKLY"
+	RRuleMonthly RRuleFrequency = "MONTHLY"
+)
+
+// rruleSchedule расписание RRULE с точкой отсчета start
+type rruleSchedule struct {
+	freq       RRuleFrequency
+	interval   int
+	byDay      []time.Weekday
+	byMonthDay []int // Отрицательные значения считаются с конца месяца (-1 - последний день)
+	byHour     []int
+	byMinute   []int
+	start      time.Time
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+func parseRRule(expr string, start time.Time) (*rruleSchedule, error) {
+	body := expr
+	if strings.HasPrefix(strings.ToUpper(body), "RRULE:") {
+		body = body[len("RRULE:"):]
+	}
+
+	schedule := &rruleSchedule{interval: 1, start: start.Truncate(time.Minute)}
+
+	for _, part := range strings.Split(body, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		var err error
+		switch key {
+		case "FREQ":
+			schedule.freq = RRuleFrequency(value)
+		case "INTERVAL":
+			schedule.interval, err = strconv.Atoi(value)
+			if err == nil && schedule.interval <= 0 {
+				err = errors.New("must be positive")
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("invalid RRULE BYDAY value %q", day)
+				}
+				schedule.byDay = append(schedule.byDay, weekday)
+			}
+		case "BYMONTHDAY":
+			schedule.byMonthDay, err = parseRRuleInts(value, -31, 31)
+		case "BYHOUR":
+			schedule.byHour, err = parseRRuleInts(value, 0, 23)
+		case "BYMINUTE":
+			schedule.byMinute, err = parseRRuleInts(value, 0, 59)
+		default:
+			return nil, fmt.Errorf("unsupported RRULE part %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE %s: %w", key, err)
+		}
+	}
+
+	switch schedule.freq {
+	case RRuleDaily, RRuleWeekly, RRuleMonthly:
+	case "":
+		return nil, errors.New("RRULE FREQ is required")
+	default:
+		return nil, fmt.Errorf("unsupported RRULE FREQ %q", schedule.freq)
+	}
+
+	if len(schedule.byHour) == 0 {
+		schedule.byHour = []int{schedule.start.Hour()}
+	}
+	if len(schedule.byMinute) == 0 {
+		schedule.byMinute = []int{schedule.start.Minute()}
+	}
+	// По умолчанию повторяем в день недели / день месяца точки отсчета
+	if schedule.freq == RRuleWeekly && len(schedule.byDay) == 0 {
+		schedule.byDay = []time.Weekday{schedule.start.Weekday()}
+	}
+	if schedule.freq == RRuleMonthly && len(schedule.byDay) == 0 && len(schedule.byMonthDay) == 0 {
+		schedule.byMonthDay = []int{schedule.start.Day()}
+	}
+	sort.Ints(schedule.byHour)
+	sort.Ints(schedule.byMinute)
+
+	return schedule, nil
+}
+
+func parseRRuleInts(value string, min, max int) ([]int, error) {
+	var result []int
+	for _, item := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(item)
+		if err != nil || number < min || number > max || number == 0 && min < 0 {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		result = append(result, number)
+	}
+	return result, nil
+}
+
+// Next перебирает дни начиная с after и возвращает первое подходящее время
+func (s *rruleSchedule) Next(after time.Time) time.Time {
+	loc := s.start.Location()
+	after = after.In(loc)
+	if after.Before(s.start) {
+		after = s.start.Add(-time.Minute)
+	}
+
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, loc)
+	limit := day.AddDate(scheduleSearchYears, 0, 0)
+
+	for ; day.Before(limit); day = day.AddDate(0, 0, 1) {
+		if !s.matchesPeriod(day) || !s.matchesDay(day) {
+			continue
+		}
+		for _, hour := range s.byHour {
+			for _, minute := range s.byMinute {
+				candidate := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+				if candidate.After(after) && !candidate.Before(s.start) {
+					return candidate
+				}
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchesPeriod проверяет, что день попадает в период, кратный INTERVAL
+func (s *rruleSchedule) matchesPeriod(day time.Time) bool {
+	startDay := time.Date(s.start.Year(), s.start.Month(), s.start.Day(), 0, 0, 0, 0, day.Location())
+
+	var index int
+	switch s.freq {
+	case RRuleDaily:
+		index = daysBetween(startDay, day)
+	case RRuleWeekly:
+		index = daysBetween(weekStart(startDay), weekStart(day)) / 7
+	case RRuleMonthly:
+		index = (day.Year()-startDay.Year())*12 + int(day.Month()) - int(startDay.Month())
+	}
+
+	return index >= 0 && index%s.interval == 0
+}
+
+func (s *rruleSchedule) matchesDay(day time.Time) bool {
+	if len(s.byDay) > 0 {
+		found := false
+		for _, weekday := range s.byDay {
+			if day.Weekday() == weekday {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(s.byMonthDay) > 0 {
+		lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+		for _, monthDay := range s.byMonthDay {
+			if monthDay < 0 {
+				monthDay = lastDay + monthDay + 1
+			}
+			if day.Day() == monthDay {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// daysBetween считает календарные дни между датами (устойчиво к переходу на летнее время)
+func daysBetween(from, to time.Time) int {
+	fromUTC := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toUTC := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toUTC.Sub(fromUTC).Hours() / 24)
+}
+
+// weekStart возвращает понедельник недели, в которую попадает день
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
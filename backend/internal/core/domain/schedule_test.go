@@ -0,0 +1,169 @@
+// internal/core/domain/schedule_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Cron(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "monthly on the first at 09:00",
+			expr:     "0 9 1 * *",
+			after:    start,
+			expected: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "every 15 minutes",
+			expr:     "*/15 * * * *",
+			after:    start,
+			expected: time.Date(2024, 1, 15, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays at 08:00",
+			expr:     "0 8 * * 1-5",
+			after:    time.Date(2024, 1, 19, 9, 0, 0, 0, time.UTC), // пятница
+			expected: time.Date(2024, 1, 22, 8, 0, 0, 0, time.UTC), // понедельник
+		},
+		{
+			name:     "daily alias",
+			expr:     "@daily",
+			after:    start,
+			expected: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			expr:     "0 12 20 * 0",
+			after:    start,
+			expected: time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr, start)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(tt.after))
+		})
+	}
+}
+
+func TestParseSchedule_RRule(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC) // понедельник
+
+	tests := []struct {
+		name     string
+		expr     string
+		after    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "monthly on the last day",
+			expr:     "RRULE:FREQ=MONTHLY;BYMONTHDAY=-1;BYHOUR=18;BYMINUTE=0",
+			after:    start,
+			expected: time.Date(2024, 1, 31, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "every second week on friday",
+			expr:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;BYHOUR=9;BYMINUTE=0",
+			after:    time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily defaults to start time",
+			expr:     "FREQ=DAILY",
+			after:    start,
+			expected: time.Date(2024, 1, 16, 10, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr, start)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(tt.after))
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	start := time.Now()
+
+	for _, expr := range []string{
+		"",
+		"* * *",
+		"61 * * * *",
+		"0 9 32 * *",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=WEEKLY;BYDAY=XX",
+	} {
+		_, err := ParseSchedule(expr, start)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseSchedule_Timezone(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	schedule, err := ParseSchedule("0 9 * * *", time.Now().In(loc))
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)) // 10:00 по Москве
+	assert.Equal(t, time.Date(2024, 3, 2, 6, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestRecurringTask(t *testing.T) {
+	t.Run("new rule is scheduled", func(t *testing.T) {
+		rt, err := NewRecurringTask("Проверка бэкапов", "0 9 1 * *", "Europe/Moscow",
+			"Проверить резервные копии", "Ежемесячная проверка", "user-1")
+		require.NoError(t, err)
+
+		assert.True(t, rt.Enabled)
+		require.NotNil(t, rt.NextRunAt)
+		assert.True(t, rt.NextRunAt.After(time.Now()))
+		assert.False(t, rt.IsDue(time.Now()))
+		assert.True(t, rt.IsDue(*rt.NextRunAt))
+	})
+
+	t.Run("mark run skips missed occurrences", func(t *testing.T) {
+		rt, err := NewRecurringTask("Отчет", "@hourly", "", "Отчет", "Почасовой отчет", "user-1")
+		require.NoError(t, err)
+
+		now := time.Now().Add(5 * time.Hour)
+		require.NoError(t, rt.MarkRun("TASK-1", now))
+
+		assert.Equal(t, "TASK-1", rt.LastTaskID)
+		require.NotNil(t, rt.NextRunAt)
+		assert.True(t, rt.NextRunAt.After(now))
+		assert.False(t, rt.IsDue(now))
+	})
+
+	t.Run("disabled rule is never due", func(t *testing.T) {
+		rt, err := NewRecurringTask("Отчет", "@hourly", "", "Отчет", "Почасовой отчет", "user-1")
+		require.NoError(t, err)
+
+		rt.Enabled = false
+		assert.False(t, rt.IsDue(time.Now().Add(24*time.Hour)))
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, err := NewRecurringTask("Отчет", "not a schedule", "", "Отчет", "Описание", "user-1")
+		assert.Error(t, err)
+
+		_, err = NewRecurringTask("Отчет", "@daily", "Mars/Olympus", "Отчет", "Описание", "user-1")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,98 @@
+// internal/core/domain/scheduled_reply.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduledReplyStatus статус запланированного ответа
+type ScheduledReplyStatus string
+
+const (
+	ScheduledReplyPending   ScheduledReplyStatus = "pending"   // Ожидает отправки
+	ScheduledReplySent      ScheduledReplyStatus = "sent"      // Отправлен
+	ScheduledReplyCancelled ScheduledReplyStatus = "cancelled" // Отменен
+	ScheduledReplyFailed    ScheduledReplyStatus = "failed"    // Не удалось отправить
+)
+
+// ScheduledReply ответ по задаче, отправка которого запланирована на определенное время
+type ScheduledReply struct {
+	ID        string
+	TaskID    string
+	AuthorID  string
+	Content   string
+	IsPrivate bool
+	SendAt    time.Time
+	Status    ScheduledReplyStatus
+	SentAt    *time.Time
+	Error     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewScheduledReply планирует ответ на момент sendAt
+func NewScheduledReply(taskID, authorID, content string, isPrivate bool, sendAt time.Time) (*ScheduledReply, error) {
+	if taskID == "" {
+		return nil, errors.New("task ID is required")
+	}
+	if authorID == "" {
+		return nil, errors.New("author ID is required")
+	}
+	if strings.TrimSpace(content) == "" {
+		return nil, errors.New("reply content is required")
+	}
+	if !sendAt.After(time.Now()) {
+		return nil, errors.New("send time must be in the future")
+	}
+
+	now := time.Now()
+	return &ScheduledReply{
+		ID:        GenerateScheduledReplyID(),
+		TaskID:    taskID,
+		AuthorID:  authorID,
+		Content:   content,
+		IsPrivate: isPrivate,
+		SendAt:    sendAt,
+		Status:    ScheduledReplyPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsDue проверяет, пора ли отправлять ответ
+func (r *ScheduledReply) IsDue(now time.Time) bool {
+	return r.Status == ScheduledReplyPending && !r.SendAt.After(now)
+}
+
+// MarkSent отмечает ответ отправленным
+func (r *ScheduledReply) MarkSent(now time.Time) {
+	r.Status = ScheduledReplySent
+	r.SentAt = &now
+	r.Error = ""
+	r.UpdatedAt = now
+}
+
+// MarkFailed отмечает ошибку отправки
+func (r *ScheduledReply) MarkFailed(err error, now time.Time) {
+	r.Status = ScheduledReplyFailed
+	r.Error = err.Error()
+	r.UpdatedAt = now
+}
+
+// Cancel отменяет ожидающий отправки ответ
+func (r *ScheduledReply) Cancel() error {
+	if r.Status != ScheduledReplyPending {
+		return fmt.Errorf("cannot cancel scheduled reply in status %s", r.Status)
+	}
+	r.Status = ScheduledReplyCancelled
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// GenerateScheduledReplyID генерирует ID для запланированного ответа
+func GenerateScheduledReplyID() string {
+	return fmt.Sprintf("SR-%d", time.Now().UnixNano())
+}
@@ -0,0 +1,217 @@
+// internal/core/domain/search.go
+package domain
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SearchEntityType тип сущности в полнотекстовом индексе
+type SearchEntityType string
+
+const (
+	SearchEntityTask     SearchEntityType = "task"
+	SearchEntityEmail    SearchEntityType = "email"
+	SearchEntityCustomer SearchEntityType = "customer"
+)
+
+// SearchEntityTypes все индексируемые типы сущностей
+var SearchEntityTypes = []SearchEntityType{SearchEntityTask, SearchEntityEmail, SearchEntityCustomer}
+
+// IsValid проверяет, что тип сущности индексируется
+func (t SearchEntityType) IsValid() bool {
+	return slices.Contains(SearchEntityTypes, t)
+}
+
+// SearchDocument запись полнотекстового индекса. Атрибуты статуса, приоритета и категории
+// используются для фильтров и фасетов, Members - для ограничения видимости задач
+type SearchDocument struct {
+	EntityType  SearchEntityType
+	EntityID    string
+	Title       string
+	Body        string // Текст, доступный всем, кто видит сущность
+	PrivateBody string // Внутренние заметки: ищутся только с правом messages:private
+	Status      string
+	Priority    string
+	Category    string
+	Members     []string // Автор, исполнитель и участники задачи
+	UpdatedAt   time.Time
+}
+
+// NewTaskSearchDocument индексирует тему, описание и сообщения задачи с именами вложений
+func NewTaskSearchDocument(task *Task) SearchDocument {
+	var public, private []string
+	public = append(public, task.Description)
+	for _, message := range task.Messages {
+		text := append([]string{message.Content}, attachmentNames(message.Attachments)...)
+		if message.IsPrivate() {
+			private = append(private, text...)
+		} else {
+			public = append(public, text...)
+		}
+	}
+
+	members := []string{task.ReporterID}
+	if task.AssigneeID != "" {
+		members = append(members, task.AssigneeID)
+	}
+	for _, participant := range task.Participants {
+		if !slices.Contains(members, participant.UserID) {
+			members = append(members, participant.UserID)
+		}
+	}
+
+	return SearchDocument{
+		EntityType:  SearchEntityTask,
+		EntityID:    task.ID,
+		Title:       task.Subject,
+		Body:        joinSearchText(public),
+		PrivateBody: joinSearchText(private),
+		Status:      string(task.Status),
+		Priority:    string(task.Priority),
+		Category:    task.Category,
+		Members:     members,
+		UpdatedAt:   task.UpdatedAt,
+	}
+}
+
+// NewEmailSearchDocument индексирует тему, отправителя, текст письма и имена вложений.
+// Письмо без текстовой части индексируется по HTML без разметки
+func NewEmailSearchDocument(msg *EmailMessage) SearchDocument {
+	body := msg.BodyText
+	if strings.TrimSpace(body) == "" {
+		body = StripHTML(msg.BodyHTML)
+	}
+	text := append([]string{string(msg.From), body}, attachmentNames(msg.Attachments)...)
+
+	status := "unprocessed"
+	if msg.Processed {
+		status = "processed"
+	}
+	return SearchDocument{
+		EntityType: SearchEntityEmail,
+		EntityID:   string(msg.ID),
+		Title:      msg.Subject,
+		Body:       joinSearchText(text),
+		Status:     status,
+		UpdatedAt:  msg.UpdatedAt,
+	}
+}
+
+// NewCustomerSearchDocument индексирует имя, email, телефон и организацию клиента
+func NewCustomerSearchDocument(customer *Customer) SearchDocument {
+	text := []string{customer.Email, customer.Phone}
+	if customer.Organization != nil {
+		text = append(text, customer.Organization.Name)
+	}
+	return SearchDocument{
+		EntityType: SearchEntityCustomer,
+		EntityID:   customer.ID,
+		Title:      customer.Name,
+		Body:       joinSearchText(text),
+		UpdatedAt:  customer.UpdatedAt,
+	}
+}
+
+// VisibleIn проверяет, что документ виден в пределах ограничения видимости задач.
+// Ограничение касается только задач
+func (d *SearchDocument) VisibleIn(scope *TaskScope) bool {
+	if scope == nil || d.EntityType != SearchEntityTask {
+		return true
+	}
+	return slices.Contains(scope.Categories, d.Category) ||
+		(scope.UserID != "" && slices.Contains(d.Members, scope.UserID))
+}
+
+// SearchHit найденная сущность с выделенным фрагментом текста
+type SearchHit struct {
+	EntityType SearchEntityType
+	EntityID   string
+	Title      string
+	Snippet    string // Экранированный фрагмент с совпадениями в <mark>...</mark>
+	Rank       float64
+	Status     string
+	Priority   string
+	Category   string
+	UpdatedAt  time.Time
+}
+
+// SearchFacets число найденных документов по значениям атрибутов
+type SearchFacets struct {
+	EntityTypes map[string]int
+	Statuses    map[string]int
+	Priorities  map[string]int
+	Categories  map[string]int
+}
+
+// NewSearchFacets создает пустые фасеты
+func NewSearchFacets() SearchFacets {
+	return SearchFacets{
+		EntityTypes: map[string]int{},
+		Statuses:    map[string]int{},
+		Priorities:  map[string]int{},
+		Categories:  map[string]int{},
+	}
+}
+
+// Add учитывает count документов с атрибутами doc; пустые значения не считаются
+func (f SearchFacets) Add(doc *SearchDocument, count int) {
+	f.EntityTypes[string(doc.EntityType)] += count
+	countFacet(f.Statuses, doc.Status, count)
+	countFacet(f.Priorities, doc.Priority, count)
+	countFacet(f.Categories, doc.Category, count)
+}
+
+func countFacet(facet map[string]int, value string, count int) {
+	if value != "" {
+		facet[value] += count
+	}
+}
+
+// Метки совпадений во фрагменте, которые возвращает индекс. Символы из области
+// частного использования не встречаются в тексте и заменяются на <mark> после экранирования
+const (
+	SearchHighlightStart = "\uE000"
+	SearchHighlightStop  = "\uE001"
+)
+
+// FormatSearchSnippet экранирует фрагмент для HTML и заменяет метки совпадений на <mark>
+func FormatSearchSnippet(raw string) string {
+	escaped := html.EscapeString(raw)
+	escaped = strings.ReplaceAll(escaped, SearchHighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, SearchHighlightStop, "</mark>")
+}
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`(?s)<(script|style)[^>]*>.*?</(script|style)>|<[^>]+>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// StripHTML убирает разметку и сворачивает пробелы
+func StripHTML(value string) string {
+	text := html.UnescapeString(htmlTagPattern.ReplaceAllString(value, " "))
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+}
+
+func attachmentNames(attachments []Attachment) []string {
+	names := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment.Name != "" {
+			names = append(names, attachment.Name)
+		}
+	}
+	return names
+}
+
+func joinSearchText(parts []string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "\n")
+}
@@ -4,6 +4,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -40,9 +41,10 @@ type Task struct {
 	Tags        []string
 
 	// Связи
-	ParentID    *string // Для подзадач
-	ProjectID   *string // Привязка к проекту (заглушка для будущего)
-	MilestoneID *string // Привязка к этапу (заглушка для будущего)
+	ParentID    *string    // Для подзадач
+	ProjectID   *string    // Привязка к проекту (заглушка для будущего)
+	MilestoneID *string    // Привязка к этапу (заглушка для будущего)
+	Links       []TaskLink // Связи с другими задачами (хранятся с обеих сторон)
 
 	// Участники
 	AssigneeID   string
@@ -54,16 +56,34 @@ type Task struct {
 	Source     TaskSource
 	SourceMeta map[string]interface{}
 
+	// Пользовательские поля (ключ описания поля → нормализованное значение)
+	CustomFields map[string]interface{}
+
 	// Сообщения и история
 	Messages []Message
 	History  []TaskEvent
 
+	// Учет времени
+	WorkLogs []WorkLog
+	Timers   []TaskTimer // Запущенные таймеры (не более одного на пользователя)
+
 	// Временные метки
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	DueDate    *time.Time
 	ResolvedAt *time.Time
 	ClosedAt   *time.Time
+	// SnoozedUntil задача отложена и скрыта из очередей до этого момента
+	SnoozedUntil *time.Time
+
+	// Опрос удовлетворенности клиента (CSAT); nil - опрос не отправлялся
+	Satisfaction *SatisfactionSurvey
+
+	// Version номер ревизии; увеличивается репозиторием при каждом сохранении изменений
+	Version int
+
+	// События истории, еще не переданные в журнал аудита
+	pendingEvents []TaskEvent
 }
 
 // TaskEvent событие в истории задачи
@@ -75,6 +95,18 @@ type TaskEvent struct {
 	NewValue  interface{}
 	Timestamp time.Time
 	Message   string
+	// Changes типизированные изменения полей
+	Changes []FieldChange
+}
+
+// TaskChanges изменяемые реквизиты задачи; nil - поле не меняется
+type TaskChanges struct {
+	Subject     *string
+	Description *string
+	Priority    *Priority
+	Category    *string
+	Tags        *[]string
+	DueDate     *time.Time
 }
 
 // NewTask создает новую задачу
@@ -117,13 +149,17 @@ func NewTask(
 				JoinedAt: now,
 			},
 		},
-		Messages:  []Message{},
-		History:   []TaskEvent{},
-		CreatedAt: now,
-		UpdatedAt: now,
+		Links:        []TaskLink{},
+		CustomFields: map[string]interface{}{},
+		Messages:     []Message{},
+		History:      []TaskEvent{},
+		WorkLogs:     []WorkLog{},
+		Timers:       []TaskTimer{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 
-	task.addHistoryEvent("created", reporterID, nil, nil, "Задача создана")
+	task.addHistoryEvent(TaskEventCreated, reporterID, nil, nil, "Задача создана")
 
 	return task, nil
 }
@@ -170,6 +206,12 @@ func NewSubTask(
 
 // AddMessage добавляет сообщение в задачу
 func (t *Task) AddMessage(authorID, content string, messageType MessageType) error {
+	return t.AddMessageWithAttachments(authorID, content, messageType, nil)
+}
+
+// AddMessageWithAttachments добавляет сообщение с вложениями.
+// Вложениям без ID присваивается ID на основе ID сообщения
+func (t *Task) AddMessageWithAttachments(authorID, content string, messageType MessageType, attachments []Attachment) error {
 	if content == "" {
 		return errors.New("message content is required")
 	}
@@ -181,9 +223,27 @@ func (t *Task) AddMessage(authorID, content string, messageType MessageType) err
 		Type:      messageType,
 		CreatedAt: time.Now(),
 	}
+	if len(attachments) > 0 {
+		message.Attachments = make([]Attachment, len(attachments))
+		for i, attachment := range attachments {
+			if attachment.ID == "" {
+				attachment.ID = AttachmentID(fmt.Sprintf("%s-ATT-%d", message.ID, i+1))
+			}
+			if attachment.Size == 0 {
+				attachment.Size = int64(len(attachment.Data))
+			}
+			message.Attachments[i] = attachment
+		}
+	}
 
 	t.Messages = append(t.Messages, message)
 	t.UpdatedAt = time.Now()
+	t.addHistoryEvent(TaskEventMessageAdded, authorID, nil, message.ID, "Добавлено сообщение")
+
+	// Ответ клиента возвращает отложенную задачу в очередь досрочно
+	if t.SnoozedUntil != nil && t.CustomerID != nil && *t.CustomerID == authorID {
+		t.Unsnooze(authorID, "ответ клиента")
+	}
 
 	// Автоматически добавляем автора в участники если его еще нет
 	if messageType != MessageTypeSystem {
@@ -215,10 +275,14 @@ func (t *Task) ChangeStatus(newStatus TaskStatus, userID string) error {
 		t.ResolvedAt = nil
 		t.ClosedAt = nil
 	}
+	// Завершенная задача больше не может быть отложена
+	if !newStatus.IsActive() {
+		t.SnoozedUntil = nil
+	}
 
 	// Записываем в историю (только коды статусов, без локализации)
 	message := fmt.Sprintf("Статус изменен: %s → %s", oldStatus, newStatus)
-	t.addHistoryEvent("status_changed", userID, oldStatus, newStatus, message)
+	t.addHistoryEvent(TaskEventStatusChanged, userID, oldStatus, newStatus, message)
 
 	return nil
 }
@@ -233,16 +297,281 @@ func (t *Task) Assign(assigneeID string, userID string) error {
 	t.AssigneeID = assigneeID
 	t.UpdatedAt = time.Now()
 
-	// Добавляем исполнителя в участники
-	t.addParticipantIfNotExists(assigneeID, RoleAssignee)
+	// Прежний исполнитель остается в задаче как обычный участник
+	if oldAssignee != "" && oldAssignee != assigneeID {
+		if participant := t.FindParticipant(oldAssignee); participant != nil && participant.Role == RoleAssignee {
+			participant.Role = RoleParticipant
+		}
+	}
+
+	// Добавляем исполнителя в участники (наблюдатель или участник повышается до исполнителя)
+	if participant := t.FindParticipant(assigneeID); participant != nil {
+		if participant.Role != RoleReporter {
+			participant.Role = RoleAssignee
+		}
+	} else {
+		t.addParticipantIfNotExists(assigneeID, RoleAssignee)
+	}
 
 	// Записываем в историю
 	message := fmt.Sprintf("Исполнитель назначен: %s", assigneeID)
-	t.addHistoryEvent("assignee_changed", userID, oldAssignee, assigneeID, message)
+	t.addHistoryEvent(TaskEventAssigneeChanged, userID, oldAssignee, assigneeID, message)
+
+	return nil
+}
+
+// AddParticipant добавляет участника с указанной ролью
+func (t *Task) AddParticipant(participantID string, role ParticipantRole, userID string) error {
+	if participantID == "" {
+		return errors.New("participant ID is required")
+	}
+	if !role.IsManageable() {
+		return fmt.Errorf("role %s cannot be assigned directly", role)
+	}
+	if t.FindParticipant(participantID) != nil {
+		return fmt.Errorf("participant %s already exists", participantID)
+	}
+
+	t.Participants = append(t.Participants, Participant{
+		UserID:   participantID,
+		Role:     role,
+		JoinedAt: time.Now(),
+	})
+	t.UpdatedAt = time.Now()
+
+	message := fmt.Sprintf("Участник добавлен: %s (%s)", participantID, role)
+	t.addHistoryEvent(TaskEventParticipantAdded, userID, nil, participantID, message)
+
+	return nil
+}
+
+// RemoveParticipant удаляет участника из задачи
+func (t *Task) RemoveParticipant(participantID string, userID string) error {
+	for i, participant := range t.Participants {
+		if participant.UserID != participantID {
+			continue
+		}
+
+		// Автор и исполнитель определяются самой задачей
+		if participant.Role == RoleReporter || participant.Role == RoleAssignee {
+			return fmt.Errorf("participant with role %s cannot be removed", participant.Role)
+		}
+
+		t.Participants = append(t.Participants[:i], t.Participants[i+1:]...)
+		t.UpdatedAt = time.Now()
+
+		message := fmt.Sprintf("Участник удален: %s", participantID)
+		t.addHistoryEvent(TaskEventParticipantRemoved, userID, participantID, nil, message)
+		return nil
+	}
+
+	return fmt.Errorf("participant not found: %s", participantID)
+}
+
+// ChangeParticipantRole изменяет роль участника
+func (t *Task) ChangeParticipantRole(participantID string, role ParticipantRole, userID string) error {
+	if !role.IsManageable() {
+		return fmt.Errorf("role %s cannot be assigned directly", role)
+	}
+
+	participant := t.FindParticipant(participantID)
+	if participant == nil {
+		return fmt.Errorf("participant not found: %s", participantID)
+	}
+	if participant.Role == RoleReporter || participant.Role == RoleAssignee {
+		return fmt.Errorf("role %s cannot be changed", participant.Role)
+	}
+	if participant.Role == role {
+		return nil
+	}
+
+	oldRole := participant.Role
+	participant.Role = role
+	t.UpdatedAt = time.Now()
+
+	message := fmt.Sprintf("Роль участника %s изменена: %s → %s", participantID, oldRole, role)
+	t.addHistoryEvent(TaskEventParticipantRoleChanged, userID, oldRole, role, message)
+
+	return nil
+}
+
+// Watch подписывает пользователя на задачу.
+// Если пользователь уже участвует в задаче, его роль не меняется.
+func (t *Task) Watch(userID string) error {
+	if userID == "" {
+		return errors.New("user ID is required")
+	}
+	if t.FindParticipant(userID) != nil {
+		return nil
+	}
+
+	return t.AddParticipant(userID, RoleWatcher, userID)
+}
+
+// Unwatch отписывает пользователя от задачи
+func (t *Task) Unwatch(userID string) error {
+	participant := t.FindParticipant(userID)
+	if participant == nil {
+		return nil
+	}
+	if participant.Role != RoleWatcher {
+		return fmt.Errorf("user %s is not a watcher (role: %s)", userID, participant.Role)
+	}
+
+	return t.RemoveParticipant(userID, userID)
+}
+
+// FindParticipant возвращает участника по ID или nil
+func (t *Task) FindParticipant(userID string) *Participant {
+	for i := range t.Participants {
+		if t.Participants[i].UserID == userID {
+			return &t.Participants[i]
+		}
+	}
+	return nil
+}
+
+// HasParticipantRole проверяет, участвует ли пользователь в задаче с указанной ролью
+func (t *Task) HasParticipantRole(userID string, role ParticipantRole) bool {
+	participant := t.FindParticipant(userID)
+	return participant != nil && participant.Role == role
+}
+
+// AddLink добавляет связь с другой задачей
+func (t *Task) AddLink(linkType TaskLinkType, targetID string, userID string) error {
+	if !linkType.IsValid() {
+		return fmt.Errorf("invalid link type: %s", linkType)
+	}
+	if targetID == "" {
+		return errors.New("target task ID is required")
+	}
+	if targetID == t.ID {
+		return errors.New("task cannot be linked to itself")
+	}
+
+	for _, link := range t.Links {
+		if link.TaskID != targetID {
+			continue
+		}
+		if link.Type == linkType {
+			return fmt.Errorf("link %s to %s already exists", linkType, targetID)
+		}
+		// Обратная связь того же вида означает цикл (A блокирует B и B блокирует A)
+		if linkType != LinkRelatesTo && link.Type == linkType.Inverse() {
+			return fmt.Errorf("conflicting link %s to %s already exists", link.Type, targetID)
+		}
+	}
+	if linkType == LinkDuplicateOf && t.DuplicateOf() != "" {
+		return fmt.Errorf("task is already a duplicate of %s", t.DuplicateOf())
+	}
+
+	t.Links = append(t.Links, TaskLink{
+		Type:      linkType,
+		TaskID:    targetID,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+	})
+	t.UpdatedAt = time.Now()
+
+	message := fmt.Sprintf("Добавлена связь: %s %s", linkType, targetID)
+	t.addHistoryEvent(TaskEventLinkAdded, userID, nil, targetID, message)
 
 	return nil
 }
 
+// RemoveLink удаляет связь с другой задачей
+func (t *Task) RemoveLink(linkType TaskLinkType, targetID string, userID string) error {
+	for i, link := range t.Links {
+		if link.Type == linkType && link.TaskID == targetID {
+			t.Links = append(t.Links[:i], t.Links[i+1:]...)
+			t.UpdatedAt = time.Now()
+
+			message := fmt.Sprintf("Удалена связь: %s %s", linkType, targetID)
+			t.addHistoryEvent(TaskEventLinkRemoved, userID, targetID, nil, message)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("link %s to %s not found", linkType, targetID)
+}
+
+// LinkedTaskIDs возвращает ID задач, связанных указанным типом связи
+func (t *Task) LinkedTaskIDs(linkType TaskLinkType) []string {
+	var ids []string
+	for _, link := range t.Links {
+		if link.Type == linkType {
+			ids = append(ids, link.TaskID)
+		}
+	}
+	return ids
+}
+
+// DuplicateOf возвращает ID оригинальной задачи, если задача помечена как дубликат
+func (t *Task) DuplicateOf() string {
+	if ids := t.LinkedTaskIDs(LinkDuplicateOf); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// UpdateDetails изменяет реквизиты задачи и записывает в историю одно событие
+// со списком фактически измененных полей
+func (t *Task) UpdateDetails(changes TaskChanges, userID string) []FieldChange {
+	var diff []FieldChange
+	track := func(field string, oldVal, newVal interface{}) {
+		oldStr, newStr := FormatAuditValue(oldVal), FormatAuditValue(newVal)
+		if oldStr != newStr {
+			diff = append(diff, FieldChange{Field: field, OldValue: oldStr, NewValue: newStr})
+		}
+	}
+
+	if changes.Subject != nil {
+		track("subject", t.Subject, *changes.Subject)
+		t.Subject = *changes.Subject
+	}
+	if changes.Description != nil {
+		track("description", t.Description, *changes.Description)
+		t.Description = *changes.Description
+	}
+	if changes.Priority != nil {
+		track("priority", string(t.Priority), string(*changes.Priority))
+		t.Priority = *changes.Priority
+	}
+	if changes.Category != nil {
+		track("category", t.Category, *changes.Category)
+		t.Category = *changes.Category
+	}
+	if changes.Tags != nil {
+		track("tags", t.Tags, *changes.Tags)
+		t.Tags = *changes.Tags
+	}
+	if changes.DueDate != nil {
+		track("due_date", t.DueDate, *changes.DueDate)
+		dueDate := *changes.DueDate
+		t.DueDate = &dueDate
+	}
+
+	if len(diff) == 0 {
+		return nil
+	}
+
+	t.UpdatedAt = time.Now()
+
+	fields := make([]string, len(diff))
+	for i, change := range diff {
+		fields[i] = change.Field
+	}
+	t.recordEvent(TaskEvent{
+		ID:        GenerateEventID(),
+		Type:      TaskEventUpdated,
+		UserID:    userID,
+		Timestamp: time.Now(),
+		Message:   fmt.Sprintf("Изменены поля: %s", strings.Join(fields, ", ")),
+		Changes:   diff,
+	})
+	return diff
+}
+
 // AddTag добавляет тег к задаче
 func (t *Task) AddTag(tag string) {
 	for _, existingTag := range t.Tags {
@@ -270,7 +599,20 @@ func (t *Task) addParticipantIfNotExists(userID string, role ParticipantRole) {
 }
 
 func (t *Task) addHistoryEvent(eventType string, userID string, oldVal, newVal interface{}, message string) {
-	event := TaskEvent{
+	t.addFieldHistoryEvent(eventType, historyEventFields[eventType], userID, oldVal, newVal, message)
+}
+
+// addFieldHistoryEvent записывает событие изменения одного поля
+func (t *Task) addFieldHistoryEvent(eventType, field, userID string, oldVal, newVal interface{}, message string) {
+	var changes []FieldChange
+	if field != "" {
+		change := FieldChange{Field: field, OldValue: FormatAuditValue(oldVal), NewValue: FormatAuditValue(newVal)}
+		if change.OldValue != "" || change.NewValue != "" {
+			changes = []FieldChange{change}
+		}
+	}
+
+	t.recordEvent(TaskEvent{
 		ID:        GenerateEventID(),
 		Type:      eventType,
 		UserID:    userID,
@@ -278,8 +620,92 @@ func (t *Task) addHistoryEvent(eventType string, userID string, oldVal, newVal i
 		NewValue:  newVal,
 		Timestamp: time.Now(),
 		Message:   message,
-	}
+		Changes:   changes,
+	})
+}
+
+func (t *Task) recordEvent(event TaskEvent) {
 	t.History = append(t.History, event)
+	t.pendingEvents = append(t.pendingEvents, event)
+}
+
+// Clone возвращает независимую копию задачи без несохраненных событий.
+// Репозитории хранят и отдают копии, чтобы изменения вне Update не попадали в хранилище
+func (t *Task) Clone() *Task {
+	clone := *t
+	clone.pendingEvents = nil
+
+	clone.Tags = cloneSlice(t.Tags)
+	clone.Links = cloneSlice(t.Links)
+	clone.Participants = cloneSlice(t.Participants)
+	clone.Messages = cloneSlice(t.Messages)
+	clone.WorkLogs = cloneSlice(t.WorkLogs)
+	clone.Timers = cloneSlice(t.Timers)
+	if t.History != nil {
+		clone.History = make([]TaskEvent, len(t.History))
+		for i, event := range t.History {
+			event.Changes = cloneSlice(event.Changes)
+			clone.History[i] = event
+		}
+	}
+
+	clone.ParentID = clonePtr(t.ParentID)
+	clone.ProjectID = clonePtr(t.ProjectID)
+	clone.MilestoneID = clonePtr(t.MilestoneID)
+	clone.CustomerID = clonePtr(t.CustomerID)
+	clone.DueDate = clonePtr(t.DueDate)
+	clone.ResolvedAt = clonePtr(t.ResolvedAt)
+	clone.ClosedAt = clonePtr(t.ClosedAt)
+	clone.SnoozedUntil = clonePtr(t.SnoozedUntil)
+	if t.Satisfaction != nil {
+		survey := *t.Satisfaction
+		survey.RespondedAt = clonePtr(t.Satisfaction.RespondedAt)
+		clone.Satisfaction = &survey
+	}
+
+	clone.SourceMeta = cloneMap(t.SourceMeta)
+	clone.CustomFields = cloneMap(t.CustomFields)
+
+	return &clone
+}
+
+func cloneSlice[T any](items []T) []T {
+	if items == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(items)), items...)
+}
+
+func clonePtr[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+func cloneMap(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+	return copied
+}
+
+// PullEvents возвращает события, накопленные с последнего вызова, и очищает очередь.
+// Используется при сохранении задачи для записи в журнал аудита
+func (t *Task) PullEvents() []TaskEvent {
+	events := t.pendingEvents
+	t.pendingEvents = nil
+	return events
+}
+
+// PendingEvents возвращает копию накопленных событий, не очищая очередь
+func (t *Task) PendingEvents() []TaskEvent {
+	return cloneSlice(t.pendingEvents)
 }
 
 func (t *Task) isValidStatusTransition(newStatus TaskStatus) bool {
@@ -300,6 +726,16 @@ func (t *Task) isValidStatusTransition(newStatus TaskStatus) bool {
 	return false
 }
 
+// IsActive проверяет, находится ли задача в работе (не решена и не закрыта)
+func (s TaskStatus) IsActive() bool {
+	return s == TaskStatusOpen || s == TaskStatusInProgress || s == TaskStatusReview
+}
+
+// IsOverdue проверяет, просрочена ли активная задача на момент now
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.Status.IsActive() && t.DueDate != nil && t.DueDate.Before(now)
+}
+
 // DisplayName возвращает отображаемое название статуса
 func (s TaskStatus) DisplayName() string {
 	names := map[TaskStatus]string{
@@ -323,6 +759,11 @@ func GenerateMessageID() string {
 	return fmt.Sprintf("MSG-%d", time.Now().UnixNano())
 }
 
+// GenerateWorkLogID генерирует ID для записи учета времени
+func GenerateWorkLogID() string {
+	return fmt.Sprintf("WL-%d", time.Now().UnixNano())
+}
+
 // GenerateEventID генерирует ID для события
 func GenerateEventID() string {
 	return fmt.Sprintf("EVT-%d", time.Now().UnixNano())
@@ -0,0 +1,9 @@
+// internal/core/domain/task_errors.go
+package domain
+
+import "errors"
+
+// Ошибки задач
+var (
+	ErrTaskBlocked = errors.New("task is blocked by open tasks")
+)
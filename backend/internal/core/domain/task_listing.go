@@ -0,0 +1,63 @@
+// internal/core/domain/task_listing.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskCustomFieldSortPrefix префикс поля сортировки по пользовательскому полю: "custom_fields.<key>"
+const TaskCustomFieldSortPrefix = "custom_fields."
+
+// TaskSortFields поля, по которым можно сортировать задачи
+var TaskSortFields = []string{
+	"created_at", "updated_at", "due_date", "resolved_at", "priority", "status", "subject",
+	TaskCustomFieldSortPrefix,
+}
+
+// DefaultTaskSort сортировка задач по умолчанию: сначала новые
+var DefaultTaskSort = []SortField{{Field: "created_at", Desc: true}}
+
+// TaskListing сортировка и постраничная выдача задач
+func TaskListing(sort []SortField) Listing[Task] {
+	return Listing[Task]{
+		Sort: sort,
+		Key:  TaskSortKey,
+		ID:   func(task *Task) string { return task.ID },
+	}
+}
+
+// TaskSortKey ключ сортировки задачи по полю; false - у задачи нет значения
+func TaskSortKey(task *Task, field string) (string, bool) {
+	switch field {
+	case "created_at":
+		return SortKeyTime(task.CreatedAt), true
+	case "updated_at":
+		return SortKeyTime(task.UpdatedAt), true
+	case "due_date":
+		return optionalTimeKey(task.DueDate)
+	case "resolved_at":
+		return optionalTimeKey(task.ResolvedAt)
+	case "priority":
+		return SortKeyNumber(float64(task.Priority.Rank())), true
+	case "status":
+		return string(task.Status), true
+	case "subject":
+		return strings.ToLower(task.Subject), true
+	}
+
+	key, ok := strings.CutPrefix(field, TaskCustomFieldSortPrefix)
+	if !ok {
+		return "", false
+	}
+	value, exists := task.CustomFields[key]
+	if !exists || value == nil {
+		return "", false
+	}
+	// Числа идут перед остальными значениями и сравниваются численно,
+	// строки и даты YYYY-MM-DD - лексикографически
+	if number, isNumber := value.(float64); isNumber {
+		return "0" + SortKeyNumber(number), true
+	}
+	return "1" + fmt.Sprint(value), true
+}
@@ -0,0 +1,53 @@
+// internal/core/domain/task_snooze.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SnoozeDisplayLayout формат времени откладывания в истории задачи
+const SnoozeDisplayLayout = "02.01.2006 15:04"
+
+// Snooze откладывает активную задачу до указанного момента: до этого времени
+// задача скрыта из очередей, затем автоматически возвращается в работу
+func (t *Task) Snooze(until time.Time, userID string) error {
+	if !t.Status.IsActive() {
+		return fmt.Errorf("cannot snooze task in status %s", t.Status)
+	}
+	if !until.After(time.Now()) {
+		return errors.New("snooze time must be in the future")
+	}
+
+	old := t.SnoozedUntil
+	t.SnoozedUntil = &until
+	t.UpdatedAt = time.Now()
+
+	message := fmt.Sprintf("Задача отложена до %s", until.Format(SnoozeDisplayLayout))
+	t.addHistoryEvent(TaskEventSnoozed, userID, old, until, message)
+	return nil
+}
+
+// Unsnooze возвращает отложенную задачу в очередь; reason попадает в историю
+func (t *Task) Unsnooze(userID string, reason string) bool {
+	if t.SnoozedUntil == nil {
+		return false
+	}
+
+	old := *t.SnoozedUntil
+	t.SnoozedUntil = nil
+	t.UpdatedAt = time.Now()
+
+	message := "Задача возвращена в работу"
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+	t.addHistoryEvent(TaskEventUnsnoozed, userID, old, nil, message)
+	return true
+}
+
+// IsSnoozed проверяет, отложена ли задача на момент now
+func (t *Task) IsSnoozed(now time.Time) bool {
+	return t.SnoozedUntil != nil && now.Before(*t.SnoozedUntil)
+}
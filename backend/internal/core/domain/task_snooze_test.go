@@ -0,0 +1,60 @@
+// internal/core/domain/task_snooze_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSnooze(t *testing.T) {
+	newTask := func(t *testing.T) *Task {
+		customerID := "customer-1"
+		task, err := NewSupportTask("Вопрос", "Описание", customerID, "user-1", SourceEmail, nil)
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("snooze and unsnooze", func(t *testing.T) {
+		task := newTask(t)
+		until := time.Now().Add(time.Hour)
+
+		require.NoError(t, task.Snooze(until, "user-2"))
+		assert.True(t, task.IsSnoozed(time.Now()))
+		assert.False(t, task.IsSnoozed(until))
+
+		assert.True(t, task.Unsnooze("user-2", ""))
+		assert.Nil(t, task.SnoozedUntil)
+		assert.False(t, task.Unsnooze("user-2", ""))
+
+		last := task.History[len(task.History)-1]
+		assert.Equal(t, "unsnoozed", last.Type)
+	})
+
+	t.Run("past time is rejected", func(t *testing.T) {
+		task := newTask(t)
+		assert.Error(t, task.Snooze(time.Now().Add(-time.Minute), "user-2"))
+	})
+
+	t.Run("customer reply wakes task", func(t *testing.T) {
+		task := newTask(t)
+		require.NoError(t, task.Snooze(time.Now().Add(time.Hour), "user-2"))
+
+		require.NoError(t, task.AddMessage("user-2", "Внутренняя заметка", MessageTypeInternal))
+		assert.NotNil(t, task.SnoozedUntil)
+
+		require.NoError(t, task.AddMessage("customer-1", "Есть новости?", MessageTypeCustomer))
+		assert.Nil(t, task.SnoozedUntil)
+	})
+
+	t.Run("closing task clears snooze", func(t *testing.T) {
+		task := newTask(t)
+		require.NoError(t, task.Snooze(time.Now().Add(time.Hour), "user-2"))
+
+		require.NoError(t, task.ChangeStatus(TaskStatusCancelled, "user-2"))
+		assert.Nil(t, task.SnoozedUntil)
+		assert.Error(t, task.Snooze(time.Now().Add(time.Hour), "user-2"))
+	})
+}
@@ -0,0 +1,77 @@
+// internal/core/domain/task_stream.go
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// TaskStreamEventType тип события потока изменений задач
+type TaskStreamEventType string
+
+const (
+	TaskStreamTaskCreated  TaskStreamEventType = "task.created"
+	TaskStreamTaskUpdated  TaskStreamEventType = "task.updated"
+	TaskStreamMessageAdded TaskStreamEventType = "task.message_added"
+	TaskStreamTaskDeleted  TaskStreamEventType = "task.deleted"
+)
+
+// ErrTaskStreamLagged подписчик не успевал читать события и был отключен;
+// после переподключения пропущенные события повторяются из буфера
+var ErrTaskStreamLagged = errors.New("task stream subscriber is too slow")
+
+// TaskStreamEvent изменение задачи для клиентов, подписанных на поток.
+// ID возрастает в пределах процесса и передается клиенту как id события SSE
+type TaskStreamEvent struct {
+	ID         uint64
+	Type       TaskStreamEventType
+	TaskID     string
+	MessageID  string // Для task.message_added
+	ActorID    string
+	Task       *Task // Снимок задачи после изменения (до удаления для task.deleted)
+	OccurredAt time.Time
+}
+
+// NewTaskStreamEvent создает событие со снимком задачи; ID назначает брокер при публикации
+func NewTaskStreamEvent(eventType TaskStreamEventType, task *Task, messageID, actorID string) TaskStreamEvent {
+	return TaskStreamEvent{
+		Type:       eventType,
+		TaskID:     task.ID,
+		MessageID:  messageID,
+		ActorID:    actorID,
+		Task:       task.Clone(),
+		OccurredAt: time.Now(),
+	}
+}
+
+// Message возвращает добавленное сообщение события task.message_added
+func (e TaskStreamEvent) Message() *Message {
+	if e.MessageID == "" || e.Task == nil {
+		return nil
+	}
+	for i := range e.Task.Messages {
+		if e.Task.Messages[i].ID == e.MessageID {
+			return &e.Task.Messages[i]
+		}
+	}
+	return nil
+}
+
+// TaskStreamFilter подписка клиента: очереди (категории задач) и отдельные задачи.
+// Пустой фильтр пропускает все задачи, доступные пользователю
+type TaskStreamFilter struct {
+	Queues  []string
+	TaskIDs []string
+}
+
+// Matches проверяет, относится ли событие к подписке
+func (f TaskStreamFilter) Matches(event TaskStreamEvent) bool {
+	if len(f.Queues) == 0 && len(f.TaskIDs) == 0 {
+		return true
+	}
+	if slices.Contains(f.TaskIDs, event.TaskID) {
+		return true
+	}
+	return event.Task != nil && slices.Contains(f.Queues, event.Task.Category)
+}
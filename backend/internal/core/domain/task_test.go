@@ -124,6 +124,86 @@ func TestTask_Assign(t *testing.T) {
 	assert.Len(t, task.History, 2)      // created + assignee_changed
 }
 
+func TestTask_Participants(t *testing.T) {
+	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
+
+	require.NoError(t, task.AddParticipant("user-2", RoleWatcher, "user-1"))
+	assert.True(t, task.HasParticipantRole("user-2", RoleWatcher))
+
+	// Повторное добавление запрещено
+	assert.Error(t, task.AddParticipant("user-2", RoleReviewer, "user-1"))
+
+	// Роли автора и исполнителя нельзя назначить напрямую
+	assert.Error(t, task.AddParticipant("user-3", RoleAssignee, "user-1"))
+
+	require.NoError(t, task.ChangeParticipantRole("user-2", RoleReviewer, "user-1"))
+	assert.True(t, task.HasParticipantRole("user-2", RoleReviewer))
+
+	// Автора нельзя удалить
+	assert.Error(t, task.RemoveParticipant("user-1", "user-1"))
+
+	require.NoError(t, task.RemoveParticipant("user-2", "user-1"))
+	assert.Nil(t, task.FindParticipant("user-2"))
+
+	// created + added + role_changed + removed
+	assert.Len(t, task.History, 4)
+	assert.Equal(t, "participant_removed", task.History[3].Type)
+}
+
+func TestTask_WatchUnwatch(t *testing.T) {
+	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
+
+	require.NoError(t, task.Watch("user-2"))
+	require.NoError(t, task.Watch("user-2")) // Идемпотентно
+	assert.Len(t, task.Participants, 2)
+	assert.True(t, task.HasParticipantRole("user-2", RoleWatcher))
+
+	// Автор уже участвует - роль не меняется, отписаться нельзя
+	require.NoError(t, task.Watch("user-1"))
+	assert.True(t, task.HasParticipantRole("user-1", RoleReporter))
+	assert.Error(t, task.Unwatch("user-1"))
+
+	require.NoError(t, task.Unwatch("user-2"))
+	assert.Nil(t, task.FindParticipant("user-2"))
+}
+
+func TestTask_Assign_PromotesWatcher(t *testing.T) {
+	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
+	require.NoError(t, task.Watch("user-2"))
+
+	require.NoError(t, task.Assign("user-2", "user-1"))
+	assert.True(t, task.HasParticipantRole("user-2", RoleAssignee))
+
+	require.NoError(t, task.Assign("user-3", "user-1"))
+	assert.True(t, task.HasParticipantRole("user-2", RoleParticipant))
+	assert.True(t, task.HasParticipantRole("user-3", RoleAssignee))
+}
+
+func TestTask_Links(t *testing.T) {
+	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
+
+	require.NoError(t, task.AddLink(LinkBlocks, "TASK-2", "user-1"))
+	assert.Equal(t, []string{"TASK-2"}, task.LinkedTaskIDs(LinkBlocks))
+	assert.Equal(t, "link_added", task.History[len(task.History)-1].Type)
+
+	assert.Error(t, task.AddLink(LinkBlocks, "TASK-2", "user-1"))    // Повтор
+	assert.Error(t, task.AddLink(LinkBlockedBy, "TASK-2", "user-1")) // Цикл
+	assert.Error(t, task.AddLink(LinkRelatesTo, task.ID, "user-1"))  // Сама на себя
+	assert.Error(t, task.AddLink("unknown", "TASK-3", "user-1"))
+
+	require.NoError(t, task.AddLink(LinkDuplicateOf, "TASK-3", "user-1"))
+	assert.Equal(t, "TASK-3", task.DuplicateOf())
+	assert.Error(t, task.AddLink(LinkDuplicateOf, "TASK-4", "user-1"))
+
+	require.NoError(t, task.RemoveLink(LinkBlocks, "TASK-2", "user-1"))
+	assert.Empty(t, task.LinkedTaskIDs(LinkBlocks))
+	assert.Error(t, task.RemoveLink(LinkBlocks, "TASK-2", "user-1"))
+
+	assert.Equal(t, LinkBlockedBy, LinkBlocks.Inverse())
+	assert.Equal(t, LinkDuplicatedBy, LinkDuplicateOf.Inverse())
+	assert.Equal(t, LinkRelatesTo, LinkRelatesTo.Inverse())
+}
+
 func TestTask_AddTag(t *testing.T) {
 	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
 
@@ -162,3 +242,26 @@ func TestGenerateIDs(t *testing.T) {
 	assert.Contains(t, msgID, "MSG-")
 	assert.Contains(t, eventID, "EVT-")
 }
+
+func TestTask_Clone(t *testing.T) {
+	customerID := "customer-1"
+	task, err := NewSupportTask("Вопрос", "Описание", customerID, "user-1", SourceEmail, map[string]interface{}{"thread": "t-1"})
+	require.NoError(t, err)
+	task.AddTag("vip")
+	require.NoError(t, task.AddMessage(customerID, "Текст", MessageTypeCustomer))
+
+	clone := task.Clone()
+	assert.Equal(t, task.Subject, clone.Subject)
+	assert.Empty(t, clone.PullEvents())
+
+	// Изменения копии не затрагивают исходную задачу
+	clone.Tags[0] = "changed"
+	clone.Participants[0].Role = RoleWatcher
+	clone.SourceMeta["thread"] = "t-2"
+	*clone.CustomerID = "customer-2"
+
+	assert.Equal(t, "vip", task.Tags[0])
+	assert.NotEqual(t, RoleWatcher, task.Participants[0].Role)
+	assert.Equal(t, "t-1", task.SourceMeta["thread"])
+	assert.Equal(t, "customer-1", *task.CustomerID)
+}
@@ -0,0 +1,142 @@
+// internal/core/domain/task_worklog.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MinWorkLogDuration минимальная длительность записи учета времени
+const MinWorkLogDuration = time.Minute
+
+// LogWork добавляет запись о затраченном времени
+func (t *Task) LogWork(userID string, duration time.Duration, date time.Time, note string, billable bool) (*WorkLog, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if duration < MinWorkLogDuration {
+		return nil, fmt.Errorf("duration must be at least %s", MinWorkLogDuration)
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	workLog := WorkLog{
+		ID:        GenerateWorkLogID(),
+		UserID:    userID,
+		Duration:  duration.Round(time.Minute),
+		Date:      date,
+		Note:      note,
+		Billable:  billable,
+		CreatedAt: time.Now(),
+	}
+
+	t.WorkLogs = append(t.WorkLogs, workLog)
+	t.UpdatedAt = time.Now()
+
+	message := fmt.Sprintf("Списано время: %s", formatWorkDuration(workLog.Duration))
+	t.addHistoryEvent(TaskEventWorkLogged, userID, nil, workLog.Duration, message)
+
+	return &workLog, nil
+}
+
+// RemoveWorkLog удаляет запись учета времени
+func (t *Task) RemoveWorkLog(workLogID string, userID string) error {
+	for i, workLog := range t.WorkLogs {
+		if workLog.ID != workLogID {
+			continue
+		}
+
+		t.WorkLogs = append(t.WorkLogs[:i], t.WorkLogs[i+1:]...)
+		t.UpdatedAt = time.Now()
+
+		message := fmt.Sprintf("Удалена запись времени: %s", formatWorkDuration(workLog.Duration))
+		t.addHistoryEvent(TaskEventWorkLogRemoved, userID, workLog.Duration, nil, message)
+		return nil
+	}
+
+	return fmt.Errorf("work log not found: %s", workLogID)
+}
+
+// StartTimer запускает таймер учета времени для пользователя
+func (t *Task) StartTimer(userID string) error {
+	if userID == "" {
+		return errors.New("user ID is required")
+	}
+	if t.FindTimer(userID) != nil {
+		return fmt.Errorf("timer is already running for user %s", userID)
+	}
+
+	now := time.Now()
+	t.Timers = append(t.Timers, TaskTimer{UserID: userID, StartedAt: now})
+	t.UpdatedAt = now
+
+	t.addHistoryEvent(TaskEventTimerStarted, userID, nil, now, "Запущен таймер учета времени")
+	return nil
+}
+
+// StopTimer останавливает таймер и списывает время (не менее одной минуты)
+func (t *Task) StopTimer(userID string, note string, billable bool) (*WorkLog, error) {
+	timer := t.FindTimer(userID)
+	if timer == nil {
+		return nil, fmt.Errorf("no running timer for user %s", userID)
+	}
+	startedAt := timer.StartedAt
+
+	for i := range t.Timers {
+		if t.Timers[i].UserID == userID {
+			t.Timers = append(t.Timers[:i], t.Timers[i+1:]...)
+			break
+		}
+	}
+
+	duration := time.Since(startedAt)
+	if duration < MinWorkLogDuration {
+		duration = MinWorkLogDuration
+	}
+
+	t.addHistoryEvent(TaskEventTimerStopped, userID, startedAt, time.Now(), "Остановлен таймер учета времени")
+
+	return t.LogWork(userID, duration, startedAt, note, billable)
+}
+
+// FindTimer возвращает запущенный таймер пользователя
+func (t *Task) FindTimer(userID string) *TaskTimer {
+	for i := range t.Timers {
+		if t.Timers[i].UserID == userID {
+			return &t.Timers[i]
+		}
+	}
+	return nil
+}
+
+// TimeSpent возвращает суммарное списанное время
+func (t *Task) TimeSpent() time.Duration {
+	var total time.Duration
+	for _, workLog := range t.WorkLogs {
+		total += workLog.Duration
+	}
+	return total
+}
+
+// BillableTime возвращает суммарное оплачиваемое время
+func (t *Task) BillableTime() time.Duration {
+	var total time.Duration
+	for _, workLog := range t.WorkLogs {
+		if workLog.Billable {
+			total += workLog.Duration
+		}
+	}
+	return total
+}
+
+// formatWorkDuration форматирует длительность в виде "1ч 30м"
+func formatWorkDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours == 0 {
+		return fmt.Sprintf("%dм", minutes)
+	}
+	return fmt.Sprintf("%dч %dм", hours, minutes)
+}
@@ -0,0 +1,47 @@
+// internal/core/domain/task_worklog_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_LogWork(t *testing.T) {
+	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
+
+	workLog, err := task.LogWork("user-2", 90*time.Minute, time.Now(), "Analysis", true)
+	require.NoError(t, err)
+	_, err = task.LogWork("user-2", 30*time.Minute, time.Now(), "", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2*time.Hour, task.TimeSpent())
+	assert.Equal(t, 90*time.Minute, task.BillableTime())
+	assert.Equal(t, "work_logged", task.History[len(task.History)-1].Type)
+
+	_, err = task.LogWork("user-2", 30*time.Second, time.Now(), "", false)
+	assert.Error(t, err)
+
+	require.NoError(t, task.RemoveWorkLog(workLog.ID, "user-2"))
+	assert.Equal(t, 30*time.Minute, task.TimeSpent())
+	assert.Error(t, task.RemoveWorkLog(workLog.ID, "user-2"))
+}
+
+func TestTask_Timer(t *testing.T) {
+	task, _ := NewTask(TaskTypeInternal, "Test", "Desc", "user-1", nil)
+
+	require.NoError(t, task.StartTimer("user-2"))
+	assert.Error(t, task.StartTimer("user-2"))
+	assert.NotNil(t, task.FindTimer("user-2"))
+
+	workLog, err := task.StopTimer("user-2", "Call", true)
+	require.NoError(t, err)
+	assert.Equal(t, MinWorkLogDuration, workLog.Duration) // Короткая работа округляется до минуты
+	assert.Nil(t, task.FindTimer("user-2"))
+	assert.Len(t, task.WorkLogs, 1)
+
+	_, err = task.StopTimer("user-2", "", false)
+	assert.Error(t, err)
+}
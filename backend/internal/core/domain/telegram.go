@@ -0,0 +1,123 @@
+// internal/core/domain/telegram.go
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TelegramChannelKey ключ канала в SourceMeta задач из Telegram.
+// Задачи используют те же ключи SourceMeta, что и входящие каналы:
+// external_thread_id - ID чата, external_id - "<чат>:<сообщение>"
+const TelegramChannelKey = "telegram"
+
+// TelegramCustomerEmailDomain домен служебных адресов клиентов из Telegram.
+// Зарезервированный домен .invalid гарантирует, что на адрес ничего не отправится
+const TelegramCustomerEmailDomain = "telegram.invalid"
+
+// TelegramAttachmentKind тип вложения сообщения Telegram
+type TelegramAttachmentKind string
+
+const (
+	TelegramAttachmentPhoto    TelegramAttachmentKind = "photo"
+	TelegramAttachmentDocument TelegramAttachmentKind = "document"
+)
+
+// TelegramAttachment файл сообщения, хранящийся на стороне Telegram
+type TelegramAttachment struct {
+	Kind     TelegramAttachmentKind
+	FileID   string
+	FileName string
+	MimeType string
+	Size     int64
+}
+
+// TelegramMessage входящее сообщение чата с ботом
+type TelegramMessage struct {
+	UpdateID    int64
+	ChatID      int64
+	MessageID   int64
+	Username    string
+	FirstName   string
+	LastName    string
+	Text        string // Текст или подпись к файлу
+	Attachments []TelegramAttachment
+	SentAt      time.Time
+}
+
+// ExternalID уникальный ID сообщения: ID сообщений уникальны только в пределах чата
+func (m *TelegramMessage) ExternalID() string {
+	return fmt.Sprintf("%d:%d", m.ChatID, m.MessageID)
+}
+
+// ThreadID ID обсуждения - чат целиком
+func (m *TelegramMessage) ThreadID() string {
+	return strconv.FormatInt(m.ChatID, 10)
+}
+
+// DisplayName имя отправителя для карточки клиента
+func (m *TelegramMessage) DisplayName() string {
+	name := strings.TrimSpace(m.FirstName + " " + m.LastName)
+	switch {
+	case name != "":
+		return name
+	case m.Username != "":
+		return "@" + m.Username
+	default:
+		return fmt.Sprintf("Telegram %d", m.ChatID)
+	}
+}
+
+// Subject тема новой задачи: первая строка текста, не длиннее 100 символов
+func (m *TelegramMessage) Subject() string {
+	line, _, _ := strings.Cut(strings.TrimSpace(m.Text), "\n")
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "Сообщение из Telegram от " + m.DisplayName()
+	}
+	if runes := []rune(line); len(runes) > 100 {
+		return string(runes[:100]) + "…"
+	}
+	return line
+}
+
+// SourceMeta мета-данные задачи, созданной сообщением
+func (m *TelegramMessage) SourceMeta() map[string]interface{} {
+	meta := map[string]interface{}{
+		SourceMetaChannel:          TelegramChannelKey,
+		SourceMetaExternalID:       m.ExternalID(),
+		SourceMetaExternalIDs:      []string{m.ExternalID()},
+		SourceMetaExternalThreadID: m.ThreadID(),
+	}
+	if m.Username != "" {
+		meta["telegram_username"] = m.Username
+	}
+	return meta
+}
+
+// TelegramCustomerEmail служебный адрес клиента, привязанного к чату.
+// Клиент находится по нему при каждом сообщении, поэтому чат всегда соответствует одному клиенту
+func TelegramCustomerEmail(chatID int64) string {
+	return fmt.Sprintf("tg-%d@%s", chatID, TelegramCustomerEmailDomain)
+}
+
+// TelegramChatID возвращает ID чата задачи, созданной из Telegram
+func TelegramChatID(task *Task) (int64, bool) {
+	if task.Source != SourceTelegram || task.SourceMeta[SourceMetaChannel] != TelegramChannelKey {
+		return 0, false
+	}
+	threadID, _ := task.SourceMeta[SourceMetaExternalThreadID].(string)
+	chatID, err := strconv.ParseInt(threadID, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return chatID, true
+}
+
+// IsConversationOpen проверяет, продолжает ли задача переписку в чате.
+// После закрытия или отмены новое сообщение клиента открывает новую задачу
+func (t *Task) IsConversationOpen() bool {
+	return t.Status != TaskStatusClosed && t.Status != TaskStatusCancelled
+}
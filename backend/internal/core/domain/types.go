@@ -10,7 +10,7 @@ const (
 	SourceWebForm  TaskSource = "web_form" // Веб-форма
 	SourceAPI      TaskSource = "api"      // API
 	SourceInternal TaskSource = "internal" // Внутренняя
-	SourceGitHub   TaskSource = "github"   // GitHub (для будущего)
+	SourceGitHub   TaskSource = "github"   // GitHub issues
 )
 
 // Priority представляет приоритет задачи
@@ -23,6 +23,32 @@ const (
 	PriorityCritical Priority = "critical" // Критический
 )
 
+// IsValid проверяет, что приоритет известен
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank порядковый вес приоритета для сортировки: чем важнее, тем больше
+func (p Priority) Rank() int {
+	switch p {
+	case PriorityLow:
+		return 1
+	case PriorityMedium:
+		return 2
+	case PriorityHigh:
+		return 3
+	case PriorityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
 // ParticipantRole представляет роль участника
 type ParticipantRole string
 
@@ -34,6 +60,17 @@ const (
 	RoleParticipant ParticipantRole = "participant" // Участник
 )
 
+// IsManageable проверяет, можно ли назначить роль через управление участниками.
+// Автор и исполнитель задаются самой задачей (создание и назначение).
+func (r ParticipantRole) IsManageable() bool {
+	switch r {
+	case RoleReviewer, RoleWatcher, RoleParticipant:
+		return true
+	default:
+		return false
+	}
+}
+
 // MessageType представляет тип сообщения
 type MessageType string
 
@@ -42,3 +79,46 @@ const (
 	MessageTypeInternal MessageType = "internal" // Внутреннее сообщение
 	MessageTypeSystem   MessageType = "system"   // Системное сообщение
 )
+
+// TaskLinkType представляет тип связи между задачами
+type TaskLinkType string
+
+const (
+	LinkBlocks       TaskLinkType = "blocks"        // Блокирует
+	LinkBlockedBy    TaskLinkType = "blocked_by"    // Заблокирована
+	LinkRelatesTo    TaskLinkType = "relates_to"    // Связана с
+	LinkDuplicateOf  TaskLinkType = "duplicate_of"  // Дубликат
+	LinkDuplicatedBy TaskLinkType = "duplicated_by" // Имеет дубликат
+	LinkCausedBy     TaskLinkType = "caused_by"     // Вызвана
+	LinkCauses       TaskLinkType = "causes"        // Является причиной
+)
+
+// Inverse возвращает обратный тип связи для второй задачи
+func (l TaskLinkType) Inverse() TaskLinkType {
+	switch l {
+	case LinkBlocks:
+		return LinkBlockedBy
+	case LinkBlockedBy:
+		return LinkBlocks
+	case LinkDuplicateOf:
+		return LinkDuplicatedBy
+	case LinkDuplicatedBy:
+		return LinkDuplicateOf
+	case LinkCausedBy:
+		return LinkCauses
+	case LinkCauses:
+		return LinkCausedBy
+	default:
+		return l
+	}
+}
+
+// IsValid проверяет, что тип связи известен
+func (l TaskLinkType) IsValid() bool {
+	switch l {
+	case LinkBlocks, LinkBlockedBy, LinkRelatesTo, LinkDuplicateOf, LinkDuplicatedBy, LinkCausedBy, LinkCauses:
+		return true
+	default:
+		return false
+	}
+}
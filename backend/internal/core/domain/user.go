@@ -0,0 +1,254 @@
+// internal/core/domain/user.go
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Ошибки управления пользователями
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserExists        = errors.New("user with this email already exists")
+	ErrUserInactive      = errors.New("user is not active")
+	ErrInvalidInvitation = errors.New("invalid or expired invitation")
+	ErrLastAdmin         = errors.New("at least one active admin is required")
+)
+
+// InvitationTTL срок действия приглашения
+const InvitationTTL = 7 * 24 * time.Hour
+
+// UserStatus состояние учетной записи
+type UserStatus string
+
+const (
+	UserStatusInvited     UserStatus = "invited"     // Приглашен, пароль еще не задан
+	UserStatusActive      UserStatus = "active"      // Работает с задачами
+	UserStatusDeactivated UserStatus = "deactivated" // Отключен: вход и назначение задач запрещены
+)
+
+// OutOfOffice период отсутствия пользователя
+type OutOfOffice struct {
+	From    time.Time
+	Until   time.Time
+	Message string // Сообщение для коллег, например кто замещает
+}
+
+// IsActive проверяет, что период отсутствия идет сейчас
+func (o *OutOfOffice) IsActive(now time.Time) bool {
+	return o != nil && !now.Before(o.From) && now.Before(o.Until)
+}
+
+// NewUser создает приглашенного пользователя
+func NewUser(email, name string, role UserRole) (*User, error) {
+	email = NormalizeUserEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil || email == "" {
+		return nil, fmt.Errorf("invalid email: %q", email)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("user name is required")
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("invalid user role: %s", role)
+	}
+
+	now := time.Now()
+	return &User{
+		ID:        GenerateUserID(),
+		Email:     email,
+		Name:      name,
+		Role:      role,
+		Status:    UserStatusInvited,
+		Language:  DefaultTemplateLanguage,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// NormalizeUserEmail приводит email к виду, в котором он хранится и ищется
+func NormalizeUserEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsActive проверяет, что пользователь может работать в системе
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
+
+// IsAvailable проверяет, что пользователь активен и не отсутствует
+func (u *User) IsAvailable(now time.Time) bool {
+	return u.IsActive() && !u.OutOfOffice.IsActive(now)
+}
+
+// CanBeAssigned проверяет, что пользователю можно назначить задачу.
+// Отсутствие не запрещает ручное назначение, но исключает пользователя из автоматического распределения
+func (u *User) CanBeAssigned() error {
+	if !u.IsActive() {
+		return fmt.Errorf("%w: %s", ErrUserInactive, u.ID)
+	}
+	return nil
+}
+
+// HasSkill проверяет наличие навыка без учета регистра
+func (u *User) HasSkill(skill string) bool {
+	return slices.ContainsFunc(u.Skills, func(s string) bool {
+		return strings.EqualFold(s, skill)
+	})
+}
+
+// Location часовой пояс пользователя; при пустом или неизвестном поясе - часовой пояс сервера
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// SetProfile обновляет имя, часовой пояс, язык и подпись
+func (u *User) SetProfile(name, timezone string, language TemplateLanguage, signature string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("user name is required")
+	}
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid timezone: %s", timezone)
+		}
+	}
+	if language == "" {
+		language = DefaultTemplateLanguage
+	}
+	if !language.IsValid() {
+		return fmt.Errorf("invalid language: %s", language)
+	}
+
+	u.Name = name
+	u.Timezone = timezone
+	u.Language = language
+	u.Signature = strings.TrimSpace(signature)
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetRouting задает навыки и категории, по которым пользователю распределяются задачи
+func (u *User) SetRouting(skills, categories []string) {
+	u.Skills = normalizeUserTags(skills)
+	u.Categories = normalizeUserTags(categories)
+	u.UpdatedAt = time.Now()
+}
+
+// SetOutOfOffice задает период отсутствия; nil отменяет отсутствие
+func (u *User) SetOutOfOffice(ooo *OutOfOffice) error {
+	if ooo != nil {
+		if !ooo.Until.After(ooo.From) {
+			return errors.New("out of office end must be after start")
+		}
+		if !ooo.Until.After(time.Now()) {
+			return errors.New("out of office end must be in the future")
+		}
+		ooo.Message = strings.TrimSpace(ooo.Message)
+	}
+	u.OutOfOffice = ooo
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// Invite назначает хеш токена приглашения
+func (u *User) Invite(invitationHash string, now time.Time) error {
+	if u.Status != UserStatusInvited {
+		return fmt.Errorf("cannot invite user in status %s", u.Status)
+	}
+	expiresAt := now.Add(InvitationTTL)
+	u.InvitationHash = invitationHash
+	u.InvitationExpiresAt = &expiresAt
+	u.UpdatedAt = now
+	return nil
+}
+
+// AcceptInvitation активирует приглашенного пользователя с заданным хешем пароля
+func (u *User) AcceptInvitation(passwordHash string, now time.Time) error {
+	if u.Status != UserStatusInvited || u.InvitationExpiresAt == nil || !now.Before(*u.InvitationExpiresAt) {
+		return ErrInvalidInvitation
+	}
+	u.PasswordHash = passwordHash
+	u.Status = UserStatusActive
+	u.InvitationHash = ""
+	u.InvitationExpiresAt = nil
+	u.UpdatedAt = now
+	return nil
+}
+
+// Activate активирует пользователя, которому пароль задан администратором
+func (u *User) Activate(now time.Time) {
+	u.Status = UserStatusActive
+	u.InvitationHash = ""
+	u.InvitationExpiresAt = nil
+	u.DeactivatedAt = nil
+	u.UpdatedAt = now
+}
+
+// Deactivate отключает пользователя
+func (u *User) Deactivate(now time.Time) error {
+	if u.Status == UserStatusDeactivated {
+		return errors.New("user is already deactivated")
+	}
+	// Приглашение сохраняется, чтобы при возврате пользователь остался приглашенным;
+	// принять его в отключенном состоянии нельзя
+	u.Status = UserStatusDeactivated
+	u.DeactivatedAt = &now
+	u.UpdatedAt = now
+	return nil
+}
+
+// Reactivate возвращает отключенного пользователя в прежний статус:
+// не принявший приглашение пользователь снова становится приглашенным
+func (u *User) Reactivate(now time.Time) error {
+	if u.Status != UserStatusDeactivated {
+		return errors.New("user is not deactivated")
+	}
+	u.DeactivatedAt = nil
+	u.UpdatedAt = now
+	if u.InvitationHash != "" {
+		u.Status = UserStatusInvited
+		return nil
+	}
+	u.Status = UserStatusActive
+	return nil
+}
+
+// normalizeUserTags убирает пустые значения и дубликаты
+func normalizeUserTags(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// HashInvitationToken хеш токена приглашения для поиска; сам токен не хранится
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+var userSeq atomic.Uint64
+
+// GenerateUserID генерирует ID пользователя
+func GenerateUserID() string {
+	return fmt.Sprintf("USR-%d-%d", time.Now().UnixNano(), userSeq.Add(1))
+}
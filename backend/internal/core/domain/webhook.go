@@ -0,0 +1,240 @@
+// internal/core/domain/webhook.go
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Ошибки вебхуков
+var (
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrWebhookDeliveryExists событие уже поставлено в очередь подписки (повторная доставка из outbox)
+	ErrWebhookDeliveryExists = errors.New("webhook delivery already exists")
+)
+
+// DomainEventWebhookTest тестовое событие, отправляемое по запросу пользователя.
+// Не входит в DomainEventTypes: на него нельзя подписаться
+const DomainEventWebhookTest DomainEventType = "webhook.test"
+
+// WebhookSignatureHeader заголовок с подписью тела запроса
+const WebhookSignatureHeader = "X-URMS-Signature"
+
+// minWebhookSecretLength минимальная длина секрета подписи
+const minWebhookSecretLength = 16
+
+// WebhookSubscription подписка внешней системы на доменные события.
+// Пустой фильтр означает отсутствие ограничения
+type WebhookSubscription struct {
+	ID          string
+	Name        string
+	URL         string
+	Secret      string
+	EventTypes  []DomainEventType
+	TaskTypes   []TaskType
+	CustomerIDs []string
+	Active      bool
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewWebhookSubscription создает активную подписку
+func NewWebhookSubscription(name, targetURL, secret string, createdBy string) (*WebhookSubscription, error) {
+	now := time.Now()
+	subscription := &WebhookSubscription{
+		ID:        GenerateWebhookID(),
+		Name:      strings.TrimSpace(name),
+		URL:       strings.TrimSpace(targetURL),
+		Secret:    secret,
+		Active:    true,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Validate проверяет адрес, секрет и фильтры подписки
+func (s *WebhookSubscription) Validate() error {
+	if s.Name == "" {
+		return errors.New("webhook name is required")
+	}
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook URL: %s", s.URL)
+	}
+	if len(s.Secret) < minWebhookSecretLength {
+		return fmt.Errorf("webhook secret must be at least %d characters", minWebhookSecretLength)
+	}
+	for _, eventType := range s.EventTypes {
+		if !eventType.IsValid() {
+			return fmt.Errorf("unknown event type: %s", eventType)
+		}
+	}
+	for _, taskType := range s.TaskTypes {
+		if taskType != TaskTypeSupport && taskType != TaskTypeInternal && taskType != TaskTypeSubTask {
+			return fmt.Errorf("invalid task type: %s", taskType)
+		}
+	}
+	return nil
+}
+
+// Matches проверяет, должно ли событие быть отправлено подписчику.
+// Фильтры по типу задачи и клиенту применяются к атрибутам события
+func (s *WebhookSubscription) Matches(event DomainEvent) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.EventTypes) > 0 && !slices.Contains(s.EventTypes, event.Type) {
+		return false
+	}
+	if len(s.TaskTypes) > 0 && !slices.Contains(s.TaskTypes, TaskType(event.Data["task_type"])) {
+		return false
+	}
+	if len(s.CustomerIDs) > 0 && !slices.Contains(s.CustomerIDs, event.Data["customer_id"]) {
+		return false
+	}
+	return true
+}
+
+// SignWebhookPayload возвращает значение заголовка X-URMS-Signature: sha256=<hex HMAC-SHA256 тела>
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature проверяет подпись тела запроса (для получателей и тестов)
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhookPayload(secret, body)), []byte(signature))
+}
+
+// WebhookDeliveryStatus статус доставки события подписчику
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"     // Ожидает первой или повторной попытки
+	WebhookDeliverySucceeded  WebhookDeliveryStatus = "succeeded"   // Получатель ответил 2xx
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "dead_letter" // Попытки исчерпаны или ошибка постоянная
+)
+
+// IsValid проверяет допустимость статуса
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliverySucceeded, WebhookDeliveryDeadLetter:
+		return true
+	}
+	return false
+}
+
+// WebhookAttempt результат одной попытки доставки
+type WebhookAttempt struct {
+	At         time.Time
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
+
+// WebhookDelivery доставка одного события одному подписчику вместе с журналом попыток
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      DomainEventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       []WebhookAttempt
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewWebhookDelivery ставит событие в очередь доставки подписчику
+func NewWebhookDelivery(subscriptionID string, event DomainEvent, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             GenerateWebhookDeliveryID(),
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// IsDue проверяет, пора ли выполнять попытку
+func (d *WebhookDelivery) IsDue(now time.Time) bool {
+	return d.Status == WebhookDeliveryPending && !d.NextAttemptAt.After(now)
+}
+
+// AttemptCount число выполненных попыток
+func (d *WebhookDelivery) AttemptCount() int {
+	return len(d.Attempts)
+}
+
+// LastAttempt последняя попытка доставки или nil
+func (d *WebhookDelivery) LastAttempt() *WebhookAttempt {
+	if len(d.Attempts) == 0 {
+		return nil
+	}
+	return &d.Attempts[len(d.Attempts)-1]
+}
+
+// RecordSuccess фиксирует успешную попытку
+func (d *WebhookDelivery) RecordSuccess(attempt WebhookAttempt) {
+	d.Attempts = append(d.Attempts, attempt)
+	d.Status = WebhookDeliverySucceeded
+	d.DeliveredAt = &attempt.At
+	d.UpdatedAt = attempt.At
+}
+
+// RecordFailure фиксирует неудачную попытку; без следующей попытки доставка уходит в dead letter
+func (d *WebhookDelivery) RecordFailure(attempt WebhookAttempt, nextAttemptAt *time.Time) {
+	d.Attempts = append(d.Attempts, attempt)
+	d.UpdatedAt = attempt.At
+	if nextAttemptAt == nil {
+		d.Status = WebhookDeliveryDeadLetter
+		return
+	}
+	d.NextAttemptAt = *nextAttemptAt
+}
+
+// Requeue возвращает доставку из dead letter в очередь
+func (d *WebhookDelivery) Requeue(now time.Time) error {
+	if d.Status != WebhookDeliveryDeadLetter {
+		return fmt.Errorf("only dead letter deliveries can be retried, current status: %s", d.Status)
+	}
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+	return nil
+}
+
+var webhookSeq atomic.Uint64
+
+// GenerateWebhookID генерирует ID подписки
+func GenerateWebhookID() string {
+	return fmt.Sprintf("WH-%d", time.Now().UnixNano())
+}
+
+// GenerateWebhookDeliveryID генерирует ID доставки
+func GenerateWebhookDeliveryID() string {
+	return fmt.Sprintf("WHD-%d-%d", time.Now().UnixNano(), webhookSeq.Add(1))
+}
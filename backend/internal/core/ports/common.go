@@ -22,8 +22,56 @@ type CorrelationKeyType string
 const (
 	// CorrelationIDKey ключ для хранения correlation ID в context
 	CorrelationIDKey CorrelationKeyType = "correlation_id"
+	// AuditSourceKey ключ для хранения источника изменений (api, email, automation) в context
+	AuditSourceKey CorrelationKeyType = "audit_source"
+	// AuthUserKey ключ для хранения аутентифицированного пользователя в context
+	AuthUserKey CorrelationKeyType = "auth_user"
+	// SystemPrincipalKey ключ признака системного контекста
+	SystemPrincipalKey CorrelationKeyType = "system_principal"
 )
 
+// CorrelationIDFromContext возвращает correlation ID из context
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(CorrelationIDKey).(string)
+	return correlationID
+}
+
+// WithAuditSource сохраняет в context источник изменений для журнала аудита
+func WithAuditSource(ctx context.Context, source domain.AuditSource) context.Context {
+	return context.WithValue(ctx, AuditSourceKey, source)
+}
+
+// AuditSourceFromContext возвращает источник изменений; по умолчанию system
+func AuditSourceFromContext(ctx context.Context) domain.AuditSource {
+	if source, ok := ctx.Value(AuditSourceKey).(domain.AuditSource); ok && source.IsValid() {
+		return source
+	}
+	return domain.AuditSourceSystem
+}
+
+// WithAuthenticatedUser сохраняет в context пользователя запроса
+func WithAuthenticatedUser(ctx context.Context, user *domain.User) context.Context {
+	return context.WithValue(ctx, AuthUserKey, user)
+}
+
+// AuthenticatedUser возвращает пользователя запроса; false - системный или анонимный контекст
+func AuthenticatedUser(ctx context.Context) (*domain.User, bool) {
+	user, ok := ctx.Value(AuthUserKey).(*domain.User)
+	return user, ok && user != nil
+}
+
+// WithSystemPrincipal помечает context как системный: фоновые задачи и интеграции,
+// подлинность которых подтверждена подписью или секретом. Системный контекст имеет все права
+func WithSystemPrincipal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SystemPrincipalKey, true)
+}
+
+// IsSystemPrincipal проверяет, выполняется ли context от имени системы
+func IsSystemPrincipal(ctx context.Context) bool {
+	system, _ := ctx.Value(SystemPrincipalKey).(bool)
+	return system
+}
+
 // DomainIDGenerator адаптер для доменного IDGenerator
 // Реализует domain.IDGenerator из domain слоя
 type DomainIDGenerator interface {
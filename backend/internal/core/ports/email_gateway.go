@@ -29,6 +29,11 @@ type EmailGateway interface {
 	GetMailboxInfo(ctx context.Context, name string) (*MailboxInfo, error)
 }
 
+// EmailSender определяет отправку исходящих писем (реализуется EmailService)
+type EmailSender interface {
+	SendEmail(ctx context.Context, msg domain.EmailMessage) error
+}
+
 // EmailRepository определяет контракт для хранения email сообщений
 type EmailRepository interface {
 	// Basic CRUD
@@ -42,11 +47,50 @@ type EmailRepository interface {
 	FindUnprocessed(ctx context.Context) ([]domain.EmailMessage, error)
 	FindByPeriod(ctx context.Context, from, to time.Time) ([]domain.EmailMessage, error)
 
+	// FindByQuery возвращает страницу писем, подходящих под фильтры, в порядке query.Sort:
+	// после query.Cursor, а без курсора - со смещения query.Offset; Limit <= 0 - до конца списка.
+	// Порядок и курсор совпадают с domain.EmailListing
+	FindByQuery(ctx context.Context, query EmailQuery) (*EmailQueryPage, error)
+
 	// Thread-related queries (для будущей интеграции с TicketManagement)
 	FindByInReplyTo(ctx context.Context, inReplyTo string) ([]domain.EmailMessage, error)
 	FindByReferences(ctx context.Context, references []string) ([]domain.EmailMessage, error)
 }
 
+// EmailQuery критерии поиска писем
+type EmailQuery struct {
+	Processed       *bool
+	Directions      []domain.Direction
+	Sources         []string
+	From            string // Адрес отправителя без учета регистра
+	RelatedTicketID *string
+	CreatedAt       domain.TimeRange
+	// Исключающие фильтры
+	ExcludeDirections []domain.Direction
+	ExcludeSources    []string
+	Sort              []domain.SortField
+	Cursor            string
+	Offset            int
+	Limit             int
+}
+
+// EmailQueryPage страница писем, выбранная репозиторием
+type EmailQueryPage struct {
+	Emails     []domain.EmailMessage
+	TotalCount int // Писем, подходящих под фильтры
+	Start      int // Позиция первого письма страницы среди них
+}
+
+// EmailSearchResult страница писем
+type EmailSearchResult struct {
+	Emails     []domain.EmailMessage
+	TotalCount int
+	Page       int
+	PageSize   int
+	TotalPages int
+	NextCursor string // Пустой на последней странице
+}
+
 // EmailConfigProvider для управления конфигурацией email каналов
 type EmailConfigProvider interface {
 	GetConfig(ctx context.Context, channelID string) (*domain.EmailChannelConfig, error)
@@ -55,6 +99,16 @@ type EmailConfigProvider interface {
 	ValidateConfig(ctx context.Context, config *domain.EmailChannelConfig) error
 }
 
+// ReconfigurableEmailGateway шлюз, параметры подключения которого меняются без перезапуска
+type ReconfigurableEmailGateway interface {
+	EmailGateway
+	// Reconfigure закрывает текущее соединение и переключает шлюз на новый канал
+	Reconfigure(ctx context.Context, config *domain.EmailChannelConfig) error
+}
+
+// EmailGatewayFactory создает отдельный шлюз для канала (проверка соединения, переключение)
+type EmailGatewayFactory func(config *domain.EmailChannelConfig) (EmailGateway, error)
+
 // Supporting types for EmailGateway
 type FetchCriteria struct {
 	Since      time.Time
@@ -0,0 +1,93 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// UnitOfWork выполняет функцию в одной транзакции хранилища.
+// Репозитории, поддерживающие транзакции, берут ее из переданного context
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EventPublisher публикует доменные события.
+// Реализация через outbox сохраняет события в транзакции из context,
+// поэтому событие записывается тогда и только тогда, когда записано изменение
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...domain.DomainEvent) error
+}
+
+// EventHandler обработчик доменного события.
+// Доставка не менее одного раза: обработчик должен быть идемпотентен по event.ID
+type EventHandler func(ctx context.Context, event domain.DomainEvent) error
+
+// EventBus шина доменных событий внутри процесса
+type EventBus interface {
+	// Subscribe регистрирует обработчик; domain.DomainEventTypeAll - все события
+	Subscribe(eventType domain.DomainEventType, name string, handler EventHandler)
+	// Dispatch передает событие всем подписчикам и возвращает ошибки обработчиков
+	Dispatch(ctx context.Context, event domain.DomainEvent) error
+}
+
+// OutboxRepository хранилище исходящих событий
+type OutboxRepository interface {
+	Append(ctx context.Context, messages ...domain.OutboxMessage) error
+	// ClaimPending выбирает ожидающие события, срок доставки которых наступил,
+	// и переносит их следующую попытку на leaseUntil, чтобы их не взял другой экземпляр
+	ClaimPending(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]domain.OutboxMessage, error)
+	MarkDispatched(ctx context.Context, eventID string, at time.Time) error
+	// Reschedule фиксирует неудачную попытку и назначает следующую
+	Reschedule(ctx context.Context, eventID string, lastError string, nextAttemptAt time.Time) error
+	// MarkFailed фиксирует последнюю неудачную попытку; событие больше не доставляется
+	MarkFailed(ctx context.Context, eventID string, lastError string) error
+	// DeleteDispatchedBefore удаляет доставленные события старше указанного момента
+	DeleteDispatchedBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// OutboxRelayResult итог одного прохода доставки
+type OutboxRelayResult struct {
+	Dispatched int
+	Retried    int
+	Failed     int
+	Purged     int
+}
+
+// OutboxRelay доставляет события из outbox подписчикам шины
+type OutboxRelay interface {
+	RelayPending(ctx context.Context, now time.Time) (*OutboxRelayResult, error)
+}
+
+// TaskStreamPublisher публикует изменения задач подписчикам потока.
+// Публикация не блокируется медленными подписчиками и не возвращает ошибку
+type TaskStreamPublisher interface {
+	Publish(ctx context.Context, event domain.TaskStreamEvent)
+}
+
+// TaskStreamAcceptFunc отбирает событие для подписчика и подготавливает его
+// (например, скрывает недоступные данные); вызывается при публикации
+type TaskStreamAcceptFunc func(event domain.TaskStreamEvent) (domain.TaskStreamEvent, bool)
+
+// TaskStreamBroker шина изменений задач внутри процесса. Последние события хранятся
+// в ограниченном буфере, чтобы переподключившийся клиент получил пропущенное
+type TaskStreamBroker interface {
+	TaskStreamPublisher
+	// Subscribe подписывает на события с ID больше lastEventID (0 - только новые)
+	Subscribe(lastEventID uint64, accept TaskStreamAcceptFunc) TaskStreamSubscription
+}
+
+// TaskStreamSubscription подписка на поток изменений задач
+type TaskStreamSubscription interface {
+	// Replay события из буфера после lastEventID, отобранные для подписчика
+	Replay() []domain.TaskStreamEvent
+	// Incomplete сообщает, что часть событий после lastEventID уже вытеснена из буфера
+	// и клиенту нужно перечитать данные
+	Incomplete() bool
+	// Events новые события; канал закрывается при Close или отключении отстающего подписчика
+	Events() <-chan domain.TaskStreamEvent
+	// Err причина закрытия канала: domain.ErrTaskStreamLagged, если подписчик не успевал читать
+	Err() error
+	Close()
+}
@@ -16,6 +16,13 @@ type MessageProcessor interface {
 	ProcessOutgoingEmail(ctx context.Context, email domain.EmailMessage) error
 }
 
+// TaskMessageProcessor процессор, который сообщает задачу, созданную или дополненную письмом.
+// EmailService записывает ее ID в RelatedTicketID письма
+type TaskMessageProcessor interface {
+	MessageProcessor
+	ProcessIncomingEmailForTask(ctx context.Context, email domain.EmailMessage) (taskID string, err error)
+}
+
 // ProcessingResult результат обработки сообщения
 type ProcessingResult struct {
 	Success      bool
@@ -3,6 +3,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
 )
@@ -23,6 +24,7 @@ type TaskRepository interface {
 	FindByType(ctx context.Context, taskType domain.TaskType) ([]domain.Task, error)
 	FindOpenTasks(ctx context.Context) ([]domain.Task, error)
 	FindSubtasks(ctx context.Context, parentID string) ([]domain.Task, error)
+	FindByParticipantID(ctx context.Context, userID string) ([]domain.Task, error)
 	// Email threading support
 	FindBySourceMeta(ctx context.Context, meta map[string]interface{}) ([]domain.Task, error)
 
@@ -40,6 +42,8 @@ type CustomerRepository interface {
 	FindByID(ctx context.Context, id string) (*domain.Customer, error)
 	FindByEmail(ctx context.Context, email string) (*domain.Customer, error)
 	FindByOrganization(ctx context.Context, orgID string) ([]domain.Customer, error)
+	// FindByQuery возвращает клиентов, подходящих под фильтры, в порядке query.Sort
+	FindByQuery(ctx context.Context, query CustomerQuery) ([]domain.Customer, error)
 	Update(ctx context.Context, customer *domain.Customer) error
 	Delete(ctx context.Context, id string) error
 }
@@ -48,12 +52,173 @@ type CustomerRepository interface {
 type UserRepository interface {
 	FindByID(ctx context.Context, id string) (*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	// FindAssignees возвращает активных и присутствующих операторов и менеджеров
 	FindAssignees(ctx context.Context) ([]domain.User, error)
+	// FindAll возвращает всех пользователей, упорядоченных по имени
+	FindAll(ctx context.Context) ([]domain.User, error)
+	// FindByInvitationHash возвращает domain.ErrInvalidInvitation, если приглашения нет
+	FindByInvitationHash(ctx context.Context, hash string) (*domain.User, error)
 	Save(ctx context.Context, user *domain.User) error
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id string) error
 }
 
+// CustomFieldRepository определяет контракт для хранения описаний пользовательских полей
+type CustomFieldRepository interface {
+	Save(ctx context.Context, def *domain.CustomFieldDefinition) error
+	FindByKey(ctx context.Context, key string) (*domain.CustomFieldDefinition, error)
+	FindAll(ctx context.Context) ([]domain.CustomFieldDefinition, error)
+	Update(ctx context.Context, def *domain.CustomFieldDefinition) error
+	Delete(ctx context.Context, key string) error
+}
+
+// ReplyTemplateRepository определяет контракт для хранения шаблонов ответов
+type ReplyTemplateRepository interface {
+	Save(ctx context.Context, tpl *domain.ReplyTemplate) error
+	FindByID(ctx context.Context, id string) (*domain.ReplyTemplate, error)
+	FindAll(ctx context.Context) ([]domain.ReplyTemplate, error)
+	Update(ctx context.Context, tpl *domain.ReplyTemplate) error
+	Delete(ctx context.Context, id string) error
+}
+
+// RecurringTaskRepository определяет контракт для хранения правил повторяющихся задач
+type RecurringTaskRepository interface {
+	Save(ctx context.Context, rt *domain.RecurringTask) error
+	FindByID(ctx context.Context, id string) (*domain.RecurringTask, error)
+	FindAll(ctx context.Context) ([]domain.RecurringTask, error)
+	FindDue(ctx context.Context, now time.Time) ([]domain.RecurringTask, error)
+	Update(ctx context.Context, rt *domain.RecurringTask) error
+	Delete(ctx context.Context, id string) error
+}
+
+// ScheduledReplyRepository определяет контракт для хранения запланированных ответов
+type ScheduledReplyRepository interface {
+	Save(ctx context.Context, reply *domain.ScheduledReply) error
+	FindByID(ctx context.Context, id string) (*domain.ScheduledReply, error)
+	FindByTaskID(ctx context.Context, taskID string) ([]domain.ScheduledReply, error)
+	FindDue(ctx context.Context, now time.Time) ([]domain.ScheduledReply, error)
+	Update(ctx context.Context, reply *domain.ScheduledReply) error
+}
+
+// AuditRepository определяет контракт для журнала аудита.
+// Журнал только дополняется: изменение и удаление записей не поддерживаются
+type AuditRepository interface {
+	Append(ctx context.Context, events ...domain.AuditEvent) error
+	Query(ctx context.Context, query AuditQuery) ([]domain.AuditEvent, int, error)
+}
+
+// AuditQuery параметры выборки из журнала аудита; пустые поля не фильтруют
+type AuditQuery struct {
+	EntityType    domain.AuditEntityType
+	EntityID      string
+	ActorID       string
+	Type          string
+	Source        domain.AuditSource
+	CorrelationID string
+	From          *time.Time
+	To            *time.Time
+	Newest        bool // Сначала новые записи; по умолчанию в хронологическом порядке
+	Offset        int
+	Limit         int // 0 - без ограничения
+}
+
+// APIKeyRepository определяет контракт для хранения ключей API
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *domain.APIKey) error
+	// FindByHash возвращает domain.ErrAPIKeyNotFound, если ключа нет
+	FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	FindByUserID(ctx context.Context, userID string) ([]domain.APIKey, error)
+	Update(ctx context.Context, key *domain.APIKey) error
+}
+
+// WebhookRepository определяет контракт для хранения подписок на вебхуки
+type WebhookRepository interface {
+	Save(ctx context.Context, subscription *domain.WebhookSubscription) error
+	FindByID(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]domain.WebhookSubscription, error)
+	FindActive(ctx context.Context) ([]domain.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *domain.WebhookSubscription) error
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookDeliveryRepository определяет контракт для очереди и журнала доставок вебхуков
+type WebhookDeliveryRepository interface {
+	// Save добавляет доставку; повтор пары подписка+событие возвращает domain.ErrWebhookDeliveryExists
+	Save(ctx context.Context, delivery *domain.WebhookDelivery) error
+	FindByID(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	Query(ctx context.Context, query WebhookDeliveryQuery) ([]domain.WebhookDelivery, int, error)
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
+
+// WebhookDeliveryQuery параметры выборки журнала доставок; новые доставки первыми
+type WebhookDeliveryQuery struct {
+	SubscriptionID string
+	Status         domain.WebhookDeliveryStatus
+	Offset         int
+	Limit          int // 0 - без ограничения
+}
+
+// InboundChannelRepository определяет контракт для хранения входящих каналов
+type InboundChannelRepository interface {
+	// Save добавляет канал; занятый ключ возвращает domain.ErrInboundChannelExists
+	Save(ctx context.Context, channel *domain.InboundChannel) error
+	FindByKey(ctx context.Context, key string) (*domain.InboundChannel, error)
+	FindAll(ctx context.Context) ([]domain.InboundChannel, error)
+	Update(ctx context.Context, channel *domain.InboundChannel) error
+	Delete(ctx context.Context, key string) error
+}
+
+// EmailSettingsRepository определяет контракт для хранения настроек email:
+// конфигураций каналов, политики обработки и окон поиска тредов
+type EmailSettingsRepository interface {
+	// SaveChannel создает или заменяет конфигурацию канала
+	SaveChannel(ctx context.Context, config *domain.EmailChannelConfig) error
+	FindChannel(ctx context.Context, id string) (*domain.EmailChannelConfig, error)
+	FindChannels(ctx context.Context) ([]domain.EmailChannelConfig, error)
+	DeleteChannel(ctx context.Context, id string) error
+	// FindPolicy возвращает domain.ErrEmailSettingsNotFound, если политика не сохранялась
+	FindPolicy(ctx context.Context) (*domain.EmailProcessingPolicy, error)
+	SavePolicy(ctx context.Context, policy *domain.EmailProcessingPolicy) error
+	// FindThreadSearch возвращает domain.ErrEmailSettingsNotFound, если окна поиска не сохранялись
+	FindThreadSearch(ctx context.Context) (*ThreadSearchConfig, error)
+	SaveThreadSearch(ctx context.Context, config *ThreadSearchConfig) error
+}
+
+// IdempotencyStore хранит ответы на запросы с ключом идемпотентности.
+// Reserve должен быть атомарным: из конкурентных запросов с одним ключом ключ получает один
+type IdempotencyStore interface {
+	// Reserve занимает ключ за выполняющимся запросом (StatusCode = 0). Если ключ занят
+	// действующей записью, возвращает ее и false; запись с истекшим сроком заменяется
+	Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error)
+	// Complete сохраняет ответ запроса, занявшего ключ, и новый срок хранения
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) error
+	// Extend продлевает блокировку ключа выполняющегося запроса до expiresAt;
+	// для завершенного или освобожденного ключа ничего не делает
+	Extend(ctx context.Context, key string, expiresAt time.Time) error
+	// Release освобождает ключ выполняющегося запроса, чтобы его можно было повторить
+	Release(ctx context.Context, key string) error
+	// DeleteExpired удаляет записи с истекшим сроком; возвращает число удаленных
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// InboundDeliveryStore хранит принятые записи входящих каналов для защиты от повторной доставки.
+// Reserve должен быть атомарным: из конкурентных доставок одной записи ключ получает одна
+type InboundDeliveryStore interface {
+	// Reserve занимает ключ за обрабатываемой записью (TaskID пуст). Если ключ занят
+	// действующей записью, возвращает ее и false; запись с истекшим сроком заменяется
+	Reserve(ctx context.Context, delivery *domain.InboundDelivery) (*domain.InboundDelivery, bool, error)
+	// Complete сохраняет задачу, принявшую запись, и новый срок хранения
+	Complete(ctx context.Context, key, taskID string, expiresAt time.Time) error
+	// Extend продлевает блокировку ключа обрабатываемой записи до expiresAt;
+	// для принятой или освобожденной записи ничего не делает
+	Extend(ctx context.Context, key string, expiresAt time.Time) error
+	// Release освобождает ключ обрабатываемой записи, чтобы доставку можно было повторить
+	Release(ctx context.Context, key string) error
+	// DeleteExpired удаляет записи с истекшим сроком; возвращает число удаленных
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
 // KnowledgeRepository определяет контракт для работы с базой знаний
 type KnowledgeRepository interface {
 	SaveDocument(ctx context.Context, doc *domain.KnowledgeDocument) error
@@ -94,13 +259,49 @@ type TaskQuery struct {
 	Category   string
 	ParentID   *string // Для поиска подзадач
 	ProjectID  *string // Для поиска по проектам
-	DateFrom   *string
-	DateTo     *string
 	SearchText string
-	Offset     int
-	Limit      int
-	SortBy     string
-	SortOrder  string // "asc" or "desc"
+	// Фильтры по датам; границы включаются
+	CreatedAt  domain.TimeRange
+	UpdatedAt  domain.TimeRange
+	DueDate    domain.TimeRange
+	ResolvedAt domain.TimeRange
+	// Исключающие фильтры: задачи с любым из значений не попадают в выдачу
+	ExcludeTypes       []domain.TaskType
+	ExcludeStatuses    []domain.TaskStatus
+	ExcludePriorities  []domain.Priority
+	ExcludeSources     []domain.TaskSource
+	ExcludeCategories  []string
+	ExcludeAssigneeIDs []string
+	ExcludeTags        []string
+	// CustomFields фильтр по значениям пользовательских полей (ключ → значение)
+	CustomFields map[string]string
+	// Snoozed true - только отложенные задачи, false - без отложенных, nil - все
+	Snoozed *bool
+	// SnoozeExpiredBy задачи, срок откладывания которых истек к этому моменту
+	SnoozeExpiredBy *time.Time
+	// Scope ограничение видимости задач пользователя; nil - без ограничений
+	Scope  *domain.TaskScope
+	Offset int
+	Limit  int
+	// Cursor курсор страницы из предыдущего ответа; имеет приоритет над Offset
+	Cursor string
+	// Sort сортировка по нескольким полям (domain.TaskSortFields)
+	Sort []domain.SortField
+	// SortBy поддерживает сортировку по пользовательскому полю: "custom_fields.<key>".
+	// Устаревший вариант Sort из одного поля
+	SortBy    string
+	SortOrder string // "asc" or "desc"
+}
+
+// SortFields сортировка запроса: Sort, иначе SortBy/SortOrder
+func (q TaskQuery) SortFields() []domain.SortField {
+	if len(q.Sort) > 0 {
+		return q.Sort
+	}
+	if q.SortBy != "" {
+		return []domain.SortField{{Field: q.SortBy, Desc: q.SortOrder == "desc"}}
+	}
+	return nil
 }
 
 // KnowledgeQuery представляет критерии поиска в базе знаний
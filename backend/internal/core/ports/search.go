@@ -0,0 +1,42 @@
+// internal/core/ports/search.go
+package ports
+
+import (
+	"context"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// SearchIndex полнотекстовый индекс задач, писем и клиентов
+type SearchIndex interface {
+	// Index добавляет документы или заменяет ранее проиндексированные
+	Index(ctx context.Context, docs ...domain.SearchDocument) error
+	// Remove удаляет документ сущности; отсутствие документа не ошибка
+	Remove(ctx context.Context, entityType domain.SearchEntityType, entityID string) error
+	// Search возвращает документы по убыванию релевантности
+	Search(ctx context.Context, query SearchQuery) (*SearchResult, error)
+}
+
+// SearchQuery критерии полнотекстового поиска.
+// Фасеты считаются по всем найденным документам без учета фильтров Statuses, Priorities
+// и Categories, чтобы показывать число результатов для соседних значений
+type SearchQuery struct {
+	Text        string
+	EntityTypes []domain.SearchEntityType // Пусто - все типы
+	Statuses    []string
+	Priorities  []string
+	Categories  []string
+	// Scope ограничение видимости задач пользователя; nil - без ограничений
+	Scope *domain.TaskScope
+	// IncludePrivate искать также во внутренних заметках
+	IncludePrivate bool
+	Offset         int
+	Limit          int
+}
+
+// SearchResult страница результатов поиска с фасетами
+type SearchResult struct {
+	Hits       []domain.SearchHit
+	TotalCount int
+	Facets     domain.SearchFacets
+}
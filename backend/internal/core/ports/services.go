@@ -3,6 +3,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
 )
@@ -23,7 +24,27 @@ type TaskService interface {
 	// Status management
 	ChangeStatus(ctx context.Context, id string, status domain.TaskStatus, userID string) (*domain.Task, error)
 	AssignTask(ctx context.Context, id string, assigneeID string, userID string) (*domain.Task, error)
-	AddParticipant(ctx context.Context, id string, userID string, role domain.ParticipantRole) (*domain.Task, error)
+	SnoozeTask(ctx context.Context, id string, until time.Time, userID string) (*domain.Task, error)
+	UnsnoozeTask(ctx context.Context, id string, userID string) (*domain.Task, error)
+
+	// Participants
+	AddParticipant(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error)
+	RemoveParticipant(ctx context.Context, id string, participantID string, userID string) (*domain.Task, error)
+	ChangeParticipantRole(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error)
+	WatchTask(ctx context.Context, id string, userID string) (*domain.Task, error)
+	UnwatchTask(ctx context.Context, id string, userID string) (*domain.Task, error)
+
+	// Links and dependencies
+	LinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error)
+	UnlinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error)
+	GetSubtaskProgress(ctx context.Context, parentID string) (*SubtaskProgress, error)
+
+	// Time tracking
+	LogWork(ctx context.Context, id string, req LogWorkRequest) (*domain.Task, error)
+	DeleteWorkLog(ctx context.Context, id string, workLogID string, userID string) (*domain.Task, error)
+	StartTimer(ctx context.Context, id string, userID string) (*domain.Task, error)
+	StopTimer(ctx context.Context, id string, req StopTimerRequest) (*domain.Task, error)
+	GetTimeReport(ctx context.Context, query TimeReportQuery) (*TimeReport, error)
 
 	// Communication
 	AddMessage(ctx context.Context, id string, req AddMessageRequest) (*domain.Task, error)
@@ -40,17 +61,275 @@ type TaskService interface {
 
 	// Analytics
 	GetStats(ctx context.Context, query StatsQuery) (*TaskStats, error)
-	GetDashboard(ctx context.Context, userID string) (*UserDashboard, error)
+	GetDashboard(ctx context.Context, userID string, userRole domain.UserRole) (*UserDashboard, error)
 
 	// Automation
 	AutoAssignTasks(ctx context.Context) ([]AutoAssignmentResult, error)
 	ProcessEscalations(ctx context.Context) ([]EscalationResult, error)
+	WakeSnoozedTasks(ctx context.Context, now time.Time) ([]string, error)
 
 	// Bulk operations
 	BulkUpdateStatus(ctx context.Context, taskIDs []string, status domain.TaskStatus, userID string) ([]BulkOperationResult, error)
 	BulkAssign(ctx context.Context, taskIDs []string, assigneeID string, userID string) ([]BulkOperationResult, error)
 }
 
+// CustomFieldService определяет управление описаниями пользовательских полей
+type CustomFieldService interface {
+	CreateField(ctx context.Context, req CustomFieldRequest) (*domain.CustomFieldDefinition, error)
+	GetField(ctx context.Context, key string) (*domain.CustomFieldDefinition, error)
+	ListFields(ctx context.Context) ([]domain.CustomFieldDefinition, error)
+	ListFieldsFor(ctx context.Context, taskType domain.TaskType, category string) ([]domain.CustomFieldDefinition, error)
+	UpdateField(ctx context.Context, key string, req CustomFieldRequest) (*domain.CustomFieldDefinition, error)
+	DeleteField(ctx context.Context, key string) error
+}
+
+// ReplyTemplateService определяет управление шаблонами ответов и их применение к задачам
+type ReplyTemplateService interface {
+	CreateTemplate(ctx context.Context, req ReplyTemplateRequest) (*domain.ReplyTemplate, error)
+	GetTemplate(ctx context.Context, id string) (*domain.ReplyTemplate, error)
+	ListTemplates(ctx context.Context, query ReplyTemplateQuery) ([]domain.ReplyTemplate, error)
+	UpdateTemplate(ctx context.Context, id string, req ReplyTemplateRequest) (*domain.ReplyTemplate, error)
+	DeleteTemplate(ctx context.Context, id string) error
+
+	// RenderTemplate подставляет данные задачи в шаблон без изменения задачи (предпросмотр)
+	RenderTemplate(ctx context.Context, req RenderTemplateRequest) (*RenderedReply, error)
+	// ApplyTemplate отправляет ответ по шаблону; в режиме макроса также применяет его действия
+	ApplyTemplate(ctx context.Context, req ApplyTemplateRequest) (*AppliedTemplateResult, error)
+}
+
+// SchedulerService определяет поведение задач во времени: повторяющиеся задачи,
+// запланированные ответы и возврат отложенных задач в работу
+type SchedulerService interface {
+	CreateRecurringTask(ctx context.Context, req RecurringTaskRequest) (*domain.RecurringTask, error)
+	GetRecurringTask(ctx context.Context, id string) (*domain.RecurringTask, error)
+	ListRecurringTasks(ctx context.Context) ([]domain.RecurringTask, error)
+	UpdateRecurringTask(ctx context.Context, id string, req RecurringTaskRequest) (*domain.RecurringTask, error)
+	DeleteRecurringTask(ctx context.Context, id string) error
+
+	ScheduleReply(ctx context.Context, req ScheduleReplyRequest) (*domain.ScheduledReply, error)
+	GetScheduledReplies(ctx context.Context, taskID string) ([]domain.ScheduledReply, error)
+	CancelScheduledReply(ctx context.Context, id string) (*domain.ScheduledReply, error)
+
+	// RunDueJobs выполняет все наступившие к моменту now действия
+	RunDueJobs(ctx context.Context, now time.Time) (*SchedulerRunResult, error)
+}
+
+// SatisfactionService определяет опросы удовлетворенности клиентов (CSAT)
+type SatisfactionService interface {
+	// SendSurvey отправляет клиенту опрос по решенной задаче
+	SendSurvey(ctx context.Context, taskID string) (*domain.Task, error)
+	// SubmitResponse записывает ответ клиента по подписанной ссылке из письма
+	SubmitResponse(ctx context.Context, req SurveyResponseRequest) (*domain.Task, error)
+	// GetSurvey проверяет ссылку опроса и возвращает задачу, не записывая оценку
+	GetSurvey(ctx context.Context, token string) (*domain.Task, error)
+	GetSatisfactionReport(ctx context.Context, query SatisfactionQuery) (*SatisfactionReport, error)
+}
+
+// AuditService определяет операции чтения журнала аудита
+type AuditService interface {
+	// GetTaskHistory возвращает журнал изменений задачи в хронологическом порядке
+	GetTaskHistory(ctx context.Context, taskID string) ([]domain.AuditEvent, error)
+	// QueryEvents выполняет выборку по всему журналу
+	QueryEvents(ctx context.Context, query AuditQuery) (*AuditQueryResult, error)
+}
+
+// WebhookService определяет управление подписками на вебхуки и их доставку
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, req CreateWebhookRequest) (*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id string, req UpdateWebhookRequest) (*domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	// SendTestEvent синхронно отправляет подписчику тестовое событие и возвращает результат
+	SendTestEvent(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, query WebhookDeliveryQuery) (*WebhookDeliveryList, error)
+	// RetryDelivery возвращает доставку из dead letter в очередь
+	RetryDelivery(ctx context.Context, subscriptionID, deliveryID string) (*domain.WebhookDelivery, error)
+	// DeliverDue выполняет наступившие попытки доставки (вызывается фоновой задачей)
+	DeliverDue(ctx context.Context, now time.Time) (*WebhookDeliveryResult, error)
+}
+
+// WebhookSender отправляет подписанный запрос получателю вебхука
+type WebhookSender interface {
+	// Send возвращает код ответа; ошибка означает, что ответ не получен
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+// InboundChannelService определяет входящие каналы для создания задач из внешних систем
+type InboundChannelService interface {
+	CreateChannel(ctx context.Context, req CreateInboundChannelRequest) (*domain.InboundChannel, error)
+	UpdateChannel(ctx context.Context, key string, req UpdateInboundChannelRequest) (*domain.InboundChannel, error)
+	GetChannel(ctx context.Context, key string) (*domain.InboundChannel, error)
+	ListChannels(ctx context.Context) ([]domain.InboundChannel, error)
+	DeleteChannel(ctx context.Context, key string) error
+	// Receive принимает payload внешней системы: создает задачу, дополняет задачу
+	// того же обсуждения или распознает повтор уже принятой записи
+	Receive(ctx context.Context, key string, body []byte, signature string) (*InboundResult, error)
+}
+
+// TelegramService ведет переписку с клиентами через Telegram-бота
+type TelegramService interface {
+	// HandleMessage принимает сообщение чата: дополняет открытую задачу чата,
+	// создает новую или распознает повтор уже принятого сообщения
+	HandleMessage(ctx context.Context, message domain.TelegramMessage) (*InboundResult, error)
+	// PollUpdates получает и обрабатывает очередную порцию обновлений (long polling)
+	PollUpdates(ctx context.Context) (int, error)
+	// HandleTaskEvent отправляет в чат публичные ответы операторов (подписчик шины событий)
+	HandleTaskEvent(ctx context.Context, event domain.DomainEvent) error
+}
+
+// TelegramGateway клиент Bot API
+type TelegramGateway interface {
+	// GetUpdates ждет новые сообщения до timeout и возвращает смещение следующего запроса.
+	// Обновления без сообщений пропускаются, но учитываются в смещении
+	GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]domain.TelegramMessage, int64, error)
+	SendMessage(ctx context.Context, chatID int64, text string) error
+	// SendAttachment отправляет изображение фотографией, остальные файлы - документом
+	SendAttachment(ctx context.Context, chatID int64, attachment domain.Attachment) error
+	DownloadFile(ctx context.Context, fileID string) ([]byte, error)
+}
+
+// GitHubService синхронизирует задачи с issue GitHub: создает связанные issue,
+// принимает issue отслеживаемых репозиториев и отражает комментарии и статусы в обе стороны
+type GitHubService interface {
+	// LinkIssue создает issue для задачи и связывает их
+	LinkIssue(ctx context.Context, taskID string, req LinkGitHubIssueRequest) (*domain.Task, error)
+	// HandleWebhookEvent применяет событие webhook (подпись уже проверена)
+	HandleWebhookEvent(ctx context.Context, event domain.GitHubWebhookEvent) error
+	// SyncRepositories забирает изменения отслеживаемых репозиториев (фоновая задача)
+	SyncRepositories(ctx context.Context) (int, error)
+	// HandleTaskEvent отправляет в GitHub комментарии и изменения статуса (подписчик шины событий)
+	HandleTaskEvent(ctx context.Context, event domain.DomainEvent) error
+}
+
+// GitHubGateway клиент REST API GitHub
+type GitHubGateway interface {
+	CreateIssue(ctx context.Context, repo, title, body string) (*domain.GitHubIssue, error)
+	CreateComment(ctx context.Context, repo string, number int, body string) (*domain.GitHubComment, error)
+	SetIssueState(ctx context.Context, repo string, number int, state string) error
+	// ListIssues возвращает issue и pull request'ы, измененные начиная с since
+	ListIssues(ctx context.Context, repo string, since time.Time) ([]domain.GitHubIssue, error)
+	// ListComments возвращает комментарии репозитория, измененные начиная с since
+	ListComments(ctx context.Context, repo string, since time.Time) ([]domain.GitHubComment, error)
+}
+
+type LinkGitHubIssueRequest struct {
+	Repo    string // Пустой - репозиторий по умолчанию
+	Title   string // Пустой - тема задачи
+	Body    string // Пустой - описание задачи
+	ActorID string
+}
+
+// AuthService аутентифицирует пользователей API: вход по паролю с выдачей JWT
+// и долгоживущие ключи API для интеграций
+type AuthService interface {
+	Login(ctx context.Context, email, password string) (*AuthTokens, error)
+	// Refresh выдает новую пару токенов по refresh-токену
+	Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error)
+	// AuthenticateToken возвращает пользователя access-токена
+	AuthenticateToken(ctx context.Context, accessToken string) (*domain.User, error)
+	// AuthenticateAPIKey возвращает пользователя, от имени которого действует ключ
+	AuthenticateAPIKey(ctx context.Context, key string) (*domain.User, error)
+	// IssueStreamToken выдает короткоживущий токен подключения к потоку изменений.
+	// Браузерный EventSource не передает заголовки, поэтому токен передается в адресе
+	// и годится только для подключения к потоку
+	IssueStreamToken(ctx context.Context, userID string) (string, time.Time, error)
+	// AuthenticateStreamToken возвращает пользователя токена потока
+	AuthenticateStreamToken(ctx context.Context, streamToken string) (*domain.User, error)
+	// SetPassword задает пароль без проверки текущего (администрирование, начальная настройка)
+	SetPassword(ctx context.Context, userID, password string) error
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error
+	// CreateAPIKey создает ключ; значение ключа возвращается только здесь
+	CreateAPIKey(ctx context.Context, userID, name string, expiresAt *time.Time) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID string) error
+}
+
+// Authorizer проверяет права пользователя запроса (AuthenticatedUser) на уровне сервисов,
+// поэтому проверки действуют для любого вызывающего кода, а не только для HTTP.
+// Системный context (WithSystemPrincipal: фоновые задачи, обработка почты, интеграции)
+// имеет все права; context без пользователя и без признака системы не имеет прав
+type Authorizer interface {
+	// Can проверяет наличие права
+	Can(ctx context.Context, permission domain.Permission) bool
+	// Authorize возвращает ошибку domain.ErrForbidden, если права нет
+	Authorize(ctx context.Context, permission domain.Permission) error
+	// AuthorizeTask дополнительно проверяет, что задача видна пользователю
+	AuthorizeTask(ctx context.Context, permission domain.Permission, task *domain.Task) error
+	// TaskScope ограничение видимости задач; nil - без ограничений (системный context)
+	TaskScope(ctx context.Context) *domain.TaskScope
+}
+
+// PasswordHasher хеширует и проверяет пароли
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) bool
+}
+
+// TokenSigner подписывает и проверяет токены доступа
+type TokenSigner interface {
+	Sign(claims domain.AuthClaims) (string, error)
+	// Parse проверяет подпись и срок действия токена
+	Parse(token string) (*domain.AuthClaims, error)
+}
+
+// AuthTokens пара токенов, выданная при входе
+type AuthTokens struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// UserService управляет пользователями системы: приглашение, роли, профиль,
+// параметры распределения задач и отсутствие
+type UserService interface {
+	// CreateUser создает пользователя. С паролем пользователь сразу активен,
+	// без пароля - приглашен; токен приглашения возвращается только здесь
+	CreateUser(ctx context.Context, req CreateUserRequest) (*domain.User, string, error)
+	// AcceptInvitation задает пароль по токену приглашения и активирует пользователя
+	AcceptInvitation(ctx context.Context, token, password string) (*domain.User, error)
+	// ResendInvitation выдает новый токен приглашения взамен прежнего
+	ResendInvitation(ctx context.Context, id string) (*domain.User, string, error)
+	GetUser(ctx context.Context, id string) (*domain.User, error)
+	ListUsers(ctx context.Context, query UserQuery) ([]domain.User, error)
+	UpdateProfile(ctx context.Context, id string, req UpdateUserProfileRequest) (*domain.User, error)
+	ChangeRole(ctx context.Context, id string, role domain.UserRole) (*domain.User, error)
+	// SetRouting задает навыки и категории задач пользователя
+	SetRouting(ctx context.Context, id string, skills, categories []string) (*domain.User, error)
+	// SetOutOfOffice задает период отсутствия; nil отменяет отсутствие
+	SetOutOfOffice(ctx context.Context, id string, ooo *domain.OutOfOffice) (*domain.User, error)
+	Deactivate(ctx context.Context, id string) (*domain.User, error)
+	Reactivate(ctx context.Context, id string) (*domain.User, error)
+}
+
+type CreateUserRequest struct {
+	Email      string
+	Name       string
+	Role       domain.UserRole
+	Password   string // Пустой - пользователь приглашается
+	Timezone   string
+	Language   domain.TemplateLanguage
+	Skills     []string
+	Categories []string
+}
+
+type UpdateUserProfileRequest struct {
+	Name      string
+	Timezone  string
+	Language  domain.TemplateLanguage
+	Signature string
+}
+
+// UserQuery фильтры списка пользователей; пустые поля не ограничивают выборку
+type UserQuery struct {
+	Role      domain.UserRole
+	Status    domain.UserStatus
+	Skill     string
+	Category  string
+	Available *bool // Только активные и присутствующие (true) или остальные (false)
+}
+
 // CustomerService определяет бизнес-операции с клиентами
 type CustomerService interface {
 	CreateCustomer(ctx context.Context, req CreateCustomerRequest) (*domain.Customer, error)
@@ -61,6 +340,105 @@ type CustomerService interface {
 	ListCustomers(ctx context.Context, query CustomerQuery) (*CustomerSearchResult, error)
 }
 
+// EmailMessageService определяет операции с сохраненными письмами
+type EmailMessageService interface {
+	ListEmails(ctx context.Context, query EmailQuery) (*EmailSearchResult, error)
+	GetEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error)
+	GetEmailStatistics(ctx context.Context) (*EmailStatistics, error)
+	// ReprocessEmail повторно обрабатывает письмо и связывает его с задачей, созданной или дополненной им
+	ReprocessEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error)
+	// LinkEmail вручную связывает письмо с задачей, UnlinkEmail убирает связь
+	LinkEmail(ctx context.Context, id domain.MessageID, taskID string) (*domain.EmailMessage, error)
+	UnlinkEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error)
+}
+
+// EmailStatistics статистика по email сообщениям
+type EmailStatistics struct {
+	UnprocessedCount int        `json:"unprocessed_count"`
+	RecentCount      int        `json:"recent_count"`
+	LastProcessed    *time.Time `json:"last_processed"`
+}
+
+// EmailSettingsService администрирование email: каналы, политика обработки и окна
+// поиска тредов. Изменения сохраняются и применяются без перезапуска
+type EmailSettingsService interface {
+	ListChannels(ctx context.Context) ([]domain.EmailChannelConfig, error)
+	GetChannel(ctx context.Context, id string) (*domain.EmailChannelConfig, error)
+	// SaveChannel создает или заменяет канал; пустой пароль сохраняет прежний.
+	// Активный канал сразу применяется к шлюзу, остальные каналы становятся неактивными
+	SaveChannel(ctx context.Context, config domain.EmailChannelConfig) (*domain.EmailChannelConfig, error)
+	// DeleteChannel удаляет неактивный канал
+	DeleteChannel(ctx context.Context, id string) error
+	// TestChannel проверяет подключение с параметрами сохраненного канала
+	TestChannel(ctx context.Context, id string) (*EmailConnectionTestResult, error)
+	GetPolicy(ctx context.Context) (*domain.EmailProcessingPolicy, error)
+	UpdatePolicy(ctx context.Context, policy domain.EmailProcessingPolicy) (*domain.EmailProcessingPolicy, error)
+	GetThreadSearchConfig(ctx context.Context) (*ThreadSearchConfig, error)
+	UpdateThreadSearchConfig(ctx context.Context, config ThreadSearchConfig) (*ThreadSearchConfig, error)
+}
+
+// EmailConnectionTestResult результат проверки подключения канала
+type EmailConnectionTestResult struct {
+	ChannelID string
+	Success   bool
+	Error     string
+	Duration  time.Duration
+}
+
+// TaskStreamRequest параметры подписки на поток изменений задач
+type TaskStreamRequest struct {
+	Filter      domain.TaskStreamFilter
+	LastEventID uint64 // ID последнего полученного события при переподключении; 0 - только новые
+}
+
+// TaskStreamService поток изменений задач с учетом прав пользователя из context
+type TaskStreamService interface {
+	Subscribe(ctx context.Context, req TaskStreamRequest) (TaskStreamSubscription, error)
+}
+
+// IdempotencyService повтор ответов на запросы с заголовком Idempotency-Key
+type IdempotencyService interface {
+	// Begin начинает запрос. Возвращает сохраненный ответ, если запрос с ключом уже выполнен,
+	// или nil, если запрос нужно выполнить и затем вызвать Complete или Abort.
+	// Другое тело с тем же ключом - domain.ErrIdempotencyKeyReused, запрос с ключом
+	// еще выполняется - domain.ErrIdempotencyRequestInProgress
+	Begin(ctx context.Context, key, requestHash string) (*domain.IdempotencyRecord, error)
+	// KeepAlive продлевает блокировку ключа, пока запрос выполняется, чтобы дубль не занял
+	// ключ после истечения блокировки у долгого запроса. Вызывается после Begin, вернувшего nil;
+	// stop прекращает продление и вызывается до Complete или Abort
+	KeepAlive(ctx context.Context, key string) (stop func())
+	// Complete сохраняет первый ответ для повторов
+	Complete(ctx context.Context, key string, statusCode int, headers map[string]string, body []byte) error
+	// Abort освобождает ключ, если ответ не должен повторяться (ошибка сервера)
+	Abort(ctx context.Context, key string) error
+	// Cleanup удаляет записи с истекшим сроком хранения
+	Cleanup(ctx context.Context) (int, error)
+}
+
+// InboundDeliveryService защита входящих каналов от повторной доставки одной внешней записи
+type InboundDeliveryService interface {
+	// Begin начинает обработку записи. Возвращает ID задачи, если запись уже принята,
+	// или пустую строку, если запись нужно обработать и затем вызвать Complete или Abort.
+	// Запись еще обрабатывается другой доставкой - domain.ErrInboundDeliveryInProgress
+	Begin(ctx context.Context, channelKey, externalID string) (string, error)
+	// KeepAlive продлевает блокировку записи, пока она обрабатывается. Вызывается после Begin,
+	// вернувшего пустую строку; stop прекращает продление и вызывается до Complete или Abort
+	KeepAlive(ctx context.Context, channelKey, externalID string) (stop func())
+	// Complete сохраняет задачу, принявшую запись
+	Complete(ctx context.Context, channelKey, externalID, taskID string) error
+	// Abort освобождает запись, чтобы повторная доставка обработала ее заново
+	Abort(ctx context.Context, channelKey, externalID string) error
+	// Cleanup удаляет записи с истекшим сроком хранения
+	Cleanup(ctx context.Context) (int, error)
+}
+
+// SearchService определяет полнотекстовый поиск по задачам, письмам и клиентам
+type SearchService interface {
+	Search(ctx context.Context, query SearchQuery) (*SearchResult, error)
+	// Reindex заново индексирует все задачи, письма и клиентов; возвращает число документов
+	Reindex(ctx context.Context) (int, error)
+}
+
 // KnowledgeService определяет сервис для работы с базой знаний
 type KnowledgeService interface {
 	CreateDocument(ctx context.Context, req CreateDocumentRequest) (*domain.KnowledgeDocument, error)
@@ -116,6 +494,94 @@ type CreateTaskRequest struct {
 	Tags        []string
 	ParentID    *string // Для подзадач
 	ProjectID   *string // Для привязки к проекту
+	// CustomFields значения пользовательских полей (ключ → значение)
+	CustomFields map[string]interface{}
+}
+
+type CustomFieldRequest struct {
+	Key          string
+	Name         string
+	Type         domain.CustomFieldType
+	TaskTypes    []domain.TaskType
+	Categories   []string
+	Required     bool
+	DefaultValue interface{}
+	Options      []string
+}
+
+type RecurringTaskRequest struct {
+	Name        string
+	Schedule    string
+	Timezone    string
+	Subject     string
+	Description string
+	Priority    domain.Priority
+	Category    string
+	Tags        []string
+	AssigneeID  string
+	ReporterID  string
+	Enabled     *bool // Пусто - при создании правило включено, при изменении не меняется
+}
+
+type ScheduleReplyRequest struct {
+	TaskID    string
+	AuthorID  string
+	Content   string
+	IsPrivate bool
+	SendAt    time.Time
+}
+
+// SchedulerRunResult итоги одного прохода планировщика
+type SchedulerRunResult struct {
+	WokenTasks   []string // Отложенные задачи, возвращенные в работу
+	CreatedTasks []string // Задачи, созданные по повторяющимся правилам
+	SentReplies  []string // Отправленные запланированные ответы
+	Errors       []string
+}
+
+type ReplyTemplateRequest struct {
+	Name      string
+	Category  string
+	Variants  map[domain.TemplateLanguage]string
+	Actions   domain.MacroActions
+	CreatedBy string
+}
+
+// ReplyTemplateQuery фильтр шаблонов ответов
+type ReplyTemplateQuery struct {
+	Category string                  // Шаблоны категории вместе с общими шаблонами; пусто - все
+	Language domain.TemplateLanguage // Только шаблоны с вариантом на этом языке
+	Search   string                  // Подстрока в названии
+}
+
+type RenderTemplateRequest struct {
+	TemplateID string
+	TaskID     string
+	OperatorID string
+	Language   domain.TemplateLanguage // Пусто - язык по умолчанию
+}
+
+type ApplyTemplateRequest struct {
+	RenderTemplateRequest
+	IsPrivate bool // Внутренняя заметка вместо ответа клиенту
+	Macro     bool // Применить действия макроса (статус, теги, исполнитель)
+}
+
+// RenderedReply результат подстановки данных задачи в шаблон
+type RenderedReply struct {
+	TemplateID string
+	TaskID     string
+	Language   domain.TemplateLanguage // Фактически использованный вариант
+	Content    string
+	Actions    domain.MacroActions
+}
+
+// AppliedTemplateResult результат применения шаблона к задаче
+type AppliedTemplateResult struct {
+	Task       *domain.Task
+	Reply      RenderedReply
+	EmailSent  bool   // Ответ отправлен клиенту по email
+	EmailError string // Ошибка отправки email (ответ в задаче при этом сохранен)
 }
 
 type CreateSupportTaskRequest struct {
@@ -138,6 +604,7 @@ type CreateInternalTaskRequest struct {
 	Category    string
 	Tags        []string
 	ProjectID   *string
+	SourceMeta  map[string]interface{}
 }
 
 type CreateSubTaskRequest struct {
@@ -151,19 +618,131 @@ type CreateSubTaskRequest struct {
 }
 
 type UpdateTaskRequest struct {
+	UserID      string // Автор изменения; пусто - system
 	Subject     *string
 	Description *string
 	Priority    *domain.Priority
 	Category    *string
 	Tags        *[]string
 	DueDate     *string
+	// CustomFields изменяемые пользовательские поля; значение nil очищает поле
+	CustomFields map[string]interface{}
+}
+
+type LogWorkRequest struct {
+	UserID   string
+	Duration time.Duration
+	Date     time.Time // Пусто - текущая дата
+	Note     string
+	Billable bool
+}
+
+type StopTimerRequest struct {
+	UserID   string
+	Note     string
+	Billable bool
+}
+
+// SurveyResponseRequest ответ клиента на опрос удовлетворенности
+type SurveyResponseRequest struct {
+	Token   string // Подписанный токен из ссылки в письме
+	Rating  int    // 1-5
+	Comment string
+}
+
+// SatisfactionGroupBy способ группировки отчета по удовлетворенности
+type SatisfactionGroupBy string
+
+const (
+	SatisfactionByOperator SatisfactionGroupBy = "operator"
+	SatisfactionByCustomer SatisfactionGroupBy = "customer"
+	SatisfactionByCategory SatisfactionGroupBy = "category"
+	SatisfactionByDay      SatisfactionGroupBy = "day"
+	SatisfactionByWeek     SatisfactionGroupBy = "week"
+	SatisfactionByMonth    SatisfactionGroupBy = "month"
+)
+
+// SatisfactionQuery параметры отчета по удовлетворенности; период - по времени ответа клиента
+type SatisfactionQuery struct {
+	From       time.Time // Включительно
+	To         time.Time // Не включительно
+	GroupBy    SatisfactionGroupBy
+	OperatorID string
+	CustomerID string
+	Category   string
+}
+
+type SatisfactionReport struct {
+	From         time.Time
+	To           time.Time
+	GroupBy      SatisfactionGroupBy
+	Rows         []SatisfactionReportRow
+	Total        SatisfactionSummary
+	SurveysSent  int     // Опросов отправлено за период
+	ResponseRate float64 // Доля отвеченных опросов, %
+}
+
+type SatisfactionReportRow struct {
+	Key  string // ID оператора или клиента, категория либо начало периода (YYYY-MM-DD)
+	Name string
+	SatisfactionSummary
+}
+
+// SatisfactionSummary сводка оценок клиентов
+type SatisfactionSummary struct {
+	Responses     int
+	Positive      int         // Оценки 4 и 5
+	AverageRating float64     // Средняя оценка 1-5
+	CSAT          float64     // Доля удовлетворенных клиентов, %
+	Distribution  map[int]int // Оценка → количество ответов
+}
+
+// TimeReportGroupBy способ группировки отчета по времени
+type TimeReportGroupBy string
+
+const (
+	TimeReportByCustomer     TimeReportGroupBy = "customer"
+	TimeReportByOrganization TimeReportGroupBy = "organization"
+	TimeReportByAssignee     TimeReportGroupBy = "assignee" // Сотрудник, списавший время
+)
+
+// TimeReportQuery параметры отчета по затраченному времени
+type TimeReportQuery struct {
+	From         time.Time // Включительно
+	To           time.Time // Не включительно
+	GroupBy      TimeReportGroupBy
+	CustomerID   string
+	UserID       string
+	BillableOnly bool
+}
+
+type TimeReport struct {
+	From            time.Time
+	To              time.Time
+	GroupBy         TimeReportGroupBy
+	Rows            []TimeReportRow
+	TotalMinutes    int
+	BillableMinutes int
+}
+
+type TimeReportRow struct {
+	Key             string // ID клиента, организации или сотрудника
+	Name            string
+	TotalMinutes    int
+	BillableMinutes int
+	EntryCount      int
+	TaskCount       int
 }
 
 type AddMessageRequest struct {
-	AuthorID  string
-	Content   string
-	Type      domain.MessageType
-	IsPrivate bool
+	AuthorID    string
+	Content     string
+	Type        domain.MessageType
+	IsPrivate   bool
+	Attachments []domain.Attachment
+	// ExternalID внешний ID записи входящего канала: запоминается в той же записи задачи,
+	// что и сообщение; уже принятая запись - domain.ErrInboundDuplicate
+	ExternalID string
 }
 
 type CreateCustomerRequest struct {
@@ -184,8 +763,14 @@ type CustomerQuery struct {
 	SearchText   string
 	Organization string
 	Email        string
-	Offset       int
-	Limit        int
+	CreatedAt    domain.TimeRange
+	UpdatedAt    domain.TimeRange
+	// ExcludeOrganizations клиенты этих организаций не попадают в выдачу
+	ExcludeOrganizations []string
+	Sort                 []domain.SortField
+	Cursor               string
+	Offset               int
+	Limit                int
 }
 
 type CreateDocumentRequest struct {
@@ -289,6 +874,7 @@ type TaskSearchResult struct {
 	Page       int
 	PageSize   int
 	TotalPages int
+	NextCursor string // Пустой на последней странице
 }
 
 type KnowledgeSearchResult struct {
@@ -313,6 +899,7 @@ type CustomerSearchResult struct {
 	Page       int
 	PageSize   int
 	TotalPages int
+	NextCursor string // Пустой на последней странице
 }
 
 type UserTasks struct {
@@ -320,6 +907,14 @@ type UserTasks struct {
 	ReportedTasks   []domain.Task
 	ParticipatingIn []domain.Task
 	Watching        []domain.Task
+	UnassignedTasks []domain.Task // Очередь неназначенных задач (только admin/manager)
+}
+
+// SubtaskProgress сводный прогресс выполнения подзадач
+type SubtaskProgress struct {
+	Total     int     // Подзадачи без учета отмененных
+	Completed int     // Решенные и закрытые подзадачи
+	Percent   float64 // Процент выполнения
 }
 
 type UserDashboard struct {
@@ -327,6 +922,7 @@ type UserDashboard struct {
 	AssignedCount   int
 	UnassignedCount int
 	OverdueCount    int
+	SnoozedCount    int // Отложенные задачи пользователя (не входят в MyTasks)
 	RecentActivity  []DashboardActivity
 	Stats           *UserStats
 }
@@ -343,7 +939,7 @@ type UserStats struct {
 	OpenTasks         int
 	ResolvedToday     int
 	AvgResolutionTime float64
-	SatisfactionRate  float64
+	SatisfactionRate  float64 // CSAT по опросам клиентов, %
 }
 
 type AutoAssignmentResult struct {
@@ -378,9 +974,88 @@ type CustomerStats struct {
 	TotalTasks      int
 	OpenTasks       int
 	AvgResponseTime float64
-	Satisfaction    float64
+	Satisfaction    float64 // CSAT по опросам клиента, %
 	ByPriority      map[domain.Priority]int
 	ByCategory      map[string]int
+	TimeSpentHours  float64 // Суммарное списанное время
+	BillableHours   float64 // Оплачиваемое время
+}
+
+// AuditQueryResult результат выборки из журнала аудита
+type AuditQueryResult struct {
+	Events     []domain.AuditEvent
+	TotalCount int
+}
+
+type CreateWebhookRequest struct {
+	Name        string
+	URL         string
+	Secret      string // Пусто - секрет генерируется
+	EventTypes  []domain.DomainEventType
+	TaskTypes   []domain.TaskType
+	CustomerIDs []string
+	CreatedBy   string
+}
+
+// UpdateWebhookRequest изменяемые параметры подписки; nil - поле не меняется
+type UpdateWebhookRequest struct {
+	Name        *string
+	URL         *string
+	Secret      *string
+	EventTypes  *[]domain.DomainEventType
+	TaskTypes   *[]domain.TaskType
+	CustomerIDs *[]string
+	Active      *bool
+}
+
+// WebhookDeliveryResult итог одного прохода доставки вебхуков
+type WebhookDeliveryResult struct {
+	Succeeded    int
+	Retried      int
+	DeadLettered int
+}
+
+// WebhookDeliveryList страница журнала доставок
+type WebhookDeliveryList struct {
+	Deliveries []domain.WebhookDelivery
+	TotalCount int
+}
+
+type CreateInboundChannelRequest struct {
+	Key             string
+	Name            string
+	Source          domain.TaskSource // Пусто - api
+	Secret          string            // Пусто - подпись не проверяется
+	Mapping         domain.InboundMapping
+	PriorityMap     map[string]domain.Priority
+	DefaultPriority domain.Priority // Пусто - medium
+	DefaultTags     []string
+}
+
+// UpdateInboundChannelRequest изменяемые параметры канала; nil - поле не меняется
+type UpdateInboundChannelRequest struct {
+	Name            *string
+	Secret          *string
+	Mapping         *domain.InboundMapping
+	PriorityMap     *map[string]domain.Priority
+	DefaultPriority *domain.Priority
+	DefaultTags     *[]string
+	Active          *bool
+}
+
+// InboundAction результат приема записи входящего канала
+type InboundAction string
+
+const (
+	InboundActionCreated   InboundAction = "created"   // Создана новая задача
+	InboundActionAppended  InboundAction = "appended"  // Добавлено сообщение в задачу обсуждения
+	InboundActionDuplicate InboundAction = "duplicate" // Запись уже принята ранее
+)
+
+// InboundResult итог приема записи
+type InboundResult struct {
+	Action InboundAction
+	Task   *domain.Task
 }
 
 // Backward compatibility types for email module
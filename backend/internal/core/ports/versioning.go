@@ -0,0 +1,65 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrVersionConflict сущность изменена после загрузки (оптимистическая блокировка)
+// либо не совпала с версией, ожидаемой клиентом (If-Match)
+var ErrVersionConflict = errors.New("version conflict")
+
+// DefaultConflictRetries число попыток повторения операции при конфликте версий
+const DefaultConflictRetries = 3
+
+// VersionPreconditionKey ключ для хранения ожидаемой версии сущности в context
+const VersionPreconditionKey CorrelationKeyType = "version_precondition"
+
+type versionPrecondition struct {
+	entityID string
+	version  int
+	checked  atomic.Bool
+}
+
+// WithVersionPrecondition сохраняет в context версию сущности, которую ожидает клиент
+func WithVersionPrecondition(ctx context.Context, entityID string, version int) context.Context {
+	return context.WithValue(ctx, VersionPreconditionKey, &versionPrecondition{entityID: entityID, version: version})
+}
+
+// CheckVersionPrecondition сверяет версию, на основе которой выполняется запись, с ожидаемой.
+// Проверяется только первая запись сущности: последующие изменения той же операции
+// (например, отправка опроса после смены статуса) основаны на уже проверенной версии
+func CheckVersionPrecondition(ctx context.Context, entityID string, current int) error {
+	precondition, ok := ctx.Value(VersionPreconditionKey).(*versionPrecondition)
+	if !ok || precondition.entityID != entityID {
+		return nil
+	}
+	if !precondition.checked.CompareAndSwap(false, true) {
+		return nil
+	}
+	if precondition.version != current {
+		return fmt.Errorf("%w: %s has version %d, expected %d", ErrVersionConflict, entityID, current, precondition.version)
+	}
+	return nil
+}
+
+// RetryOnConflict повторяет операцию load-modify-update, пока она завершается конфликтом версий.
+// Операция должна заново загружать сущность на каждой попытке
+func RetryOnConflict(ctx context.Context, attempts int, operation func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = operation(); !errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+	}
+	return err
+}
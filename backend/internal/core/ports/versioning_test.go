@@ -0,0 +1,63 @@
+package ports_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVersionPrecondition(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, ports.CheckVersionPrecondition(ctx, "TASK-1", 3))
+
+	ctx = ports.WithVersionPrecondition(ctx, "TASK-1", 2)
+	assert.NoError(t, ports.CheckVersionPrecondition(ctx, "TASK-2", 7), "other entities are not affected")
+
+	err := ports.CheckVersionPrecondition(ctx, "TASK-1", 3)
+	assert.ErrorIs(t, err, ports.ErrVersionConflict)
+
+	// Проверяется только первая запись сущности
+	assert.NoError(t, ports.CheckVersionPrecondition(ctx, "TASK-1", 4))
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries until success", func(t *testing.T) {
+		calls := 0
+		err := ports.RetryOnConflict(ctx, 3, func() error {
+			calls++
+			if calls < 3 {
+				return ports.ErrVersionConflict
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after attempts", func(t *testing.T) {
+		calls := 0
+		err := ports.RetryOnConflict(ctx, 2, func() error {
+			calls++
+			return ports.ErrVersionConflict
+		})
+		assert.ErrorIs(t, err, ports.ErrVersionConflict)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		calls := 0
+		failure := errors.New("boom")
+		err := ports.RetryOnConflict(ctx, 3, func() error {
+			calls++
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 1, calls)
+	})
+}
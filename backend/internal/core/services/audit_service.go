@@ -0,0 +1,246 @@
+// internal/core/services/audit_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// maxAuditPageSize максимальный размер страницы выборки журнала
+const maxAuditPageSize = 500
+
+// AuditService предоставляет доступ к журналу аудита
+type AuditService struct {
+	auditRepo ports.AuditRepository
+	taskRepo  ports.TaskRepository
+	logger    ports.Logger
+}
+
+func NewAuditService(auditRepo ports.AuditRepository, taskRepo ports.TaskRepository, logger ports.Logger) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		taskRepo:  taskRepo,
+		logger:    logger,
+	}
+}
+
+// GetTaskHistory возвращает журнал изменений задачи в хронологическом порядке
+func (s *AuditService) GetTaskHistory(ctx context.Context, taskID string) ([]domain.AuditEvent, error) {
+	if taskID == "" {
+		return nil, errors.New("task ID is required")
+	}
+
+	if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	events, _, err := s.auditRepo.Query(ctx, ports.AuditQuery{
+		EntityType: domain.AuditEntityTask,
+		EntityID:   taskID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task history: %w", err)
+	}
+
+	return events, nil
+}
+
+// QueryEvents выполняет выборку по всему журналу; новые записи идут первыми
+func (s *AuditService) QueryEvents(ctx context.Context, query ports.AuditQuery) (*ports.AuditQueryResult, error) {
+	if query.From != nil && query.To != nil && query.To.Before(*query.From) {
+		return nil, errors.New("invalid period: 'to' is before 'from'")
+	}
+	if query.Source != "" && !query.Source.IsValid() {
+		return nil, fmt.Errorf("invalid audit source: %s", query.Source)
+	}
+	if query.Limit <= 0 || query.Limit > maxAuditPageSize {
+		query.Limit = maxAuditPageSize
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	query.Newest = true
+
+	events, total, err := s.auditRepo.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+
+	return &ports.AuditQueryResult{
+		Events:     events,
+		TotalCount: total,
+	}, nil
+}
+
+// AuditedTaskRepository записывает в журнал аудита события задачи при каждом сохранении
+// и публикует соответствующие доменные события.
+// Автор берется из события, источник и correlation ID - из context запроса
+type AuditedTaskRepository struct {
+	ports.TaskRepository
+	auditRepo ports.AuditRepository
+	publisher ports.EventPublisher
+	uow       ports.UnitOfWork
+	logger    ports.Logger
+}
+
+// NewAuditedTaskRepository оборачивает репозиторий задач записью в журнал аудита
+func NewAuditedTaskRepository(repo ports.TaskRepository, auditRepo ports.AuditRepository, logger ports.Logger) *AuditedTaskRepository {
+	return &AuditedTaskRepository{
+		TaskRepository: repo,
+		auditRepo:      auditRepo,
+		uow:            NoopUnitOfWork{},
+		logger:         logger,
+	}
+}
+
+// SetEventPublisher включает публикацию доменных событий. Изменение задачи, журнал
+// и события записываются в одной единице работы: событие не теряется и не появляется без изменения
+func (r *AuditedTaskRepository) SetEventPublisher(publisher ports.EventPublisher, uow ports.UnitOfWork) {
+	r.publisher = publisher
+	if uow != nil {
+		r.uow = uow
+	}
+}
+
+// Save и Update записывают журнал и события до задачи. Хранилище задач находится в памяти
+// и не участвует в транзакции: при ошибке outbox задача остается нетронутой, а если транзакция
+// не зафиксирована уже после записи задачи, прежнее состояние задачи восстанавливается.
+// События забираются до записи: при ошибке (например, конфликте версий) изменение
+// отброшено вместе с ними, а сохраненная копия задачи не содержит необработанных событий
+func (r *AuditedTaskRepository) Save(ctx context.Context, task *domain.Task) error {
+	previous, err := r.TaskRepository.FindByID(ctx, task.ID)
+	if err != nil {
+		previous = nil // новая задача
+	}
+	return r.write(ctx, task, previous, r.TaskRepository.Save)
+}
+
+func (r *AuditedTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	previous, err := r.TaskRepository.FindByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	// Конфликт версий выявляется до записи событий: outbox в памяти не откатывается
+	if err := ports.CheckVersionPrecondition(ctx, task.ID, task.Version); err != nil {
+		return err
+	}
+	if previous.Version != task.Version {
+		return fmt.Errorf("%w: task %s was modified (version %d, stored %d)",
+			ports.ErrVersionConflict, task.ID, task.Version, previous.Version)
+	}
+	return r.write(ctx, task, previous, r.TaskRepository.Update)
+}
+
+// write выполняет запись в единице работы: журнал, события, затем задача
+func (r *AuditedTaskRepository) write(ctx context.Context, task, previous *domain.Task, store func(context.Context, *domain.Task) error) error {
+	events := task.PullEvents()
+	written := false
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		r.record(ctx, task.ID, events)
+		if err := r.publish(ctx, task, events); err != nil {
+			return err
+		}
+		if err := store(ctx, task); err != nil {
+			return err
+		}
+		written = true
+		return nil
+	})
+	if err != nil && written {
+		r.restore(ctx, task, previous)
+	}
+	return err
+}
+
+// restore отменяет запись задачи, события которой не зафиксированы. Восстановление
+// основано на записанной версии: если задачу уже изменили снова, оно не выполняется.
+// Версия увеличивается, поэтому изменения на основе отмененной версии отклоняются
+func (r *AuditedTaskRepository) restore(ctx context.Context, written, previous *domain.Task) {
+	ctx = context.WithoutCancel(ctx)
+
+	var err error
+	if previous == nil {
+		err = r.TaskRepository.Delete(ctx, written.ID)
+	} else {
+		previous.Version = written.Version
+		err = r.TaskRepository.Update(ctx, previous)
+	}
+	if err != nil {
+		r.logger.Error(ctx, "failed to restore task after rolled back transaction",
+			"task_id", written.ID, "error", err.Error())
+		return
+	}
+	r.logger.Warn(ctx, "task change rolled back: transaction was not committed", "task_id", written.ID)
+}
+
+func (r *AuditedTaskRepository) Delete(ctx context.Context, id string) error {
+	if err := r.TaskRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	event := domain.TaskEvent{
+		ID:        domain.GenerateEventID(),
+		Type:      domain.TaskEventDeleted,
+		UserID:    contextActor(ctx),
+		Timestamp: time.Now(),
+		Message:   "Задача удалена",
+	}
+	r.append(ctx, domain.NewTaskAuditEvent(id, event, ports.AuditSourceFromContext(ctx), ports.CorrelationIDFromContext(ctx)))
+	return nil
+}
+
+// contextActor автор действия, не отраженного в событиях задачи: пользователь запроса.
+// "system" указывается только для системного context
+func contextActor(ctx context.Context) string {
+	if user, ok := ports.AuthenticatedUser(ctx); ok {
+		return user.ID
+	}
+	if ports.IsSystemPrincipal(ctx) {
+		return "system"
+	}
+	return ""
+}
+
+// record переносит события задачи в журнал.
+// Ошибка журнала не отменяет уже сохраненное изменение и только логируется
+func (r *AuditedTaskRepository) record(ctx context.Context, taskID string, events []domain.TaskEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	source := ports.AuditSourceFromContext(ctx)
+	correlationID := ports.CorrelationIDFromContext(ctx)
+
+	auditEvents := make([]domain.AuditEvent, len(events))
+	for i, event := range events {
+		auditEvents[i] = domain.NewTaskAuditEvent(taskID, event, source, correlationID)
+	}
+	r.append(ctx, auditEvents...)
+}
+
+// publish записывает доменные события; ошибка отменяет транзакцию вместе с изменением
+func (r *AuditedTaskRepository) publish(ctx context.Context, task *domain.Task, events []domain.TaskEvent) error {
+	if r.publisher == nil {
+		return nil
+	}
+	domainEvents := domain.TaskDomainEvents(task, events)
+	if len(domainEvents) == 0 {
+		return nil
+	}
+	if err := r.publisher.Publish(ctx, domainEvents...); err != nil {
+		return fmt.Errorf("failed to publish task events: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditedTaskRepository) append(ctx context.Context, events ...domain.AuditEvent) {
+	if err := r.auditRepo.Append(ctx, events...); err != nil {
+		r.logger.Error(ctx, "failed to append audit events",
+			"entity_id", events[0].EntityID, "count", len(events), "error", err.Error())
+	}
+}
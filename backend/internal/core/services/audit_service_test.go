@@ -0,0 +1,122 @@
+// internal/core/services/audit_service_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditService(t *testing.T) {
+	logger := &services.MockLogger{}
+	auditRepo := inmemory.NewAuditRepository(logger)
+	taskRepo := services.NewAuditedTaskRepository(inmemory.NewTaskRepository(logger), auditRepo, logger)
+	taskService := services.NewTaskService(taskRepo, inmemory.NewCustomerRepository(logger), inmemory.NewUserRepository(logger), logger)
+	auditService := services.NewAuditService(auditRepo, taskRepo, logger)
+
+	apiCtx := ports.WithAuditSource(context.WithValue(context.Background(), ports.CorrelationIDKey, "req-1"), domain.AuditSourceAPI)
+
+	task, err := taskService.CreateTask(apiCtx, ports.CreateTaskRequest{
+		Type:        domain.TaskTypeInternal,
+		Subject:     "Настроить VPN",
+		Description: "Описание",
+		ReporterID:  "user-1",
+		Priority:    domain.PriorityMedium,
+	})
+	require.NoError(t, err)
+
+	t.Run("update records field diff with actor and context", func(t *testing.T) {
+		subject := "Настроить VPN для офиса"
+		priority := domain.PriorityHigh
+		_, err := taskService.UpdateTask(apiCtx, task.ID, ports.UpdateTaskRequest{
+			UserID:   "user-2",
+			Subject:  &subject,
+			Priority: &priority,
+		})
+		require.NoError(t, err)
+
+		history, err := auditService.GetTaskHistory(context.Background(), task.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		assert.Equal(t, domain.TaskEventCreated, history[0].Type)
+		updated := history[1]
+		assert.Equal(t, domain.TaskEventUpdated, updated.Type)
+		assert.Equal(t, "user-2", updated.ActorID)
+		assert.Equal(t, domain.AuditSourceAPI, updated.Source)
+		assert.Equal(t, "req-1", updated.CorrelationID)
+		assert.Equal(t, []domain.FieldChange{
+			{Field: "subject", OldValue: "Настроить VPN", NewValue: subject},
+			{Field: "priority", OldValue: "medium", NewValue: "high"},
+		}, updated.Changes)
+	})
+
+	t.Run("automation source is taken from context", func(t *testing.T) {
+		ctx := ports.WithAuditSource(context.Background(), domain.AuditSourceAutomation)
+		_, err := taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusInProgress, "system")
+		require.NoError(t, err)
+
+		result, err := auditService.QueryEvents(context.Background(), ports.AuditQuery{Source: domain.AuditSourceAutomation})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.TotalCount)
+		assert.Equal(t, domain.TaskEventStatusChanged, result.Events[0].Type)
+		assert.Empty(t, result.Events[0].CorrelationID)
+	})
+
+	t.Run("global query filters by user and period, newest first", func(t *testing.T) {
+		result, err := auditService.QueryEvents(context.Background(), ports.AuditQuery{ActorID: "user-1"})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.TotalCount)
+		assert.Equal(t, domain.TaskEventCreated, result.Events[0].Type)
+
+		all, err := auditService.QueryEvents(context.Background(), ports.AuditQuery{EntityType: domain.AuditEntityTask, EntityID: task.ID})
+		require.NoError(t, err)
+		require.Equal(t, 3, all.TotalCount)
+		assert.Equal(t, domain.TaskEventStatusChanged, all.Events[0].Type)
+
+		future := time.Now().Add(time.Hour)
+		result, err = auditService.QueryEvents(context.Background(), ports.AuditQuery{From: &future})
+		require.NoError(t, err)
+		assert.Zero(t, result.TotalCount)
+
+		past := time.Now().Add(-time.Hour)
+		_, err = auditService.QueryEvents(context.Background(), ports.AuditQuery{From: &future, To: &past})
+		assert.Error(t, err)
+	})
+
+	t.Run("delete is recorded and history of unknown task fails", func(t *testing.T) {
+		ctx := ports.WithAuthenticatedUser(apiCtx, &domain.User{ID: "user-3", Role: domain.UserRoleAdmin})
+		require.NoError(t, taskService.DeleteTask(ctx, task.ID))
+
+		result, err := auditService.QueryEvents(context.Background(), ports.AuditQuery{Type: domain.TaskEventDeleted})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.TotalCount)
+		assert.Equal(t, "user-3", result.Events[0].ActorID, "автор удаления - пользователь запроса")
+		assert.Equal(t, task.ID, result.Events[0].EntityID)
+
+		// Удаление из фоновой задачи записывается от имени системы
+		background, err := taskService.CreateTask(apiCtx, ports.CreateTaskRequest{
+			Type:        domain.TaskTypeInternal,
+			Subject:     "Удаляется автоматически",
+			Description: "Описание",
+			ReporterID:  "user-1",
+			Priority:    domain.PriorityLow,
+		})
+		require.NoError(t, err)
+		require.NoError(t, taskService.DeleteTask(ports.WithSystemPrincipal(context.Background()), background.ID))
+		result, err = auditService.QueryEvents(context.Background(), ports.AuditQuery{Type: domain.TaskEventDeleted, EntityID: background.ID})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.TotalCount)
+		assert.Equal(t, "system", result.Events[0].ActorID)
+
+		_, err = auditService.GetTaskHistory(context.Background(), task.ID)
+		assert.Error(t, err)
+	})
+}
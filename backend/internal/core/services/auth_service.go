@@ -0,0 +1,310 @@
+// internal/core/services/auth_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// apiKeyUsageResolution точность отметки последнего использования ключа:
+// запись не выполняется на каждый запрос интеграции
+const apiKeyUsageResolution = time.Minute
+
+// streamTokenTTL срок действия токена потока: токен нужен только для подключения,
+// а адреса с ним могут попасть в журналы прокси
+const streamTokenTTL = time.Minute
+
+// AuthConfig сроки действия токенов
+type AuthConfig struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// AuthService реализует ports.AuthService.
+// Токены не хранятся: refresh-токен действует до истечения срока, а смена роли
+// пользователя учитывается при следующем обновлении пары токенов
+type AuthService struct {
+	userRepo   ports.UserRepository
+	apiKeyRepo ports.APIKeyRepository
+	hasher     ports.PasswordHasher
+	signer     ports.TokenSigner
+	config     AuthConfig
+	logger     ports.Logger
+}
+
+func NewAuthService(
+	userRepo ports.UserRepository,
+	apiKeyRepo ports.APIKeyRepository,
+	hasher ports.PasswordHasher,
+	signer ports.TokenSigner,
+	config AuthConfig,
+	logger ports.Logger,
+) *AuthService {
+	return &AuthService{
+		userRepo:   userRepo,
+		apiKeyRepo: apiKeyRepo,
+		hasher:     hasher,
+		signer:     signer,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Login проверяет пароль и выдает пару токенов
+func (s *AuthService) Login(ctx context.Context, email, password string) (*ports.AuthTokens, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || password == "" {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil || !user.IsActive() || user.PasswordHash == "" || !s.hasher.Verify(user.PasswordHash, password) {
+		s.logger.Warn(ctx, "failed login attempt", "email", email)
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	tokens, err := s.issueTokens(user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "user logged in", "user_id", user.ID)
+	return tokens, nil
+}
+
+// Refresh выдает новую пару токенов с актуальной ролью пользователя
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*ports.AuthTokens, error) {
+	user, err := s.authenticate(ctx, refreshToken, domain.AuthTokenRefresh)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueTokens(user)
+}
+
+// AuthenticateToken проверяет access-токен
+func (s *AuthService) AuthenticateToken(ctx context.Context, accessToken string) (*domain.User, error) {
+	return s.authenticate(ctx, accessToken, domain.AuthTokenAccess)
+}
+
+// AuthenticateAPIKey проверяет ключ API и отмечает его использование
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (*domain.User, error) {
+	apiKey, err := s.apiKeyRepo.FindByHash(ctx, domain.HashAPIKey(key))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return nil, domain.ErrInvalidToken
+	}
+	user, err := s.userRepo.FindByID(ctx, apiKey.UserID)
+	if err != nil || !user.IsActive() {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyUsageResolution {
+		apiKey.LastUsedAt = &now
+		if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
+			s.logger.Warn(ctx, "failed to record api key usage", "key_id", apiKey.ID, "error", err.Error())
+		}
+	}
+	return user, nil
+}
+
+// IssueStreamToken выдает токен подключения к потоку изменений
+func (s *AuthService) IssueStreamToken(ctx context.Context, userID string) (string, time.Time, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to find user: %w", err)
+	}
+	if !user.IsActive() {
+		return "", time.Time{}, domain.ErrInvalidToken
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(streamTokenTTL)
+	token, err := s.signer.Sign(domain.AuthClaims{
+		TokenID:   generateTokenID(),
+		UserID:    user.ID,
+		Role:      user.Role,
+		Type:      domain.AuthTokenStream,
+		IssuedAt:  now,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign stream token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// AuthenticateStreamToken проверяет токен потока. Срок действия проверяется
+// только при подключении: открытый поток не прерывается по истечении токена
+func (s *AuthService) AuthenticateStreamToken(ctx context.Context, streamToken string) (*domain.User, error) {
+	return s.authenticate(ctx, streamToken, domain.AuthTokenStream)
+}
+
+// SetPassword задает пароль пользователя
+func (s *AuthService) SetPassword(ctx context.Context, userID, password string) error {
+	if len(password) < domain.MinPasswordLength {
+		return domain.ErrPasswordTooShort
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	updated := *user
+	updated.PasswordHash = hash
+	if err := s.userRepo.Update(ctx, &updated); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.logger.Info(ctx, "user password set", "user_id", userID)
+	return nil
+}
+
+// ChangePassword меняет пароль после проверки текущего
+func (s *AuthService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.PasswordHash != "" && !s.hasher.Verify(user.PasswordHash, currentPassword) {
+		return domain.ErrInvalidCredentials
+	}
+	return s.SetPassword(ctx, userID, newPassword)
+}
+
+// CreateAPIKey создает ключ API пользователя
+func (s *AuthService) CreateAPIKey(ctx context.Context, userID, name string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, "", fmt.Errorf("failed to find user: %w", err)
+	}
+
+	value, err := generateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := domain.NewAPIKey(userID, name, value, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.apiKeyRepo.Save(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	s.logger.Info(ctx, "api key created", "key_id", key.ID, "user_id", userID, "name", key.Name)
+	return key, value, nil
+}
+
+// ListAPIKeys возвращает ключи пользователя, новые первыми
+func (s *AuthService) ListAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// RevokeAPIKey отзывает ключ пользователя
+func (s *AuthService) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find api keys: %w", err)
+	}
+	for i := range keys {
+		if keys[i].ID != keyID {
+			continue
+		}
+		keys[i].Revoke(time.Now())
+		if err := s.apiKeyRepo.Update(ctx, &keys[i]); err != nil {
+			return fmt.Errorf("failed to revoke api key: %w", err)
+		}
+		s.logger.Info(ctx, "api key revoked", "key_id", keyID, "user_id", userID)
+		return nil
+	}
+	return domain.ErrAPIKeyNotFound
+}
+
+func (s *AuthService) authenticate(ctx context.Context, token string, tokenType domain.AuthTokenType) (*domain.User, error) {
+	claims, err := s.signer.Parse(token)
+	if err != nil || claims.Type != tokenType {
+		return nil, domain.ErrInvalidToken
+	}
+	// Отключенный пользователь теряет доступ сразу, не дожидаясь истечения токенов
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil || !user.IsActive() {
+		return nil, domain.ErrInvalidToken
+	}
+	return user, nil
+}
+
+func (s *AuthService) issueTokens(user *domain.User) (*ports.AuthTokens, error) {
+	now := time.Now()
+	access, err := s.signer.Sign(domain.AuthClaims{
+		TokenID:   generateTokenID(),
+		UserID:    user.ID,
+		Role:      user.Role,
+		Type:      domain.AuthTokenAccess,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.config.AccessTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	refresh, err := s.signer.Sign(domain.AuthClaims{
+		TokenID:   generateTokenID(),
+		UserID:    user.ID,
+		Role:      user.Role,
+		Type:      domain.AuthTokenRefresh,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.config.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
+	}
+
+	return &ports.AuthTokens{
+		AccessToken:      access,
+		AccessExpiresAt:  now.Add(s.config.AccessTokenTTL),
+		RefreshToken:     refresh,
+		RefreshExpiresAt: now.Add(s.config.RefreshTokenTTL),
+	}, nil
+}
+
+func generateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return domain.APIKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+func generateTokenID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,149 @@
+// internal/core/services/auth_service_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/auth"
+	authinmemory "github.com/audetv/urms/internal/infrastructure/persistence/auth/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestAuthService(t *testing.T) *services.AuthService {
+	t.Helper()
+	logger := &services.MockLogger{}
+	signer, err := auth.NewJWTSigner("0123456789abcdef0123456789abcdef", "urms")
+	require.NoError(t, err)
+
+	authService := services.NewAuthService(
+		inmemory.NewUserRepository(logger),
+		authinmemory.NewAPIKeyRepository(logger),
+		auth.NewBcryptHasher(bcrypt.MinCost),
+		signer,
+		services.AuthConfig{AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: 24 * time.Hour},
+		logger,
+	)
+	require.NoError(t, authService.SetPassword(context.Background(), "user-1", "correct-horse"))
+	return authService
+}
+
+func TestAuthService_LoginAndRefresh(t *testing.T) {
+	ctx := context.Background()
+	authService := newTestAuthService(t)
+
+	_, err := authService.Login(ctx, "admin@company.com", "wrong-password")
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = authService.Login(ctx, "nobody@company.com", "correct-horse")
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = authService.Login(ctx, "manager@company.com", "any-password")
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials, "пользователь без пароля не может войти")
+
+	tokens, err := authService.Login(ctx, " Admin@Company.com ", "correct-horse")
+	require.NoError(t, err)
+
+	user, err := authService.AuthenticateToken(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.ID)
+
+	// Refresh-токен не принимается как access-токен и наоборот
+	_, err = authService.AuthenticateToken(ctx, tokens.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	_, err = authService.Refresh(ctx, tokens.AccessToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	refreshed, err := authService.Refresh(ctx, tokens.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, tokens.AccessToken, refreshed.AccessToken)
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	authService := newTestAuthService(t)
+
+	err := authService.ChangePassword(ctx, "user-1", "wrong-password", "new-password")
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	err = authService.ChangePassword(ctx, "user-1", "correct-horse", "short")
+	assert.ErrorIs(t, err, domain.ErrPasswordTooShort)
+
+	require.NoError(t, authService.ChangePassword(ctx, "user-1", "correct-horse", "new-password"))
+	_, err = authService.Login(ctx, "admin@company.com", "correct-horse")
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = authService.Login(ctx, "admin@company.com", "new-password")
+	assert.NoError(t, err)
+}
+
+func TestAuthService_APIKeys(t *testing.T) {
+	ctx := context.Background()
+	authService := newTestAuthService(t)
+
+	key, value, err := authService.CreateAPIKey(ctx, "user-2", "CI", nil)
+	require.NoError(t, err)
+	assert.True(t, len(value) > len(domain.APIKeyPrefix))
+	assert.NotContains(t, key.KeyHash, value, "ключ хранится только в виде хеша")
+	assert.Equal(t, value[:len(key.Prefix)], key.Prefix)
+
+	user, err := authService.AuthenticateAPIKey(ctx, value)
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", user.ID)
+
+	keys, err := authService.ListAPIKeys(ctx, "user-2")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+
+	_, err = authService.AuthenticateAPIKey(ctx, domain.APIKeyPrefix+"unknown")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	// Чужой ключ отозвать нельзя
+	assert.ErrorIs(t, authService.RevokeAPIKey(ctx, "user-1", key.ID), domain.ErrAPIKeyNotFound)
+	require.NoError(t, authService.RevokeAPIKey(ctx, "user-2", key.ID))
+	_, err = authService.AuthenticateAPIKey(ctx, value)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	past := time.Now().Add(-time.Hour)
+	_, _, err = authService.CreateAPIKey(ctx, "user-2", "expired", &past)
+	assert.Error(t, err)
+	_, _, err = authService.CreateAPIKey(ctx, "user-2", " ", nil)
+	assert.Error(t, err)
+}
+
+func TestAuthService_StreamToken(t *testing.T) {
+	ctx := context.Background()
+	authService := newTestAuthService(t)
+
+	streamToken, expiresAt, err := authService.IssueStreamToken(ctx, "user-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+	user, err := authService.AuthenticateStreamToken(ctx, streamToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.ID)
+
+	// Токен потока передается в адресе, поэтому не заменяет access-токен, и наоборот
+	_, err = authService.AuthenticateToken(ctx, streamToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	tokens, err := authService.Login(ctx, "admin@company.com", "correct-horse")
+	require.NoError(t, err)
+	_, err = authService.AuthenticateStreamToken(ctx, tokens.AccessToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
+
+func TestAuthenticatedUserContext(t *testing.T) {
+	ctx := ports.WithAuthenticatedUser(context.Background(), &domain.User{ID: "user-3", Role: domain.UserRoleOperator})
+
+	user, ok := ports.AuthenticatedUser(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "user-3", user.ID)
+	assert.Nil(t, ctx.Value("user_id"), "user is available only through AuthenticatedUser")
+
+	_, ok = ports.AuthenticatedUser(context.Background())
+	assert.False(t, ok)
+}
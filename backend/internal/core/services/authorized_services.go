@@ -0,0 +1,683 @@
+// internal/core/services/authorized_services.go
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// Обертки сервисов с проверкой прав. Операции, которые выполняются только
+// фоновыми задачами и подписчиками шины событий (системный context) или по подписи
+// внешней системы, передаются без изменений через встроенный интерфейс
+
+// AuthorizedCustomerService проверяет права на работу с клиентами
+type AuthorizedCustomerService struct {
+	next       ports.CustomerService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedCustomerService(next ports.CustomerService, authorizer ports.Authorizer) *AuthorizedCustomerService {
+	return &AuthorizedCustomerService{next: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedCustomerService) CreateCustomer(ctx context.Context, req ports.CreateCustomerRequest) (*domain.Customer, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionCustomersWrite); err != nil {
+		return nil, err
+	}
+	return s.next.CreateCustomer(ctx, req)
+}
+
+func (s *AuthorizedCustomerService) FindOrCreateByEmail(ctx context.Context, email, name string) (*domain.Customer, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionCustomersWrite); err != nil {
+		return nil, err
+	}
+	return s.next.FindOrCreateByEmail(ctx, email, name)
+}
+
+func (s *AuthorizedCustomerService) GetCustomerProfile(ctx context.Context, id string) (*ports.CustomerProfile, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionCustomersRead); err != nil {
+		return nil, err
+	}
+	profile, err := s.next.GetCustomerProfile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	profile.Tasks = visibleTasks(ctx, s.authorizer, profile.Tasks)
+	profile.RecentTasks = visibleTasks(ctx, s.authorizer, profile.RecentTasks)
+	return profile, nil
+}
+
+func (s *AuthorizedCustomerService) UpdateCustomer(ctx context.Context, id string, req ports.UpdateCustomerRequest) (*domain.Customer, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionCustomersWrite); err != nil {
+		return nil, err
+	}
+	return s.next.UpdateCustomer(ctx, id, req)
+}
+
+func (s *AuthorizedCustomerService) DeleteCustomer(ctx context.Context, id string) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionCustomersDelete); err != nil {
+		return err
+	}
+	return s.next.DeleteCustomer(ctx, id)
+}
+
+func (s *AuthorizedCustomerService) ListCustomers(ctx context.Context, query ports.CustomerQuery) (*ports.CustomerSearchResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionCustomersRead); err != nil {
+		return nil, err
+	}
+	return s.next.ListCustomers(ctx, query)
+}
+
+// AuthorizedCustomFieldService: описания полей читают все, изменяют - с правом settings:manage
+type AuthorizedCustomFieldService struct {
+	ports.CustomFieldService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedCustomFieldService(next ports.CustomFieldService, authorizer ports.Authorizer) *AuthorizedCustomFieldService {
+	return &AuthorizedCustomFieldService{CustomFieldService: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedCustomFieldService) CreateField(ctx context.Context, req ports.CustomFieldRequest) (*domain.CustomFieldDefinition, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.CustomFieldService.CreateField(ctx, req)
+}
+
+func (s *AuthorizedCustomFieldService) UpdateField(ctx context.Context, key string, req ports.CustomFieldRequest) (*domain.CustomFieldDefinition, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.CustomFieldService.UpdateField(ctx, key, req)
+}
+
+func (s *AuthorizedCustomFieldService) DeleteField(ctx context.Context, key string) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return err
+	}
+	return s.CustomFieldService.DeleteField(ctx, key)
+}
+
+// AuthorizedReplyTemplateService: шаблоны изменяют с правом settings:manage.
+// Применение шаблона проверяется сервисом задач, через который оно выполняется
+type AuthorizedReplyTemplateService struct {
+	ports.ReplyTemplateService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedReplyTemplateService(next ports.ReplyTemplateService, authorizer ports.Authorizer) *AuthorizedReplyTemplateService {
+	return &AuthorizedReplyTemplateService{ReplyTemplateService: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedReplyTemplateService) CreateTemplate(ctx context.Context, req ports.ReplyTemplateRequest) (*domain.ReplyTemplate, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.ReplyTemplateService.CreateTemplate(ctx, req)
+}
+
+func (s *AuthorizedReplyTemplateService) UpdateTemplate(ctx context.Context, id string, req ports.ReplyTemplateRequest) (*domain.ReplyTemplate, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.ReplyTemplateService.UpdateTemplate(ctx, id, req)
+}
+
+func (s *AuthorizedReplyTemplateService) DeleteTemplate(ctx context.Context, id string) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return err
+	}
+	return s.ReplyTemplateService.DeleteTemplate(ctx, id)
+}
+
+func (s *AuthorizedReplyTemplateService) ApplyTemplate(ctx context.Context, req ports.ApplyTemplateRequest) (*ports.AppliedTemplateResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.ReplyTemplateService.ApplyTemplate(ctx, req)
+}
+
+// AuthorizedSchedulerService: повторяющиеся задачи - settings:manage,
+// запланированные ответы - как ответы в задаче
+type AuthorizedSchedulerService struct {
+	ports.SchedulerService
+	authorizer ports.Authorizer
+	tasks      ports.TaskService
+}
+
+func NewAuthorizedSchedulerService(next ports.SchedulerService, tasks ports.TaskService, authorizer ports.Authorizer) *AuthorizedSchedulerService {
+	return &AuthorizedSchedulerService{SchedulerService: next, authorizer: authorizer, tasks: tasks}
+}
+
+func (s *AuthorizedSchedulerService) CreateRecurringTask(ctx context.Context, req ports.RecurringTaskRequest) (*domain.RecurringTask, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.CreateRecurringTask(ctx, req)
+}
+
+func (s *AuthorizedSchedulerService) GetRecurringTask(ctx context.Context, id string) (*domain.RecurringTask, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.GetRecurringTask(ctx, id)
+}
+
+func (s *AuthorizedSchedulerService) ListRecurringTasks(ctx context.Context) ([]domain.RecurringTask, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.ListRecurringTasks(ctx)
+}
+
+func (s *AuthorizedSchedulerService) UpdateRecurringTask(ctx context.Context, id string, req ports.RecurringTaskRequest) (*domain.RecurringTask, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.UpdateRecurringTask(ctx, id, req)
+}
+
+func (s *AuthorizedSchedulerService) DeleteRecurringTask(ctx context.Context, id string) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return err
+	}
+	return s.SchedulerService.DeleteRecurringTask(ctx, id)
+}
+
+func (s *AuthorizedSchedulerService) ScheduleReply(ctx context.Context, req ports.ScheduleReplyRequest) (*domain.ScheduledReply, error) {
+	if err := authorizeTaskID(ctx, s.authorizer, s.tasks, req.TaskID, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.ScheduleReply(ctx, req)
+}
+
+func (s *AuthorizedSchedulerService) GetScheduledReplies(ctx context.Context, taskID string) ([]domain.ScheduledReply, error) {
+	if err := authorizeTaskID(ctx, s.authorizer, s.tasks, taskID, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.GetScheduledReplies(ctx, taskID)
+}
+
+func (s *AuthorizedSchedulerService) CancelScheduledReply(ctx context.Context, id string) (*domain.ScheduledReply, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.CancelScheduledReply(ctx, id)
+}
+
+func (s *AuthorizedSchedulerService) RunDueJobs(ctx context.Context, now time.Time) (*ports.SchedulerRunResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksBulk); err != nil {
+		return nil, err
+	}
+	return s.SchedulerService.RunDueJobs(ctx, now)
+}
+
+// AuthorizedSatisfactionService: ответ на опрос принимается по подписи ссылки,
+// отправка опроса - как изменение задачи, отчет - reports:read
+type AuthorizedSatisfactionService struct {
+	ports.SatisfactionService
+	authorizer ports.Authorizer
+	tasks      ports.TaskService
+}
+
+func NewAuthorizedSatisfactionService(next ports.SatisfactionService, tasks ports.TaskService, authorizer ports.Authorizer) *AuthorizedSatisfactionService {
+	return &AuthorizedSatisfactionService{SatisfactionService: next, authorizer: authorizer, tasks: tasks}
+}
+
+func (s *AuthorizedSatisfactionService) SendSurvey(ctx context.Context, taskID string) (*domain.Task, error) {
+	if err := authorizeTaskID(ctx, s.authorizer, s.tasks, taskID, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.SatisfactionService.SendSurvey(ctx, taskID)
+}
+
+func (s *AuthorizedSatisfactionService) GetSatisfactionReport(ctx context.Context, query ports.SatisfactionQuery) (*ports.SatisfactionReport, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionReportsRead); err != nil {
+		return nil, err
+	}
+	return s.SatisfactionService.GetSatisfactionReport(ctx, query)
+}
+
+// AuthorizedAuditService: история задачи доступна тем, кто видит задачу,
+// выборка по всему журналу - reports:read
+type AuthorizedAuditService struct {
+	ports.AuditService
+	authorizer ports.Authorizer
+	tasks      ports.TaskService
+}
+
+func NewAuthorizedAuditService(next ports.AuditService, tasks ports.TaskService, authorizer ports.Authorizer) *AuthorizedAuditService {
+	return &AuthorizedAuditService{AuditService: next, authorizer: authorizer, tasks: tasks}
+}
+
+func (s *AuthorizedAuditService) GetTaskHistory(ctx context.Context, taskID string) ([]domain.AuditEvent, error) {
+	if err := authorizeTaskID(ctx, s.authorizer, s.tasks, taskID, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	return s.AuditService.GetTaskHistory(ctx, taskID)
+}
+
+func (s *AuthorizedAuditService) QueryEvents(ctx context.Context, query ports.AuditQuery) (*ports.AuditQueryResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionReportsRead); err != nil {
+		return nil, err
+	}
+	return s.AuditService.QueryEvents(ctx, query)
+}
+
+// AuthorizedWebhookService: подписки на вебхуки - channels:manage.
+// Доставка выполняется фоновой задачей и подписчиком шины событий
+type AuthorizedWebhookService struct {
+	ports.WebhookService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedWebhookService(next ports.WebhookService, authorizer ports.Authorizer) *AuthorizedWebhookService {
+	return &AuthorizedWebhookService{WebhookService: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedWebhookService) CreateSubscription(ctx context.Context, req ports.CreateWebhookRequest) (*domain.WebhookSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.WebhookService.CreateSubscription(ctx, req)
+}
+
+func (s *AuthorizedWebhookService) UpdateSubscription(ctx context.Context, id string, req ports.UpdateWebhookRequest) (*domain.WebhookSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.WebhookService.UpdateSubscription(ctx, id, req)
+}
+
+func (s *AuthorizedWebhookService) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.WebhookService.GetSubscription(ctx, id)
+}
+
+func (s *AuthorizedWebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.WebhookService.ListSubscriptions(ctx)
+}
+
+func (s *AuthorizedWebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return err
+	}
+	return s.WebhookService.DeleteSubscription(ctx, id)
+}
+
+func (s *AuthorizedWebhookService) SendTestEvent(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.WebhookService.SendTestEvent(ctx, id)
+}
+
+func (s *AuthorizedWebhookService) ListDeliveries(ctx context.Context, query ports.WebhookDeliveryQuery) (*ports.WebhookDeliveryList, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.WebhookService.ListDeliveries(ctx, query)
+}
+
+func (s *AuthorizedWebhookService) RetryDelivery(ctx context.Context, subscriptionID, deliveryID string) (*domain.WebhookDelivery, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.WebhookService.RetryDelivery(ctx, subscriptionID, deliveryID)
+}
+
+// AuthorizedInboundChannelService: настройка каналов - channels:manage.
+// Прием записей защищен подписью канала
+type AuthorizedInboundChannelService struct {
+	ports.InboundChannelService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedInboundChannelService(next ports.InboundChannelService, authorizer ports.Authorizer) *AuthorizedInboundChannelService {
+	return &AuthorizedInboundChannelService{InboundChannelService: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedInboundChannelService) CreateChannel(ctx context.Context, req ports.CreateInboundChannelRequest) (*domain.InboundChannel, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.InboundChannelService.CreateChannel(ctx, req)
+}
+
+func (s *AuthorizedInboundChannelService) UpdateChannel(ctx context.Context, key string, req ports.UpdateInboundChannelRequest) (*domain.InboundChannel, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.InboundChannelService.UpdateChannel(ctx, key, req)
+}
+
+func (s *AuthorizedInboundChannelService) GetChannel(ctx context.Context, key string) (*domain.InboundChannel, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.InboundChannelService.GetChannel(ctx, key)
+}
+
+func (s *AuthorizedInboundChannelService) ListChannels(ctx context.Context) ([]domain.InboundChannel, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.InboundChannelService.ListChannels(ctx)
+}
+
+func (s *AuthorizedInboundChannelService) DeleteChannel(ctx context.Context, key string) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return err
+	}
+	return s.InboundChannelService.DeleteChannel(ctx, key)
+}
+
+// AuthorizedGitHubService: создание связанного issue - изменение задачи.
+// События webhook и синхронизация выполняются в системном context
+type AuthorizedGitHubService struct {
+	ports.GitHubService
+	authorizer ports.Authorizer
+	tasks      ports.TaskService
+}
+
+func NewAuthorizedGitHubService(next ports.GitHubService, tasks ports.TaskService, authorizer ports.Authorizer) *AuthorizedGitHubService {
+	return &AuthorizedGitHubService{GitHubService: next, authorizer: authorizer, tasks: tasks}
+}
+
+func (s *AuthorizedGitHubService) LinkIssue(ctx context.Context, taskID string, req ports.LinkGitHubIssueRequest) (*domain.Task, error) {
+	if err := authorizeTaskID(ctx, s.authorizer, s.tasks, taskID, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.GitHubService.LinkIssue(ctx, taskID, req)
+}
+
+// AuthorizedUserService: список и карточки пользователей доступны всем, кто видит задачи;
+// профиль и отсутствие пользователь меняет сам, остальное - users:manage.
+// Принятие приглашения защищено токеном приглашения
+type AuthorizedUserService struct {
+	ports.UserService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedUserService(next ports.UserService, authorizer ports.Authorizer) *AuthorizedUserService {
+	return &AuthorizedUserService{UserService: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedUserService) CreateUser(ctx context.Context, req ports.CreateUserRequest) (*domain.User, string, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionUsersManage); err != nil {
+		return nil, "", err
+	}
+	return s.UserService.CreateUser(ctx, req)
+}
+
+func (s *AuthorizedUserService) ResendInvitation(ctx context.Context, id string) (*domain.User, string, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionUsersManage); err != nil {
+		return nil, "", err
+	}
+	return s.UserService.ResendInvitation(ctx, id)
+}
+
+func (s *AuthorizedUserService) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	if !isCurrentUser(ctx, id) {
+		if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+			return nil, err
+		}
+	}
+	return s.UserService.GetUser(ctx, id)
+}
+
+func (s *AuthorizedUserService) ListUsers(ctx context.Context, query ports.UserQuery) ([]domain.User, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	return s.UserService.ListUsers(ctx, query)
+}
+
+func (s *AuthorizedUserService) UpdateProfile(ctx context.Context, id string, req ports.UpdateUserProfileRequest) (*domain.User, error) {
+	if err := s.authorizeSelfOrManage(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.UserService.UpdateProfile(ctx, id, req)
+}
+
+func (s *AuthorizedUserService) ChangeRole(ctx context.Context, id string, role domain.UserRole) (*domain.User, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionUsersManage); err != nil {
+		return nil, err
+	}
+	return s.UserService.ChangeRole(ctx, id, role)
+}
+
+func (s *AuthorizedUserService) SetRouting(ctx context.Context, id string, skills, categories []string) (*domain.User, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionUsersManage); err != nil {
+		return nil, err
+	}
+	return s.UserService.SetRouting(ctx, id, skills, categories)
+}
+
+func (s *AuthorizedUserService) SetOutOfOffice(ctx context.Context, id string, ooo *domain.OutOfOffice) (*domain.User, error) {
+	if err := s.authorizeSelfOrManage(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.UserService.SetOutOfOffice(ctx, id, ooo)
+}
+
+func (s *AuthorizedUserService) Deactivate(ctx context.Context, id string) (*domain.User, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionUsersManage); err != nil {
+		return nil, err
+	}
+	return s.UserService.Deactivate(ctx, id)
+}
+
+func (s *AuthorizedUserService) Reactivate(ctx context.Context, id string) (*domain.User, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionUsersManage); err != nil {
+		return nil, err
+	}
+	return s.UserService.Reactivate(ctx, id)
+}
+
+func (s *AuthorizedUserService) authorizeSelfOrManage(ctx context.Context, id string) error {
+	if isCurrentUser(ctx, id) {
+		return nil
+	}
+	return s.authorizer.Authorize(ctx, domain.PermissionUsersManage)
+}
+
+// AuthorizedSearchService ограничивает поиск сущностями, доступными пользователю:
+// задачи - с правом tasks:read и в пределах его видимости, клиенты - с правом customers:read,
+// письма - с доступом ко всем задачам (tasks:all). Внутренние заметки ищутся с правом messages:private
+type AuthorizedSearchService struct {
+	next       ports.SearchService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedSearchService(next ports.SearchService, authorizer ports.Authorizer) *AuthorizedSearchService {
+	return &AuthorizedSearchService{next: next, authorizer: authorizer}
+}
+
+// searchEntityPermissions право, необходимое для поиска по типу сущности
+var searchEntityPermissions = map[domain.SearchEntityType]domain.Permission{
+	domain.SearchEntityTask:     domain.PermissionTasksRead,
+	domain.SearchEntityEmail:    domain.PermissionTasksAll,
+	domain.SearchEntityCustomer: domain.PermissionCustomersRead,
+}
+
+// Search отказывает, если явно запрошен недоступный тип; без явных типов
+// ищет по всем доступным
+func (s *AuthorizedSearchService) Search(ctx context.Context, query ports.SearchQuery) (*ports.SearchResult, error) {
+	requested := query.EntityTypes
+	if len(requested) == 0 {
+		requested = domain.SearchEntityTypes
+	}
+
+	var allowed []domain.SearchEntityType
+	for _, entityType := range requested {
+		permission, ok := searchEntityPermissions[entityType]
+		if !ok {
+			allowed = append(allowed, entityType) // Неизвестный тип отклонит сервис
+			continue
+		}
+		if s.authorizer.Can(ctx, permission) {
+			allowed = append(allowed, entityType)
+		} else if len(query.EntityTypes) > 0 {
+			return nil, s.authorizer.Authorize(ctx, permission)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, s.authorizer.Authorize(ctx, domain.PermissionTasksRead)
+	}
+
+	query.EntityTypes = allowed
+	query.Scope = s.authorizer.TaskScope(ctx)
+	query.IncludePrivate = s.authorizer.Can(ctx, domain.PermissionMessagesPrivate)
+	return s.next.Search(ctx, query)
+}
+
+func (s *AuthorizedSearchService) Reindex(ctx context.Context) (int, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return 0, err
+	}
+	return s.next.Reindex(ctx)
+}
+
+// AuthorizedEmailMessageService: письма не привязаны к категориям задач, поэтому
+// просмотр требует доступа ко всем задачам (tasks:all), а изменение - еще и права tasks:write
+type AuthorizedEmailMessageService struct {
+	next       ports.EmailMessageService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedEmailMessageService(next ports.EmailMessageService, authorizer ports.Authorizer) *AuthorizedEmailMessageService {
+	return &AuthorizedEmailMessageService{next: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedEmailMessageService) ListEmails(ctx context.Context, query ports.EmailQuery) (*ports.EmailSearchResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksAll); err != nil {
+		return nil, err
+	}
+	return s.next.ListEmails(ctx, query)
+}
+
+func (s *AuthorizedEmailMessageService) GetEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksAll); err != nil {
+		return nil, err
+	}
+	return s.next.GetEmail(ctx, id)
+}
+
+func (s *AuthorizedEmailMessageService) GetEmailStatistics(ctx context.Context) (*ports.EmailStatistics, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksAll); err != nil {
+		return nil, err
+	}
+	return s.next.GetEmailStatistics(ctx)
+}
+
+func (s *AuthorizedEmailMessageService) ReprocessEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error) {
+	if err := s.authorizeWrite(ctx); err != nil {
+		return nil, err
+	}
+	return s.next.ReprocessEmail(ctx, id)
+}
+
+func (s *AuthorizedEmailMessageService) LinkEmail(ctx context.Context, id domain.MessageID, taskID string) (*domain.EmailMessage, error) {
+	if err := s.authorizeWrite(ctx); err != nil {
+		return nil, err
+	}
+	return s.next.LinkEmail(ctx, id, taskID)
+}
+
+func (s *AuthorizedEmailMessageService) UnlinkEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error) {
+	if err := s.authorizeWrite(ctx); err != nil {
+		return nil, err
+	}
+	return s.next.UnlinkEmail(ctx, id)
+}
+
+func (s *AuthorizedEmailMessageService) authorizeWrite(ctx context.Context) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksAll); err != nil {
+		return err
+	}
+	return s.authorizer.Authorize(ctx, domain.PermissionTasksWrite)
+}
+
+// AuthorizedEmailSettingsService: почтовые каналы (с паролями) - channels:manage,
+// политика обработки и окна поиска тредов - settings:manage
+type AuthorizedEmailSettingsService struct {
+	next       ports.EmailSettingsService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedEmailSettingsService(next ports.EmailSettingsService, authorizer ports.Authorizer) *AuthorizedEmailSettingsService {
+	return &AuthorizedEmailSettingsService{next: next, authorizer: authorizer}
+}
+
+func (s *AuthorizedEmailSettingsService) ListChannels(ctx context.Context) ([]domain.EmailChannelConfig, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.next.ListChannels(ctx)
+}
+
+func (s *AuthorizedEmailSettingsService) GetChannel(ctx context.Context, id string) (*domain.EmailChannelConfig, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.next.GetChannel(ctx, id)
+}
+
+func (s *AuthorizedEmailSettingsService) SaveChannel(ctx context.Context, config domain.EmailChannelConfig) (*domain.EmailChannelConfig, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.next.SaveChannel(ctx, config)
+}
+
+func (s *AuthorizedEmailSettingsService) DeleteChannel(ctx context.Context, id string) error {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return err
+	}
+	return s.next.DeleteChannel(ctx, id)
+}
+
+func (s *AuthorizedEmailSettingsService) TestChannel(ctx context.Context, id string) (*ports.EmailConnectionTestResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionChannelsManage); err != nil {
+		return nil, err
+	}
+	return s.next.TestChannel(ctx, id)
+}
+
+func (s *AuthorizedEmailSettingsService) GetPolicy(ctx context.Context) (*domain.EmailProcessingPolicy, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.next.GetPolicy(ctx)
+}
+
+func (s *AuthorizedEmailSettingsService) UpdatePolicy(ctx context.Context, policy domain.EmailProcessingPolicy) (*domain.EmailProcessingPolicy, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.next.UpdatePolicy(ctx, policy)
+}
+
+func (s *AuthorizedEmailSettingsService) GetThreadSearchConfig(ctx context.Context) (*ports.ThreadSearchConfig, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.next.GetThreadSearchConfig(ctx)
+}
+
+func (s *AuthorizedEmailSettingsService) UpdateThreadSearchConfig(ctx context.Context, config ports.ThreadSearchConfig) (*ports.ThreadSearchConfig, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+	return s.next.UpdateThreadSearchConfig(ctx, config)
+}
@@ -0,0 +1,415 @@
+// internal/core/services/authorized_task_service.go
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// AuthorizedTaskService проверяет права пользователя перед вызовом TaskService:
+// операторы работают только с задачами своих категорий, а пользователи без права
+// messages:private не получают внутренние заметки
+type AuthorizedTaskService struct {
+	next       ports.TaskService
+	authorizer ports.Authorizer
+}
+
+func NewAuthorizedTaskService(next ports.TaskService, authorizer ports.Authorizer) *AuthorizedTaskService {
+	return &AuthorizedTaskService{next: next, authorizer: authorizer}
+}
+
+// Core operations
+
+func (s *AuthorizedTaskService) CreateTask(ctx context.Context, req ports.CreateTaskRequest) (*domain.Task, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.CreateTask(ctx, req)
+}
+
+func (s *AuthorizedTaskService) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	task, err := s.next.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizer.AuthorizeTask(ctx, domain.PermissionTasksRead, task); err != nil {
+		return nil, err
+	}
+	return s.redact(ctx, task), nil
+}
+
+func (s *AuthorizedTaskService) UpdateTask(ctx context.Context, id string, req ports.UpdateTaskRequest) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.UpdateTask(ctx, id, req)
+}
+
+func (s *AuthorizedTaskService) DeleteTask(ctx context.Context, id string) error {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksDelete); err != nil {
+		return err
+	}
+	return s.next.DeleteTask(ctx, id)
+}
+
+// Task type specific creation
+
+func (s *AuthorizedTaskService) CreateSupportTask(ctx context.Context, req ports.CreateSupportTaskRequest) (*domain.Task, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.CreateSupportTask(ctx, req)
+}
+
+func (s *AuthorizedTaskService) CreateInternalTask(ctx context.Context, req ports.CreateInternalTaskRequest) (*domain.Task, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.CreateInternalTask(ctx, req)
+}
+
+func (s *AuthorizedTaskService) CreateSubTask(ctx context.Context, req ports.CreateSubTaskRequest) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, req.ParentID, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.CreateSubTask(ctx, req)
+}
+
+// Status management
+
+func (s *AuthorizedTaskService) ChangeStatus(ctx context.Context, id string, status domain.TaskStatus, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.ChangeStatus(ctx, id, status, userID)
+}
+
+func (s *AuthorizedTaskService) AssignTask(ctx context.Context, id string, assigneeID string, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.AssignTask(ctx, id, assigneeID, userID)
+}
+
+func (s *AuthorizedTaskService) SnoozeTask(ctx context.Context, id string, until time.Time, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.SnoozeTask(ctx, id, until, userID)
+}
+
+func (s *AuthorizedTaskService) UnsnoozeTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.UnsnoozeTask(ctx, id, userID)
+}
+
+// Participants
+
+func (s *AuthorizedTaskService) AddParticipant(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.AddParticipant(ctx, id, participantID, role, userID)
+}
+
+func (s *AuthorizedTaskService) RemoveParticipant(ctx context.Context, id string, participantID string, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.RemoveParticipant(ctx, id, participantID, userID)
+}
+
+func (s *AuthorizedTaskService) ChangeParticipantRole(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.ChangeParticipantRole(ctx, id, participantID, role, userID)
+}
+
+func (s *AuthorizedTaskService) WatchTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.WatchTask(ctx, id, userID)
+}
+
+func (s *AuthorizedTaskService) UnwatchTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.UnwatchTask(ctx, id, userID)
+}
+
+// Links and dependencies
+
+func (s *AuthorizedTaskService) LinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeTask(ctx, targetID, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	return s.next.LinkTasks(ctx, id, targetID, linkType, userID)
+}
+
+func (s *AuthorizedTaskService) UnlinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.UnlinkTasks(ctx, id, targetID, linkType, userID)
+}
+
+func (s *AuthorizedTaskService) GetSubtaskProgress(ctx context.Context, parentID string) (*ports.SubtaskProgress, error) {
+	if err := s.authorizeTask(ctx, parentID, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	return s.next.GetSubtaskProgress(ctx, parentID)
+}
+
+// Time tracking
+
+func (s *AuthorizedTaskService) LogWork(ctx context.Context, id string, req ports.LogWorkRequest) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.LogWork(ctx, id, req)
+}
+
+func (s *AuthorizedTaskService) DeleteWorkLog(ctx context.Context, id string, workLogID string, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.DeleteWorkLog(ctx, id, workLogID, userID)
+}
+
+func (s *AuthorizedTaskService) StartTimer(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.StartTimer(ctx, id, userID)
+}
+
+func (s *AuthorizedTaskService) StopTimer(ctx context.Context, id string, req ports.StopTimerRequest) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.StopTimer(ctx, id, req)
+}
+
+// GetTimeReport доступен с правом reports:read; свое время пользователь видит всегда
+func (s *AuthorizedTaskService) GetTimeReport(ctx context.Context, query ports.TimeReportQuery) (*ports.TimeReport, error) {
+	if !isCurrentUser(ctx, query.UserID) {
+		if err := s.authorizer.Authorize(ctx, domain.PermissionReportsRead); err != nil {
+			return nil, err
+		}
+	}
+	return s.next.GetTimeReport(ctx, query)
+}
+
+// Communication
+
+func (s *AuthorizedTaskService) AddMessage(ctx context.Context, id string, req ports.AddMessageRequest) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.AddMessage(ctx, id, req)
+}
+
+func (s *AuthorizedTaskService) AddInternalNote(ctx context.Context, id string, authorID, content string) (*domain.Task, error) {
+	if err := s.authorizeTask(ctx, id, domain.PermissionTasksWrite); err != nil {
+		return nil, err
+	}
+	return s.next.AddInternalNote(ctx, id, authorID, content)
+}
+
+// Email threading support
+
+func (s *AuthorizedTaskService) FindBySourceMeta(ctx context.Context, meta map[string]interface{}) ([]domain.Task, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	tasks, err := s.next.FindBySourceMeta(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+	return s.visible(ctx, tasks), nil
+}
+
+// Search and lists
+
+func (s *AuthorizedTaskService) SearchTasks(ctx context.Context, query ports.TaskQuery) (*ports.TaskSearchResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	query.Scope = s.authorizer.TaskScope(ctx)
+	result, err := s.next.SearchTasks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result.Tasks = s.visible(ctx, result.Tasks)
+	return result, nil
+}
+
+func (s *AuthorizedTaskService) GetCustomerTasks(ctx context.Context, customerID string) ([]domain.Task, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	tasks, err := s.next.GetCustomerTasks(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.visible(ctx, tasks), nil
+}
+
+func (s *AuthorizedTaskService) GetUserTasks(ctx context.Context, userID string, userRole domain.UserRole) (*ports.UserTasks, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	userTasks, err := s.next.GetUserTasks(ctx, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+	userTasks.AssignedTasks = s.visible(ctx, userTasks.AssignedTasks)
+	userTasks.ReportedTasks = s.visible(ctx, userTasks.ReportedTasks)
+	userTasks.ParticipatingIn = s.visible(ctx, userTasks.ParticipatingIn)
+	userTasks.Watching = s.visible(ctx, userTasks.Watching)
+	userTasks.UnassignedTasks = s.visible(ctx, userTasks.UnassignedTasks)
+	return userTasks, nil
+}
+
+func (s *AuthorizedTaskService) GetSubtasks(ctx context.Context, parentID string) ([]domain.Task, error) {
+	if err := s.authorizeTask(ctx, parentID, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	tasks, err := s.next.GetSubtasks(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.visible(ctx, tasks), nil
+}
+
+// Analytics
+
+func (s *AuthorizedTaskService) GetStats(ctx context.Context, query ports.StatsQuery) (*ports.TaskStats, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionReportsRead); err != nil {
+		return nil, err
+	}
+	return s.next.GetStats(ctx, query)
+}
+
+func (s *AuthorizedTaskService) GetDashboard(ctx context.Context, userID string, userRole domain.UserRole) (*ports.UserDashboard, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+	dashboard, err := s.next.GetDashboard(ctx, userID, userRole)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.MyTasks = s.visible(ctx, dashboard.MyTasks)
+	return dashboard, nil
+}
+
+// Automation
+
+func (s *AuthorizedTaskService) AutoAssignTasks(ctx context.Context) ([]ports.AutoAssignmentResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksBulk); err != nil {
+		return nil, err
+	}
+	return s.next.AutoAssignTasks(ctx)
+}
+
+func (s *AuthorizedTaskService) ProcessEscalations(ctx context.Context) ([]ports.EscalationResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksBulk); err != nil {
+		return nil, err
+	}
+	return s.next.ProcessEscalations(ctx)
+}
+
+func (s *AuthorizedTaskService) WakeSnoozedTasks(ctx context.Context, now time.Time) ([]string, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksBulk); err != nil {
+		return nil, err
+	}
+	return s.next.WakeSnoozedTasks(ctx, now)
+}
+
+// Bulk operations
+
+func (s *AuthorizedTaskService) BulkUpdateStatus(ctx context.Context, taskIDs []string, status domain.TaskStatus, userID string) ([]ports.BulkOperationResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksBulk); err != nil {
+		return nil, err
+	}
+	return s.next.BulkUpdateStatus(ctx, taskIDs, status, userID)
+}
+
+func (s *AuthorizedTaskService) BulkAssign(ctx context.Context, taskIDs []string, assigneeID string, userID string) ([]ports.BulkOperationResult, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksBulk); err != nil {
+		return nil, err
+	}
+	return s.next.BulkAssign(ctx, taskIDs, assigneeID, userID)
+}
+
+func (s *AuthorizedTaskService) authorizeTask(ctx context.Context, id string, permission domain.Permission) error {
+	return authorizeTaskID(ctx, s.authorizer, s.next, id, permission)
+}
+
+// authorizeTaskID проверяет право и видимость задачи; задача загружается,
+// только если видимость пользователя ограничена
+func authorizeTaskID(ctx context.Context, authorizer ports.Authorizer, tasks ports.TaskService, id string, permission domain.Permission) error {
+	if err := authorizer.Authorize(ctx, permission); err != nil {
+		return err
+	}
+	if authorizer.TaskScope(ctx) == nil {
+		return nil
+	}
+	task, err := tasks.GetTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	return authorizer.AuthorizeTask(ctx, permission, task)
+}
+
+func (s *AuthorizedTaskService) visible(ctx context.Context, tasks []domain.Task) []domain.Task {
+	return visibleTasks(ctx, s.authorizer, tasks)
+}
+
+func (s *AuthorizedTaskService) redact(ctx context.Context, task *domain.Task) *domain.Task {
+	return redactTask(ctx, s.authorizer, task)
+}
+
+// visibleTasks оставляет видимые пользователю задачи и скрывает в них внутренние заметки
+func visibleTasks(ctx context.Context, authorizer ports.Authorizer, tasks []domain.Task) []domain.Task {
+	scope := authorizer.TaskScope(ctx)
+	result := make([]domain.Task, 0, len(tasks))
+	for i := range tasks {
+		if scope.Includes(&tasks[i]) {
+			result = append(result, *redactTask(ctx, authorizer, &tasks[i]))
+		}
+	}
+	return result
+}
+
+// redactTask скрывает внутренние заметки от пользователей без права messages:private
+func redactTask(ctx context.Context, authorizer ports.Authorizer, task *domain.Task) *domain.Task {
+	if authorizer.Can(ctx, domain.PermissionMessagesPrivate) {
+		return task
+	}
+	redacted := *task
+	redacted.Messages = task.CustomerVisibleMessages()
+	return &redacted
+}
+
+// isCurrentUser проверяет, что userID - аутентифицированный пользователь запроса
+func isCurrentUser(ctx context.Context, userID string) bool {
+	user, ok := ports.AuthenticatedUser(ctx)
+	return ok && userID != "" && user.ID == userID
+}
+
+var _ ports.TaskService = (*AuthorizedTaskService)(nil)
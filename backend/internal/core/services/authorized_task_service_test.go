@@ -0,0 +1,178 @@
+// internal/core/services/authorized_task_service_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthorizedTaskService(t *testing.T) (ports.TaskService, ports.CustomerService) {
+	t.Helper()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	authorizer := services.NewRoleAuthorizer(logger)
+
+	userRepo := inmemory.NewUserRepository(logger)
+	for _, role := range []domain.UserRole{domain.UserRoleViewer, domain.UserRoleOperator, domain.UserRoleManager} {
+		require.NoError(t, userRepo.Save(context.Background(), &domain.User{
+			ID:     "user-" + string(role),
+			Name:   string(role),
+			Role:   role,
+			Status: domain.UserStatusActive,
+		}))
+	}
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+	return services.NewAuthorizedTaskService(taskService, authorizer),
+		services.NewAuthorizedCustomerService(customerService, authorizer)
+}
+
+func asUser(role domain.UserRole, categories ...string) context.Context {
+	return ports.WithAuthenticatedUser(context.Background(), &domain.User{
+		ID:         "user-" + string(role),
+		Role:       role,
+		Categories: categories,
+	})
+}
+
+func createCategoryTask(t *testing.T, taskService ports.TaskService, category string) *domain.Task {
+	t.Helper()
+	// Системный context имеет все права
+	task, err := taskService.CreateInternalTask(ports.WithSystemPrincipal(context.Background()), ports.CreateInternalTaskRequest{
+		Subject:     "Задача " + category,
+		Description: "Описание",
+		ReporterID:  "user-2",
+		Priority:    domain.PriorityMedium,
+		Category:    category,
+	})
+	require.NoError(t, err)
+	return task
+}
+
+func TestAuthorizedTaskService_RolePermissions(t *testing.T) {
+	taskService, customerService := newAuthorizedTaskService(t)
+	task := createCategoryTask(t, taskService, "billing")
+
+	viewer := asUser(domain.UserRoleViewer)
+	_, err := taskService.GetTask(viewer, task.ID)
+	assert.NoError(t, err)
+	_, err = taskService.ChangeStatus(viewer, task.ID, domain.TaskStatusInProgress, "user-viewer")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = taskService.CreateInternalTask(viewer, ports.CreateInternalTaskRequest{Subject: "x", ReporterID: "user-viewer"})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	operator := asUser(domain.UserRoleOperator)
+	_, err = taskService.ChangeStatus(operator, task.ID, domain.TaskStatusInProgress, "user-operator")
+	assert.NoError(t, err)
+	_, err = taskService.BulkAssign(operator, []string{task.ID}, "user-3", "user-operator")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	assert.ErrorIs(t, taskService.DeleteTask(operator, task.ID), domain.ErrForbidden)
+	_, err = taskService.GetStats(operator, ports.StatsQuery{})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = taskService.GetTimeReport(operator, ports.TimeReportQuery{UserID: "user-operator"})
+	assert.NotErrorIs(t, err, domain.ErrForbidden, "свое время оператор видит без reports:read")
+	assert.ErrorIs(t, customerService.DeleteCustomer(operator, "customer-1"), domain.ErrForbidden)
+
+	manager := asUser(domain.UserRoleManager)
+	_, err = taskService.BulkAssign(manager, []string{task.ID}, "user-3", "user-manager")
+	assert.NoError(t, err)
+	assert.NoError(t, taskService.DeleteTask(manager, task.ID))
+}
+
+func TestAuthorizedTaskService_AnonymousContextIsDenied(t *testing.T) {
+	taskService, customerService := newAuthorizedTaskService(t)
+	task := createCategoryTask(t, taskService, "billing")
+
+	// Context без пользователя и без признака системы прав не имеет
+	anonymous := context.Background()
+	_, err := taskService.GetTask(anonymous, task.ID)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = taskService.SearchTasks(anonymous, ports.TaskQuery{})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = taskService.CreateInternalTask(anonymous, ports.CreateInternalTaskRequest{Subject: "x", ReporterID: "user-2"})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	assert.ErrorIs(t, customerService.DeleteCustomer(anonymous, "customer-1"), domain.ErrForbidden)
+
+	authorizer := services.NewRoleAuthorizer(&services.MockLogger{})
+	assert.False(t, authorizer.Can(anonymous, domain.PermissionTasksRead))
+	assert.False(t, authorizer.TaskScope(anonymous).Includes(&domain.Task{ID: "unassigned"}),
+		"empty scope does not include tasks without assignee")
+
+	system := ports.WithSystemPrincipal(context.Background())
+	assert.True(t, authorizer.Can(system, domain.PermissionTasksDelete))
+	assert.Nil(t, authorizer.TaskScope(system))
+	_, err = taskService.GetTask(system, task.ID)
+	assert.NoError(t, err)
+}
+
+func TestAuthorizedTaskService_OperatorCategories(t *testing.T) {
+	taskService, _ := newAuthorizedTaskService(t)
+	billing := createCategoryTask(t, taskService, "billing")
+	technical := createCategoryTask(t, taskService, "technical")
+
+	operator := asUser(domain.UserRoleOperator, "billing")
+
+	_, err := taskService.GetTask(operator, billing.ID)
+	assert.NoError(t, err)
+	_, err = taskService.GetTask(operator, technical.ID)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = taskService.AddMessage(operator, technical.ID, ports.AddMessageRequest{AuthorID: "user-operator", Content: "Ответ"})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	result, err := taskService.SearchTasks(operator, ports.TaskQuery{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, billing.ID, result.Tasks[0].ID)
+	assert.Equal(t, 1, result.TotalCount)
+
+	// Назначенная оператору задача видна вне его категорий
+	_, err = taskService.AssignTask(ports.WithSystemPrincipal(context.Background()), technical.ID, "user-operator", "system")
+	require.NoError(t, err)
+	_, err = taskService.GetTask(operator, technical.ID)
+	assert.NoError(t, err)
+
+	// Менеджер видит задачи всех категорий независимо от списка категорий
+	result, err = taskService.SearchTasks(asUser(domain.UserRoleManager, "billing"), ports.TaskQuery{Limit: 50})
+	require.NoError(t, err)
+	assert.Len(t, result.Tasks, 2)
+}
+
+func TestAuthorizedTaskService_PrivateMessages(t *testing.T) {
+	taskService, _ := newAuthorizedTaskService(t)
+	task := createCategoryTask(t, taskService, "billing")
+
+	operator := asUser(domain.UserRoleOperator)
+	_, err := taskService.AddMessage(operator, task.ID, ports.AddMessageRequest{AuthorID: "user-operator", Content: "Ответ клиенту"})
+	require.NoError(t, err)
+	_, err = taskService.AddMessage(operator, task.ID, ports.AddMessageRequest{AuthorID: "user-operator", Content: "Заметка", IsPrivate: true})
+	require.NoError(t, err)
+
+	got, err := taskService.GetTask(operator, task.ID)
+	require.NoError(t, err)
+	assert.Len(t, got.Messages, 2)
+
+	viewer := asUser(domain.UserRoleViewer)
+	got, err = taskService.GetTask(viewer, task.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Messages, 1)
+	assert.Equal(t, "Ответ клиенту", got.Messages[0].Content)
+
+	result, err := taskService.SearchTasks(viewer, ports.TaskQuery{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 1)
+	assert.Len(t, result.Tasks[0].Messages, 1)
+
+	// Скрытие заметок не меняет сохраненную задачу
+	got, err = taskService.GetTask(ports.WithSystemPrincipal(context.Background()), task.ID)
+	require.NoError(t, err)
+	assert.Len(t, got.Messages, 2)
+}
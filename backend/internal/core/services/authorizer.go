@@ -0,0 +1,63 @@
+// internal/core/services/authorizer.go
+package services
+
+import (
+	"context"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// RoleAuthorizer реализует ports.Authorizer по ролям пользователей (domain.RolePermissions)
+type RoleAuthorizer struct {
+	logger ports.Logger
+}
+
+func NewRoleAuthorizer(logger ports.Logger) *RoleAuthorizer {
+	return &RoleAuthorizer{logger: logger}
+}
+
+// Can разрешает пользователю права его роли, а системному context - все права.
+// Context без пользователя и без признака системы прав не имеет
+func (a *RoleAuthorizer) Can(ctx context.Context, permission domain.Permission) bool {
+	if user, ok := ports.AuthenticatedUser(ctx); ok {
+		return user.Role.Has(permission)
+	}
+	return ports.IsSystemPrincipal(ctx)
+}
+
+func (a *RoleAuthorizer) Authorize(ctx context.Context, permission domain.Permission) error {
+	if a.Can(ctx, permission) {
+		return nil
+	}
+	if user, ok := ports.AuthenticatedUser(ctx); ok {
+		a.logger.Warn(ctx, "access denied", "user_id", user.ID, "role", user.Role, "permission", permission)
+	} else {
+		a.logger.Warn(ctx, "access denied: no authenticated principal", "permission", permission)
+	}
+	return domain.NewForbiddenError(permission)
+}
+
+func (a *RoleAuthorizer) AuthorizeTask(ctx context.Context, permission domain.Permission, task *domain.Task) error {
+	if err := a.Authorize(ctx, permission); err != nil {
+		return err
+	}
+	if !a.TaskScope(ctx).Includes(task) {
+		user, _ := ports.AuthenticatedUser(ctx)
+		a.logger.Warn(ctx, "task access denied", "user_id", user.ID, "task_id", task.ID, "category", task.Category)
+		return domain.NewForbiddenError(domain.PermissionTasksAll)
+	}
+	return nil
+}
+
+// TaskScope без пользователя: системному context видны все задачи, анонимному - никакие
+func (a *RoleAuthorizer) TaskScope(ctx context.Context) *domain.TaskScope {
+	user, ok := ports.AuthenticatedUser(ctx)
+	if !ok {
+		if ports.IsSystemPrincipal(ctx) {
+			return nil
+		}
+		return &domain.TaskScope{}
+	}
+	return domain.TaskScopeFor(user)
+}
@@ -49,6 +49,9 @@ func (m *BackgroundTaskManager) StartAll(ctx context.Context) error {
 	// ✅ ВОЗВРАЩАЕМ НОРМАЛЬНОЕ ЛОГИРОВАНИЕ
 	m.logger.Info(ctx, "starting all background tasks", "task_count", len(m.tasks))
 
+	// Фоновые задачи выполняются от имени системы
+	ctx = ports.WithSystemPrincipal(ctx)
+
 	for _, task := range m.tasks {
 		m.wg.Add(1)
 		go func(t ports.BackgroundTask) {
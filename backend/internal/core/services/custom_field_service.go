@@ -0,0 +1,129 @@
+// internal/core/services/custom_field_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// CustomFieldService управляет описаниями пользовательских полей задач
+type CustomFieldService struct {
+	fieldRepo ports.CustomFieldRepository
+	logger    ports.Logger
+}
+
+func NewCustomFieldService(fieldRepo ports.CustomFieldRepository, logger ports.Logger) *CustomFieldService {
+	return &CustomFieldService{
+		fieldRepo: fieldRepo,
+		logger:    logger,
+	}
+}
+
+// CreateField создает описание пользовательского поля
+func (s *CustomFieldService) CreateField(ctx context.Context, req ports.CustomFieldRequest) (*domain.CustomFieldDefinition, error) {
+	def, err := domain.NewCustomFieldDefinition(req.Key, req.Name, req.Type, req.Options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom field: %w", err)
+	}
+	applyCustomFieldRequest(def, req)
+
+	if err := def.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid custom field: %w", err)
+	}
+
+	if err := s.fieldRepo.Save(ctx, def); err != nil {
+		return nil, fmt.Errorf("failed to save custom field: %w", err)
+	}
+
+	s.logger.Info(ctx, "custom field created", "key", def.Key, "type", def.Type)
+	return def, nil
+}
+
+// GetField возвращает описание поля по ключу
+func (s *CustomFieldService) GetField(ctx context.Context, key string) (*domain.CustomFieldDefinition, error) {
+	def, err := s.fieldRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom field: %w", err)
+	}
+	return def, nil
+}
+
+// ListFields возвращает все описания полей
+func (s *CustomFieldService) ListFields(ctx context.Context) ([]domain.CustomFieldDefinition, error) {
+	fields, err := s.fieldRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+	return fields, nil
+}
+
+// ListFieldsFor возвращает поля, применимые к задаче указанного типа и категории
+func (s *CustomFieldService) ListFieldsFor(ctx context.Context, taskType domain.TaskType, category string) ([]domain.CustomFieldDefinition, error) {
+	fields, err := s.ListFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applicable := []domain.CustomFieldDefinition{}
+	for _, def := range fields {
+		if def.AppliesTo(taskType, category) {
+			applicable = append(applicable, def)
+		}
+	}
+	return applicable, nil
+}
+
+// UpdateField изменяет описание поля. Ключ и тип поля не меняются,
+// чтобы не сделать некорректными уже сохраненные значения
+func (s *CustomFieldService) UpdateField(ctx context.Context, key string, req ports.CustomFieldRequest) (*domain.CustomFieldDefinition, error) {
+	existing, err := s.fieldRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find custom field: %w", err)
+	}
+
+	if req.Type != "" && req.Type != existing.Type {
+		return nil, errors.New("custom field type cannot be changed")
+	}
+
+	updated := *existing
+	if req.Name != "" {
+		updated.Name = req.Name
+	}
+	applyCustomFieldRequest(&updated, req)
+	updated.UpdatedAt = time.Now()
+
+	if err := updated.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid custom field: %w", err)
+	}
+
+	if err := s.fieldRepo.Update(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update custom field: %w", err)
+	}
+
+	s.logger.Info(ctx, "custom field updated", "key", key)
+	return &updated, nil
+}
+
+// DeleteField удаляет описание поля. Значения в задачах сохраняются как есть
+func (s *CustomFieldService) DeleteField(ctx context.Context, key string) error {
+	if err := s.fieldRepo.Delete(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+
+	s.logger.Info(ctx, "custom field deleted", "key", key)
+	return nil
+}
+
+// applyCustomFieldRequest переносит изменяемые атрибуты из запроса в описание поля
+func applyCustomFieldRequest(def *domain.CustomFieldDefinition, req ports.CustomFieldRequest) {
+	def.TaskTypes = req.TaskTypes
+	def.Categories = req.Categories
+	def.Required = req.Required
+	def.DefaultValue = req.DefaultValue
+	def.Options = req.Options
+}
@@ -205,21 +205,31 @@ func (s *CustomerService) DeleteCustomer(ctx context.Context, id string) error {
 	return nil
 }
 
-// ListCustomers возвращает список клиентов с пагинацией
+// ListCustomers возвращает страницу клиентов по курсору или смещению.
+// Без сортировки клиенты упорядочиваются по имени
 func (s *CustomerService) ListCustomers(ctx context.Context, query ports.CustomerQuery) (*ports.CustomerSearchResult, error) {
-	// TODO: Реализовать полнофункциональный поиск клиентов
-	// Временная реализация - возвращаем всех клиентов
-
-	// Для демонстрации создаем пустой результат
-	result := &ports.CustomerSearchResult{
-		Customers:  []domain.Customer{},
-		TotalCount: 0,
-		Page:       1,
-		PageSize:   query.Limit,
-		TotalPages: 0,
+	if len(query.Sort) == 0 {
+		query.Sort = domain.DefaultCustomerSort
 	}
 
-	return result, nil
+	customers, err := s.customerRepo.FindByQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customers: %w", err)
+	}
+
+	page, err := paginate(domain.CustomerListing(query.Sort), customers, query.Cursor, query.Offset, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ports.CustomerSearchResult{
+		Customers:  page.Items,
+		TotalCount: page.TotalCount,
+		Page:       page.Page,
+		PageSize:   page.PageSize,
+		TotalPages: page.TotalPages,
+		NextCursor: page.NextCursor,
+	}, nil
 }
 
 // Вспомогательные методы
@@ -263,11 +273,15 @@ func (s *CustomerService) calculateCustomerStats(tasks []domain.Task) *ports.Cus
 		if task.Category != "" {
 			stats.ByCategory[task.Category]++
 		}
+
+		// Учет времени
+		stats.TimeSpentHours += task.TimeSpent().Hours()
+		stats.BillableHours += task.BillableTime().Hours()
 	}
 
-	// TODO: Реализовать расчет времени ответа и удовлетворенности
+	// TODO: Реализовать расчет времени ответа
 	stats.AvgResponseTime = 0
-	stats.Satisfaction = 0
+	stats.Satisfaction = taskSatisfaction(tasks).CSAT
 
 	return stats
 }
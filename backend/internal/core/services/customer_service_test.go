@@ -369,8 +369,37 @@ func TestCustomerService_ListCustomers(t *testing.T) {
 
 		result, err := customerService.ListCustomers(ctx, query)
 		require.NoError(t, err)
-		// Note: InMemory реализация пока возвращает пустой результат
-		// Это нормально для текущей стадии разработки
-		assert.NotNil(t, result)
+		require.Len(t, result.Customers, 2)
+		assert.Equal(t, "Customer A", result.Customers[0].Name)
+		assert.Equal(t, 3, result.TotalCount)
+		assert.Equal(t, 2, result.TotalPages)
+		require.NotEmpty(t, result.NextCursor)
+
+		next, err := customerService.ListCustomers(ctx, ports.CustomerQuery{Limit: 2, Cursor: result.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, next.Customers, 1)
+		assert.Equal(t, "Customer C", next.Customers[0].Name)
+		assert.Equal(t, 2, next.Page)
+		assert.Empty(t, next.NextCursor)
+	})
+
+	t.Run("sort and search", func(t *testing.T) {
+		result, err := customerService.ListCustomers(ctx, ports.CustomerQuery{
+			SearchText: "customer",
+			Sort:       []domain.SortField{{Field: "email", Desc: true}},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Customers, 3)
+		assert.Equal(t, "c@example.com", result.Customers[0].Email)
+
+		// Курсор другой сортировки не принимается
+		page, err := customerService.ListCustomers(ctx, ports.CustomerQuery{Limit: 1})
+		require.NoError(t, err)
+		_, err = customerService.ListCustomers(ctx, ports.CustomerQuery{
+			Limit:  1,
+			Cursor: page.NextCursor,
+			Sort:   []domain.SortField{{Field: "email", Desc: true}},
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
 	})
 }
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
@@ -16,6 +18,10 @@ type EmailService struct {
 	processor   ports.MessageProcessor
 	idGenerator domain.IDGenerator
 	policy      domain.EmailProcessingPolicy
+	policyMu    sync.RWMutex
+	publisher   ports.EventPublisher
+	uow         ports.UnitOfWork
+	taskService ports.TaskService
 	logger      ports.Logger
 }
 
@@ -34,15 +40,45 @@ func NewEmailService(
 		processor:   processor,
 		idGenerator: idGenerator,
 		policy:      policy,
+		uow:         NoopUnitOfWork{},
 		logger:      logger,
 	}
 }
 
+// SetEventPublisher включает публикацию событий email.received и email.sent.
+// Входящее письмо и событие о нем сохраняются в одной единице работы
+func (s *EmailService) SetEventPublisher(publisher ports.EventPublisher, uow ports.UnitOfWork) {
+	s.publisher = publisher
+	if uow != nil {
+		s.uow = uow
+	}
+}
+
+// SetTaskService включает проверку задачи при ручной привязке письма.
+// Проверка идет через сервис задач, поэтому учитывает видимость задач пользователя
+func (s *EmailService) SetTaskService(taskService ports.TaskService) {
+	s.taskService = taskService
+}
+
+// SetPolicy заменяет политику обработки; следующие письма обрабатываются по новой политике
+func (s *EmailService) SetPolicy(policy domain.EmailProcessingPolicy) {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+	s.policy = policy
+}
+
+// Policy возвращает текущую политику обработки
+func (s *EmailService) Policy() domain.EmailProcessingPolicy {
+	s.policyMu.RLock()
+	defer s.policyMu.RUnlock()
+	return s.policy
+}
+
 // ProcessIncomingEmails обрабатывает входящие email сообщения
 func (s *EmailService) ProcessIncomingEmails(ctx context.Context) error {
 	s.logger.Info(ctx, "Starting incoming email processing",
 		"operation", "process_incoming_emails",
-		"read_only_mode", s.policy.ReadOnlyMode)
+		"read_only_mode", s.Policy().ReadOnlyMode)
 
 	// Проверяем соединение
 	if err := s.gateway.HealthCheck(ctx); err != nil {
@@ -127,7 +163,7 @@ func (s *EmailService) SendEmail(ctx context.Context, msg domain.EmailMessage) e
 	}
 
 	// Применяем бизнес-правила
-	if s.policy.ReadOnlyMode {
+	if s.Policy().ReadOnlyMode {
 		s.logger.Warn(ctx, "Read-only mode enabled, skipping actual send")
 		return nil
 	}
@@ -156,6 +192,9 @@ func (s *EmailService) SendEmail(ctx context.Context, msg domain.EmailMessage) e
 	outgoingMsg.CC = msg.CC
 	outgoingMsg.BCC = msg.BCC
 	outgoingMsg.Attachments = msg.Attachments
+	outgoingMsg.InReplyTo = msg.InReplyTo
+	outgoingMsg.References = msg.References
+	outgoingMsg.RelatedTicketID = msg.RelatedTicketID
 
 	// Сохраняем в репозиторий перед отправкой
 	if err := s.repo.Save(ctx, outgoingMsg); err != nil {
@@ -167,6 +206,13 @@ func (s *EmailService) SendEmail(ctx context.Context, msg domain.EmailMessage) e
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
+	if s.publisher != nil {
+		if err := s.publisher.Publish(ctx, domain.NewEmailDomainEvent(domain.DomainEventEmailSent, *outgoingMsg)); err != nil {
+			s.logger.Error(ctx, "Failed to publish email sent event",
+				"message_id", outgoingMsg.MessageID, "error", err.Error())
+		}
+	}
+
 	// Обрабатываем исходящее сообщение
 	if s.processor != nil {
 		if err := s.processor.ProcessOutgoingEmail(ctx, *outgoingMsg); err != nil {
@@ -203,7 +249,7 @@ func (s *EmailService) TestConnection(ctx context.Context) error {
 }
 
 // GetEmailStatistics возвращает статистику по email сообщениям
-func (s *EmailService) GetEmailStatistics(ctx context.Context) (*EmailStatistics, error) {
+func (s *EmailService) GetEmailStatistics(ctx context.Context) (*ports.EmailStatistics, error) {
 	// Получаем непрочитанные сообщения
 	unprocessed, err := s.repo.FindUnprocessed(ctx)
 	if err != nil {
@@ -217,7 +263,7 @@ func (s *EmailService) GetEmailStatistics(ctx context.Context) (*EmailStatistics
 		return nil, fmt.Errorf("failed to get recent emails: %w", err)
 	}
 
-	stats := &EmailStatistics{
+	stats := &ports.EmailStatistics{
 		UnprocessedCount: len(unprocessed),
 		RecentCount:      len(recent),
 		LastProcessed:    s.getLastProcessedTime(unprocessed),
@@ -226,6 +272,111 @@ func (s *EmailService) GetEmailStatistics(ctx context.Context) (*EmailStatistics
 	return stats, nil
 }
 
+// ListEmails возвращает страницу писем по курсору или смещению.
+// Без сортировки письма упорядочиваются от новых к старым
+func (s *EmailService) ListEmails(ctx context.Context, query ports.EmailQuery) (*ports.EmailSearchResult, error) {
+	if len(query.Sort) == 0 {
+		query.Sort = domain.DefaultEmailSort
+	}
+
+	// Репозиторий выбирает страницу сам: в PostgreSQL курсор и LIMIT применяются в запросе
+	result, err := s.repo.FindByQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emails: %w", err)
+	}
+
+	page := newListPage(domain.EmailListing(query.Sort), result.Emails, result.Start, result.TotalCount, query.Limit)
+
+	return &ports.EmailSearchResult{
+		Emails:     page.Items,
+		TotalCount: page.TotalCount,
+		Page:       page.Page,
+		PageSize:   page.PageSize,
+		TotalPages: page.TotalPages,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// GetEmail возвращает письмо со всеми заголовками, телом и вложениями
+func (s *EmailService) GetEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error) {
+	msg, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email: %w", err)
+	}
+	return msg, nil
+}
+
+// ReprocessEmail заново проводит сохраненное письмо через процессор, например после
+// ошибки в определении треда. Письмо связывается с задачей, которую процессор создал или дополнил
+func (s *EmailService) ReprocessEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error) {
+	if s.processor == nil {
+		return nil, errors.New("message processor is not configured")
+	}
+
+	msg, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email: %w", err)
+	}
+	if msg.Direction != domain.DirectionIncoming {
+		return nil, errors.New("only incoming emails can be reprocessed")
+	}
+
+	ticketID, err := s.runProcessor(ctx, *msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reprocess email: %w", err)
+	}
+
+	msg.MarkAsProcessed(ticketID)
+	if err := s.repo.Update(ctx, msg); err != nil {
+		return nil, fmt.Errorf("failed to update email: %w", err)
+	}
+
+	s.logger.Info(ctx, "Email reprocessed",
+		"email_id", msg.ID, "message_id", msg.MessageID, "task_id", ticketID)
+	return msg, nil
+}
+
+// LinkEmail связывает письмо с задачей вручную
+func (s *EmailService) LinkEmail(ctx context.Context, id domain.MessageID, taskID string) (*domain.EmailMessage, error) {
+	if taskID == "" {
+		return nil, errors.New("task ID is required")
+	}
+
+	msg, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email: %w", err)
+	}
+	if s.taskService != nil {
+		if _, err := s.taskService.GetTask(ctx, taskID); err != nil {
+			return nil, err
+		}
+	}
+
+	msg.LinkToTicket(taskID)
+	if err := s.repo.Update(ctx, msg); err != nil {
+		return nil, fmt.Errorf("failed to update email: %w", err)
+	}
+
+	s.logger.Info(ctx, "Email linked to task", "email_id", msg.ID, "task_id", taskID)
+	return msg, nil
+}
+
+// UnlinkEmail убирает связь письма с задачей
+func (s *EmailService) UnlinkEmail(ctx context.Context, id domain.MessageID) (*domain.EmailMessage, error) {
+	msg, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email: %w", err)
+	}
+
+	msg.UnlinkFromTicket()
+	if err := s.repo.Update(ctx, msg); err != nil {
+		return nil, fmt.Errorf("failed to update email: %w", err)
+	}
+
+	s.logger.Info(ctx, "Email unlinked from task", "email_id", msg.ID)
+	return msg, nil
+}
+
 // ProcessSingleEmail обрабатывает одно email сообщение (для тестирования)
 func (s *EmailService) ProcessSingleEmail(ctx context.Context, msg domain.EmailMessage) error {
 	return s.processSingleEmail(ctx, msg)
@@ -250,8 +401,10 @@ func (s *EmailService) processSingleEmail(ctx context.Context, msg domain.EmailM
 		return nil
 	}
 
+	policy := s.Policy()
+
 	// Проверяем спам-фильтр
-	if msg.IsSpam(s.policy) {
+	if msg.IsSpam(policy) {
 		s.logger.Info(ctx, "Skipping spam email",
 			"message_id", msg.MessageID,
 			"subject", msg.Subject,
@@ -268,7 +421,7 @@ func (s *EmailService) processSingleEmail(ctx context.Context, msg domain.EmailM
 	}
 
 	// Проверяем разрешенных отправителей
-	if !msg.IsFromAllowedSender(s.policy) {
+	if !msg.IsFromAllowedSender(policy) {
 		s.logger.Warn(ctx, "Email from blocked sender",
 			"message_id", msg.MessageID,
 			"from", msg.From,
@@ -283,13 +436,39 @@ func (s *EmailService) processSingleEmail(ctx context.Context, msg domain.EmailM
 		return nil
 	}
 
+	// Проверяем лимит размера
+	if msg.ExceedsSizeLimit(policy) {
+		s.logger.Warn(ctx, "Email exceeds size limit",
+			"message_id", msg.MessageID,
+			"size", msg.Size(),
+			"max_size", policy.MaxMessageSize,
+			"operation", "size_limit")
+		msg.Processed = true
+		msg.ProcessedAt = time.Now()
+		if err := s.repo.Save(ctx, &msg); err != nil {
+			s.logger.Error(ctx, "Failed to save oversized email",
+				"message_id", msg.MessageID,
+				"error", err.Error())
+		}
+		return nil
+	}
+
 	// Сохраняем сообщение
 	msg.Direction = domain.DirectionIncoming
 	msg.Processed = false
 	msg.CreatedAt = time.Now()
 	msg.UpdatedAt = time.Now()
 
-	if err := s.repo.Save(ctx, &msg); err != nil {
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Save(ctx, &msg); err != nil {
+			return err
+		}
+		if s.publisher == nil {
+			return nil
+		}
+		return s.publisher.Publish(ctx, domain.NewEmailDomainEvent(domain.DomainEventEmailReceived, msg))
+	})
+	if err != nil {
 		s.logger.Error(ctx, "Failed to save incoming email",
 			"message_id", msg.MessageID,
 			"error", err.Error())
@@ -301,8 +480,10 @@ func (s *EmailService) processSingleEmail(ctx context.Context, msg domain.EmailM
 		"operation", "save_repository")
 
 	// Обрабатываем через процессор если он есть
+	var ticketID *string
 	if s.processor != nil {
-		if err := s.processor.ProcessIncomingEmail(ctx, msg); err != nil {
+		ticketID, err = s.runProcessor(ctx, msg)
+		if err != nil {
 			s.logger.Error(ctx, "Failed to process incoming email",
 				"message_id", msg.MessageID,
 				"error", err.Error())
@@ -311,8 +492,7 @@ func (s *EmailService) processSingleEmail(ctx context.Context, msg domain.EmailM
 	}
 
 	// Помечаем как обработанное
-	msg.Processed = true
-	msg.ProcessedAt = time.Now()
+	msg.MarkAsProcessed(ticketID)
 	if err := s.repo.Update(ctx, &msg); err != nil {
 		s.logger.Error(ctx, "Failed to mark email as processed",
 			"message_id", msg.MessageID,
@@ -327,11 +507,24 @@ func (s *EmailService) processSingleEmail(ctx context.Context, msg domain.EmailM
 	return nil
 }
 
+// runProcessor обрабатывает письмо процессором; ID задачи известен,
+// только если процессор реализует ports.TaskMessageProcessor
+func (s *EmailService) runProcessor(ctx context.Context, msg domain.EmailMessage) (*string, error) {
+	if processor, ok := s.processor.(ports.TaskMessageProcessor); ok {
+		taskID, err := processor.ProcessIncomingEmailForTask(ctx, msg)
+		if err != nil || taskID == "" {
+			return nil, err
+		}
+		return &taskID, nil
+	}
+	return nil, s.processor.ProcessIncomingEmail(ctx, msg)
+}
+
 // isSpamRecipient проверяет получателей на спам (для исходящих)
 func (s *EmailService) isSpamRecipient(msg domain.EmailMessage) bool {
 	// Проверяем всех получателей на наличие в заблокированных
 	for _, recipient := range msg.To {
-		for _, blocked := range s.policy.BlockedSenders {
+		for _, blocked := range s.Policy().BlockedSenders {
 			if recipient == blocked {
 				return true
 			}
@@ -362,10 +555,3 @@ func (s *EmailService) getLastProcessedTime(messages []domain.EmailMessage) *tim
 
 	return &lastTime
 }
-
-// EmailStatistics статистика по email сообщениям
-type EmailStatistics struct {
-	UnprocessedCount int        `json:"unprocessed_count"`
-	RecentCount      int        `json:"recent_count"`
-	LastProcessed    *time.Time `json:"last_processed"`
-}
@@ -3,14 +3,19 @@ package services_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
 	"github.com/audetv/urms/internal/core/ports"
 	"github.com/audetv/urms/internal/core/services"
+	emailinmemory "github.com/audetv/urms/internal/infrastructure/persistence/email/inmemory"
+	eventsinmemory "github.com/audetv/urms/internal/infrastructure/persistence/events/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock dependencies
@@ -113,6 +118,11 @@ func (m *MockEmailRepository) FindByPeriod(ctx context.Context, from, to time.Ti
 	return args.Get(0).([]domain.EmailMessage), args.Error(1)
 }
 
+func (m *MockEmailRepository) FindByQuery(ctx context.Context, query ports.EmailQuery) (*ports.EmailQueryPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(*ports.EmailQueryPage), args.Error(1)
+}
+
 func (m *MockEmailRepository) FindByInReplyTo(ctx context.Context, inReplyTo string) ([]domain.EmailMessage, error) {
 	args := m.Called(ctx, inReplyTo)
 	return args.Get(0).([]domain.EmailMessage), args.Error(1)
@@ -314,3 +324,193 @@ func TestEmailService_SendEmail(t *testing.T) {
 		gateway.AssertNotCalled(t, "SendMessage", ctx, mock.Anything)
 	})
 }
+
+func TestEmailService_PublishesEvents(t *testing.T) {
+	ctx := context.Background()
+	logger := new(services.MockLogger)
+	outboxRepo := eventsinmemory.NewOutboxRepository(logger)
+
+	gateway := new(MockEmailGateway)
+	repo := new(MockEmailRepository)
+	processor := new(MockMessageProcessor)
+	idGenerator := new(MockIDGenerator)
+
+	policy := domain.EmailProcessingPolicy{
+		AllowedSenders: []domain.EmailAddress{"customer@example.com"},
+	}
+	service := services.NewEmailService(gateway, repo, processor, idGenerator, policy, logger)
+	service.SetEventPublisher(services.NewOutboxPublisher(outboxRepo), services.NoopUnitOfWork{})
+
+	idGenerator.On("GenerateMessageID").Return("<reply@urms.local>")
+	idGenerator.On("GenerateID").Return("outgoing-id")
+	repo.On("FindByMessageID", ctx, "<incoming@example.com>").Return((*domain.EmailMessage)(nil), domain.ErrEmailNotFound)
+	repo.On("Save", ctx, mock.AnythingOfType("*domain.EmailMessage")).Return(nil)
+	repo.On("Update", ctx, mock.AnythingOfType("*domain.EmailMessage")).Return(nil)
+	gateway.On("SendMessage", ctx, mock.AnythingOfType("domain.EmailMessage")).Return(nil)
+	processor.On("ProcessIncomingEmail", ctx, mock.AnythingOfType("domain.EmailMessage")).Return(nil)
+	processor.On("ProcessOutgoingEmail", ctx, mock.AnythingOfType("domain.EmailMessage")).Return(nil)
+
+	require.NoError(t, service.ProcessSingleEmail(ctx, domain.EmailMessage{
+		ID:        "incoming-id",
+		MessageID: "<incoming@example.com>",
+		From:      "customer@example.com",
+		To:        []domain.EmailAddress{"support@company.com"},
+		Subject:   "Не работает вход",
+		BodyText:  "Помогите",
+	}))
+	require.NoError(t, service.SendEmail(ctx, domain.EmailMessage{
+		From:     "support@company.com",
+		To:       []domain.EmailAddress{"customer@example.com"},
+		Subject:  "Re: Не работает вход",
+		BodyText: "Проверьте пароль",
+	}))
+
+	messages := outboxRepo.FindAll(ctx)
+	require.Len(t, messages, 2)
+
+	received := messages[0].Event
+	assert.Equal(t, domain.DomainEventEmailReceived, received.Type)
+	assert.Equal(t, domain.AggregateEmail, received.AggregateType)
+	assert.Equal(t, "incoming-id", received.AggregateID)
+	assert.Equal(t, "customer@example.com", received.Data["from"])
+
+	sent := messages[1].Event
+	assert.Equal(t, domain.DomainEventEmailSent, sent.Type)
+	assert.Equal(t, "<reply@urms.local>", sent.Data["message_id"])
+	assert.Equal(t, "customer@example.com", sent.Data["to"])
+}
+
+// taskLinkingProcessor процессор, который относит каждое письмо к заданной задаче
+type taskLinkingProcessor struct {
+	taskID    string
+	processed []string
+}
+
+func (p *taskLinkingProcessor) ProcessIncomingEmail(ctx context.Context, email domain.EmailMessage) error {
+	_, err := p.ProcessIncomingEmailForTask(ctx, email)
+	return err
+}
+
+func (p *taskLinkingProcessor) ProcessIncomingEmailForTask(ctx context.Context, email domain.EmailMessage) (string, error) {
+	p.processed = append(p.processed, string(email.ID))
+	return p.taskID, nil
+}
+
+func (p *taskLinkingProcessor) ProcessOutgoingEmail(ctx context.Context, email domain.EmailMessage) error {
+	return nil
+}
+
+func TestEmailService_ManageStoredEmails(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	taskService := services.NewTaskService(taskRepo, customerRepo, inmemory.NewUserRepository(logger), logger)
+	task, err := taskService.CreateInternalTask(ctx, ports.CreateInternalTaskRequest{
+		Subject:     "Не работает вход",
+		Description: "Описание",
+		ReporterID:  "user-1",
+		Priority:    domain.PriorityMedium,
+	})
+	require.NoError(t, err)
+
+	repo := emailinmemory.NewInMemoryEmailRepo()
+	processor := &taskLinkingProcessor{taskID: task.ID}
+	service := services.NewEmailService(new(MockEmailGateway), repo, processor, new(MockIDGenerator), domain.EmailProcessingPolicy{}, logger)
+	service.SetTaskService(taskService)
+
+	require.NoError(t, service.ProcessSingleEmail(ctx, domain.EmailMessage{
+		ID:        "email-1",
+		MessageID: "<email-1@example.com>",
+		From:      "customer@example.com",
+		To:        []domain.EmailAddress{"support@company.com"},
+		Subject:   "Не работает вход",
+		BodyText:  "Помогите",
+	}))
+
+	t.Run("processing links email to task", func(t *testing.T) {
+		msg, err := service.GetEmail(ctx, "email-1")
+		require.NoError(t, err)
+		assert.True(t, msg.Processed)
+		require.NotNil(t, msg.RelatedTicketID)
+		assert.Equal(t, task.ID, *msg.RelatedTicketID)
+	})
+
+	t.Run("unlink and link", func(t *testing.T) {
+		msg, err := service.UnlinkEmail(ctx, "email-1")
+		require.NoError(t, err)
+		assert.Nil(t, msg.RelatedTicketID)
+
+		_, err = service.LinkEmail(ctx, "email-1", "missing-task")
+		assert.Error(t, err, "task must exist")
+
+		msg, err = service.LinkEmail(ctx, "email-1", task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, msg.RelatedTicketID)
+		assert.Equal(t, task.ID, *msg.RelatedTicketID)
+
+		linked, err := service.ListEmails(ctx, ports.EmailQuery{RelatedTicketID: &task.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, linked.TotalCount)
+	})
+
+	t.Run("reprocess runs processor again", func(t *testing.T) {
+		_, err := service.UnlinkEmail(ctx, "email-1")
+		require.NoError(t, err)
+
+		msg, err := service.ReprocessEmail(ctx, "email-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email-1", "email-1"}, processor.processed)
+		require.NotNil(t, msg.RelatedTicketID)
+		assert.Equal(t, task.ID, *msg.RelatedTicketID)
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		_, err := service.ReprocessEmail(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrEmailNotFound)
+		_, err = service.UnlinkEmail(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrEmailNotFound)
+	})
+}
+
+func TestEmailService_ListEmailsPagesInRepository(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	repo := emailinmemory.NewInMemoryEmailRepo()
+	service := services.NewEmailService(new(MockEmailGateway), repo, nil, new(MockIDGenerator), domain.EmailProcessingPolicy{}, logger)
+
+	base := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		require.NoError(t, repo.Save(ctx, &domain.EmailMessage{
+			ID:        domain.MessageID(fmt.Sprintf("email-%d", i)),
+			MessageID: fmt.Sprintf("<email-%d@example.com>", i),
+			From:      "customer@example.com",
+			Subject:   fmt.Sprintf("Письмо %d", i),
+			Direction: domain.DirectionIncoming,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	var ids []domain.MessageID
+	query := ports.EmailQuery{Limit: 2}
+	for page := 1; ; page++ {
+		result, err := service.ListEmails(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.TotalCount)
+		assert.Equal(t, 3, result.TotalPages)
+		assert.Equal(t, page, result.Page, "page number is derived from cursor position")
+		for _, msg := range result.Emails {
+			ids = append(ids, msg.ID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		query.Cursor = result.NextCursor
+	}
+	assert.Equal(t, []domain.MessageID{"email-4", "email-3", "email-2", "email-1", "email-0"}, ids)
+
+	// Курсор выдан для другой сортировки
+	_, err := service.ListEmails(ctx, ports.EmailQuery{Sort: []domain.SortField{{Field: "subject"}}, Cursor: query.Cursor})
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
@@ -0,0 +1,419 @@
+// internal/core/services/email_settings_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// EmailSettingsService хранит настройки email и применяет их без перезапуска:
+// активный канал переключает шлюз, политика передается EmailService, окна поиска
+// тредов читаются IMAP-адаптером и обработчиком сообщений через EmailSearchConfigProvider
+type EmailSettingsService struct {
+	repo     ports.EmailSettingsRepository
+	fallback ports.EmailSearchConfigProvider // Файловая конфигурация: значения по умолчанию и настройки провайдеров
+	logger   ports.Logger
+
+	gateway        ports.ReconfigurableEmailGateway
+	gatewayFactory ports.EmailGatewayFactory
+	emailService   *EmailService
+
+	mu           sync.RWMutex
+	threadSearch *ports.ThreadSearchConfig
+}
+
+// NewEmailSettingsService создает сервис настроек email
+func NewEmailSettingsService(
+	repo ports.EmailSettingsRepository,
+	fallback ports.EmailSearchConfigProvider,
+	logger ports.Logger,
+) *EmailSettingsService {
+	return &EmailSettingsService{
+		repo:     repo,
+		fallback: fallback,
+		logger:   logger,
+	}
+}
+
+// SetGateway включает применение активного канала к шлюзу и проверку соединения
+// через отдельный шлюз, созданный фабрикой
+func (s *EmailSettingsService) SetGateway(gateway ports.ReconfigurableEmailGateway, factory ports.EmailGatewayFactory) {
+	s.gateway = gateway
+	s.gatewayFactory = factory
+}
+
+// SetEmailService включает применение политики обработки к EmailService
+func (s *EmailSettingsService) SetEmailService(emailService *EmailService) {
+	s.emailService = emailService
+}
+
+// Load загружает сохраненные настройки и применяет их. Отсутствующие настройки
+// заполняются значениями по умолчанию: каналом и политикой из конфигурации приложения
+// и окнами поиска из файловой конфигурации
+func (s *EmailSettingsService) Load(ctx context.Context, defaultChannel *domain.EmailChannelConfig, defaultPolicy domain.EmailProcessingPolicy) error {
+	threadSearch, err := s.repo.FindThreadSearch(ctx)
+	if errors.Is(err, domain.ErrEmailSettingsNotFound) {
+		if threadSearch, err = s.fallbackThreadSearch(ctx); err != nil {
+			return err
+		}
+		err = s.repo.SaveThreadSearch(ctx, threadSearch)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load thread search config: %w", err)
+	}
+	s.setThreadSearch(threadSearch)
+
+	policy, err := s.repo.FindPolicy(ctx)
+	if errors.Is(err, domain.ErrEmailSettingsNotFound) {
+		policy = &defaultPolicy
+		err = s.repo.SavePolicy(ctx, policy)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load email processing policy: %w", err)
+	}
+	s.applyPolicy(*policy)
+
+	channels, err := s.repo.FindChannels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load email channels: %w", err)
+	}
+	if len(channels) == 0 && defaultChannel != nil {
+		channel := *defaultChannel
+		channel.Active = true
+		channel.UpdatedAt = time.Now()
+		if err := s.repo.SaveChannel(ctx, &channel); err != nil {
+			return fmt.Errorf("failed to save default email channel: %w", err)
+		}
+		// Шлюз уже создан с параметрами из конфигурации приложения
+		s.logger.Info(ctx, "Default email channel saved", "channel_id", channel.ID)
+		return nil
+	}
+	for i := range channels {
+		if channels[i].Active {
+			return s.applyChannel(ctx, &channels[i])
+		}
+	}
+	return nil
+}
+
+// ListChannels возвращает каналы, упорядоченные по ID
+func (s *EmailSettingsService) ListChannels(ctx context.Context) ([]domain.EmailChannelConfig, error) {
+	return s.ListConfigs(ctx)
+}
+
+// GetChannel возвращает канал по ID
+func (s *EmailSettingsService) GetChannel(ctx context.Context, id string) (*domain.EmailChannelConfig, error) {
+	return s.GetConfig(ctx, id)
+}
+
+// SaveChannel создает или заменяет канал
+func (s *EmailSettingsService) SaveChannel(ctx context.Context, config domain.EmailChannelConfig) (*domain.EmailChannelConfig, error) {
+	config.ID = strings.TrimSpace(config.ID)
+	config.Name = strings.TrimSpace(config.Name)
+	if config.Provider == "" {
+		config.Provider = "imap"
+	}
+	if config.Password == "" {
+		existing, err := s.repo.FindChannel(ctx, config.ID)
+		if err == nil {
+			config.Password = existing.Password
+		} else if !errors.Is(err, domain.ErrEmailChannelNotFound) {
+			return nil, fmt.Errorf("failed to find email channel: %w", err)
+		}
+	}
+
+	if err := s.SaveConfig(ctx, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// DeleteChannel удаляет неактивный канал
+func (s *EmailSettingsService) DeleteChannel(ctx context.Context, id string) error {
+	config, err := s.repo.FindChannel(ctx, id)
+	if err != nil {
+		return err
+	}
+	if config.Active {
+		return domain.ErrEmailChannelActive
+	}
+	if err := s.repo.DeleteChannel(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Email channel deleted", "channel_id", id)
+	return nil
+}
+
+// TestChannel подключается к серверу с параметрами канала через отдельный шлюз,
+// не прерывая работу активного канала
+func (s *EmailSettingsService) TestChannel(ctx context.Context, id string) (*ports.EmailConnectionTestResult, error) {
+	config, err := s.repo.FindChannel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.gatewayFactory == nil {
+		return nil, errors.New("email gateway factory is not configured")
+	}
+
+	gateway, err := s.gatewayFactory(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email gateway: %w", err)
+	}
+	defer gateway.Disconnect()
+
+	tester := NewEmailService(gateway, nil, nil, nil, domain.EmailProcessingPolicy{}, s.logger)
+	start := time.Now()
+	err = tester.TestConnection(ctx)
+	result := &ports.EmailConnectionTestResult{
+		ChannelID: id,
+		Success:   err == nil,
+		Duration:  time.Since(start),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		s.logger.Warn(ctx, "Email channel connection test failed", "channel_id", id, "error", err.Error())
+	}
+	return result, nil
+}
+
+// GetPolicy возвращает сохраненную политику обработки
+func (s *EmailSettingsService) GetPolicy(ctx context.Context) (*domain.EmailProcessingPolicy, error) {
+	return s.repo.FindPolicy(ctx)
+}
+
+// UpdatePolicy проверяет, сохраняет и применяет политику обработки
+func (s *EmailSettingsService) UpdatePolicy(ctx context.Context, policy domain.EmailProcessingPolicy) (*domain.EmailProcessingPolicy, error) {
+	policy.NormalizeSenders()
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SavePolicy(ctx, &policy); err != nil {
+		return nil, fmt.Errorf("failed to save email processing policy: %w", err)
+	}
+	s.applyPolicy(policy)
+
+	s.logger.Info(ctx, "Email processing policy updated",
+		"read_only_mode", policy.ReadOnlyMode,
+		"allowed_senders", len(policy.AllowedSenders),
+		"blocked_senders", len(policy.BlockedSenders))
+	return &policy, nil
+}
+
+// GetThreadSearchConfig возвращает текущие окна поиска тредов
+func (s *EmailSettingsService) GetThreadSearchConfig(ctx context.Context) (*ports.ThreadSearchConfig, error) {
+	s.mu.RLock()
+	threadSearch := s.threadSearch
+	s.mu.RUnlock()
+
+	if threadSearch == nil {
+		return s.fallbackThreadSearch(ctx)
+	}
+	config := *threadSearch
+	config.SubjectPrefixes = append([]string(nil), threadSearch.SubjectPrefixes...)
+	return &config, nil
+}
+
+// UpdateThreadSearchConfig проверяет, сохраняет и применяет окна поиска тредов
+func (s *EmailSettingsService) UpdateThreadSearchConfig(ctx context.Context, config ports.ThreadSearchConfig) (*ports.ThreadSearchConfig, error) {
+	validated, err := domain.NewEmailSearchConfig(
+		config.DefaultDaysBack,
+		config.ExtendedDaysBack,
+		config.MaxDaysBack,
+		config.FetchTimeout,
+		config.IncludeSeenMessages,
+		config.SubjectPrefixes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	config.SubjectPrefixes = validated.SubjectPrefixes()
+
+	if err := s.repo.SaveThreadSearch(ctx, &config); err != nil {
+		return nil, fmt.Errorf("failed to save thread search config: %w", err)
+	}
+	s.setThreadSearch(&config)
+
+	s.logger.Info(ctx, "Thread search config updated",
+		"default_days", config.DefaultDaysBack,
+		"extended_days", config.ExtendedDaysBack,
+		"max_days", config.MaxDaysBack)
+	return s.GetThreadSearchConfig(ctx)
+}
+
+// SearchConfigProvider возвращает провайдер конфигурации поиска поверх сохраненных
+// окон: IMAP-адаптер и обработчик сообщений видят изменения без перезапуска
+func (s *EmailSettingsService) SearchConfigProvider() ports.EmailSearchConfigProvider {
+	return emailSettingsSearchConfig{settings: s}
+}
+
+// GetProviderSpecificConfig возвращает настройки провайдера из файловой конфигурации;
+// глубина поиска не превышает текущего максимального окна
+func (s *EmailSettingsService) GetProviderSpecificConfig(ctx context.Context, provider string) (*ports.ProviderSearchConfig, error) {
+	threadSearch, err := s.GetThreadSearchConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var config *ports.ProviderSearchConfig
+	if s.fallback != nil {
+		if config, err = s.fallback.GetProviderSpecificConfig(ctx, provider); err != nil {
+			return nil, err
+		}
+	} else {
+		config = &ports.ProviderSearchConfig{
+			ProviderName:  provider,
+			Optimizations: []string{"standard_search"},
+		}
+	}
+
+	if config.MaxDaysBack <= 0 || config.MaxDaysBack > threadSearch.MaxDaysBack {
+		config.MaxDaysBack = threadSearch.MaxDaysBack
+	}
+	if config.SearchTimeout <= 0 {
+		config.SearchTimeout = threadSearch.FetchTimeout
+	}
+	return config, nil
+}
+
+// GetConfig возвращает канал по ID (ports.EmailConfigProvider)
+func (s *EmailSettingsService) GetConfig(ctx context.Context, channelID string) (*domain.EmailChannelConfig, error) {
+	return s.repo.FindChannel(ctx, channelID)
+}
+
+// ListConfigs возвращает все каналы (ports.EmailConfigProvider)
+func (s *EmailSettingsService) ListConfigs(ctx context.Context) ([]domain.EmailChannelConfig, error) {
+	return s.repo.FindChannels(ctx)
+}
+
+// ValidateConfig проверяет канал перед сохранением (ports.EmailConfigProvider).
+// Пароль обязателен: без него шлюз не сможет подключиться
+func (s *EmailSettingsService) ValidateConfig(ctx context.Context, config *domain.EmailChannelConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if config.Password == "" {
+		return errors.New("password is required")
+	}
+	return nil
+}
+
+// SaveConfig проверяет и сохраняет канал; активный канал применяется к шлюзу,
+// а остальные каналы снимаются с активности (ports.EmailConfigProvider)
+func (s *EmailSettingsService) SaveConfig(ctx context.Context, config *domain.EmailChannelConfig) error {
+	if err := s.ValidateConfig(ctx, config); err != nil {
+		return err
+	}
+
+	if config.Active {
+		if err := s.applyChannel(ctx, config); err != nil {
+			return err
+		}
+	}
+
+	config.UpdatedAt = time.Now()
+	if config.Active {
+		if err := s.deactivateOtherChannels(ctx, config); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.SaveChannel(ctx, config); err != nil {
+		return fmt.Errorf("failed to save email channel: %w", err)
+	}
+
+	s.logger.Info(ctx, "Email channel saved", "channel_id", config.ID, "active", config.Active)
+	return nil
+}
+
+// deactivateOtherChannels снимает активность с остальных каналов до сохранения активного
+func (s *EmailSettingsService) deactivateOtherChannels(ctx context.Context, active *domain.EmailChannelConfig) error {
+	channels, err := s.repo.FindChannels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load email channels: %w", err)
+	}
+	for i := range channels {
+		if channels[i].ID == active.ID || !channels[i].Active {
+			continue
+		}
+		channels[i].Active = false
+		channels[i].UpdatedAt = active.UpdatedAt
+		if err := s.repo.SaveChannel(ctx, &channels[i]); err != nil {
+			return fmt.Errorf("failed to deactivate email channel %s: %w", channels[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// applyChannel переключает шлюз на канал
+func (s *EmailSettingsService) applyChannel(ctx context.Context, config *domain.EmailChannelConfig) error {
+	if s.gateway == nil {
+		return nil
+	}
+	if err := s.gateway.Reconfigure(ctx, config); err != nil {
+		return fmt.Errorf("failed to apply email channel %s: %w", config.ID, err)
+	}
+	s.logger.Info(ctx, "Email gateway switched to channel", "channel_id", config.ID)
+	return nil
+}
+
+func (s *EmailSettingsService) applyPolicy(policy domain.EmailProcessingPolicy) {
+	if s.emailService != nil {
+		s.emailService.SetPolicy(policy)
+	}
+}
+
+func (s *EmailSettingsService) setThreadSearch(config *ports.ThreadSearchConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.threadSearch = config
+}
+
+// fallbackThreadSearch окна поиска из файловой конфигурации
+func (s *EmailSettingsService) fallbackThreadSearch(ctx context.Context) (*ports.ThreadSearchConfig, error) {
+	if s.fallback == nil {
+		return nil, errors.New("thread search config is not loaded")
+	}
+	return s.fallback.GetThreadSearchConfig(ctx)
+}
+
+// emailSettingsSearchConfig реализует ports.EmailSearchConfigProvider поверх EmailSettingsService
+type emailSettingsSearchConfig struct {
+	settings *EmailSettingsService
+}
+
+func (c emailSettingsSearchConfig) GetThreadSearchConfig(ctx context.Context) (*ports.ThreadSearchConfig, error) {
+	return c.settings.GetThreadSearchConfig(ctx)
+}
+
+func (c emailSettingsSearchConfig) GetProviderSpecificConfig(ctx context.Context, provider string) (*ports.ProviderSearchConfig, error) {
+	return c.settings.GetProviderSpecificConfig(ctx, provider)
+}
+
+// ValidateConfig проверяет текущие окна поиска тредов
+func (c emailSettingsSearchConfig) ValidateConfig(ctx context.Context) error {
+	config, err := c.settings.GetThreadSearchConfig(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = domain.NewEmailSearchConfig(
+		config.DefaultDaysBack,
+		config.ExtendedDaysBack,
+		config.MaxDaysBack,
+		config.FetchTimeout,
+		config.IncludeSeenMessages,
+		config.SubjectPrefixes,
+	)
+	return err
+}
+
+var (
+	_ ports.EmailSettingsService = (*EmailSettingsService)(nil)
+	_ ports.EmailConfigProvider  = (*EmailSettingsService)(nil)
+)
@@ -0,0 +1,276 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	emailinmemory "github.com/audetv/urms/internal/infrastructure/persistence/email/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSearchConfig файловая конфигурация поиска
+type stubSearchConfig struct {
+	thread ports.ThreadSearchConfig
+}
+
+func (s stubSearchConfig) GetThreadSearchConfig(ctx context.Context) (*ports.ThreadSearchConfig, error) {
+	config := s.thread
+	return &config, nil
+}
+
+func (s stubSearchConfig) GetProviderSpecificConfig(ctx context.Context, provider string) (*ports.ProviderSearchConfig, error) {
+	return &ports.ProviderSearchConfig{ProviderName: provider, MaxDaysBack: 365, SearchTimeout: time.Minute}, nil
+}
+
+func (s stubSearchConfig) ValidateConfig(ctx context.Context) error { return nil }
+
+// recordingGateway запоминает каналы, на которые переключался шлюз
+type recordingGateway struct {
+	*MockEmailGateway
+	channels []string
+}
+
+func (g *recordingGateway) Reconfigure(ctx context.Context, config *domain.EmailChannelConfig) error {
+	g.channels = append(g.channels, config.ID)
+	return nil
+}
+
+type emailSettingsFixture struct {
+	settings     *services.EmailSettingsService
+	emailService *services.EmailService
+	gateway      *recordingGateway
+	connectErr   map[string]error
+}
+
+func newEmailSettingsFixture(t *testing.T) *emailSettingsFixture {
+	t.Helper()
+	logger := &services.MockLogger{}
+	f := &emailSettingsFixture{
+		gateway:    &recordingGateway{MockEmailGateway: new(MockEmailGateway)},
+		connectErr: map[string]error{},
+	}
+
+	f.settings = services.NewEmailSettingsService(
+		emailinmemory.NewEmailSettingsRepository(),
+		stubSearchConfig{thread: ports.ThreadSearchConfig{
+			DefaultDaysBack:  30,
+			ExtendedDaysBack: 90,
+			MaxDaysBack:      180,
+			FetchTimeout:     time.Minute,
+			SubjectPrefixes:  []string{"Re:"},
+		}},
+		logger,
+	)
+	f.settings.SetGateway(f.gateway, func(config *domain.EmailChannelConfig) (ports.EmailGateway, error) {
+		gateway := new(MockEmailGateway)
+		gateway.On("Connect", mock.Anything).Return(f.connectErr[config.ID])
+		gateway.On("ListMailboxes", mock.Anything).Return([]ports.MailboxInfo{{Name: "INBOX"}}, nil)
+		gateway.On("Disconnect").Return(nil)
+		return gateway, nil
+	})
+
+	f.emailService = services.NewEmailService(f.gateway, new(MockEmailRepository), nil, &MockIDGenerator{}, domain.EmailProcessingPolicy{}, logger)
+	f.settings.SetEmailService(f.emailService)
+
+	require.NoError(t, f.settings.Load(context.Background(), &domain.EmailChannelConfig{
+		ID:       "default",
+		Provider: "imap",
+		Server:   "imap.example.com",
+		Port:     993,
+		Username: "support@example.com",
+		Password: "secret",
+		Mailbox:  "INBOX",
+		SSL:      true,
+	}, domain.EmailProcessingPolicy{SpamFilter: true, MaxMessageSize: 1024}))
+	return f
+}
+
+func testChannel(id string) domain.EmailChannelConfig {
+	return domain.EmailChannelConfig{
+		ID:       id,
+		Server:   "imap." + id + ".example.com",
+		Port:     993,
+		Username: "support@example.com",
+		Password: "secret",
+		Mailbox:  "INBOX",
+		SSL:      true,
+	}
+}
+
+func TestEmailSettingsService_Load(t *testing.T) {
+	f := newEmailSettingsFixture(t)
+	ctx := context.Background()
+
+	channels, err := f.settings.ListChannels(ctx)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, "default", channels[0].ID)
+	assert.True(t, channels[0].Active)
+	assert.Empty(t, f.gateway.channels, "default channel is already used by the gateway")
+
+	assert.True(t, f.emailService.Policy().SpamFilter)
+	assert.Equal(t, int64(1024), f.emailService.Policy().MaxMessageSize)
+
+	threadSearch, err := f.settings.SearchConfigProvider().GetThreadSearchConfig(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 30, threadSearch.DefaultDaysBack)
+
+	t.Run("stored settings are applied on next load", func(t *testing.T) {
+		_, err := f.settings.SaveChannel(ctx, testChannel("backup"))
+		require.NoError(t, err)
+		active := testChannel("primary")
+		active.Active = true
+		_, err = f.settings.SaveChannel(ctx, active)
+		require.NoError(t, err)
+		f.gateway.channels = nil
+
+		require.NoError(t, f.settings.Load(ctx, nil, domain.EmailProcessingPolicy{}))
+		assert.Equal(t, []string{"primary"}, f.gateway.channels)
+		assert.True(t, f.emailService.Policy().SpamFilter, "stored policy wins over defaults")
+	})
+}
+
+func TestEmailSettingsService_Channels(t *testing.T) {
+	f := newEmailSettingsFixture(t)
+	ctx := context.Background()
+
+	t.Run("invalid config is rejected", func(t *testing.T) {
+		invalid := testChannel("Bad ID")
+		_, err := f.settings.SaveChannel(ctx, invalid)
+		assert.Error(t, err)
+
+		noPassword := testChannel("new")
+		noPassword.Password = ""
+		_, err = f.settings.SaveChannel(ctx, noPassword)
+		assert.ErrorContains(t, err, "password is required")
+
+		noServer := testChannel("new")
+		noServer.Server = ""
+		assert.Error(t, f.settings.ValidateConfig(ctx, &noServer))
+	})
+
+	t.Run("activating a channel switches the gateway", func(t *testing.T) {
+		channel := testChannel("sales")
+		channel.Active = true
+		saved, err := f.settings.SaveChannel(ctx, channel)
+		require.NoError(t, err)
+		assert.Equal(t, "imap", saved.Provider)
+		assert.Equal(t, []string{"sales"}, f.gateway.channels)
+
+		previous, err := f.settings.GetChannel(ctx, "default")
+		require.NoError(t, err)
+		assert.False(t, previous.Active)
+	})
+
+	t.Run("empty password keeps the stored one", func(t *testing.T) {
+		update := testChannel("sales")
+		update.Password = ""
+		update.Mailbox = "Support"
+		update.Active = true
+		saved, err := f.settings.SaveChannel(ctx, update)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", saved.Password)
+		assert.Equal(t, "Support", saved.Mailbox)
+	})
+
+	t.Run("active channel cannot be deleted", func(t *testing.T) {
+		assert.ErrorIs(t, f.settings.DeleteChannel(ctx, "sales"), domain.ErrEmailChannelActive)
+		require.NoError(t, f.settings.DeleteChannel(ctx, "default"))
+		assert.ErrorIs(t, f.settings.DeleteChannel(ctx, "default"), domain.ErrEmailChannelNotFound)
+	})
+
+	t.Run("test connection uses a separate gateway", func(t *testing.T) {
+		require.NoError(t, ignoreResult(f.settings.SaveChannel(ctx, testChannel("broken"))))
+		f.connectErr["broken"] = errors.New("authentication failed")
+
+		result, err := f.settings.TestChannel(ctx, "sales")
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+
+		result, err = f.settings.TestChannel(ctx, "broken")
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "authentication failed")
+
+		_, err = f.settings.TestChannel(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrEmailChannelNotFound)
+		f.gateway.AssertNotCalled(t, "Connect", mock.Anything)
+	})
+}
+
+func TestEmailSettingsService_PolicyAndThreadSearch(t *testing.T) {
+	f := newEmailSettingsFixture(t)
+	ctx := context.Background()
+
+	t.Run("policy is validated and applied", func(t *testing.T) {
+		_, err := f.settings.UpdatePolicy(ctx, domain.EmailProcessingPolicy{MaxMessageSize: 1024, AutoReply: true})
+		assert.ErrorContains(t, err, "auto reply text")
+
+		_, err = f.settings.UpdatePolicy(ctx, domain.EmailProcessingPolicy{
+			MaxMessageSize: 1024,
+			AllowedSenders: []domain.EmailAddress{"client@example.com"},
+			BlockedSenders: []domain.EmailAddress{"client@example.com"},
+		})
+		assert.Error(t, err)
+
+		policy, err := f.settings.UpdatePolicy(ctx, domain.EmailProcessingPolicy{
+			AutoReply:      true,
+			AutoReplyText:  "Мы получили ваше письмо",
+			MaxMessageSize: 2048,
+			BlockedSenders: []domain.EmailAddress{" spam@example.com", "spam@example.com"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EmailAddress{"spam@example.com"}, policy.BlockedSenders)
+		assert.Equal(t, int64(2048), f.emailService.Policy().MaxMessageSize)
+		assert.Equal(t, policy.BlockedSenders, f.emailService.Policy().BlockedSenders)
+	})
+
+	t.Run("thread search windows are validated and visible to the provider", func(t *testing.T) {
+		_, err := f.settings.UpdateThreadSearchConfig(ctx, ports.ThreadSearchConfig{
+			DefaultDaysBack: 60, ExtendedDaysBack: 30, MaxDaysBack: 90, FetchTimeout: time.Minute,
+		})
+		assert.Error(t, err)
+
+		config, err := f.settings.UpdateThreadSearchConfig(ctx, ports.ThreadSearchConfig{
+			DefaultDaysBack: 7, ExtendedDaysBack: 30, MaxDaysBack: 90, FetchTimeout: time.Minute,
+			SubjectPrefixes: []string{"Re", "Re:"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Re:"}, config.SubjectPrefixes)
+
+		provider := f.settings.SearchConfigProvider()
+		threadSearch, err := provider.GetThreadSearchConfig(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 7, threadSearch.DefaultDaysBack)
+		require.NoError(t, provider.ValidateConfig(ctx))
+
+		providerConfig, err := provider.GetProviderSpecificConfig(ctx, "gmail")
+		require.NoError(t, err)
+		assert.Equal(t, 90, providerConfig.MaxDaysBack, "provider depth is capped by max window")
+	})
+
+	t.Run("settings require admin permissions", func(t *testing.T) {
+		authorized := services.NewAuthorizedEmailSettingsService(f.settings, services.NewRoleAuthorizer(&services.MockLogger{}))
+
+		_, err := authorized.ListChannels(asUser(domain.UserRoleManager))
+		assert.ErrorIs(t, err, domain.ErrForbidden, "channels hold passwords")
+		_, err = authorized.GetPolicy(asUser(domain.UserRoleOperator))
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+
+		_, err = authorized.GetPolicy(asUser(domain.UserRoleManager))
+		assert.NoError(t, err)
+		_, err = authorized.ListChannels(asUser(domain.UserRoleAdmin))
+		assert.NoError(t, err)
+	})
+}
+
+func ignoreResult(_ *domain.EmailChannelConfig, err error) error {
+	return err
+}
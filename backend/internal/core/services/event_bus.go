@@ -0,0 +1,110 @@
+// internal/core/services/event_bus.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// EventBus шина доменных событий внутри процесса. События попадают в нее
+// из outbox через OutboxRelay, поэтому обработчики вызываются уже после фиксации изменения
+type EventBus struct {
+	subscribers map[domain.DomainEventType][]eventSubscriber
+	mu          sync.RWMutex
+	logger      ports.Logger
+}
+
+type eventSubscriber struct {
+	name    string
+	handler ports.EventHandler
+}
+
+func NewEventBus(logger ports.Logger) *EventBus {
+	return &EventBus{
+		subscribers: make(map[domain.DomainEventType][]eventSubscriber),
+		logger:      logger,
+	}
+}
+
+// Subscribe регистрирует обработчик события
+func (b *EventBus) Subscribe(eventType domain.DomainEventType, name string, handler ports.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[eventType] = append(b.subscribers[eventType], eventSubscriber{name: name, handler: handler})
+}
+
+// Dispatch вызывает всех подписчиков события. Ошибка одного обработчика не мешает
+// остальным; при повторной доставке событие снова получат все подписчики
+func (b *EventBus) Dispatch(ctx context.Context, event domain.DomainEvent) error {
+	b.mu.RLock()
+	subscribers := append(append([]eventSubscriber(nil), b.subscribers[event.Type]...), b.subscribers[domain.DomainEventTypeAll]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		if err := b.invoke(ctx, subscriber, event); err != nil {
+			b.logger.Warn(ctx, "event handler failed",
+				"subscriber", subscriber.name,
+				"event_id", event.ID,
+				"event_type", event.Type,
+				"error", err.Error())
+			errs = append(errs, fmt.Errorf("%s: %w", subscriber.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// invoke изолирует панику обработчика, чтобы она не остановила доставку
+func (b *EventBus) invoke(ctx context.Context, subscriber eventSubscriber, event domain.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+	return subscriber.handler(ctx, event)
+}
+
+// OutboxPublisher публикует события записью в outbox в транзакции из context
+type OutboxPublisher struct {
+	outboxRepo ports.OutboxRepository
+}
+
+func NewOutboxPublisher(outboxRepo ports.OutboxRepository) *OutboxPublisher {
+	return &OutboxPublisher{
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (p *OutboxPublisher) Publish(ctx context.Context, events ...domain.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	correlationID := ports.CorrelationIDFromContext(ctx)
+	messages := make([]domain.OutboxMessage, len(events))
+	for i, event := range events {
+		if event.CorrelationID == "" {
+			event.CorrelationID = correlationID
+		}
+		messages[i] = domain.NewOutboxMessage(event)
+	}
+
+	if err := p.outboxRepo.Append(ctx, messages...); err != nil {
+		return fmt.Errorf("failed to append events to outbox: %w", err)
+	}
+	return nil
+}
+
+// NoopUnitOfWork выполняет функцию без транзакции - для хранилищ в памяти
+type NoopUnitOfWork struct{}
+
+func (NoopUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,480 @@
+// internal/core/services/github_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// sourceMetaGitHubAuthor логин автора issue, принятого как задача
+const sourceMetaGitHubAuthor = "github_author"
+
+// GitHubConfig параметры синхронизации с GitHub
+type GitHubConfig struct {
+	Repositories    []string      // Отслеживаемые репозитории owner/repo; первый - по умолчанию для новых issue
+	InitialLookback time.Duration // За какой период забрать изменения при первой синхронизации
+}
+
+// GitHubService синхронизирует задачи с issue GitHub.
+// Изменения из GitHub выполняются от имени domain.GitHubAuthorID, а комментарии,
+// отправленные из URMS, помечаются скрытым маркером, поэтому ни одно изменение
+// не возвращается туда, откуда пришло
+type GitHubService struct {
+	gateway         ports.GitHubGateway
+	taskRepo        ports.TaskRepository
+	taskService     ports.TaskService
+	customerService ports.CustomerService
+	config          GitHubConfig
+	logger          ports.Logger
+
+	mu      sync.Mutex
+	cursors map[string]time.Time
+}
+
+func NewGitHubService(
+	gateway ports.GitHubGateway,
+	taskRepo ports.TaskRepository,
+	taskService ports.TaskService,
+	customerService ports.CustomerService,
+	config GitHubConfig,
+	logger ports.Logger,
+) *GitHubService {
+	return &GitHubService{
+		gateway:         gateway,
+		taskRepo:        taskRepo,
+		taskService:     taskService,
+		customerService: customerService,
+		config:          config,
+		logger:          logger,
+		cursors:         make(map[string]time.Time),
+	}
+}
+
+// LinkIssue создает issue для задачи и связывает их
+func (s *GitHubService) LinkIssue(ctx context.Context, taskID string, req ports.LinkGitHubIssueRequest) (*domain.Task, error) {
+	repo := req.Repo
+	if repo == "" && len(s.config.Repositories) > 0 {
+		repo = s.config.Repositories[0]
+	}
+	if !s.isWatched(repo) {
+		return nil, fmt.Errorf("%w: %q", domain.ErrGitHubRepoNotWatched, repo)
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	if _, linked := task.GitHubIssue(); linked {
+		return nil, domain.ErrGitHubIssueAlreadyLinked
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = task.Subject
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		body = task.Description
+	}
+	body += "\n\n" + domain.GitHubTaskMarker(task.ID)
+
+	issue, err := s.gateway.CreateIssue(ctx, repo, title, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github issue: %w", err)
+	}
+
+	var updated *domain.Task
+	err = ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		current, err := s.taskRepo.FindByID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if err := current.LinkGitHubIssue(issue.Ref(), issue.URL, req.ActorID); err != nil {
+			return err
+		}
+		if err := s.taskRepo.Update(ctx, current); err != nil {
+			return err
+		}
+		updated = current
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link github issue %s: %w", issue.Ref(), err)
+	}
+
+	s.logger.Info(ctx, "github issue linked to task",
+		"task_id", task.ID,
+		"issue", issue.Ref().String(),
+		"actor_id", req.ActorID)
+	return updated, nil
+}
+
+// HandleWebhookEvent применяет событие issues или issue_comment
+func (s *GitHubService) HandleWebhookEvent(ctx context.Context, event domain.GitHubWebhookEvent) error {
+	issue := event.Issue
+	if issue == nil || issue.IsPullRequest {
+		return nil
+	}
+
+	task, err := s.findIssueTask(ctx, issue)
+	if err != nil {
+		return err
+	}
+
+	if event.Comment != nil {
+		if event.Action != domain.GitHubActionCreated {
+			return nil
+		}
+		if task == nil {
+			if task, err = s.ingestIssue(ctx, issue); err != nil || task == nil {
+				return err
+			}
+		}
+		return s.applyComment(ctx, task, event.Comment)
+	}
+
+	if task == nil {
+		_, err := s.ingestIssue(ctx, issue)
+		return err
+	}
+	return s.applyIssueState(ctx, task, issue, event.Action)
+}
+
+// SyncRepositories забирает issue и комментарии, измененные с прошлой синхронизации.
+// Webhook доставляет изменения сразу, синхронизация восполняет пропущенные доставки
+func (s *GitHubService) SyncRepositories(ctx context.Context) (int, error) {
+	var errs []error
+	synced := 0
+	for _, repo := range s.config.Repositories {
+		count, err := s.syncRepository(ctx, repo)
+		synced += count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", repo, err))
+		}
+	}
+	return synced, errors.Join(errs...)
+}
+
+func (s *GitHubService) syncRepository(ctx context.Context, repo string) (int, error) {
+	s.mu.Lock()
+	since, ok := s.cursors[repo]
+	s.mu.Unlock()
+	startedAt := time.Now()
+	if !ok {
+		since = startedAt.Add(-s.config.InitialLookback)
+	}
+
+	issues, err := s.gateway.ListIssues(ctx, repo, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list issues: %w", err)
+	}
+	synced := 0
+	for i := range issues {
+		if err := s.HandleWebhookEvent(ctx, domain.GitHubWebhookEvent{Issue: &issues[i]}); err != nil {
+			return synced, fmt.Errorf("failed to sync issue %s: %w", issues[i].Ref(), err)
+		}
+		synced++
+	}
+
+	comments, err := s.gateway.ListComments(ctx, repo, since)
+	if err != nil {
+		return synced, fmt.Errorf("failed to list comments: %w", err)
+	}
+	for i := range comments {
+		comment := &comments[i]
+		task, err := s.findTask(ctx, domain.GitHubIssueRef{Repo: repo, Number: comment.IssueNumber})
+		if err != nil {
+			return synced, err
+		}
+		if task == nil {
+			continue
+		}
+		if err := s.applyComment(ctx, task, comment); err != nil {
+			return synced, fmt.Errorf("failed to sync comment %d: %w", comment.ID, err)
+		}
+		synced++
+	}
+
+	// Курсор сдвигается только после полной обработки: повтор безопасен,
+	// так как принятые issue и комментарии распознаются по SourceMeta
+	s.mu.Lock()
+	s.cursors[repo] = startedAt
+	s.mu.Unlock()
+	return synced, nil
+}
+
+// ingestIssue принимает открытый issue отслеживаемого репозитория как задачу поддержки
+func (s *GitHubService) ingestIssue(ctx context.Context, issue *domain.GitHubIssue) (*domain.Task, error) {
+	if !s.isWatched(issue.Repo) || issue.State != domain.GitHubIssueOpen || issue.LinkedTaskID() != "" {
+		return nil, nil
+	}
+
+	customer, err := s.customerService.FindOrCreateByEmail(ctx, domain.GitHubCustomerEmail(issue.AuthorLogin), issue.AuthorLogin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find or create customer: %w", err)
+	}
+
+	description := strings.TrimSpace(issue.Body)
+	if description == "" {
+		description = issue.Title
+	}
+	task, err := s.taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+		Subject:     issue.Title,
+		Description: description,
+		CustomerID:  customer.ID,
+		ReporterID:  customer.ID,
+		Source:      domain.SourceGitHub,
+		SourceMeta: map[string]interface{}{
+			domain.SourceMetaGitHubIssue:    issue.Ref().String(),
+			domain.SourceMetaGitHubIssueURL: issue.URL,
+			sourceMetaGitHubAuthor:          issue.AuthorLogin,
+		},
+		Priority: domain.PriorityMedium,
+		Tags:     []string{"github"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	s.logger.Info(ctx, "task created from github issue",
+		"issue", issue.Ref().String(),
+		"task_id", task.ID,
+		"customer_id", customer.ID)
+	return task, nil
+}
+
+// applyIssueState переносит закрытие и повторное открытие issue на задачу.
+// Открытое состояние без действия reopened (при синхронизации) не переоткрывает задачу:
+// это может быть еще не отправленное в GitHub закрытие задачи оператором
+func (s *GitHubService) applyIssueState(ctx context.Context, task *domain.Task, issue *domain.GitHubIssue, action string) error {
+	var status domain.TaskStatus
+	switch {
+	case issue.State == domain.GitHubIssueClosed && !domain.IsGitHubResolvedStatus(task.Status):
+		status = domain.TaskStatusResolved
+	case action == domain.GitHubActionReopened &&
+		(task.Status == domain.TaskStatusResolved || task.Status == domain.TaskStatusClosed):
+		status = domain.TaskStatusOpen
+	default:
+		return nil
+	}
+
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		_, err := s.taskService.ChangeStatus(ctx, task.ID, status, domain.GitHubAuthorID)
+		return err
+	})
+	if err != nil {
+		// Например, незакрытые блокирующие задачи: оператор решит вручную
+		s.logger.Warn(ctx, "failed to apply github issue state to task",
+			"issue", issue.Ref().String(), "task_id", task.ID, "status", status, "error", err.Error())
+		return nil
+	}
+
+	s.logger.Info(ctx, "task status synced from github",
+		"issue", issue.Ref().String(), "task_id", task.ID, "status", status)
+	return nil
+}
+
+// applyComment добавляет комментарий issue сообщением задачи
+func (s *GitHubService) applyComment(ctx context.Context, task *domain.Task, comment *domain.GitHubComment) error {
+	commentID := comment.ExternalID()
+	if task.HasGitHubComment(commentID) || comment.LinkedMessageID() != "" || strings.TrimSpace(comment.Body) == "" {
+		return nil
+	}
+
+	authorID := domain.GitHubAuthorID
+	content := fmt.Sprintf("@%s (GitHub):\n%s", comment.AuthorLogin, comment.Body)
+	if author, _ := task.SourceMeta[sourceMetaGitHubAuthor].(string); author != "" &&
+		task.CustomerID != nil && strings.EqualFold(author, comment.AuthorLogin) {
+		authorID = *task.CustomerID
+		content = comment.Body
+	}
+
+	var messageID string
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		updated, err := s.taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+			AuthorID:  authorID,
+			Content:   content,
+			IsPrivate: task.GitHubMirrorMessageType() == domain.MessageTypeInternal,
+		})
+		if err != nil {
+			return err
+		}
+		messageID = updated.Messages[len(updated.Messages)-1].ID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add github comment to task: %w", err)
+	}
+
+	if err := s.recordComment(ctx, task.ID, commentID, messageID); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "github comment added to task",
+		"task_id", task.ID, "comment_id", commentID, "message_id", messageID)
+	return nil
+}
+
+// HandleTaskEvent отправляет в GitHub сообщения и изменения статуса связанных задач
+func (s *GitHubService) HandleTaskEvent(ctx context.Context, event domain.DomainEvent) error {
+	if event.ActorID == domain.GitHubAuthorID {
+		return nil
+	}
+	switch event.Type {
+	case domain.DomainEventTaskMessageAdded:
+		return s.mirrorMessage(ctx, event)
+	case domain.DomainEventTaskStatusChanged:
+		return s.mirrorStatus(ctx, event)
+	}
+	return nil
+}
+
+func (s *GitHubService) mirrorMessage(ctx context.Context, event domain.DomainEvent) error {
+	task, err := s.taskRepo.FindByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to find task: %w", err)
+	}
+	ref, linked := task.GitHubIssue()
+	if !linked {
+		return nil
+	}
+
+	var message *domain.Message
+	for i := range task.Messages {
+		if task.Messages[i].ID == event.Data["message_id"] {
+			message = &task.Messages[i]
+			break
+		}
+	}
+	if message == nil || message.Type != task.GitHubMirrorMessageType() || task.HasGitHubMessage(message.ID) {
+		return nil
+	}
+	// Сообщения автора issue пришли из GitHub
+	if task.Source == domain.SourceGitHub && task.CustomerID != nil && message.AuthorID == *task.CustomerID {
+		return nil
+	}
+
+	comment, err := s.gateway.CreateComment(ctx, ref.Repo, ref.Number,
+		message.Content+"\n\n"+domain.GitHubMessageMarker(message.ID))
+	if err != nil {
+		return fmt.Errorf("failed to create github comment: %w", err)
+	}
+	if err := s.recordComment(ctx, task.ID, comment.ExternalID(), message.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "task message sent to github",
+		"task_id", task.ID, "issue", ref.String(), "message_id", message.ID, "comment_id", comment.ID)
+	return nil
+}
+
+func (s *GitHubService) mirrorStatus(ctx context.Context, event domain.DomainEvent) error {
+	wasResolved := domain.IsGitHubResolvedStatus(domain.TaskStatus(event.Data["old_status"]))
+	isResolved := domain.IsGitHubResolvedStatus(domain.TaskStatus(event.Data["status"]))
+	if wasResolved == isResolved {
+		return nil
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to find task: %w", err)
+	}
+	ref, linked := task.GitHubIssue()
+	if !linked {
+		return nil
+	}
+
+	state := domain.GitHubIssueOpen
+	if isResolved {
+		state = domain.GitHubIssueClosed
+	}
+	if err := s.gateway.SetIssueState(ctx, ref.Repo, ref.Number, state); err != nil {
+		return fmt.Errorf("failed to set github issue state: %w", err)
+	}
+
+	s.logger.Info(ctx, "github issue state synced from task",
+		"task_id", task.ID, "issue", ref.String(), "state", state)
+	return nil
+}
+
+func (s *GitHubService) recordComment(ctx context.Context, taskID, commentID, messageID string) error {
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		current, err := s.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		current.RecordGitHubComment(commentID, messageID)
+		return s.taskRepo.Update(ctx, current)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record github comment: %w", err)
+	}
+	return nil
+}
+
+// findIssueTask находит задачу issue. Issue, созданный из URMS, может прийти
+// раньше, чем связь сохранена в задаче: такой issue связывается по маркеру
+func (s *GitHubService) findIssueTask(ctx context.Context, issue *domain.GitHubIssue) (*domain.Task, error) {
+	task, err := s.findTask(ctx, issue.Ref())
+	if err != nil || task != nil {
+		return task, err
+	}
+
+	taskID := issue.LinkedTaskID()
+	if taskID == "" {
+		return nil, nil
+	}
+	var linked *domain.Task
+	err = ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		current, err := s.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if ref, ok := current.GitHubIssue(); ok {
+			if ref != issue.Ref() {
+				return nil
+			}
+			linked = current
+			return nil
+		}
+		if err := current.LinkGitHubIssue(issue.Ref(), issue.URL, domain.GitHubAuthorID); err != nil {
+			return err
+		}
+		if err := s.taskRepo.Update(ctx, current); err != nil {
+			return err
+		}
+		linked = current
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn(ctx, "failed to link github issue by marker",
+			"issue", issue.Ref().String(), "task_id", taskID, "error", err.Error())
+		return nil, nil
+	}
+	return linked, nil
+}
+
+func (s *GitHubService) findTask(ctx context.Context, ref domain.GitHubIssueRef) (*domain.Task, error) {
+	tasks, err := s.taskService.FindBySourceMeta(ctx, domain.GitHubIssueCriteria(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks by source meta: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	return &tasks[0], nil
+}
+
+func (s *GitHubService) isWatched(repo string) bool {
+	return slices.ContainsFunc(s.config.Repositories, func(watched string) bool {
+		return strings.EqualFold(watched, repo)
+	})
+}
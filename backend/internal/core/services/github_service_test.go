@@ -0,0 +1,306 @@
+// internal/core/services/github_service_test.go
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/github"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGitHubToken = "ghp_test"
+	testGitHubRepo  = "acme/app"
+)
+
+// gitHubAPIStub заглушка REST API GitHub для одного репозитория:
+// хранит issue и комментарии, запоминает изменения состояния
+type gitHubAPIStub struct {
+	mu            sync.Mutex
+	issues        map[int]map[string]interface{}
+	comments      []map[string]interface{}
+	nextNumber    int
+	nextCommentID int64
+	stateChanges  []string
+}
+
+func newGitHubAPIStub() *gitHubAPIStub {
+	return &gitHubAPIStub{issues: make(map[int]map[string]interface{}), nextNumber: 1, nextCommentID: 100}
+}
+
+func (s *gitHubAPIStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Header.Get("Authorization") != "Bearer "+testGitHubToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Bad credentials"}`)
+		return
+	}
+	path, ok := strings.CutPrefix(req.URL.Path, "/repos/"+testGitHubRepo+"/issues")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+		return
+	}
+
+	var params map[string]string
+	if req.Body != nil {
+		json.NewDecoder(req.Body).Decode(&params)
+	}
+
+	switch {
+	case req.Method == http.MethodGet && path == "":
+		list := []map[string]interface{}{}
+		for number := 1; number < s.nextNumber; number++ {
+			list = append(list, s.issues[number])
+		}
+		json.NewEncoder(w).Encode(list)
+	case req.Method == http.MethodGet && path == "/comments":
+		json.NewEncoder(w).Encode(append([]map[string]interface{}{}, s.comments...))
+	case req.Method == http.MethodPost && path == "":
+		issue := s.addIssue(params["title"], params["body"], "urms-bot", false)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(issue)
+	case req.Method == http.MethodPost && strings.HasSuffix(path, "/comments"):
+		number, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/comments"))
+		comment := s.addComment(number, params["body"], "urms-bot")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(comment)
+	case req.Method == http.MethodPatch:
+		number, _ := strconv.Atoi(strings.TrimPrefix(path, "/"))
+		s.issues[number]["state"] = params["state"]
+		s.stateChanges = append(s.stateChanges, fmt.Sprintf("#%d:%s", number, params["state"]))
+		json.NewEncoder(w).Encode(s.issues[number])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	}
+}
+
+func (s *gitHubAPIStub) addIssue(title, body, login string, pullRequest bool) map[string]interface{} {
+	number := s.nextNumber
+	s.nextNumber++
+	issue := map[string]interface{}{
+		"number":     number,
+		"title":      title,
+		"body":       body,
+		"state":      domain.GitHubIssueOpen,
+		"html_url":   fmt.Sprintf("https://github.com/%s/issues/%d", testGitHubRepo, number),
+		"user":       map[string]string{"login": login},
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	if pullRequest {
+		issue["pull_request"] = map[string]string{"url": "https://api.github.com/pulls/1"}
+	}
+	s.issues[number] = issue
+	return issue
+}
+
+func (s *gitHubAPIStub) addComment(number int, body, login string) map[string]interface{} {
+	s.nextCommentID++
+	comment := map[string]interface{}{
+		"id":         s.nextCommentID,
+		"body":       body,
+		"html_url":   fmt.Sprintf("https://github.com/%s/issues/%d#issuecomment-%d", testGitHubRepo, number, s.nextCommentID),
+		"issue_url":  fmt.Sprintf("https://api.github.com/repos/%s/issues/%d", testGitHubRepo, number),
+		"user":       map[string]string{"login": login},
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	s.comments = append(s.comments, comment)
+	return comment
+}
+
+func (s *gitHubAPIStub) issue(number int) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issues[number]
+}
+
+func (s *gitHubAPIStub) commentBodies() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var bodies []string
+	for _, comment := range s.comments {
+		bodies = append(bodies, comment["body"].(string))
+	}
+	return bodies
+}
+
+func newTestGitHubService(t *testing.T) (*services.GitHubService, *gitHubAPIStub, ports.TaskService, ports.CustomerRepository) {
+	stub := newGitHubAPIStub()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	taskService := services.NewTaskService(taskRepo, customerRepo, inmemory.NewUserRepository(logger), logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+
+	service := services.NewGitHubService(
+		github.NewClient(server.URL, testGitHubToken, 5*time.Second),
+		taskRepo,
+		taskService,
+		customerService,
+		services.GitHubConfig{Repositories: []string{testGitHubRepo}, InitialLookback: time.Hour},
+		logger,
+	)
+	return service, stub, taskService, customerRepo
+}
+
+func TestGitHubService_IssueIngestion(t *testing.T) {
+	ctx := context.Background()
+	service, stub, taskService, customerRepo := newTestGitHubService(t)
+
+	stub.addIssue("Падает экспорт", "Ошибка 500 при экспорте в CSV", "alice", false)
+	stub.addIssue("Исправить экспорт", "", "bob", true)
+	stub.addComment(1, "Воспроизводится на версии 2.3", "alice")
+	stub.addComment(1, "Подтверждаю", "bob")
+
+	synced, err := service.SyncRepositories(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, synced)
+
+	customer, err := customerRepo.FindByEmail(ctx, domain.GitHubCustomerEmail("alice"))
+	require.NoError(t, err)
+	require.NotNil(t, customer)
+
+	tasks, err := taskService.GetCustomerTasks(ctx, customer.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1, "pull requests are not ingested")
+	task := &tasks[0]
+	assert.Equal(t, domain.SourceGitHub, task.Source)
+	assert.Equal(t, "Падает экспорт", task.Subject)
+	assert.Equal(t, testGitHubRepo+"#1", task.SourceMeta[domain.SourceMetaGitHubIssue])
+
+	require.Len(t, task.Messages, 2)
+	assert.Equal(t, customer.ID, task.Messages[0].AuthorID)
+	assert.Equal(t, "Воспроизводится на версии 2.3", task.Messages[0].Content)
+	assert.Equal(t, domain.GitHubAuthorID, task.Messages[1].AuthorID)
+	assert.Equal(t, "@bob (GitHub):\nПодтверждаю", task.Messages[1].Content)
+	assert.Equal(t, domain.MessageTypeCustomer, task.Messages[1].Type)
+
+	// Повторная синхронизация не дублирует задачу и переписку
+	_, err = service.SyncRepositories(ctx)
+	require.NoError(t, err)
+	task = mustGetTask(t, taskService, task.ID)
+	assert.Len(t, task.Messages, 2)
+
+	// Ответ оператора уходит комментарием, сообщения из GitHub обратно не отправляются
+	require.NoError(t, service.HandleTaskEvent(ctx, lastTaskEvents(task)[0]))
+	task, err = taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{AuthorID: "operator-1", Content: "Исправим в 2.4"})
+	require.NoError(t, err)
+	replyEvent := lastTaskEvents(task)[0]
+	require.NoError(t, service.HandleTaskEvent(ctx, replyEvent))
+	require.NoError(t, service.HandleTaskEvent(ctx, replyEvent))
+
+	bodies := stub.commentBodies()
+	require.Len(t, bodies, 3)
+	assert.True(t, strings.HasPrefix(bodies[2], "Исправим в 2.4"))
+	assert.Contains(t, bodies[2], domain.GitHubMessageMarker(task.Messages[2].ID))
+
+	_, err = service.SyncRepositories(ctx)
+	require.NoError(t, err)
+	assert.Len(t, mustGetTask(t, taskService, task.ID).Messages, 3, "own comments are not imported back")
+
+	// Закрытие issue решает задачу и не отправляет изменение статуса обратно
+	closed := stub.issue(1)
+	closed["state"] = domain.GitHubIssueClosed
+	err = service.HandleWebhookEvent(ctx, domain.GitHubWebhookEvent{
+		Action: domain.GitHubActionClosed,
+		Issue:  &domain.GitHubIssue{Repo: testGitHubRepo, Number: 1, State: domain.GitHubIssueClosed, AuthorLogin: "alice"},
+	})
+	require.NoError(t, err)
+	task = mustGetTask(t, taskService, task.ID)
+	assert.Equal(t, domain.TaskStatusResolved, task.Status)
+	require.NoError(t, service.HandleTaskEvent(ctx, lastTaskEvents(task)[0]))
+	assert.Empty(t, stub.stateChanges)
+
+	// Переоткрытие задачи оператором переоткрывает issue
+	task, err = taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusOpen, "operator-1")
+	require.NoError(t, err)
+	require.NoError(t, service.HandleTaskEvent(ctx, lastTaskEvents(task)[0]))
+	assert.Equal(t, []string{"#1:open"}, stub.stateChanges)
+}
+
+func TestGitHubService_LinkIssue(t *testing.T) {
+	ctx := context.Background()
+	service, stub, taskService, customerRepo := newTestGitHubService(t)
+
+	customer := &domain.Customer{ID: "customer-1", Name: "Клиент", Email: "client@example.com"}
+	require.NoError(t, customerRepo.Save(ctx, customer))
+	task, err := taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+		Subject:     "Не работает экспорт",
+		Description: "Клиент не может выгрузить отчет",
+		CustomerID:  customer.ID,
+		ReporterID:  customer.ID,
+		Source:      domain.SourceEmail,
+		Priority:    domain.PriorityHigh,
+	})
+	require.NoError(t, err)
+
+	_, err = service.LinkIssue(ctx, task.ID, ports.LinkGitHubIssueRequest{Repo: "other/repo", ActorID: "operator-1"})
+	assert.ErrorIs(t, err, domain.ErrGitHubRepoNotWatched)
+
+	task, err = service.LinkIssue(ctx, task.ID, ports.LinkGitHubIssueRequest{ActorID: "operator-1"})
+	require.NoError(t, err)
+	assert.Equal(t, testGitHubRepo+"#1", task.SourceMeta[domain.SourceMetaGitHubIssue])
+	issue := stub.issue(1)
+	assert.Equal(t, "Не работает экспорт", issue["title"])
+	assert.Contains(t, issue["body"], domain.GitHubTaskMarker(task.ID))
+
+	_, err = service.LinkIssue(ctx, task.ID, ports.LinkGitHubIssueRequest{ActorID: "operator-1"})
+	assert.ErrorIs(t, err, domain.ErrGitHubIssueAlreadyLinked)
+
+	// Созданный из URMS issue не принимается как новая задача
+	_, err = service.SyncRepositories(ctx)
+	require.NoError(t, err)
+	tasks, err := taskService.GetCustomerTasks(ctx, customer.ID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	// С issue синхронизируется только внутреннее обсуждение
+	task, err = taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{AuthorID: "operator-1", Content: "Передали разработчикам"})
+	require.NoError(t, err)
+	require.NoError(t, service.HandleTaskEvent(ctx, lastTaskEvents(task)[0]))
+	assert.Empty(t, stub.commentBodies(), "customer correspondence stays out of the issue")
+
+	task, err = taskService.AddInternalNote(ctx, task.ID, "operator-1", "Воспроизводится на больших отчетах")
+	require.NoError(t, err)
+	require.NoError(t, service.HandleTaskEvent(ctx, lastTaskEvents(task)[0]))
+	require.Len(t, stub.commentBodies(), 1)
+
+	err = service.HandleWebhookEvent(ctx, domain.GitHubWebhookEvent{
+		Action:  domain.GitHubActionCreated,
+		Issue:   &domain.GitHubIssue{Repo: testGitHubRepo, Number: 1, State: domain.GitHubIssueOpen, AuthorLogin: "urms-bot"},
+		Comment: &domain.GitHubComment{ID: 9001, Repo: testGitHubRepo, IssueNumber: 1, Body: "Исправлено в main", AuthorLogin: "dev"},
+	})
+	require.NoError(t, err)
+	task = mustGetTask(t, taskService, task.ID)
+	last := task.Messages[len(task.Messages)-1]
+	assert.Equal(t, "@dev (GitHub):\nИсправлено в main", last.Content)
+	assert.Equal(t, domain.MessageTypeInternal, last.Type)
+}
+
+func mustGetTask(t *testing.T, taskService ports.TaskService, id string) *domain.Task {
+	t.Helper()
+	task, err := taskService.GetTask(context.Background(), id)
+	require.NoError(t, err)
+	return task
+}
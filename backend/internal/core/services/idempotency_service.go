@@ -0,0 +1,157 @@
+// internal/core/services/idempotency_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// IdempotencyConfig параметры хранения ответов на запросы с ключом идемпотентности
+type IdempotencyConfig struct {
+	TTL         time.Duration // Сколько хранить ответ для повторов
+	LockTimeout time.Duration // Через сколько освобождается ключ, блокировку которого перестали продлевать (KeepAlive)
+}
+
+// DefaultIdempotencyConfig возвращает конфигурацию по умолчанию
+func DefaultIdempotencyConfig() IdempotencyConfig {
+	return IdempotencyConfig{
+		TTL:         24 * time.Hour,
+		LockTimeout: time.Minute,
+	}
+}
+
+// IdempotencyService реализует ports.IdempotencyService.
+// Первый запрос занимает ключ до получения ответа, поэтому конкурентный дубль
+// не выполняется повторно, а получает domain.ErrIdempotencyRequestInProgress
+type IdempotencyService struct {
+	store  ports.IdempotencyStore
+	config IdempotencyConfig
+	logger ports.Logger
+}
+
+func NewIdempotencyService(store ports.IdempotencyStore, config IdempotencyConfig, logger ports.Logger) *IdempotencyService {
+	defaults := DefaultIdempotencyConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = defaults.LockTimeout
+	}
+
+	return &IdempotencyService{
+		store:  store,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Begin занимает ключ или возвращает сохраненный ответ
+func (s *IdempotencyService) Begin(ctx context.Context, key, requestHash string) (*domain.IdempotencyRecord, error) {
+	now := time.Now()
+	record := &domain.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.config.LockTimeout),
+	}
+
+	existing, reserved, err := s.store.Reserve(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	// Несовпадение тела проверяется первым: клиент ошибся независимо от состояния первого запроса
+	if existing.RequestHash != requestHash {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, domain.ErrIdempotencyRequestInProgress
+	}
+	return existing, nil
+}
+
+// KeepAlive продлевает блокировку ключа на LockTimeout каждую треть LockTimeout.
+// Продление не зависит от отмены ctx: обработчик может продолжать работу после отключения клиента
+func (s *IdempotencyService) KeepAlive(ctx context.Context, key string) (stop func()) {
+	return keepLockAlive(ctx, s.config.LockTimeout, s.logger, func(ctx context.Context, expiresAt time.Time) error {
+		return s.store.Extend(ctx, key, expiresAt)
+	})
+}
+
+// keepLockAlive продлевает блокировку на lockTimeout каждую треть lockTimeout, пока не вызван stop.
+// Продление не зависит от отмены ctx
+func keepLockAlive(ctx context.Context, lockTimeout time.Duration, logger ports.Logger, extend func(ctx context.Context, expiresAt time.Time) error) (stop func()) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(lockTimeout / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := extend(ctx, time.Now().Add(lockTimeout)); err != nil && ctx.Err() == nil {
+					// Следующая попытка через треть срока; до истечения блокировки их еще две
+					logger.Warn(ctx, "failed to extend lock", "error", err.Error())
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Complete сохраняет ответ на срок TTL
+func (s *IdempotencyService) Complete(ctx context.Context, key string, statusCode int, headers map[string]string, body []byte) error {
+	if statusCode <= 0 {
+		return fmt.Errorf("invalid response status code %d", statusCode)
+	}
+
+	now := time.Now()
+	record := &domain.IdempotencyRecord{
+		Key:             key,
+		StatusCode:      statusCode,
+		ResponseBody:    append([]byte(nil), body...),
+		ResponseHeaders: maps.Clone(headers),
+		ExpiresAt:       now.Add(s.config.TTL),
+	}
+	if err := s.store.Complete(ctx, record); err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Abort освобождает ключ незавершенного запроса
+func (s *IdempotencyService) Abort(ctx context.Context, key string) error {
+	if err := s.store.Release(ctx, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Cleanup удаляет записи с истекшим сроком хранения
+func (s *IdempotencyService) Cleanup(ctx context.Context) (int, error) {
+	deleted, err := s.store.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency records: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.Info(ctx, "expired idempotency records deleted", "count", deleted)
+	}
+	return deleted, nil
+}
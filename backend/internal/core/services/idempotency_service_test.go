@@ -0,0 +1,126 @@
+// internal/core/services/idempotency_service_test.go
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/services"
+	idempotencyinmemory "github.com/audetv/urms/internal/infrastructure/persistence/idempotency/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotencyService(config services.IdempotencyConfig) *services.IdempotencyService {
+	logger := &services.MockLogger{}
+	return services.NewIdempotencyService(idempotencyinmemory.NewIdempotencyStore(logger), config, logger)
+}
+
+func TestIdempotencyService_ReplaysCompletedResponse(t *testing.T) {
+	ctx := context.Background()
+	service := newIdempotencyService(services.DefaultIdempotencyConfig())
+
+	record, err := service.Begin(ctx, "key-1", "hash-a")
+	require.NoError(t, err)
+	assert.Nil(t, record, "first request is executed")
+
+	body := []byte(`{"success":true}`)
+	headers := map[string]string{"Location": "/api/v1/tasks/task-1"}
+	require.NoError(t, service.Complete(ctx, "key-1", http.StatusCreated, headers, body))
+
+	record, err = service.Begin(ctx, "key-1", "hash-a")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, http.StatusCreated, record.StatusCode)
+	assert.Equal(t, body, record.ResponseBody)
+	assert.Equal(t, headers, record.ResponseHeaders)
+
+	_, err = service.Begin(ctx, "key-1", "hash-b")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+}
+
+func TestIdempotencyService_InProgressAndAbort(t *testing.T) {
+	ctx := context.Background()
+	service := newIdempotencyService(services.DefaultIdempotencyConfig())
+
+	_, err := service.Begin(ctx, "key-1", "hash-a")
+	require.NoError(t, err)
+
+	_, err = service.Begin(ctx, "key-1", "hash-a")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyRequestInProgress)
+	_, err = service.Begin(ctx, "key-1", "hash-b")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused, "payload mismatch is reported even while in progress")
+
+	// После ошибки сервера ключ освобождается, и повтор выполняется заново
+	require.NoError(t, service.Abort(ctx, "key-1"))
+	record, err := service.Begin(ctx, "key-1", "hash-b")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestIdempotencyService_ConcurrentDuplicates(t *testing.T) {
+	ctx := context.Background()
+	service := newIdempotencyService(services.DefaultIdempotencyConfig())
+
+	const requests = 20
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		executed int
+		blocked  int
+	)
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record, err := service.Begin(ctx, "key-1", "hash-a")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil && record == nil:
+				executed++
+			case assert.ErrorIs(t, err, domain.ErrIdempotencyRequestInProgress):
+				blocked++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, executed)
+	assert.Equal(t, requests-1, blocked)
+}
+
+func TestIdempotencyService_Expiration(t *testing.T) {
+	ctx := context.Background()
+	service := newIdempotencyService(services.IdempotencyConfig{
+		TTL:         20 * time.Millisecond,
+		LockTimeout: 20 * time.Millisecond,
+	})
+
+	// Незавершенный запрос не блокирует ключ дольше LockTimeout
+	_, err := service.Begin(ctx, "stale", "hash-a")
+	require.NoError(t, err)
+
+	_, err = service.Begin(ctx, "done", "hash-a")
+	require.NoError(t, err)
+	require.NoError(t, service.Complete(ctx, "done", http.StatusOK, nil, []byte(`{}`)))
+
+	time.Sleep(30 * time.Millisecond)
+
+	record, err := service.Begin(ctx, "stale", "hash-b")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+	require.NoError(t, service.Abort(ctx, "stale"))
+
+	deleted, err := service.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted, "expired response is deleted")
+
+	record, err = service.Begin(ctx, "done", "hash-b")
+	require.NoError(t, err)
+	assert.Nil(t, record, "expired key can be reused with another payload")
+}
@@ -0,0 +1,284 @@
+// internal/core/services/inbound_channel_service.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// InboundChannelService принимает записи внешних систем через настраиваемые каналы.
+// Поля задачи извлекаются из payload выражениями JSONPath канала, повторы
+// распознаются по уникальному ключу записи и внешнему ID, сохраненному в SourceMeta задачи
+type InboundChannelService struct {
+	channelRepo     ports.InboundChannelRepository
+	taskRepo        ports.TaskRepository
+	taskService     ports.TaskService
+	customerService ports.CustomerService
+	deliveries      ports.InboundDeliveryService
+	logger          ports.Logger
+}
+
+func NewInboundChannelService(
+	channelRepo ports.InboundChannelRepository,
+	taskRepo ports.TaskRepository,
+	taskService ports.TaskService,
+	customerService ports.CustomerService,
+	logger ports.Logger,
+) *InboundChannelService {
+	return &InboundChannelService{
+		channelRepo:     channelRepo,
+		taskRepo:        taskRepo,
+		taskService:     taskService,
+		customerService: customerService,
+		logger:          logger,
+	}
+}
+
+// SetDeliveryService включает защиту от конкурентной повторной доставки:
+// уникальный ключ записи занимается на время ее обработки
+func (s *InboundChannelService) SetDeliveryService(deliveries ports.InboundDeliveryService) {
+	s.deliveries = deliveries
+}
+
+func (s *InboundChannelService) CreateChannel(ctx context.Context, req ports.CreateInboundChannelRequest) (*domain.InboundChannel, error) {
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	channel, err := domain.NewInboundChannel(req.Key, req.Name, req.Source, secret, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	channel.PriorityMap = req.PriorityMap
+	channel.DefaultTags = req.DefaultTags
+	if req.DefaultPriority != "" {
+		channel.DefaultPriority = req.DefaultPriority
+	}
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.channelRepo.Save(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "inbound channel created", "channel", channel.Key, "source", channel.Source)
+	return channel, nil
+}
+
+func (s *InboundChannelService) UpdateChannel(ctx context.Context, key string, req ports.UpdateInboundChannelRequest) (*domain.InboundChannel, error) {
+	channel, err := s.channelRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		channel.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Secret != nil {
+		channel.Secret = *req.Secret
+	}
+	if req.Mapping != nil {
+		channel.Mapping = *req.Mapping
+	}
+	if req.PriorityMap != nil {
+		channel.PriorityMap = *req.PriorityMap
+	}
+	if req.DefaultPriority != nil {
+		channel.DefaultPriority = *req.DefaultPriority
+	}
+	if req.DefaultTags != nil {
+		channel.DefaultTags = *req.DefaultTags
+	}
+	if req.Active != nil {
+		channel.Active = *req.Active
+	}
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+	channel.UpdatedAt = time.Now()
+
+	if err := s.channelRepo.Update(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to update inbound channel: %w", err)
+	}
+
+	s.logger.Info(ctx, "inbound channel updated", "channel", channel.Key, "active", channel.Active)
+	return channel, nil
+}
+
+func (s *InboundChannelService) GetChannel(ctx context.Context, key string) (*domain.InboundChannel, error) {
+	return s.channelRepo.FindByKey(ctx, key)
+}
+
+func (s *InboundChannelService) ListChannels(ctx context.Context) ([]domain.InboundChannel, error) {
+	return s.channelRepo.FindAll(ctx)
+}
+
+func (s *InboundChannelService) DeleteChannel(ctx context.Context, key string) error {
+	if err := s.channelRepo.Delete(ctx, key); err != nil {
+		return err
+	}
+	s.logger.Info(ctx, "inbound channel deleted", "channel", key)
+	return nil
+}
+
+// Receive принимает запись внешней системы
+func (s *InboundChannelService) Receive(ctx context.Context, key string, body []byte, signature string) (*ports.InboundResult, error) {
+	channel, err := s.channelRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !channel.Active {
+		return nil, domain.ErrInboundChannelDisabled
+	}
+	// Канал без секрета (созданный до обязательной подписи) записи не принимает
+	if channel.Secret == "" || !domain.VerifyWebhookSignature(channel.Secret, body, signature) {
+		return nil, domain.ErrInboundSignatureInvalid
+	}
+	// Подпись подтверждает отправителя: задача создается от имени системы
+	ctx = ports.WithSystemPrincipal(ctx)
+
+	// UseNumber сохраняет длинные числовые ID без потери точности
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInboundPayloadInvalid, err)
+	}
+
+	item, err := channel.Extract(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return acceptInbound(ctx, s.deliveries, s.taskRepo, s.logger, channel.Key, item.ExternalID, func() (*ports.InboundResult, error) {
+		return s.receive(ctx, channel, item)
+	})
+}
+
+// receive создает задачу по внешней записи или дополняет задачу ее обсуждения
+func (s *InboundChannelService) receive(ctx context.Context, channel *domain.InboundChannel, item *domain.InboundItem) (*ports.InboundResult, error) {
+	// Повторная отправка уже принятой записи ничего не меняет
+	existing, err := s.findTask(ctx, domain.InboundExternalIDCriteria(channel.Key, item.ExternalID))
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		s.logger.Info(ctx, "inbound record already accepted",
+			"channel", channel.Key, "external_id", item.ExternalID, "task_id", existing.ID)
+		return &ports.InboundResult{Action: ports.InboundActionDuplicate, Task: existing}, nil
+	}
+
+	customerName := item.CustomerName
+	if customerName == "" {
+		customerName = item.CustomerEmail
+	}
+	customer, err := s.customerService.FindOrCreateByEmail(ctx, item.CustomerEmail, customerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find or create customer: %w", err)
+	}
+
+	if item.ExternalThreadID != "" {
+		threadTask, err := s.findTask(ctx, domain.InboundThreadCriteria(channel.Key, item.ExternalThreadID))
+		if err != nil {
+			return nil, err
+		}
+		if threadTask != nil {
+			return s.appendToTask(ctx, channel, threadTask, item, customer.ID)
+		}
+	}
+
+	description := item.Description
+	if description == "" {
+		description = item.Subject
+	}
+	task, err := s.taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+		Subject:     item.Subject,
+		Description: description,
+		CustomerID:  customer.ID,
+		ReporterID:  customer.ID,
+		Source:      channel.Source,
+		SourceMeta:  channel.SourceMeta(item),
+		Priority:    item.Priority,
+		Tags:        item.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	s.logger.Info(ctx, "task created from inbound channel",
+		"channel", channel.Key,
+		"external_id", item.ExternalID,
+		"external_thread_id", item.ExternalThreadID,
+		"task_id", task.ID,
+		"customer_id", customer.ID)
+	return &ports.InboundResult{Action: ports.InboundActionCreated, Task: task}, nil
+}
+
+// appendToTask добавляет запись сообщением клиента. Сообщение и внешний ID записи
+// сохраняются одним изменением задачи, поэтому повтор не продублирует сообщение
+func (s *InboundChannelService) appendToTask(ctx context.Context, channel *domain.InboundChannel, task *domain.Task, item *domain.InboundItem, customerID string) (*ports.InboundResult, error) {
+	content := item.Description
+	if content == "" {
+		content = item.Subject
+	}
+
+	var updated *domain.Task
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		var err error
+		updated, err = s.taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+			AuthorID:   customerID,
+			Content:    content,
+			Type:       domain.MessageTypeCustomer,
+			ExternalID: item.ExternalID,
+		})
+		return err
+	})
+	if errors.Is(err, domain.ErrInboundDuplicate) {
+		s.logger.Info(ctx, "inbound record already accepted",
+			"channel", channel.Key, "external_id", item.ExternalID, "task_id", task.ID)
+		return &ports.InboundResult{Action: ports.InboundActionDuplicate, Task: task}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add message to task: %w", err)
+	}
+
+	s.logger.Info(ctx, "inbound record appended to task",
+		"channel", channel.Key,
+		"external_id", item.ExternalID,
+		"external_thread_id", item.ExternalThreadID,
+		"task_id", task.ID)
+	return &ports.InboundResult{Action: ports.InboundActionAppended, Task: updated}, nil
+}
+
+func (s *InboundChannelService) findTask(ctx context.Context, criteria map[string]interface{}) (*domain.Task, error) {
+	tasks, err := s.taskService.FindBySourceMeta(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks by source meta: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	// Несколько совпадений возможны только при ручном изменении мета-данных: берем самую раннюю задачу
+	earliest := &tasks[0]
+	for i := range tasks[1:] {
+		if tasks[i+1].CreatedAt.Before(earliest.CreatedAt) {
+			earliest = &tasks[i+1]
+		}
+	}
+	return earliest, nil
+}
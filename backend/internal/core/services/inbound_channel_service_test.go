@@ -0,0 +1,312 @@
+// internal/core/services/inbound_channel_service_test.go
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	channelinmemory "github.com/audetv/urms/internal/infrastructure/persistence/channel/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChannelSecret секрет подписи тестового канала
+const testChannelSecret = "channel-secret-0123456789"
+
+// signed подписывает payload секретом тестового канала
+func signed(payload []byte) string {
+	return domain.SignWebhookPayload(testChannelSecret, payload)
+}
+
+func newTestInboundChannelService(t *testing.T) (*services.InboundChannelService, ports.TaskService, ports.CustomerRepository) {
+	return newTestInboundChannelServiceWithRepo(t, inmemory.NewTaskRepository(&services.MockLogger{}))
+}
+
+func newTestInboundChannelServiceWithRepo(t *testing.T, taskRepo ports.TaskRepository) (*services.InboundChannelService, ports.TaskService, ports.CustomerRepository) {
+	logger := &services.MockLogger{}
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	taskService := services.NewTaskService(taskRepo, customerRepo, inmemory.NewUserRepository(logger), logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+
+	service := services.NewInboundChannelService(
+		channelinmemory.NewInboundChannelRepository(logger),
+		taskRepo,
+		taskService,
+		customerService,
+		logger,
+	)
+
+	_, err := service.CreateChannel(context.Background(), ports.CreateInboundChannelRequest{
+		Key:    "crm",
+		Name:   "CRM",
+		Secret: testChannelSecret,
+		Mapping: domain.InboundMapping{
+			Subject:          "$.ticket.title",
+			Description:      "$.comment.body",
+			CustomerEmail:    "$.requester.email",
+			CustomerName:     "$.requester.name",
+			Priority:         "$.ticket.severity",
+			Tags:             "$.ticket.labels[*]",
+			ExternalID:       "$.comment.id",
+			ExternalThreadID: "$.ticket.id",
+		},
+		PriorityMap: map[string]domain.Priority{"P1": domain.PriorityCritical},
+		DefaultTags: []string{"crm"},
+	})
+	require.NoError(t, err)
+
+	return service, taskService, customerRepo
+}
+
+func TestInboundChannelService_Receive(t *testing.T) {
+	ctx := context.Background()
+	service, taskService, customerRepo := newTestInboundChannelService(t)
+
+	first := []byte(`{
+		"ticket": {"id": 1001, "title": "Не приходят счета", "severity": "P1", "labels": ["billing"]},
+		"comment": {"id": "c-1", "body": "Счета за март не пришли"},
+		"requester": {"email": "client@example.com", "name": "ООО Ромашка"}
+	}`)
+
+	result, err := service.Receive(ctx, "crm", first, signed(first))
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionCreated, result.Action)
+
+	task := result.Task
+	assert.Equal(t, domain.TaskTypeSupport, task.Type)
+	assert.Equal(t, domain.SourceAPI, task.Source)
+	assert.Equal(t, "Не приходят счета", task.Subject)
+	assert.Equal(t, "Счета за март не пришли", task.Description)
+	assert.Equal(t, domain.PriorityCritical, task.Priority)
+	assert.Equal(t, []string{"crm", "billing"}, task.Tags)
+	assert.Equal(t, "crm", task.SourceMeta[domain.SourceMetaChannel])
+	assert.Equal(t, "c-1", task.SourceMeta[domain.SourceMetaExternalID])
+	assert.Equal(t, "1001", task.SourceMeta[domain.SourceMetaExternalThreadID])
+
+	customer, err := customerRepo.FindByEmail(ctx, "client@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ООО Ромашка", customer.Name)
+	require.NotNil(t, task.CustomerID)
+	assert.Equal(t, customer.ID, *task.CustomerID)
+
+	// Повторная отправка той же записи не создает задачу
+	result, err = service.Receive(ctx, "crm", first, signed(first))
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionDuplicate, result.Action)
+	assert.Equal(t, task.ID, result.Task.ID)
+
+	// Новый комментарий того же обсуждения дополняет задачу
+	reply := []byte(`{
+		"ticket": {"id": 1001, "title": "Не приходят счета"},
+		"comment": {"id": "c-2", "body": "Апрельские тоже"},
+		"requester": {"email": "client@example.com"}
+	}`)
+	result, err = service.Receive(ctx, "crm", reply, signed(reply))
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionAppended, result.Action)
+	assert.Equal(t, task.ID, result.Task.ID)
+
+	result, err = service.Receive(ctx, "crm", reply, signed(reply))
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionDuplicate, result.Action)
+
+	stored, err := taskService.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Messages, 1)
+	assert.Equal(t, "Апрельские тоже", stored.Messages[0].Content)
+	assert.Equal(t, customer.ID, stored.Messages[0].AuthorID)
+	assert.Equal(t, domain.MessageTypeCustomer, stored.Messages[0].Type)
+
+	// Другое обсуждение - новая задача
+	other := []byte(`{
+		"ticket": {"id": 1002, "title": "Смена реквизитов"},
+		"comment": {"id": "c-3"},
+		"requester": {"email": "client@example.com"}
+	}`)
+	result, err = service.Receive(ctx, "crm", other, signed(other))
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionCreated, result.Action)
+	assert.NotEqual(t, task.ID, result.Task.ID)
+	assert.Equal(t, "Смена реквизитов", result.Task.Description, "subject used when description is not mapped")
+	assert.Equal(t, domain.PriorityMedium, result.Task.Priority)
+}
+
+// blockingTaskRepository останавливает первую запись после вызова hold, пока не закрыт release,
+// чтобы повторные доставки гарантированно приходили во время обработки первой
+type blockingTaskRepository struct {
+	ports.TaskRepository
+	mu      sync.Mutex
+	held    chan struct{}
+	release chan struct{}
+}
+
+// hold останавливает следующую запись: held закрывается, когда запись остановлена
+func (r *blockingTaskRepository) hold() (held <-chan struct{}, release chan<- struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.held = make(chan struct{})
+	r.release = make(chan struct{})
+	return r.held, r.release
+}
+
+func (r *blockingTaskRepository) wait() {
+	r.mu.Lock()
+	held, release := r.held, r.release
+	r.held, r.release = nil, nil
+	r.mu.Unlock()
+
+	if held != nil {
+		close(held)
+		<-release
+	}
+}
+
+func (r *blockingTaskRepository) Save(ctx context.Context, task *domain.Task) error {
+	r.wait()
+	return r.TaskRepository.Save(ctx, task)
+}
+
+func (r *blockingTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	r.wait()
+	return r.TaskRepository.Update(ctx, task)
+}
+
+func TestInboundChannelService_ConcurrentRedelivery(t *testing.T) {
+	ctx := context.Background()
+	taskRepo := &blockingTaskRepository{TaskRepository: inmemory.NewTaskRepository(&services.MockLogger{})}
+	service, taskService, _ := newTestInboundChannelServiceWithRepo(t, taskRepo)
+	logger := &services.MockLogger{}
+	service.SetDeliveryService(services.NewInboundDeliveryService(
+		channelinmemory.NewInboundDeliveryStore(logger), services.DefaultInboundDeliveryConfig(), logger))
+
+	// receiveDuringFirst повторяет доставку записи, пока первая доставка остановлена на записи задачи
+	receiveDuringFirst := func(payload []byte) *ports.InboundResult {
+		held, release := taskRepo.hold()
+		type outcome struct {
+			result *ports.InboundResult
+			err    error
+		}
+		first := make(chan outcome, 1)
+		go func() {
+			result, err := service.Receive(ctx, "crm", payload, signed(payload))
+			first <- outcome{result, err}
+		}()
+		select {
+		case <-held:
+		case done := <-first:
+			t.Fatalf("first delivery finished without writing the task: %v", done.err)
+		}
+
+		for range 3 {
+			_, err := service.Receive(ctx, "crm", payload, signed(payload))
+			assert.ErrorIs(t, err, domain.ErrInboundDeliveryInProgress)
+		}
+
+		close(release)
+		done := <-first
+		require.NoError(t, done.err)
+		return done.result
+	}
+
+	first := []byte(`{"ticket": {"id": 7, "title": "Тема"}, "comment": {"id": "c-1"}, "requester": {"email": "client@example.com"}}`)
+	created := receiveDuringFirst(first)
+	assert.Equal(t, ports.InboundActionCreated, created.Action, "only the first delivery creates the task")
+
+	tasks, err := taskService.FindBySourceMeta(ctx, domain.InboundThreadCriteria("crm", "7"))
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	result, err := service.Receive(ctx, "crm", first, signed(first))
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionDuplicate, result.Action)
+	assert.Equal(t, tasks[0].ID, result.Task.ID)
+
+	for i := range 3 {
+		reply := []byte(fmt.Sprintf(`{"ticket": {"id": 7, "title": "Тема"}, "comment": {"id": "c-%d", "body": "Дополнение"}, "requester": {"email": "client@example.com"}}`, i+2))
+		result = receiveDuringFirst(reply)
+		assert.Equal(t, ports.InboundActionAppended, result.Action)
+
+		result, err = service.Receive(ctx, "crm", reply, signed(reply))
+		require.NoError(t, err)
+		assert.Equal(t, ports.InboundActionDuplicate, result.Action)
+	}
+
+	stored, err := taskService.GetTask(ctx, tasks[0].ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Messages, 3, "each record is appended once")
+	for _, externalID := range []string{"c-2", "c-3", "c-4"} {
+		assert.True(t, stored.HasExternalID(externalID), "external ID is stored with the message")
+	}
+
+	// Сообщение и внешний ID записываются вместе: повтор без ключа распознается по задаче
+	_, err = taskService.AddMessage(ctx, stored.ID, ports.AddMessageRequest{
+		AuthorID: "customer", Content: "Дополнение", Type: domain.MessageTypeCustomer, ExternalID: "c-3",
+	})
+	assert.ErrorIs(t, err, domain.ErrInboundDuplicate)
+}
+
+func TestInboundChannelService_Rejects(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestInboundChannelService(t)
+
+	payload := []byte(`{"ticket": {"id": 1, "title": "Тема"}, "comment": {"id": "c-1"}, "requester": {"email": "client@example.com"}}`)
+
+	_, err := service.Receive(ctx, "unknown", payload, signed(payload))
+	assert.ErrorIs(t, err, domain.ErrInboundChannelNotFound)
+
+	truncated := []byte(`{"ticket":`)
+	_, err = service.Receive(ctx, "crm", truncated, signed(truncated))
+	assert.ErrorIs(t, err, domain.ErrInboundPayloadInvalid)
+
+	withoutID := []byte(`{"ticket": {"title": "Тема"}, "requester": {"email": "client@example.com"}}`)
+	_, err = service.Receive(ctx, "crm", withoutID, signed(withoutID))
+	assert.ErrorIs(t, err, domain.ErrInboundPayloadInvalid)
+
+	// Канал принимает только payload с верной подписью
+	_, err = service.Receive(ctx, "crm", payload, "")
+	assert.ErrorIs(t, err, domain.ErrInboundSignatureInvalid)
+	_, err = service.Receive(ctx, "crm", payload, domain.SignWebhookPayload("another-secret-0123", payload))
+	assert.ErrorIs(t, err, domain.ErrInboundSignatureInvalid)
+
+	// Подпись обязательна: канал без секрета не создается, а секрет нельзя снять
+	empty := ""
+	_, err = service.UpdateChannel(ctx, "crm", ports.UpdateInboundChannelRequest{Secret: &empty})
+	assert.Error(t, err)
+
+	secret := "rotated-secret-0123456789"
+	_, err = service.UpdateChannel(ctx, "crm", ports.UpdateInboundChannelRequest{Secret: &secret})
+	require.NoError(t, err)
+	_, err = service.Receive(ctx, "crm", payload, signed(payload))
+	assert.ErrorIs(t, err, domain.ErrInboundSignatureInvalid, "old secret is rejected after rotation")
+
+	result, err := service.Receive(ctx, "crm", payload, domain.SignWebhookPayload(secret, payload))
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionCreated, result.Action)
+
+	generated, err := service.CreateChannel(ctx, ports.CreateInboundChannelRequest{
+		Key:     "forms",
+		Name:    "Формы",
+		Mapping: domain.InboundMapping{Subject: "$.s", CustomerEmail: "$.e", ExternalID: "$.id"},
+	})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, len(generated.Secret), 16, "secret is generated when not set")
+
+	active := false
+	_, err = service.UpdateChannel(ctx, "crm", ports.UpdateInboundChannelRequest{Active: &active})
+	require.NoError(t, err)
+	_, err = service.Receive(ctx, "crm", payload, domain.SignWebhookPayload(secret, payload))
+	assert.ErrorIs(t, err, domain.ErrInboundChannelDisabled)
+
+	_, err = service.CreateChannel(ctx, ports.CreateInboundChannelRequest{
+		Key:     "crm",
+		Name:    "Дубликат",
+		Mapping: domain.InboundMapping{Subject: "$.s", CustomerEmail: "$.e", ExternalID: "$.id"},
+	})
+	assert.ErrorIs(t, err, domain.ErrInboundChannelExists)
+}
@@ -0,0 +1,174 @@
+// internal/core/services/inbound_delivery.go
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// InboundDeliveryConfig параметры хранения принятых записей входящих каналов
+type InboundDeliveryConfig struct {
+	TTL         time.Duration // Сколько хранить ключ принятой записи; позже повтор распознает поиск по SourceMeta
+	LockTimeout time.Duration // Через сколько освобождается ключ, блокировку которого перестали продлевать (KeepAlive)
+}
+
+// DefaultInboundDeliveryConfig возвращает конфигурацию по умолчанию
+func DefaultInboundDeliveryConfig() InboundDeliveryConfig {
+	return InboundDeliveryConfig{
+		TTL:         24 * time.Hour,
+		LockTimeout: time.Minute,
+	}
+}
+
+// InboundDeliveryService реализует ports.InboundDeliveryService.
+// Первая доставка занимает ключ записи до ее приема, поэтому конкурентный дубль
+// не обрабатывается повторно, а получает domain.ErrInboundDeliveryInProgress
+type InboundDeliveryService struct {
+	store  ports.InboundDeliveryStore
+	config InboundDeliveryConfig
+	logger ports.Logger
+}
+
+func NewInboundDeliveryService(store ports.InboundDeliveryStore, config InboundDeliveryConfig, logger ports.Logger) *InboundDeliveryService {
+	defaults := DefaultInboundDeliveryConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = defaults.LockTimeout
+	}
+
+	return &InboundDeliveryService{
+		store:  store,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Begin занимает ключ записи или возвращает ID принявшей ее задачи
+func (s *InboundDeliveryService) Begin(ctx context.Context, channelKey, externalID string) (string, error) {
+	now := time.Now()
+	delivery := &domain.InboundDelivery{
+		Key:       inboundDeliveryKey(channelKey, externalID),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.config.LockTimeout),
+	}
+
+	existing, reserved, err := s.store.Reserve(ctx, delivery)
+	if err != nil {
+		return "", fmt.Errorf("failed to reserve inbound delivery key: %w", err)
+	}
+	if reserved {
+		return "", nil
+	}
+	if !existing.Accepted() {
+		return "", domain.ErrInboundDeliveryInProgress
+	}
+	return existing.TaskID, nil
+}
+
+// KeepAlive продлевает блокировку ключа на LockTimeout каждую треть LockTimeout
+func (s *InboundDeliveryService) KeepAlive(ctx context.Context, channelKey, externalID string) (stop func()) {
+	key := inboundDeliveryKey(channelKey, externalID)
+	return keepLockAlive(ctx, s.config.LockTimeout, s.logger, func(ctx context.Context, expiresAt time.Time) error {
+		return s.store.Extend(ctx, key, expiresAt)
+	})
+}
+
+// Complete сохраняет задачу, принявшую запись, на срок TTL
+func (s *InboundDeliveryService) Complete(ctx context.Context, channelKey, externalID, taskID string) error {
+	if taskID == "" {
+		return fmt.Errorf("task ID cannot be empty")
+	}
+
+	key := inboundDeliveryKey(channelKey, externalID)
+	if err := s.store.Complete(ctx, key, taskID, time.Now().Add(s.config.TTL)); err != nil {
+		return fmt.Errorf("failed to store inbound delivery: %w", err)
+	}
+	return nil
+}
+
+// Abort освобождает ключ необработанной записи
+func (s *InboundDeliveryService) Abort(ctx context.Context, channelKey, externalID string) error {
+	if err := s.store.Release(ctx, inboundDeliveryKey(channelKey, externalID)); err != nil {
+		return fmt.Errorf("failed to release inbound delivery key: %w", err)
+	}
+	return nil
+}
+
+// Cleanup удаляет записи с истекшим сроком хранения
+func (s *InboundDeliveryService) Cleanup(ctx context.Context) (int, error) {
+	deleted, err := s.store.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired inbound deliveries: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.Info(ctx, "expired inbound deliveries deleted", "count", deleted)
+	}
+	return deleted, nil
+}
+
+// inboundDeliveryKey уникальный ключ внешней записи в хранилище принятых записей
+func inboundDeliveryKey(channelKey, externalID string) string {
+	sum := sha256.Sum256([]byte(channelKey + "\x00" + externalID))
+	return hex.EncodeToString(sum[:])
+}
+
+// acceptInbound принимает внешнюю запись под ее уникальным ключом (канал и внешний ID).
+// Ключ занимается на время обработки, поэтому конкурентная повторная доставка не создает
+// вторую задачу, а получает domain.ErrInboundDeliveryInProgress. После обработки с ключом
+// сохраняется ID задачи, и повтор распознается без поиска по SourceMeta;
+// по истечении срока хранения ключа повтор распознает поиск по SourceMeta в handle
+func acceptInbound(
+	ctx context.Context,
+	deliveries ports.InboundDeliveryService,
+	taskRepo ports.TaskRepository,
+	logger ports.Logger,
+	channelKey, externalID string,
+	handle func() (*ports.InboundResult, error),
+) (*ports.InboundResult, error) {
+	if deliveries == nil {
+		return handle()
+	}
+
+	taskID, err := deliveries.Begin(ctx, channelKey, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if taskID != "" {
+		task, err := taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			// Задача удалена после приема: решение принимает обычная обработка
+			return handle()
+		}
+		logger.Info(ctx, "inbound record already accepted",
+			"channel", channelKey, "external_id", externalID, "task_id", task.ID)
+		return &ports.InboundResult{Action: ports.InboundActionDuplicate, Task: task}, nil
+	}
+
+	// Ключ освобождается и сохраняется и при отмене запроса отправителем
+	storeCtx := context.WithoutCancel(ctx)
+	stopKeepAlive := deliveries.KeepAlive(ctx, channelKey, externalID)
+	defer stopKeepAlive()
+	result, err := handle()
+	if err != nil {
+		if abortErr := deliveries.Abort(storeCtx, channelKey, externalID); abortErr != nil {
+			logger.Error(ctx, "failed to release inbound delivery key",
+				"channel", channelKey, "external_id", externalID, "error", abortErr.Error())
+		}
+		return nil, err
+	}
+
+	if err := deliveries.Complete(storeCtx, channelKey, externalID, result.Task.ID); err != nil {
+		// Запись уже принята; повтор после истечения блокировки ключа распознает поиск по SourceMeta
+		logger.Error(ctx, "failed to store inbound delivery key",
+			"channel", channelKey, "external_id", externalID, "error", err.Error())
+	}
+	return result, nil
+}
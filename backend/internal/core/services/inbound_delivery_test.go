@@ -0,0 +1,76 @@
+// internal/core/services/inbound_delivery_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/services"
+	channelinmemory "github.com/audetv/urms/internal/infrastructure/persistence/channel/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInboundDeliveryService(config services.InboundDeliveryConfig) *services.InboundDeliveryService {
+	logger := &services.MockLogger{}
+	return services.NewInboundDeliveryService(channelinmemory.NewInboundDeliveryStore(logger), config, logger)
+}
+
+func TestInboundDeliveryService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("accepted record returns its task", func(t *testing.T) {
+		service := newInboundDeliveryService(services.DefaultInboundDeliveryConfig())
+
+		taskID, err := service.Begin(ctx, "crm", "c-1")
+		require.NoError(t, err)
+		assert.Empty(t, taskID, "first delivery is processed")
+
+		_, err = service.Begin(ctx, "crm", "c-1")
+		assert.ErrorIs(t, err, domain.ErrInboundDeliveryInProgress)
+
+		// Тот же внешний ID другого канала - другая запись
+		taskID, err = service.Begin(ctx, "github", "c-1")
+		require.NoError(t, err)
+		assert.Empty(t, taskID)
+
+		require.NoError(t, service.Complete(ctx, "crm", "c-1", "task-1"))
+		taskID, err = service.Begin(ctx, "crm", "c-1")
+		require.NoError(t, err)
+		assert.Equal(t, "task-1", taskID)
+
+		assert.Error(t, service.Complete(ctx, "crm", "c-1", "task-2"), "accepted record is not overwritten")
+	})
+
+	t.Run("aborted record is processed again", func(t *testing.T) {
+		service := newInboundDeliveryService(services.DefaultInboundDeliveryConfig())
+
+		_, err := service.Begin(ctx, "crm", "c-1")
+		require.NoError(t, err)
+		require.NoError(t, service.Abort(ctx, "crm", "c-1"))
+
+		taskID, err := service.Begin(ctx, "crm", "c-1")
+		require.NoError(t, err)
+		assert.Empty(t, taskID)
+	})
+
+	t.Run("expired records are replaced and cleaned up", func(t *testing.T) {
+		service := newInboundDeliveryService(services.InboundDeliveryConfig{TTL: time.Nanosecond, LockTimeout: time.Nanosecond})
+
+		_, err := service.Begin(ctx, "crm", "c-1")
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		taskID, err := service.Begin(ctx, "crm", "c-1")
+		require.NoError(t, err, "abandoned lock is taken over")
+		assert.Empty(t, taskID)
+		require.NoError(t, service.Complete(ctx, "crm", "c-1", "task-1"))
+		time.Sleep(time.Millisecond)
+
+		deleted, err := service.Cleanup(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+	})
+}
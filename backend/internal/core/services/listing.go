@@ -0,0 +1,49 @@
+// internal/core/services/listing.go
+package services
+
+import "github.com/audetv/urms/internal/core/domain"
+
+// listPage страница списка с общим числом подходящих элементов
+type listPage[T any] struct {
+	Items      []T
+	TotalCount int
+	Page       int
+	PageSize   int
+	TotalPages int
+	NextCursor string
+}
+
+// paginate сортирует элементы и выдает страницу после курсора, а без курсора - со смещения offset.
+// Сортировка выполняется здесь, чтобы порядок совпадал с ключами курсора независимо от
+// порядка, в котором элементы вернул репозиторий
+func paginate[T any](listing domain.Listing[T], items []T, cursor string, offset, limit int) (*listPage[T], error) {
+	listing.SortItems(items)
+
+	page, start, err := listing.Window(items, cursor, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return newListPage(listing, page, start, len(items), limit), nil
+}
+
+// newListPage собирает страницу, первый элемент которой стоит на позиции start в списке
+// из total элементов. Используется и для страниц, которые репозиторий выбрал сам
+func newListPage[T any](listing domain.Listing[T], page []T, start, total, limit int) *listPage[T] {
+	result := &listPage[T]{
+		Items:      page,
+		TotalCount: total,
+		Page:       1,
+		PageSize:   limit,
+	}
+	if len(page) > 0 && start+len(page) < total {
+		result.NextCursor = listing.Cursor(&page[len(page)-1])
+	}
+	switch {
+	case limit > 0:
+		result.Page = start/limit + 1
+		result.TotalPages = (total + limit - 1) / limit
+	case total > 0:
+		result.TotalPages = 1
+	}
+	return result
+}
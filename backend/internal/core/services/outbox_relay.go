@@ -0,0 +1,148 @@
+// internal/core/services/outbox_relay.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// OutboxRelayConfig параметры доставки событий из outbox
+type OutboxRelayConfig struct {
+	BatchSize     int           // Событий за один проход
+	MaxAttempts   int           // После стольких неудач событие помечается failed
+	BaseDelay     time.Duration // Задержка перед второй попыткой
+	MaxDelay      time.Duration // Верхняя граница задержки
+	BackoffFactor float64       // Множитель задержки для каждой следующей попытки
+	Lease         time.Duration // На сколько выбранные события скрываются от других экземпляров
+	Retention     time.Duration // Сколько хранить доставленные события; 0 - не удалять
+}
+
+// DefaultOutboxRelayConfig возвращает конфигурацию по умолчанию
+func DefaultOutboxRelayConfig() OutboxRelayConfig {
+	return OutboxRelayConfig{
+		BatchSize:     100,
+		MaxAttempts:   10,
+		BaseDelay:     5 * time.Second,
+		MaxDelay:      time.Hour,
+		BackoffFactor: 2,
+		Lease:         time.Minute,
+		Retention:     7 * 24 * time.Hour,
+	}
+}
+
+// OutboxRelayService доставляет события из outbox в шину не менее одного раза:
+// событие помечается доставленным только после успешной обработки всеми подписчиками
+type OutboxRelayService struct {
+	outboxRepo ports.OutboxRepository
+	bus        ports.EventBus
+	config     OutboxRelayConfig
+	logger     ports.Logger
+}
+
+func NewOutboxRelayService(outboxRepo ports.OutboxRepository, bus ports.EventBus, config OutboxRelayConfig, logger ports.Logger) *OutboxRelayService {
+	defaults := DefaultOutboxRelayConfig()
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.BaseDelay <= 0 {
+		config.BaseDelay = defaults.BaseDelay
+	}
+	if config.MaxDelay < config.BaseDelay {
+		config.MaxDelay = config.BaseDelay
+	}
+	if config.BackoffFactor < 1 {
+		config.BackoffFactor = defaults.BackoffFactor
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+
+	return &OutboxRelayService{
+		outboxRepo: outboxRepo,
+		bus:        bus,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// RelayPending доставляет наступившие события одной пачкой
+func (s *OutboxRelayService) RelayPending(ctx context.Context, now time.Time) (*ports.OutboxRelayResult, error) {
+	messages, err := s.outboxRepo.ClaimPending(ctx, now, s.config.BatchSize, now.Add(s.config.Lease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	result := &ports.OutboxRelayResult{}
+	for _, message := range messages {
+		event := message.Event
+		// Обработчики событий выполняются от имени системы
+		eventCtx := ports.WithSystemPrincipal(ctx)
+		if event.CorrelationID != "" {
+			eventCtx = context.WithValue(eventCtx, ports.CorrelationIDKey, event.CorrelationID)
+		}
+
+		dispatchErr := s.bus.Dispatch(eventCtx, event)
+		if dispatchErr == nil {
+			if err := s.outboxRepo.MarkDispatched(ctx, event.ID, now); err != nil {
+				// Событие будет доставлено повторно после истечения аренды
+				s.logger.Error(ctx, "failed to mark outbox event dispatched", "event_id", event.ID, "error", err.Error())
+				continue
+			}
+			result.Dispatched++
+			continue
+		}
+
+		attempt := message.Attempts + 1
+		if attempt >= s.config.MaxAttempts {
+			if err := s.outboxRepo.MarkFailed(ctx, event.ID, dispatchErr.Error()); err != nil {
+				s.logger.Error(ctx, "failed to mark outbox event failed", "event_id", event.ID, "error", err.Error())
+				continue
+			}
+			s.logger.Error(eventCtx, "outbox event delivery failed permanently",
+				"event_id", event.ID, "event_type", event.Type, "attempts", attempt, "error", dispatchErr.Error())
+			result.Failed++
+			continue
+		}
+
+		nextAttemptAt := now.Add(s.retryDelay(attempt))
+		if err := s.outboxRepo.Reschedule(ctx, event.ID, dispatchErr.Error(), nextAttemptAt); err != nil {
+			s.logger.Error(ctx, "failed to reschedule outbox event", "event_id", event.ID, "error", err.Error())
+			continue
+		}
+		result.Retried++
+	}
+
+	if s.config.Retention > 0 {
+		purged, err := s.outboxRepo.DeleteDispatchedBefore(ctx, now.Add(-s.config.Retention))
+		if err != nil {
+			s.logger.Warn(ctx, "failed to purge dispatched outbox events", "error", err.Error())
+		}
+		result.Purged = purged
+	}
+
+	if len(messages) > 0 {
+		s.logger.Info(ctx, "outbox relay pass completed",
+			"claimed", len(messages),
+			"dispatched", result.Dispatched,
+			"retried", result.Retried,
+			"failed", result.Failed)
+	}
+
+	return result, nil
+}
+
+// retryDelay задержка перед следующей попыткой после attempt неудачных
+func (s *OutboxRelayService) retryDelay(attempt int) time.Duration {
+	delay := time.Duration(float64(s.config.BaseDelay) * math.Pow(s.config.BackoffFactor, float64(attempt-1)))
+	if delay > s.config.MaxDelay || delay <= 0 {
+		return s.config.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,240 @@
+// internal/core/services/outbox_relay_test.go
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	eventsinmemory "github.com/audetv/urms/internal/infrastructure/persistence/events/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutbox_TaskEvents(t *testing.T) {
+	logger := &services.MockLogger{}
+	outboxRepo := eventsinmemory.NewOutboxRepository(logger)
+	taskRepo := services.NewAuditedTaskRepository(inmemory.NewTaskRepository(logger), inmemory.NewAuditRepository(logger), logger)
+	taskRepo.SetEventPublisher(services.NewOutboxPublisher(outboxRepo), services.NoopUnitOfWork{})
+	taskService := services.NewTaskService(taskRepo, inmemory.NewCustomerRepository(logger), inmemory.NewUserRepository(logger), logger)
+
+	ctx := context.WithValue(context.Background(), ports.CorrelationIDKey, "req-42")
+
+	task, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+		Type:        domain.TaskTypeInternal,
+		Subject:     "Обновить сертификат",
+		Description: "Описание",
+		ReporterID:  "user-1",
+		Priority:    domain.PriorityHigh,
+	})
+	require.NoError(t, err)
+
+	_, err = taskService.AssignTask(ctx, task.ID, "user-2", "user-1")
+	require.NoError(t, err)
+	_, err = taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusInProgress, "user-2")
+	require.NoError(t, err)
+	_, err = taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+		AuthorID: "user-2",
+		Content:  "Сертификат обновлен",
+		Type:     domain.MessageTypeInternal,
+	})
+	require.NoError(t, err)
+
+	// Изменение без доменного аналога событие не публикует
+	subject := "Обновить сертификат до пятницы"
+	_, err = taskService.UpdateTask(ctx, task.ID, ports.UpdateTaskRequest{UserID: "user-1", Subject: &subject})
+	require.NoError(t, err)
+
+	messages := outboxRepo.FindAll(ctx)
+	require.Len(t, messages, 4)
+
+	types := make([]domain.DomainEventType, len(messages))
+	for i, message := range messages {
+		types[i] = message.Event.Type
+		assert.Equal(t, domain.OutboxStatusPending, message.Status)
+		assert.Equal(t, domain.AggregateTask, message.Event.AggregateType)
+		assert.Equal(t, task.ID, message.Event.AggregateID)
+		assert.Equal(t, "req-42", message.Event.CorrelationID)
+	}
+	assert.Equal(t, []domain.DomainEventType{
+		domain.DomainEventTaskCreated,
+		domain.DomainEventTaskAssigned,
+		domain.DomainEventTaskStatusChanged,
+		domain.DomainEventTaskMessageAdded,
+	}, types)
+
+	assert.Equal(t, "user-2", messages[1].Event.Data["assignee_id"])
+	assert.Equal(t, "open", messages[2].Event.Data["old_status"])
+	assert.Equal(t, "in_progress", messages[2].Event.Data["status"])
+	assert.Equal(t, "user-2", messages[3].Event.Data["author_id"])
+	assert.NotEmpty(t, messages[3].Event.Data["message_id"])
+}
+
+// failingPublisher имитирует ошибку записи в outbox
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, events ...domain.DomainEvent) error {
+	return errors.New("outbox unavailable")
+}
+
+// failingCommitUnitOfWork выполняет функцию, но не фиксирует транзакцию
+type failingCommitUnitOfWork struct{}
+
+func (failingCommitUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	return errors.New("commit failed")
+}
+
+func TestOutbox_TaskIsNotChangedWithoutEvents(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	store := inmemory.NewTaskRepository(logger)
+	taskRepo := services.NewAuditedTaskRepository(store, inmemory.NewAuditRepository(logger), logger)
+	taskService := services.NewTaskService(taskRepo, inmemory.NewCustomerRepository(logger), inmemory.NewUserRepository(logger), logger)
+
+	task, err := taskService.CreateInternalTask(ctx, ports.CreateInternalTaskRequest{
+		Subject:     "Обновить сертификат",
+		Description: "Описание",
+		ReporterID:  "user-1",
+		Priority:    domain.PriorityHigh,
+	})
+	require.NoError(t, err)
+
+	for name, setup := range map[string]func(){
+		"outbox write fails": func() { taskRepo.SetEventPublisher(failingPublisher{}, services.NoopUnitOfWork{}) },
+		"commit fails": func() {
+			taskRepo.SetEventPublisher(services.NewOutboxPublisher(eventsinmemory.NewOutboxRepository(logger)), failingCommitUnitOfWork{})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setup()
+
+			_, err := taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusInProgress, "user-1")
+			require.Error(t, err)
+			stored, err := store.FindByID(ctx, task.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.TaskStatusOpen, stored.Status, "task change is not applied without its event")
+
+			_, err = taskService.CreateInternalTask(ctx, ports.CreateInternalTaskRequest{
+				Subject:     "Новая задача",
+				Description: "Описание",
+				ReporterID:  "user-1",
+				Priority:    domain.PriorityLow,
+			})
+			require.Error(t, err)
+			tasks, err := store.FindByQuery(ctx, ports.TaskQuery{})
+			require.NoError(t, err)
+			assert.Len(t, tasks, 1, "new task is not stored without its event")
+		})
+	}
+
+	// После восстановления хранилища изменение проходит
+	taskRepo.SetEventPublisher(services.NewOutboxPublisher(eventsinmemory.NewOutboxRepository(logger)), services.NoopUnitOfWork{})
+	changed, err := taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusInProgress, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.TaskStatusInProgress, changed.Status)
+}
+
+func TestOutboxRelayService(t *testing.T) {
+	logger := &services.MockLogger{}
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	newRelay := func(handler ports.EventHandler) (*eventsinmemory.OutboxRepository, *services.OutboxRelayService) {
+		outboxRepo := eventsinmemory.NewOutboxRepository(logger)
+		bus := services.NewEventBus(logger)
+		bus.Subscribe(domain.DomainEventTaskCreated, "test", handler)
+		relay := services.NewOutboxRelayService(outboxRepo, bus, services.OutboxRelayConfig{
+			BatchSize:     10,
+			MaxAttempts:   3,
+			BaseDelay:     time.Second,
+			MaxDelay:      time.Minute,
+			BackoffFactor: 2,
+		}, logger)
+		return outboxRepo, relay
+	}
+
+	appendEvent := func(t *testing.T, repo *eventsinmemory.OutboxRepository) domain.DomainEvent {
+		event := domain.NewDomainEvent(domain.DomainEventTaskCreated, domain.AggregateTask, "TASK-1", "user-1", nil)
+		message := domain.NewOutboxMessage(event)
+		message.NextAttemptAt = now
+		message.CreatedAt = now
+		require.NoError(t, repo.Append(ctx, message))
+		return event
+	}
+
+	t.Run("dispatched once to subscribers", func(t *testing.T) {
+		var received []string
+		outboxRepo, relay := newRelay(func(ctx context.Context, event domain.DomainEvent) error {
+			received = append(received, event.ID)
+			return nil
+		})
+		event := appendEvent(t, outboxRepo)
+
+		result, err := relay.RelayPending(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Dispatched)
+
+		result, err = relay.RelayPending(ctx, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Dispatched)
+
+		assert.Equal(t, []string{event.ID}, received)
+		stored := outboxRepo.FindAll(ctx)[0]
+		assert.Equal(t, domain.OutboxStatusDispatched, stored.Status)
+		require.NotNil(t, stored.DispatchedAt)
+	})
+
+	t.Run("failed handler retried with backoff until max attempts", func(t *testing.T) {
+		calls := 0
+		outboxRepo, relay := newRelay(func(ctx context.Context, event domain.DomainEvent) error {
+			calls++
+			return errors.New("subscriber unavailable")
+		})
+		appendEvent(t, outboxRepo)
+
+		result, err := relay.RelayPending(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Retried)
+		stored := outboxRepo.FindAll(ctx)[0]
+		assert.Equal(t, 1, stored.Attempts)
+		assert.Equal(t, now.Add(time.Second), stored.NextAttemptAt)
+		assert.Contains(t, stored.LastError, "subscriber unavailable")
+
+		// До наступления следующей попытки событие не выбирается
+		result, err = relay.RelayPending(ctx, now.Add(500*time.Millisecond))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Retried)
+
+		_, err = relay.RelayPending(ctx, now.Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(3*time.Second), outboxRepo.FindAll(ctx)[0].NextAttemptAt)
+
+		result, err = relay.RelayPending(ctx, now.Add(3*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, 3, calls)
+
+		stored = outboxRepo.FindAll(ctx)[0]
+		assert.Equal(t, domain.OutboxStatusFailed, stored.Status)
+		assert.Equal(t, 3, stored.Attempts)
+	})
+
+	t.Run("handler panic does not stop delivery", func(t *testing.T) {
+		outboxRepo, relay := newRelay(func(ctx context.Context, event domain.DomainEvent) error {
+			panic("boom")
+		})
+		appendEvent(t, outboxRepo)
+
+		result, err := relay.RelayPending(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Retried)
+	})
+}
@@ -0,0 +1,313 @@
+// internal/core/services/reply_template_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// ReplyTemplateService управляет шаблонами ответов и применяет их к задачам.
+// Ответ добавляется через TaskService.AddMessage; для задач из email
+// он дополнительно отправляется клиенту письмом, если подключен EmailSender
+type ReplyTemplateService struct {
+	templateRepo ports.ReplyTemplateRepository
+	taskService  ports.TaskService
+	customerRepo ports.CustomerRepository
+	userRepo     ports.UserRepository
+	logger       ports.Logger
+	replier      emailReplier
+}
+
+func NewReplyTemplateService(
+	templateRepo ports.ReplyTemplateRepository,
+	taskService ports.TaskService,
+	customerRepo ports.CustomerRepository,
+	userRepo ports.UserRepository,
+	logger ports.Logger,
+) *ReplyTemplateService {
+	return &ReplyTemplateService{
+		templateRepo: templateRepo,
+		taskService:  taskService,
+		customerRepo: customerRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+		replier:      emailReplier{customerRepo: customerRepo},
+	}
+}
+
+// SetEmailSender подключает отправку ответов клиентам по email.
+// from - адрес, от имени которого отправляются ответы
+func (s *ReplyTemplateService) SetEmailSender(sender ports.EmailSender, from domain.EmailAddress) {
+	s.replier.sender = sender
+	s.replier.from = from
+}
+
+// CreateTemplate создает шаблон ответа
+func (s *ReplyTemplateService) CreateTemplate(ctx context.Context, req ports.ReplyTemplateRequest) (*domain.ReplyTemplate, error) {
+	tpl, err := domain.NewReplyTemplate(req.Name, req.Category, req.Variants, req.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reply template: %w", err)
+	}
+	tpl.Actions = req.Actions
+
+	if err := tpl.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid reply template: %w", err)
+	}
+
+	if err := s.templateRepo.Save(ctx, tpl); err != nil {
+		return nil, fmt.Errorf("failed to save reply template: %w", err)
+	}
+
+	s.logger.Info(ctx, "reply template created", "template_id", tpl.ID, "category", tpl.Category)
+	return tpl, nil
+}
+
+// GetTemplate возвращает шаблон по ID
+func (s *ReplyTemplateService) GetTemplate(ctx context.Context, id string) (*domain.ReplyTemplate, error) {
+	tpl, err := s.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reply template: %w", err)
+	}
+	return tpl, nil
+}
+
+// ListTemplates возвращает шаблоны по фильтру. Для категории возвращается ее
+// библиотека вместе с общими шаблонами (без категории)
+func (s *ReplyTemplateService) ListTemplates(ctx context.Context, query ports.ReplyTemplateQuery) ([]domain.ReplyTemplate, error) {
+	templates, err := s.templateRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reply templates: %w", err)
+	}
+
+	search := strings.ToLower(query.Search)
+	result := []domain.ReplyTemplate{}
+	for _, tpl := range templates {
+		if query.Category != "" && tpl.Category != "" && tpl.Category != query.Category {
+			continue
+		}
+		if query.Language != "" {
+			if _, ok := tpl.Variants[query.Language]; !ok {
+				continue
+			}
+		}
+		if search != "" && !strings.Contains(strings.ToLower(tpl.Name), search) {
+			continue
+		}
+		result = append(result, tpl)
+	}
+
+	return result, nil
+}
+
+// UpdateTemplate изменяет шаблон. Пустые название и варианты не меняются,
+// категория и действия макроса заменяются значениями из запроса
+func (s *ReplyTemplateService) UpdateTemplate(ctx context.Context, id string, req ports.ReplyTemplateRequest) (*domain.ReplyTemplate, error) {
+	existing, err := s.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reply template: %w", err)
+	}
+
+	updated := *existing
+	if req.Name != "" {
+		updated.Name = req.Name
+	}
+	if len(req.Variants) > 0 {
+		updated.Variants = req.Variants
+	}
+	updated.Category = req.Category
+	updated.Actions = req.Actions
+	updated.UpdatedAt = time.Now()
+
+	if err := updated.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid reply template: %w", err)
+	}
+
+	if err := s.templateRepo.Update(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update reply template: %w", err)
+	}
+
+	s.logger.Info(ctx, "reply template updated", "template_id", id)
+	return &updated, nil
+}
+
+// DeleteTemplate удаляет шаблон
+func (s *ReplyTemplateService) DeleteTemplate(ctx context.Context, id string) error {
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete reply template: %w", err)
+	}
+
+	s.logger.Info(ctx, "reply template deleted", "template_id", id)
+	return nil
+}
+
+// RenderTemplate подставляет данные задачи, клиента и оператора в шаблон
+func (s *ReplyTemplateService) RenderTemplate(ctx context.Context, req ports.RenderTemplateRequest) (*ports.RenderedReply, error) {
+	tpl, err := s.templateRepo.FindByID(ctx, req.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reply template: %w", err)
+	}
+
+	task, err := s.taskService.GetTask(ctx, req.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	return s.render(ctx, tpl, task, req)
+}
+
+// ApplyTemplate добавляет в задачу ответ по шаблону. Публичный ответ на задачу
+// из email отправляется клиенту письмом в той же цепочке. В режиме макроса
+// после ответа меняются статус, теги и исполнитель задачи
+func (s *ReplyTemplateService) ApplyTemplate(ctx context.Context, req ports.ApplyTemplateRequest) (*ports.AppliedTemplateResult, error) {
+	tpl, err := s.templateRepo.FindByID(ctx, req.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reply template: %w", err)
+	}
+
+	task, err := s.taskService.GetTask(ctx, req.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	reply, err := s.render(ctx, tpl, task, req.RenderTemplateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err = s.taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+		AuthorID:  req.OperatorID,
+		Content:   reply.Content,
+		IsPrivate: req.IsPrivate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add reply: %w", err)
+	}
+
+	result := &ports.AppliedTemplateResult{Reply: *reply}
+
+	if !req.IsPrivate && s.replier.canSend(task) {
+		if err := s.replier.send(ctx, task, reply.Content); err != nil {
+			s.logger.Error(ctx, "failed to send template reply by email",
+				"task_id", task.ID, "template_id", tpl.ID, "error", err.Error())
+			result.EmailError = err.Error()
+		} else {
+			result.EmailSent = true
+		}
+	}
+
+	if req.Macro {
+		task, err = s.applyMacroActions(ctx, task, tpl.Actions, req.OperatorID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Task = task
+
+	s.logger.Info(ctx, "reply template applied",
+		"task_id", task.ID,
+		"template_id", tpl.ID,
+		"language", reply.Language,
+		"macro", req.Macro,
+		"email_sent", result.EmailSent,
+	)
+
+	return result, nil
+}
+
+// render собирает переменные шаблона и выполняет подстановку
+func (s *ReplyTemplateService) render(ctx context.Context, tpl *domain.ReplyTemplate, task *domain.Task, req ports.RenderTemplateRequest) (*ports.RenderedReply, error) {
+	var customer *domain.Customer
+	if task.CustomerID != nil {
+		found, err := s.customerRepo.FindByID(ctx, *task.CustomerID)
+		if err != nil {
+			s.logger.Warn(ctx, "customer not found for reply template",
+				"task_id", task.ID, "customer_id", *task.CustomerID, "error", err.Error())
+		} else {
+			customer = found
+		}
+	}
+
+	var operator *domain.User
+	if req.OperatorID != "" {
+		found, err := s.userRepo.FindByID(ctx, req.OperatorID)
+		if err != nil {
+			s.logger.Warn(ctx, "operator not found for reply template",
+				"task_id", task.ID, "operator_id", req.OperatorID, "error", err.Error())
+		} else {
+			operator = found
+		}
+	}
+
+	language := req.Language
+	if language == "" {
+		language = domain.DefaultTemplateLanguage
+	}
+
+	content, usedLanguage, err := tpl.Render(language, domain.NewReplyTemplateData(task, customer, operator))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render reply template: %w", err)
+	}
+
+	return &ports.RenderedReply{
+		TemplateID: tpl.ID,
+		TaskID:     task.ID,
+		Language:   usedLanguage,
+		Content:    content,
+		Actions:    tpl.Actions,
+	}, nil
+}
+
+// applyMacroActions применяет действия макроса через TaskService, чтобы
+// сохранить проверки переходов статусов, блокировок и историю задачи
+func (s *ReplyTemplateService) applyMacroActions(ctx context.Context, task *domain.Task, actions domain.MacroActions, userID string) (*domain.Task, error) {
+	var err error
+
+	if actions.Status != "" && actions.Status != task.Status {
+		task, err = s.taskService.ChangeStatus(ctx, task.ID, actions.Status, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply macro status: %w", err)
+		}
+	}
+
+	if len(actions.Tags) > 0 {
+		tags := mergeTags(task.Tags, actions.Tags)
+		if len(tags) != len(task.Tags) {
+			task, err = s.taskService.UpdateTask(ctx, task.ID, ports.UpdateTaskRequest{Tags: &tags})
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply macro tags: %w", err)
+			}
+		}
+	}
+
+	if actions.AssigneeID != "" && actions.AssigneeID != task.AssigneeID {
+		task, err = s.taskService.AssignTask(ctx, task.ID, actions.AssigneeID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply macro assignee: %w", err)
+		}
+	}
+
+	return task, nil
+}
+
+// mergeTags добавляет к тегам задачи новые, сохраняя порядок и без повторов
+func mergeTags(current []string, added []string) []string {
+	tags := append([]string{}, current...)
+	seen := make(map[string]bool, len(current))
+	for _, tag := range current {
+		seen[tag] = true
+	}
+	for _, tag := range added {
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
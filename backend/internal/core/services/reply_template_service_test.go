@@ -0,0 +1,179 @@
+// internal/core/services/reply_template_service_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEmailSender запоминает отправленные письма
+type recordingEmailSender struct {
+	sent []domain.EmailMessage
+}
+
+func (s *recordingEmailSender) SendEmail(ctx context.Context, msg domain.EmailMessage) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestReplyTemplateService(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+	templateService := services.NewReplyTemplateService(
+		inmemory.NewReplyTemplateRepository(logger), taskService, customerRepo, userRepo, logger,
+	)
+	sender := &recordingEmailSender{}
+	templateService.SetEmailSender(sender, "support@company.com")
+
+	customer, err := customerService.CreateCustomer(ctx, ports.CreateCustomerRequest{
+		Name:  "Иван Петров",
+		Email: "ivan@example.com",
+	})
+	require.NoError(t, err)
+
+	task, err := taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+		Subject:     "Не работает оплата",
+		Description: "Ошибка при оплате",
+		CustomerID:  customer.ID,
+		ReporterID:  "user-1",
+		Source:      domain.SourceEmail,
+		SourceMeta:  map[string]interface{}{"message_id": "<original@example.com>"},
+		Priority:    domain.PriorityHigh,
+		Category:    "billing",
+	})
+	require.NoError(t, err)
+
+	resolved, err := templateService.CreateTemplate(ctx, ports.ReplyTemplateRequest{
+		Name:     "Оплата восстановлена",
+		Category: "billing",
+		Variants: map[domain.TemplateLanguage]string{
+			domain.TemplateLanguageRU: "Здравствуйте, {{.CustomerName}}! Проблема по заявке {{.TaskID}} устранена.\n{{.Signature}}",
+			domain.TemplateLanguageEN: "Hello {{.CustomerName}}, ticket {{.TaskID}} is fixed.\n{{.Signature}}",
+		},
+		Actions: domain.MacroActions{
+			Status:     domain.TaskStatusInProgress,
+			Tags:       []string{"billing-fixed"},
+			AssigneeID: "user-3",
+		},
+		CreatedBy: "user-1",
+	})
+	require.NoError(t, err)
+
+	_, err = templateService.CreateTemplate(ctx, ports.ReplyTemplateRequest{
+		Name:      "Общее приветствие",
+		Variants:  map[domain.TemplateLanguage]string{domain.TemplateLanguageRU: "Здравствуйте!"},
+		CreatedBy: "user-1",
+	})
+	require.NoError(t, err)
+
+	_, err = templateService.CreateTemplate(ctx, ports.ReplyTemplateRequest{
+		Name:      "Доставка",
+		Category:  "delivery",
+		Variants:  map[domain.TemplateLanguage]string{domain.TemplateLanguageRU: "Заказ в пути"},
+		CreatedBy: "user-1",
+	})
+	require.NoError(t, err)
+
+	t.Run("list by category includes shared templates", func(t *testing.T) {
+		templates, err := templateService.ListTemplates(ctx, ports.ReplyTemplateQuery{Category: "billing"})
+		require.NoError(t, err)
+		assert.Len(t, templates, 2)
+
+		templates, err = templateService.ListTemplates(ctx, ports.ReplyTemplateQuery{Language: domain.TemplateLanguageEN})
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		assert.Equal(t, resolved.ID, templates[0].ID)
+	})
+
+	t.Run("preview does not change task", func(t *testing.T) {
+		reply, err := templateService.RenderTemplate(ctx, ports.RenderTemplateRequest{
+			TemplateID: resolved.ID,
+			TaskID:     task.ID,
+			OperatorID: "user-3",
+			Language:   domain.TemplateLanguageEN,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.TemplateLanguageEN, reply.Language)
+		assert.Contains(t, reply.Content, "Hello Иван Петров, ticket "+task.ID+" is fixed.")
+		assert.Contains(t, reply.Content, "Best regards,\nOperator User")
+
+		unchanged, err := taskService.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, unchanged.Messages)
+		assert.Empty(t, sender.sent)
+	})
+
+	t.Run("reply mode adds message and sends email", func(t *testing.T) {
+		result, err := templateService.ApplyTemplate(ctx, ports.ApplyTemplateRequest{
+			RenderTemplateRequest: ports.RenderTemplateRequest{
+				TemplateID: resolved.ID,
+				TaskID:     task.ID,
+				OperatorID: "user-3",
+			},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.EmailSent)
+		require.Len(t, result.Task.Messages, 1)
+		assert.Contains(t, result.Task.Messages[0].Content, "Здравствуйте, Иван Петров!")
+		assert.Equal(t, domain.TaskStatusOpen, result.Task.Status, "actions are applied only in macro mode")
+
+		require.Len(t, sender.sent, 1)
+		email := sender.sent[0]
+		assert.Equal(t, []domain.EmailAddress{"ivan@example.com"}, email.To)
+		assert.Equal(t, "Re: Не работает оплата", email.Subject)
+		assert.Equal(t, "<original@example.com>", email.InReplyTo)
+	})
+
+	t.Run("macro mode applies status, tags and assignee", func(t *testing.T) {
+		result, err := templateService.ApplyTemplate(ctx, ports.ApplyTemplateRequest{
+			RenderTemplateRequest: ports.RenderTemplateRequest{
+				TemplateID: resolved.ID,
+				TaskID:     task.ID,
+				OperatorID: "user-2",
+			},
+			IsPrivate: true,
+			Macro:     true,
+		})
+		require.NoError(t, err)
+		assert.False(t, result.EmailSent, "private replies are not emailed")
+		assert.Len(t, sender.sent, 1)
+
+		assert.Equal(t, domain.TaskStatusInProgress, result.Task.Status)
+		assert.Contains(t, result.Task.Tags, "billing-fixed")
+		assert.Equal(t, "user-3", result.Task.AssigneeID)
+		assert.Equal(t, domain.MessageTypeInternal, result.Task.Messages[1].Type)
+	})
+
+	t.Run("update and delete template", func(t *testing.T) {
+		updated, err := templateService.UpdateTemplate(ctx, resolved.ID, ports.ReplyTemplateRequest{
+			Name:     "Оплата восстановлена (v2)",
+			Category: "billing",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Оплата восстановлена (v2)", updated.Name)
+		assert.Len(t, updated.Variants, 2, "variants are kept when not provided")
+		assert.True(t, updated.Actions.IsEmpty())
+
+		_, err = templateService.UpdateTemplate(ctx, resolved.ID, ports.ReplyTemplateRequest{
+			Variants: map[domain.TemplateLanguage]string{domain.TemplateLanguageRU: "{{.Missing}}"},
+		})
+		assert.Error(t, err)
+
+		require.NoError(t, templateService.DeleteTemplate(ctx, resolved.ID))
+		_, err = templateService.GetTemplate(ctx, resolved.ID)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,459 @@
+// internal/core/services/satisfaction_service.go
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// SurveyConfig настройки опросов удовлетворенности
+type SurveyConfig struct {
+	Secret        string        // Ключ подписи ссылок; пусто - опросы отключены
+	PublicBaseURL string        // Внешний адрес API, на который ведут ссылки из письма
+	TokenTTL      time.Duration // Срок действия ссылок
+}
+
+// SatisfactionService отправляет клиентам опросы после решения задач
+// и собирает статистику удовлетворенности (CSAT)
+type SatisfactionService struct {
+	taskRepo     ports.TaskRepository
+	customerRepo ports.CustomerRepository
+	userRepo     ports.UserRepository
+	logger       ports.Logger
+	config       SurveyConfig
+	replier      emailReplier
+}
+
+func NewSatisfactionService(
+	taskRepo ports.TaskRepository,
+	customerRepo ports.CustomerRepository,
+	userRepo ports.UserRepository,
+	config SurveyConfig,
+	logger ports.Logger,
+) *SatisfactionService {
+	if config.TokenTTL <= 0 {
+		config.TokenTTL = 30 * 24 * time.Hour
+	}
+	config.PublicBaseURL = strings.TrimRight(config.PublicBaseURL, "/")
+
+	return &SatisfactionService{
+		taskRepo:     taskRepo,
+		customerRepo: customerRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+		config:       config,
+		replier:      emailReplier{customerRepo: customerRepo},
+	}
+}
+
+// SetEmailSender подключает отправку опросов клиентам по email
+func (s *SatisfactionService) SetEmailSender(sender ports.EmailSender, from domain.EmailAddress) {
+	s.replier.sender = sender
+	s.replier.from = from
+}
+
+// HandleTaskEvent отправляет опрос при первом решении задачи поддержки (task.status_changed).
+// Событие доставляется после фиксации смены статуса, поэтому почтовый сервер ее не задерживает.
+// При повторном решении после переоткрытия и повторной доставке события опрос не дублируется;
+// если письмо не ушло, ошибка возвращается и событие будет доставлено снова
+func (s *SatisfactionService) HandleTaskEvent(ctx context.Context, event domain.DomainEvent) error {
+	if event.Type != domain.DomainEventTaskStatusChanged || event.Data["status"] != string(domain.TaskStatusResolved) {
+		return nil
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to find task: %w", err)
+	}
+	if task.Status != domain.TaskStatusResolved || task.Satisfaction != nil || !s.canSurvey(task) {
+		return nil
+	}
+
+	if err := s.sendSurvey(ctx, task); err != nil {
+		s.logger.Error(ctx, "failed to send satisfaction survey", "task_id", task.ID, "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+// SendSurvey отправляет (или повторно отправляет) опрос по решенной задаче
+func (s *SatisfactionService) SendSurvey(ctx context.Context, taskID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if task.Status != domain.TaskStatusResolved && task.Status != domain.TaskStatusClosed {
+		return nil, fmt.Errorf("survey can only be sent for resolved tasks, current status: %s", task.Status)
+	}
+	if task.Satisfaction.IsAnswered() {
+		return nil, errors.New("customer has already rated this task")
+	}
+	if !s.canSurvey(task) {
+		return nil, errors.New("satisfaction surveys are not available for this task")
+	}
+
+	if err := s.sendSurvey(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// SubmitResponse проверяет подпись ссылки и записывает оценку клиента в задачу
+func (s *SatisfactionService) SubmitResponse(ctx context.Context, req ports.SurveyResponseRequest) (*domain.Task, error) {
+	task, err := s.findSurveyTask(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := task.RateSatisfaction(req.Rating, req.Comment, *task.CustomerID); err != nil {
+		return nil, fmt.Errorf("failed to record survey response: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "satisfaction survey answered",
+		"task_id", task.ID,
+		"rating", req.Rating,
+		"operator_id", task.Satisfaction.OperatorID,
+	)
+	return task, nil
+}
+
+// GetSurvey проверяет ссылку опроса. Переход по ссылке не записывает оценку:
+// письма открывают сканеры ссылок и предзагрузка почтовых клиентов
+func (s *SatisfactionService) GetSurvey(ctx context.Context, token string) (*domain.Task, error) {
+	task, err := s.findSurveyTask(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if task.Satisfaction == nil {
+		return nil, domain.ErrSurveyNotSent
+	}
+	return task, nil
+}
+
+// findSurveyTask находит задачу по токену опроса и сверяет клиента
+func (s *SatisfactionService) findSurveyTask(ctx context.Context, token string) (*domain.Task, error) {
+	taskID, customerID, err := s.verifyToken(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	if task.CustomerID == nil || *task.CustomerID != customerID {
+		return nil, domain.ErrInvalidSurveyToken
+	}
+	return task, nil
+}
+
+// GetSatisfactionReport строит отчет по оценкам клиентов за период
+func (s *SatisfactionService) GetSatisfactionReport(ctx context.Context, query ports.SatisfactionQuery) (*ports.SatisfactionReport, error) {
+	if query.From.IsZero() || query.To.IsZero() {
+		return nil, errors.New("report period is required")
+	}
+	if !query.From.Before(query.To) {
+		return nil, errors.New("report period start must be before end")
+	}
+	if query.GroupBy == "" {
+		query.GroupBy = ports.SatisfactionByOperator
+	}
+
+	switch query.GroupBy {
+	case ports.SatisfactionByOperator, ports.SatisfactionByCustomer, ports.SatisfactionByCategory,
+		ports.SatisfactionByDay, ports.SatisfactionByWeek, ports.SatisfactionByMonth:
+	default:
+		return nil, fmt.Errorf("unsupported report grouping: %s", query.GroupBy)
+	}
+
+	tasks, err := s.taskRepo.FindByQuery(ctx, ports.TaskQuery{
+		CustomerID: query.CustomerID,
+		Category:   query.Category,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	report := &ports.SatisfactionReport{
+		From:    query.From,
+		To:      query.To,
+		GroupBy: query.GroupBy,
+		Rows:    []ports.SatisfactionReportRow{},
+	}
+
+	var all []*domain.SatisfactionSurvey
+	groups := make(map[string][]*domain.SatisfactionSurvey)
+
+	for i := range tasks {
+		task := &tasks[i]
+		survey := task.Satisfaction
+		if survey == nil {
+			continue
+		}
+
+		if query.OperatorID != "" && surveyOperator(task) != query.OperatorID {
+			continue
+		}
+		if inPeriod(survey.SentAt, query.From, query.To) {
+			report.SurveysSent++
+		}
+		if !survey.IsAnswered() || !inPeriod(*survey.RespondedAt, query.From, query.To) {
+			continue
+		}
+
+		key := satisfactionGroupKey(query.GroupBy, task)
+		groups[key] = append(groups[key], survey)
+		all = append(all, survey)
+	}
+
+	for key, surveys := range groups {
+		report.Rows = append(report.Rows, ports.SatisfactionReportRow{
+			Key:                 key,
+			Name:                s.satisfactionGroupName(ctx, query.GroupBy, key),
+			SatisfactionSummary: summarizeSatisfaction(surveys),
+		})
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Key < report.Rows[j].Key
+	})
+
+	report.Total = summarizeSatisfaction(all)
+	if report.SurveysSent > 0 {
+		report.ResponseRate = float64(report.Total.Responses) / float64(report.SurveysSent) * 100
+	}
+
+	return report, nil
+}
+
+// SurveyLinks возвращает ссылки оценки задачи в один клик (оценка → URL)
+func (s *SatisfactionService) SurveyLinks(task *domain.Task, now time.Time) map[int]string {
+	token := s.signToken(task.ID, *task.CustomerID, now.Add(s.config.TokenTTL))
+
+	links := make(map[int]string, domain.MaxSatisfactionRating)
+	for rating := domain.MinSatisfactionRating; rating <= domain.MaxSatisfactionRating; rating++ {
+		links[rating] = fmt.Sprintf("%s/api/v1/public/surveys/%s?rating=%d",
+			s.config.PublicBaseURL, url.PathEscape(token), rating)
+	}
+	return links
+}
+
+// canSurvey проверяет, можно ли опросить клиента по задаче
+func (s *SatisfactionService) canSurvey(task *domain.Task) bool {
+	return s.config.Secret != "" && s.replier.sender != nil &&
+		task.Type == domain.TaskTypeSupport && task.CustomerID != nil
+}
+
+// sendSurvey отправляет письмо с опросом и отмечает отправку в задаче
+// sendSurvey сначала сохраняет отметку об отправке, затем отправляет письмо:
+// ссылки из письма должны находить опрос. Если письмо не ушло, отметка отменяется
+func (s *SatisfactionService) sendSurvey(ctx context.Context, task *domain.Task) error {
+	now := time.Now()
+	previous := task.Satisfaction
+	task.MarkSurveySent(now)
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	subject := fmt.Sprintf("Оцените решение обращения: %s", task.Subject)
+	if err := s.replier.sendWithSubject(ctx, task, subject, s.surveyBody(task, now)); err != nil {
+		s.revertSurveySent(ctx, task.ID, now, previous)
+		return fmt.Errorf("failed to send survey email: %w", err)
+	}
+
+	s.logger.Info(ctx, "satisfaction survey sent", "task_id", task.ID, "customer_id", *task.CustomerID)
+	return nil
+}
+
+// revertSurveySent отменяет отметку об отправке опроса, письмо с которым не ушло
+func (s *SatisfactionService) revertSurveySent(ctx context.Context, taskID string, sentAt time.Time, previous *domain.SatisfactionSurvey) {
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		task, err := s.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if !task.RevertSurveySent(sentAt, previous) {
+			return nil
+		}
+		return s.taskRepo.Update(ctx, task)
+	})
+	if err != nil {
+		s.logger.Warn(ctx, "failed to revert survey sent mark", "task_id", taskID, "error", err.Error())
+	}
+}
+
+// surveyBody формирует текст письма со ссылками оценки
+func (s *SatisfactionService) surveyBody(task *domain.Task, now time.Time) string {
+	links := s.SurveyLinks(task, now)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Здравствуйте!\n\nВаше обращение %s «%s» решено.\n", task.ID, task.Subject)
+	body.WriteString("Пожалуйста, оцените работу службы поддержки - перейдите по одной из ссылок и подтвердите оценку:\n\n")
+	for rating := domain.MaxSatisfactionRating; rating >= domain.MinSatisfactionRating; rating-- {
+		fmt.Fprintf(&body, "%d - %s\n%s\n\n", rating, satisfactionRatingLabel(rating), links[rating])
+	}
+	body.WriteString("Комментарий к оценке можно оставить при подтверждении.\n\nСпасибо!\nСлужба поддержки")
+	return body.String()
+}
+
+// signToken формирует токен ссылки: данные и HMAC-SHA256 подпись в base64url
+func (s *SatisfactionService) signToken(taskID, customerID string, expiresAt time.Time) string {
+	payload := strings.Join([]string{taskID, customerID, strconv.FormatInt(expiresAt.Unix(), 10)}, "|")
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded))
+}
+
+// verifyToken проверяет подпись и срок действия токена
+func (s *SatisfactionService) verifyToken(token string, now time.Time) (taskID, customerID string, err error) {
+	if s.config.Secret == "" {
+		return "", "", domain.ErrInvalidSurveyToken
+	}
+
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return "", "", domain.ErrInvalidSurveyToken
+	}
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.sign(encoded)) {
+		return "", "", domain.ErrInvalidSurveyToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", domain.ErrInvalidSurveyToken
+	}
+	parts := strings.Split(string(payload), "|")
+	if len(parts) != 3 {
+		return "", "", domain.ErrInvalidSurveyToken
+	}
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || now.Unix() > expiresAt {
+		return "", "", domain.ErrInvalidSurveyToken
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func (s *SatisfactionService) sign(data string) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.Secret))
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// satisfactionGroupName возвращает название группы отчета
+func (s *SatisfactionService) satisfactionGroupName(ctx context.Context, groupBy ports.SatisfactionGroupBy, key string) string {
+	switch groupBy {
+	case ports.SatisfactionByOperator:
+		if user, err := s.userRepo.FindByID(ctx, key); err == nil {
+			return user.Name
+		}
+	case ports.SatisfactionByCustomer:
+		if customer, err := s.customerRepo.FindByID(ctx, key); err == nil {
+			return customer.Name
+		}
+	}
+	return key
+}
+
+// satisfactionGroupKey возвращает ключ группы отчета для задачи
+func satisfactionGroupKey(groupBy ports.SatisfactionGroupBy, task *domain.Task) string {
+	respondedAt := *task.Satisfaction.RespondedAt
+
+	switch groupBy {
+	case ports.SatisfactionByOperator:
+		return surveyOperator(task)
+	case ports.SatisfactionByCustomer:
+		if task.CustomerID != nil {
+			return *task.CustomerID
+		}
+		return ""
+	case ports.SatisfactionByCategory:
+		return task.Category
+	case ports.SatisfactionByDay:
+		return respondedAt.Format(time.DateOnly)
+	case ports.SatisfactionByWeek:
+		day := time.Date(respondedAt.Year(), respondedAt.Month(), respondedAt.Day(), 0, 0, 0, 0, respondedAt.Location())
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)).Format(time.DateOnly)
+	case ports.SatisfactionByMonth:
+		return respondedAt.Format("2006-01")
+	}
+	return ""
+}
+
+// surveyOperator возвращает оператора, к которому относится оценка
+func surveyOperator(task *domain.Task) string {
+	if task.Satisfaction != nil && task.Satisfaction.OperatorID != "" {
+		return task.Satisfaction.OperatorID
+	}
+	return task.AssigneeID
+}
+
+// summarizeSatisfaction сводит отвеченные опросы; неотвеченные пропускаются
+func summarizeSatisfaction(surveys []*domain.SatisfactionSurvey) ports.SatisfactionSummary {
+	summary := ports.SatisfactionSummary{Distribution: make(map[int]int)}
+
+	total := 0
+	for _, survey := range surveys {
+		if !survey.IsAnswered() {
+			continue
+		}
+		summary.Responses++
+		summary.Distribution[survey.Rating]++
+		total += survey.Rating
+		if survey.IsPositive() {
+			summary.Positive++
+		}
+	}
+
+	if summary.Responses > 0 {
+		summary.AverageRating = float64(total) / float64(summary.Responses)
+		summary.CSAT = float64(summary.Positive) / float64(summary.Responses) * 100
+	}
+	return summary
+}
+
+// taskSatisfaction сводит оценки клиентов по задачам
+func taskSatisfaction(tasks []domain.Task) ports.SatisfactionSummary {
+	surveys := make([]*domain.SatisfactionSurvey, 0, len(tasks))
+	for i := range tasks {
+		if tasks[i].Satisfaction != nil {
+			surveys = append(surveys, tasks[i].Satisfaction)
+		}
+	}
+	return summarizeSatisfaction(surveys)
+}
+
+func satisfactionRatingLabel(rating int) string {
+	switch rating {
+	case 5:
+		return "отлично"
+	case 4:
+		return "хорошо"
+	case 3:
+		return "удовлетворительно"
+	case 2:
+		return "плохо"
+	default:
+		return "очень плохо"
+	}
+}
+
+func inPeriod(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
@@ -0,0 +1,249 @@
+// internal/core/services/satisfaction_service_test.go
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// surveyToken извлекает токен из ссылки опроса
+func surveyToken(t *testing.T, link string) string {
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	token, err := url.PathUnescape(strings.TrimPrefix(parsed.Path, "/api/v1/public/surveys/"))
+	require.NoError(t, err)
+	return token
+}
+
+// failingEmailSender имитирует недоступный почтовый сервер
+type failingEmailSender struct{}
+
+func (failingEmailSender) SendEmail(ctx context.Context, msg domain.EmailMessage) error {
+	return errors.New("smtp unavailable")
+}
+
+func TestSatisfactionService(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+	satisfactionService := services.NewSatisfactionService(taskRepo, customerRepo, userRepo, services.SurveyConfig{
+		Secret:        "test-secret",
+		PublicBaseURL: "https://support.example.com/",
+		TokenTTL:      time.Hour,
+	}, logger)
+	sender := &recordingEmailSender{}
+	satisfactionService.SetEmailSender(sender, "support@company.com")
+
+	customer, err := customerService.CreateCustomer(ctx, ports.CreateCustomerRequest{
+		Name:  "Иван Петров",
+		Email: "ivan@example.com",
+	})
+	require.NoError(t, err)
+
+	resolveTask := func(t *testing.T, category string) *domain.Task {
+		task, err := taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+			Subject:     "Не работает оплата",
+			Description: "Ошибка при оплате",
+			CustomerID:  customer.ID,
+			ReporterID:  "user-1",
+			Source:      domain.SourceEmail,
+			Category:    category,
+		})
+		require.NoError(t, err)
+		_, err = taskService.AssignTask(ctx, task.ID, "user-3", "user-1")
+		require.NoError(t, err)
+		task, err = taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusResolved, "user-3")
+		require.NoError(t, err)
+		// Опрос отправляет подписчик события смены статуса
+		require.NoError(t, satisfactionService.HandleTaskEvent(ctx, lastTaskEvents(task)[0]))
+		task, err = taskService.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("survey is sent on resolution", func(t *testing.T) {
+		sentBefore := len(sender.sent)
+		task := resolveTask(t, "billing")
+
+		require.NotNil(t, task.Satisfaction)
+		require.Len(t, sender.sent, sentBefore+1)
+		email := sender.sent[len(sender.sent)-1]
+		assert.Equal(t, []domain.EmailAddress{"ivan@example.com"}, email.To)
+		assert.Contains(t, email.BodyText, "https://support.example.com/api/v1/public/surveys/")
+		assert.Contains(t, email.BodyText, "rating=5")
+
+		// Повторная доставка события и повторное решение после переоткрытия не дублируют опрос
+		events := domain.TaskDomainEvents(task, task.History)
+		require.NoError(t, satisfactionService.HandleTaskEvent(ctx, events[len(events)-1]))
+		_, err := taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusOpen, "user-1")
+		require.NoError(t, err)
+		resolved, err := taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusResolved, "user-3")
+		require.NoError(t, err)
+		require.NoError(t, satisfactionService.HandleTaskEvent(ctx, lastTaskEvents(resolved)[0]))
+		assert.Len(t, sender.sent, sentBefore+1)
+	})
+
+	t.Run("status change does not wait for the survey email", func(t *testing.T) {
+		task, err := taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+			Subject:     "Не приходят уведомления",
+			Description: "Нет писем",
+			CustomerID:  customer.ID,
+			ReporterID:  "user-1",
+			Source:      domain.SourceEmail,
+			Category:    "notifications",
+		})
+		require.NoError(t, err)
+		sentBefore := len(sender.sent)
+
+		resolved, err := taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusResolved, "user-3")
+		require.NoError(t, err)
+		assert.Nil(t, resolved.Satisfaction)
+		assert.Len(t, sender.sent, sentBefore)
+
+		// Недоступный почтовый сервер: ошибка возвращается для повторной доставки события
+		failing := services.NewSatisfactionService(taskRepo, customerRepo, userRepo, services.SurveyConfig{
+			Secret:        "test-secret",
+			PublicBaseURL: "https://support.example.com/",
+			TokenTTL:      time.Hour,
+		}, logger)
+		failing.SetEmailSender(&failingEmailSender{}, "support@company.com")
+		event := lastTaskEvents(resolved)[0]
+		assert.Error(t, failing.HandleTaskEvent(ctx, event))
+
+		require.NoError(t, satisfactionService.HandleTaskEvent(ctx, event))
+		assert.Len(t, sender.sent, sentBefore+1)
+
+		// Задача не должна попасть в отчет следующего теста
+		require.NoError(t, taskService.DeleteTask(ctx, task.ID))
+	})
+
+	t.Run("signed link records rating", func(t *testing.T) {
+		task := resolveTask(t, "billing")
+		token := surveyToken(t, satisfactionService.SurveyLinks(task, time.Now())[5])
+
+		// Открытие ссылки (в том числе сканером почты) оценку не записывает
+		opened, err := satisfactionService.GetSurvey(ctx, token)
+		require.NoError(t, err)
+		assert.False(t, opened.Satisfaction.IsAnswered())
+
+		rated, err := satisfactionService.SubmitResponse(ctx, ports.SurveyResponseRequest{Token: token, Rating: 5})
+		require.NoError(t, err)
+		assert.Equal(t, 5, rated.Satisfaction.Rating)
+		assert.Equal(t, "user-3", rated.Satisfaction.OperatorID)
+
+		rated, err = satisfactionService.SubmitResponse(ctx, ports.SurveyResponseRequest{
+			Token: token, Rating: 4, Comment: "Хорошо, но долго",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, rated.Satisfaction.Rating)
+		assert.Equal(t, "Хорошо, но долго", rated.Satisfaction.Comment)
+	})
+
+	t.Run("tampered or expired token is rejected", func(t *testing.T) {
+		task := resolveTask(t, "billing")
+		token := surveyToken(t, satisfactionService.SurveyLinks(task, time.Now())[5])
+
+		_, err := satisfactionService.SubmitResponse(ctx, ports.SurveyResponseRequest{Token: token + "x", Rating: 5})
+		assert.ErrorIs(t, err, domain.ErrInvalidSurveyToken)
+		_, err = satisfactionService.GetSurvey(ctx, token+"x")
+		assert.ErrorIs(t, err, domain.ErrInvalidSurveyToken)
+
+		_, err = satisfactionService.SubmitResponse(ctx, ports.SurveyResponseRequest{Token: "garbage", Rating: 5})
+		assert.ErrorIs(t, err, domain.ErrInvalidSurveyToken)
+
+		expired := surveyToken(t, satisfactionService.SurveyLinks(task, time.Now().Add(-2*time.Hour))[5])
+		_, err = satisfactionService.SubmitResponse(ctx, ports.SurveyResponseRequest{Token: expired, Rating: 5})
+		assert.ErrorIs(t, err, domain.ErrInvalidSurveyToken)
+	})
+
+	t.Run("satisfaction is aggregated", func(t *testing.T) {
+		task := resolveTask(t, "access")
+		token := surveyToken(t, satisfactionService.SurveyLinks(task, time.Now())[1])
+		_, err := satisfactionService.SubmitResponse(ctx, ports.SurveyResponseRequest{Token: token, Rating: 1})
+		require.NoError(t, err)
+
+		now := time.Now()
+		report, err := satisfactionService.GetSatisfactionReport(ctx, ports.SatisfactionQuery{
+			From:    now.Add(-time.Hour),
+			To:      now.Add(time.Hour),
+			GroupBy: ports.SatisfactionByCategory,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, report.Total.Responses)
+		assert.Equal(t, 1, report.Total.Positive)
+		assert.Equal(t, 50.0, report.Total.CSAT)
+		assert.Equal(t, 2.5, report.Total.AverageRating)
+		assert.Equal(t, 4, report.SurveysSent)
+		assert.Equal(t, 50.0, report.ResponseRate)
+
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, "access", report.Rows[0].Key)
+		assert.Equal(t, 0.0, report.Rows[0].CSAT)
+		assert.Equal(t, "billing", report.Rows[1].Key)
+		assert.Equal(t, 100.0, report.Rows[1].CSAT)
+
+		byOperator, err := satisfactionService.GetSatisfactionReport(ctx, ports.SatisfactionQuery{
+			From: now.Add(-time.Hour),
+			To:   now.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, byOperator.Rows, 1)
+		assert.Equal(t, "Operator User", byOperator.Rows[0].Name)
+
+		profile, err := customerService.GetCustomerProfile(ctx, customer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 50.0, profile.Stats.Satisfaction)
+
+		dashboard, err := taskService.GetDashboard(ctx, "user-3", domain.UserRoleOperator)
+		require.NoError(t, err)
+		assert.Equal(t, 50.0, dashboard.Stats.SatisfactionRate)
+	})
+
+	t.Run("survey mark is saved before the email is sent", func(t *testing.T) {
+		task := resolveTask(t, "billing")
+		sentBefore := len(sender.sent)
+
+		// Запись отметки не удалась - письмо со ссылками не отправляется
+		staleCtx := ports.WithVersionPrecondition(ctx, task.ID, task.Version-1)
+		_, err := satisfactionService.SendSurvey(staleCtx, task.ID)
+		assert.ErrorIs(t, err, ports.ErrVersionConflict)
+		assert.Len(t, sender.sent, sentBefore)
+
+		// Письмо не ушло - отметка повторной отправки отменяется, прежние ссылки действуют
+		failing := services.NewSatisfactionService(taskRepo, customerRepo, userRepo, services.SurveyConfig{
+			Secret:        "test-secret",
+			PublicBaseURL: "https://support.example.com/",
+			TokenTTL:      time.Hour,
+		}, logger)
+		failing.SetEmailSender(&failingEmailSender{}, "support@company.com")
+		_, err = failing.SendSurvey(ctx, task.ID)
+		assert.Error(t, err)
+
+		stored, err := taskService.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.Satisfaction)
+		assert.True(t, stored.Satisfaction.SentAt.Equal(task.Satisfaction.SentAt))
+		assert.Equal(t, domain.TaskEventSurveyFailed, stored.History[len(stored.History)-1].Type)
+
+		token := surveyToken(t, satisfactionService.SurveyLinks(task, time.Now())[5])
+		_, err = satisfactionService.GetSurvey(ctx, token)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,364 @@
+// internal/core/services/scheduler_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// SchedulerService управляет поведением задач во времени. Состояние (правила,
+// запланированные ответы, сроки откладывания) хранится в репозиториях, поэтому
+// после перезапуска планировщик продолжает с того же места
+type SchedulerService struct {
+	recurringRepo ports.RecurringTaskRepository
+	replyRepo     ports.ScheduledReplyRepository
+	taskService   ports.TaskService
+	logger        ports.Logger
+	replier       emailReplier
+}
+
+func NewSchedulerService(
+	recurringRepo ports.RecurringTaskRepository,
+	replyRepo ports.ScheduledReplyRepository,
+	taskService ports.TaskService,
+	customerRepo ports.CustomerRepository,
+	logger ports.Logger,
+) *SchedulerService {
+	return &SchedulerService{
+		recurringRepo: recurringRepo,
+		replyRepo:     replyRepo,
+		taskService:   taskService,
+		logger:        logger,
+		replier:       emailReplier{customerRepo: customerRepo},
+	}
+}
+
+// SetEmailSender подключает отправку запланированных ответов клиентам по email
+func (s *SchedulerService) SetEmailSender(sender ports.EmailSender, from domain.EmailAddress) {
+	s.replier.sender = sender
+	s.replier.from = from
+}
+
+// CreateRecurringTask создает правило повторяющейся задачи
+func (s *SchedulerService) CreateRecurringTask(ctx context.Context, req ports.RecurringTaskRequest) (*domain.RecurringTask, error) {
+	rt, err := domain.NewRecurringTask(req.Name, req.Schedule, req.Timezone, req.Subject, req.Description, req.ReporterID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurring task: %w", err)
+	}
+
+	if req.Priority != "" {
+		rt.Priority = req.Priority
+	}
+	rt.Category = req.Category
+	if req.Tags != nil {
+		rt.Tags = req.Tags
+	}
+	rt.AssigneeID = req.AssigneeID
+	if req.Enabled != nil {
+		rt.Enabled = *req.Enabled
+	}
+
+	if err := s.recurringRepo.Save(ctx, rt); err != nil {
+		return nil, fmt.Errorf("failed to save recurring task: %w", err)
+	}
+
+	s.logger.Info(ctx, "recurring task created",
+		"recurring_task_id", rt.ID,
+		"schedule", rt.Schedule,
+		"next_run_at", rt.NextRunAt,
+	)
+	return rt, nil
+}
+
+// GetRecurringTask возвращает правило по ID
+func (s *SchedulerService) GetRecurringTask(ctx context.Context, id string) (*domain.RecurringTask, error) {
+	rt, err := s.recurringRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring task: %w", err)
+	}
+	return rt, nil
+}
+
+// ListRecurringTasks возвращает все правила
+func (s *SchedulerService) ListRecurringTasks(ctx context.Context) ([]domain.RecurringTask, error) {
+	rules, err := s.recurringRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring tasks: %w", err)
+	}
+	return rules, nil
+}
+
+// UpdateRecurringTask изменяет правило. Пустые поля запроса не меняются;
+// при смене расписания или включении правила следующее срабатывание пересчитывается
+func (s *SchedulerService) UpdateRecurringTask(ctx context.Context, id string, req ports.RecurringTaskRequest) (*domain.RecurringTask, error) {
+	existing, err := s.recurringRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring task: %w", err)
+	}
+
+	updated := *existing
+	reschedule := false
+
+	if req.Name != "" {
+		updated.Name = req.Name
+	}
+	if req.Schedule != "" && req.Schedule != updated.Schedule {
+		updated.Schedule = req.Schedule
+		reschedule = true
+	}
+	if req.Timezone != "" && req.Timezone != updated.Timezone {
+		updated.Timezone = req.Timezone
+		reschedule = true
+	}
+	if req.Subject != "" {
+		updated.Subject = req.Subject
+	}
+	if req.Description != "" {
+		updated.Description = req.Description
+	}
+	if req.Priority != "" {
+		updated.Priority = req.Priority
+	}
+	if req.Category != "" {
+		updated.Category = req.Category
+	}
+	if req.Tags != nil {
+		updated.Tags = req.Tags
+	}
+	if req.AssigneeID != "" {
+		updated.AssigneeID = req.AssigneeID
+	}
+	if req.Enabled != nil {
+		// Пропущенные за время отключения срабатывания не выполняются
+		if *req.Enabled && !updated.Enabled {
+			reschedule = true
+		}
+		updated.Enabled = *req.Enabled
+	}
+
+	if err := updated.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid recurring task: %w", err)
+	}
+
+	now := time.Now()
+	if reschedule {
+		if err := updated.Reschedule(now); err != nil {
+			return nil, fmt.Errorf("invalid recurring task: %w", err)
+		}
+	}
+	updated.UpdatedAt = now
+
+	if err := s.recurringRepo.Update(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update recurring task: %w", err)
+	}
+
+	s.logger.Info(ctx, "recurring task updated", "recurring_task_id", id, "next_run_at", updated.NextRunAt)
+	return &updated, nil
+}
+
+// DeleteRecurringTask удаляет правило. Созданные по нему задачи сохраняются
+func (s *SchedulerService) DeleteRecurringTask(ctx context.Context, id string) error {
+	if err := s.recurringRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete recurring task: %w", err)
+	}
+
+	s.logger.Info(ctx, "recurring task deleted", "recurring_task_id", id)
+	return nil
+}
+
+// ScheduleReply планирует отправку ответа по задаче
+func (s *SchedulerService) ScheduleReply(ctx context.Context, req ports.ScheduleReplyRequest) (*domain.ScheduledReply, error) {
+	if _, err := s.taskService.GetTask(ctx, req.TaskID); err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	reply, err := domain.NewScheduledReply(req.TaskID, req.AuthorID, req.Content, req.IsPrivate, req.SendAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduled reply: %w", err)
+	}
+
+	if err := s.replyRepo.Save(ctx, reply); err != nil {
+		return nil, fmt.Errorf("failed to save scheduled reply: %w", err)
+	}
+
+	s.logger.Info(ctx, "reply scheduled",
+		"scheduled_reply_id", reply.ID,
+		"task_id", reply.TaskID,
+		"send_at", reply.SendAt,
+	)
+	return reply, nil
+}
+
+// GetScheduledReplies возвращает запланированные ответы задачи в порядке отправки
+func (s *SchedulerService) GetScheduledReplies(ctx context.Context, taskID string) ([]domain.ScheduledReply, error) {
+	replies, err := s.replyRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled replies: %w", err)
+	}
+
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].SendAt.Before(replies[j].SendAt)
+	})
+	return replies, nil
+}
+
+// CancelScheduledReply отменяет еще не отправленный ответ
+func (s *SchedulerService) CancelScheduledReply(ctx context.Context, id string) (*domain.ScheduledReply, error) {
+	reply, err := s.replyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find scheduled reply: %w", err)
+	}
+
+	if err := reply.Cancel(); err != nil {
+		return nil, err
+	}
+
+	if err := s.replyRepo.Update(ctx, reply); err != nil {
+		return nil, fmt.Errorf("failed to update scheduled reply: %w", err)
+	}
+
+	s.logger.Info(ctx, "scheduled reply cancelled", "scheduled_reply_id", id)
+	return reply, nil
+}
+
+// RunDueJobs возвращает в работу отложенные задачи, создает задачи по наступившим
+// правилам и отправляет запланированные ответы. Ошибки отдельных действий
+// не прерывают проход и возвращаются в результате
+func (s *SchedulerService) RunDueJobs(ctx context.Context, now time.Time) (*ports.SchedulerRunResult, error) {
+	result := &ports.SchedulerRunResult{
+		WokenTasks:   []string{},
+		CreatedTasks: []string{},
+		SentReplies:  []string{},
+		Errors:       []string{},
+	}
+
+	woken, err := s.taskService.WakeSnoozedTasks(ctx, now)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	} else {
+		result.WokenTasks = woken
+	}
+
+	rules, err := s.recurringRepo.FindDue(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due recurring tasks: %w", err)
+	}
+	for i := range rules {
+		taskID, err := s.runRecurringTask(ctx, &rules[i], now)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		result.CreatedTasks = append(result.CreatedTasks, taskID)
+	}
+
+	replies, err := s.replyRepo.FindDue(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due scheduled replies: %w", err)
+	}
+	for i := range replies {
+		if err := s.sendScheduledReply(ctx, &replies[i], now); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		result.SentReplies = append(result.SentReplies, replies[i].ID)
+	}
+
+	if len(result.WokenTasks)+len(result.CreatedTasks)+len(result.SentReplies)+len(result.Errors) > 0 {
+		s.logger.Info(ctx, "scheduler run completed",
+			"woken_tasks", len(result.WokenTasks),
+			"created_tasks", len(result.CreatedTasks),
+			"sent_replies", len(result.SentReplies),
+			"errors", len(result.Errors),
+		)
+	}
+
+	return result, nil
+}
+
+// runRecurringTask создает внутреннюю задачу по правилу и переносит следующее срабатывание.
+// Срабатывание переносится и при ошибке, чтобы неисправное правило не повторялось на каждом проходе
+func (s *SchedulerService) runRecurringTask(ctx context.Context, rt *domain.RecurringTask, now time.Time) (string, error) {
+	task, err := s.taskService.CreateInternalTask(ctx, ports.CreateInternalTaskRequest{
+		Subject:     rt.Subject,
+		Description: rt.Description,
+		ReporterID:  rt.ReporterID,
+		Priority:    rt.Priority,
+		Category:    rt.Category,
+		Tags:        rt.Tags,
+		SourceMeta:  map[string]interface{}{"recurring_task_id": rt.ID},
+	})
+	if err != nil {
+		s.logger.Error(ctx, "failed to create recurring task instance",
+			"recurring_task_id", rt.ID, "error", err.Error())
+		if rescheduleErr := rt.Reschedule(now); rescheduleErr == nil {
+			rt.UpdatedAt = now
+			if updateErr := s.recurringRepo.Update(ctx, rt); updateErr != nil {
+				s.logger.Error(ctx, "failed to reschedule recurring task",
+					"recurring_task_id", rt.ID, "error", updateErr.Error())
+			}
+		}
+		return "", fmt.Errorf("recurring task %s: %w", rt.ID, err)
+	}
+
+	if rt.AssigneeID != "" {
+		if _, err := s.taskService.AssignTask(ctx, task.ID, rt.AssigneeID, "system"); err != nil {
+			s.logger.Warn(ctx, "failed to assign recurring task instance",
+				"recurring_task_id", rt.ID, "task_id", task.ID, "error", err.Error())
+		}
+	}
+
+	if err := rt.MarkRun(task.ID, now); err != nil {
+		return "", fmt.Errorf("recurring task %s: %w", rt.ID, err)
+	}
+	if err := s.recurringRepo.Update(ctx, rt); err != nil {
+		return "", fmt.Errorf("failed to update recurring task %s: %w", rt.ID, err)
+	}
+
+	s.logger.Info(ctx, "recurring task instance created",
+		"recurring_task_id", rt.ID,
+		"task_id", task.ID,
+		"next_run_at", rt.NextRunAt,
+	)
+	return task.ID, nil
+}
+
+// sendScheduledReply добавляет ответ в задачу и, для публичных ответов на email-задачи,
+// отправляет его клиенту письмом
+func (s *SchedulerService) sendScheduledReply(ctx context.Context, reply *domain.ScheduledReply, now time.Time) error {
+	task, err := s.taskService.AddMessage(ctx, reply.TaskID, ports.AddMessageRequest{
+		AuthorID:  reply.AuthorID,
+		Content:   reply.Content,
+		IsPrivate: reply.IsPrivate,
+	})
+	if err != nil {
+		reply.MarkFailed(err, now)
+		if updateErr := s.replyRepo.Update(ctx, reply); updateErr != nil {
+			s.logger.Error(ctx, "failed to update scheduled reply",
+				"scheduled_reply_id", reply.ID, "error", updateErr.Error())
+		}
+		return fmt.Errorf("scheduled reply %s: %w", reply.ID, err)
+	}
+
+	reply.MarkSent(now)
+	if !reply.IsPrivate && s.replier.canSend(task) {
+		if err := s.replier.send(ctx, task, reply.Content); err != nil {
+			// Ответ уже сохранен в задаче - фиксируем только ошибку доставки
+			s.logger.Error(ctx, "failed to send scheduled reply by email",
+				"scheduled_reply_id", reply.ID, "task_id", task.ID, "error", err.Error())
+			reply.Error = fmt.Sprintf("email delivery failed: %s", err.Error())
+		}
+	}
+
+	if err := s.replyRepo.Update(ctx, reply); err != nil {
+		return fmt.Errorf("failed to update scheduled reply %s: %w", reply.ID, err)
+	}
+
+	s.logger.Info(ctx, "scheduled reply sent", "scheduled_reply_id", reply.ID, "task_id", reply.TaskID)
+	return nil
+}
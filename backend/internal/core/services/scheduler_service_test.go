@@ -0,0 +1,215 @@
+// internal/core/services/scheduler_service_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerService(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+	recurringRepo := inmemory.NewRecurringTaskRepository(logger)
+	replyRepo := inmemory.NewScheduledReplyRepository(logger)
+	schedulerService := services.NewSchedulerService(recurringRepo, replyRepo, taskService, customerRepo, logger)
+	sender := &recordingEmailSender{}
+	schedulerService.SetEmailSender(sender, "support@company.com")
+
+	customer, err := customerService.CreateCustomer(ctx, ports.CreateCustomerRequest{
+		Name:  "Иван Петров",
+		Email: "ivan@example.com",
+	})
+	require.NoError(t, err)
+
+	newSupportTask := func(t *testing.T) *domain.Task {
+		task, err := taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+			Subject:     "Не работает оплата",
+			Description: "Ошибка при оплате",
+			CustomerID:  customer.ID,
+			ReporterID:  "user-1",
+			Source:      domain.SourceEmail,
+			SourceMeta:  map[string]interface{}{"message_id": "<original@example.com>"},
+		})
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("snoozed task leaves queue and wakes up", func(t *testing.T) {
+		task := newSupportTask(t)
+		until := time.Now().Add(time.Hour)
+
+		_, err := taskService.SnoozeTask(ctx, task.ID, until, "user-2")
+		require.NoError(t, err)
+
+		open, err := taskRepo.FindOpenTasks(ctx)
+		require.NoError(t, err)
+		for _, openTask := range open {
+			assert.NotEqual(t, task.ID, openTask.ID)
+		}
+
+		result, err := schedulerService.RunDueJobs(ctx, time.Now())
+		require.NoError(t, err)
+		assert.NotContains(t, result.WokenTasks, task.ID)
+
+		result, err = schedulerService.RunDueJobs(ctx, until.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Contains(t, result.WokenTasks, task.ID)
+
+		woken, err := taskService.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, woken.SnoozedUntil)
+	})
+
+	t.Run("customer reply wakes task early", func(t *testing.T) {
+		task := newSupportTask(t)
+		_, err := taskService.SnoozeTask(ctx, task.ID, time.Now().Add(24*time.Hour), "user-2")
+		require.NoError(t, err)
+
+		updated, err := taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+			AuthorID: customer.ID,
+			Content:  "Есть новости?",
+			Type:     domain.MessageTypeCustomer,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, updated.SnoozedUntil)
+	})
+
+	t.Run("recurring task creates internal task", func(t *testing.T) {
+		rt, err := schedulerService.CreateRecurringTask(ctx, ports.RecurringTaskRequest{
+			Name:        "Проверка бэкапов",
+			Schedule:    "0 9 1 * *",
+			Timezone:    "Europe/Moscow",
+			Subject:     "Проверить резервные копии",
+			Description: "Ежемесячная проверка восстановления из бэкапа",
+			Priority:    domain.PriorityHigh,
+			Tags:        []string{"backup"},
+			AssigneeID:  "user-3",
+			ReporterID:  "user-1",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, rt.NextRunAt)
+
+		runAt := rt.NextRunAt.Add(time.Minute)
+		result, err := schedulerService.RunDueJobs(ctx, runAt)
+		require.NoError(t, err)
+		require.Len(t, result.CreatedTasks, 1)
+		assert.Empty(t, result.Errors)
+
+		created, err := taskService.GetTask(ctx, result.CreatedTasks[0])
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskTypeInternal, created.Type)
+		assert.Equal(t, "Проверить резервные копии", created.Subject)
+		assert.Equal(t, domain.PriorityHigh, created.Priority)
+		assert.Equal(t, "user-3", created.AssigneeID)
+		assert.Equal(t, rt.ID, created.SourceMeta["recurring_task_id"])
+
+		stored, err := schedulerService.GetRecurringTask(ctx, rt.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, stored.LastTaskID)
+		assert.True(t, stored.NextRunAt.After(runAt))
+
+		// Повторный проход в тот же момент не создает дубликат
+		result, err = schedulerService.RunDueJobs(ctx, runAt)
+		require.NoError(t, err)
+		assert.Empty(t, result.CreatedTasks)
+	})
+
+	t.Run("disabled recurring task is skipped", func(t *testing.T) {
+		enabled := false
+		rt, err := schedulerService.CreateRecurringTask(ctx, ports.RecurringTaskRequest{
+			Name:        "Отчет",
+			Schedule:    "@hourly",
+			Subject:     "Отчет",
+			Description: "Почасовой отчет",
+			ReporterID:  "user-1",
+			Enabled:     &enabled,
+		})
+		require.NoError(t, err)
+
+		result, err := schedulerService.RunDueJobs(ctx, rt.NextRunAt.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, result.CreatedTasks)
+
+		_, err = schedulerService.UpdateRecurringTask(ctx, rt.ID, ports.RecurringTaskRequest{Schedule: "not a schedule"})
+		assert.Error(t, err)
+	})
+
+	t.Run("scheduled reply is sent to customer", func(t *testing.T) {
+		task := newSupportTask(t)
+		sendAt := time.Now().Add(time.Hour)
+
+		reply, err := schedulerService.ScheduleReply(ctx, ports.ScheduleReplyRequest{
+			TaskID:   task.ID,
+			AuthorID: "user-2",
+			Content:  "Проблема решена, проверьте, пожалуйста",
+			SendAt:   sendAt,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScheduledReplyPending, reply.Status)
+
+		sentBefore := len(sender.sent)
+		result, err := schedulerService.RunDueJobs(ctx, sendAt.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Contains(t, result.SentReplies, reply.ID)
+		require.Len(t, sender.sent, sentBefore+1)
+		assert.Equal(t, "<original@example.com>", sender.sent[len(sender.sent)-1].InReplyTo)
+
+		replies, err := schedulerService.GetScheduledReplies(ctx, task.ID)
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		assert.Equal(t, domain.ScheduledReplySent, replies[0].Status)
+
+		updated, err := taskService.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Проблема решена, проверьте, пожалуйста", updated.Messages[len(updated.Messages)-1].Content)
+	})
+
+	t.Run("cancelled reply is not sent", func(t *testing.T) {
+		task := newSupportTask(t)
+		sendAt := time.Now().Add(time.Hour)
+
+		reply, err := schedulerService.ScheduleReply(ctx, ports.ScheduleReplyRequest{
+			TaskID:    task.ID,
+			AuthorID:  "user-2",
+			Content:   "Напоминание",
+			IsPrivate: true,
+			SendAt:    sendAt,
+		})
+		require.NoError(t, err)
+
+		cancelled, err := schedulerService.CancelScheduledReply(ctx, reply.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScheduledReplyCancelled, cancelled.Status)
+
+		_, err = schedulerService.CancelScheduledReply(ctx, reply.ID)
+		assert.Error(t, err)
+
+		result, err := schedulerService.RunDueJobs(ctx, sendAt.Add(time.Minute))
+		require.NoError(t, err)
+		assert.NotContains(t, result.SentReplies, reply.ID)
+	})
+
+	t.Run("reply for unknown task is rejected", func(t *testing.T) {
+		_, err := schedulerService.ScheduleReply(ctx, ports.ScheduleReplyRequest{
+			TaskID:   "TASK-unknown",
+			AuthorID: "user-2",
+			Content:  "Ответ",
+			SendAt:   time.Now().Add(time.Hour),
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,241 @@
+// internal/core/services/search_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// Размер страницы результатов поиска
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// SearchService выполняет полнотекстовый поиск и переиндексацию
+type SearchService struct {
+	index        ports.SearchIndex
+	taskRepo     ports.TaskRepository
+	customerRepo ports.CustomerRepository
+	emailRepo    ports.EmailRepository
+	logger       ports.Logger
+}
+
+func NewSearchService(
+	index ports.SearchIndex,
+	taskRepo ports.TaskRepository,
+	customerRepo ports.CustomerRepository,
+	emailRepo ports.EmailRepository,
+	logger ports.Logger,
+) *SearchService {
+	return &SearchService{
+		index:        index,
+		taskRepo:     taskRepo,
+		customerRepo: customerRepo,
+		emailRepo:    emailRepo,
+		logger:       logger,
+	}
+}
+
+// Search проверяет запрос и ищет по индексу
+func (s *SearchService) Search(ctx context.Context, query ports.SearchQuery) (*ports.SearchResult, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		return nil, errors.New("search text is required")
+	}
+	for _, entityType := range query.EntityTypes {
+		if !entityType.IsValid() {
+			return nil, fmt.Errorf("invalid search entity type: %s", entityType)
+		}
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchPageSize
+	}
+	query.Limit = min(query.Limit, maxSearchPageSize)
+	query.Offset = max(query.Offset, 0)
+
+	result, err := s.index.Search(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	return result, nil
+}
+
+// Reindex строит индекс заново по данным хранилищ, например после включения
+// поиска на существующей базе
+func (s *SearchService) Reindex(ctx context.Context) (int, error) {
+	var docs []domain.SearchDocument
+
+	tasks, err := s.taskRepo.FindByQuery(ctx, ports.TaskQuery{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load tasks: %w", err)
+	}
+	for i := range tasks {
+		docs = append(docs, domain.NewTaskSearchDocument(&tasks[i]))
+	}
+
+	customers, err := s.customerRepo.FindByQuery(ctx, ports.CustomerQuery{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load customers: %w", err)
+	}
+	for i := range customers {
+		docs = append(docs, domain.NewCustomerSearchDocument(&customers[i]))
+	}
+
+	emails, err := s.emailRepo.FindByQuery(ctx, ports.EmailQuery{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load emails: %w", err)
+	}
+	for i := range emails.Emails {
+		docs = append(docs, domain.NewEmailSearchDocument(&emails.Emails[i]))
+	}
+
+	if err := s.index.Index(ctx, docs...); err != nil {
+		return 0, fmt.Errorf("failed to index documents: %w", err)
+	}
+
+	s.logger.Info(ctx, "search index rebuilt",
+		"tasks", len(tasks), "customers", len(customers), "emails", len(emails.Emails))
+	return len(docs), nil
+}
+
+// IndexedTaskRepository обновляет поисковый индекс при сохранении и удалении задач.
+// Ошибка индекса не отменяет уже сохраненное изменение и только логируется
+type IndexedTaskRepository struct {
+	ports.TaskRepository
+	index  ports.SearchIndex
+	logger ports.Logger
+}
+
+func NewIndexedTaskRepository(repo ports.TaskRepository, index ports.SearchIndex, logger ports.Logger) *IndexedTaskRepository {
+	return &IndexedTaskRepository{TaskRepository: repo, index: index, logger: logger}
+}
+
+func (r *IndexedTaskRepository) Save(ctx context.Context, task *domain.Task) error {
+	if err := r.TaskRepository.Save(ctx, task); err != nil {
+		return err
+	}
+	indexDocument(ctx, r.index, r.logger, domain.NewTaskSearchDocument(task))
+	return nil
+}
+
+func (r *IndexedTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	if err := r.TaskRepository.Update(ctx, task); err != nil {
+		return err
+	}
+	indexDocument(ctx, r.index, r.logger, domain.NewTaskSearchDocument(task))
+	return nil
+}
+
+func (r *IndexedTaskRepository) Delete(ctx context.Context, id string) error {
+	if err := r.TaskRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	removeDocument(ctx, r.index, r.logger, domain.SearchEntityTask, id)
+	return nil
+}
+
+// BulkUpdateStatus переиндексирует задачи, чтобы фильтр и фасеты статуса остались верными
+func (r *IndexedTaskRepository) BulkUpdateStatus(ctx context.Context, taskIDs []string, status domain.TaskStatus) error {
+	if err := r.TaskRepository.BulkUpdateStatus(ctx, taskIDs, status); err != nil {
+		return err
+	}
+	for _, id := range taskIDs {
+		task, err := r.TaskRepository.FindByID(ctx, id)
+		if err != nil {
+			r.logger.Warn(ctx, "failed to reload task for search index", "task_id", id, "error", err.Error())
+			continue
+		}
+		indexDocument(ctx, r.index, r.logger, domain.NewTaskSearchDocument(task))
+	}
+	return nil
+}
+
+// IndexedCustomerRepository обновляет поисковый индекс при изменении клиентов
+type IndexedCustomerRepository struct {
+	ports.CustomerRepository
+	index  ports.SearchIndex
+	logger ports.Logger
+}
+
+func NewIndexedCustomerRepository(repo ports.CustomerRepository, index ports.SearchIndex, logger ports.Logger) *IndexedCustomerRepository {
+	return &IndexedCustomerRepository{CustomerRepository: repo, index: index, logger: logger}
+}
+
+func (r *IndexedCustomerRepository) Save(ctx context.Context, customer *domain.Customer) error {
+	if err := r.CustomerRepository.Save(ctx, customer); err != nil {
+		return err
+	}
+	indexDocument(ctx, r.index, r.logger, domain.NewCustomerSearchDocument(customer))
+	return nil
+}
+
+func (r *IndexedCustomerRepository) Update(ctx context.Context, customer *domain.Customer) error {
+	if err := r.CustomerRepository.Update(ctx, customer); err != nil {
+		return err
+	}
+	indexDocument(ctx, r.index, r.logger, domain.NewCustomerSearchDocument(customer))
+	return nil
+}
+
+func (r *IndexedCustomerRepository) Delete(ctx context.Context, id string) error {
+	if err := r.CustomerRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	removeDocument(ctx, r.index, r.logger, domain.SearchEntityCustomer, id)
+	return nil
+}
+
+// IndexedEmailRepository обновляет поисковый индекс при сохранении и удалении писем
+type IndexedEmailRepository struct {
+	ports.EmailRepository
+	index  ports.SearchIndex
+	logger ports.Logger
+}
+
+func NewIndexedEmailRepository(repo ports.EmailRepository, index ports.SearchIndex, logger ports.Logger) *IndexedEmailRepository {
+	return &IndexedEmailRepository{EmailRepository: repo, index: index, logger: logger}
+}
+
+func (r *IndexedEmailRepository) Save(ctx context.Context, msg *domain.EmailMessage) error {
+	if err := r.EmailRepository.Save(ctx, msg); err != nil {
+		return err
+	}
+	indexDocument(ctx, r.index, r.logger, domain.NewEmailSearchDocument(msg))
+	return nil
+}
+
+func (r *IndexedEmailRepository) Update(ctx context.Context, msg *domain.EmailMessage) error {
+	if err := r.EmailRepository.Update(ctx, msg); err != nil {
+		return err
+	}
+	indexDocument(ctx, r.index, r.logger, domain.NewEmailSearchDocument(msg))
+	return nil
+}
+
+func (r *IndexedEmailRepository) Delete(ctx context.Context, id domain.MessageID) error {
+	if err := r.EmailRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	removeDocument(ctx, r.index, r.logger, domain.SearchEntityEmail, string(id))
+	return nil
+}
+
+func indexDocument(ctx context.Context, index ports.SearchIndex, logger ports.Logger, doc domain.SearchDocument) {
+	if err := index.Index(ctx, doc); err != nil {
+		logger.Error(ctx, "failed to index search document",
+			"entity_type", doc.EntityType, "entity_id", doc.EntityID, "error", err.Error())
+	}
+}
+
+func removeDocument(ctx context.Context, index ports.SearchIndex, logger ports.Logger, entityType domain.SearchEntityType, id string) {
+	if err := index.Remove(ctx, entityType, id); err != nil {
+		logger.Error(ctx, "failed to remove search document",
+			"entity_type", entityType, "entity_id", id, "error", err.Error())
+	}
+}
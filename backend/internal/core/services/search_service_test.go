@@ -0,0 +1,220 @@
+// internal/core/services/search_service_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	emailinmemory "github.com/audetv/urms/internal/infrastructure/persistence/email/inmemory"
+	searchinmemory "github.com/audetv/urms/internal/infrastructure/persistence/search/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchFixture struct {
+	taskService  ports.TaskService
+	customerRepo ports.CustomerRepository
+	emailRepo    ports.EmailRepository
+	search       ports.SearchService
+	index        ports.SearchIndex
+}
+
+func newSearchFixture(t *testing.T) *searchFixture {
+	t.Helper()
+	logger := &services.MockLogger{}
+	index := searchinmemory.NewSearchIndex(logger)
+
+	taskRepo := services.NewIndexedTaskRepository(inmemory.NewTaskRepository(logger), index, logger)
+	customerRepo := services.NewIndexedCustomerRepository(inmemory.NewCustomerRepository(logger), index, logger)
+	emailRepo := services.NewIndexedEmailRepository(emailinmemory.NewInMemoryEmailRepo(), index, logger)
+	userRepo := inmemory.NewUserRepository(logger)
+	authorizer := services.NewRoleAuthorizer(logger)
+
+	return &searchFixture{
+		taskService:  services.NewTaskService(taskRepo, customerRepo, userRepo, logger),
+		customerRepo: customerRepo,
+		emailRepo:    emailRepo,
+		search: services.NewAuthorizedSearchService(
+			services.NewSearchService(index, taskRepo, customerRepo, emailRepo, logger),
+			authorizer,
+		),
+		index: index,
+	}
+}
+
+func (f *searchFixture) createTask(t *testing.T, subject, description, category string, priority domain.Priority) *domain.Task {
+	t.Helper()
+	task, err := f.taskService.CreateInternalTask(ports.WithSystemPrincipal(context.Background()), ports.CreateInternalTaskRequest{
+		Subject:     subject,
+		Description: description,
+		ReporterID:  "user-reporter",
+		Priority:    priority,
+		Category:    category,
+	})
+	require.NoError(t, err)
+	return task
+}
+
+func hitIDs(result *ports.SearchResult) []string {
+	ids := make([]string, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.EntityID
+	}
+	return ids
+}
+
+func TestSearchService_IndexesAllEntityTypes(t *testing.T) {
+	f := newSearchFixture(t)
+	ctx := ports.WithSystemPrincipal(context.Background())
+
+	task := f.createTask(t, "Не работает принтер", "Принтер на третьем этаже не печатает", "hardware", domain.PriorityHigh)
+	require.NoError(t, f.customerRepo.Save(ctx, &domain.Customer{
+		ID:           "cust-1",
+		Name:         "Иван Принтеров",
+		Email:        "ivan@example.com",
+		Organization: &domain.Organization{ID: "org-1", Name: "Acme"},
+		UpdatedAt:    time.Now(),
+	}))
+	require.NoError(t, f.emailRepo.Save(ctx, &domain.EmailMessage{
+		ID:          "email-1",
+		Subject:     "Счет за март",
+		From:        "billing@example.com",
+		BodyHTML:    "<p>Во вложении <b>счет</b></p>",
+		Attachments: []domain.Attachment{{Name: "invoice-printer.pdf"}},
+		UpdatedAt:   time.Now(),
+	}))
+
+	result, err := f.search.Search(ctx, ports.SearchQuery{Text: "принтер"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{task.ID, "cust-1"}, hitIDs(result), "title match ranks first")
+
+	result, err = f.search.Search(ctx, ports.SearchQuery{Text: "invoice"})
+	require.NoError(t, err)
+	require.Len(t, result.Hits, 1, "attachment names are indexed")
+	assert.Equal(t, domain.SearchEntityEmail, result.Hits[0].EntityType)
+
+	result, err = f.search.Search(ctx, ports.SearchQuery{Text: "acme"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cust-1"}, hitIDs(result))
+
+	result, err = f.search.Search(ctx, ports.SearchQuery{Text: "третьем этаже"})
+	require.NoError(t, err)
+	require.Len(t, result.Hits, 1)
+	assert.Equal(t, "Принтер на <mark>третьем</mark> <mark>этаже</mark> не печатает", result.Hits[0].Snippet)
+}
+
+func TestSearchService_UpdatesIndexOnChanges(t *testing.T) {
+	f := newSearchFixture(t)
+	ctx := ports.WithSystemPrincipal(context.Background())
+
+	task := f.createTask(t, "Ошибка входа", "Не принимает пароль", "access", domain.PriorityMedium)
+
+	_, err := f.taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+		AuthorID: "user-reporter",
+		Content:  "Помог сброс кеша браузера",
+	})
+	require.NoError(t, err)
+
+	result, err := f.search.Search(ctx, ports.SearchQuery{Text: "кеша"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{task.ID}, hitIDs(result), "new messages are indexed")
+
+	require.NoError(t, f.taskService.DeleteTask(ctx, task.ID))
+	result, err = f.search.Search(ctx, ports.SearchQuery{Text: "кеша"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Hits, "deleted tasks are removed from the index")
+}
+
+func TestSearchService_FacetsIgnoreAttributeFilters(t *testing.T) {
+	f := newSearchFixture(t)
+	ctx := ports.WithSystemPrincipal(context.Background())
+
+	high := f.createTask(t, "Сервер недоступен", "Описание", "network", domain.PriorityHigh)
+	f.createTask(t, "Сервер медленно отвечает", "Описание", "network", domain.PriorityLow)
+	f.createTask(t, "Сервер почты", "Описание", "mail", domain.PriorityLow)
+
+	result, err := f.search.Search(ctx, ports.SearchQuery{Text: "сервер", Priorities: []string{"high"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{high.ID}, hitIDs(result))
+	assert.Equal(t, 1, result.TotalCount)
+	assert.Equal(t, map[string]int{"high": 1, "low": 2}, result.Facets.Priorities)
+	assert.Equal(t, map[string]int{"network": 2, "mail": 1}, result.Facets.Categories)
+	assert.Equal(t, map[string]int{"task": 3}, result.Facets.EntityTypes)
+
+	result, err = f.search.Search(ctx, ports.SearchQuery{Text: "сервер", Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.TotalCount)
+	assert.Len(t, result.Hits, 1)
+}
+
+func TestSearchService_Permissions(t *testing.T) {
+	f := newSearchFixture(t)
+	ctx := ports.WithSystemPrincipal(context.Background())
+
+	visible := f.createTask(t, "Сбой VPN", "Описание", "network", domain.PriorityMedium)
+	hidden := f.createTask(t, "Сбой VPN у бухгалтерии", "Описание", "finance", domain.PriorityMedium)
+	_, err := f.taskService.AddInternalNote(ctx, visible.ID, "user-reporter", "Секретный ключ доступа")
+	require.NoError(t, err)
+	require.NoError(t, f.emailRepo.Save(ctx, &domain.EmailMessage{
+		ID: "email-1", Subject: "Сбой VPN", From: "user@example.com", UpdatedAt: time.Now(),
+	}))
+
+	t.Run("operator sees tasks of own categories only", func(t *testing.T) {
+		result, err := f.search.Search(asUser(domain.UserRoleOperator, "network"), ports.SearchQuery{Text: "vpn"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{visible.ID}, hitIDs(result))
+		assert.NotContains(t, hitIDs(result), hidden.ID)
+		assert.Equal(t, map[string]int{"task": 1}, result.Facets.EntityTypes, "emails require tasks:all")
+	})
+
+	t.Run("operator cannot request emails explicitly", func(t *testing.T) {
+		_, err := f.search.Search(asUser(domain.UserRoleOperator, "network"), ports.SearchQuery{
+			Text:        "vpn",
+			EntityTypes: []domain.SearchEntityType{domain.SearchEntityEmail},
+		})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("internal notes are searched with messages:private only", func(t *testing.T) {
+		result, err := f.search.Search(asUser(domain.UserRoleOperator, "network"), ports.SearchQuery{Text: "секретный"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{visible.ID}, hitIDs(result))
+
+		result, err = f.search.Search(asUser(domain.UserRoleViewer), ports.SearchQuery{Text: "секретный"})
+		require.NoError(t, err)
+		assert.Empty(t, result.Hits)
+	})
+
+	t.Run("reindex requires settings:manage", func(t *testing.T) {
+		_, err := f.search.Reindex(asUser(domain.UserRoleOperator))
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+}
+
+func TestSearchService_Reindex(t *testing.T) {
+	f := newSearchFixture(t)
+	ctx := ports.WithSystemPrincipal(context.Background())
+
+	task := f.createTask(t, "Обновить сертификат", "Описание", "security", domain.PriorityMedium)
+	require.NoError(t, f.index.Remove(ctx, domain.SearchEntityTask, task.ID))
+
+	result, err := f.search.Search(ctx, ports.SearchQuery{Text: "сертификат"})
+	require.NoError(t, err)
+	require.Empty(t, result.Hits)
+
+	count, err := f.search.Reindex(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	result, err = f.search.Search(ctx, ports.SearchQuery{Text: "сертификат"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{task.ID}, hitIDs(result))
+
+	_, err = f.search.Search(ctx, ports.SearchQuery{Text: "  "})
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+// internal/core/services/task_custom_fields.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// SetCustomFieldRepository подключает описания пользовательских полей.
+// Без репозитория задачи не принимают пользовательские поля
+func (s *TaskService) SetCustomFieldRepository(repo ports.CustomFieldRepository) {
+	s.customFieldRepo = repo
+}
+
+// resolveCustomFields проверяет значения пользовательских полей и возвращает
+// нормализованные изменения (nil - очистка поля). При создании задачи
+// подставляются значения по умолчанию и проверяются обязательные поля
+func (s *TaskService) resolveCustomFields(
+	ctx context.Context,
+	taskType domain.TaskType,
+	category string,
+	source domain.TaskSource,
+	current map[string]interface{},
+	values map[string]interface{},
+	isCreate bool,
+) (map[string]interface{}, error) {
+	if s.customFieldRepo == nil {
+		if len(values) > 0 {
+			return nil, errors.New("custom fields are not configured")
+		}
+		return nil, nil
+	}
+
+	definitions, err := s.customFieldRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load custom fields: %w", err)
+	}
+
+	applicable := make(map[string]domain.CustomFieldDefinition)
+	for _, def := range definitions {
+		if def.AppliesTo(taskType, category) {
+			applicable[def.Key] = def
+		}
+	}
+
+	changes := make(map[string]interface{})
+	for key, value := range values {
+		def, ok := applicable[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %q for task type %s and category %q", key, taskType, category)
+		}
+
+		if value == nil {
+			if def.Required {
+				return nil, fmt.Errorf("custom field %s is required", key)
+			}
+			changes[key] = nil
+			continue
+		}
+
+		normalized, err := def.NormalizeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.validateCustomFieldReference(ctx, def, normalized); err != nil {
+			return nil, err
+		}
+		changes[key] = normalized
+	}
+
+	if !isCreate {
+		return changes, nil
+	}
+
+	for key, def := range applicable {
+		if _, provided := changes[key]; provided {
+			continue
+		}
+		if _, exists := current[key]; exists {
+			continue
+		}
+		if def.DefaultValue != nil {
+			changes[key] = def.DefaultValue
+			continue
+		}
+		// Задачи из внешних каналов создаются автоматически - обязательные поля
+		// заполняет оператор при разборе задачи
+		if def.Required && !isAutomatedSource(source) {
+			return nil, fmt.Errorf("custom field %s is required", key)
+		}
+	}
+
+	return changes, nil
+}
+
+// applyCustomFieldChanges записывает проверенные значения в задачу
+func applyCustomFieldChanges(task *domain.Task, changes map[string]interface{}, userID string) {
+	for key, value := range changes {
+		task.SetCustomField(key, value, userID)
+	}
+}
+
+// validateCustomFieldReference проверяет существование пользователя или клиента
+func (s *TaskService) validateCustomFieldReference(ctx context.Context, def domain.CustomFieldDefinition, value interface{}) error {
+	id, _ := value.(string)
+
+	switch def.Type {
+	case domain.CustomFieldUser:
+		if user, err := s.userRepo.FindByID(ctx, id); err != nil || user == nil {
+			return fmt.Errorf("custom field %s: user not found: %s", def.Key, id)
+		}
+	case domain.CustomFieldCustomer:
+		if customer, err := s.customerRepo.FindByID(ctx, id); err != nil || customer == nil {
+			return fmt.Errorf("custom field %s: customer not found: %s", def.Key, id)
+		}
+	}
+
+	return nil
+}
+
+// isAutomatedSource проверяет, создается ли задача автоматически из внешнего канала
+func isAutomatedSource(source domain.TaskSource) bool {
+	switch source {
+	case domain.SourceEmail, domain.SourceTelegram, domain.SourceGitHub:
+		return true
+	default:
+		return false
+	}
+}
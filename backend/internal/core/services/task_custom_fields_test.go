@@ -0,0 +1,156 @@
+// internal/core/services/task_custom_fields_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskService_CustomFields(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+	fieldRepo := inmemory.NewCustomFieldRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	taskService.SetCustomFieldRepository(fieldRepo)
+	fieldService := services.NewCustomFieldService(fieldRepo, logger)
+
+	_, err := fieldService.CreateField(ctx, ports.CustomFieldRequest{
+		Key:      "severity",
+		Name:     "Серьезность",
+		Type:     domain.CustomFieldEnum,
+		Options:  []string{"minor", "major"},
+		Required: true,
+	})
+	require.NoError(t, err)
+
+	_, err = fieldService.CreateField(ctx, ports.CustomFieldRequest{
+		Key:          "estimate",
+		Name:         "Оценка",
+		Type:         domain.CustomFieldNumber,
+		DefaultValue: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = fieldService.CreateField(ctx, ports.CustomFieldRequest{
+		Key:        "owner",
+		Name:       "Ответственный",
+		Type:       domain.CustomFieldUser,
+		Categories: []string{"billing"},
+	})
+	require.NoError(t, err)
+
+	newTask := func(fields map[string]interface{}, category string) (*domain.Task, error) {
+		return taskService.CreateTask(ctx, ports.CreateTaskRequest{
+			Type:         domain.TaskTypeInternal,
+			Subject:      "Custom fields task",
+			Description:  "Test Description",
+			ReporterID:   "user-1",
+			Priority:     domain.PriorityMedium,
+			Category:     category,
+			CustomFields: fields,
+		})
+	}
+
+	t.Run("create validates fields and applies defaults", func(t *testing.T) {
+		task, err := newTask(map[string]interface{}{"severity": "major"}, "general")
+		require.NoError(t, err)
+		assert.Equal(t, "major", task.CustomFields["severity"])
+		assert.Equal(t, 1.0, task.CustomFields["estimate"])
+
+		_, err = newTask(nil, "general")
+		assert.Error(t, err, "required field is missing")
+
+		_, err = newTask(map[string]interface{}{"severity": "blocker"}, "general")
+		assert.Error(t, err, "value is not in enum options")
+
+		_, err = newTask(map[string]interface{}{"severity": "minor", "owner": "user-2"}, "general")
+		assert.Error(t, err, "field does not apply to category")
+
+		_, err = newTask(map[string]interface{}{"severity": "minor", "owner": "user-404"}, "billing")
+		assert.Error(t, err, "referenced user does not exist")
+	})
+
+	t.Run("update validates and clears fields", func(t *testing.T) {
+		task, err := newTask(map[string]interface{}{"severity": "minor"}, "general")
+		require.NoError(t, err)
+
+		updated, err := taskService.UpdateTask(ctx, task.ID, ports.UpdateTaskRequest{
+			CustomFields: map[string]interface{}{"estimate": "8", "severity": "major"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 8.0, updated.CustomFields["estimate"])
+		assert.Equal(t, "major", updated.CustomFields["severity"])
+
+		_, err = taskService.UpdateTask(ctx, task.ID, ports.UpdateTaskRequest{
+			CustomFields: map[string]interface{}{"severity": nil},
+		})
+		assert.Error(t, err, "required field cannot be cleared")
+
+		updated, err = taskService.UpdateTask(ctx, task.ID, ports.UpdateTaskRequest{
+			CustomFields: map[string]interface{}{"estimate": nil},
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, updated.CustomFields, "estimate")
+	})
+
+	t.Run("automated sources skip required fields", func(t *testing.T) {
+		customerID := "customer-1"
+		require.NoError(t, customerRepo.Save(ctx, &domain.Customer{ID: customerID, Email: "c@example.com"}))
+
+		task, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+			Type:        domain.TaskTypeSupport,
+			Subject:     "From email",
+			Description: "Email body",
+			CustomerID:  &customerID,
+			ReporterID:  customerID,
+			Source:      domain.SourceEmail,
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, task.CustomFields, "severity")
+	})
+
+	t.Run("filter and sort by custom field", func(t *testing.T) {
+		taskRepo := inmemory.NewTaskRepository(logger)
+		taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+		taskService.SetCustomFieldRepository(fieldRepo)
+
+		for _, estimate := range []int{5, 13, 2} {
+			_, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+				Type:         domain.TaskTypeInternal,
+				Subject:      "Sort task",
+				Description:  "Test Description",
+				ReporterID:   "user-1",
+				CustomFields: map[string]interface{}{"severity": "minor", "estimate": estimate},
+			})
+			require.NoError(t, err)
+		}
+
+		result, err := taskService.SearchTasks(ctx, ports.TaskQuery{
+			SortBy:    "custom_fields.estimate",
+			SortOrder: "desc",
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Tasks, 3)
+		assert.Equal(t, 13.0, result.Tasks[0].CustomFields["estimate"])
+		assert.Equal(t, 2.0, result.Tasks[2].CustomFields["estimate"])
+
+		result, err = taskService.SearchTasks(ctx, ports.TaskQuery{
+			CustomFields: map[string]string{"estimate": "5"},
+			Limit:        10,
+		})
+		require.NoError(t, err)
+		assert.Len(t, result.Tasks, 1)
+	})
+}
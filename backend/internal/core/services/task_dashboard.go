@@ -0,0 +1,278 @@
+// internal/core/services/task_dashboard.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// dashboardActivityLimit максимальное число событий в ленте активности
+const dashboardActivityLimit = 20
+
+// activeStatuses статусы задач, находящихся в работе
+var activeStatuses = []domain.TaskStatus{
+	domain.TaskStatusOpen,
+	domain.TaskStatusInProgress,
+	domain.TaskStatusReview,
+}
+
+// GetUserTasks возвращает задачи пользователя с учетом его роли
+func (s *TaskService) GetUserTasks(ctx context.Context, userID string, userRole domain.UserRole) (*ports.UserTasks, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	assigned, err := s.taskRepo.FindByAssigneeID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned tasks: %w", err)
+	}
+
+	reported, err := s.taskRepo.FindByQuery(ctx, ports.TaskQuery{ReporterID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reported tasks: %w", err)
+	}
+
+	involved, err := s.taskRepo.FindByParticipantID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participating tasks: %w", err)
+	}
+
+	result := &ports.UserTasks{
+		AssignedTasks:   assigned,
+		ReportedTasks:   reported,
+		ParticipatingIn: []domain.Task{},
+		Watching:        []domain.Task{},
+		UnassignedTasks: []domain.Task{},
+	}
+
+	for _, task := range involved {
+		participant := task.FindParticipant(userID)
+		switch participant.Role {
+		case domain.RoleWatcher:
+			result.Watching = append(result.Watching, task)
+		case domain.RoleParticipant, domain.RoleReviewer:
+			result.ParticipatingIn = append(result.ParticipatingIn, task)
+		}
+	}
+
+	// Администраторы и менеджеры распределяют задачи - показываем им очередь неназначенных
+	if isSupervisorRole(userRole) {
+		notSnoozed := false
+		active, err := s.taskRepo.FindByQuery(ctx, ports.TaskQuery{Statuses: activeStatuses, Snoozed: &notSnoozed})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get unassigned tasks: %w", err)
+		}
+		for _, task := range active {
+			if task.AssigneeID == "" {
+				result.UnassignedTasks = append(result.UnassignedTasks, task)
+			}
+		}
+	}
+
+	sortByUpdatedDesc(result.AssignedTasks)
+	sortByUpdatedDesc(result.ReportedTasks)
+	sortByUpdatedDesc(result.ParticipatingIn)
+	sortByUpdatedDesc(result.Watching)
+	sortByUpdatedDesc(result.UnassignedTasks)
+
+	return result, nil
+}
+
+// GetDashboard возвращает данные домашнего экрана оператора
+func (s *TaskService) GetDashboard(ctx context.Context, userID string, userRole domain.UserRole) (*ports.UserDashboard, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	now := time.Now()
+
+	assigned, err := s.taskRepo.FindByAssigneeID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned tasks: %w", err)
+	}
+
+	notSnoozed := false
+	active, err := s.taskRepo.FindByQuery(ctx, ports.TaskQuery{Statuses: activeStatuses, Snoozed: &notSnoozed})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active tasks: %w", err)
+	}
+
+	// Отложенные задачи не попадают в рабочую очередь до истечения срока
+	myTasks := []domain.Task{}
+	snoozedCount := 0
+	for _, task := range assigned {
+		if !task.Status.IsActive() {
+			continue
+		}
+		if task.IsSnoozed(now) {
+			snoozedCount++
+			continue
+		}
+		myTasks = append(myTasks, task)
+	}
+	sortByUrgency(myTasks, now)
+
+	dashboard := &ports.UserDashboard{
+		MyTasks:       myTasks,
+		AssignedCount: len(myTasks),
+		SnoozedCount:  snoozedCount,
+	}
+
+	for _, task := range active {
+		if task.AssigneeID == "" {
+			dashboard.UnassignedCount++
+		}
+	}
+
+	// Просроченные: свои задачи для операторов, все активные для руководителей
+	overdueScope := myTasks
+	if isSupervisorRole(userRole) {
+		overdueScope = active
+	}
+	for _, task := range overdueScope {
+		if task.IsOverdue(now) {
+			dashboard.OverdueCount++
+		}
+	}
+
+	// Лента активности: задачи, в которых участвует пользователь, либо все задачи для руководителей
+	var activityScope []domain.Task
+	if isSupervisorRole(userRole) {
+		activityScope, err = s.taskRepo.FindByQuery(ctx, ports.TaskQuery{})
+	} else {
+		activityScope, err = s.taskRepo.FindByParticipantID(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks for activity: %w", err)
+	}
+	dashboard.RecentActivity = buildRecentActivity(activityScope, dashboardActivityLimit)
+
+	dashboard.Stats = calculateUserStats(assigned, now)
+
+	return dashboard, nil
+}
+
+// Вспомогательные функции
+
+// isSupervisorRole проверяет, видит ли роль задачи всей команды
+func isSupervisorRole(role domain.UserRole) bool {
+	return role == domain.UserRoleAdmin || role == domain.UserRoleManager
+}
+
+// buildRecentActivity собирает последние события из истории задач
+func buildRecentActivity(tasks []domain.Task, limit int) []ports.DashboardActivity {
+	type taskEvent struct {
+		task  *domain.Task
+		event domain.TaskEvent
+	}
+
+	var events []taskEvent
+	for i := range tasks {
+		for _, event := range tasks[i].History {
+			events = append(events, taskEvent{task: &tasks[i], event: event})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].event.Timestamp.After(events[j].event.Timestamp)
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	activity := make([]ports.DashboardActivity, len(events))
+	for i, item := range events {
+		activity[i] = ports.DashboardActivity{
+			Type:      item.event.Type,
+			TaskID:    item.task.ID,
+			Subject:   item.task.Subject,
+			UserID:    item.event.UserID,
+			Timestamp: item.event.Timestamp.Format(time.RFC3339),
+		}
+	}
+
+	return activity
+}
+
+// calculateUserStats считает персональную статистику по назначенным задачам
+func calculateUserStats(assigned []domain.Task, now time.Time) *ports.UserStats {
+	stats := &ports.UserStats{}
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var totalResolution float64
+	resolvedCount := 0
+
+	for _, task := range assigned {
+		if task.Status.IsActive() {
+			stats.OpenTasks++
+		}
+
+		if task.ResolvedAt == nil {
+			continue
+		}
+
+		resolvedCount++
+		totalResolution += task.ResolvedAt.Sub(task.CreatedAt).Hours()
+
+		if !task.ResolvedAt.Before(startOfDay) {
+			stats.ResolvedToday++
+		}
+	}
+
+	if resolvedCount > 0 {
+		stats.AvgResolutionTime = totalResolution / float64(resolvedCount)
+	}
+
+	// Удовлетворенность - по ответам клиентов на опросы после решения задач
+	stats.SatisfactionRate = taskSatisfaction(assigned).CSAT
+
+	return stats
+}
+
+// sortByUpdatedDesc сортирует задачи по времени обновления (новые сначала)
+func sortByUpdatedDesc(tasks []domain.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+	})
+}
+
+// sortByUrgency сортирует задачи: просроченные, затем по приоритету и сроку
+func sortByUrgency(tasks []domain.Task, now time.Time) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+
+		if a.IsOverdue(now) != b.IsOverdue(now) {
+			return a.IsOverdue(now)
+		}
+		if priorityRank(a.Priority) != priorityRank(b.Priority) {
+			return priorityRank(a.Priority) > priorityRank(b.Priority)
+		}
+		if a.DueDate != nil && b.DueDate != nil {
+			return a.DueDate.Before(*b.DueDate)
+		}
+		return a.DueDate != nil
+	})
+}
+
+// priorityRank возвращает числовой вес приоритета
+func priorityRank(priority domain.Priority) int {
+	switch priority {
+	case domain.PriorityCritical:
+		return 4
+	case domain.PriorityHigh:
+		return 3
+	case domain.PriorityMedium:
+		return 2
+	case domain.PriorityLow:
+		return 1
+	default:
+		return 0
+	}
+}
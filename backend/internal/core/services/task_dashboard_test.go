@@ -0,0 +1,107 @@
+// internal/core/services/task_dashboard_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskService_GetDashboard(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+
+	newTask := func(subject string, priority domain.Priority) *domain.Task {
+		task, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+			Type:        domain.TaskTypeInternal,
+			Subject:     subject,
+			Description: "Test Description",
+			ReporterID:  "user-1",
+			Priority:    priority,
+		})
+		require.NoError(t, err)
+		return task
+	}
+
+	overdue := newTask("Overdue task", domain.PriorityLow)
+	_, err := taskService.AssignTask(ctx, overdue.ID, "user-3", "user-1")
+	require.NoError(t, err)
+	pastDue := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	_, err = taskService.UpdateTask(ctx, overdue.ID, ports.UpdateTaskRequest{DueDate: &pastDue})
+	require.NoError(t, err)
+
+	critical := newTask("Critical task", domain.PriorityCritical)
+	_, err = taskService.AssignTask(ctx, critical.ID, "user-3", "user-1")
+	require.NoError(t, err)
+
+	resolved := newTask("Resolved task", domain.PriorityMedium)
+	_, err = taskService.AssignTask(ctx, resolved.ID, "user-3", "user-1")
+	require.NoError(t, err)
+	_, err = taskService.ChangeStatus(ctx, resolved.ID, domain.TaskStatusResolved, "user-3")
+	require.NoError(t, err)
+
+	newTask("Unassigned task", domain.PriorityHigh)
+
+	t.Run("operator dashboard", func(t *testing.T) {
+		dashboard, err := taskService.GetDashboard(ctx, "user-3", domain.UserRoleOperator)
+		require.NoError(t, err)
+
+		require.Len(t, dashboard.MyTasks, 2)
+		assert.Equal(t, overdue.ID, dashboard.MyTasks[0].ID) // Просроченные первыми
+		assert.Equal(t, critical.ID, dashboard.MyTasks[1].ID)
+		assert.Equal(t, 2, dashboard.AssignedCount)
+		assert.Equal(t, 1, dashboard.UnassignedCount)
+		assert.Equal(t, 1, dashboard.OverdueCount)
+		assert.NotEmpty(t, dashboard.RecentActivity)
+
+		require.NotNil(t, dashboard.Stats)
+		assert.Equal(t, 2, dashboard.Stats.OpenTasks)
+		assert.Equal(t, 1, dashboard.Stats.ResolvedToday)
+		assert.Equal(t, 0.0, dashboard.Stats.SatisfactionRate) // Клиенты еще не оценивали
+	})
+
+	t.Run("survey responses drive satisfaction", func(t *testing.T) {
+		rate := func(rating int) {
+			task, err := taskRepo.FindByID(ctx, resolved.ID)
+			require.NoError(t, err)
+			if task.Satisfaction == nil {
+				task.MarkSurveySent(time.Now())
+			}
+			require.NoError(t, task.RateSatisfaction(rating, "", "customer-1"))
+			require.NoError(t, taskRepo.Update(ctx, task))
+		}
+
+		rate(5)
+		dashboard, err := taskService.GetDashboard(ctx, "user-3", domain.UserRoleOperator)
+		require.NoError(t, err)
+		assert.Equal(t, 100.0, dashboard.Stats.SatisfactionRate)
+
+		rate(2)
+		dashboard, err = taskService.GetDashboard(ctx, "user-3", domain.UserRoleOperator)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, dashboard.Stats.SatisfactionRate)
+	})
+
+	t.Run("user tasks are role aware", func(t *testing.T) {
+		operatorTasks, err := taskService.GetUserTasks(ctx, "user-3", domain.UserRoleOperator)
+		require.NoError(t, err)
+		assert.Len(t, operatorTasks.AssignedTasks, 3)
+		assert.Empty(t, operatorTasks.UnassignedTasks)
+
+		managerTasks, err := taskService.GetUserTasks(ctx, "user-2", domain.UserRoleManager)
+		require.NoError(t, err)
+		assert.Len(t, managerTasks.UnassignedTasks, 1)
+	})
+}
@@ -0,0 +1,215 @@
+// internal/core/services/task_links.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// LinkTasks связывает две задачи. Связь сохраняется с обеих сторон:
+// у второй задачи появляется обратная связь (blocks ↔ blocked_by и т.д.).
+// Сначала записывается обратная связь; если запись первой задачи не удалась,
+// обратная связь снимается, чтобы связь не осталась односторонней
+func (s *TaskService) LinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error) {
+	if !linkType.IsValid() {
+		return nil, fmt.Errorf("invalid link type: %s", linkType)
+	}
+	if targetID == "" {
+		return nil, errors.New("target task ID is required")
+	}
+
+	// Версия, ожидаемая клиентом (If-Match), проверяется до повторов:
+	// повтор не должен обходить несовпадение версии
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	if err := ports.CheckVersionPrecondition(ctx, task.ID, task.Version); err != nil {
+		return nil, err
+	}
+
+	err = ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		var err error
+		task, err = s.linkTasks(ctx, id, targetID, linkType, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "tasks linked",
+		"task_id", task.ID,
+		"target_id", targetID,
+		"link_type", linkType,
+		"user_id", userID,
+	)
+
+	return task, nil
+}
+
+// linkTasks одна попытка связывания: обе задачи загружаются заново
+func (s *TaskService) linkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	target, err := s.taskRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find target task: %w", err)
+	}
+
+	// Дубликат должен указывать на оригинал, а не на другой дубликат
+	if linkType == domain.LinkDuplicateOf && target.DuplicateOf() != "" {
+		return nil, fmt.Errorf("target task %s is itself a duplicate of %s", target.ID, target.DuplicateOf())
+	}
+
+	if err := task.AddLink(linkType, target.ID, userID); err != nil {
+		return nil, fmt.Errorf("failed to link task: %w", err)
+	}
+	if err := target.AddLink(linkType.Inverse(), task.ID, userID); err != nil {
+		return nil, fmt.Errorf("failed to link target task: %w", err)
+	}
+
+	// Дубликат закрывается: дальнейшая работа ведется в оригинальной задаче
+	if linkType == domain.LinkDuplicateOf && task.Status != domain.TaskStatusClosed && task.Status != domain.TaskStatusCancelled {
+		if err := task.ChangeStatus(domain.TaskStatusClosed, userID); err != nil {
+			return nil, fmt.Errorf("failed to close duplicate task: %w", err)
+		}
+	}
+
+	if err := s.taskRepo.Update(ctx, target); err != nil {
+		return nil, fmt.Errorf("failed to update target task: %w", err)
+	}
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		s.removeInverseLink(ctx, target.ID, linkType, task.ID, userID)
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return task, nil
+}
+
+// removeInverseLink снимает обратную связь, записанную до неудачной записи первой задачи
+func (s *TaskService) removeInverseLink(ctx context.Context, targetID string, linkType domain.TaskLinkType, taskID, userID string) {
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		target, err := s.taskRepo.FindByID(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		if err := target.RemoveLink(linkType.Inverse(), taskID, userID); err != nil {
+			return nil
+		}
+		return s.taskRepo.Update(ctx, target)
+	})
+	if err != nil {
+		s.logger.Warn(ctx, "failed to roll back inverse task link",
+			"task_id", targetID,
+			"linked_task_id", taskID,
+			"error", err.Error(),
+		)
+	}
+}
+
+// UnlinkTasks удаляет связь между задачами с обеих сторон
+func (s *TaskService) UnlinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.RemoveLink(linkType, targetID, userID); err != nil {
+		return nil, fmt.Errorf("failed to unlink task: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	// Вторая задача могла быть удалена - обратную связь снимаем по возможности
+	if target, err := s.taskRepo.FindByID(ctx, targetID); err == nil {
+		if err := target.RemoveLink(linkType.Inverse(), task.ID, userID); err == nil {
+			if err := s.taskRepo.Update(ctx, target); err != nil {
+				return nil, fmt.Errorf("failed to update target task: %w", err)
+			}
+		}
+	}
+
+	s.logger.Info(ctx, "tasks unlinked",
+		"task_id", task.ID,
+		"target_id", targetID,
+		"link_type", linkType,
+		"user_id", userID,
+	)
+
+	return task, nil
+}
+
+// GetSubtaskProgress возвращает процент выполнения подзадач
+func (s *TaskService) GetSubtaskProgress(ctx context.Context, parentID string) (*ports.SubtaskProgress, error) {
+	subtasks, err := s.GetSubtasks(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &ports.SubtaskProgress{}
+	for _, subtask := range subtasks {
+		switch subtask.Status {
+		case domain.TaskStatusCancelled:
+			// Отмененные подзадачи не влияют на прогресс
+			continue
+		case domain.TaskStatusResolved, domain.TaskStatusClosed:
+			progress.Completed++
+		}
+		progress.Total++
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = float64(progress.Completed) / float64(progress.Total) * 100
+	}
+
+	return progress, nil
+}
+
+// checkBlockers запрещает решать задачу, пока открыты блокирующие ее задачи
+func (s *TaskService) checkBlockers(ctx context.Context, task *domain.Task) error {
+	var openBlockers []string
+	for _, blockerID := range task.LinkedTaskIDs(domain.LinkBlockedBy) {
+		blocker, err := s.taskRepo.FindByID(ctx, blockerID)
+		if err != nil {
+			// Удаленная блокирующая задача больше не мешает
+			continue
+		}
+		if blocker.Status.IsActive() {
+			openBlockers = append(openBlockers, blocker.ID)
+		}
+	}
+
+	if len(openBlockers) > 0 {
+		return fmt.Errorf("%w: %v", domain.ErrTaskBlocked, openBlockers)
+	}
+	return nil
+}
+
+// unlinkAll снимает обратные связи у задач, связанных с удаляемой задачей
+func (s *TaskService) unlinkAll(ctx context.Context, task *domain.Task) {
+	for _, link := range task.Links {
+		target, err := s.taskRepo.FindByID(ctx, link.TaskID)
+		if err != nil {
+			continue
+		}
+		if err := target.RemoveLink(link.Type.Inverse(), task.ID, "system"); err != nil {
+			continue
+		}
+		if err := s.taskRepo.Update(ctx, target); err != nil {
+			s.logger.Warn(ctx, "failed to remove link from related task",
+				"task_id", target.ID,
+				"linked_task_id", task.ID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
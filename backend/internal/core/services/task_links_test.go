@@ -0,0 +1,230 @@
+// internal/core/services/task_links_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskService_Links(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+
+	newTask := func(subject string) *domain.Task {
+		task, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+			Type:        domain.TaskTypeInternal,
+			Subject:     subject,
+			Description: "Test Description",
+			ReporterID:  "user-1",
+			Priority:    domain.PriorityMedium,
+		})
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("blockers prevent resolving", func(t *testing.T) {
+		blocker := newTask("Blocker")
+		blocked := newTask("Blocked")
+
+		_, err := taskService.LinkTasks(ctx, blocker.ID, blocked.ID, domain.LinkBlocks, "user-1")
+		require.NoError(t, err)
+
+		// Связь хранится с обеих сторон
+		stored, err := taskService.GetTask(ctx, blocked.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{blocker.ID}, stored.LinkedTaskIDs(domain.LinkBlockedBy))
+
+		_, err = taskService.ChangeStatus(ctx, blocked.ID, domain.TaskStatusResolved, "user-1")
+		assert.ErrorIs(t, err, domain.ErrTaskBlocked)
+
+		_, err = taskService.ChangeStatus(ctx, blocker.ID, domain.TaskStatusResolved, "user-1")
+		require.NoError(t, err)
+		_, err = taskService.ChangeStatus(ctx, blocked.ID, domain.TaskStatusResolved, "user-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("duplicate closes task", func(t *testing.T) {
+		original := newTask("Original")
+		duplicate := newTask("Duplicate")
+
+		updated, err := taskService.LinkTasks(ctx, duplicate.ID, original.ID, domain.LinkDuplicateOf, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusClosed, updated.Status)
+		assert.Equal(t, original.ID, updated.DuplicateOf())
+
+		stored, err := taskService.GetTask(ctx, original.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{duplicate.ID}, stored.LinkedTaskIDs(domain.LinkDuplicatedBy))
+
+		// Дубликат дубликата не допускается
+		another := newTask("Another")
+		_, err = taskService.LinkTasks(ctx, another.ID, duplicate.ID, domain.LinkDuplicateOf, "user-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("unlink and delete remove inverse links", func(t *testing.T) {
+		first := newTask("First")
+		second := newTask("Second")
+
+		_, err := taskService.LinkTasks(ctx, first.ID, second.ID, domain.LinkRelatesTo, "user-1")
+		require.NoError(t, err)
+		_, err = taskService.UnlinkTasks(ctx, first.ID, second.ID, domain.LinkRelatesTo, "user-1")
+		require.NoError(t, err)
+
+		stored, err := taskService.GetTask(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Links)
+
+		_, err = taskService.LinkTasks(ctx, first.ID, second.ID, domain.LinkCausedBy, "user-1")
+		require.NoError(t, err)
+		require.NoError(t, taskService.DeleteTask(ctx, second.ID))
+
+		stored, err = taskService.GetTask(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Links)
+	})
+
+	t.Run("subtask progress", func(t *testing.T) {
+		parent := newTask("Parent")
+
+		var subtasks []*domain.Task
+		for _, subject := range []string{"Sub 1", "Sub 2", "Sub 3"} {
+			subtask, err := taskService.CreateSubTask(ctx, ports.CreateSubTaskRequest{
+				ParentID:    parent.ID,
+				Subject:     subject,
+				Description: "Subtask",
+				ReporterID:  "user-1",
+				Priority:    domain.PriorityLow,
+			})
+			require.NoError(t, err)
+			subtasks = append(subtasks, subtask)
+		}
+
+		_, err := taskService.ChangeStatus(ctx, subtasks[0].ID, domain.TaskStatusResolved, "user-1")
+		require.NoError(t, err)
+		_, err = taskService.ChangeStatus(ctx, subtasks[1].ID, domain.TaskStatusCancelled, "user-1")
+		require.NoError(t, err)
+
+		progress, err := taskService.GetSubtaskProgress(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, progress.Total)
+		assert.Equal(t, 1, progress.Completed)
+		assert.Equal(t, 50.0, progress.Percent)
+	})
+}
+
+// concurrentWriteTaskRepository перед первой записью задачи записывает ее копию,
+// как параллельный запрос, поэтому первая запись получает конфликт версий
+type concurrentWriteTaskRepository struct {
+	ports.TaskRepository
+	taskID  string
+	written bool
+}
+
+func (r *concurrentWriteTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	if task.ID == r.taskID && !r.written {
+		r.written = true
+		concurrent, err := r.TaskRepository.FindByID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		concurrent.AddTag("concurrent")
+		if err := r.TaskRepository.Update(ctx, concurrent); err != nil {
+			return err
+		}
+	}
+	return r.TaskRepository.Update(ctx, task)
+}
+
+func TestTaskService_LinkTasksConflict(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := &concurrentWriteTaskRepository{TaskRepository: inmemory.NewTaskRepository(logger)}
+	taskService := services.NewTaskService(taskRepo, inmemory.NewCustomerRepository(logger), inmemory.NewUserRepository(logger), logger)
+
+	newTask := func(subject string) *domain.Task {
+		task, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+			Type:        domain.TaskTypeInternal,
+			Subject:     subject,
+			Description: "Test Description",
+			ReporterID:  "user-1",
+			Priority:    domain.PriorityMedium,
+		})
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("conflict on the task is retried without a one-sided link", func(t *testing.T) {
+		blocker := newTask("Blocker")
+		blocked := newTask("Blocked")
+		taskRepo.taskID, taskRepo.written = blocker.ID, false
+
+		updated, err := taskService.LinkTasks(ctx, blocker.ID, blocked.ID, domain.LinkBlocks, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{blocked.ID}, updated.LinkedTaskIDs(domain.LinkBlocks))
+		assert.Contains(t, updated.Tags, "concurrent", "повтор основан на актуальной версии")
+
+		stored, err := taskService.GetTask(ctx, blocked.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{blocker.ID}, stored.LinkedTaskIDs(domain.LinkBlockedBy))
+	})
+
+	t.Run("stale expected version is not retried", func(t *testing.T) {
+		first := newTask("First")
+		second := newTask("Second")
+		taskRepo.taskID = ""
+
+		staleCtx := ports.WithVersionPrecondition(ctx, first.ID, first.Version-1)
+		_, err := taskService.LinkTasks(staleCtx, first.ID, second.ID, domain.LinkRelatesTo, "user-1")
+		assert.ErrorIs(t, err, ports.ErrVersionConflict)
+
+		stored, err := taskService.GetTask(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Links)
+	})
+
+	t.Run("failed task write removes the inverse link", func(t *testing.T) {
+		first := newTask("First")
+		second := newTask("Second")
+		taskRepo.taskID = ""
+
+		// Каждая попытка записи первой задачи проигрывает параллельному запросу
+		conflicting := &alwaysConflictingTaskRepository{TaskRepository: taskRepo, taskID: first.ID}
+		conflictService := services.NewTaskService(conflicting, inmemory.NewCustomerRepository(logger), inmemory.NewUserRepository(logger), logger)
+
+		_, err := conflictService.LinkTasks(ctx, first.ID, second.ID, domain.LinkRelatesTo, "user-1")
+		assert.ErrorIs(t, err, ports.ErrVersionConflict)
+
+		stored, err := taskService.GetTask(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Links, "обратная связь снята")
+
+		// Повтор после сбоя не упирается в уже существующую связь
+		_, err = taskService.LinkTasks(ctx, first.ID, second.ID, domain.LinkRelatesTo, "user-1")
+		assert.NoError(t, err)
+	})
+}
+
+type alwaysConflictingTaskRepository struct {
+	ports.TaskRepository
+	taskID string
+}
+
+func (r *alwaysConflictingTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	if task.ID == r.taskID {
+		return ports.ErrVersionConflict
+	}
+	return r.TaskRepository.Update(ctx, task)
+}
@@ -0,0 +1,64 @@
+// internal/core/services/task_reply.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// emailReplier дублирует ответы операторов клиентам по email в цепочке исходного письма.
+// Используется сервисами, отправляющими ответы от имени оператора
+type emailReplier struct {
+	sender       ports.EmailSender
+	from         domain.EmailAddress
+	customerRepo ports.CustomerRepository
+}
+
+// canSend проверяет, нужно ли дублировать ответ клиенту по email
+func (r *emailReplier) canSend(task *domain.Task) bool {
+	return r.sender != nil && task.Source == domain.SourceEmail && task.CustomerID != nil
+}
+
+// send отправляет ответ клиенту в цепочке исходного письма
+func (r *emailReplier) send(ctx context.Context, task *domain.Task, content string) error {
+	return r.sendWithSubject(ctx, task, replySubject(task.Subject), content)
+}
+
+// sendWithSubject отправляет письмо клиенту задачи с указанной темой.
+// Если задача создана из письма, ответ попадает в его цепочку
+func (r *emailReplier) sendWithSubject(ctx context.Context, task *domain.Task, subject, content string) error {
+	customer, err := r.customerRepo.FindByID(ctx, *task.CustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to find customer: %w", err)
+	}
+
+	msg := domain.EmailMessage{
+		From:            r.from,
+		To:              []domain.EmailAddress{domain.EmailAddress(customer.Email)},
+		Subject:         subject,
+		BodyText:        content,
+		RelatedTicketID: &task.ID,
+	}
+
+	if messageID, ok := task.SourceMeta["message_id"].(string); ok && messageID != "" {
+		msg.InReplyTo = messageID
+		if references, ok := task.SourceMeta["references"].([]string); ok {
+			msg.References = append(msg.References, references...)
+		}
+		msg.References = append(msg.References, messageID)
+	}
+
+	return r.sender.SendEmail(ctx, msg)
+}
+
+// replySubject формирует тему ответного письма
+func replySubject(subject string) string {
+	if strings.HasPrefix(strings.ToLower(subject), "re:") {
+		return subject
+	}
+	return "Re: " + subject
+}
@@ -16,6 +16,8 @@ type TaskService struct {
 	customerRepo ports.CustomerRepository
 	userRepo     ports.UserRepository
 	logger       ports.Logger
+
+	customFieldRepo ports.CustomFieldRepository
 }
 
 func NewTaskService(
@@ -95,6 +97,12 @@ func (s *TaskService) CreateTask(ctx context.Context, req ports.CreateTaskReques
 		task.ProjectID = req.ProjectID
 	}
 
+	customFields, err := s.resolveCustomFields(ctx, task.Type, task.Category, req.Source, task.CustomFields, req.CustomFields, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom fields: %w", err)
+	}
+	applyCustomFieldChanges(task, customFields, req.ReporterID)
+
 	// Сохраняем задачу
 	if err := s.taskRepo.Save(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to save task: %w", err)
@@ -138,6 +146,7 @@ func (s *TaskService) CreateInternalTask(ctx context.Context, req ports.CreateIn
 		Category:    req.Category,
 		Tags:        req.Tags,
 		ProjectID:   req.ProjectID,
+		SourceMeta:  req.SourceMeta,
 	}
 
 	return s.CreateTask(ctx, createReq)
@@ -180,33 +189,40 @@ func (s *TaskService) UpdateTask(ctx context.Context, id string, req ports.Updat
 		return nil, fmt.Errorf("failed to find task: %w", err)
 	}
 
-	// Сохраняем старое время для сравнения
-	// oldUpdatedAt := task.UpdatedAt // ДОБАВИТЬ ЭТУ СТРОКУ
-
-	// Обновляем поля если они предоставлены
-	if req.Subject != nil {
-		task.Subject = *req.Subject
-	}
-	if req.Description != nil {
-		task.Description = *req.Description
-	}
-	if req.Priority != nil {
-		task.Priority = *req.Priority
+	userID := req.UserID
+	if userID == "" {
+		userID = "system"
 	}
+
+	// Пользовательские поля проверяем до изменения задачи с учетом новой категории
+	category := task.Category
 	if req.Category != nil {
-		task.Category = *req.Category
+		category = *req.Category
 	}
-	if req.Tags != nil {
-		task.Tags = *req.Tags
+	customFields, err := s.resolveCustomFields(ctx, task.Type, category, task.Source, task.CustomFields, req.CustomFields, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom fields: %w", err)
+	}
+
+	changes := domain.TaskChanges{
+		Subject:     req.Subject,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Category:    req.Category,
+		Tags:        req.Tags,
 	}
 	if req.DueDate != nil {
 		dueDate, err := time.Parse(time.RFC3339, *req.DueDate)
 		if err != nil {
 			return nil, fmt.Errorf("invalid due date format: %w", err)
 		}
-		task.DueDate = &dueDate
+		changes.DueDate = &dueDate
 	}
 
+	// Изменения полей попадают в историю одним событием с перечнем отличий
+	task.UpdateDetails(changes, userID)
+	applyCustomFieldChanges(task, customFields, userID)
+
 	task.UpdatedAt = time.Now()
 
 	if err := s.taskRepo.Update(ctx, task); err != nil {
@@ -224,11 +240,14 @@ func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 	}
 
 	// Проверяем существование задачи
-	_, err := s.taskRepo.FindByID(ctx, id)
+	task, err := s.taskRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to find task: %w", err)
 	}
 
+	// Связанные задачи не должны ссылаться на удаленную
+	s.unlinkAll(ctx, task)
+
 	if err := s.taskRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -244,6 +263,12 @@ func (s *TaskService) ChangeStatus(ctx context.Context, id string, status domain
 		return nil, fmt.Errorf("failed to find task: %w", err)
 	}
 
+	if status == domain.TaskStatusResolved {
+		if err := s.checkBlockers(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := task.ChangeStatus(status, userID); err != nil {
 		return nil, fmt.Errorf("failed to change status: %w", err)
 	}
@@ -270,6 +295,14 @@ func (s *TaskService) AssignTask(ctx context.Context, id string, assigneeID stri
 		return nil, errors.New("assignee ID is required")
 	}
 
+	assignee, err := s.userRepo.FindByID(ctx, assigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assignee: %w", err)
+	}
+	if err := assignee.CanBeAssigned(); err != nil {
+		return nil, err
+	}
+
 	task, err := s.taskRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find task: %w", err)
@@ -295,6 +328,126 @@ func (s *TaskService) AssignTask(ctx context.Context, id string, assigneeID stri
 	return task, nil
 }
 
+// AddParticipant добавляет участника задачи (пользователя или клиента)
+func (s *TaskService) AddParticipant(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error) {
+	if participantID == "" {
+		return nil, errors.New("participant ID is required")
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := s.validateParticipant(ctx, participantID); err != nil {
+		return nil, err
+	}
+
+	if err := task.AddParticipant(participantID, role, userID); err != nil {
+		return nil, fmt.Errorf("failed to add participant: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "participant added to task",
+		"task_id", task.ID,
+		"participant_id", participantID,
+		"role", role,
+		"added_by", userID,
+	)
+
+	return task, nil
+}
+
+// RemoveParticipant удаляет участника из задачи
+func (s *TaskService) RemoveParticipant(ctx context.Context, id string, participantID string, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.RemoveParticipant(participantID, userID); err != nil {
+		return nil, fmt.Errorf("failed to remove participant: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "participant removed from task",
+		"task_id", task.ID,
+		"participant_id", participantID,
+		"removed_by", userID,
+	)
+
+	return task, nil
+}
+
+// ChangeParticipantRole изменяет роль участника задачи
+func (s *TaskService) ChangeParticipantRole(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.ChangeParticipantRole(participantID, role, userID); err != nil {
+		return nil, fmt.Errorf("failed to change participant role: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "participant role changed",
+		"task_id", task.ID,
+		"participant_id", participantID,
+		"role", role,
+		"changed_by", userID,
+	)
+
+	return task, nil
+}
+
+// WatchTask подписывает пользователя на задачу
+func (s *TaskService) WatchTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.Watch(userID); err != nil {
+		return nil, fmt.Errorf("failed to watch task: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "user is watching task", "task_id", task.ID, "user_id", userID)
+	return task, nil
+}
+
+// UnwatchTask отписывает пользователя от задачи
+func (s *TaskService) UnwatchTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.Unwatch(userID); err != nil {
+		return nil, fmt.Errorf("failed to unwatch task: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "user stopped watching task", "task_id", task.ID, "user_id", userID)
+	return task, nil
+}
+
 // AddMessage добавляет сообщение в задачу
 func (s *TaskService) AddMessage(ctx context.Context, id string, req ports.AddMessageRequest) (*domain.Task, error) {
 	task, err := s.taskRepo.FindByID(ctx, id)
@@ -307,7 +460,15 @@ func (s *TaskService) AddMessage(ctx context.Context, id string, req ports.AddMe
 		messageType = domain.MessageTypeInternal
 	}
 
-	if err := task.AddMessage(req.AuthorID, req.Content, messageType); err != nil {
+	if req.ExternalID != "" {
+		// Повторная доставка внешней записи не добавляет сообщение второй раз
+		if task.HasExternalID(req.ExternalID) {
+			return nil, domain.ErrInboundDuplicate
+		}
+		task.AddExternalID(req.ExternalID)
+	}
+
+	if err := task.AddMessageWithAttachments(req.AuthorID, req.Content, messageType, req.Attachments); err != nil {
 		return nil, fmt.Errorf("failed to add message: %w", err)
 	}
 
@@ -319,6 +480,7 @@ func (s *TaskService) AddMessage(ctx context.Context, id string, req ports.AddMe
 		"task_id", task.ID,
 		"author_id", req.AuthorID,
 		"message_type", messageType,
+		"attachments", len(req.Attachments),
 	)
 
 	return task, nil
@@ -352,43 +514,32 @@ func (s *TaskService) FindBySourceMeta(ctx context.Context, meta map[string]inte
 	return tasks, nil
 }
 
-// SearchTasks ищет задачи по критериям
+// SearchTasks ищет задачи по критериям и возвращает страницу по курсору или смещению.
+// Без сортировки задачи упорядочиваются от новых к старым
 func (s *TaskService) SearchTasks(ctx context.Context, query ports.TaskQuery) (*ports.TaskSearchResult, error) {
+	query.Sort = query.SortFields()
+	if len(query.Sort) == 0 {
+		query.Sort = domain.DefaultTaskSort
+	}
+
 	tasks, err := s.taskRepo.FindByQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search tasks: %w", err)
 	}
 
-	totalCount := len(tasks)
-
-	// Применяем пагинацию
-	start := query.Offset
-	if start < 0 {
-		start = 0
-	}
-	if start >= totalCount {
-		start = totalCount
-	}
-
-	end := start + query.Limit
-	if end > totalCount {
-		end = totalCount
-	}
-	if query.Limit <= 0 {
-		end = totalCount
-	}
-
-	pagedTasks := tasks[start:end]
-
-	result := &ports.TaskSearchResult{
-		Tasks:      pagedTasks,
-		TotalCount: totalCount,
-		Page:       query.Offset/query.Limit + 1,
-		PageSize:   query.Limit,
-		TotalPages: (totalCount + query.Limit - 1) / query.Limit,
+	page, err := paginate(domain.TaskListing(query.Sort), tasks, query.Cursor, query.Offset, query.Limit)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return &ports.TaskSearchResult{
+		Tasks:      page.Items,
+		TotalCount: page.TotalCount,
+		Page:       page.Page,
+		PageSize:   page.PageSize,
+		TotalPages: page.TotalPages,
+		NextCursor: page.NextCursor,
+	}, nil
 }
 
 // GetCustomerTasks возвращает задачи клиента
@@ -459,17 +610,18 @@ func (s *TaskService) validateCreateTaskRequest(req ports.CreateTaskRequest) err
 	return nil
 }
 
-// Методы требующие реализации (заглушки)
-
-func (s *TaskService) GetUserTasks(ctx context.Context, userID string, userRole domain.UserRole) (*ports.UserTasks, error) {
-	// TODO: Реализовать получение задач пользователя по роли
-	return &ports.UserTasks{}, nil
+// validateParticipant проверяет, что участник является пользователем системы или клиентом
+func (s *TaskService) validateParticipant(ctx context.Context, participantID string) error {
+	if user, err := s.userRepo.FindByID(ctx, participantID); err == nil && user != nil {
+		return nil
+	}
+	if customer, err := s.customerRepo.FindByID(ctx, participantID); err == nil && customer != nil {
+		return nil
+	}
+	return fmt.Errorf("participant not found: %s", participantID)
 }
 
-func (s *TaskService) GetDashboard(ctx context.Context, userID string) (*ports.UserDashboard, error) {
-	// TODO: Реализовать дашборд пользователя
-	return &ports.UserDashboard{}, nil
-}
+// Методы требующие реализации (заглушки)
 
 func (s *TaskService) AutoAssignTasks(ctx context.Context) ([]ports.AutoAssignmentResult, error) {
 	// TODO: Реализовать автоматическое назначение
@@ -490,8 +642,3 @@ func (s *TaskService) BulkAssign(ctx context.Context, taskIDs []string, assignee
 	// TODO: Реализовать массовое назначение
 	return []ports.BulkOperationResult{}, nil
 }
-
-func (s *TaskService) AddParticipant(ctx context.Context, id string, userID string, role domain.ParticipantRole) (*domain.Task, error) {
-	// TODO: Реализовать добавление участника
-	return &domain.Task{}, nil
-}
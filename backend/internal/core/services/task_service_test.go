@@ -514,9 +514,178 @@ func TestTaskService_SearchTasks(t *testing.T) {
 		assert.Equal(t, 3, result.TotalCount)
 		assert.Equal(t, 2, result.TotalPages)
 	})
+
+	t.Run("cursor pagination with multi-field sort", func(t *testing.T) {
+		sort := []domain.SortField{{Field: "priority", Desc: true}, {Field: "created_at"}}
+		first, err := taskService.SearchTasks(ctx, ports.TaskQuery{Sort: sort, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first.Tasks, 2)
+		assert.Equal(t, domain.PriorityHigh, first.Tasks[0].Priority)
+		assert.Equal(t, domain.PriorityMedium, first.Tasks[1].Priority)
+		require.NotEmpty(t, first.NextCursor)
+
+		// Новая задача с высоким приоритетом попадает до курсора и не сдвигает следующую страницу
+		_, err = taskService.CreateTask(ctx, ports.CreateTaskRequest{
+			Type:        domain.TaskTypeInternal,
+			Subject:     "Another Bug",
+			Description: "Created between pages",
+			ReporterID:  "user-2",
+			Priority:    domain.PriorityCritical,
+		})
+		require.NoError(t, err)
+
+		second, err := taskService.SearchTasks(ctx, ports.TaskQuery{Sort: sort, Limit: 2, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, second.Tasks, 1)
+		assert.Equal(t, domain.PriorityLow, second.Tasks[0].Priority)
+		assert.Equal(t, 4, second.TotalCount)
+		assert.Empty(t, second.NextCursor)
+
+		_, err = taskService.SearchTasks(ctx, ports.TaskQuery{Limit: 2, Cursor: "not-a-cursor"})
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	})
+
+	t.Run("negation and date filters", func(t *testing.T) {
+		result, err := taskService.SearchTasks(ctx, ports.TaskQuery{
+			ExcludeTypes:      []domain.TaskType{domain.TaskTypeInternal},
+			ExcludePriorities: []domain.Priority{domain.PriorityLow},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Tasks, 1)
+		assert.Equal(t, "High Priority Bug", result.Tasks[0].Subject)
+
+		future := time.Now().Add(time.Hour)
+		result, err = taskService.SearchTasks(ctx, ports.TaskQuery{CreatedAt: domain.TimeRange{From: &future}})
+		require.NoError(t, err)
+		assert.Empty(t, result.Tasks)
+
+		// Нерешенные задачи не попадают в интервал по дате решения
+		past := time.Now().Add(-time.Hour)
+		result, err = taskService.SearchTasks(ctx, ports.TaskQuery{ResolvedAt: domain.TimeRange{From: &past}})
+		require.NoError(t, err)
+		assert.Empty(t, result.Tasks)
+	})
+}
+
+func TestTaskService_Participants(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+
+	task, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+		Type:        domain.TaskTypeInternal,
+		Subject:     "Participants Test Task",
+		Description: "Test Description",
+		ReporterID:  "user-1",
+	})
+	require.NoError(t, err)
+
+	t.Run("add watcher", func(t *testing.T) {
+		updatedTask, err := taskService.AddParticipant(ctx, task.ID, "user-2", domain.RoleWatcher, "user-1")
+		require.NoError(t, err)
+		assert.True(t, updatedTask.HasParticipantRole("user-2", domain.RoleWatcher))
+	})
+
+	t.Run("fail to add unknown participant", func(t *testing.T) {
+		_, err := taskService.AddParticipant(ctx, task.ID, "unknown", domain.RoleWatcher, "user-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "participant not found")
+	})
+
+	t.Run("change role to reviewer", func(t *testing.T) {
+		updatedTask, err := taskService.ChangeParticipantRole(ctx, task.ID, "user-2", domain.RoleReviewer, "user-1")
+		require.NoError(t, err)
+		assert.True(t, updatedTask.HasParticipantRole("user-2", domain.RoleReviewer))
+	})
+
+	t.Run("watch and user tasks", func(t *testing.T) {
+		_, err := taskService.WatchTask(ctx, task.ID, "user-3")
+		require.NoError(t, err)
+
+		userTasks, err := taskService.GetUserTasks(ctx, "user-3", domain.UserRoleOperator)
+		require.NoError(t, err)
+		assert.Len(t, userTasks.Watching, 1)
+		assert.Empty(t, userTasks.ParticipatingIn)
+
+		reviewerTasks, err := taskService.GetUserTasks(ctx, "user-2", domain.UserRoleManager)
+		require.NoError(t, err)
+		assert.Len(t, reviewerTasks.ParticipatingIn, 1)
+
+		_, err = taskService.UnwatchTask(ctx, task.ID, "user-3")
+		require.NoError(t, err)
+
+		userTasks, err = taskService.GetUserTasks(ctx, "user-3", domain.UserRoleOperator)
+		require.NoError(t, err)
+		assert.Empty(t, userTasks.Watching)
+	})
+
+	t.Run("remove participant", func(t *testing.T) {
+		updatedTask, err := taskService.RemoveParticipant(ctx, task.ID, "user-2", "user-1")
+		require.NoError(t, err)
+		assert.Nil(t, updatedTask.FindParticipant("user-2"))
+	})
 }
 
 // Вспомогательная функция
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestTaskService_Versioning(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	taskService := services.NewTaskService(taskRepo, inmemory.NewCustomerRepository(logger), inmemory.NewUserRepository(logger), logger)
+
+	task, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+		Type:        domain.TaskTypeInternal,
+		Subject:     "Versioned",
+		Description: "Test Description",
+		ReporterID:  "user-1",
+		Priority:    domain.PriorityMedium,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, task.Version)
+
+	t.Run("every update bumps the version", func(t *testing.T) {
+		updated, err := taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusInProgress, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Version)
+	})
+
+	t.Run("stale copy is rejected", func(t *testing.T) {
+		first, err := taskRepo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+		second, err := taskRepo.FindByID(ctx, task.ID)
+		require.NoError(t, err)
+
+		first.AddTag("first")
+		require.NoError(t, taskRepo.Update(ctx, first))
+
+		second.AddTag("second")
+		err = taskRepo.Update(ctx, second)
+		assert.ErrorIs(t, err, ports.ErrVersionConflict)
+
+		stored, err := taskService.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first"}, stored.Tags)
+	})
+
+	t.Run("expected version from request context", func(t *testing.T) {
+		current, err := taskService.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+
+		staleCtx := ports.WithVersionPrecondition(ctx, task.ID, current.Version-1)
+		_, err = taskService.AssignTask(staleCtx, task.ID, "user-2", "user-1")
+		assert.ErrorIs(t, err, ports.ErrVersionConflict)
+
+		freshCtx := ports.WithVersionPrecondition(ctx, task.ID, current.Version)
+		updated, err := taskService.AssignTask(freshCtx, task.ID, "user-2", "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, current.Version+1, updated.Version)
+	})
+}
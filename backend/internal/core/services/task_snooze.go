@@ -0,0 +1,81 @@
+// internal/core/services/task_snooze.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// SnoozeTask откладывает задачу до указанного момента
+func (s *TaskService) SnoozeTask(ctx context.Context, id string, until time.Time, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.Snooze(until, userID); err != nil {
+		return nil, fmt.Errorf("failed to snooze task: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "task snoozed",
+		"task_id", task.ID,
+		"until", until,
+		"user_id", userID,
+	)
+
+	return task, nil
+}
+
+// UnsnoozeTask досрочно возвращает отложенную задачу в работу
+func (s *TaskService) UnsnoozeTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if !task.Unsnooze(userID, "") {
+		return nil, fmt.Errorf("task %s is not snoozed", id)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "task unsnoozed", "task_id", task.ID, "user_id", userID)
+	return task, nil
+}
+
+// WakeSnoozedTasks возвращает в работу задачи, срок откладывания которых истек к моменту now
+func (s *TaskService) WakeSnoozedTasks(ctx context.Context, now time.Time) ([]string, error) {
+	tasks, err := s.taskRepo.FindByQuery(ctx, ports.TaskQuery{SnoozeExpiredBy: &now})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find snoozed tasks: %w", err)
+	}
+
+	woken := []string{}
+	for i := range tasks {
+		task := &tasks[i]
+		if !task.Unsnooze("system", "истек срок откладывания") {
+			continue
+		}
+		if err := s.taskRepo.Update(ctx, task); err != nil {
+			s.logger.Error(ctx, "failed to wake snoozed task", "task_id", task.ID, "error", err.Error())
+			continue
+		}
+		woken = append(woken, task.ID)
+	}
+
+	if len(woken) > 0 {
+		s.logger.Info(ctx, "snoozed tasks woken", "count", len(woken))
+	}
+
+	return woken, nil
+}
@@ -0,0 +1,249 @@
+// internal/core/services/task_stream.go
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// TaskStreamConfig параметры потока изменений задач
+type TaskStreamConfig struct {
+	BufferSize       int // Последних событий в буфере для повтора после переподключения
+	SubscriberBuffer int // Событий в очереди подписчика; при переполнении подписчик отключается
+}
+
+// DefaultTaskStreamConfig возвращает конфигурацию по умолчанию
+func DefaultTaskStreamConfig() TaskStreamConfig {
+	return TaskStreamConfig{
+		BufferSize:       1000,
+		SubscriberBuffer: 64,
+	}
+}
+
+// TaskStreamBroker реализует ports.TaskStreamBroker в памяти процесса.
+// Публикация никогда не ждет подписчиков: подписчик с переполненной очередью
+// отключается с domain.ErrTaskStreamLagged и после переподключения получает
+// пропущенное из буфера. Нумерация событий начинается с текущего времени в микросекундах,
+// поэтому Last-Event-ID, полученный до перезапуска, распознается как вытесненный из буфера
+type TaskStreamBroker struct {
+	config      TaskStreamConfig
+	buffer      []domain.TaskStreamEvent // кольцевой буфер последних событий
+	head        int                      // индекс самого старого события
+	lastID      uint64
+	subscribers map[*taskStreamSubscription]struct{}
+	mu          sync.Mutex
+	logger      ports.Logger
+}
+
+func NewTaskStreamBroker(config TaskStreamConfig, logger ports.Logger) *TaskStreamBroker {
+	defaults := DefaultTaskStreamConfig()
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaults.BufferSize
+	}
+	if config.SubscriberBuffer <= 0 {
+		config.SubscriberBuffer = defaults.SubscriberBuffer
+	}
+
+	return &TaskStreamBroker{
+		config:      config,
+		buffer:      make([]domain.TaskStreamEvent, 0, config.BufferSize),
+		lastID:      uint64(time.Now().UnixMicro()),
+		subscribers: make(map[*taskStreamSubscription]struct{}),
+		logger:      logger,
+	}
+}
+
+// Publish назначает событию ID, сохраняет его в буфере и передает подписчикам
+func (b *TaskStreamBroker) Publish(ctx context.Context, event domain.TaskStreamEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if len(b.buffer) < b.config.BufferSize {
+		b.buffer = append(b.buffer, event)
+	} else {
+		b.buffer[b.head] = event
+		b.head = (b.head + 1) % len(b.buffer)
+	}
+
+	for subscription := range b.subscribers {
+		accepted, ok := subscription.accept(event)
+		if !ok {
+			continue
+		}
+		select {
+		case subscription.events <- accepted:
+		default:
+			subscription.err = domain.ErrTaskStreamLagged
+			b.unsubscribe(subscription)
+			b.logger.Warn(ctx, "task stream subscriber disconnected: queue is full",
+				"queue_size", b.config.SubscriberBuffer, "event_id", event.ID)
+		}
+	}
+}
+
+// Subscribe подписывает на события после lastEventID. Повтор из буфера и регистрация
+// подписчика выполняются под одной блокировкой, поэтому события не теряются и не дублируются
+func (b *TaskStreamBroker) Subscribe(lastEventID uint64, accept ports.TaskStreamAcceptFunc) ports.TaskStreamSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription := &taskStreamSubscription{
+		broker: b,
+		accept: accept,
+		events: make(chan domain.TaskStreamEvent, b.config.SubscriberBuffer),
+	}
+
+	if lastEventID > 0 {
+		oldestID := b.lastID + 1
+		if len(b.buffer) > 0 {
+			oldestID = b.buffer[b.head].ID
+		}
+		// Неизвестный ID (из будущего или старше буфера) означает пропуск событий
+		subscription.incomplete = lastEventID > b.lastID || lastEventID+1 < oldestID
+
+		for i := range b.buffer {
+			event := b.buffer[(b.head+i)%len(b.buffer)]
+			if event.ID <= lastEventID {
+				continue
+			}
+			if accepted, ok := accept(event); ok {
+				subscription.replay = append(subscription.replay, accepted)
+			}
+		}
+	}
+
+	b.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// unsubscribe удаляет подписчика и закрывает его канал; вызывается под блокировкой
+func (b *TaskStreamBroker) unsubscribe(subscription *taskStreamSubscription) {
+	if _, ok := b.subscribers[subscription]; !ok {
+		return
+	}
+	delete(b.subscribers, subscription)
+	close(subscription.events)
+}
+
+// taskStreamSubscription реализует ports.TaskStreamSubscription
+type taskStreamSubscription struct {
+	broker     *TaskStreamBroker
+	accept     ports.TaskStreamAcceptFunc
+	events     chan domain.TaskStreamEvent
+	replay     []domain.TaskStreamEvent
+	incomplete bool
+	err        error
+}
+
+func (s *taskStreamSubscription) Replay() []domain.TaskStreamEvent {
+	return s.replay
+}
+
+func (s *taskStreamSubscription) Incomplete() bool {
+	return s.incomplete
+}
+
+func (s *taskStreamSubscription) Events() <-chan domain.TaskStreamEvent {
+	return s.events
+}
+
+func (s *taskStreamSubscription) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.err
+}
+
+func (s *taskStreamSubscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.unsubscribe(s)
+}
+
+// StreamingTaskRepository публикует изменения задач в поток для клиентов после успешной записи.
+// Публикация выполняется в репозитории, поэтому подписчики получают изменения любого сервиса,
+// а не только TaskService: интеграций, опросов удовлетворенности, фоновых задач.
+// Автор изменения - пользователь запроса, а без него - автор из события задачи
+type StreamingTaskRepository struct {
+	ports.TaskRepository
+	publisher ports.TaskStreamPublisher
+}
+
+func NewStreamingTaskRepository(repo ports.TaskRepository, publisher ports.TaskStreamPublisher) *StreamingTaskRepository {
+	return &StreamingTaskRepository{TaskRepository: repo, publisher: publisher}
+}
+
+func (r *StreamingTaskRepository) Save(ctx context.Context, task *domain.Task) error {
+	if err := r.TaskRepository.Save(ctx, task); err != nil {
+		return err
+	}
+	r.publish(ctx, domain.TaskStreamTaskCreated, task, "", streamActor(ctx, task.ReporterID))
+	return nil
+}
+
+// Update публикует task.message_added для каждого добавленного сообщения или task.updated
+// для остальных изменений. События задачи читаются до записи: репозиторий забирает их при записи
+func (r *StreamingTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	pending := task.PendingEvents()
+	if err := r.TaskRepository.Update(ctx, task); err != nil {
+		return err
+	}
+
+	eventActor := ""
+	messageAdded := false
+	for _, event := range pending {
+		if event.UserID != "" {
+			eventActor = event.UserID
+		}
+		if event.Type == domain.TaskEventMessageAdded {
+			messageAdded = true
+			r.publish(ctx, domain.TaskStreamMessageAdded, task, domain.FormatAuditValue(event.NewValue), event.UserID)
+		}
+	}
+	if !messageAdded {
+		r.publish(ctx, domain.TaskStreamTaskUpdated, task, "", streamActor(ctx, eventActor))
+	}
+	return nil
+}
+
+// Delete публикует последний снимок задачи, поэтому подписчик может проверить, видна ли она ему
+func (r *StreamingTaskRepository) Delete(ctx context.Context, id string) error {
+	task, err := r.TaskRepository.FindByID(ctx, id)
+	if err != nil {
+		return r.TaskRepository.Delete(ctx, id)
+	}
+	if err := r.TaskRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.publish(ctx, domain.TaskStreamTaskDeleted, task, "", streamActor(ctx, ""))
+	return nil
+}
+
+func (r *StreamingTaskRepository) BulkUpdateStatus(ctx context.Context, taskIDs []string, status domain.TaskStatus) error {
+	if err := r.TaskRepository.BulkUpdateStatus(ctx, taskIDs, status); err != nil {
+		return err
+	}
+	for _, id := range taskIDs {
+		if task, err := r.TaskRepository.FindByID(ctx, id); err == nil {
+			r.publish(ctx, domain.TaskStreamTaskUpdated, task, "", streamActor(ctx, ""))
+		}
+	}
+	return nil
+}
+
+func (r *StreamingTaskRepository) publish(ctx context.Context, eventType domain.TaskStreamEventType, task *domain.Task, messageID, actorID string) {
+	r.publisher.Publish(ctx, domain.NewTaskStreamEvent(eventType, task, messageID, actorID))
+}
+
+// streamActor автор изменения: аутентифицированный пользователь запроса, иначе fallback
+func streamActor(ctx context.Context, fallback string) string {
+	if user, ok := ports.AuthenticatedUser(ctx); ok {
+		return user.ID
+	}
+	return fallback
+}
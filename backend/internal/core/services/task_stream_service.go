@@ -0,0 +1,58 @@
+// internal/core/services/task_stream_service.go
+package services
+
+import (
+	"context"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// TaskStreamService реализует ports.TaskStreamService: подписчик получает только события
+// задач, видимых ему (domain.TaskScope), а внутренние заметки - при наличии права
+// domain.PermissionMessagesPrivate. Права фиксируются при подписке; смена роли
+// учитывается после переподключения
+type TaskStreamService struct {
+	broker     ports.TaskStreamBroker
+	authorizer ports.Authorizer
+	logger     ports.Logger
+}
+
+func NewTaskStreamService(broker ports.TaskStreamBroker, authorizer ports.Authorizer, logger ports.Logger) *TaskStreamService {
+	return &TaskStreamService{
+		broker:     broker,
+		authorizer: authorizer,
+		logger:     logger,
+	}
+}
+
+func (s *TaskStreamService) Subscribe(ctx context.Context, req ports.TaskStreamRequest) (ports.TaskStreamSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, domain.PermissionTasksRead); err != nil {
+		return nil, err
+	}
+
+	scope := s.authorizer.TaskScope(ctx)
+	canReadPrivate := s.authorizer.Can(ctx, domain.PermissionMessagesPrivate)
+	filter := req.Filter
+
+	accept := func(event domain.TaskStreamEvent) (domain.TaskStreamEvent, bool) {
+		if event.Task == nil || !filter.Matches(event) || !scope.Includes(event.Task) {
+			return event, false
+		}
+		if !canReadPrivate {
+			if message := event.Message(); message != nil && message.IsPrivate() {
+				return event, false
+			}
+		}
+		event.Task = redactTask(ctx, s.authorizer, event.Task)
+		return event, true
+	}
+
+	subscription := s.broker.Subscribe(req.LastEventID, accept)
+	s.logger.Debug(ctx, "task stream subscribed",
+		"queues", filter.Queues,
+		"task_ids", filter.TaskIDs,
+		"last_event_id", req.LastEventID,
+		"replayed", len(subscription.Replay()))
+	return subscription, nil
+}
@@ -0,0 +1,243 @@
+// internal/core/services/task_stream_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamingTaskService(config services.TaskStreamConfig) (*services.TaskService, *services.TaskStreamService) {
+	taskService, _, streamService := newStreamingTaskRepository(config)
+	return taskService, streamService
+}
+
+func newStreamingTaskRepository(config services.TaskStreamConfig) (*services.TaskService, ports.TaskRepository, *services.TaskStreamService) {
+	logger := &services.MockLogger{}
+	broker := services.NewTaskStreamBroker(config, logger)
+	taskRepo := services.NewStreamingTaskRepository(inmemory.NewTaskRepository(logger), broker)
+	taskService := services.NewTaskService(
+		taskRepo, inmemory.NewCustomerRepository(logger), inmemory.NewUserRepository(logger), logger)
+	return taskService, taskRepo, services.NewTaskStreamService(broker, services.NewRoleAuthorizer(logger), logger)
+}
+
+func createStreamTask(t *testing.T, taskService *services.TaskService, category string) *domain.Task {
+	t.Helper()
+	task, err := taskService.CreateInternalTask(context.Background(), ports.CreateInternalTaskRequest{
+		Subject:     "Задача " + category,
+		Description: "Описание",
+		ReporterID:  "user-2",
+		Priority:    domain.PriorityMedium,
+		Category:    category,
+	})
+	require.NoError(t, err)
+	return task
+}
+
+// drain читает события, уже находящиеся в очереди подписчика
+func drain(subscription ports.TaskStreamSubscription) []domain.TaskStreamEvent {
+	var events []domain.TaskStreamEvent
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestTaskStream_TaskServicePublishesChanges(t *testing.T) {
+	ctx := ports.WithSystemPrincipal(context.Background())
+	taskService, streamService := newStreamingTaskService(services.DefaultTaskStreamConfig())
+
+	subscription, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{})
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	task := createStreamTask(t, taskService, "billing")
+	_, err = taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusInProgress, "user-2")
+	require.NoError(t, err)
+	updated, err := taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{AuthorID: "user-3", Content: "Ответ"})
+	require.NoError(t, err)
+	require.NoError(t, taskService.DeleteTask(ctx, task.ID))
+
+	events := drain(subscription)
+	require.Len(t, events, 4)
+
+	types := make([]domain.TaskStreamEventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+		assert.Equal(t, task.ID, event.TaskID)
+		if i > 0 {
+			assert.Greater(t, event.ID, events[i-1].ID, "event IDs increase")
+		}
+	}
+	assert.Equal(t, []domain.TaskStreamEventType{
+		domain.TaskStreamTaskCreated, domain.TaskStreamTaskUpdated,
+		domain.TaskStreamMessageAdded, domain.TaskStreamTaskDeleted,
+	}, types)
+
+	assert.Equal(t, domain.TaskStatusInProgress, events[1].Task.Status, "event carries task snapshot")
+	message := events[2].Message()
+	require.NotNil(t, message)
+	assert.Equal(t, updated.Messages[len(updated.Messages)-1].ID, message.ID)
+	assert.Equal(t, "user-3", events[2].ActorID)
+}
+
+func TestTaskStream_RepositoryWritesReachSubscribers(t *testing.T) {
+	ctx := ports.WithSystemPrincipal(context.Background())
+	taskService, taskRepo, streamService := newStreamingTaskRepository(services.DefaultTaskStreamConfig())
+
+	subscription, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{})
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	task := createStreamTask(t, taskService, "billing")
+
+	// Интеграции и фоновые задачи меняют задачи через репозиторий, минуя TaskService
+	stored, err := taskRepo.FindByID(ctx, task.ID)
+	require.NoError(t, err)
+	require.NoError(t, stored.ChangeStatus(domain.TaskStatusInProgress, "github"))
+	require.NoError(t, taskRepo.Update(ctx, stored))
+
+	// Автор - пользователь запроса
+	operator := ports.WithAuthenticatedUser(ctx, &domain.User{ID: "user-7", Role: domain.UserRoleOperator})
+	_, err = taskService.ChangeStatus(operator, task.ID, domain.TaskStatusReview, "user-2")
+	require.NoError(t, err)
+
+	events := drain(subscription)
+	require.Len(t, events, 3)
+	assert.Equal(t, domain.TaskStreamTaskUpdated, events[1].Type)
+	assert.Equal(t, "github", events[1].ActorID, "without a user the actor comes from the task event")
+	assert.Equal(t, domain.TaskStatusInProgress, events[1].Task.Status)
+	assert.Equal(t, "user-7", events[2].ActorID)
+}
+
+func TestTaskStream_ReplayAfterReconnect(t *testing.T) {
+	ctx := ports.WithSystemPrincipal(context.Background())
+	taskService, streamService := newStreamingTaskService(services.TaskStreamConfig{BufferSize: 3, SubscriberBuffer: 10})
+
+	first, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{})
+	require.NoError(t, err)
+	createStreamTask(t, taskService, "billing")
+	received := drain(first)
+	require.Len(t, received, 1)
+	first.Close()
+
+	// Пока клиент отключен, происходят два изменения
+	createStreamTask(t, taskService, "support")
+	createStreamTask(t, taskService, "sales")
+
+	reconnected, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{LastEventID: received[0].ID})
+	require.NoError(t, err)
+	defer reconnected.Close()
+	assert.False(t, reconnected.Incomplete())
+	require.Len(t, reconnected.Replay(), 2)
+	assert.Equal(t, "support", reconnected.Replay()[0].Task.Category)
+
+	// Еще два изменения вытесняют из буфера событие, следующее за received[0]
+	createStreamTask(t, taskService, "legal")
+	createStreamTask(t, taskService, "hr")
+
+	late, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{LastEventID: received[0].ID})
+	require.NoError(t, err)
+	defer late.Close()
+	assert.True(t, late.Incomplete(), "evicted events are reported")
+	assert.Len(t, late.Replay(), 3)
+
+	unknown, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{LastEventID: 42})
+	require.NoError(t, err)
+	defer unknown.Close()
+	assert.True(t, unknown.Incomplete(), "ID from before restart is older than the buffer")
+}
+
+func TestTaskStream_SlowSubscriberIsDisconnected(t *testing.T) {
+	ctx := ports.WithSystemPrincipal(context.Background())
+	taskService, streamService := newStreamingTaskService(services.TaskStreamConfig{BufferSize: 10, SubscriberBuffer: 2})
+
+	slow, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{})
+	require.NoError(t, err)
+	defer slow.Close()
+
+	for range 3 {
+		createStreamTask(t, taskService, "billing")
+	}
+
+	events := drain(slow)
+	assert.Len(t, events, 2)
+	_, open := <-slow.Events()
+	assert.False(t, open, "channel is closed after overflow")
+	assert.ErrorIs(t, slow.Err(), domain.ErrTaskStreamLagged)
+
+	// После переподключения пропущенное событие повторяется из буфера
+	resumed, err := streamService.Subscribe(ctx, ports.TaskStreamRequest{LastEventID: events[1].ID})
+	require.NoError(t, err)
+	defer resumed.Close()
+	assert.False(t, resumed.Incomplete())
+	assert.Len(t, resumed.Replay(), 1)
+}
+
+func TestTaskStream_FiltersByPermissionsAndSubscription(t *testing.T) {
+	ctx := ports.WithSystemPrincipal(context.Background())
+	taskService, streamService := newStreamingTaskService(services.DefaultTaskStreamConfig())
+
+	operator, err := streamService.Subscribe(asUser(domain.UserRoleOperator, "billing"), ports.TaskStreamRequest{})
+	require.NoError(t, err)
+	defer operator.Close()
+	viewer, err := streamService.Subscribe(asUser(domain.UserRoleViewer), ports.TaskStreamRequest{})
+	require.NoError(t, err)
+	defer viewer.Close()
+
+	billing := createStreamTask(t, taskService, "billing")
+	support := createStreamTask(t, taskService, "support")
+
+	queue, err := streamService.Subscribe(asUser(domain.UserRoleManager), ports.TaskStreamRequest{
+		Filter: domain.TaskStreamFilter{Queues: []string{"support"}},
+	})
+	require.NoError(t, err)
+	defer queue.Close()
+	single, err := streamService.Subscribe(asUser(domain.UserRoleManager), ports.TaskStreamRequest{
+		Filter: domain.TaskStreamFilter{TaskIDs: []string{billing.ID}},
+	})
+	require.NoError(t, err)
+	defer single.Close()
+
+	_, err = taskService.AddInternalNote(ctx, billing.ID, "user-2", "Внутренняя заметка")
+	require.NoError(t, err)
+	_, err = taskService.ChangeStatus(ctx, support.ID, domain.TaskStatusInProgress, "user-2")
+	require.NoError(t, err)
+
+	operatorEvents := drain(operator)
+	require.Len(t, operatorEvents, 2, "operator sees only tasks of own categories")
+	for _, event := range operatorEvents {
+		assert.Equal(t, billing.ID, event.TaskID)
+	}
+	assert.Equal(t, domain.TaskStreamMessageAdded, operatorEvents[1].Type)
+
+	viewerEvents := drain(viewer)
+	require.Len(t, viewerEvents, 3, "viewer does not receive internal notes")
+	for _, event := range viewerEvents {
+		assert.Empty(t, event.Task.Messages, "internal notes are removed from snapshots")
+	}
+
+	queueEvents := drain(queue)
+	require.Len(t, queueEvents, 1)
+	assert.Equal(t, support.ID, queueEvents[0].TaskID)
+
+	singleEvents := drain(single)
+	require.Len(t, singleEvents, 1)
+	assert.Equal(t, billing.ID, singleEvents[0].TaskID)
+
+	_, err = streamService.Subscribe(ports.WithAuthenticatedUser(ctx, &domain.User{ID: "u", Role: "unknown"}), ports.TaskStreamRequest{})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
@@ -0,0 +1,213 @@
+// internal/core/services/task_time_tracking.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// LogWork списывает время на задачу
+func (s *TaskService) LogWork(ctx context.Context, id string, req ports.LogWorkRequest) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	workLog, err := task.LogWork(req.UserID, req.Duration, req.Date, req.Note, req.Billable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log work: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "work logged",
+		"task_id", task.ID,
+		"work_log_id", workLog.ID,
+		"user_id", req.UserID,
+		"minutes", int(workLog.Duration.Minutes()),
+		"billable", req.Billable,
+	)
+
+	return task, nil
+}
+
+// DeleteWorkLog удаляет запись учета времени
+func (s *TaskService) DeleteWorkLog(ctx context.Context, id string, workLogID string, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.RemoveWorkLog(workLogID, userID); err != nil {
+		return nil, fmt.Errorf("failed to remove work log: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "work log removed", "task_id", task.ID, "work_log_id", workLogID, "user_id", userID)
+	return task, nil
+}
+
+// StartTimer запускает таймер учета времени
+func (s *TaskService) StartTimer(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if err := task.StartTimer(userID); err != nil {
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "timer started", "task_id", task.ID, "user_id", userID)
+	return task, nil
+}
+
+// StopTimer останавливает таймер и списывает накопленное время
+func (s *TaskService) StopTimer(ctx context.Context, id string, req ports.StopTimerRequest) (*domain.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	workLog, err := task.StopTimer(req.UserID, req.Note, req.Billable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	s.logger.Info(ctx, "timer stopped",
+		"task_id", task.ID,
+		"user_id", req.UserID,
+		"minutes", int(workLog.Duration.Minutes()),
+	)
+
+	return task, nil
+}
+
+// GetTimeReport строит отчет по списанному времени за период
+func (s *TaskService) GetTimeReport(ctx context.Context, query ports.TimeReportQuery) (*ports.TimeReport, error) {
+	if query.From.IsZero() || query.To.IsZero() {
+		return nil, errors.New("report period is required")
+	}
+	if !query.From.Before(query.To) {
+		return nil, errors.New("report period start must be before end")
+	}
+	if query.GroupBy == "" {
+		query.GroupBy = ports.TimeReportByCustomer
+	}
+
+	switch query.GroupBy {
+	case ports.TimeReportByCustomer, ports.TimeReportByOrganization, ports.TimeReportByAssignee:
+	default:
+		return nil, fmt.Errorf("unsupported report grouping: %s", query.GroupBy)
+	}
+
+	tasks, err := s.taskRepo.FindByQuery(ctx, ports.TaskQuery{CustomerID: query.CustomerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	report := &ports.TimeReport{
+		From:    query.From,
+		To:      query.To,
+		GroupBy: query.GroupBy,
+	}
+
+	rows := make(map[string]*ports.TimeReportRow)
+	rowTasks := make(map[string]map[string]bool)
+
+	for _, task := range tasks {
+		for _, workLog := range task.WorkLogs {
+			if workLog.Date.Before(query.From) || !workLog.Date.Before(query.To) {
+				continue
+			}
+			if query.UserID != "" && workLog.UserID != query.UserID {
+				continue
+			}
+			if query.BillableOnly && !workLog.Billable {
+				continue
+			}
+
+			key, name := s.timeReportGroup(ctx, query.GroupBy, task, workLog)
+			row, exists := rows[key]
+			if !exists {
+				row = &ports.TimeReportRow{Key: key, Name: name}
+				rows[key] = row
+				rowTasks[key] = make(map[string]bool)
+			}
+
+			minutes := int(workLog.Duration.Minutes())
+			row.TotalMinutes += minutes
+			row.EntryCount++
+			report.TotalMinutes += minutes
+			if workLog.Billable {
+				row.BillableMinutes += minutes
+				report.BillableMinutes += minutes
+			}
+			rowTasks[key][task.ID] = true
+		}
+	}
+
+	report.Rows = make([]ports.TimeReportRow, 0, len(rows))
+	for key, row := range rows {
+		row.TaskCount = len(rowTasks[key])
+		report.Rows = append(report.Rows, *row)
+	}
+
+	// Больше всего времени - первыми
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].TotalMinutes != report.Rows[j].TotalMinutes {
+			return report.Rows[i].TotalMinutes > report.Rows[j].TotalMinutes
+		}
+		return report.Rows[i].Key < report.Rows[j].Key
+	})
+
+	return report, nil
+}
+
+// timeReportGroup определяет строку отчета для записи учета времени
+func (s *TaskService) timeReportGroup(ctx context.Context, groupBy ports.TimeReportGroupBy, task domain.Task, workLog domain.WorkLog) (string, string) {
+	switch groupBy {
+	case ports.TimeReportByAssignee:
+		if user, err := s.userRepo.FindByID(ctx, workLog.UserID); err == nil && user != nil {
+			return user.ID, user.Name
+		}
+		return workLog.UserID, workLog.UserID
+
+	case ports.TimeReportByOrganization:
+		if task.CustomerID == nil {
+			return "", "Без организации"
+		}
+		customer, err := s.customerRepo.FindByID(ctx, *task.CustomerID)
+		if err != nil || customer == nil || customer.Organization == nil {
+			return "", "Без организации"
+		}
+		return customer.Organization.ID, customer.Organization.Name
+
+	default:
+		if task.CustomerID == nil {
+			return "", "Внутренние задачи"
+		}
+		if customer, err := s.customerRepo.FindByID(ctx, *task.CustomerID); err == nil && customer != nil {
+			return customer.ID, customer.Name
+		}
+		return *task.CustomerID, *task.CustomerID
+	}
+}
@@ -0,0 +1,128 @@
+// internal/core/services/task_time_tracking_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskService_TimeTracking(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+
+	acme, err := customerService.CreateCustomer(ctx, ports.CreateCustomerRequest{
+		Name: "Acme", Email: "acme@example.com", Organization: "Acme Corp",
+	})
+	require.NoError(t, err)
+	globex, err := customerService.CreateCustomer(ctx, ports.CreateCustomerRequest{
+		Name: "Globex", Email: "globex@example.com",
+	})
+	require.NoError(t, err)
+
+	newSupportTask := func(customerID string) *domain.Task {
+		task, err := taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+			Subject:     "Support",
+			Description: "Need help",
+			CustomerID:  customerID,
+			ReporterID:  customerID,
+			Source:      domain.SourceAPI,
+			Priority:    domain.PriorityMedium,
+		})
+		require.NoError(t, err)
+		return task
+	}
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	acmeTask := newSupportTask(acme.ID)
+	globexTask := newSupportTask(globex.ID)
+
+	logWork := func(taskID, userID string, minutes int, date time.Time, billable bool) {
+		_, err := taskService.LogWork(ctx, taskID, ports.LogWorkRequest{
+			UserID:   userID,
+			Duration: time.Duration(minutes) * time.Minute,
+			Date:     date,
+			Billable: billable,
+		})
+		require.NoError(t, err)
+	}
+
+	logWork(acmeTask.ID, "user-2", 60, day, true)
+	logWork(acmeTask.ID, "user-3", 30, day, false)
+	logWork(globexTask.ID, "user-3", 45, day.AddDate(0, 0, 1), true)
+	logWork(globexTask.ID, "user-3", 15, day.AddDate(0, 0, 10), true) // Вне периода
+
+	t.Run("report grouped by customer", func(t *testing.T) {
+		report, err := taskService.GetTimeReport(ctx, ports.TimeReportQuery{
+			From: day,
+			To:   day.AddDate(0, 0, 7),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, acme.ID, report.Rows[0].Key)
+		assert.Equal(t, 90, report.Rows[0].TotalMinutes)
+		assert.Equal(t, 60, report.Rows[0].BillableMinutes)
+		assert.Equal(t, 135, report.TotalMinutes)
+		assert.Equal(t, 105, report.BillableMinutes)
+	})
+
+	t.Run("report grouped by assignee and organization", func(t *testing.T) {
+		report, err := taskService.GetTimeReport(ctx, ports.TimeReportQuery{
+			From:    day,
+			To:      day.AddDate(0, 0, 7),
+			GroupBy: ports.TimeReportByAssignee,
+		})
+		require.NoError(t, err)
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, "user-3", report.Rows[0].Key)
+		assert.Equal(t, 75, report.Rows[0].TotalMinutes)
+		assert.Equal(t, 2, report.Rows[0].TaskCount)
+
+		report, err = taskService.GetTimeReport(ctx, ports.TimeReportQuery{
+			From:         day,
+			To:           day.AddDate(0, 0, 7),
+			GroupBy:      ports.TimeReportByOrganization,
+			BillableOnly: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, report.Rows, 2)
+		assert.Equal(t, "Acme Corp", report.Rows[0].Name)
+		assert.Equal(t, 60, report.Rows[0].TotalMinutes)
+	})
+
+	t.Run("invalid report period", func(t *testing.T) {
+		_, err := taskService.GetTimeReport(ctx, ports.TimeReportQuery{From: day, To: day})
+		assert.Error(t, err)
+	})
+
+	t.Run("timer creates work log", func(t *testing.T) {
+		_, err := taskService.StartTimer(ctx, acmeTask.ID, "user-2")
+		require.NoError(t, err)
+
+		task, err := taskService.StopTimer(ctx, acmeTask.ID, ports.StopTimerRequest{UserID: "user-2", Billable: true})
+		require.NoError(t, err)
+		assert.Len(t, task.WorkLogs, 3)
+		assert.Empty(t, task.Timers)
+	})
+
+	t.Run("customer stats include billable time", func(t *testing.T) {
+		profile, err := customerService.GetCustomerProfile(ctx, acme.ID)
+		require.NoError(t, err)
+		assert.InDelta(t, 91.0/60, profile.Stats.TimeSpentHours, 0.001)
+		assert.InDelta(t, 61.0/60, profile.Stats.BillableHours, 0.001)
+	})
+}
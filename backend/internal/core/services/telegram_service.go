@@ -0,0 +1,368 @@
+// internal/core/services/telegram_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// telegramSentEventsTTL сколько помнить отправленные в чат события
+const telegramSentEventsTTL = 24 * time.Hour
+
+// TelegramService ведет переписку с клиентами через Telegram-бота.
+// Чат соответствует клиенту, переписка - задаче поддержки: сообщения дополняют
+// последнюю незакрытую задачу чата, после закрытия создается новая.
+// Публичные ответы операторов отправляются обратно в чат
+type TelegramService struct {
+	gateway         ports.TelegramGateway
+	taskRepo        ports.TaskRepository
+	taskService     ports.TaskService
+	customerService ports.CustomerService
+	deliveries      ports.InboundDeliveryService
+	pollTimeout     time.Duration
+	logger          ports.Logger
+
+	mu         sync.Mutex
+	offset     int64
+	sentEvents map[string]time.Time
+}
+
+func NewTelegramService(
+	gateway ports.TelegramGateway,
+	taskRepo ports.TaskRepository,
+	taskService ports.TaskService,
+	customerService ports.CustomerService,
+	pollTimeout time.Duration,
+	logger ports.Logger,
+) *TelegramService {
+	return &TelegramService{
+		gateway:         gateway,
+		taskRepo:        taskRepo,
+		taskService:     taskService,
+		customerService: customerService,
+		pollTimeout:     pollTimeout,
+		logger:          logger,
+		sentEvents:      make(map[string]time.Time),
+	}
+}
+
+// PollUpdates получает обновления начиная с последнего подтвержденного.
+// При ошибке обработки смещение останавливается на сообщении с ошибкой,
+// и следующий вызов получит его повторно
+func (s *TelegramService) PollUpdates(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	offset := s.offset
+	s.mu.Unlock()
+
+	messages, next, err := s.gateway.GetUpdates(ctx, offset, s.pollTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get telegram updates: %w", err)
+	}
+
+	handled := 0
+	for _, message := range messages {
+		if _, err := s.HandleMessage(ctx, message); err != nil {
+			s.setOffset(message.UpdateID)
+			return handled, fmt.Errorf("failed to handle telegram message %s: %w", message.ExternalID(), err)
+		}
+		handled++
+	}
+
+	s.setOffset(next)
+	return handled, nil
+}
+
+func (s *TelegramService) setOffset(offset int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if offset > s.offset {
+		s.offset = offset
+	}
+}
+
+// SetDeliveryService включает защиту от конкурентной повторной доставки:
+// уникальный ключ сообщения занимается на время его обработки
+func (s *TelegramService) SetDeliveryService(deliveries ports.InboundDeliveryService) {
+	s.deliveries = deliveries
+}
+
+// HandleMessage принимает сообщение чата
+func (s *TelegramService) HandleMessage(ctx context.Context, message domain.TelegramMessage) (*ports.InboundResult, error) {
+	return acceptInbound(ctx, s.deliveries, s.taskRepo, s.logger, domain.TelegramChannelKey, message.ExternalID(), func() (*ports.InboundResult, error) {
+		return s.handleMessage(ctx, message)
+	})
+}
+
+// handleMessage создает задачу по сообщению или дополняет текущую задачу чата
+func (s *TelegramService) handleMessage(ctx context.Context, message domain.TelegramMessage) (*ports.InboundResult, error) {
+	// Telegram повторяет обновление, если не получил подтверждение
+	existing, err := s.findTask(ctx, domain.InboundExternalIDCriteria(domain.TelegramChannelKey, message.ExternalID()))
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		s.logger.Info(ctx, "telegram message already accepted",
+			"external_id", message.ExternalID(), "task_id", existing.ID)
+		return &ports.InboundResult{Action: ports.InboundActionDuplicate, Task: existing}, nil
+	}
+
+	customer, err := s.customerService.FindOrCreateByEmail(ctx, domain.TelegramCustomerEmail(message.ChatID), message.DisplayName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find or create customer: %w", err)
+	}
+
+	attachments := s.downloadAttachments(ctx, message)
+	content := buildTelegramMessageContent(message)
+
+	conversation, err := s.findConversation(ctx, message.ThreadID())
+	if err != nil {
+		return nil, err
+	}
+	if conversation != nil {
+		return s.appendToTask(ctx, conversation, message, customer.ID, content, attachments)
+	}
+
+	tags := []string{domain.TelegramChannelKey}
+	if len(message.Attachments) > 0 {
+		tags = append(tags, "has-attachments")
+	}
+	task, err := s.taskService.CreateSupportTask(ctx, ports.CreateSupportTaskRequest{
+		Subject:     message.Subject(),
+		Description: buildTelegramTaskDescription(message),
+		CustomerID:  customer.ID,
+		ReporterID:  customer.ID,
+		Source:      domain.SourceTelegram,
+		SourceMeta:  message.SourceMeta(),
+		Priority:    domain.PriorityMedium,
+		Tags:        tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	// Как и для email, первое сообщение хранится в переписке, а не в описании
+	err = ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		updated, err := s.taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+			AuthorID:    customer.ID,
+			Content:     content,
+			Type:        domain.MessageTypeCustomer,
+			Attachments: attachments,
+		})
+		if err == nil {
+			task = updated
+		}
+		return err
+	})
+	if err != nil {
+		s.logger.Warn(ctx, "failed to add first telegram message to new task",
+			"task_id", task.ID, "external_id", message.ExternalID(), "error", err.Error())
+	}
+
+	s.logger.Info(ctx, "task created from telegram message",
+		"chat_id", message.ChatID,
+		"external_id", message.ExternalID(),
+		"task_id", task.ID,
+		"customer_id", customer.ID)
+	return &ports.InboundResult{Action: ports.InboundActionCreated, Task: task}, nil
+}
+
+// appendToTask добавляет сообщение в переписку. Сообщение и его внешний ID
+// сохраняются одним изменением задачи, поэтому повтор не продублирует сообщение
+func (s *TelegramService) appendToTask(ctx context.Context, task *domain.Task, message domain.TelegramMessage, customerID, content string, attachments []domain.Attachment) (*ports.InboundResult, error) {
+	var updated *domain.Task
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		var err error
+		updated, err = s.taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+			AuthorID:    customerID,
+			Content:     content,
+			Type:        domain.MessageTypeCustomer,
+			Attachments: attachments,
+			ExternalID:  message.ExternalID(),
+		})
+		return err
+	})
+	if errors.Is(err, domain.ErrInboundDuplicate) {
+		s.logger.Info(ctx, "telegram message already accepted",
+			"external_id", message.ExternalID(), "task_id", task.ID)
+		return &ports.InboundResult{Action: ports.InboundActionDuplicate, Task: task}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add message to task: %w", err)
+	}
+
+	s.logger.Info(ctx, "telegram message appended to task",
+		"chat_id", message.ChatID,
+		"external_id", message.ExternalID(),
+		"task_id", task.ID)
+	return &ports.InboundResult{Action: ports.InboundActionAppended, Task: updated}, nil
+}
+
+// downloadAttachments скачивает файлы сообщения. Недоступный файл не блокирует
+// сообщение: он остается в списке вложений текста сообщения
+func (s *TelegramService) downloadAttachments(ctx context.Context, message domain.TelegramMessage) []domain.Attachment {
+	var attachments []domain.Attachment
+	for _, file := range message.Attachments {
+		data, err := s.gateway.DownloadFile(ctx, file.FileID)
+		if err != nil {
+			s.logger.Warn(ctx, "failed to download telegram file",
+				"external_id", message.ExternalID(), "file_id", file.FileID, "error", err.Error())
+			continue
+		}
+		attachments = append(attachments, domain.Attachment{
+			Name:        file.FileName,
+			ContentType: file.MimeType,
+			Size:        int64(len(data)),
+			Data:        data,
+		})
+	}
+	return attachments
+}
+
+// HandleTaskEvent отправляет в чат публичный ответ оператора
+func (s *TelegramService) HandleTaskEvent(ctx context.Context, event domain.DomainEvent) error {
+	if event.Type != domain.DomainEventTaskMessageAdded ||
+		event.Data["message_type"] != string(domain.MessageTypeCustomer) {
+		return nil
+	}
+	if s.wasSent(event.ID) {
+		return nil
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, event.AggregateID)
+	if err != nil {
+		return fmt.Errorf("failed to find task: %w", err)
+	}
+	chatID, ok := domain.TelegramChatID(task)
+	if !ok {
+		return nil
+	}
+
+	var message *domain.Message
+	for i := range task.Messages {
+		if task.Messages[i].ID == event.Data["message_id"] {
+			message = &task.Messages[i]
+			break
+		}
+	}
+	// Сообщения самого клиента уже есть в чате
+	if message == nil || (task.CustomerID != nil && message.AuthorID == *task.CustomerID) {
+		return nil
+	}
+
+	if err := s.gateway.SendMessage(ctx, chatID, message.Content); err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+	for _, attachment := range message.Attachments {
+		if len(attachment.Data) == 0 {
+			continue
+		}
+		if err := s.gateway.SendAttachment(ctx, chatID, attachment); err != nil {
+			return fmt.Errorf("failed to send telegram attachment %s: %w", attachment.Name, err)
+		}
+	}
+	s.markSent(event.ID)
+
+	s.logger.Info(ctx, "operator reply sent to telegram",
+		"task_id", task.ID,
+		"message_id", message.ID,
+		"chat_id", chatID,
+		"attachments", len(message.Attachments))
+	return nil
+}
+
+func (s *TelegramService) wasSent(eventID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, sent := s.sentEvents[eventID]
+	return sent
+}
+
+func (s *TelegramService) markSent(eventID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, sentAt := range s.sentEvents {
+		if now.Sub(sentAt) > telegramSentEventsTTL {
+			delete(s.sentEvents, id)
+		}
+	}
+	s.sentEvents[eventID] = now
+}
+
+// findConversation возвращает последнюю незакрытую задачу чата
+func (s *TelegramService) findConversation(ctx context.Context, threadID string) (*domain.Task, error) {
+	tasks, err := s.taskService.FindBySourceMeta(ctx, domain.InboundThreadCriteria(domain.TelegramChannelKey, threadID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks by source meta: %w", err)
+	}
+
+	var latest *domain.Task
+	for i := range tasks {
+		if !tasks[i].IsConversationOpen() {
+			continue
+		}
+		if latest == nil || tasks[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &tasks[i]
+		}
+	}
+	return latest, nil
+}
+
+func (s *TelegramService) findTask(ctx context.Context, criteria map[string]interface{}) (*domain.Task, error) {
+	tasks, err := s.taskService.FindBySourceMeta(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks by source meta: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	return &tasks[0], nil
+}
+
+// buildTelegramMessageContent текст сообщения со списком вложений в формате входящих писем
+func buildTelegramMessageContent(message domain.TelegramMessage) string {
+	var content strings.Builder
+	content.WriteString(strings.TrimSpace(message.Text))
+
+	if len(message.Attachments) > 0 {
+		if content.Len() > 0 {
+			content.WriteString("\n\n")
+		}
+		content.WriteString(fmt.Sprintf("📎 Вложения: %d файл(ов)", len(message.Attachments)))
+		for _, file := range message.Attachments {
+			content.WriteString(fmt.Sprintf("\n- %s (%s, %d bytes)", file.FileName, file.MimeType, file.Size))
+		}
+	}
+
+	if content.Len() == 0 {
+		return "[No message content]"
+	}
+	return content.String()
+}
+
+// buildTelegramTaskDescription описание задачи с мета-информацией о чате
+func buildTelegramTaskDescription(message domain.TelegramMessage) string {
+	var description strings.Builder
+
+	description.WriteString("Заявка создана автоматически из сообщения в Telegram.\n\n")
+	description.WriteString("От: " + message.DisplayName())
+	if message.Username != "" {
+		description.WriteString(" (@" + message.Username + ")")
+	}
+	description.WriteString("\n")
+	description.WriteString(fmt.Sprintf("Чат: %d\n", message.ChatID))
+	if !message.SentAt.IsZero() {
+		description.WriteString("Дата: " + message.SentAt.Format("2006-01-02 15:04:05") + "\n")
+	}
+	description.WriteString("\nСодержимое первого сообщения доступно в истории переписки.")
+
+	return description.String()
+}
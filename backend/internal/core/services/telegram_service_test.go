@@ -0,0 +1,257 @@
+// internal/core/services/telegram_service_test.go
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/telegram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBotToken = "123456:test-token"
+
+// botAPIStub заглушка Bot API: отдает подготовленные обновления и файлы,
+// запоминает отправленные ботом сообщения
+type botAPIStub struct {
+	mu      sync.Mutex
+	updates []string
+	offsets []int64
+	files   map[string][]byte
+	sent    []botAPICall
+}
+
+type botAPICall struct {
+	Method   string
+	ChatID   string
+	Text     string
+	FileName string
+	Data     []byte
+}
+
+func (s *botAPIStub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if path, ok := strings.CutPrefix(req.URL.Path, "/file/bot"+testBotToken+"/"); ok {
+		w.Write(s.files[path])
+		return
+	}
+	method, ok := strings.CutPrefix(req.URL.Path, "/bot"+testBotToken+"/")
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"ok":false,"error_code":401,"description":"Unauthorized"}`)
+		return
+	}
+
+	switch method {
+	case "getUpdates":
+		var params struct {
+			Offset int64 `json:"offset"`
+		}
+		json.NewDecoder(req.Body).Decode(&params)
+		s.offsets = append(s.offsets, params.Offset)
+		result := "[" + strings.Join(s.updates, ",") + "]"
+		s.updates = nil
+		fmt.Fprintf(w, `{"ok":true,"result":%s}`, result)
+	case "getFile":
+		var params struct {
+			FileID string `json:"file_id"`
+		}
+		json.NewDecoder(req.Body).Decode(&params)
+		fmt.Fprintf(w, `{"ok":true,"result":{"file_id":%q,"file_path":"files/%s"}}`, params.FileID, params.FileID)
+	case "sendMessage":
+		var params struct {
+			ChatID int64  `json:"chat_id"`
+			Text   string `json:"text"`
+		}
+		json.NewDecoder(req.Body).Decode(&params)
+		s.sent = append(s.sent, botAPICall{Method: method, ChatID: fmt.Sprint(params.ChatID), Text: params.Text})
+		fmt.Fprint(w, `{"ok":true,"result":{}}`)
+	case "sendPhoto", "sendDocument":
+		field := strings.ToLower(strings.TrimPrefix(method, "send"))
+		file, header, err := req.FormFile(field)
+		if err != nil {
+			fmt.Fprintf(w, `{"ok":false,"error_code":400,"description":%q}`, err.Error())
+			return
+		}
+		data, _ := io.ReadAll(file)
+		s.sent = append(s.sent, botAPICall{Method: method, ChatID: req.FormValue("chat_id"), FileName: header.Filename, Data: data})
+		fmt.Fprint(w, `{"ok":true,"result":{}}`)
+	default:
+		fmt.Fprintf(w, `{"ok":false,"error_code":404,"description":"unknown method %s"}`, method)
+	}
+}
+
+func (s *botAPIStub) queue(updates ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updates = append(s.updates, updates...)
+}
+
+func (s *botAPIStub) sentCalls() []botAPICall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]botAPICall(nil), s.sent...)
+}
+
+func newTestTelegramService(t *testing.T) (*services.TelegramService, *botAPIStub, ports.TaskService, ports.CustomerRepository) {
+	stub := &botAPIStub{files: map[string][]byte{"files/photo-big": []byte("jpeg-bytes")}}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	logger := &services.MockLogger{}
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	taskService := services.NewTaskService(taskRepo, customerRepo, inmemory.NewUserRepository(logger), logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+
+	service := services.NewTelegramService(
+		telegram.NewClient(server.URL, testBotToken, 5*time.Second),
+		taskRepo,
+		taskService,
+		customerService,
+		0,
+		logger,
+	)
+	return service, stub, taskService, customerRepo
+}
+
+// lastTaskEvents доменные события последнего изменения задачи
+func lastTaskEvents(task *domain.Task) []domain.DomainEvent {
+	return domain.TaskDomainEvents(task, task.History[len(task.History)-1:])
+}
+
+func TestTelegramService_Conversation(t *testing.T) {
+	ctx := context.Background()
+	service, stub, taskService, customerRepo := newTestTelegramService(t)
+
+	stub.queue(
+		`{"update_id": 10, "message": {"message_id": 1, "date": 1700000000,
+			"from": {"id": 555, "first_name": "Иван", "last_name": "Петров", "username": "ivanp"},
+			"chat": {"id": 555, "type": "private"}, "text": "Не могу войти в кабинет\nПишет ошибку"}}`,
+		`{"update_id": 11, "edited_message": {"message_id": 1, "chat": {"id": 555}, "text": "правка"}}`,
+		`{"update_id": 12, "message": {"message_id": 2, "date": 1700000060,
+			"from": {"id": 555, "first_name": "Иван"}, "chat": {"id": 555, "type": "private"},
+			"caption": "Скриншот", "photo": [
+				{"file_id": "photo-small", "file_unique_id": "s", "width": 90, "height": 90, "file_size": 100},
+				{"file_id": "photo-big", "file_unique_id": "b", "width": 800, "height": 600, "file_size": 10}
+			]}}`,
+	)
+
+	handled, err := service.PollUpdates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, handled)
+
+	customer, err := customerRepo.FindByEmail(ctx, domain.TelegramCustomerEmail(555))
+	require.NoError(t, err)
+	require.NotNil(t, customer)
+	assert.Equal(t, "Иван Петров", customer.Name)
+
+	tasks, err := taskService.GetCustomerTasks(ctx, customer.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	task := tasks[0]
+	assert.Equal(t, domain.SourceTelegram, task.Source)
+	assert.Equal(t, "Не могу войти в кабинет", task.Subject)
+	assert.Equal(t, "555", task.SourceMeta[domain.SourceMetaExternalThreadID])
+	assert.Equal(t, []string{domain.TelegramChannelKey}, task.Tags)
+
+	require.Len(t, task.Messages, 2)
+	assert.Equal(t, "Не могу войти в кабинет\nПишет ошибку", task.Messages[0].Content)
+	assert.Equal(t, customer.ID, task.Messages[0].AuthorID)
+	assert.Contains(t, task.Messages[1].Content, "Скриншот")
+	assert.Contains(t, task.Messages[1].Content, "photo_b.jpg")
+	require.Len(t, task.Messages[1].Attachments, 1)
+	assert.Equal(t, []byte("jpeg-bytes"), task.Messages[1].Attachments[0].Data)
+	assert.Equal(t, "image/jpeg", task.Messages[1].Attachments[0].ContentType)
+
+	// Следующий запрос подтверждает все полученные обновления, включая пропущенные
+	_, err = service.PollUpdates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 13}, stub.offsets)
+
+	// Повторная доставка того же сообщения не дублирует переписку
+	result, err := service.HandleMessage(ctx, domain.TelegramMessage{ChatID: 555, MessageID: 1, Text: "Не могу войти в кабинет"})
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionDuplicate, result.Action)
+	assert.Equal(t, task.ID, result.Task.ID)
+
+	// Дополнившее задачу сообщение сохранено вместе со своим внешним ID
+	result, err = service.HandleMessage(ctx, domain.TelegramMessage{ChatID: 555, MessageID: 2, Text: "Скриншот"})
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionDuplicate, result.Action)
+	stored, err := taskService.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Messages, 2)
+
+	// После закрытия задачи новое сообщение открывает новое обращение
+	_, err = taskService.ChangeStatus(ctx, task.ID, domain.TaskStatusClosed, "operator-1")
+	require.NoError(t, err)
+	result, err = service.HandleMessage(ctx, domain.TelegramMessage{ChatID: 555, MessageID: 3, FirstName: "Иван", Text: "Снова не работает"})
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionCreated, result.Action)
+	assert.NotEqual(t, task.ID, result.Task.ID)
+	assert.Equal(t, customer.ID, *result.Task.CustomerID, "chat keeps its customer")
+
+	result, err = service.HandleMessage(ctx, domain.TelegramMessage{ChatID: 555, MessageID: 4, Text: "Уточнение"})
+	require.NoError(t, err)
+	assert.Equal(t, ports.InboundActionAppended, result.Action)
+	assert.Len(t, result.Task.Messages, 2)
+}
+
+func TestTelegramService_OperatorReplies(t *testing.T) {
+	ctx := context.Background()
+	service, stub, taskService, _ := newTestTelegramService(t)
+
+	result, err := service.HandleMessage(ctx, domain.TelegramMessage{ChatID: 777, MessageID: 1, FirstName: "Анна", Text: "Нужен счет"})
+	require.NoError(t, err)
+	task := result.Task
+	customerEvents := lastTaskEvents(task)
+	require.Len(t, customerEvents, 1)
+
+	// Сообщение клиента уже есть в чате
+	require.NoError(t, service.HandleTaskEvent(ctx, customerEvents[0]))
+	assert.Empty(t, stub.sentCalls())
+
+	task, err = taskService.AddInternalNote(ctx, task.ID, "operator-1", "Проверить оплату")
+	require.NoError(t, err)
+	require.NoError(t, service.HandleTaskEvent(ctx, lastTaskEvents(task)[0]))
+	assert.Empty(t, stub.sentCalls(), "internal notes stay private")
+
+	task, err = taskService.AddMessage(ctx, task.ID, ports.AddMessageRequest{
+		AuthorID: "operator-1",
+		Content:  "Счет во вложении",
+		Type:     domain.MessageTypeCustomer,
+		Attachments: []domain.Attachment{
+			{Name: "invoice.pdf", ContentType: "application/pdf", Data: []byte("%PDF")},
+			{Name: "stamp.png", ContentType: "image/png", Data: []byte("png-bytes")},
+		},
+	})
+	require.NoError(t, err)
+	replyEvent := lastTaskEvents(task)[0]
+	require.NoError(t, service.HandleTaskEvent(ctx, replyEvent))
+
+	sent := stub.sentCalls()
+	require.Len(t, sent, 3)
+	assert.Equal(t, botAPICall{Method: "sendMessage", ChatID: "777", Text: "Счет во вложении"}, sent[0])
+	assert.Equal(t, botAPICall{Method: "sendDocument", ChatID: "777", FileName: "invoice.pdf", Data: []byte("%PDF")}, sent[1])
+	assert.Equal(t, botAPICall{Method: "sendPhoto", ChatID: "777", FileName: "stamp.png", Data: []byte("png-bytes")}, sent[2])
+
+	// Повторная доставка события не отправляет ответ второй раз
+	require.NoError(t, service.HandleTaskEvent(ctx, replyEvent))
+	assert.Len(t, stub.sentCalls(), 3)
+}
@@ -0,0 +1,301 @@
+// internal/core/services/user_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// UserService реализует ports.UserService
+type UserService struct {
+	userRepo ports.UserRepository
+	hasher   ports.PasswordHasher
+	logger   ports.Logger
+}
+
+func NewUserService(userRepo ports.UserRepository, hasher ports.PasswordHasher, logger ports.Logger) *UserService {
+	return &UserService{
+		userRepo: userRepo,
+		hasher:   hasher,
+		logger:   logger,
+	}
+}
+
+// CreateUser создает активного пользователя с паролем или приглашенного без пароля
+func (s *UserService) CreateUser(ctx context.Context, req ports.CreateUserRequest) (*domain.User, string, error) {
+	user, err := domain.NewUser(req.Email, req.Name, req.Role)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := user.SetProfile(user.Name, req.Timezone, req.Language, ""); err != nil {
+		return nil, "", err
+	}
+	user.SetRouting(req.Skills, req.Categories)
+
+	existing, err := s.userRepo.FindByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find user: %w", err)
+	}
+	if existing != nil {
+		return nil, "", fmt.Errorf("%w: %s", domain.ErrUserExists, user.Email)
+	}
+
+	now := time.Now()
+	var token string
+	if req.Password != "" {
+		if len(req.Password) < domain.MinPasswordLength {
+			return nil, "", domain.ErrPasswordTooShort
+		}
+		hash, err := s.hasher.Hash(req.Password)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to hash password: %w", err)
+		}
+		user.PasswordHash = hash
+		user.Activate(now)
+	} else {
+		if token, err = s.invite(user, now); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return nil, "", fmt.Errorf("failed to save user: %w", err)
+	}
+
+	s.logger.Info(ctx, "user created", "user_id", user.ID, "role", user.Role, "status", user.Status)
+	return user, token, nil
+}
+
+// AcceptInvitation задает пароль приглашенного пользователя и активирует его
+func (s *UserService) AcceptInvitation(ctx context.Context, token, password string) (*domain.User, error) {
+	if len(password) < domain.MinPasswordLength {
+		return nil, domain.ErrPasswordTooShort
+	}
+
+	user, err := s.userRepo.FindByInvitationHash(ctx, domain.HashInvitationToken(token))
+	if err != nil {
+		return nil, err
+	}
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	updated := *user
+	if err := updated.AcceptInvitation(hash, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.logger.Info(ctx, "user invitation accepted", "user_id", updated.ID)
+	return &updated, nil
+}
+
+// ResendInvitation выдает новый токен приглашения; прежний перестает действовать
+func (s *UserService) ResendInvitation(ctx context.Context, id string) (*domain.User, string, error) {
+	var token string
+	user, err := s.update(ctx, id, func(user *domain.User) error {
+		var err error
+		token, err = s.invite(user, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info(ctx, "user invitation resent", "user_id", id)
+	return user, token, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers возвращает пользователей, подходящих под фильтры
+func (s *UserService) ListUsers(ctx context.Context, query ports.UserQuery) ([]domain.User, error) {
+	users, err := s.userRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		if query.Role != "" && user.Role != query.Role {
+			continue
+		}
+		if query.Status != "" && user.Status != query.Status {
+			continue
+		}
+		if query.Skill != "" && !user.HasSkill(query.Skill) {
+			continue
+		}
+		if query.Category != "" && !slices.Contains(user.Categories, query.Category) {
+			continue
+		}
+		if query.Available != nil && user.IsAvailable(now) != *query.Available {
+			continue
+		}
+		result = append(result, user)
+	}
+	return result, nil
+}
+
+func (s *UserService) UpdateProfile(ctx context.Context, id string, req ports.UpdateUserProfileRequest) (*domain.User, error) {
+	user, err := s.update(ctx, id, func(user *domain.User) error {
+		return user.SetProfile(req.Name, req.Timezone, req.Language, req.Signature)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "user profile updated", "user_id", id)
+	return user, nil
+}
+
+// ChangeRole меняет роль; последний активный администратор не может потерять роль
+func (s *UserService) ChangeRole(ctx context.Context, id string, role domain.UserRole) (*domain.User, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("invalid user role: %s", role)
+	}
+
+	user, err := s.update(ctx, id, func(user *domain.User) error {
+		if user.Role == domain.UserRoleAdmin && role != domain.UserRoleAdmin {
+			if err := s.ensureAnotherAdmin(ctx, user.ID); err != nil {
+				return err
+			}
+		}
+		user.Role = role
+		user.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "user role changed", "user_id", id, "role", role)
+	return user, nil
+}
+
+func (s *UserService) SetRouting(ctx context.Context, id string, skills, categories []string) (*domain.User, error) {
+	user, err := s.update(ctx, id, func(user *domain.User) error {
+		user.SetRouting(skills, categories)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "user routing updated", "user_id", id, "skills", user.Skills, "categories", user.Categories)
+	return user, nil
+}
+
+func (s *UserService) SetOutOfOffice(ctx context.Context, id string, ooo *domain.OutOfOffice) (*domain.User, error) {
+	user, err := s.update(ctx, id, func(user *domain.User) error {
+		return user.SetOutOfOffice(ooo)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ooo != nil {
+		s.logger.Info(ctx, "user out of office set", "user_id", id, "from", ooo.From, "until", ooo.Until)
+	} else {
+		s.logger.Info(ctx, "user out of office cleared", "user_id", id)
+	}
+	return user, nil
+}
+
+// Deactivate отключает пользователя; последнего активного администратора отключить нельзя.
+// Назначенные задачи остаются за пользователем и переназначаются вручную
+func (s *UserService) Deactivate(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.update(ctx, id, func(user *domain.User) error {
+		if user.Role == domain.UserRoleAdmin && user.IsActive() {
+			if err := s.ensureAnotherAdmin(ctx, user.ID); err != nil {
+				return err
+			}
+		}
+		return user.Deactivate(time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "user deactivated", "user_id", id)
+	return user, nil
+}
+
+func (s *UserService) Reactivate(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.update(ctx, id, func(user *domain.User) error {
+		return user.Reactivate(time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "user reactivated", "user_id", id, "status", user.Status)
+	return user, nil
+}
+
+// update применяет изменение к копии пользователя и сохраняет ее
+func (s *UserService) update(ctx context.Context, id string, apply func(user *domain.User) error) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *user
+	if err := apply(&updated); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return &updated, nil
+}
+
+// ensureAnotherAdmin проверяет, что кроме пользователя есть другой активный администратор
+func (s *UserService) ensureAnotherAdmin(ctx context.Context, userID string) error {
+	users, err := s.userRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find users: %w", err)
+	}
+	for _, user := range users {
+		if user.ID != userID && user.Role == domain.UserRoleAdmin && user.IsActive() {
+			return nil
+		}
+	}
+	return domain.ErrLastAdmin
+}
+
+func (s *UserService) invite(user *domain.User, now time.Time) (string, error) {
+	token, err := generateInvitationToken()
+	if err != nil {
+		return "", err
+	}
+	if err := user.Invite(domain.HashInvitationToken(token), now); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func generateInvitationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,218 @@
+// internal/core/services/user_service_test.go
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/auth"
+	authinmemory "github.com/audetv/urms/internal/infrastructure/persistence/auth/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestUserService(t *testing.T) (*services.UserService, ports.UserRepository) {
+	t.Helper()
+	logger := &services.MockLogger{}
+	userRepo := inmemory.NewUserRepository(logger)
+	return services.NewUserService(userRepo, auth.NewBcryptHasher(bcrypt.MinCost), logger), userRepo
+}
+
+func TestUserService_InviteAndAccept(t *testing.T) {
+	ctx := context.Background()
+	userService, _ := newTestUserService(t)
+
+	user, token, err := userService.CreateUser(ctx, ports.CreateUserRequest{
+		Email:      " New.Operator@Company.com ",
+		Name:       "Новый оператор",
+		Role:       domain.UserRoleOperator,
+		Timezone:   "Europe/Moscow",
+		Skills:     []string{"english", "english", " "},
+		Categories: []string{"billing"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	assert.Equal(t, "new.operator@company.com", user.Email)
+	assert.Equal(t, domain.UserStatusInvited, user.Status)
+	assert.Equal(t, []string{"english"}, user.Skills)
+	assert.Equal(t, domain.DefaultTemplateLanguage, user.Language)
+
+	_, _, err = userService.CreateUser(ctx, ports.CreateUserRequest{
+		Email: "new.operator@company.com", Name: "Дубликат", Role: domain.UserRoleOperator,
+	})
+	assert.ErrorIs(t, err, domain.ErrUserExists)
+
+	_, err = userService.AcceptInvitation(ctx, "wrong-token", "long-password")
+	assert.ErrorIs(t, err, domain.ErrInvalidInvitation)
+
+	accepted, err := userService.AcceptInvitation(ctx, token, "long-password")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserStatusActive, accepted.Status)
+	assert.NotEmpty(t, accepted.PasswordHash)
+
+	// Приглашение действует один раз
+	_, err = userService.AcceptInvitation(ctx, token, "long-password")
+	assert.ErrorIs(t, err, domain.ErrInvalidInvitation)
+
+	_, _, err = userService.CreateUser(ctx, ports.CreateUserRequest{
+		Email: "bad-timezone@company.com", Name: "Оператор", Role: domain.UserRoleOperator, Timezone: "Mars/Olympus",
+	})
+	assert.Error(t, err)
+}
+
+func TestUserService_DeactivateBlocksAssignment(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	userRepo := inmemory.NewUserRepository(logger)
+	userService := services.NewUserService(userRepo, auth.NewBcryptHasher(bcrypt.MinCost), logger)
+	taskService := services.NewTaskService(inmemory.NewTaskRepository(logger), inmemory.NewCustomerRepository(logger), userRepo, logger)
+
+	task, err := taskService.CreateInternalTask(ctx, ports.CreateInternalTaskRequest{
+		Subject:     "Задача",
+		Description: "Описание",
+		ReporterID:  "user-1",
+		Priority:    domain.PriorityMedium,
+	})
+	require.NoError(t, err)
+
+	_, err = userService.Deactivate(ctx, "user-3")
+	require.NoError(t, err)
+
+	_, err = taskService.AssignTask(ctx, task.ID, "user-3", "user-1")
+	assert.ErrorIs(t, err, domain.ErrUserInactive)
+	_, err = taskService.AssignTask(ctx, task.ID, "user-404", "user-1")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	assignees, err := userRepo.FindAssignees(ctx)
+	require.NoError(t, err)
+	for _, assignee := range assignees {
+		assert.NotEqual(t, "user-3", assignee.ID)
+	}
+
+	_, err = userService.Reactivate(ctx, "user-3")
+	require.NoError(t, err)
+	updated, err := taskService.AssignTask(ctx, task.ID, "user-3", "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-3", updated.AssigneeID)
+}
+
+func TestUserService_OutOfOffice(t *testing.T) {
+	ctx := context.Background()
+	userService, userRepo := newTestUserService(t)
+
+	now := time.Now()
+	_, err := userService.SetOutOfOffice(ctx, "user-2", &domain.OutOfOffice{From: now, Until: now.Add(-time.Hour)})
+	assert.Error(t, err)
+
+	user, err := userService.SetOutOfOffice(ctx, "user-2", &domain.OutOfOffice{
+		From:    now.Add(-time.Minute),
+		Until:   now.Add(24 * time.Hour),
+		Message: "Замещает user-3",
+	})
+	require.NoError(t, err)
+	assert.False(t, user.IsAvailable(time.Now()))
+	// Отсутствие не запрещает ручное назначение
+	assert.NoError(t, user.CanBeAssigned())
+
+	assignees, err := userRepo.FindAssignees(ctx)
+	require.NoError(t, err)
+	require.Len(t, assignees, 1)
+	assert.Equal(t, "user-3", assignees[0].ID)
+
+	available := false
+	away, err := userService.ListUsers(ctx, ports.UserQuery{Available: &available})
+	require.NoError(t, err)
+	require.Len(t, away, 1)
+	assert.Equal(t, "user-2", away[0].ID)
+
+	user, err = userService.SetOutOfOffice(ctx, "user-2", nil)
+	require.NoError(t, err)
+	assert.True(t, user.IsAvailable(time.Now()))
+}
+
+func TestUserService_LastAdmin(t *testing.T) {
+	ctx := context.Background()
+	userService, _ := newTestUserService(t)
+
+	_, err := userService.ChangeRole(ctx, "user-1", domain.UserRoleManager)
+	assert.ErrorIs(t, err, domain.ErrLastAdmin)
+	_, err = userService.Deactivate(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrLastAdmin)
+
+	_, err = userService.ChangeRole(ctx, "user-2", domain.UserRoleAdmin)
+	require.NoError(t, err)
+	user, err := userService.ChangeRole(ctx, "user-1", domain.UserRoleManager)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserRoleManager, user.Role)
+
+	_, err = userService.ChangeRole(ctx, "user-1", domain.UserRole("superuser"))
+	assert.Error(t, err)
+}
+
+func TestAuthorizedUserService_Permissions(t *testing.T) {
+	userService, _ := newTestUserService(t)
+	authorized := services.NewAuthorizedUserService(userService, services.NewRoleAuthorizer(&services.MockLogger{}))
+
+	operator := ports.WithAuthenticatedUser(context.Background(), &domain.User{ID: "user-3", Role: domain.UserRoleOperator})
+
+	_, _, err := authorized.CreateUser(operator, ports.CreateUserRequest{
+		Email: "x@company.com", Name: "X", Role: domain.UserRoleAdmin,
+	})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = authorized.ChangeRole(operator, "user-3", domain.UserRoleAdmin)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = authorized.UpdateProfile(operator, "user-2", ports.UpdateUserProfileRequest{Name: "Чужой профиль"})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	// Свой профиль и отсутствие оператор меняет сам
+	user, err := authorized.UpdateProfile(operator, "user-3", ports.UpdateUserProfileRequest{
+		Name: "Оператор", Timezone: "Asia/Novosibirsk", Language: domain.TemplateLanguageEN, Signature: "С уважением",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Novosibirsk", user.Timezone)
+	assert.Equal(t, domain.TemplateLanguageEN, user.Language)
+
+	users, err := authorized.ListUsers(operator, ports.UserQuery{Role: domain.UserRoleOperator})
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	admin := ports.WithAuthenticatedUser(context.Background(), &domain.User{ID: "user-1", Role: domain.UserRoleAdmin})
+	_, err = authorized.SetRouting(admin, "user-3", []string{"english"}, []string{"billing"})
+	assert.NoError(t, err)
+}
+
+func TestUserService_DeactivatedUserLosesAccess(t *testing.T) {
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	userRepo := inmemory.NewUserRepository(logger)
+	hasher := auth.NewBcryptHasher(bcrypt.MinCost)
+	signer, err := auth.NewJWTSigner("0123456789abcdef0123456789abcdef", "urms")
+	require.NoError(t, err)
+	authService := services.NewAuthService(userRepo, authinmemory.NewAPIKeyRepository(logger), hasher, signer,
+		services.AuthConfig{AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: 24 * time.Hour}, logger)
+	userService := services.NewUserService(userRepo, hasher, logger)
+
+	require.NoError(t, authService.SetPassword(ctx, "user-3", "correct-horse"))
+	tokens, err := authService.Login(ctx, "operator@company.com", "correct-horse")
+	require.NoError(t, err)
+	_, apiKey, err := authService.CreateAPIKey(ctx, "user-3", "CRM", nil)
+	require.NoError(t, err)
+
+	_, err = userService.Deactivate(ctx, "user-3")
+	require.NoError(t, err)
+
+	_, err = authService.Login(ctx, "operator@company.com", "correct-horse")
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = authService.AuthenticateToken(ctx, tokens.AccessToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	_, err = authService.Refresh(ctx, tokens.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	_, err = authService.AuthenticateAPIKey(ctx, apiKey)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
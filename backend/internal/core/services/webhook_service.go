@@ -0,0 +1,450 @@
+// internal/core/services/webhook_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// maxWebhookDeliveryPageSize максимальный размер страницы журнала доставок
+const maxWebhookDeliveryPageSize = 500
+
+// WebhookConfig параметры доставки вебхуков. Повторы следуют семантике RetryManager:
+// задержка BaseDelay * BackoffFactor^(attempt-1), но не больше MaxDelay, после
+// MaxAttempts попыток или при постоянной ошибке доставка уходит в dead letter
+type WebhookConfig struct {
+	MaxAttempts   int
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+	BackoffFactor float64
+	Timeout       time.Duration // Ожидание ответа получателя
+	BatchSize     int           // Доставок за один проход
+}
+
+// DefaultWebhookConfig возвращает конфигурацию по умолчанию
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts:   8,
+		BaseDelay:     10 * time.Second,
+		MaxDelay:      time.Hour,
+		BackoffFactor: 3,
+		Timeout:       10 * time.Second,
+		BatchSize:     100,
+	}
+}
+
+// WebhookService управляет подписками и доставляет им доменные события
+type WebhookService struct {
+	webhookRepo  ports.WebhookRepository
+	deliveryRepo ports.WebhookDeliveryRepository
+	sender       ports.WebhookSender
+	config       WebhookConfig
+	logger       ports.Logger
+}
+
+func NewWebhookService(
+	webhookRepo ports.WebhookRepository,
+	deliveryRepo ports.WebhookDeliveryRepository,
+	sender ports.WebhookSender,
+	config WebhookConfig,
+	logger ports.Logger,
+) *WebhookService {
+	defaults := DefaultWebhookConfig()
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.BaseDelay <= 0 {
+		config.BaseDelay = defaults.BaseDelay
+	}
+	if config.MaxDelay < config.BaseDelay {
+		config.MaxDelay = config.BaseDelay
+	}
+	if config.BackoffFactor < 1 {
+		config.BackoffFactor = defaults.BackoffFactor
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+
+	return &WebhookService{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		config:       config,
+		logger:       logger,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, req ports.CreateWebhookRequest) (*domain.WebhookSubscription, error) {
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription, err := domain.NewWebhookSubscription(req.Name, req.URL, secret, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	subscription.EventTypes = req.EventTypes
+	subscription.TaskTypes = req.TaskTypes
+	subscription.CustomerIDs = req.CustomerIDs
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Save(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	s.logger.Info(ctx, "webhook subscription created",
+		"webhook_id", subscription.ID, "url", subscription.URL, "event_types", subscription.EventTypes)
+	return subscription, nil
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id string, req ports.UpdateWebhookRequest) (*domain.WebhookSubscription, error) {
+	subscription, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		subscription.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.URL != nil {
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Secret != nil {
+		subscription.Secret = *req.Secret
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = *req.EventTypes
+	}
+	if req.TaskTypes != nil {
+		subscription.TaskTypes = *req.TaskTypes
+	}
+	if req.CustomerIDs != nil {
+		subscription.CustomerIDs = *req.CustomerIDs
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.webhookRepo.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	s.logger.Info(ctx, "webhook subscription updated", "webhook_id", subscription.ID, "active", subscription.Active)
+	return subscription, nil
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	return s.webhookRepo.FindByID(ctx, id)
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.webhookRepo.FindAll(ctx)
+}
+
+// DeleteSubscription удаляет подписку; журнал доставок сохраняется,
+// а ожидающие доставки уходят в dead letter при следующей попытке
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info(ctx, "webhook subscription deleted", "webhook_id", id)
+	return nil
+}
+
+// HandleEvent подписчик шины событий: ставит событие в очередь каждой подходящей подписки.
+// Повторная доставка события из outbox не создает дублей
+func (s *WebhookService) HandleEvent(ctx context.Context, event domain.DomainEvent) error {
+	subscriptions, err := s.webhookRepo.FindActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = buildWebhookPayload(event); err != nil {
+				return err
+			}
+		}
+
+		delivery := domain.NewWebhookDelivery(subscription.ID, event, payload)
+		if err := s.deliveryRepo.Save(ctx, delivery); err != nil {
+			if errors.Is(err, domain.ErrWebhookDeliveryExists) {
+				continue
+			}
+			return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+		s.logger.Debug(ctx, "webhook delivery enqueued",
+			"webhook_id", subscription.ID, "delivery_id", delivery.ID, "event_type", event.Type)
+	}
+
+	return nil
+}
+
+// DeliverDue выполняет наступившие попытки доставки
+func (s *WebhookService) DeliverDue(ctx context.Context, now time.Time) (*ports.WebhookDeliveryResult, error) {
+	deliveries, err := s.deliveryRepo.FindDue(ctx, now, s.config.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due webhook deliveries: %w", err)
+	}
+
+	result := &ports.WebhookDeliveryResult{}
+	subscriptions := make(map[string]*domain.WebhookSubscription)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		subscription, cached := subscriptions[delivery.SubscriptionID]
+		if !cached {
+			subscription, err = s.webhookRepo.FindByID(ctx, delivery.SubscriptionID)
+			if err != nil && !errors.Is(err, domain.ErrWebhookNotFound) {
+				s.logger.Error(ctx, "failed to load webhook subscription",
+					"webhook_id", delivery.SubscriptionID, "error", err.Error())
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		switch {
+		case subscription == nil:
+			delivery.RecordFailure(domain.WebhookAttempt{At: now, Error: "subscription deleted"}, nil)
+		case !subscription.Active:
+			delivery.RecordFailure(domain.WebhookAttempt{At: now, Error: "subscription disabled"}, nil)
+		default:
+			s.attempt(ctx, subscription, delivery, now, true)
+		}
+
+		if err := s.deliveryRepo.Update(ctx, delivery); err != nil {
+			s.logger.Error(ctx, "failed to update webhook delivery",
+				"delivery_id", delivery.ID, "error", err.Error())
+			continue
+		}
+
+		switch delivery.Status {
+		case domain.WebhookDeliverySucceeded:
+			result.Succeeded++
+		case domain.WebhookDeliveryDeadLetter:
+			result.DeadLettered++
+			s.logger.Warn(ctx, "webhook delivery moved to dead letter",
+				"webhook_id", delivery.SubscriptionID,
+				"delivery_id", delivery.ID,
+				"attempts", delivery.AttemptCount(),
+				"error", delivery.LastAttempt().Error)
+		default:
+			result.Retried++
+		}
+	}
+
+	if len(deliveries) > 0 {
+		s.logger.Info(ctx, "webhook delivery pass completed",
+			"due", len(deliveries),
+			"succeeded", result.Succeeded,
+			"retried", result.Retried,
+			"dead_lettered", result.DeadLettered)
+	}
+
+	return result, nil
+}
+
+// SendTestEvent отправляет подписчику тестовое событие одной попыткой без повторов
+func (s *WebhookService) SendTestEvent(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	subscription, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	event := domain.NewDomainEvent(domain.DomainEventWebhookTest, "webhook", subscription.ID, contextActor(ctx), map[string]string{
+		"webhook_id": subscription.ID,
+		"message":    "Тестовое событие URMS",
+	})
+	event.CorrelationID = ports.CorrelationIDFromContext(ctx)
+
+	payload, err := buildWebhookPayload(event)
+	if err != nil {
+		return nil, err
+	}
+	delivery := domain.NewWebhookDelivery(subscription.ID, event, payload)
+	s.attempt(ctx, subscription, delivery, time.Now(), false)
+
+	if err := s.deliveryRepo.Save(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to save test delivery: %w", err)
+	}
+
+	s.logger.Info(ctx, "webhook test event sent",
+		"webhook_id", subscription.ID, "delivery_id", delivery.ID, "status", delivery.Status)
+	return delivery, nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, query ports.WebhookDeliveryQuery) (*ports.WebhookDeliveryList, error) {
+	if query.SubscriptionID != "" {
+		if _, err := s.webhookRepo.FindByID(ctx, query.SubscriptionID); err != nil {
+			return nil, err
+		}
+	}
+	if query.Status != "" && !query.Status.IsValid() {
+		return nil, fmt.Errorf("invalid delivery status: %s", query.Status)
+	}
+	if query.Limit <= 0 || query.Limit > maxWebhookDeliveryPageSize {
+		query.Limit = maxWebhookDeliveryPageSize
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	deliveries, total, err := s.deliveryRepo.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+
+	return &ports.WebhookDeliveryList{
+		Deliveries: deliveries,
+		TotalCount: total,
+	}, nil
+}
+
+func (s *WebhookService) RetryDelivery(ctx context.Context, subscriptionID, deliveryID string) (*domain.WebhookDelivery, error) {
+	delivery, err := s.deliveryRepo.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionID != subscriptionID {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+
+	if err := delivery.Requeue(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.deliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	s.logger.Info(ctx, "webhook delivery requeued", "webhook_id", subscriptionID, "delivery_id", deliveryID)
+	return delivery, nil
+}
+
+// attempt выполняет одну попытку; подпись вычисляется текущим секретом подписки
+func (s *WebhookService) attempt(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery, now time.Time, retry bool) {
+	headers := map[string]string{
+		"Content-Type":                "application/json",
+		"User-Agent":                  "URMS-Webhooks/1.0",
+		"X-URMS-Event":                string(delivery.EventType),
+		"X-URMS-Delivery":             delivery.ID,
+		domain.WebhookSignatureHeader: domain.SignWebhookPayload(subscription.Secret, delivery.Payload),
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	started := time.Now()
+	statusCode, err := s.sender.Send(sendCtx, subscription.URL, headers, delivery.Payload)
+	cancel()
+
+	attempt := domain.WebhookAttempt{
+		At:         now,
+		StatusCode: statusCode,
+		Duration:   time.Since(started),
+	}
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		delivery.RecordSuccess(attempt)
+		return
+	}
+
+	if err != nil {
+		attempt.Error = err.Error()
+	} else {
+		attempt.Error = fmt.Sprintf("unexpected response status %d", statusCode)
+	}
+
+	var nextAttemptAt *time.Time
+	attemptNumber := delivery.AttemptCount() + 1
+	if retry && isRetryableWebhookStatus(statusCode) && attemptNumber < s.config.MaxAttempts {
+		next := now.Add(s.retryDelay(attemptNumber))
+		nextAttemptAt = &next
+	}
+	delivery.RecordFailure(attempt, nextAttemptAt)
+}
+
+// retryDelay вычисляет задержку после attempt неудачных попыток
+func (s *WebhookService) retryDelay(attempt int) time.Duration {
+	delay := time.Duration(float64(s.config.BaseDelay) * math.Pow(s.config.BackoffFactor, float64(attempt-1)))
+	if delay > s.config.MaxDelay || delay <= 0 {
+		return s.config.MaxDelay
+	}
+	return delay
+}
+
+// isRetryableWebhookStatus ошибки клиента постоянны, кроме таймаута и ограничения частоты.
+// Код 0 - ответ не получен (сетевая ошибка), такую попытку повторяем
+func isRetryableWebhookStatus(statusCode int) bool {
+	if statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return statusCode < 400 || statusCode >= 500
+}
+
+// webhookPayload тело запроса вебхука
+type webhookPayload struct {
+	ID            string            `json:"id"`
+	Type          string            `json:"type"`
+	OccurredAt    time.Time         `json:"occurred_at"`
+	AggregateType string            `json:"aggregate_type"`
+	AggregateID   string            `json:"aggregate_id"`
+	ActorID       string            `json:"actor_id,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	Data          map[string]string `json:"data"`
+}
+
+func buildWebhookPayload(event domain.DomainEvent) ([]byte, error) {
+	data := event.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	payload, err := json.Marshal(webhookPayload{
+		ID:            event.ID,
+		Type:          string(event.Type),
+		OccurredAt:    event.OccurredAt.UTC(),
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		ActorID:       event.ActorID,
+		CorrelationID: event.CorrelationID,
+		Data:          data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	return payload, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
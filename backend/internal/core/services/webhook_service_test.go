@@ -0,0 +1,309 @@
+// internal/core/services/webhook_service_test.go
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	webhookinmemory "github.com/audetv/urms/internal/infrastructure/persistence/webhook/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "test-secret-0123456789"
+
+// webhookReceiver тестовый получатель, отвечающий заданными кодами
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status = r.statuses[0]
+		if len(r.statuses) > 1 {
+			r.statuses = r.statuses[1:]
+		}
+	}
+	w.WriteHeader(status)
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func newTestWebhookService(t *testing.T, statuses ...int) (*services.WebhookService, *webhookReceiver, string) {
+	receiver := &webhookReceiver{statuses: statuses}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	logger := &services.MockLogger{}
+	service := services.NewWebhookService(
+		webhookinmemory.NewWebhookRepository(logger),
+		webhookinmemory.NewWebhookDeliveryRepository(logger),
+		webhook.NewHTTPSender(5*time.Second),
+		services.WebhookConfig{
+			MaxAttempts:   3,
+			BaseDelay:     time.Second,
+			MaxDelay:      time.Minute,
+			BackoffFactor: 2,
+		},
+		logger,
+	)
+	return service, receiver, server.URL
+}
+
+func taskCreatedEvent(taskType domain.TaskType, customerID string) domain.DomainEvent {
+	return domain.NewDomainEvent(domain.DomainEventTaskCreated, domain.AggregateTask, "TASK-1", "user-1", map[string]string{
+		"task_id":     "TASK-1",
+		"task_type":   string(taskType),
+		"customer_id": customerID,
+	})
+}
+
+func TestWebhookService_SignedDelivery(t *testing.T) {
+	ctx := context.Background()
+	service, receiver, url := newTestWebhookService(t)
+
+	subscription, err := service.CreateSubscription(ctx, ports.CreateWebhookRequest{
+		Name:   "CRM",
+		URL:    url,
+		Secret: testWebhookSecret,
+	})
+	require.NoError(t, err)
+
+	event := taskCreatedEvent(domain.TaskTypeSupport, "CUST-1")
+	require.NoError(t, service.HandleEvent(ctx, event))
+	// Повторная доставка события из outbox не создает вторую доставку
+	require.NoError(t, service.HandleEvent(ctx, event))
+
+	result, err := service.DeliverDue(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	require.Equal(t, 1, receiver.count())
+
+	request, body := receiver.requests[0], receiver.bodies[0]
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, string(domain.DomainEventTaskCreated), request.Header.Get("X-URMS-Event"))
+	assert.True(t, domain.VerifyWebhookSignature(testWebhookSecret, body, request.Header.Get(domain.WebhookSignatureHeader)))
+	assert.False(t, domain.VerifyWebhookSignature("another-secret-0123", body, request.Header.Get(domain.WebhookSignatureHeader)))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, event.ID, payload["id"])
+	assert.Equal(t, "task.created", payload["type"])
+	assert.Equal(t, "CUST-1", payload["data"].(map[string]any)["customer_id"])
+
+	list, err := service.ListDeliveries(ctx, ports.WebhookDeliveryQuery{SubscriptionID: subscription.ID})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.TotalCount)
+	delivery := list.Deliveries[0]
+	assert.Equal(t, domain.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, request.Header.Get("X-URMS-Delivery"), delivery.ID)
+	require.Len(t, delivery.Attempts, 1)
+	assert.Equal(t, http.StatusOK, delivery.Attempts[0].StatusCode)
+	require.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestWebhookService_Filters(t *testing.T) {
+	ctx := context.Background()
+	service, _, url := newTestWebhookService(t)
+
+	subscription, err := service.CreateSubscription(ctx, ports.CreateWebhookRequest{
+		Name:        "Только поддержка клиента",
+		URL:         url,
+		EventTypes:  []domain.DomainEventType{domain.DomainEventTaskCreated},
+		TaskTypes:   []domain.TaskType{domain.TaskTypeSupport},
+		CustomerIDs: []string{"CUST-1"},
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^whsec_[0-9a-f]{64}$`, subscription.Secret)
+
+	events := []domain.DomainEvent{
+		taskCreatedEvent(domain.TaskTypeSupport, "CUST-1"),
+		taskCreatedEvent(domain.TaskTypeInternal, "CUST-1"),
+		taskCreatedEvent(domain.TaskTypeSupport, "CUST-2"),
+		domain.NewDomainEvent(domain.DomainEventTaskStatusChanged, domain.AggregateTask, "TASK-1", "user-1", map[string]string{
+			"task_type":   string(domain.TaskTypeSupport),
+			"customer_id": "CUST-1",
+		}),
+	}
+	for _, event := range events {
+		require.NoError(t, service.HandleEvent(ctx, event))
+	}
+
+	list, err := service.ListDeliveries(ctx, ports.WebhookDeliveryQuery{SubscriptionID: subscription.ID})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.TotalCount)
+	assert.Equal(t, events[0].ID, list.Deliveries[0].EventID)
+
+	// Отключенная подписка новых событий не получает
+	active := false
+	_, err = service.UpdateSubscription(ctx, subscription.ID, ports.UpdateWebhookRequest{Active: &active})
+	require.NoError(t, err)
+	require.NoError(t, service.HandleEvent(ctx, taskCreatedEvent(domain.TaskTypeSupport, "CUST-1")))
+
+	list, err = service.ListDeliveries(ctx, ports.WebhookDeliveryQuery{SubscriptionID: subscription.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.TotalCount)
+
+	_, err = service.CreateSubscription(ctx, ports.CreateWebhookRequest{Name: "FTP", URL: "ftp://example.com"})
+	assert.Error(t, err)
+	_, err = service.CreateSubscription(ctx, ports.CreateWebhookRequest{Name: "Short", URL: url, Secret: "short"})
+	assert.Error(t, err)
+}
+
+func TestWebhookService_RetriesAndDeadLetter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("server errors retried with backoff until dead letter", func(t *testing.T) {
+		service, receiver, url := newTestWebhookService(t, http.StatusServiceUnavailable)
+		subscription, err := service.CreateSubscription(ctx, ports.CreateWebhookRequest{Name: "CRM", URL: url})
+		require.NoError(t, err)
+		require.NoError(t, service.HandleEvent(ctx, taskCreatedEvent(domain.TaskTypeSupport, "CUST-1")))
+
+		now := time.Now()
+		result, err := service.DeliverDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Retried)
+
+		list, err := service.ListDeliveries(ctx, ports.WebhookDeliveryQuery{SubscriptionID: subscription.ID})
+		require.NoError(t, err)
+		delivery := list.Deliveries[0]
+		assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, now.Add(time.Second), delivery.NextAttemptAt)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.Attempts[0].StatusCode)
+
+		// До наступления следующей попытки запрос не отправляется
+		result, err = service.DeliverDue(ctx, now.Add(500*time.Millisecond))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Retried)
+		assert.Equal(t, 1, receiver.count())
+
+		_, err = service.DeliverDue(ctx, now.Add(time.Second))
+		require.NoError(t, err)
+		list, err = service.ListDeliveries(ctx, ports.WebhookDeliveryQuery{SubscriptionID: subscription.ID})
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(3*time.Second), list.Deliveries[0].NextAttemptAt)
+
+		result, err = service.DeliverDue(ctx, now.Add(3*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.DeadLettered)
+		assert.Equal(t, 3, receiver.count())
+
+		list, err = service.ListDeliveries(ctx, ports.WebhookDeliveryQuery{
+			SubscriptionID: subscription.ID,
+			Status:         domain.WebhookDeliveryDeadLetter,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, list.TotalCount)
+		assert.Len(t, list.Deliveries[0].Attempts, 3)
+	})
+
+	t.Run("client error is permanent", func(t *testing.T) {
+		service, receiver, url := newTestWebhookService(t, http.StatusGone)
+		_, err := service.CreateSubscription(ctx, ports.CreateWebhookRequest{Name: "CRM", URL: url})
+		require.NoError(t, err)
+		require.NoError(t, service.HandleEvent(ctx, taskCreatedEvent(domain.TaskTypeSupport, "CUST-1")))
+
+		result, err := service.DeliverDue(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.DeadLettered)
+		assert.Equal(t, 1, receiver.count())
+	})
+
+	t.Run("dead letter requeued manually", func(t *testing.T) {
+		service, receiver, url := newTestWebhookService(t, http.StatusBadRequest, http.StatusOK)
+		subscription, err := service.CreateSubscription(ctx, ports.CreateWebhookRequest{Name: "CRM", URL: url})
+		require.NoError(t, err)
+		require.NoError(t, service.HandleEvent(ctx, taskCreatedEvent(domain.TaskTypeSupport, "CUST-1")))
+
+		_, err = service.DeliverDue(ctx, time.Now())
+		require.NoError(t, err)
+		list, err := service.ListDeliveries(ctx, ports.WebhookDeliveryQuery{SubscriptionID: subscription.ID})
+		require.NoError(t, err)
+		deliveryID := list.Deliveries[0].ID
+
+		_, err = service.RetryDelivery(ctx, "WH-other", deliveryID)
+		assert.ErrorIs(t, err, domain.ErrWebhookDeliveryNotFound)
+
+		delivery, err := service.RetryDelivery(ctx, subscription.ID, deliveryID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+
+		result, err := service.DeliverDue(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 2, receiver.count())
+
+		// Доставленное событие повторно не ставится в очередь
+		_, err = service.RetryDelivery(ctx, subscription.ID, deliveryID)
+		assert.Error(t, err)
+	})
+
+	t.Run("deleted subscription dead letters pending deliveries", func(t *testing.T) {
+		service, receiver, url := newTestWebhookService(t)
+		subscription, err := service.CreateSubscription(ctx, ports.CreateWebhookRequest{Name: "CRM", URL: url})
+		require.NoError(t, err)
+		require.NoError(t, service.HandleEvent(ctx, taskCreatedEvent(domain.TaskTypeSupport, "CUST-1")))
+		require.NoError(t, service.DeleteSubscription(ctx, subscription.ID))
+
+		result, err := service.DeliverDue(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.DeadLettered)
+		assert.Equal(t, 0, receiver.count())
+	})
+}
+
+func TestWebhookService_SendTestEvent(t *testing.T) {
+	ctx := context.Background()
+	service, receiver, url := newTestWebhookService(t, http.StatusInternalServerError)
+
+	subscription, err := service.CreateSubscription(ctx, ports.CreateWebhookRequest{
+		Name:       "CRM",
+		URL:        url,
+		Secret:     testWebhookSecret,
+		EventTypes: []domain.DomainEventType{domain.DomainEventTaskAssigned},
+	})
+	require.NoError(t, err)
+
+	// Тестовое событие отправляется независимо от фильтров и без повторов
+	userCtx := ports.WithAuthenticatedUser(ctx, &domain.User{ID: "user-2", Role: domain.UserRoleAdmin})
+	delivery, err := service.SendTestEvent(userCtx, subscription.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DomainEventWebhookTest, delivery.EventType)
+	assert.Equal(t, domain.WebhookDeliveryDeadLetter, delivery.Status)
+	require.Len(t, delivery.Attempts, 1)
+	assert.Equal(t, http.StatusInternalServerError, delivery.Attempts[0].StatusCode)
+
+	require.Equal(t, 1, receiver.count())
+	assert.Equal(t, "webhook.test", receiver.requests[0].Header.Get("X-URMS-Event"))
+	assert.True(t, domain.VerifyWebhookSignature(testWebhookSecret, receiver.bodies[0], receiver.requests[0].Header.Get(domain.WebhookSignatureHeader)))
+	assert.Contains(t, string(receiver.bodies[0]), `"actor_id":"user-2"`, "автор - пользователь запроса")
+
+	_, err = service.SendTestEvent(ctx, "WH-missing")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
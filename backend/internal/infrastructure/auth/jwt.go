@@ -0,0 +1,111 @@
+// internal/infrastructure/auth/jwt.go
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// MinJWTSecretLength минимальная длина секрета подписи HS256
+const MinJWTSecretLength = 32
+
+var (
+	errMalformedToken = errors.New("malformed token")
+	jwtHeader         = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+)
+
+// jwtClaims стандартные и собственные поля JWT
+type jwtClaims struct {
+	ID        string `json:"jti"`
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	Role      string `json:"role"`
+	Type      string `json:"typ"`
+}
+
+// JWTSigner реализует ports.TokenSigner: JWT с подписью HMAC-SHA256
+type JWTSigner struct {
+	secret []byte
+	issuer string
+	now    func() time.Time
+}
+
+func NewJWTSigner(secret, issuer string) (*JWTSigner, error) {
+	if len(secret) < MinJWTSecretLength {
+		return nil, fmt.Errorf("jwt secret must be at least %d characters", MinJWTSecretLength)
+	}
+	return &JWTSigner{secret: []byte(secret), issuer: issuer, now: time.Now}, nil
+}
+
+func (s *JWTSigner) Sign(claims domain.AuthClaims) (string, error) {
+	payload, err := json.Marshal(jwtClaims{
+		ID:        claims.TokenID,
+		Issuer:    s.issuer,
+		Subject:   claims.UserID,
+		IssuedAt:  claims.IssuedAt.Unix(),
+		ExpiresAt: claims.ExpiresAt.Unix(),
+		Role:      string(claims.Role),
+		Type:      string(claims.Type),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned), nil
+}
+
+func (s *JWTSigner) Parse(token string) (*domain.AuthClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+	// Заголовок сравнивается целиком: другие алгоритмы (включая none) не принимаются
+	if parts[0] != jwtHeader {
+		return nil, errors.New("unsupported token header")
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(parts[0]+"."+parts[1]))) {
+		return nil, errors.New("invalid token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errMalformedToken
+	}
+	if claims.Issuer != s.issuer || claims.Subject == "" {
+		return nil, errors.New("invalid token claims")
+	}
+
+	result := &domain.AuthClaims{
+		TokenID:   claims.ID,
+		UserID:    claims.Subject,
+		Role:      domain.UserRole(claims.Role),
+		Type:      domain.AuthTokenType(claims.Type),
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}
+	if result.IsExpired(s.now()) {
+		return nil, errors.New("token expired")
+	}
+	return result, nil
+}
+
+func (s *JWTSigner) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
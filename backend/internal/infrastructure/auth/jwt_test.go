@@ -0,0 +1,93 @@
+// internal/infrastructure/auth/jwt_test.go
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func testClaims(now time.Time) domain.AuthClaims {
+	return domain.AuthClaims{
+		TokenID:   "jti-1",
+		UserID:    "user-1",
+		Role:      domain.UserRoleAdmin,
+		Type:      domain.AuthTokenAccess,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(15 * time.Minute),
+	}
+}
+
+func TestJWTSigner_RoundTrip(t *testing.T) {
+	signer, err := NewJWTSigner(testSecret, "urms")
+	require.NoError(t, err)
+
+	token, err := signer.Sign(testClaims(time.Now()))
+	require.NoError(t, err)
+
+	claims, err := signer.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, domain.UserRoleAdmin, claims.Role)
+	assert.Equal(t, domain.AuthTokenAccess, claims.Type)
+	assert.Equal(t, "jti-1", claims.TokenID)
+}
+
+func TestJWTSigner_Rejects(t *testing.T) {
+	signer, err := NewJWTSigner(testSecret, "urms")
+	require.NoError(t, err)
+	token, err := signer.Sign(testClaims(time.Now()))
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	t.Run("short secret", func(t *testing.T) {
+		_, err := NewJWTSigner("short", "urms")
+		assert.Error(t, err)
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		other, err := NewJWTSigner(strings.Repeat("x", MinJWTSecretLength), "urms")
+		require.NoError(t, err)
+		_, err = other.Parse(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		payload := base64.RawURLEncoding.EncodeToString([]byte(
+			`{"jti":"jti-1","iss":"urms","sub":"user-2","iat":0,"exp":9999999999,"role":"admin","typ":"access"}`))
+		_, err := signer.Parse(parts[0] + "." + payload + "." + parts[2])
+		assert.Error(t, err)
+	})
+
+	t.Run("alg none", func(t *testing.T) {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+		_, err := signer.Parse(header + "." + parts[1] + ".")
+		assert.Error(t, err)
+	})
+
+	t.Run("other issuer", func(t *testing.T) {
+		other, err := NewJWTSigner(testSecret, "other")
+		require.NoError(t, err)
+		_, err = other.Parse(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		signer.now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { signer.now = time.Now }()
+		_, err := signer.Parse(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := signer.Parse("not-a-token")
+		assert.Error(t, err)
+	})
+}
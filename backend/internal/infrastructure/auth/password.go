@@ -0,0 +1,31 @@
+// internal/infrastructure/auth/password.go
+package auth
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher реализует ports.PasswordHasher на bcrypt
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher создает хешер; cost 0 - стоимость bcrypt по умолчанию
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Verify(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
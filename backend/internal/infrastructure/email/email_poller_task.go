@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/audetv/urms/internal/core/domain"
 	"github.com/audetv/urms/internal/core/ports"
 	"github.com/audetv/urms/internal/core/services"
 )
@@ -112,6 +113,7 @@ func (t *EmailPollerTask) pollingLoop(ctx context.Context) {
 
 func (t *EmailPollerTask) executePoll(ctx context.Context) {
 	pollCtx := context.WithValue(ctx, ports.CorrelationIDKey, "email-poller-"+generateShortID())
+	pollCtx = ports.WithAuditSource(pollCtx, domain.AuditSourceEmail)
 
 	// ✅ НОРМАЛЬНЫЕ ЛОГИ (без принудительных)
 	t.logger.Info(pollCtx, "email poller running scheduled check")
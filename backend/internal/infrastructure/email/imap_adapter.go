@@ -1118,6 +1118,8 @@ func (a *IMAPAdapter) convertToDomainMessageWithBody(imapMsg *imap.Message) (dom
 	if err != nil {
 		return domain.EmailMessage{}, err
 	}
+	// Исходный текст нужен для выгрузки письма в .eml
+	domainMsg.RawMessage = rawData
 
 	// ✅ ДЕТАЛЬНАЯ ВАЛИДАЦИЯ РЕЗУЛЬТАТА
 	a.validateMessageConversion(domainMsg, rawData)
@@ -4,6 +4,7 @@ package email
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
 	"github.com/audetv/urms/internal/core/ports"
@@ -202,6 +203,12 @@ func (m *MockTaskService) ChangeStatus(ctx context.Context, id string, status do
 func (m *MockTaskService) AssignTask(ctx context.Context, id string, assigneeID string, userID string) (*domain.Task, error) {
 	return nil, nil
 }
+func (m *MockTaskService) SnoozeTask(ctx context.Context, id string, until time.Time, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) UnsnoozeTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	return nil, nil
+}
 func (m *MockTaskService) AddMessage(ctx context.Context, id string, req ports.AddMessageRequest) (*domain.Task, error) {
 	return nil, nil
 }
@@ -217,13 +224,37 @@ func (m *MockTaskService) GetCustomerTasks(ctx context.Context, customerID strin
 func (m *MockTaskService) GetSubtasks(ctx context.Context, parentID string) ([]domain.Task, error) {
 	return nil, nil
 }
+func (m *MockTaskService) LinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) UnlinkTasks(ctx context.Context, id string, targetID string, linkType domain.TaskLinkType, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) GetSubtaskProgress(ctx context.Context, parentID string) (*ports.SubtaskProgress, error) {
+	return nil, nil
+}
+func (m *MockTaskService) LogWork(ctx context.Context, id string, req ports.LogWorkRequest) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) DeleteWorkLog(ctx context.Context, id string, workLogID string, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) StartTimer(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) StopTimer(ctx context.Context, id string, req ports.StopTimerRequest) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) GetTimeReport(ctx context.Context, query ports.TimeReportQuery) (*ports.TimeReport, error) {
+	return nil, nil
+}
 func (m *MockTaskService) GetStats(ctx context.Context, query ports.StatsQuery) (*ports.TaskStats, error) {
 	return nil, nil
 }
 func (m *MockTaskService) GetUserTasks(ctx context.Context, userID string, userRole domain.UserRole) (*ports.UserTasks, error) {
 	return nil, nil
 }
-func (m *MockTaskService) GetDashboard(ctx context.Context, userID string) (*ports.UserDashboard, error) {
+func (m *MockTaskService) GetDashboard(ctx context.Context, userID string, userRole domain.UserRole) (*ports.UserDashboard, error) {
 	return nil, nil
 }
 func (m *MockTaskService) AutoAssignTasks(ctx context.Context) ([]ports.AutoAssignmentResult, error) {
@@ -232,12 +263,27 @@ func (m *MockTaskService) AutoAssignTasks(ctx context.Context) ([]ports.AutoAssi
 func (m *MockTaskService) ProcessEscalations(ctx context.Context) ([]ports.EscalationResult, error) {
 	return nil, nil
 }
+func (m *MockTaskService) WakeSnoozedTasks(ctx context.Context, now time.Time) ([]string, error) {
+	return nil, nil
+}
 func (m *MockTaskService) BulkUpdateStatus(ctx context.Context, taskIDs []string, status domain.TaskStatus, userID string) ([]ports.BulkOperationResult, error) {
 	return nil, nil
 }
 func (m *MockTaskService) BulkAssign(ctx context.Context, taskIDs []string, assigneeID string, userID string) ([]ports.BulkOperationResult, error) {
 	return nil, nil
 }
-func (m *MockTaskService) AddParticipant(ctx context.Context, id string, userID string, role domain.ParticipantRole) (*domain.Task, error) {
+func (m *MockTaskService) AddParticipant(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) RemoveParticipant(ctx context.Context, id string, participantID string, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) ChangeParticipantRole(ctx context.Context, id string, participantID string, role domain.ParticipantRole, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) WatchTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockTaskService) UnwatchTask(ctx context.Context, id string, userID string) (*domain.Task, error) {
 	return nil, nil
 }
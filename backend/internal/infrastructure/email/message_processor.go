@@ -46,6 +46,12 @@ func NewMessageProcessor(
 }
 
 func (p *MessageProcessor) ProcessIncomingEmail(ctx context.Context, email domain.EmailMessage) error {
+	_, err := p.ProcessIncomingEmailForTask(ctx, email)
+	return err
+}
+
+// ProcessIncomingEmailForTask обрабатывает письмо и возвращает ID задачи, созданной или дополненной им
+func (p *MessageProcessor) ProcessIncomingEmailForTask(ctx context.Context, email domain.EmailMessage) (string, error) {
 	// ✅ СОКРАЩАЕМ ЛОГИРОВАНИЕ, НО СОХРАНЯЕМ ВСЮ ЛОГИКУ
 	p.logger.Info(ctx, "Processing incoming email",
 		"message_id", email.MessageID,
@@ -55,21 +61,21 @@ func (p *MessageProcessor) ProcessIncomingEmail(ctx context.Context, email domai
 	// 1. Валидация email (сохраняем всю логику)
 	if err := p.validateIncomingEmail(ctx, email); err != nil {
 		p.logger.Error(ctx, "Incoming email validation failed", "message_id", email.MessageID, "error", err.Error())
-		return fmt.Errorf("email validation failed: %w", err)
+		return "", fmt.Errorf("email validation failed: %w", err)
 	}
 
 	// 2. ФИЛЬТРАЦИЯ ЗАГОЛОВКОВ (сохраняем всю логику)
 	emailHeaders, err := p.headerFilter.FilterEssentialHeaders(ctx, &email)
 	if err != nil {
 		p.logger.Error(ctx, "Failed to filter essential headers", "message_id", email.MessageID, "error", err.Error())
-		return fmt.Errorf("headers filtering failed: %w", err)
+		return "", fmt.Errorf("headers filtering failed: %w", err)
 	}
 
 	// 3. Поиск или создание клиента (сохраняем всю логику)
 	customer, err := p.findOrCreateCustomer(ctx, email)
 	if err != nil {
 		p.logger.Error(ctx, "Failed to find or create customer", "message_id", email.MessageID, "error", err.Error())
-		return fmt.Errorf("customer management failed: %w", err)
+		return "", fmt.Errorf("customer management failed: %w", err)
 	}
 
 	// 4. Поиск существующей задачи (сохраняем всю логику, оптимизируем логи)
@@ -83,13 +89,18 @@ func (p *MessageProcessor) ProcessIncomingEmail(ctx context.Context, email domai
 		// Продолжаем обработку, создаем новую задачу
 	}
 
+	// Ответы на задачу-дубликат направляем в оригинальную задачу
+	if existingTask != nil {
+		existingTask = p.resolveDuplicate(ctx, existingTask)
+	}
+
 	var task *domain.Task
 	if existingTask != nil {
 		// 5a. Добавление сообщения в существующую задачу (сохраняем всю логику)
 		task, err = p.addMessageToExistingTask(ctx, existingTask, email, customer.ID, emailHeaders)
 		if err != nil {
 			p.logger.Error(ctx, "Failed to add message to existing task", "task_id", existingTask.ID, "error", err.Error())
-			return fmt.Errorf("failed to update existing task: %w", err)
+			return "", fmt.Errorf("failed to update existing task: %w", err)
 		}
 		p.logger.Info(ctx, "Message added to existing task", "task_id", existingTask.ID)
 	} else {
@@ -97,12 +108,15 @@ func (p *MessageProcessor) ProcessIncomingEmail(ctx context.Context, email domai
 		task, err = p.createNewTaskFromEmail(ctx, email, customer.ID, emailHeaders)
 		if err != nil {
 			p.logger.Error(ctx, "Failed to create new task from email", "message_id", email.MessageID, "error", err.Error())
-			return fmt.Errorf("failed to create task: %w", err)
+			return "", fmt.Errorf("failed to create task: %w", err)
 		}
 		p.logger.Info(ctx, "New task created from email", "task_id", task.ID)
 	}
 
-	// 6. Автоматическое назначение (сохраняем всю логику, оптимизируем логи)
+	// 6. Адресаты из CC становятся наблюдателями задачи
+	task = p.addCCWatchers(ctx, task, email)
+
+	// 7. Автоматическое назначение (сохраняем всю логику, оптимизируем логи)
 	if task.AssigneeID == "" {
 		task, err = p.autoAssignTask(ctx, task)
 		if err != nil {
@@ -113,7 +127,7 @@ func (p *MessageProcessor) ProcessIncomingEmail(ctx context.Context, email domai
 	}
 
 	p.logger.Debug(ctx, "Incoming email processing completed", "task_id", task.ID)
-	return nil
+	return task.ID, nil
 }
 
 // findExistingTaskByThreadEnhanced - ОПТИМИЗИРУЕМ логирование
@@ -255,7 +269,10 @@ func (p *MessageProcessor) ProcessOutgoingEmail(ctx context.Context, email domai
 				Type:      domain.MessageTypeInternal,
 				IsPrivate: true,
 			}
-			_, err = p.taskService.AddMessage(ctx, task.ID, messageReq)
+			err = ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+				_, err := p.taskService.AddMessage(ctx, task.ID, messageReq)
+				return err
+			})
 			if err != nil {
 				p.logger.Warn(ctx, "Failed to add outgoing message to task",
 					"task_id", task.ID,
@@ -396,7 +413,12 @@ func (p *MessageProcessor) createNewTaskFromEmail(ctx context.Context, email dom
 		IsPrivate: false,
 	}
 
-	taskWithMessage, err := p.taskService.AddMessage(ctx, task.ID, messageReq)
+	var taskWithMessage *domain.Task
+	err = ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		var err error
+		taskWithMessage, err = p.taskService.AddMessage(ctx, task.ID, messageReq)
+		return err
+	})
 	if err != nil {
 		p.logger.Warn(ctx, "Failed to add first message to new task",
 			"task_id", task.ID,
@@ -441,7 +463,14 @@ func (p *MessageProcessor) addMessageToExistingTask(ctx context.Context, task *d
 		IsPrivate: false,
 	}
 
-	updatedTask, err := p.taskService.AddMessage(ctx, task.ID, messageReq)
+	// Оператор может менять задачу одновременно с обработкой письма:
+	// при конфликте версий операция повторяется на свежей копии задачи
+	var updatedTask *domain.Task
+	err := ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+		var err error
+		updatedTask, err = p.taskService.AddMessage(ctx, task.ID, messageReq)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to add message to task: %w", err)
 	}
@@ -449,6 +478,77 @@ func (p *MessageProcessor) addMessageToExistingTask(ctx context.Context, task *d
 	return updatedTask, nil
 }
 
+// resolveDuplicate проходит по цепочке duplicate_of до оригинальной задачи
+func (p *MessageProcessor) resolveDuplicate(ctx context.Context, task *domain.Task) *domain.Task {
+	visited := map[string]bool{task.ID: true}
+
+	for task.DuplicateOf() != "" {
+		original, err := p.taskService.GetTask(ctx, task.DuplicateOf())
+		if err != nil {
+			p.logger.Warn(ctx, "Original task for duplicate not found",
+				"task_id", task.ID,
+				"original_id", task.DuplicateOf(),
+				"error", err.Error())
+			return task
+		}
+		if visited[original.ID] {
+			p.logger.Warn(ctx, "Duplicate link cycle detected", "task_id", original.ID)
+			return task
+		}
+		visited[original.ID] = true
+
+		p.logger.Info(ctx, "Reply routed from duplicate to original task",
+			"duplicate_id", task.ID,
+			"original_id", original.ID)
+		task = original
+	}
+
+	return task
+}
+
+// addCCWatchers добавляет адресатов из CC в наблюдатели задачи
+func (p *MessageProcessor) addCCWatchers(ctx context.Context, task *domain.Task, email domain.EmailMessage) *domain.Task {
+	for _, cc := range email.CC {
+		if cc == "" || strings.EqualFold(string(cc), string(email.From)) {
+			continue
+		}
+
+		customer, err := p.customerService.FindOrCreateByEmail(ctx, string(cc), p.extractNameFromEmail(string(cc)))
+		if err != nil {
+			p.logger.Warn(ctx, "Failed to resolve CC address",
+				"task_id", task.ID,
+				"cc", cc,
+				"error", err.Error())
+			continue
+		}
+
+		if task.FindParticipant(customer.ID) != nil {
+			continue
+		}
+
+		var updatedTask *domain.Task
+		err = ports.RetryOnConflict(ctx, ports.DefaultConflictRetries, func() error {
+			var err error
+			updatedTask, err = p.taskService.AddParticipant(ctx, task.ID, customer.ID, domain.RoleWatcher, "system")
+			return err
+		})
+		if err != nil {
+			p.logger.Warn(ctx, "Failed to add CC watcher",
+				"task_id", task.ID,
+				"customer_id", customer.ID,
+				"error", err.Error())
+			continue
+		}
+
+		p.logger.Debug(ctx, "CC address added as watcher",
+			"task_id", task.ID,
+			"customer_id", customer.ID)
+		task = updatedTask
+	}
+
+	return task
+}
+
 // autoAssignTask автоматически назначает задачу
 func (p *MessageProcessor) autoAssignTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
 	// Базовая логика назначения - по категории
@@ -699,3 +799,5 @@ func (p *MessageProcessor) getPreview(text string, length int) string {
 	}
 	return text[:length] + "..."
 }
+
+var _ ports.TaskMessageProcessor = (*MessageProcessor)(nil)
@@ -364,6 +364,80 @@ func TestMessageProcessor_OutgoingEmail(t *testing.T) {
 	assert.True(t, len(updatedTask.Messages) >= 1)
 }
 
+// TestMessageProcessor_CCBecomesWatchers проверяет, что адресаты из CC становятся наблюдателями задачи
+func TestMessageProcessor_CCBecomesWatchers(t *testing.T) {
+	ctx := context.Background()
+	logger := &TestLogger{}
+
+	taskRepo := inmemory.NewTaskRepository(logger)
+	customerRepo := inmemory.NewCustomerRepository(logger)
+	userRepo := inmemory.NewUserRepository(logger)
+
+	taskService := services.NewTaskService(taskRepo, customerRepo, userRepo, logger)
+	customerService := services.NewCustomerService(customerRepo, taskRepo, logger)
+	messageProcessor := email.NewMessageProcessor(
+		taskService,
+		customerService,
+		&mockEmailGateway{},
+		&MockEmailSearchConfigProvider{},
+		logger,
+	).(ports.TaskMessageProcessor)
+
+	taskID, err := messageProcessor.ProcessIncomingEmailForTask(ctx, domain.EmailMessage{
+		MessageID: "<cc-initial@example.com>",
+		InReplyTo: "<operator-reply@company.com>",
+		From:      "client@example.com",
+		To:        []domain.EmailAddress{"support@company.com"},
+		CC:        []domain.EmailAddress{"colleague@example.com", "Client@Example.com", "manager@example.com"},
+		Subject:   "Вопрос с копией коллегам",
+		BodyText:  "Прошу держать коллег в курсе",
+		Direction: domain.DirectionIncoming,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	client, err := customerService.FindOrCreateByEmail(ctx, "client@example.com", "")
+	require.NoError(t, err)
+	colleague, err := customerService.FindOrCreateByEmail(ctx, "colleague@example.com", "")
+	require.NoError(t, err)
+	manager, err := customerService.FindOrCreateByEmail(ctx, "manager@example.com", "")
+	require.NoError(t, err)
+
+	task, err := taskService.GetTask(ctx, taskID)
+	require.NoError(t, err)
+	assert.True(t, task.HasParticipantRole(colleague.ID, domain.RoleWatcher))
+	assert.True(t, task.HasParticipantRole(manager.ID, domain.RoleWatcher))
+	assert.False(t, task.HasParticipantRole(client.ID, domain.RoleWatcher), "отправитель в CC не становится наблюдателем")
+
+	// Участник с другой ролью остается в своей роли
+	_, err = taskService.ChangeParticipantRole(ctx, taskID, manager.ID, domain.RoleReviewer, "user-1")
+	require.NoError(t, err)
+
+	replyTaskID, err := messageProcessor.ProcessIncomingEmailForTask(ctx, domain.EmailMessage{
+		MessageID: "<cc-reply@example.com>",
+		InReplyTo: "<operator-reply@company.com>",
+		From:      "client@example.com",
+		To:        []domain.EmailAddress{"support@company.com"},
+		CC:        []domain.EmailAddress{"colleague@example.com", "manager@example.com"},
+		Subject:   "Re: Вопрос с копией коллегам",
+		BodyText:  "Дополнение",
+		Direction: domain.DirectionIncoming,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, taskID, replyTaskID, "ответ дополняет ту же задачу")
+
+	task, err = taskService.GetTask(ctx, taskID)
+	require.NoError(t, err)
+	counts := map[string]int{}
+	for _, participant := range task.Participants {
+		counts[participant.UserID]++
+	}
+	assert.Equal(t, 1, counts[colleague.ID], "повторный CC не создает второго наблюдателя")
+	assert.Equal(t, 1, counts[manager.ID])
+	assert.True(t, task.HasParticipantRole(manager.ID, domain.RoleReviewer))
+}
+
 // ✅ ДОБАВЛЯЕМ НОВЫЙ ТЕСТ ДЛЯ ПРОВЕРКИ КОНФИГУРАЦИИ
 func TestMessageProcessor_WithEnhancedSearchConfiguration(t *testing.T) {
 	ctx := context.Background()
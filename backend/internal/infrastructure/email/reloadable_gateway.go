@@ -0,0 +1,106 @@
+// internal/infrastructure/email/reloadable_gateway.go
+package email
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// ReloadableGateway реализует ports.ReconfigurableEmailGateway: делегирует вызовы
+// текущему шлюзу и заменяет его шлюзом нового канала без перезапуска приложения.
+// Операции, начатые до переключения, завершаются на прежнем шлюзе
+type ReloadableGateway struct {
+	mu      sync.RWMutex
+	current ports.EmailGateway
+	factory ports.EmailGatewayFactory
+	logger  ports.Logger
+}
+
+// NewReloadableGateway создает шлюз поверх начального шлюза и фабрики шлюзов каналов
+func NewReloadableGateway(initial ports.EmailGateway, factory ports.EmailGatewayFactory, logger ports.Logger) *ReloadableGateway {
+	return &ReloadableGateway{
+		current: initial,
+		factory: factory,
+		logger:  logger,
+	}
+}
+
+// Reconfigure создает шлюз канала и переключается на него; прежний шлюз отключается
+func (g *ReloadableGateway) Reconfigure(ctx context.Context, config *domain.EmailChannelConfig) error {
+	next, err := g.factory(config)
+	if err != nil {
+		return fmt.Errorf("failed to create gateway for channel %s: %w", config.ID, err)
+	}
+
+	g.mu.Lock()
+	previous := g.current
+	g.current = next
+	g.mu.Unlock()
+
+	if previous != nil {
+		if err := previous.Disconnect(); err != nil {
+			g.logger.Warn(ctx, "Failed to disconnect previous email gateway", "error", err.Error())
+		}
+	}
+	g.logger.Info(ctx, "Email gateway reconfigured",
+		"channel_id", config.ID,
+		"server", config.Server,
+		"mailbox", config.Mailbox)
+	return nil
+}
+
+func (g *ReloadableGateway) gateway() ports.EmailGateway {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.current
+}
+
+func (g *ReloadableGateway) Connect(ctx context.Context) error {
+	return g.gateway().Connect(ctx)
+}
+
+func (g *ReloadableGateway) Disconnect() error {
+	return g.gateway().Disconnect()
+}
+
+func (g *ReloadableGateway) HealthCheck(ctx context.Context) error {
+	return g.gateway().HealthCheck(ctx)
+}
+
+func (g *ReloadableGateway) FetchMessages(ctx context.Context, criteria ports.FetchCriteria) ([]domain.EmailMessage, error) {
+	return g.gateway().FetchMessages(ctx, criteria)
+}
+
+func (g *ReloadableGateway) SendMessage(ctx context.Context, msg domain.EmailMessage) error {
+	return g.gateway().SendMessage(ctx, msg)
+}
+
+func (g *ReloadableGateway) MarkAsRead(ctx context.Context, messageIDs []string) error {
+	return g.gateway().MarkAsRead(ctx, messageIDs)
+}
+
+func (g *ReloadableGateway) MarkAsProcessed(ctx context.Context, messageIDs []string) error {
+	return g.gateway().MarkAsProcessed(ctx, messageIDs)
+}
+
+func (g *ReloadableGateway) SearchThreadMessages(ctx context.Context, criteria ports.ThreadSearchCriteria) ([]domain.EmailMessage, error) {
+	return g.gateway().SearchThreadMessages(ctx, criteria)
+}
+
+func (g *ReloadableGateway) ListMailboxes(ctx context.Context) ([]ports.MailboxInfo, error) {
+	return g.gateway().ListMailboxes(ctx)
+}
+
+func (g *ReloadableGateway) SelectMailbox(ctx context.Context, name string) error {
+	return g.gateway().SelectMailbox(ctx, name)
+}
+
+func (g *ReloadableGateway) GetMailboxInfo(ctx context.Context, name string) (*ports.MailboxInfo, error) {
+	return g.gateway().GetMailboxInfo(ctx, name)
+}
+
+var _ ports.ReconfigurableEmailGateway = (*ReloadableGateway)(nil)
@@ -4,9 +4,11 @@ package email
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/audetv/urms/internal/core/ports"
+	"gopkg.in/yaml.v3"
 )
 
 // SearchConfigAdapter инфраструктурная реализация EmailSearchConfigProvider
@@ -39,6 +41,43 @@ type ProviderSearchConfig struct {
 	Optimizations  []string      `yaml:"optimizations"`
 }
 
+// LoadSearchConfig читает конфигурацию поиска из YAML-файла (секция email_search)
+func LoadSearchConfig(path string) (*EmailSearchConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search config: %w", err)
+	}
+
+	var file struct {
+		EmailSearch EmailSearchConfig `yaml:"email_search"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse search config %s: %w", path, err)
+	}
+	return &file.EmailSearch, nil
+}
+
+// DefaultSearchConfig конфигурация поиска, если файл конфигурации недоступен
+func DefaultSearchConfig() *EmailSearchConfig {
+	return &EmailSearchConfig{
+		ThreadSearch: ThreadSearchConfig{
+			DefaultDaysBack:     180, // 6 месяцев
+			ExtendedDaysBack:    365, // 1 год
+			MaxDaysBack:         730, // 2 года
+			FetchTimeout:        120 * time.Second,
+			IncludeSeenMessages: true,
+			SubjectPrefixes:     []string{"Re:", "RE:", "Fwd:", "FW:", "Ответ:", "FWD:"},
+		},
+		ProviderConfig: map[string]ProviderSearchConfig{
+			"generic": {
+				MaxDaysBack:   180,
+				SearchTimeout: 120 * time.Second,
+				Optimizations: []string{"standard_search"},
+			},
+		},
+	}
+}
+
 // NewSearchConfigAdapter создает новый адаптер конфигурации поиска
 func NewSearchConfigAdapter(config *EmailSearchConfig, logger ports.Logger) *SearchConfigAdapter {
 	return &SearchConfigAdapter{
@@ -258,3 +258,18 @@ func TestSearchConfigAdapter_ValidateConfig_InvalidThreadConfig(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "thread search configuration validation failed")
 }
+
+func TestLoadSearchConfig(t *testing.T) {
+	config, err := LoadSearchConfig("../../../config/email_search.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, 180, config.ThreadSearch.DefaultDaysBack)
+	assert.Equal(t, 730, config.ThreadSearch.MaxDaysBack)
+	assert.Equal(t, 120*time.Second, config.ThreadSearch.FetchTimeout)
+	assert.Contains(t, config.ThreadSearch.SubjectPrefixes, "Ответ:")
+	require.Contains(t, config.ProviderConfig, "gmail")
+	assert.Equal(t, 180*time.Second, config.ProviderConfig["gmail"].SearchTimeout)
+
+	_, err = LoadSearchConfig("missing.yaml")
+	assert.Error(t, err)
+}
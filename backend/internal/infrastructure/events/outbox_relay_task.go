@@ -0,0 +1,133 @@
+// internal/infrastructure/events/outbox_relay_task.go
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// OutboxRelayTask фоновая задача, периодически доставляющая события из outbox
+// подписчикам шины. Недоставленные события остаются в outbox и после перезапуска
+// доставляются первым же проходом
+type OutboxRelayTask struct {
+	relay            ports.OutboxRelay
+	interval         time.Duration
+	operationTimeout time.Duration
+	logger           ports.Logger
+	cancelFunc       context.CancelFunc
+	isRunning        bool
+	lastRunAt        time.Time
+	mu               sync.RWMutex
+}
+
+func NewOutboxRelayTask(
+	relay ports.OutboxRelay,
+	interval time.Duration,
+	operationTimeout time.Duration,
+	logger ports.Logger,
+) *OutboxRelayTask {
+	return &OutboxRelayTask{
+		relay:            relay,
+		interval:         interval,
+		operationTimeout: operationTimeout,
+		logger:           logger,
+		isRunning:        false,
+	}
+}
+
+func (t *OutboxRelayTask) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isRunning {
+		return fmt.Errorf("outbox relay task already running")
+	}
+	if t.interval <= 0 {
+		return fmt.Errorf("outbox relay interval must be positive")
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	t.cancelFunc = cancel
+	t.isRunning = true
+
+	go t.runLoop(taskCtx)
+
+	t.logger.Info(ctx, "outbox relay task started",
+		"interval", t.interval,
+		"operation_timeout", t.operationTimeout)
+
+	return nil
+}
+
+func (t *OutboxRelayTask) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.isRunning {
+		return nil
+	}
+
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
+
+	t.isRunning = false
+	t.logger.Info(ctx, "outbox relay task stopped")
+	return nil
+}
+
+func (t *OutboxRelayTask) Name() string {
+	return "outbox_relay"
+}
+
+func (t *OutboxRelayTask) Health(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.isRunning {
+		return fmt.Errorf("outbox relay task is not running")
+	}
+	if !t.lastRunAt.IsZero() && time.Since(t.lastRunAt) > 3*t.interval+t.operationTimeout {
+		return fmt.Errorf("outbox relay has not run since %s", t.lastRunAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (t *OutboxRelayTask) runLoop(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	t.executeRun(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.logger.Info(ctx, "outbox relay loop stopped")
+			return
+		case <-ticker.C:
+			t.executeRun(ctx)
+		}
+	}
+}
+
+func (t *OutboxRelayTask) executeRun(ctx context.Context) {
+	now := time.Now()
+	// Изменения, сделанные подписчиками, попадают в журнал как автоматические
+	runCtx := ports.WithAuditSource(ctx, domain.AuditSourceAutomation)
+
+	timeoutCtx, cancel := context.WithTimeout(runCtx, t.operationTimeout)
+	defer cancel()
+
+	if _, err := t.relay.RelayPending(timeoutCtx, now); err != nil {
+		t.logger.Error(runCtx, "outbox relay run failed", "error", err)
+	}
+
+	t.mu.Lock()
+	t.lastRunAt = now
+	t.mu.Unlock()
+}
@@ -0,0 +1,187 @@
+// internal/infrastructure/github/client.go
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+const (
+	// DefaultAPIBaseURL адрес REST API GitHub по умолчанию (для GitHub Enterprise - .../api/v3)
+	DefaultAPIBaseURL = "https://api.github.com"
+
+	apiVersion = "2022-11-28"
+	pageSize   = 100
+	// maxPages ограничивает одну синхронизацию: остаток заберет следующий проход
+	maxPages = 10
+)
+
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Client реализует ports.GitHubGateway поверх REST API.
+// Базовый адрес настраивается для GitHub Enterprise и тестового сервера
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL, token string, timeout time.Duration) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIBaseURL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *Client) CreateIssue(ctx context.Context, repo, title, body string) (*domain.GitHubIssue, error) {
+	var issue apiIssue
+	payload := map[string]string{"title": title, "body": body}
+	if _, err := c.do(ctx, http.MethodPost, c.repoURL(repo, "/issues"), payload, &issue); err != nil {
+		return nil, err
+	}
+	result := issue.toDomain(repo)
+	return &result, nil
+}
+
+func (c *Client) CreateComment(ctx context.Context, repo string, number int, body string) (*domain.GitHubComment, error) {
+	var comment apiComment
+	payload := map[string]string{"body": body}
+	if _, err := c.do(ctx, http.MethodPost, c.repoURL(repo, fmt.Sprintf("/issues/%d/comments", number)), payload, &comment); err != nil {
+		return nil, err
+	}
+	result := comment.toDomain(repo)
+	result.IssueNumber = number
+	return &result, nil
+}
+
+func (c *Client) SetIssueState(ctx context.Context, repo string, number int, state string) error {
+	payload := map[string]string{"state": state}
+	_, err := c.do(ctx, http.MethodPatch, c.repoURL(repo, fmt.Sprintf("/issues/%d", number)), payload, nil)
+	return err
+}
+
+func (c *Client) ListIssues(ctx context.Context, repo string, since time.Time) ([]domain.GitHubIssue, error) {
+	query := url.Values{
+		"state":     {"all"},
+		"since":     {since.UTC().Format(time.RFC3339)},
+		"sort":      {"updated"},
+		"direction": {"asc"},
+		"per_page":  {fmt.Sprint(pageSize)},
+	}
+
+	var result []domain.GitHubIssue
+	err := c.list(ctx, c.repoURL(repo, "/issues")+"?"+query.Encode(), func(data json.RawMessage) error {
+		var issues []apiIssue
+		if err := json.Unmarshal(data, &issues); err != nil {
+			return err
+		}
+		for i := range issues {
+			result = append(result, issues[i].toDomain(repo))
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (c *Client) ListComments(ctx context.Context, repo string, since time.Time) ([]domain.GitHubComment, error) {
+	query := url.Values{
+		"since":     {since.UTC().Format(time.RFC3339)},
+		"sort":      {"updated"},
+		"direction": {"asc"},
+		"per_page":  {fmt.Sprint(pageSize)},
+	}
+
+	var result []domain.GitHubComment
+	err := c.list(ctx, c.repoURL(repo, "/issues/comments")+"?"+query.Encode(), func(data json.RawMessage) error {
+		var comments []apiComment
+		if err := json.Unmarshal(data, &comments); err != nil {
+			return err
+		}
+		for i := range comments {
+			result = append(result, comments[i].toDomain(repo))
+		}
+		return nil
+	})
+	return result, err
+}
+
+// list проходит по страницам ответа согласно заголовку Link
+func (c *Client) list(ctx context.Context, pageURL string, handle func(json.RawMessage) error) error {
+	for page := 0; pageURL != "" && page < maxPages; page++ {
+		var data json.RawMessage
+		header, err := c.do(ctx, http.MethodGet, pageURL, nil, &data)
+		if err != nil {
+			return err
+		}
+		if err := handle(data); err != nil {
+			return fmt.Errorf("invalid github list response: %w", err)
+		}
+
+		pageURL = ""
+		if match := nextLinkPattern.FindStringSubmatch(header.Get("Link")); match != nil {
+			pageURL = match[1]
+		}
+	}
+	return nil
+}
+
+func (c *Client) repoURL(repo, suffix string) string {
+	return c.baseURL + "/repos/" + repo + suffix
+}
+
+func (c *Client) do(ctx context.Context, method, requestURL string, payload interface{}, result interface{}) (http.Header, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode github request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	req.Header.Set("User-Agent", "urms")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github %s %s failed: %w", method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&apiErr)
+		return nil, fmt.Errorf("github %s %s failed: status %d: %s", method, req.URL.Path, resp.StatusCode, apiErr.Message)
+	}
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return nil, fmt.Errorf("invalid github response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
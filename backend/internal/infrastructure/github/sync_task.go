@@ -0,0 +1,134 @@
+// internal/infrastructure/github/sync_task.go
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// SyncTask фоновая задача, периодически забирающая issue и комментарии
+// отслеживаемых репозиториев. Дополняет webhook: события, пропущенные
+// во время недоступности сервиса, подхватываются при следующем проходе
+type SyncTask struct {
+	githubService    ports.GitHubService
+	interval         time.Duration
+	operationTimeout time.Duration
+	logger           ports.Logger
+	cancelFunc       context.CancelFunc
+	isRunning        bool
+	lastRunAt        time.Time
+	mu               sync.RWMutex
+}
+
+func NewSyncTask(
+	githubService ports.GitHubService,
+	interval time.Duration,
+	operationTimeout time.Duration,
+	logger ports.Logger,
+) *SyncTask {
+	return &SyncTask{
+		githubService:    githubService,
+		interval:         interval,
+		operationTimeout: operationTimeout,
+		logger:           logger,
+		isRunning:        false,
+	}
+}
+
+func (t *SyncTask) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isRunning {
+		return fmt.Errorf("github sync task already running")
+	}
+	if t.interval <= 0 {
+		return fmt.Errorf("github sync interval must be positive")
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	t.cancelFunc = cancel
+	t.isRunning = true
+
+	go t.runLoop(taskCtx)
+
+	t.logger.Info(ctx, "github sync task started",
+		"interval", t.interval,
+		"operation_timeout", t.operationTimeout)
+
+	return nil
+}
+
+func (t *SyncTask) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.isRunning {
+		return nil
+	}
+
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
+
+	t.isRunning = false
+	t.logger.Info(ctx, "github sync task stopped")
+	return nil
+}
+
+func (t *SyncTask) Name() string {
+	return "github_sync"
+}
+
+func (t *SyncTask) Health(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.isRunning {
+		return fmt.Errorf("github sync task is not running")
+	}
+	if !t.lastRunAt.IsZero() && time.Since(t.lastRunAt) > 3*t.interval+t.operationTimeout {
+		return fmt.Errorf("github sync has not run since %s", t.lastRunAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (t *SyncTask) runLoop(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	t.executeRun(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.logger.Info(ctx, "github sync loop stopped")
+			return
+		case <-ticker.C:
+			t.executeRun(ctx)
+		}
+	}
+}
+
+func (t *SyncTask) executeRun(ctx context.Context) {
+	now := time.Now()
+	runCtx := context.WithValue(ctx, ports.CorrelationIDKey, fmt.Sprintf("github-%d", now.UnixNano()))
+
+	timeoutCtx, cancel := context.WithTimeout(runCtx, t.operationTimeout)
+	defer cancel()
+
+	handled, err := t.githubService.SyncRepositories(timeoutCtx)
+	if err != nil {
+		t.logger.Error(runCtx, "github sync run failed", "error", err)
+	} else if handled > 0 {
+		t.logger.Debug(runCtx, "github items synchronized", "count", handled)
+	}
+
+	t.mu.Lock()
+	t.lastRunAt = now
+	t.mu.Unlock()
+}
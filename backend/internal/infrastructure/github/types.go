@@ -0,0 +1,117 @@
+// internal/infrastructure/github/types.go
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// Типы REST API и webhook GitHub, нужные для синхронизации задач
+
+type apiUser struct {
+	Login string `json:"login"`
+}
+
+type apiIssue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	State       string          `json:"state"`
+	HTMLURL     string          `json:"html_url"`
+	User        apiUser         `json:"user"`
+	PullRequest json.RawMessage `json:"pull_request"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+func (i *apiIssue) toDomain(repo string) domain.GitHubIssue {
+	return domain.GitHubIssue{
+		Repo:          repo,
+		Number:        i.Number,
+		Title:         i.Title,
+		Body:          i.Body,
+		State:         i.State,
+		URL:           i.HTMLURL,
+		AuthorLogin:   i.User.Login,
+		IsPullRequest: len(i.PullRequest) > 0 && string(i.PullRequest) != "null",
+		CreatedAt:     i.CreatedAt,
+		UpdatedAt:     i.UpdatedAt,
+	}
+}
+
+type apiComment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	IssueURL  string    `json:"issue_url"`
+	User      apiUser   `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c *apiComment) toDomain(repo string) domain.GitHubComment {
+	// Номер issue есть только в адресе: .../repos/owner/repo/issues/12
+	number, _ := strconv.Atoi(path.Base(c.IssueURL))
+	return domain.GitHubComment{
+		ID:          c.ID,
+		Repo:        repo,
+		IssueNumber: number,
+		Body:        c.Body,
+		AuthorLogin: c.User.Login,
+		URL:         c.HTMLURL,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+type webhookPayload struct {
+	Action     string      `json:"action"`
+	Issue      *apiIssue   `json:"issue"`
+	Comment    *apiComment `json:"comment"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// Имена событий из заголовка X-GitHub-Event
+const (
+	EventIssues       = "issues"
+	EventIssueComment = "issue_comment"
+	EventPing         = "ping"
+)
+
+// ParseWebhookEvent разбирает тело webhook по имени события.
+// Возвращает false для событий, не относящихся к issue
+func ParseWebhookEvent(eventName string, body []byte) (domain.GitHubWebhookEvent, bool, error) {
+	if eventName != EventIssues && eventName != EventIssueComment {
+		return domain.GitHubWebhookEvent{}, false, nil
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return domain.GitHubWebhookEvent{}, false, fmt.Errorf("invalid github %s payload: %w", eventName, err)
+	}
+	if payload.Issue == nil || payload.Repository.FullName == "" {
+		return domain.GitHubWebhookEvent{}, false, fmt.Errorf("github %s payload has no issue or repository", eventName)
+	}
+
+	repo := payload.Repository.FullName
+	issue := payload.Issue.toDomain(repo)
+	event := domain.GitHubWebhookEvent{Action: payload.Action, Issue: &issue}
+	if eventName == EventIssueComment {
+		if payload.Comment == nil {
+			return domain.GitHubWebhookEvent{}, false, fmt.Errorf("github %s payload has no comment", eventName)
+		}
+		comment := payload.Comment.toDomain(repo)
+		comment.IssueNumber = issue.Number
+		event.Comment = &comment
+	}
+	return event, true, nil
+}
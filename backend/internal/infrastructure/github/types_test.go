@@ -0,0 +1,48 @@
+// internal/infrastructure/github/types_test.go
+package github
+
+import (
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	event, ok, err := ParseWebhookEvent(EventIssueComment, []byte(`{
+		"action": "created",
+		"repository": {"full_name": "acme/app"},
+		"issue": {"number": 12, "title": "Ошибка", "state": "open", "user": {"login": "alice"},
+			"body": "Текст\n\n<!-- urms-task:TASK-1 -->"},
+		"comment": {"id": 555, "body": "Ответ\n\n<!-- urms-message:MSG-1 -->", "user": {"login": "bob"},
+			"issue_url": "https://api.github.com/repos/acme/app/issues/12"}
+	}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, domain.GitHubActionCreated, event.Action)
+	assert.Equal(t, domain.GitHubIssueRef{Repo: "acme/app", Number: 12}, event.Issue.Ref())
+	assert.Equal(t, "TASK-1", event.Issue.LinkedTaskID())
+	assert.False(t, event.Issue.IsPullRequest)
+	require.NotNil(t, event.Comment)
+	assert.Equal(t, 12, event.Comment.IssueNumber)
+	assert.Equal(t, "555", event.Comment.ExternalID())
+	assert.Equal(t, "MSG-1", event.Comment.LinkedMessageID())
+
+	event, ok, err = ParseWebhookEvent(EventIssues, []byte(`{
+		"action": "closed",
+		"repository": {"full_name": "acme/app"},
+		"issue": {"number": 13, "state": "closed", "pull_request": {"url": "https://api.github.com/pulls/13"}}
+	}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, event.Issue.IsPullRequest)
+	assert.Nil(t, event.Comment)
+
+	_, ok, err = ParseWebhookEvent(EventPing, []byte(`{"zen": "Keep it logically awesome."}`))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = ParseWebhookEvent(EventIssues, []byte(`{"action": "opened"}`))
+	assert.Error(t, err)
+}
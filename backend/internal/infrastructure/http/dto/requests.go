@@ -20,6 +20,8 @@ type CreateTaskRequest struct {
 	ParentID    *string         `json:"parent_id,omitempty"`
 	ProjectID   *string         `json:"project_id,omitempty"`
 	DueDate     *time.Time      `json:"due_date,omitempty"`
+	// CustomFields значения пользовательских полей (ключ → значение)
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 type CreateSupportTaskRequest struct {
@@ -56,6 +58,8 @@ type UpdateTaskRequest struct {
 	Category    *string          `json:"category,omitempty" binding:"omitempty,min=1,max=100"`
 	Tags        *[]string        `json:"tags,omitempty"`
 	DueDate     *time.Time       `json:"due_date,omitempty"`
+	// CustomFields изменяемые пользовательские поля; null очищает поле
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 type ChangeStatusRequest struct {
@@ -66,10 +70,171 @@ type AssignTaskRequest struct {
 	AssigneeID string `json:"assignee_id" binding:"required"`
 }
 
+type AddParticipantRequest struct {
+	UserID string                 `json:"user_id" binding:"required"`
+	Role   domain.ParticipantRole `json:"role" binding:"required,oneof=reviewer watcher participant"`
+}
+
+type ChangeParticipantRoleRequest struct {
+	Role domain.ParticipantRole `json:"role" binding:"required,oneof=reviewer watcher participant"`
+}
+
+type LinkTaskRequest struct {
+	TargetID string              `json:"target_id" binding:"required"`
+	Type     domain.TaskLinkType `json:"type" binding:"required,oneof=blocks blocked_by relates_to duplicate_of duplicated_by caused_by causes"`
+}
+
+// CreateGitHubIssueRequest создание issue, связанного с задачей.
+// Пустые поля заполняются репозиторием по умолчанию, темой и описанием задачи
+type CreateGitHubIssueRequest struct {
+	Repo  string `json:"repo"`
+	Title string `json:"title" binding:"max=256"`
+	Body  string `json:"body"`
+}
+
+type LogWorkRequest struct {
+	Minutes  int    `json:"minutes" binding:"required,min=1,max=1440"`
+	Date     string `json:"date,omitempty"` // YYYY-MM-DD, по умолчанию - сегодня
+	Note     string `json:"note,omitempty" binding:"max=1000"`
+	Billable bool   `json:"billable"`
+}
+
+type StopTimerRequest struct {
+	Note     string `json:"note,omitempty" binding:"max=1000"`
+	Billable bool   `json:"billable"`
+}
+
+type TimeReportRequest struct {
+	From         string `form:"from" binding:"required"` // YYYY-MM-DD
+	To           string `form:"to" binding:"required"`   // YYYY-MM-DD, включительно
+	GroupBy      string `form:"group_by" binding:"omitempty,oneof=customer organization assignee"`
+	CustomerID   string `form:"customer_id"`
+	UserID       string `form:"user_id"`
+	BillableOnly bool   `form:"billable_only"`
+	Format       string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+type SatisfactionReportRequest struct {
+	From       string `form:"from" binding:"required"` // YYYY-MM-DD
+	To         string `form:"to" binding:"required"`   // YYYY-MM-DD, включительно
+	GroupBy    string `form:"group_by" binding:"omitempty,oneof=operator customer category day week month"`
+	OperatorID string `form:"operator_id"`
+	CustomerID string `form:"customer_id"`
+	Category   string `form:"category"`
+}
+
+// AuditQueryRequest параметры выборки из журнала аудита
+type AuditQueryRequest struct {
+	UserID        string `form:"user_id"`
+	EntityType    string `form:"entity_type" binding:"omitempty,oneof=task"`
+	EntityID      string `form:"entity_id"`
+	Type          string `form:"type"`
+	Source        string `form:"source" binding:"omitempty,oneof=api email automation system"`
+	CorrelationID string `form:"correlation_id"`
+	From          string `form:"from"` // RFC3339 или YYYY-MM-DD
+	To            string `form:"to"`   // RFC3339 или YYYY-MM-DD, включительно
+	Language      string `form:"language" binding:"omitempty,oneof=ru en"`
+	Page          int    `form:"page" binding:"omitempty,min=1"`
+	PageSize      int    `form:"page_size" binding:"omitempty,min=1,max=500"`
+}
+
+// SearchRequest параметры полнотекстового поиска; фильтры принимают значения через запятую
+type SearchRequest struct {
+	Query    string `form:"q" binding:"required"`
+	Type     string `form:"type"` // task, email, customer
+	Status   string `form:"status"`
+	Priority string `form:"priority"`
+	Category string `form:"category"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// WebhookRequest подписка на вебхуки
+type WebhookRequest struct {
+	Name        string                   `json:"name" binding:"required,min=1,max=255"`
+	URL         string                   `json:"url" binding:"required,url"`
+	Secret      string                   `json:"secret,omitempty" binding:"omitempty,min=16,max=255"` // Пусто - генерируется
+	EventTypes  []domain.DomainEventType `json:"event_types,omitempty"`                               // Пусто - все события
+	TaskTypes   []domain.TaskType        `json:"task_types,omitempty"`
+	CustomerIDs []string                 `json:"customer_ids,omitempty"`
+}
+
+// UpdateWebhookRequest изменение подписки; отсутствующие поля не меняются
+type UpdateWebhookRequest struct {
+	Name        *string                   `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	URL         *string                   `json:"url,omitempty" binding:"omitempty,url"`
+	Secret      *string                   `json:"secret,omitempty" binding:"omitempty,min=16,max=255"`
+	EventTypes  *[]domain.DomainEventType `json:"event_types,omitempty"`
+	TaskTypes   *[]domain.TaskType        `json:"task_types,omitempty"`
+	CustomerIDs *[]string                 `json:"customer_ids,omitempty"`
+	Active      *bool                     `json:"active,omitempty"`
+}
+
+// WebhookDeliveryQueryRequest параметры выборки журнала доставок
+type WebhookDeliveryQueryRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending succeeded dead_letter"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=500"`
+}
+
+// InboundMappingRequest выражения JSONPath, по которым из payload извлекаются поля задачи
+type InboundMappingRequest struct {
+	Subject          string `json:"subject" binding:"required"`
+	Description      string `json:"description,omitempty"`
+	CustomerEmail    string `json:"customer_email" binding:"required"`
+	CustomerName     string `json:"customer_name,omitempty"`
+	Priority         string `json:"priority,omitempty"`
+	Tags             string `json:"tags,omitempty"`
+	ExternalID       string `json:"external_id" binding:"required"`
+	ExternalThreadID string `json:"external_thread_id,omitempty"`
+}
+
+// InboundChannelRequest создание входящего канала
+type InboundChannelRequest struct {
+	Key             string                     `json:"key" binding:"required,max=64"`
+	Name            string                     `json:"name" binding:"required,min=1,max=255"`
+	Source          domain.TaskSource          `json:"source,omitempty" binding:"omitempty,oneof=api web_form"`
+	Secret          string                     `json:"secret,omitempty" binding:"omitempty,min=16,max=255"` // Пусто - генерируется
+	Mapping         InboundMappingRequest      `json:"mapping" binding:"required"`
+	PriorityMap     map[string]domain.Priority `json:"priority_map,omitempty"`
+	DefaultPriority domain.Priority            `json:"default_priority,omitempty" binding:"omitempty,oneof=low medium high critical"`
+	DefaultTags     []string                   `json:"default_tags,omitempty"`
+}
+
+// UpdateInboundChannelRequest изменение входящего канала; отсутствующие поля не меняются
+type UpdateInboundChannelRequest struct {
+	Name            *string                     `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Secret          *string                     `json:"secret,omitempty" binding:"omitempty,min=16,max=255"` // Новый секрет подписи
+	Mapping         *InboundMappingRequest      `json:"mapping,omitempty"`
+	PriorityMap     *map[string]domain.Priority `json:"priority_map,omitempty"`
+	DefaultPriority *domain.Priority            `json:"default_priority,omitempty" binding:"omitempty,oneof=low medium high critical"`
+	DefaultTags     *[]string                   `json:"default_tags,omitempty"`
+	Active          *bool                       `json:"active,omitempty"`
+}
+
+// SurveyResponseRequest ответ клиента на опрос: из формы подтверждения или JSON
+type SurveyResponseRequest struct {
+	Rating  int    `json:"rating" form:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment,omitempty" form:"comment" binding:"max=2000"`
+}
+
+// SurveyLinkQuery оценка, выбранная в письме; переход по ссылке только предлагает ее подтвердить
+type SurveyLinkQuery struct {
+	Rating int `form:"rating" binding:"omitempty,min=1,max=5"`
+}
+
 type AddMessageRequest struct {
-	Content   string             `json:"content" binding:"required,min=1,max=10000"`
-	Type      domain.MessageType `json:"type" binding:"required,oneof=customer internal system"`
-	IsPrivate bool               `json:"is_private,omitempty"`
+	Content     string                     `json:"content" binding:"required,min=1,max=10000"`
+	Type        domain.MessageType         `json:"type" binding:"required,oneof=customer internal system"`
+	IsPrivate   bool                       `json:"is_private,omitempty"`
+	Attachments []MessageAttachmentRequest `json:"attachments,omitempty" binding:"omitempty,max=10,dive"`
+}
+
+// MessageAttachmentRequest вложение сообщения; data передается в base64
+type MessageAttachmentRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	ContentType string `json:"content_type" binding:"required,max=255"`
+	Data        []byte `json:"data" binding:"required"`
 }
 
 type AddInternalNoteRequest struct {
@@ -108,6 +273,99 @@ type TaskSearchRequest struct {
 	PageSize   int                 `json:"page_size,omitempty" form:"page_size" binding:"omitempty,min=1,max=100"`
 	SortBy     string              `json:"sort_by,omitempty" form:"sort_by"`
 	SortOrder  string              `json:"sort_order,omitempty" form:"sort_order" binding:"omitempty,oneof=asc desc"`
+	Snoozed    string              `json:"snoozed,omitempty" form:"snoozed" binding:"omitempty,oneof=true false all"` // По умолчанию отложенные задачи скрыты
+	ListPageRequest
+}
+
+// ListPageRequest сортировка и курсорная пагинация списков:
+// sort=-priority,created_at&limit=50&cursor=<next_cursor из предыдущего ответа>
+type ListPageRequest struct {
+	Sort   string `json:"sort,omitempty" form:"sort"`
+	Cursor string `json:"cursor,omitempty" form:"cursor"`
+	Limit  int    `json:"limit,omitempty" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type CustomFieldRequest struct {
+	Key          string                 `json:"key" binding:"required"`
+	Name         string                 `json:"name" binding:"required,min=1,max=255"`
+	Type         domain.CustomFieldType `json:"type" binding:"required,oneof=text number enum date user customer"`
+	TaskTypes    []domain.TaskType      `json:"task_types,omitempty"`
+	Categories   []string               `json:"categories,omitempty"`
+	Required     bool                   `json:"required"`
+	DefaultValue interface{}            `json:"default_value,omitempty"`
+	Options      []string               `json:"options,omitempty"`
+}
+
+type UpdateCustomFieldRequest struct {
+	Name         string            `json:"name" binding:"omitempty,min=1,max=255"`
+	TaskTypes    []domain.TaskType `json:"task_types,omitempty"`
+	Categories   []string          `json:"categories,omitempty"`
+	Required     bool              `json:"required"`
+	DefaultValue interface{}       `json:"default_value,omitempty"`
+	Options      []string          `json:"options,omitempty"`
+}
+
+// MacroActions действия макроса, применяемые вместе с ответом по шаблону
+type MacroActions struct {
+	Status     domain.TaskStatus `json:"status,omitempty" binding:"omitempty,oneof=open in_progress review resolved closed cancelled"`
+	Tags       []string          `json:"tags,omitempty"`
+	AssigneeID string            `json:"assignee_id,omitempty"`
+}
+
+type ReplyTemplateRequest struct {
+	Name     string                             `json:"name" binding:"required,min=1,max=255"`
+	Category string                             `json:"category,omitempty"`
+	Variants map[domain.TemplateLanguage]string `json:"variants" binding:"required,min=1"`
+	Actions  MacroActions                       `json:"actions"`
+}
+
+type UpdateReplyTemplateRequest struct {
+	Name     string                             `json:"name" binding:"omitempty,min=1,max=255"`
+	Category string                             `json:"category,omitempty"`
+	Variants map[domain.TemplateLanguage]string `json:"variants,omitempty"`
+	Actions  MacroActions                       `json:"actions"`
+}
+
+type ApplyReplyTemplateRequest struct {
+	Language  domain.TemplateLanguage `json:"language,omitempty" binding:"omitempty,oneof=ru en"`
+	IsPrivate bool                    `json:"is_private"`
+	Macro     bool                    `json:"macro"` // Применить действия макроса вместе с ответом
+}
+
+type SnoozeTaskRequest struct {
+	Until time.Time `json:"until" binding:"required"`
+}
+
+type RecurringTaskRequest struct {
+	Name        string          `json:"name" binding:"required,min=1,max=255"`
+	Schedule    string          `json:"schedule" binding:"required"` // Cron ("0 9 1 * *", @daily) или RRULE
+	Timezone    string          `json:"timezone,omitempty"`
+	Subject     string          `json:"subject" binding:"required,min=1,max=500"`
+	Description string          `json:"description" binding:"required,min=1"`
+	Priority    domain.Priority `json:"priority,omitempty" binding:"omitempty,oneof=low medium high critical"`
+	Category    string          `json:"category,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	AssigneeID  string          `json:"assignee_id,omitempty"`
+	Enabled     *bool           `json:"enabled,omitempty"`
+}
+
+type UpdateRecurringTaskRequest struct {
+	Name        string          `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Schedule    string          `json:"schedule,omitempty"`
+	Timezone    string          `json:"timezone,omitempty"`
+	Subject     string          `json:"subject,omitempty" binding:"omitempty,min=1,max=500"`
+	Description string          `json:"description,omitempty"`
+	Priority    domain.Priority `json:"priority,omitempty" binding:"omitempty,oneof=low medium high critical"`
+	Category    string          `json:"category,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	AssigneeID  string          `json:"assignee_id,omitempty"`
+	Enabled     *bool           `json:"enabled,omitempty"`
+}
+
+type ScheduleReplyRequest struct {
+	Content   string    `json:"content" binding:"required,min=1"`
+	IsPrivate bool      `json:"is_private"`
+	SendAt    time.Time `json:"send_at" binding:"required"`
 }
 
 type CustomerSearchRequest struct {
@@ -116,4 +374,139 @@ type CustomerSearchRequest struct {
 	Email        string `json:"email,omitempty" form:"email"`
 	Page         int    `json:"page,omitempty" form:"page" binding:"omitempty,min=1"`
 	PageSize     int    `json:"page_size,omitempty" form:"page_size" binding:"omitempty,min=1,max=100"`
+	ListPageRequest
+}
+
+// EmailSearchRequest фильтры списка писем; направление, источник и период задаются
+// компактными фильтрами: direction=incoming, source!=smtp, created>=2025-01-01
+type EmailSearchRequest struct {
+	Processed *bool  `json:"processed,omitempty" form:"processed"`
+	From      string `json:"from,omitempty" form:"from"` // Адрес отправителя
+	TaskID    string `json:"task_id,omitempty" form:"task_id"`
+	Page      int    `json:"page,omitempty" form:"page" binding:"omitempty,min=1"`
+	PageSize  int    `json:"page_size,omitempty" form:"page_size" binding:"omitempty,min=1,max=100"`
+	ListPageRequest
+}
+
+// LinkEmailRequest ручная привязка письма к задаче
+type LinkEmailRequest struct {
+	TaskID string `json:"task_id" binding:"required"`
+}
+
+// Email Settings Requests
+
+// EmailChannelRequest конфигурация почтового канала; ID канала задается в пути
+type EmailChannelRequest struct {
+	Name                string `json:"name" binding:"max=255"`
+	Provider            string `json:"provider,omitempty" binding:"omitempty,oneof=imap"` // Пусто - imap
+	Server              string `json:"server" binding:"required,max=255"`
+	Port                int    `json:"port" binding:"required,min=1,max=65535"`
+	Username            string `json:"username" binding:"required,max=255"`
+	Password            string `json:"password,omitempty"`                  // Пусто - сохраняется прежний пароль
+	Mailbox             string `json:"mailbox,omitempty" binding:"max=255"` // Пусто - INBOX
+	SSL                 bool   `json:"ssl"`
+	PollIntervalSeconds int    `json:"poll_interval_seconds" binding:"min=0"`
+	Active              bool   `json:"active"`
+}
+
+// EmailPolicyRequest политика обработки входящих писем
+type EmailPolicyRequest struct {
+	ReadOnlyMode   bool     `json:"read_only_mode"`
+	AutoReply      bool     `json:"auto_reply"`
+	AutoReplyText  string   `json:"auto_reply_text,omitempty" binding:"max=5000"`
+	SpamFilter     bool     `json:"spam_filter"`
+	MaxMessageSize int64    `json:"max_message_size" binding:"required,min=1"` // Байты
+	AllowedSenders []string `json:"allowed_senders,omitempty"`                 // Пусто - разрешены все
+	BlockedSenders []string `json:"blocked_senders,omitempty"`
+}
+
+// ThreadSearchConfigRequest окна поиска писем треда, в днях
+type ThreadSearchConfigRequest struct {
+	DefaultDaysBack     int      `json:"default_days_back" binding:"required,min=1"`
+	ExtendedDaysBack    int      `json:"extended_days_back" binding:"required,min=1"`
+	MaxDaysBack         int      `json:"max_days_back" binding:"required,min=1"`
+	FetchTimeoutSeconds int      `json:"fetch_timeout_seconds" binding:"required,min=1"`
+	IncludeSeenMessages bool     `json:"include_seen_messages"`
+	SubjectPrefixes     []string `json:"subject_prefixes,omitempty"` // Пусто - префиксы по умолчанию
+}
+
+// Auth Requests
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
+}
+
+// CreateUserRequest без password пользователь приглашается и задает пароль сам
+type CreateUserRequest struct {
+	Email      string   `json:"email" binding:"required,email"`
+	Name       string   `json:"name" binding:"required,min=1,max=255"`
+	Role       string   `json:"role" binding:"required,oneof=admin manager operator viewer"`
+	Password   string   `json:"password,omitempty" binding:"omitempty,min=8,max=72"`
+	Timezone   string   `json:"timezone,omitempty"`
+	Language   string   `json:"language,omitempty" binding:"omitempty,oneof=ru en"`
+	Skills     []string `json:"skills,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+type UpdateUserProfileRequest struct {
+	Name      string `json:"name" binding:"required,min=1,max=255"`
+	Timezone  string `json:"timezone"`
+	Language  string `json:"language" binding:"omitempty,oneof=ru en"`
+	Signature string `json:"signature" binding:"max=2000"`
+}
+
+type ChangeUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin manager operator viewer"`
+}
+
+// UpdateUserRoutingRequest пустые списки: без навыков, все категории
+type UpdateUserRoutingRequest struct {
+	Skills     []string `json:"skills"`
+	Categories []string `json:"categories"`
+}
+
+// OutOfOfficeRequest без from отсутствие начинается сразу
+type OutOfOfficeRequest struct {
+	From    *time.Time `json:"from,omitempty"`
+	Until   time.Time  `json:"until" binding:"required"`
+	Message string     `json:"message" binding:"max=1000"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// UserListQuery фильтры списка пользователей
+type UserListQuery struct {
+	Role      string `form:"role" binding:"omitempty,oneof=admin manager operator viewer"`
+	Status    string `form:"status" binding:"omitempty,oneof=invited active deactivated"`
+	Skill     string `form:"skill"`
+	Category  string `form:"category"`
+	Available *bool  `form:"available"`
+}
+
+// CreateAPIKeyRequest ключ API интеграции; без expires_at ключ бессрочный
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,min=1,max=255"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// TaskStreamQuery подписка на поток изменений задач. Без очередей и задач
+// передаются изменения всех задач, доступных пользователю
+type TaskStreamQuery struct {
+	Queues      []string `form:"queue" binding:"max=50,dive,min=1"`   // Очереди (категории задач)
+	TaskIDs     []string `form:"task_id" binding:"max=50,dive,min=1"` // Отдельные задачи
+	LastEventID uint64   `form:"last_event_id"`                       // Для клиентов, которые не передают заголовок Last-Event-ID
+	StreamToken string   `form:"stream_token"`                        // Токен POST /auth/stream-token для EventSource, который не передает Authorization
 }
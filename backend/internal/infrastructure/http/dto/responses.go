@@ -2,6 +2,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
@@ -29,10 +30,11 @@ type MetaInfo struct {
 }
 
 type PageInfo struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"page_size"`
-	TotalCount int `json:"total_count"`
-	TotalPages int `json:"total_pages"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalCount int    `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"` // Курсор следующей страницы; нет на последней
 }
 
 // Task Responses
@@ -46,6 +48,7 @@ type TaskResponse struct {
 	Priority    domain.Priority   `json:"priority"`
 	Category    string            `json:"category"`
 	Tags        []string          `json:"tags"`
+	Version     int               `json:"version"` // Совпадает с ETag; передается в If-Match при изменении
 
 	// Relationships
 	ParentID   *string `json:"parent_id,omitempty"`
@@ -58,9 +61,22 @@ type TaskResponse struct {
 	Source     domain.TaskSource      `json:"source"`
 	SourceMeta map[string]interface{} `json:"source_meta,omitempty"`
 
+	// Custom fields
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
 	// Participants
 	Participants []ParticipantResponse `json:"participants,omitempty"`
 
+	// Links and subtasks
+	Links           []TaskLinkResponse       `json:"links,omitempty"`
+	SubtaskProgress *SubtaskProgressResponse `json:"subtask_progress,omitempty"`
+
+	// Time tracking
+	TimeSpentMinutes int                 `json:"time_spent_minutes"`
+	BillableMinutes  int                 `json:"billable_minutes"`
+	WorkLogs         []WorkLogResponse   `json:"work_logs,omitempty"`
+	Timers           []TaskTimerResponse `json:"timers,omitempty"`
+
 	// Messages (only in detailed responses)
 	Messages []MessageResponse `json:"messages,omitempty"`
 
@@ -73,6 +89,135 @@ type TaskResponse struct {
 	DueDate    *time.Time `json:"due_date,omitempty"`
 	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
 	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+
+	// Snooze
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+
+	// CSAT
+	Satisfaction *SatisfactionResponse `json:"satisfaction,omitempty"`
+}
+
+type SatisfactionResponse struct {
+	SentAt      time.Time  `json:"sent_at"`
+	Rating      int        `json:"rating,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+	OperatorID  string     `json:"operator_id,omitempty"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+// SurveyResultResponse ответ публичного эндпоинта опроса (без данных задачи)
+type SurveyResultResponse struct {
+	TaskID  string `json:"task_id"`
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment,omitempty"`
+	Message string `json:"message"`
+}
+
+// SurveyConfirmationResponse ответ на переход по ссылке опроса: оценка еще не записана,
+// клиент подтверждает ее POST-запросом на тот же адрес
+type SurveyConfirmationResponse struct {
+	TaskID        string `json:"task_id"`
+	Rating        int    `json:"rating,omitempty"`         // Оценка, выбранная в письме
+	CurrentRating int    `json:"current_rating,omitempty"` // Ранее записанная оценка
+	Answered      bool   `json:"answered"`
+	Message       string `json:"message"`
+}
+
+type SatisfactionSummaryResponse struct {
+	Responses     int         `json:"responses"`
+	Positive      int         `json:"positive"`
+	AverageRating float64     `json:"average_rating"`
+	CSAT          float64     `json:"csat"`
+	Distribution  map[int]int `json:"distribution"`
+}
+
+type SatisfactionReportRowResponse struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	SatisfactionSummaryResponse
+}
+
+type SatisfactionReportResponse struct {
+	From         string                          `json:"from"`
+	To           string                          `json:"to"`
+	GroupBy      string                          `json:"group_by"`
+	Rows         []SatisfactionReportRowResponse `json:"rows"`
+	Total        SatisfactionSummaryResponse     `json:"total"`
+	SurveysSent  int                             `json:"surveys_sent"`
+	ResponseRate float64                         `json:"response_rate"`
+}
+
+type CustomFieldResponse struct {
+	Key          string                 `json:"key"`
+	Name         string                 `json:"name"`
+	Type         domain.CustomFieldType `json:"type"`
+	TaskTypes    []domain.TaskType      `json:"task_types"`
+	Categories   []string               `json:"categories"`
+	Required     bool                   `json:"required"`
+	DefaultValue interface{}            `json:"default_value,omitempty"`
+	Options      []string               `json:"options,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+type ReplyTemplateResponse struct {
+	ID        string                             `json:"id"`
+	Name      string                             `json:"name"`
+	Category  string                             `json:"category,omitempty"`
+	Variants  map[domain.TemplateLanguage]string `json:"variants"`
+	Languages []domain.TemplateLanguage          `json:"languages"`
+	Actions   MacroActions                       `json:"actions"`
+	CreatedBy string                             `json:"created_by,omitempty"`
+	CreatedAt time.Time                          `json:"created_at"`
+	UpdatedAt time.Time                          `json:"updated_at"`
+}
+
+type RenderedReplyResponse struct {
+	TemplateID string                  `json:"template_id"`
+	TaskID     string                  `json:"task_id"`
+	Language   domain.TemplateLanguage `json:"language"`
+	Content    string                  `json:"content"`
+	Actions    MacroActions            `json:"actions"`
+}
+
+type ApplyReplyTemplateResponse struct {
+	Task       TaskResponse          `json:"task"`
+	Reply      RenderedReplyResponse `json:"reply"`
+	EmailSent  bool                  `json:"email_sent"`
+	EmailError string                `json:"email_error,omitempty"`
+}
+
+type RecurringTaskResponse struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Schedule    string          `json:"schedule"`
+	Timezone    string          `json:"timezone,omitempty"`
+	Subject     string          `json:"subject"`
+	Description string          `json:"description"`
+	Priority    domain.Priority `json:"priority"`
+	Category    string          `json:"category,omitempty"`
+	Tags        []string        `json:"tags"`
+	AssigneeID  string          `json:"assignee_id,omitempty"`
+	ReporterID  string          `json:"reporter_id"`
+	Enabled     bool            `json:"enabled"`
+	NextRunAt   *time.Time      `json:"next_run_at,omitempty"`
+	LastRunAt   *time.Time      `json:"last_run_at,omitempty"`
+	LastTaskID  string          `json:"last_task_id,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type ScheduledReplyResponse struct {
+	ID        string                      `json:"id"`
+	TaskID    string                      `json:"task_id"`
+	AuthorID  string                      `json:"author_id"`
+	Content   string                      `json:"content"`
+	IsPrivate bool                        `json:"is_private"`
+	SendAt    time.Time                   `json:"send_at"`
+	Status    domain.ScheduledReplyStatus `json:"status"`
+	SentAt    *time.Time                  `json:"sent_at,omitempty"`
+	Error     string                      `json:"error,omitempty"`
+	CreatedAt time.Time                   `json:"created_at"`
 }
 
 type ParticipantResponse struct {
@@ -81,12 +226,72 @@ type ParticipantResponse struct {
 	JoinedAt time.Time              `json:"joined_at"`
 }
 
+type TaskLinkResponse struct {
+	Type      domain.TaskLinkType `json:"type"`
+	TaskID    string              `json:"task_id"`
+	CreatedBy string              `json:"created_by"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+type SubtaskProgressResponse struct {
+	Total     int     `json:"total"`
+	Completed int     `json:"completed"`
+	Percent   float64 `json:"percent"`
+}
+
+type SubtasksResponse struct {
+	Subtasks []TaskResponse          `json:"subtasks"`
+	Progress SubtaskProgressResponse `json:"progress"`
+}
+
+type WorkLogResponse struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Minutes   int       `json:"minutes"`
+	Date      string    `json:"date"`
+	Note      string    `json:"note,omitempty"`
+	Billable  bool      `json:"billable"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TaskTimerResponse struct {
+	UserID    string    `json:"user_id"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+type TimeReportResponse struct {
+	From            string                  `json:"from"`
+	To              string                  `json:"to"`
+	GroupBy         string                  `json:"group_by"`
+	Rows            []TimeReportRowResponse `json:"rows"`
+	TotalMinutes    int                     `json:"total_minutes"`
+	BillableMinutes int                     `json:"billable_minutes"`
+}
+
+type TimeReportRowResponse struct {
+	Key             string `json:"key"`
+	Name            string `json:"name"`
+	TotalMinutes    int    `json:"total_minutes"`
+	BillableMinutes int    `json:"billable_minutes"`
+	EntryCount      int    `json:"entry_count"`
+	TaskCount       int    `json:"task_count"`
+}
+
 type MessageResponse struct {
-	ID        string             `json:"id"`
-	Content   string             `json:"content"`
-	AuthorID  string             `json:"author_id"`
-	Type      domain.MessageType `json:"type"`
-	CreatedAt time.Time          `json:"created_at"`
+	ID          string                      `json:"id"`
+	Content     string                      `json:"content"`
+	AuthorID    string                      `json:"author_id"`
+	Type        domain.MessageType          `json:"type"`
+	IsPrivate   bool                        `json:"is_private"`
+	Attachments []MessageAttachmentResponse `json:"attachments,omitempty"`
+	CreatedAt   time.Time                   `json:"created_at"`
+}
+
+type MessageAttachmentResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
 }
 
 type TaskEventResponse struct {
@@ -97,6 +302,146 @@ type TaskEventResponse struct {
 	NewValue  interface{} `json:"new_value,omitempty"`
 	Timestamp time.Time   `json:"timestamp"`
 	Message   string      `json:"message"`
+	// Changes типизированные изменения полей
+	Changes []FieldChangeResponse `json:"changes,omitempty"`
+}
+
+// FieldChangeResponse изменение поля
+type FieldChangeResponse struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+// AuditEventResponse запись журнала аудита; Text - описание на запрошенном языке
+type AuditEventResponse struct {
+	ID            string                `json:"id"`
+	EntityType    string                `json:"entity_type"`
+	EntityID      string                `json:"entity_id"`
+	Type          string                `json:"type"`
+	ActorID       string                `json:"actor_id"`
+	Source        string                `json:"source"`
+	CorrelationID string                `json:"correlation_id,omitempty"`
+	Changes       []FieldChangeResponse `json:"changes,omitempty"`
+	Text          string                `json:"text"`
+	Timestamp     time.Time             `json:"timestamp"`
+}
+
+// AuditLogResponse страница журнала аудита
+type AuditLogResponse struct {
+	Events     []AuditEventResponse `json:"events"`
+	Pagination PageInfo             `json:"pagination"`
+}
+
+// SearchHitResponse найденная сущность; Snippet - HTML с совпадениями в <mark>
+type SearchHitResponse struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Title      string    `json:"title"`
+	Snippet    string    `json:"snippet,omitempty"`
+	Rank       float64   `json:"rank"`
+	Status     string    `json:"status,omitempty"`
+	Priority   string    `json:"priority,omitempty"`
+	Category   string    `json:"category,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SearchFacetsResponse число найденных документов по значениям атрибутов
+type SearchFacetsResponse struct {
+	EntityTypes map[string]int `json:"entity_types"`
+	Statuses    map[string]int `json:"statuses"`
+	Priorities  map[string]int `json:"priorities"`
+	Categories  map[string]int `json:"categories"`
+}
+
+// SearchResponse страница результатов полнотекстового поиска
+type SearchResponse struct {
+	Hits       []SearchHitResponse  `json:"hits"`
+	Facets     SearchFacetsResponse `json:"facets"`
+	Pagination PageInfo             `json:"pagination"`
+}
+
+// ReindexResponse результат переиндексации
+type ReindexResponse struct {
+	Documents int `json:"documents"`
+}
+
+// WebhookResponse подписка на вебхуки; секрет возвращается только при создании
+type WebhookResponse struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	URL         string                   `json:"url"`
+	Secret      string                   `json:"secret,omitempty"`
+	EventTypes  []domain.DomainEventType `json:"event_types"`
+	TaskTypes   []domain.TaskType        `json:"task_types"`
+	CustomerIDs []string                 `json:"customer_ids"`
+	Active      bool                     `json:"active"`
+	CreatedBy   string                   `json:"created_by"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+type WebhookAttemptResponse struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID            string                   `json:"id"`
+	WebhookID     string                   `json:"webhook_id"`
+	EventID       string                   `json:"event_id"`
+	EventType     domain.DomainEventType   `json:"event_type"`
+	Status        string                   `json:"status"`
+	Attempts      []WebhookAttemptResponse `json:"attempts"`
+	NextAttemptAt *time.Time               `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time               `json:"delivered_at,omitempty"`
+	Payload       json.RawMessage          `json:"payload"`
+	CreatedAt     time.Time                `json:"created_at"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+}
+
+// WebhookDeliveryListResponse страница журнала доставок
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Pagination PageInfo                  `json:"pagination"`
+}
+
+type InboundMappingResponse struct {
+	Subject          string `json:"subject"`
+	Description      string `json:"description,omitempty"`
+	CustomerEmail    string `json:"customer_email"`
+	CustomerName     string `json:"customer_name,omitempty"`
+	Priority         string `json:"priority,omitempty"`
+	Tags             string `json:"tags,omitempty"`
+	ExternalID       string `json:"external_id"`
+	ExternalThreadID string `json:"external_thread_id,omitempty"`
+}
+
+// InboundChannelResponse входящий канал; секрет возвращается только при создании
+type InboundChannelResponse struct {
+	Key             string                     `json:"key"`
+	Name            string                     `json:"name"`
+	Source          domain.TaskSource          `json:"source"`
+	Secret          string                     `json:"secret,omitempty"`
+	Signed          bool                       `json:"signed"` // false - канал без секрета, записи не принимаются до его установки
+	InboundPath     string                     `json:"inbound_path"`
+	Mapping         InboundMappingResponse     `json:"mapping"`
+	PriorityMap     map[string]domain.Priority `json:"priority_map"`
+	DefaultPriority domain.Priority            `json:"default_priority"`
+	DefaultTags     []string                   `json:"default_tags"`
+	Active          bool                       `json:"active"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+}
+
+// InboundResultResponse итог приема записи входящего канала
+type InboundResultResponse struct {
+	Action     string            `json:"action"` // created, appended, duplicate
+	TaskID     string            `json:"task_id"`
+	TaskStatus domain.TaskStatus `json:"task_status"`
+	CustomerID string            `json:"customer_id,omitempty"`
 }
 
 type TaskListResponse struct {
@@ -104,6 +449,39 @@ type TaskListResponse struct {
 	Pagination PageInfo       `json:"pagination"`
 }
 
+type UserTasksResponse struct {
+	AssignedTasks   []TaskResponse `json:"assigned_tasks"`
+	ReportedTasks   []TaskResponse `json:"reported_tasks"`
+	ParticipatingIn []TaskResponse `json:"participating_in"`
+	Watching        []TaskResponse `json:"watching"`
+	UnassignedTasks []TaskResponse `json:"unassigned_tasks,omitempty"`
+}
+
+type DashboardResponse struct {
+	MyTasks         []TaskResponse              `json:"my_tasks"`
+	AssignedCount   int                         `json:"assigned_count"`
+	UnassignedCount int                         `json:"unassigned_count"`
+	SnoozedCount    int                         `json:"snoozed_count"`
+	OverdueCount    int                         `json:"overdue_count"`
+	RecentActivity  []DashboardActivityResponse `json:"recent_activity"`
+	Stats           UserStatsResponse           `json:"stats"`
+}
+
+type DashboardActivityResponse struct {
+	Type      string `json:"type"`
+	TaskID    string `json:"task_id"`
+	Subject   string `json:"subject"`
+	UserID    string `json:"user_id"`
+	Timestamp string `json:"timestamp"`
+}
+
+type UserStatsResponse struct {
+	OpenTasks         int     `json:"open_tasks"`
+	ResolvedToday     int     `json:"resolved_today"`
+	AvgResolutionTime float64 `json:"avg_resolution_time"`
+	SatisfactionRate  float64 `json:"satisfaction_rate"`
+}
+
 // Customer Responses
 
 type CustomerResponse struct {
@@ -136,6 +514,8 @@ type CustomerStats struct {
 	Satisfaction    float64                 `json:"satisfaction"`
 	ByPriority      map[domain.Priority]int `json:"by_priority"`
 	ByCategory      map[string]int          `json:"by_category"`
+	TimeSpentHours  float64                 `json:"time_spent_hours"`
+	BillableHours   float64                 `json:"billable_hours"`
 }
 
 type CustomerListResponse struct {
@@ -143,13 +523,182 @@ type CustomerListResponse struct {
 	Pagination PageInfo           `json:"pagination"`
 }
 
+// Email Responses
+
+// EmailResponse письмо в списке
+type EmailResponse struct {
+	ID               string     `json:"id"`
+	MessageID        string     `json:"message_id"`
+	From             string     `json:"from"`
+	To               []string   `json:"to"`
+	Subject          string     `json:"subject"`
+	Direction        string     `json:"direction"`
+	Source           string     `json:"source"`
+	Processed        bool       `json:"processed"`
+	ProcessedAt      *time.Time `json:"processed_at,omitempty"`
+	RelatedTaskID    string     `json:"related_task_id,omitempty"`
+	AttachmentsCount int        `json:"attachments_count"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// EmailAttachmentResponse описание вложения без содержимого
+type EmailAttachmentResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+// EmailDetailResponse письмо со всеми заголовками и телом.
+// HasRaw - сохранен исходный текст; иначе .eml собирается из сохраненных полей
+type EmailDetailResponse struct {
+	EmailResponse
+	InReplyTo   string                    `json:"in_reply_to,omitempty"`
+	References  []string                  `json:"references,omitempty"`
+	CC          []string                  `json:"cc,omitempty"`
+	BCC         []string                  `json:"bcc,omitempty"`
+	Headers     map[string][]string       `json:"headers"`
+	BodyText    string                    `json:"body_text"`
+	BodyHTML    string                    `json:"body_html"`
+	Attachments []EmailAttachmentResponse `json:"attachments"`
+	HasRaw      bool                      `json:"has_raw"`
+}
+
+type EmailListResponse struct {
+	Emails     []EmailResponse `json:"emails"`
+	Pagination PageInfo        `json:"pagination"`
+}
+
+// EmailStatisticsResponse статистика обработки писем
+type EmailStatisticsResponse struct {
+	UnprocessedCount int        `json:"unprocessed_count"`
+	RecentCount      int        `json:"recent_count"` // За последние 24 часа
+	LastProcessed    *time.Time `json:"last_processed,omitempty"`
+}
+
+// Email Settings Responses
+
+// EmailChannelResponse конфигурация почтового канала; пароль не возвращается
+type EmailChannelResponse struct {
+	ID                  string    `json:"id"`
+	Name                string    `json:"name"`
+	Provider            string    `json:"provider"`
+	Server              string    `json:"server"`
+	Port                int       `json:"port"`
+	Username            string    `json:"username"`
+	HasPassword         bool      `json:"has_password"`
+	Mailbox             string    `json:"mailbox"`
+	SSL                 bool      `json:"ssl"`
+	PollIntervalSeconds int       `json:"poll_interval_seconds"`
+	Active              bool      `json:"active"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type EmailPolicyResponse struct {
+	ReadOnlyMode   bool     `json:"read_only_mode"`
+	AutoReply      bool     `json:"auto_reply"`
+	AutoReplyText  string   `json:"auto_reply_text"`
+	SpamFilter     bool     `json:"spam_filter"`
+	MaxMessageSize int64    `json:"max_message_size"`
+	AllowedSenders []string `json:"allowed_senders"`
+	BlockedSenders []string `json:"blocked_senders"`
+}
+
+type ThreadSearchConfigResponse struct {
+	DefaultDaysBack     int      `json:"default_days_back"`
+	ExtendedDaysBack    int      `json:"extended_days_back"`
+	MaxDaysBack         int      `json:"max_days_back"`
+	FetchTimeoutSeconds int      `json:"fetch_timeout_seconds"`
+	IncludeSeenMessages bool     `json:"include_seen_messages"`
+	SubjectPrefixes     []string `json:"subject_prefixes"`
+}
+
+// EmailConnectionTestResponse результат проверки подключения канала
+type EmailConnectionTestResponse struct {
+	ChannelID  string `json:"channel_id"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
 // User Responses
 
 type UserResponse struct {
-	ID    string          `json:"id"`
-	Email string          `json:"email"`
-	Name  string          `json:"name"`
-	Role  domain.UserRole `json:"role"`
+	ID            string               `json:"id"`
+	Email         string               `json:"email"`
+	Name          string               `json:"name"`
+	Role          domain.UserRole      `json:"role"`
+	Status        domain.UserStatus    `json:"status"`
+	Available     bool                 `json:"available"` // Активен и не отсутствует
+	Timezone      string               `json:"timezone,omitempty"`
+	Language      string               `json:"language,omitempty"`
+	Signature     string               `json:"signature,omitempty"`
+	Skills        []string             `json:"skills"`
+	Categories    []string             `json:"categories"`
+	OutOfOffice   *OutOfOfficeResponse `json:"out_of_office,omitempty"`
+	CreatedAt     *time.Time           `json:"created_at,omitempty"`
+	DeactivatedAt *time.Time           `json:"deactivated_at,omitempty"`
+}
+
+type OutOfOfficeResponse struct {
+	From    time.Time `json:"from"`
+	Until   time.Time `json:"until"`
+	Message string    `json:"message,omitempty"`
+	Active  bool      `json:"active"` // Отсутствие идет сейчас
+}
+
+// InvitedUserResponse токен приглашения показывается только в ответе на создание
+// или повторное приглашение и передается пользователю администратором
+type InvitedUserResponse struct {
+	UserResponse
+	InvitationToken     string     `json:"invitation_token,omitempty"`
+	InvitationExpiresAt *time.Time `json:"invitation_expires_at,omitempty"`
+}
+
+// Auth Responses
+
+type AuthTokensResponse struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int       `json:"expires_in"` // Секунд до истечения access-токена
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	Active     bool       `json:"active"`
+}
+
+// StreamTokenResponse токен подключения к потоку изменений (параметр stream_token)
+type StreamTokenResponse struct {
+	StreamToken string    `json:"stream_token"`
+	ExpiresAt   time.Time `json:"expires_at"` // Токен проверяется только при подключении
+}
+
+// CreatedAPIKeyResponse ключ показывается только в ответе на создание
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// TaskStreamEventResponse событие потока изменений задач (поле data события SSE)
+type TaskStreamEventResponse struct {
+	ID         string                     `json:"id"`
+	Type       domain.TaskStreamEventType `json:"type"`
+	TaskID     string                     `json:"task_id"`
+	ActorID    string                     `json:"actor_id,omitempty"`
+	OccurredAt time.Time                  `json:"occurred_at"`
+	Task       TaskResponse               `json:"task"`              // Без сообщений и истории
+	Message    *MessageResponse           `json:"message,omitempty"` // Добавленное сообщение для task.message_added
 }
 
 // Health and System Responses
@@ -0,0 +1,20 @@
+// internal/infrastructure/http/handlers/access.go
+package handlers
+
+import (
+	"errors"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// abortForbidden передает отказ в доступе в ErrorHandlerMiddleware, который отвечает 403;
+// для остальных ошибок возвращает false
+func abortForbidden(c *gin.Context, err error) bool {
+	if !errors.Is(err, domain.ErrForbidden) {
+		return false
+	}
+	c.Error(err)
+	c.Abort()
+	return true
+}
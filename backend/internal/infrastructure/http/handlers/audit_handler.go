@@ -0,0 +1,209 @@
+// internal/infrastructure/http/handlers/audit_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultAuditPageSize размер страницы журнала по умолчанию
+const defaultAuditPageSize = 50
+
+// AuditHandler обслуживает историю задач и журнал аудита
+type AuditHandler struct {
+	auditService ports.AuditService
+	logger       ports.Logger
+}
+
+func NewAuditHandler(auditService ports.AuditService, logger ports.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// GetTaskHistory возвращает историю изменений задачи
+// @Summary История задачи
+// @Description Все изменения задачи в хронологическом порядке с описанием на выбранном языке
+// @Tags audit
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param language query string false "Язык описаний (ru, en); по умолчанию из Accept-Language"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.AuditEventResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/history [get]
+func (h *AuditHandler) GetTaskHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	events, err := h.auditService.GetTaskHistory(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get task history", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"TASK_NOT_FOUND",
+			"Задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toAuditEventResponses(events, requestLanguage(c, c.Query("language")))))
+}
+
+// QueryEvents выполняет выборку из журнала аудита
+// @Summary Журнал аудита
+// @Description Изменения всех сущностей с фильтрами по пользователю, периоду и сущности; новые записи первыми
+// @Tags audit
+// @Produce json
+// @Param user_id query string false "Автор изменения"
+// @Param entity_type query string false "Тип сущности" Enums(task)
+// @Param entity_id query string false "ID сущности"
+// @Param type query string false "Тип события"
+// @Param source query string false "Источник" Enums(api, email, automation, system)
+// @Param correlation_id query string false "Correlation ID запроса"
+// @Param from query string false "Начало периода (RFC3339 или YYYY-MM-DD)"
+// @Param to query string false "Конец периода включительно (RFC3339 или YYYY-MM-DD)"
+// @Param language query string false "Язык описаний (ru, en)"
+// @Param page query int false "Номер страницы"
+// @Param page_size query int false "Размер страницы"
+// @Success 200 {object} dto.BaseResponse{data=dto.AuditLogResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/audit [get]
+func (h *AuditHandler) QueryEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.AuditQueryRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	from, errFrom := parseAuditTime(req.From, false)
+	to, errTo := parseAuditTime(req.To, true)
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_DATE",
+			"Неверный формат даты (ожидается RFC3339 или YYYY-MM-DD)",
+			"from="+req.From+" to="+req.To,
+		))
+		return
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PageSize == 0 {
+		req.PageSize = defaultAuditPageSize
+	}
+
+	result, err := h.auditService.QueryEvents(ctx, ports.AuditQuery{
+		EntityType:    domain.AuditEntityType(req.EntityType),
+		EntityID:      req.EntityID,
+		ActorID:       req.UserID,
+		Type:          req.Type,
+		Source:        domain.AuditSource(req.Source),
+		CorrelationID: req.CorrelationID,
+		From:          from,
+		To:            to,
+		Offset:        (req.Page - 1) * req.PageSize,
+		Limit:         req.PageSize,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to query audit log", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"AUDIT_QUERY_FAILED",
+			"Не удалось выполнить выборку из журнала",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.AuditLogResponse{
+		Events: toAuditEventResponses(result.Events, requestLanguage(c, req.Language)),
+		Pagination: dto.PageInfo{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: result.TotalCount,
+			TotalPages: (result.TotalCount + req.PageSize - 1) / req.PageSize,
+		},
+	}))
+}
+
+// requestLanguage определяет язык ответа: явный параметр, затем Accept-Language
+func requestLanguage(c *gin.Context, explicit string) domain.TemplateLanguage {
+	if lang := domain.TemplateLanguage(explicit); lang.IsValid() {
+		return lang
+	}
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if lang := domain.TemplateLanguage(base); lang.IsValid() {
+			return lang
+		}
+	}
+	return domain.DefaultTemplateLanguage
+}
+
+// parseAuditTime разбирает границу периода; дата без времени для конца периода включает весь день
+func parseAuditTime(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return &t, nil
+}
+
+func toAuditEventResponses(events []domain.AuditEvent, lang domain.TemplateLanguage) []dto.AuditEventResponse {
+	responses := make([]dto.AuditEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = dto.AuditEventResponse{
+			ID:            event.ID,
+			EntityType:    string(event.EntityType),
+			EntityID:      event.EntityID,
+			Type:          event.Type,
+			ActorID:       event.ActorID,
+			Source:        string(event.Source),
+			CorrelationID: event.CorrelationID,
+			Changes:       toFieldChangeResponses(event.Changes),
+			Text:          domain.RenderAuditEvent(event, lang),
+			Timestamp:     event.Timestamp,
+		}
+	}
+	return responses
+}
+
+func toFieldChangeResponses(changes []domain.FieldChange) []dto.FieldChangeResponse {
+	if len(changes) == 0 {
+		return nil
+	}
+	responses := make([]dto.FieldChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = dto.FieldChangeResponse(change)
+	}
+	return responses
+}
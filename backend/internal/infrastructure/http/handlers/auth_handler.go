@@ -0,0 +1,298 @@
+// internal/infrastructure/http/handlers/auth_handler.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler выдает токены и управляет ключами API текущего пользователя
+type AuthHandler struct {
+	authService ports.AuthService
+	logger      ports.Logger
+}
+
+func NewAuthHandler(authService ports.AuthService, logger ports.Logger) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+// Login выполняет вход по паролю
+// @Summary Вход
+// @Description Проверяет email и пароль и выдает access- и refresh-токены
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginRequest true "Учетные данные"
+// @Success 200 {object} dto.BaseResponse{data=dto.AuthTokensResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 401 {object} dto.BaseResponse
+// @Router /api/auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.LoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	tokens, err := h.authService.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		h.respondAuthError(c, "Failed to log in", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toAuthTokensResponse(tokens)))
+}
+
+// Refresh обновляет пару токенов
+// @Summary Обновить токены
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh-токен"
+// @Success 200 {object} dto.BaseResponse{data=dto.AuthTokensResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 401 {object} dto.BaseResponse
+// @Router /api/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	tokens, err := h.authService.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		h.respondAuthError(c, "Failed to refresh tokens", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toAuthTokensResponse(tokens)))
+}
+
+// Me возвращает текущего пользователя
+// @Summary Текущий пользователь
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 401 {object} dto.BaseResponse
+// @Router /api/auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	user, ok := ports.AuthenticatedUser(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("UNAUTHORIZED", "Требуется аутентификация", ""))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// ChangePassword меняет пароль текущего пользователя
+// @Summary Сменить пароль
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ChangePasswordRequest true "Текущий и новый пароль"
+// @Success 200 {object} dto.BaseResponse
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 401 {object} dto.BaseResponse
+// @Router /api/auth/password [put]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.ChangePasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	if err := h.authService.ChangePassword(ctx, currentUserID(c), req.CurrentPassword, req.NewPassword); err != nil {
+		h.respondAuthError(c, "Failed to change password", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(nil))
+}
+
+// ListAPIKeys возвращает ключи API текущего пользователя
+// @Summary Ключи API
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=[]dto.APIKeyResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/auth/api-keys [get]
+func (h *AuthHandler) ListAPIKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	keys, err := h.authService.ListAPIKeys(ctx, currentUserID(c))
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list api keys", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"API_KEYS_FETCH_FAILED",
+			"Не удалось получить ключи API",
+			err.Error(),
+		))
+		return
+	}
+
+	now := time.Now()
+	responses := make([]dto.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = toAPIKeyResponse(&keys[i], now)
+	}
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// IssueStreamToken выдает токен подключения к потоку изменений
+// @Summary Токен потока изменений
+// @Description Короткоживущий токен для GET /api/v1/stream?stream_token=...: браузерный EventSource не передает заголовок Authorization
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.StreamTokenResponse}
+// @Failure 401 {object} dto.BaseResponse
+// @Router /api/auth/stream-token [post]
+func (h *AuthHandler) IssueStreamToken(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	token, expiresAt, err := h.authService.IssueStreamToken(ctx, currentUserID(c))
+	if err != nil {
+		h.respondAuthError(c, "Failed to issue stream token", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.StreamTokenResponse{
+		StreamToken: token,
+		ExpiresAt:   expiresAt,
+	}))
+}
+
+// CreateAPIKey создает ключ API текущего пользователя
+// @Summary Создать ключ API
+// @Description Ключ действует от имени текущего пользователя; значение ключа возвращается только в этом ответе
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "Название и срок действия"
+// @Success 201 {object} dto.BaseResponse{data=dto.CreatedAPIKeyResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/auth/api-keys [post]
+func (h *AuthHandler) CreateAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.CreateAPIKeyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	key, value, err := h.authService.CreateAPIKey(ctx, currentUserID(c), req.Name, req.ExpiresAt)
+	if err != nil {
+		h.logger.Warn(ctx, "Failed to create api key", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"API_KEY_CREATE_FAILED",
+			"Не удалось создать ключ API",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.CreatedAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key, time.Now()),
+		Key:            value,
+	}))
+}
+
+// RevokeAPIKey отзывает ключ API текущего пользователя
+// @Summary Отозвать ключ API
+// @Tags auth
+// @Produce json
+// @Param id path string true "ID ключа"
+// @Success 200 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/auth/api-keys/{id} [delete]
+func (h *AuthHandler) RevokeAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+	keyID := c.Param("id")
+
+	if err := h.authService.RevokeAPIKey(ctx, currentUserID(c), keyID); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("API_KEY_NOT_FOUND", "Ключ API не найден", err.Error()))
+			return
+		}
+		h.logger.Error(ctx, "Failed to revoke api key", "key_id", keyID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"API_KEY_REVOKE_FAILED",
+			"Не удалось отозвать ключ API",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(nil))
+}
+
+func (h *AuthHandler) respondAuthError(c *gin.Context, message string, err error) {
+	ctx := c.Request.Context()
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("INVALID_CREDENTIALS", "Неверный email или пароль", err.Error()))
+	case errors.Is(err, domain.ErrInvalidToken):
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("INVALID_TOKEN", "Токен недействителен или истек", err.Error()))
+	case errors.Is(err, domain.ErrPasswordTooShort):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_PASSWORD", "Слишком короткий пароль", err.Error()))
+	default:
+		h.logger.Error(ctx, message, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("AUTH_FAILED", "Ошибка аутентификации", err.Error()))
+	}
+}
+
+func toAuthTokensResponse(tokens *ports.AuthTokens) dto.AuthTokensResponse {
+	return dto.AuthTokensResponse{
+		AccessToken:      tokens.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(time.Until(tokens.AccessExpiresAt).Seconds()),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+	}
+}
+
+func toAPIKeyResponse(key *domain.APIKey, now time.Time) dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		Active:     key.IsActive(now),
+	}
+}
@@ -0,0 +1,334 @@
+// internal/infrastructure/http/handlers/channel_handler.go
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// maxInboundPayloadSize ограничение размера payload входящего канала
+const maxInboundPayloadSize = 1 << 20
+
+// ChannelHandler управляет входящими каналами и принимает записи внешних систем
+type ChannelHandler struct {
+	channelService ports.InboundChannelService
+	logger         ports.Logger
+}
+
+func NewChannelHandler(channelService ports.InboundChannelService, logger ports.Logger) *ChannelHandler {
+	return &ChannelHandler{
+		channelService: channelService,
+		logger:         logger,
+	}
+}
+
+// ListChannels возвращает входящие каналы
+// @Summary Список входящих каналов
+// @Tags channels
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=[]dto.InboundChannelResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/channels [get]
+func (h *ChannelHandler) ListChannels(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	channels, err := h.channelService.ListChannels(ctx)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to list inbound channels", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"CHANNELS_FETCH_FAILED",
+			"Не удалось получить входящие каналы",
+			err.Error(),
+		))
+		return
+	}
+
+	responses := make([]dto.InboundChannelResponse, len(channels))
+	for i := range channels {
+		responses[i] = toInboundChannelResponse(&channels[i], false)
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// CreateChannel создает входящий канал
+// @Summary Создать входящий канал
+// @Description Канал задает JSONPath-выражения для извлечения полей задачи из payload внешней системы
+// @Description Секрет подписи генерируется, если не задан, и возвращается только в этом ответе
+// @Tags channels
+// @Accept json
+// @Produce json
+// @Param request body dto.InboundChannelRequest true "Канал"
+// @Success 201 {object} dto.BaseResponse{data=dto.InboundChannelResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 409 {object} dto.BaseResponse
+// @Router /api/channels [post]
+func (h *ChannelHandler) CreateChannel(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.InboundChannelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid create channel request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	channel, err := h.channelService.CreateChannel(ctx, ports.CreateInboundChannelRequest{
+		Key:             req.Key,
+		Name:            req.Name,
+		Source:          req.Source,
+		Secret:          req.Secret,
+		Mapping:         toDomainInboundMapping(req.Mapping),
+		PriorityMap:     req.PriorityMap,
+		DefaultPriority: req.DefaultPriority,
+		DefaultTags:     req.DefaultTags,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "CHANNEL_CREATION_FAILED", "Не удалось создать входящий канал")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(toInboundChannelResponse(channel, true)))
+}
+
+// GetChannel возвращает входящий канал
+// @Summary Получить входящий канал
+// @Tags channels
+// @Produce json
+// @Param channelKey path string true "Ключ канала"
+// @Success 200 {object} dto.BaseResponse{data=dto.InboundChannelResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/channels/{channelKey} [get]
+func (h *ChannelHandler) GetChannel(c *gin.Context) {
+	channel, err := h.channelService.GetChannel(c.Request.Context(), c.Param("channelKey"))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "CHANNEL_FETCH_FAILED", "Не удалось получить входящий канал")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toInboundChannelResponse(channel, false)))
+}
+
+// UpdateChannel изменяет входящий канал
+// @Summary Изменить входящий канал
+// @Tags channels
+// @Accept json
+// @Produce json
+// @Param channelKey path string true "Ключ канала"
+// @Param request body dto.UpdateInboundChannelRequest true "Изменения"
+// @Success 200 {object} dto.BaseResponse{data=dto.InboundChannelResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/channels/{channelKey} [put]
+func (h *ChannelHandler) UpdateChannel(c *gin.Context) {
+	ctx := c.Request.Context()
+	key := c.Param("channelKey")
+	var req dto.UpdateInboundChannelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid update channel request", "channel", key, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	update := ports.UpdateInboundChannelRequest{
+		Name:            req.Name,
+		Secret:          req.Secret,
+		PriorityMap:     req.PriorityMap,
+		DefaultPriority: req.DefaultPriority,
+		DefaultTags:     req.DefaultTags,
+		Active:          req.Active,
+	}
+	if req.Mapping != nil {
+		mapping := toDomainInboundMapping(*req.Mapping)
+		update.Mapping = &mapping
+	}
+
+	channel, err := h.channelService.UpdateChannel(ctx, key, update)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "CHANNEL_UPDATE_FAILED", "Не удалось обновить входящий канал")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toInboundChannelResponse(channel, false)))
+}
+
+// DeleteChannel удаляет входящий канал
+// @Summary Удалить входящий канал
+// @Tags channels
+// @Param channelKey path string true "Ключ канала"
+// @Success 204
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/channels/{channelKey} [delete]
+func (h *ChannelHandler) DeleteChannel(c *gin.Context) {
+	if err := h.channelService.DeleteChannel(c.Request.Context(), c.Param("channelKey")); err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "CHANNEL_DELETE_FAILED", "Не удалось удалить входящий канал")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReceiveInbound принимает запись внешней системы
+// @Summary Принять запись входящего канала
+// @Description Создает задачу поддержки, дополняет задачу того же внешнего обсуждения или распознает повтор по внешнему ID.
+// @Description Тело должно быть подписано секретом канала: X-URMS-Signature: sha256=<hex HMAC-SHA256>
+// @Tags channels
+// @Accept json
+// @Produce json
+// @Param channelKey path string true "Ключ канала"
+// @Param X-URMS-Signature header string true "Подпись тела запроса"
+// @Param request body object true "Payload внешней системы"
+// @Success 200 {object} dto.BaseResponse{data=dto.InboundResultResponse}
+// @Success 201 {object} dto.BaseResponse{data=dto.InboundResultResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 401 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Failure 409 {object} dto.BaseResponse
+// @Router /api/channels/{channelKey}/inbound [post]
+func (h *ChannelHandler) ReceiveInbound(c *gin.Context) {
+	ctx := c.Request.Context()
+	key := c.Param("channelKey")
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxInboundPayloadSize))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.NewErrorResponse(
+			"PAYLOAD_TOO_LARGE",
+			"Слишком большой запрос",
+			err.Error(),
+		))
+		return
+	}
+
+	result, err := h.channelService.Receive(ctx, key, body, c.GetHeader(domain.WebhookSignatureHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInboundChannelNotFound):
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("CHANNEL_NOT_FOUND", "Входящий канал не найден", err.Error()))
+		case errors.Is(err, domain.ErrInboundChannelDisabled):
+			c.JSON(http.StatusForbidden, dto.NewErrorResponse("CHANNEL_DISABLED", "Входящий канал отключен", err.Error()))
+		case errors.Is(err, domain.ErrInboundSignatureInvalid):
+			c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("INVALID_SIGNATURE", "Неверная подпись запроса", err.Error()))
+		case errors.Is(err, domain.ErrInboundPayloadInvalid):
+			h.logger.Warn(ctx, "Invalid inbound payload", "channel", key, "error", err.Error())
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_PAYLOAD", "Неверный формат данных канала", err.Error()))
+		case errors.Is(err, domain.ErrInboundDeliveryInProgress):
+			// Та же запись обрабатывается параллельной доставкой; повтор получит ее результат
+			c.JSON(http.StatusConflict, dto.NewErrorResponse("INBOUND_IN_PROGRESS", "Запись уже обрабатывается", err.Error()))
+		default:
+			h.logger.Error(ctx, "Failed to process inbound record", "channel", key, "error", err.Error())
+			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+				"INBOUND_PROCESSING_FAILED",
+				"Не удалось обработать запись",
+				err.Error(),
+			))
+		}
+		return
+	}
+
+	response := dto.InboundResultResponse{
+		Action:     string(result.Action),
+		TaskID:     result.Task.ID,
+		TaskStatus: result.Task.Status,
+	}
+	if result.Task.CustomerID != nil {
+		response.CustomerID = *result.Task.CustomerID
+	}
+
+	status := http.StatusOK
+	if result.Action == ports.InboundActionCreated {
+		status = http.StatusCreated
+	}
+	c.JSON(status, dto.NewSuccessResponse(response))
+}
+
+// respondError отвечает 404 для отсутствующего канала, 409 для занятого ключа, иначе 400
+func (h *ChannelHandler) respondError(c *gin.Context, err error, code, message string) {
+	switch {
+	case errors.Is(err, domain.ErrInboundChannelNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("CHANNEL_NOT_FOUND", "Входящий канал не найден", err.Error()))
+	case errors.Is(err, domain.ErrInboundChannelExists):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("CHANNEL_EXISTS", "Канал с таким ключом уже существует", err.Error()))
+	default:
+		h.logger.Error(c.Request.Context(), message, "channel", c.Param("channelKey"), "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(code, message, err.Error()))
+	}
+}
+
+func toDomainInboundMapping(mapping dto.InboundMappingRequest) domain.InboundMapping {
+	return domain.InboundMapping{
+		Subject:          mapping.Subject,
+		Description:      mapping.Description,
+		CustomerEmail:    mapping.CustomerEmail,
+		CustomerName:     mapping.CustomerName,
+		Priority:         mapping.Priority,
+		Tags:             mapping.Tags,
+		ExternalID:       mapping.ExternalID,
+		ExternalThreadID: mapping.ExternalThreadID,
+	}
+}
+
+func toInboundChannelResponse(channel *domain.InboundChannel, withSecret bool) dto.InboundChannelResponse {
+	response := dto.InboundChannelResponse{
+		Key:         channel.Key,
+		Name:        channel.Name,
+		Source:      channel.Source,
+		Signed:      channel.Secret != "",
+		InboundPath: "/api/v1/channels/" + channel.Key + "/inbound",
+		Mapping: dto.InboundMappingResponse{
+			Subject:          channel.Mapping.Subject,
+			Description:      channel.Mapping.Description,
+			CustomerEmail:    channel.Mapping.CustomerEmail,
+			CustomerName:     channel.Mapping.CustomerName,
+			Priority:         channel.Mapping.Priority,
+			Tags:             channel.Mapping.Tags,
+			ExternalID:       channel.Mapping.ExternalID,
+			ExternalThreadID: channel.Mapping.ExternalThreadID,
+		},
+		PriorityMap:     channel.PriorityMap,
+		DefaultPriority: channel.DefaultPriority,
+		DefaultTags:     channel.DefaultTags,
+		Active:          channel.Active,
+		CreatedAt:       channel.CreatedAt,
+		UpdatedAt:       channel.UpdatedAt,
+	}
+	if response.PriorityMap == nil {
+		response.PriorityMap = map[string]domain.Priority{}
+	}
+	if response.DefaultTags == nil {
+		response.DefaultTags = []string{}
+	}
+	if withSecret {
+		response.Secret = channel.Secret
+	}
+	return response
+}
@@ -0,0 +1,241 @@
+// internal/infrastructure/http/handlers/custom_field_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldHandler struct {
+	customFieldService ports.CustomFieldService
+	logger             ports.Logger
+}
+
+func NewCustomFieldHandler(customFieldService ports.CustomFieldService, logger ports.Logger) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		customFieldService: customFieldService,
+		logger:             logger,
+	}
+}
+
+// ListFields возвращает описания пользовательских полей
+// @Summary Список пользовательских полей
+// @Description Возвращает описания полей; с task_type и category - только применимые к задаче
+// @Tags custom-fields
+// @Produce json
+// @Param task_type query string false "Тип задачи"
+// @Param category query string false "Категория"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.CustomFieldResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/custom-fields [get]
+func (h *CustomFieldHandler) ListFields(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var (
+		fields []domain.CustomFieldDefinition
+		err    error
+	)
+	if taskType := c.Query("task_type"); taskType != "" {
+		fields, err = h.customFieldService.ListFieldsFor(ctx, domain.TaskType(taskType), c.Query("category"))
+	} else {
+		fields, err = h.customFieldService.ListFields(ctx)
+	}
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to list custom fields", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"CUSTOM_FIELDS_FETCH_FAILED",
+			"Не удалось получить пользовательские поля",
+			err.Error(),
+		))
+		return
+	}
+
+	responses := make([]dto.CustomFieldResponse, len(fields))
+	for i := range fields {
+		responses[i] = toCustomFieldResponse(&fields[i])
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// CreateField создает пользовательское поле
+// @Summary Создать пользовательское поле
+// @Description Создает описание пользовательского поля задач
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param request body dto.CustomFieldRequest true "Описание поля"
+// @Success 201 {object} dto.BaseResponse{data=dto.CustomFieldResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/custom-fields [post]
+func (h *CustomFieldHandler) CreateField(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.CustomFieldRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid create custom field request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	field, err := h.customFieldService.CreateField(ctx, ports.CustomFieldRequest{
+		Key:          req.Key,
+		Name:         req.Name,
+		Type:         req.Type,
+		TaskTypes:    req.TaskTypes,
+		Categories:   req.Categories,
+		Required:     req.Required,
+		DefaultValue: req.DefaultValue,
+		Options:      req.Options,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to create custom field", "key", req.Key, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"CUSTOM_FIELD_CREATION_FAILED",
+			"Не удалось создать пользовательское поле",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Custom field created", "key", field.Key)
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(toCustomFieldResponse(field)))
+}
+
+// GetField возвращает пользовательское поле
+// @Summary Получить пользовательское поле
+// @Tags custom-fields
+// @Produce json
+// @Param key path string true "Ключ поля"
+// @Success 200 {object} dto.BaseResponse{data=dto.CustomFieldResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/custom-fields/{key} [get]
+func (h *CustomFieldHandler) GetField(c *gin.Context) {
+	ctx := c.Request.Context()
+	key := c.Param("key")
+
+	field, err := h.customFieldService.GetField(ctx, key)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"CUSTOM_FIELD_NOT_FOUND",
+			"Пользовательское поле не найдено",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toCustomFieldResponse(field)))
+}
+
+// UpdateField изменяет пользовательское поле
+// @Summary Изменить пользовательское поле
+// @Description Изменяет описание поля (ключ и тип не меняются)
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param key path string true "Ключ поля"
+// @Param request body dto.UpdateCustomFieldRequest true "Описание поля"
+// @Success 200 {object} dto.BaseResponse{data=dto.CustomFieldResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/custom-fields/{key} [put]
+func (h *CustomFieldHandler) UpdateField(c *gin.Context) {
+	ctx := c.Request.Context()
+	key := c.Param("key")
+	var req dto.UpdateCustomFieldRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid update custom field request", "key", key, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	field, err := h.customFieldService.UpdateField(ctx, key, ports.CustomFieldRequest{
+		Name:         req.Name,
+		TaskTypes:    req.TaskTypes,
+		Categories:   req.Categories,
+		Required:     req.Required,
+		DefaultValue: req.DefaultValue,
+		Options:      req.Options,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to update custom field", "key", key, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"CUSTOM_FIELD_UPDATE_FAILED",
+			"Не удалось обновить пользовательское поле",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Custom field updated", "key", key)
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toCustomFieldResponse(field)))
+}
+
+// DeleteField удаляет пользовательское поле
+// @Summary Удалить пользовательское поле
+// @Tags custom-fields
+// @Produce json
+// @Param key path string true "Ключ поля"
+// @Success 204
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/custom-fields/{key} [delete]
+func (h *CustomFieldHandler) DeleteField(c *gin.Context) {
+	ctx := c.Request.Context()
+	key := c.Param("key")
+
+	if err := h.customFieldService.DeleteField(ctx, key); err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to delete custom field", "key", key, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"CUSTOM_FIELD_DELETE_FAILED",
+			"Не удалось удалить пользовательское поле",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Custom field deleted", "key", key)
+	c.Status(http.StatusNoContent)
+}
+
+// toCustomFieldResponse преобразует описание поля в DTO
+func toCustomFieldResponse(field *domain.CustomFieldDefinition) dto.CustomFieldResponse {
+	return dto.CustomFieldResponse{
+		Key:          field.Key,
+		Name:         field.Name,
+		Type:         field.Type,
+		TaskTypes:    field.TaskTypes,
+		Categories:   field.Categories,
+		Required:     field.Required,
+		DefaultValue: field.DefaultValue,
+		Options:      field.Options,
+		CreatedAt:    field.CreatedAt,
+		UpdatedAt:    field.UpdatedAt,
+	}
+}
@@ -62,6 +62,9 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 
 	customer, err := h.customerService.CreateCustomer(ctx, createReq)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to create customer", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"CUSTOMER_CREATION_FAILED",
@@ -91,6 +94,9 @@ func (h *CustomerHandler) GetCustomer(c *gin.Context) {
 
 	customer, err := h.customerService.GetCustomerProfile(ctx, customerID)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to get customer", "customer_id", customerID, "error", err.Error())
 		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
 			"CUSTOMER_NOT_FOUND",
@@ -119,6 +125,9 @@ func (h *CustomerHandler) GetCustomerProfile(c *gin.Context) {
 
 	profile, err := h.customerService.GetCustomerProfile(ctx, customerID)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to get customer profile", "customer_id", customerID, "error", err.Error())
 		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
 			"CUSTOMER_NOT_FOUND",
@@ -169,6 +178,9 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 
 	customer, err := h.customerService.UpdateCustomer(ctx, customerID, updateReq)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to update customer", "customer_id", customerID, "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"CUSTOMER_UPDATE_FAILED",
@@ -199,6 +211,9 @@ func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
 
 	err := h.customerService.DeleteCustomer(ctx, customerID)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to delete customer", "customer_id", customerID, "error", err.Error())
 
 		// Определяем тип ошибки для соответствующего HTTP статуса
@@ -235,6 +250,10 @@ func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
 // @Param email query string false "Email"
 // @Param page query int false "Номер страницы" default(1) minimum(1)
 // @Param page_size query int false "Размер страницы" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Сортировка, минус - по убыванию: name, email, organization, created_at, updated_at"
+// @Param cursor query string false "Курсор следующей страницы (pagination.next_cursor)"
+// @Param limit query int false "Размер страницы (синоним page_size)" minimum(1) maximum(100)
+// @Param filter query string false "Компактные фильтры: organization!=Acme, created>=2025-01-01"
 // @Success 200 {object} dto.BaseResponse{data=dto.CustomerListResponse}
 // @Failure 400 {object} dto.BaseResponse
 // @Failure 500 {object} dto.BaseResponse
@@ -253,24 +272,36 @@ func (h *CustomerHandler) ListCustomers(c *gin.Context) {
 		return
 	}
 
-	// Устанавливаем значения по умолчанию
-	if req.Page == 0 {
-		req.Page = 1
-	}
-	if req.PageSize == 0 {
-		req.PageSize = 20
+	sort, ok := parseListSort(c, req.Sort, domain.CustomerSortFields)
+	if !ok {
+		return
 	}
+	offset, limit := listPageInfo(req.Page, req.PageSize, req.Limit, req.Cursor)
 
 	query := ports.CustomerQuery{
 		SearchText:   req.SearchText,
 		Organization: req.Organization,
 		Email:        req.Email,
-		Offset:       (req.Page - 1) * req.PageSize,
-		Limit:        req.PageSize,
+		Sort:         sort,
+		Cursor:       req.Cursor,
+		Offset:       offset,
+		Limit:        limit,
+	}
+	filters := listFilterSet{
+		"organization": func(f listFilter) error { return filterValues(f, nil, &query.ExcludeOrganizations) },
+		"created":      func(f listFilter) error { return filterTimeRange(f, &query.CreatedAt) },
+		"updated":      func(f listFilter) error { return filterTimeRange(f, &query.UpdatedAt) },
+	}
+	if err := applyListFilters(c.Request.URL.RawQuery, filters); err != nil {
+		abortInvalidListFilter(c, err)
+		return
 	}
 
 	result, err := h.customerService.ListCustomers(ctx, query)
 	if err != nil {
+		if abortForbidden(c, err) || abortInvalidCursor(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to list customers", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"LIST_CUSTOMERS_FAILED",
@@ -293,6 +324,7 @@ func (h *CustomerHandler) ListCustomers(c *gin.Context) {
 			PageSize:   result.PageSize,
 			TotalCount: result.TotalCount,
 			TotalPages: result.TotalPages,
+			NextCursor: result.NextCursor,
 		},
 	}
 
@@ -315,6 +347,9 @@ func (h *CustomerHandler) GetCustomerTasks(c *gin.Context) {
 
 	tasks, err := h.taskService.GetCustomerTasks(ctx, customerID)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to get customer tasks", "customer_id", customerID, "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"GET_CUSTOMER_TASKS_FAILED",
@@ -364,6 +399,9 @@ func (h *CustomerHandler) FindOrCreateCustomer(c *gin.Context) {
 
 	customer, err := h.customerService.FindOrCreateByEmail(ctx, email, name)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to find or create customer", "email", email, "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"FIND_OR_CREATE_FAILED",
@@ -408,6 +446,8 @@ func (h *CustomerHandler) toCustomerProfileResponse(profile *ports.CustomerProfi
 			Satisfaction:    profile.Stats.Satisfaction,
 			ByPriority:      profile.Stats.ByPriority,
 			ByCategory:      profile.Stats.ByCategory,
+			TimeSpentHours:  profile.Stats.TimeSpentHours,
+			BillableHours:   profile.Stats.BillableHours,
 		},
 	}
 
@@ -0,0 +1,376 @@
+// internal/infrastructure/http/handlers/email_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type EmailHandler struct {
+	emailService ports.EmailMessageService
+	logger       ports.Logger
+}
+
+func NewEmailHandler(emailService ports.EmailMessageService, logger ports.Logger) *EmailHandler {
+	return &EmailHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// ListEmails возвращает список сохраненных писем
+// @Summary Список писем
+// @Description Письма с фильтрами по обработке, направлению, периоду, отправителю и связанной задаче
+// @Tags emails
+// @Produce json
+// @Param processed query bool false "Обработано"
+// @Param from query string false "Адрес отправителя"
+// @Param task_id query string false "ID связанной задачи"
+// @Param page query int false "Номер страницы" default(1) minimum(1)
+// @Param page_size query int false "Размер страницы" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Сортировка, минус - по убыванию: created_at, updated_at, processed_at, subject, from"
+// @Param cursor query string false "Курсор следующей страницы (pagination.next_cursor)"
+// @Param limit query int false "Размер страницы (синоним page_size)" minimum(1) maximum(100)
+// @Param filter query string false "Компактные фильтры: direction=incoming, source!=smtp, created>=2025-01-01"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailListResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/v1/emails [get]
+func (h *EmailHandler) ListEmails(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.EmailSearchRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	sort, ok := parseListSort(c, req.Sort, domain.EmailSortFields)
+	if !ok {
+		return
+	}
+	offset, limit := listPageInfo(req.Page, req.PageSize, req.Limit, req.Cursor)
+
+	query := ports.EmailQuery{
+		Processed: req.Processed,
+		From:      req.From,
+		Sort:      sort,
+		Cursor:    req.Cursor,
+		Offset:    offset,
+		Limit:     limit,
+	}
+	if req.TaskID != "" {
+		query.RelatedTicketID = &req.TaskID
+	}
+	filters := listFilterSet{
+		"direction": func(f listFilter) error {
+			return filterValues(f, &query.Directions, &query.ExcludeDirections)
+		},
+		"source":  func(f listFilter) error { return filterValues(f, &query.Sources, &query.ExcludeSources) },
+		"created": func(f listFilter) error { return filterTimeRange(f, &query.CreatedAt) },
+	}
+	if err := applyListFilters(c.Request.URL.RawQuery, filters); err != nil {
+		abortInvalidListFilter(c, err)
+		return
+	}
+
+	result, err := h.emailService.ListEmails(ctx, query)
+	if err != nil {
+		if abortForbidden(c, err) || abortInvalidCursor(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to list emails", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"LIST_EMAILS_FAILED",
+			"Не удалось получить список писем",
+			err.Error(),
+		))
+		return
+	}
+
+	emails := make([]dto.EmailResponse, len(result.Emails))
+	for i := range result.Emails {
+		emails[i] = toEmailResponse(&result.Emails[i])
+	}
+
+	response := dto.EmailListResponse{
+		Emails: emails,
+		Pagination: dto.PageInfo{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			TotalCount: result.TotalCount,
+			TotalPages: result.TotalPages,
+			NextCursor: result.NextCursor,
+		},
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(response, response.Pagination))
+}
+
+// GetEmailStatistics возвращает статистику обработки писем
+// @Summary Статистика писем
+// @Tags emails
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailStatisticsResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/v1/emails/statistics [get]
+func (h *EmailHandler) GetEmailStatistics(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	stats, err := h.emailService.GetEmailStatistics(ctx)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get email statistics", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"EMAIL_STATISTICS_FAILED",
+			"Не удалось получить статистику писем",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.EmailStatisticsResponse{
+		UnprocessedCount: stats.UnprocessedCount,
+		RecentCount:      stats.RecentCount,
+		LastProcessed:    stats.LastProcessed,
+	}))
+}
+
+// GetEmail возвращает письмо со всеми заголовками и телом
+// @Summary Получить письмо
+// @Tags emails
+// @Produce json
+// @Param id path string true "ID письма"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailDetailResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/v1/emails/{id} [get]
+func (h *EmailHandler) GetEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	msg, err := h.emailService.GetEmail(ctx, domain.MessageID(c.Param("id")))
+	if err != nil {
+		h.abortEmailError(c, err, "Failed to get email")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailDetailResponse(msg)))
+}
+
+// DownloadEmail выгружает письмо в формате .eml
+// @Summary Скачать письмо (.eml)
+// @Description Исходный текст письма, если он сохранен при получении; иначе письмо, собранное из сохраненных полей
+// @Tags emails
+// @Produce message/rfc822
+// @Param id path string true "ID письма"
+// @Success 200 {file} file
+// @Failure 403 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/v1/emails/{id}/raw [get]
+func (h *EmailHandler) DownloadEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	msg, err := h.emailService.GetEmail(ctx, domain.MessageID(c.Param("id")))
+	if err != nil {
+		h.abortEmailError(c, err, "Failed to get email")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.eml"`, msg.ID))
+	c.Data(http.StatusOK, "message/rfc822", msg.RFC822())
+}
+
+// ReprocessEmail повторно обрабатывает письмо
+// @Summary Обработать письмо повторно
+// @Description Проводит письмо через обработчик заново и связывает его с созданной или дополненной задачей
+// @Tags emails
+// @Produce json
+// @Param id path string true "ID письма"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailDetailResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Failure 422 {object} dto.BaseResponse
+// @Router /api/v1/emails/{id}/reprocess [post]
+func (h *EmailHandler) ReprocessEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	msg, err := h.emailService.ReprocessEmail(ctx, domain.MessageID(c.Param("id")))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrEmailNotFound) {
+			h.abortEmailError(c, err, "Failed to reprocess email")
+			return
+		}
+		h.logger.Error(ctx, "Failed to reprocess email", "email_id", c.Param("id"), "error", err.Error())
+		c.JSON(http.StatusUnprocessableEntity, dto.NewErrorResponse(
+			"EMAIL_REPROCESS_FAILED",
+			"Не удалось обработать письмо",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailDetailResponse(msg)))
+}
+
+// LinkEmail связывает письмо с задачей
+// @Summary Привязать письмо к задаче
+// @Tags emails
+// @Accept json
+// @Produce json
+// @Param id path string true "ID письма"
+// @Param request body dto.LinkEmailRequest true "Задача"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailDetailResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 403 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/v1/emails/{id}/task [put]
+func (h *EmailHandler) LinkEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.LinkEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	msg, err := h.emailService.LinkEmail(ctx, domain.MessageID(c.Param("id")), req.TaskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrEmailNotFound) {
+			h.abortEmailError(c, err, "Failed to link email")
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"EMAIL_LINK_FAILED",
+			"Не удалось привязать письмо к задаче",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailDetailResponse(msg)))
+}
+
+// UnlinkEmail убирает связь письма с задачей
+// @Summary Отвязать письмо от задачи
+// @Tags emails
+// @Produce json
+// @Param id path string true "ID письма"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailDetailResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/v1/emails/{id}/task [delete]
+func (h *EmailHandler) UnlinkEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	msg, err := h.emailService.UnlinkEmail(ctx, domain.MessageID(c.Param("id")))
+	if err != nil {
+		h.abortEmailError(c, err, "Failed to unlink email")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailDetailResponse(msg)))
+}
+
+// abortEmailError отвечает 403 на отказ в доступе, 404 на отсутствующее письмо и 500 на прочие ошибки
+func (h *EmailHandler) abortEmailError(c *gin.Context, err error, logMessage string) {
+	if abortForbidden(c, err) {
+		return
+	}
+	if errors.Is(err, domain.ErrEmailNotFound) {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"EMAIL_NOT_FOUND",
+			"Письмо не найдено",
+			err.Error(),
+		))
+		return
+	}
+	h.logger.Error(c.Request.Context(), logMessage, "email_id", c.Param("id"), "error", err.Error())
+	c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+		"EMAIL_OPERATION_FAILED",
+		"Не удалось выполнить операцию с письмом",
+		err.Error(),
+	))
+}
+
+func toEmailResponse(msg *domain.EmailMessage) dto.EmailResponse {
+	response := dto.EmailResponse{
+		ID:               string(msg.ID),
+		MessageID:        msg.MessageID,
+		From:             string(msg.From),
+		To:               emailAddressStrings(msg.To),
+		Subject:          msg.Subject,
+		Direction:        string(msg.Direction),
+		Source:           msg.Source,
+		Processed:        msg.Processed,
+		AttachmentsCount: len(msg.Attachments),
+		CreatedAt:        msg.CreatedAt,
+		UpdatedAt:        msg.UpdatedAt,
+	}
+	if !msg.ProcessedAt.IsZero() {
+		processedAt := msg.ProcessedAt
+		response.ProcessedAt = &processedAt
+	}
+	if msg.RelatedTicketID != nil {
+		response.RelatedTaskID = *msg.RelatedTicketID
+	}
+	return response
+}
+
+func toEmailDetailResponse(msg *domain.EmailMessage) dto.EmailDetailResponse {
+	attachments := make([]dto.EmailAttachmentResponse, len(msg.Attachments))
+	for i, attachment := range msg.Attachments {
+		attachments[i] = dto.EmailAttachmentResponse{
+			ID:          string(attachment.ID),
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			ContentID:   attachment.ContentID,
+		}
+	}
+	headers := msg.Headers
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+	return dto.EmailDetailResponse{
+		EmailResponse: toEmailResponse(msg),
+		InReplyTo:     msg.InReplyTo,
+		References:    msg.References,
+		CC:            emailAddressStrings(msg.CC),
+		BCC:           emailAddressStrings(msg.BCC),
+		Headers:       headers,
+		BodyText:      msg.BodyText,
+		BodyHTML:      msg.BodyHTML,
+		Attachments:   attachments,
+		HasRaw:        len(msg.RawMessage) > 0,
+	}
+}
+
+func emailAddressStrings(addresses []domain.EmailAddress) []string {
+	values := make([]string, len(addresses))
+	for i, address := range addresses {
+		values[i] = string(address)
+	}
+	return values
+}
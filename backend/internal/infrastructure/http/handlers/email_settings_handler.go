@@ -0,0 +1,353 @@
+// internal/infrastructure/http/handlers/email_settings_handler.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailSettingsHandler управляет почтовыми каналами, политикой обработки и окнами
+// поиска тредов. Изменения применяются без перезапуска приложения
+type EmailSettingsHandler struct {
+	settingsService ports.EmailSettingsService
+	logger          ports.Logger
+}
+
+func NewEmailSettingsHandler(settingsService ports.EmailSettingsService, logger ports.Logger) *EmailSettingsHandler {
+	return &EmailSettingsHandler{
+		settingsService: settingsService,
+		logger:          logger,
+	}
+}
+
+// ListChannels возвращает почтовые каналы
+// @Summary Список почтовых каналов
+// @Tags email-settings
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=[]dto.EmailChannelResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/channels [get]
+func (h *EmailSettingsHandler) ListChannels(c *gin.Context) {
+	channels, err := h.settingsService.ListChannels(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "EMAIL_CHANNELS_FETCH_FAILED", "Не удалось получить почтовые каналы")
+		return
+	}
+
+	responses := make([]dto.EmailChannelResponse, len(channels))
+	for i := range channels {
+		responses[i] = toEmailChannelResponse(&channels[i])
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// GetChannel возвращает почтовый канал
+// @Summary Получить почтовый канал
+// @Tags email-settings
+// @Produce json
+// @Param id path string true "ID канала"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailChannelResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/channels/{id} [get]
+func (h *EmailSettingsHandler) GetChannel(c *gin.Context) {
+	channel, err := h.settingsService.GetChannel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "EMAIL_CHANNEL_FETCH_FAILED", "Не удалось получить почтовый канал")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailChannelResponse(channel)))
+}
+
+// SaveChannel создает или заменяет почтовый канал
+// @Summary Сохранить почтовый канал
+// @Description Создает или заменяет канал. Без пароля сохраняется прежний. Активный канал сразу
+// @Description используется шлюзом, остальные каналы становятся неактивными. Интервал опроса
+// @Description применяется при следующем запуске
+// @Tags email-settings
+// @Accept json
+// @Produce json
+// @Param id path string true "ID канала"
+// @Param request body dto.EmailChannelRequest true "Канал"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailChannelResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/channels/{id} [put]
+func (h *EmailSettingsHandler) SaveChannel(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.EmailChannelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid email channel request", "channel_id", c.Param("id"), "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+	if req.Mailbox == "" {
+		req.Mailbox = "INBOX"
+	}
+
+	channel, err := h.settingsService.SaveChannel(ctx, domain.EmailChannelConfig{
+		ID:           c.Param("id"),
+		Name:         req.Name,
+		Provider:     req.Provider,
+		Server:       req.Server,
+		Port:         req.Port,
+		Username:     req.Username,
+		Password:     req.Password,
+		Mailbox:      req.Mailbox,
+		SSL:          req.SSL,
+		PollInterval: time.Duration(req.PollIntervalSeconds) * time.Second,
+		Active:       req.Active,
+	})
+	if err != nil {
+		h.respondError(c, err, "EMAIL_CHANNEL_SAVE_FAILED", "Не удалось сохранить почтовый канал")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailChannelResponse(channel)))
+}
+
+// DeleteChannel удаляет неактивный почтовый канал
+// @Summary Удалить почтовый канал
+// @Tags email-settings
+// @Param id path string true "ID канала"
+// @Success 204
+// @Failure 404 {object} dto.BaseResponse
+// @Failure 409 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/channels/{id} [delete]
+func (h *EmailSettingsHandler) DeleteChannel(c *gin.Context) {
+	if err := h.settingsService.DeleteChannel(c.Request.Context(), c.Param("id")); err != nil {
+		h.respondError(c, err, "EMAIL_CHANNEL_DELETE_FAILED", "Не удалось удалить почтовый канал")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TestChannel проверяет подключение канала
+// @Summary Проверить подключение почтового канала
+// @Description Подключается к серверу с параметрами канала, не прерывая работу активного канала.
+// @Description Неудачное подключение возвращается в результате с success=false
+// @Tags email-settings
+// @Produce json
+// @Param id path string true "ID канала"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailConnectionTestResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/channels/{id}/test [post]
+func (h *EmailSettingsHandler) TestChannel(c *gin.Context) {
+	result, err := h.settingsService.TestChannel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "EMAIL_CHANNEL_TEST_FAILED", "Не удалось проверить подключение")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.EmailConnectionTestResponse{
+		ChannelID:  result.ChannelID,
+		Success:    result.Success,
+		Error:      result.Error,
+		DurationMs: result.Duration.Milliseconds(),
+	}))
+}
+
+// GetPolicy возвращает политику обработки писем
+// @Summary Политика обработки писем
+// @Tags email-settings
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailPolicyResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/policy [get]
+func (h *EmailSettingsHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.settingsService.GetPolicy(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "EMAIL_POLICY_FETCH_FAILED", "Не удалось получить политику обработки")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailPolicyResponse(policy)))
+}
+
+// UpdatePolicy заменяет политику обработки писем
+// @Summary Изменить политику обработки писем
+// @Description Разрешенные и заблокированные отправители, авто-ответ, спам-фильтр и лимит размера.
+// @Description Следующие письма обрабатываются по новой политике
+// @Tags email-settings
+// @Accept json
+// @Produce json
+// @Param request body dto.EmailPolicyRequest true "Политика"
+// @Success 200 {object} dto.BaseResponse{data=dto.EmailPolicyResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/policy [put]
+func (h *EmailSettingsHandler) UpdatePolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.EmailPolicyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid email policy request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	policy, err := h.settingsService.UpdatePolicy(ctx, domain.EmailProcessingPolicy{
+		ReadOnlyMode:   req.ReadOnlyMode,
+		AutoReply:      req.AutoReply,
+		AutoReplyText:  req.AutoReplyText,
+		SpamFilter:     req.SpamFilter,
+		MaxMessageSize: req.MaxMessageSize,
+		AllowedSenders: toEmailAddressList(req.AllowedSenders),
+		BlockedSenders: toEmailAddressList(req.BlockedSenders),
+	})
+	if err != nil {
+		h.respondError(c, err, "EMAIL_POLICY_UPDATE_FAILED", "Не удалось изменить политику обработки")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toEmailPolicyResponse(policy)))
+}
+
+// GetThreadSearchConfig возвращает окна поиска тредов
+// @Summary Окна поиска писем треда
+// @Tags email-settings
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.ThreadSearchConfigResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/thread-search [get]
+func (h *EmailSettingsHandler) GetThreadSearchConfig(c *gin.Context) {
+	config, err := h.settingsService.GetThreadSearchConfig(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "THREAD_SEARCH_FETCH_FAILED", "Не удалось получить окна поиска тредов")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toThreadSearchConfigResponse(config)))
+}
+
+// UpdateThreadSearchConfig заменяет окна поиска тредов
+// @Summary Изменить окна поиска писем треда
+// @Description Стандартное, расширенное и максимальное окно (не более 730 дней) и таймаут поиска
+// @Tags email-settings
+// @Accept json
+// @Produce json
+// @Param request body dto.ThreadSearchConfigRequest true "Окна поиска"
+// @Success 200 {object} dto.BaseResponse{data=dto.ThreadSearchConfigResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/v1/settings/email/thread-search [put]
+func (h *EmailSettingsHandler) UpdateThreadSearchConfig(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.ThreadSearchConfigRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid thread search config request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	config, err := h.settingsService.UpdateThreadSearchConfig(ctx, ports.ThreadSearchConfig{
+		DefaultDaysBack:     req.DefaultDaysBack,
+		ExtendedDaysBack:    req.ExtendedDaysBack,
+		MaxDaysBack:         req.MaxDaysBack,
+		FetchTimeout:        time.Duration(req.FetchTimeoutSeconds) * time.Second,
+		IncludeSeenMessages: req.IncludeSeenMessages,
+		SubjectPrefixes:     req.SubjectPrefixes,
+	})
+	if err != nil {
+		h.respondError(c, err, "THREAD_SEARCH_UPDATE_FAILED", "Не удалось изменить окна поиска тредов")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toThreadSearchConfigResponse(config)))
+}
+
+// respondError отвечает 403, 404 для отсутствующего канала, 409 для удаления
+// активного канала, иначе 400 (ошибки проверки и применения настроек)
+func (h *EmailSettingsHandler) respondError(c *gin.Context, err error, code, message string) {
+	if abortForbidden(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrEmailChannelNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("EMAIL_CHANNEL_NOT_FOUND", "Почтовый канал не найден", err.Error()))
+	case errors.Is(err, domain.ErrEmailChannelActive):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("EMAIL_CHANNEL_ACTIVE", "Активный канал нельзя удалить", err.Error()))
+	default:
+		h.logger.Error(c.Request.Context(), message, "channel_id", c.Param("id"), "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(code, message, err.Error()))
+	}
+}
+
+func toEmailChannelResponse(channel *domain.EmailChannelConfig) dto.EmailChannelResponse {
+	return dto.EmailChannelResponse{
+		ID:                  channel.ID,
+		Name:                channel.Name,
+		Provider:            channel.Provider,
+		Server:              channel.Server,
+		Port:                channel.Port,
+		Username:            channel.Username,
+		HasPassword:         channel.Password != "",
+		Mailbox:             channel.Mailbox,
+		SSL:                 channel.SSL,
+		PollIntervalSeconds: int(channel.PollInterval / time.Second),
+		Active:              channel.Active,
+		UpdatedAt:           channel.UpdatedAt,
+	}
+}
+
+func toEmailPolicyResponse(policy *domain.EmailProcessingPolicy) dto.EmailPolicyResponse {
+	response := dto.EmailPolicyResponse{
+		ReadOnlyMode:   policy.ReadOnlyMode,
+		AutoReply:      policy.AutoReply,
+		AutoReplyText:  policy.AutoReplyText,
+		SpamFilter:     policy.SpamFilter,
+		MaxMessageSize: policy.MaxMessageSize,
+		AllowedSenders: make([]string, len(policy.AllowedSenders)),
+		BlockedSenders: make([]string, len(policy.BlockedSenders)),
+	}
+	for i, sender := range policy.AllowedSenders {
+		response.AllowedSenders[i] = string(sender)
+	}
+	for i, sender := range policy.BlockedSenders {
+		response.BlockedSenders[i] = string(sender)
+	}
+	return response
+}
+
+func toThreadSearchConfigResponse(config *ports.ThreadSearchConfig) dto.ThreadSearchConfigResponse {
+	response := dto.ThreadSearchConfigResponse{
+		DefaultDaysBack:     config.DefaultDaysBack,
+		ExtendedDaysBack:    config.ExtendedDaysBack,
+		MaxDaysBack:         config.MaxDaysBack,
+		FetchTimeoutSeconds: int(config.FetchTimeout / time.Second),
+		IncludeSeenMessages: config.IncludeSeenMessages,
+		SubjectPrefixes:     config.SubjectPrefixes,
+	}
+	if response.SubjectPrefixes == nil {
+		response.SubjectPrefixes = []string{}
+	}
+	return response
+}
+
+func toEmailAddressList(values []string) []domain.EmailAddress {
+	addresses := make([]domain.EmailAddress, len(values))
+	for i, value := range values {
+		addresses[i] = domain.EmailAddress(value)
+	}
+	return addresses
+}
@@ -0,0 +1,178 @@
+// internal/infrastructure/http/handlers/github_handler.go
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/github"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// Заголовки webhook GitHub
+const (
+	gitHubSignatureHeader = "X-Hub-Signature-256"
+	gitHubEventHeader     = "X-GitHub-Event"
+)
+
+// GitHubHandler принимает webhook GitHub и связывает задачи с issue
+type GitHubHandler struct {
+	githubService ports.GitHubService
+	taskHandler   *TaskHandler
+	secret        string
+	logger        ports.Logger
+}
+
+func NewGitHubHandler(githubService ports.GitHubService, taskHandler *TaskHandler, secret string, logger ports.Logger) *GitHubHandler {
+	return &GitHubHandler{
+		githubService: githubService,
+		taskHandler:   taskHandler,
+		secret:        secret,
+		logger:        logger,
+	}
+}
+
+// ReceiveWebhook принимает событие GitHub
+// @Summary Webhook GitHub
+// @Description Принимает события issues и issue_comment отслеживаемых репозиториев. Подпись X-Hub-Signature-256 обязательна
+// @Tags github
+// @Accept json
+// @Produce json
+// @Param X-GitHub-Event header string true "Тип события"
+// @Param X-Hub-Signature-256 header string true "sha256=<HMAC-SHA256 тела>"
+// @Success 200 {object} dto.BaseResponse
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 401 {object} dto.BaseResponse
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/github/webhook [post]
+func (h *GitHubHandler) ReceiveWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxInboundPayloadSize))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.NewErrorResponse(
+			"PAYLOAD_TOO_LARGE",
+			"Слишком большой запрос",
+			err.Error(),
+		))
+		return
+	}
+
+	// Формат подписи GitHub совпадает с подписью исходящих вебхуков URMS
+	if !domain.VerifyWebhookSignature(h.secret, body, c.GetHeader(gitHubSignatureHeader)) {
+		h.logger.Warn(ctx, "Invalid github webhook signature", "event", c.GetHeader(gitHubEventHeader))
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("INVALID_SIGNATURE", "Неверная подпись запроса", ""))
+		return
+	}
+	// Подпись подтверждает отправителя: событие обрабатывается от имени системы
+	ctx = ports.WithSystemPrincipal(ctx)
+
+	eventName := c.GetHeader(gitHubEventHeader)
+	event, ok, err := github.ParseWebhookEvent(eventName, body)
+	if err != nil {
+		h.logger.Warn(ctx, "Invalid github webhook payload", "event", eventName, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_PAYLOAD", "Неверный формат события", err.Error()))
+		return
+	}
+	if !ok {
+		// ping и прочие события подтверждаются без обработки
+		c.JSON(http.StatusOK, dto.NewSuccessResponse(nil))
+		return
+	}
+
+	if err := h.githubService.HandleWebhookEvent(ctx, event); err != nil {
+		h.logger.Error(ctx, "Failed to process github webhook",
+			"event", eventName, "action", event.Action, "issue", event.Issue.Ref().String(), "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"GITHUB_PROCESSING_FAILED",
+			"Не удалось обработать событие",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(nil))
+}
+
+// CreateLinkedIssue создает issue GitHub для задачи
+// @Summary Создать связанную задачу GitHub
+// @Description Создает issue в репозитории и связывает его с задачей: комментарии и закрытие синхронизируются в обе стороны
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param request body dto.CreateGitHubIssueRequest true "Репозиторий, заголовок и текст issue"
+// @Success 201 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Failure 409 {object} dto.BaseResponse
+// @Failure 502 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/github-issue [post]
+func (h *GitHubHandler) CreateLinkedIssue(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	var req dto.CreateGitHubIssueRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid github issue request", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	if _, err := h.taskHandler.taskService.GetTask(ctx, taskID); err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"TASK_NOT_FOUND",
+			"Задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	task, err := h.githubService.LinkIssue(ctx, taskID, ports.LinkGitHubIssueRequest{
+		Repo:    req.Repo,
+		Title:   req.Title,
+		Body:    req.Body,
+		ActorID: currentUserID(c),
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to create github issue", "task_id", taskID, "repo", req.Repo, "error", err.Error())
+		switch {
+		case errors.Is(err, domain.ErrGitHubIssueAlreadyLinked):
+			c.JSON(http.StatusConflict, dto.NewErrorResponse(
+				"GITHUB_ISSUE_ALREADY_LINKED",
+				"Задача уже связана с задачей GitHub",
+				err.Error(),
+			))
+		case errors.Is(err, domain.ErrGitHubRepoNotWatched):
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"GITHUB_REPO_NOT_CONFIGURED",
+				"Репозиторий не подключен",
+				err.Error(),
+			))
+		default:
+			c.JSON(http.StatusBadGateway, dto.NewErrorResponse(
+				"GITHUB_REQUEST_FAILED",
+				"Не удалось создать задачу GitHub",
+				err.Error(),
+			))
+		}
+		return
+	}
+
+	h.logger.Info(ctx, "GitHub issue linked", "task_id", taskID, "issue", task.SourceMeta[domain.SourceMetaGitHubIssue])
+	h.taskHandler.respondWithTask(c, http.StatusCreated, task)
+}
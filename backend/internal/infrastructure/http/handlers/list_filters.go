@@ -0,0 +1,189 @@
+// internal/infrastructure/http/handlers/list_filters.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// listFilterOperators операторы компактного синтаксиса фильтров; двухсимвольные проверяются первыми
+var listFilterOperators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// listFilter условие компактного синтаксиса фильтров списков:
+// status!=closed, created>=2025-01-01, priority=high,critical
+type listFilter struct {
+	Field  string
+	Op     string
+	Values []string // Значения через запятую
+}
+
+// listFilterSet обработчики фильтров по имени поля
+type listFilterSet map[string]func(f listFilter) error
+
+// applyListFilters разбирает строку запроса и применяет условия к запросу через обработчики.
+// Условия "=" по неизвестным полям - обычные параметры запроса и пропускаются;
+// сравнения и отрицания по неизвестным полям считаются ошибкой
+func applyListFilters(rawQuery string, filters listFilterSet) error {
+	for _, part := range strings.Split(rawQuery, "&") {
+		filter, ok, err := parseListFilter(part)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		apply, known := filters[filter.Field]
+		if !known {
+			if filter.Op == "=" {
+				continue
+			}
+			return fmt.Errorf("unknown filter field %q", filter.Field)
+		}
+		if err := apply(filter); err != nil {
+			return fmt.Errorf("filter %s%s: %w", filter.Field, filter.Op, err)
+		}
+	}
+	return nil
+}
+
+func parseListFilter(part string) (listFilter, bool, error) {
+	part, err := url.QueryUnescape(part)
+	if err != nil {
+		return listFilter{}, false, fmt.Errorf("invalid query parameter: %w", err)
+	}
+	idx := strings.IndexAny(part, "!=<>")
+	if idx <= 0 {
+		return listFilter{}, false, nil
+	}
+	for _, op := range listFilterOperators {
+		if value, ok := strings.CutPrefix(part[idx:], op); ok {
+			return listFilter{Field: part[:idx], Op: op, Values: splitListValues(value)}, true, nil
+		}
+	}
+	return listFilter{}, false, fmt.Errorf("invalid filter %q", part)
+}
+
+func splitListValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// filterValues добавляет значения в список включаемых ("=") или исключаемых ("!=") значений.
+// include == nil - условие "=" обрабатывается обычным параметром запроса
+func filterValues[T ~string](f listFilter, include, exclude *[]T) error {
+	var target *[]T
+	switch f.Op {
+	case "=":
+		if include == nil {
+			return nil
+		}
+		target = include
+	case "!=":
+		target = exclude
+	default:
+		return errors.New("only = and != are supported")
+	}
+	if len(f.Values) == 0 {
+		return errors.New("value is required")
+	}
+	for _, value := range f.Values {
+		*target = append(*target, T(value))
+	}
+	return nil
+}
+
+// filterTimeRange сужает интервал по сравнению с датой (YYYY-MM-DD) или моментом (RFC3339).
+// Дата означает весь день: created<=2025-01-31 включает 31 января, created=2025-01-31 - только его
+func filterTimeRange(f listFilter, r *domain.TimeRange) error {
+	if len(f.Values) != 1 {
+		return errors.New("exactly one date is required")
+	}
+	start, err := parseAuditTime(f.Values[0], false)
+	if err != nil {
+		return fmt.Errorf("invalid date %q", f.Values[0])
+	}
+	end, _ := parseAuditTime(f.Values[0], true)
+
+	after := func(t time.Time) *time.Time { t = t.Add(time.Nanosecond); return &t }
+	before := func(t time.Time) *time.Time { t = t.Add(-time.Nanosecond); return &t }
+	switch f.Op {
+	case "=":
+		r.From, r.To = start, end
+	case ">=":
+		r.From = start
+	case ">":
+		r.From = after(*end)
+	case "<=":
+		r.To = end
+	case "<":
+		r.To = before(*start)
+	default:
+		return errors.New("only =, >, >=, <, <= are supported")
+	}
+	return nil
+}
+
+// parseListSort разбирает параметр sort; пустая строка - сортировка по умолчанию
+func parseListSort(c *gin.Context, expr string, allowed []string) ([]domain.SortField, bool) {
+	sort, err := domain.ParseSort(expr, allowed...)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_SORT",
+			"Неверная сортировка",
+			err.Error(),
+		))
+		return nil, false
+	}
+	return sort, true
+}
+
+// abortInvalidListFilter отвечает 400 на ошибку разбора фильтров
+func abortInvalidListFilter(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+		"INVALID_FILTER",
+		"Неверный фильтр",
+		err.Error(),
+	))
+}
+
+// abortInvalidCursor отвечает 400, если ошибка вызвана недействительным курсором
+func abortInvalidCursor(c *gin.Context, err error) bool {
+	if !errors.Is(err, domain.ErrInvalidCursor) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+		"INVALID_CURSOR",
+		"Недействительный курсор страницы",
+		err.Error(),
+	))
+	return true
+}
+
+// listPageInfo параметры страницы: курсор имеет приоритет над номером страницы,
+// limit - синоним page_size
+func listPageInfo(page, pageSize, limit int, cursor string) (offset, size int) {
+	size = pageSize
+	if limit > 0 {
+		size = limit
+	}
+	if size == 0 {
+		size = 20
+	}
+	if cursor != "" || page <= 1 {
+		return 0, size
+	}
+	return (page - 1) * size, size
+}
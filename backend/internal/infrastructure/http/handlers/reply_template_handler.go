@@ -0,0 +1,383 @@
+// internal/infrastructure/http/handlers/reply_template_handler.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type ReplyTemplateHandler struct {
+	replyTemplateService ports.ReplyTemplateService
+	logger               ports.Logger
+}
+
+func NewReplyTemplateHandler(replyTemplateService ports.ReplyTemplateService, logger ports.Logger) *ReplyTemplateHandler {
+	return &ReplyTemplateHandler{
+		replyTemplateService: replyTemplateService,
+		logger:               logger,
+	}
+}
+
+// ListTemplates возвращает шаблоны ответов
+// @Summary Список шаблонов ответов
+// @Description Возвращает шаблоны; с category - библиотеку категории вместе с общими шаблонами
+// @Tags reply-templates
+// @Produce json
+// @Param category query string false "Категория задач"
+// @Param language query string false "Язык варианта (ru, en)"
+// @Param search query string false "Подстрока в названии"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.ReplyTemplateResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/reply-templates [get]
+func (h *ReplyTemplateHandler) ListTemplates(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	templates, err := h.replyTemplateService.ListTemplates(ctx, ports.ReplyTemplateQuery{
+		Category: c.Query("category"),
+		Language: domain.TemplateLanguage(c.Query("language")),
+		Search:   c.Query("search"),
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to list reply templates", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"REPLY_TEMPLATES_FETCH_FAILED",
+			"Не удалось получить шаблоны ответов",
+			err.Error(),
+		))
+		return
+	}
+
+	responses := make([]dto.ReplyTemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = toReplyTemplateResponse(&templates[i])
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// CreateTemplate создает шаблон ответа
+// @Summary Создать шаблон ответа
+// @Description Создает шаблон ответа (text/template) с языковыми вариантами и действиями макроса
+// @Tags reply-templates
+// @Accept json
+// @Produce json
+// @Param request body dto.ReplyTemplateRequest true "Шаблон ответа"
+// @Success 201 {object} dto.BaseResponse{data=dto.ReplyTemplateResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/reply-templates [post]
+func (h *ReplyTemplateHandler) CreateTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.ReplyTemplateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid create reply template request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	tpl, err := h.replyTemplateService.CreateTemplate(ctx, ports.ReplyTemplateRequest{
+		Name:      req.Name,
+		Category:  req.Category,
+		Variants:  req.Variants,
+		Actions:   toDomainMacroActions(req.Actions),
+		CreatedBy: currentUserID(c),
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to create reply template", "name", req.Name, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"REPLY_TEMPLATE_CREATION_FAILED",
+			"Не удалось создать шаблон ответа",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Reply template created", "template_id", tpl.ID)
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(toReplyTemplateResponse(tpl)))
+}
+
+// GetTemplate возвращает шаблон ответа
+// @Summary Получить шаблон ответа
+// @Tags reply-templates
+// @Produce json
+// @Param id path string true "ID шаблона"
+// @Success 200 {object} dto.BaseResponse{data=dto.ReplyTemplateResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/reply-templates/{id} [get]
+func (h *ReplyTemplateHandler) GetTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	templateID := c.Param("id")
+
+	tpl, err := h.replyTemplateService.GetTemplate(ctx, templateID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"REPLY_TEMPLATE_NOT_FOUND",
+			"Шаблон ответа не найден",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toReplyTemplateResponse(tpl)))
+}
+
+// UpdateTemplate изменяет шаблон ответа
+// @Summary Изменить шаблон ответа
+// @Tags reply-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID шаблона"
+// @Param request body dto.UpdateReplyTemplateRequest true "Шаблон ответа"
+// @Success 200 {object} dto.BaseResponse{data=dto.ReplyTemplateResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/reply-templates/{id} [put]
+func (h *ReplyTemplateHandler) UpdateTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	templateID := c.Param("id")
+	var req dto.UpdateReplyTemplateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid update reply template request", "template_id", templateID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	tpl, err := h.replyTemplateService.UpdateTemplate(ctx, templateID, ports.ReplyTemplateRequest{
+		Name:     req.Name,
+		Category: req.Category,
+		Variants: req.Variants,
+		Actions:  toDomainMacroActions(req.Actions),
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to update reply template", "template_id", templateID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"REPLY_TEMPLATE_UPDATE_FAILED",
+			"Не удалось обновить шаблон ответа",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Reply template updated", "template_id", templateID)
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toReplyTemplateResponse(tpl)))
+}
+
+// DeleteTemplate удаляет шаблон ответа
+// @Summary Удалить шаблон ответа
+// @Tags reply-templates
+// @Produce json
+// @Param id path string true "ID шаблона"
+// @Success 204
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/reply-templates/{id} [delete]
+func (h *ReplyTemplateHandler) DeleteTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	templateID := c.Param("id")
+
+	if err := h.replyTemplateService.DeleteTemplate(ctx, templateID); err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to delete reply template", "template_id", templateID, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"REPLY_TEMPLATE_DELETE_FAILED",
+			"Не удалось удалить шаблон ответа",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Reply template deleted", "template_id", templateID)
+	c.Status(http.StatusNoContent)
+}
+
+// PreviewTemplate показывает текст ответа для задачи без его отправки
+// @Summary Предпросмотр шаблона для задачи
+// @Tags reply-templates
+// @Produce json
+// @Param id path string true "ID шаблона"
+// @Param task_id query string true "ID задачи"
+// @Param language query string false "Язык варианта (ru, en)"
+// @Success 200 {object} dto.BaseResponse{data=dto.RenderedReplyResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/reply-templates/{id}/preview [get]
+func (h *ReplyTemplateHandler) PreviewTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	templateID := c.Param("id")
+	taskID := c.Query("task_id")
+
+	if taskID == "" {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Не указана задача для предпросмотра",
+			"task_id query parameter is required",
+		))
+		return
+	}
+
+	reply, err := h.replyTemplateService.RenderTemplate(ctx, ports.RenderTemplateRequest{
+		TemplateID: templateID,
+		TaskID:     taskID,
+		OperatorID: currentUserID(c),
+		Language:   domain.TemplateLanguage(c.Query("language")),
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Warn(ctx, "Failed to render reply template",
+			"template_id", templateID, "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"REPLY_TEMPLATE_RENDER_FAILED",
+			"Не удалось подготовить ответ по шаблону",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toRenderedReplyResponse(reply)))
+}
+
+// ApplyTemplate отвечает в задаче по шаблону; в режиме макроса применяет его действия
+// @Summary Ответить по шаблону
+// @Description Добавляет ответ по шаблону (и отправляет его клиенту по email для email-задач); macro=true также меняет статус, теги и исполнителя
+// @Tags reply-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param templateId path string true "ID шаблона"
+// @Param request body dto.ApplyReplyTemplateRequest false "Параметры ответа"
+// @Success 200 {object} dto.BaseResponse{data=dto.ApplyReplyTemplateResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/reply-templates/{templateId} [post]
+func (h *ReplyTemplateHandler) ApplyTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	templateID := c.Param("templateId")
+	var req dto.ApplyReplyTemplateRequest
+
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Warn(ctx, "Invalid apply reply template request", "task_id", taskID, "error", err.Error())
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"INVALID_REQUEST",
+				"Неверный формат запроса",
+				err.Error(),
+			))
+			return
+		}
+	}
+
+	result, err := h.replyTemplateService.ApplyTemplate(ctx, ports.ApplyTemplateRequest{
+		RenderTemplateRequest: ports.RenderTemplateRequest{
+			TemplateID: templateID,
+			TaskID:     taskID,
+			OperatorID: currentUserID(c),
+			Language:   req.Language,
+		},
+		IsPrivate: req.IsPrivate,
+		Macro:     req.Macro,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to apply reply template",
+			"task_id", taskID, "template_id", templateID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		status := http.StatusBadRequest
+		code := "REPLY_TEMPLATE_APPLY_FAILED"
+		if errors.Is(err, domain.ErrTaskBlocked) {
+			status = http.StatusConflict
+			code = "TASK_BLOCKED"
+		}
+		c.JSON(status, dto.NewErrorResponse(
+			code,
+			"Не удалось ответить по шаблону",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Reply template applied",
+		"task_id", taskID, "template_id", templateID, "macro", req.Macro)
+
+	tasks := &TaskHandler{logger: h.logger}
+	c.Header("ETag", taskETag(result.Task))
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.ApplyReplyTemplateResponse{
+		Task:       tasks.toTaskResponse(result.Task),
+		Reply:      toRenderedReplyResponse(&result.Reply),
+		EmailSent:  result.EmailSent,
+		EmailError: result.EmailError,
+	}))
+}
+
+// toReplyTemplateResponse преобразует шаблон ответа в DTO
+func toReplyTemplateResponse(tpl *domain.ReplyTemplate) dto.ReplyTemplateResponse {
+	return dto.ReplyTemplateResponse{
+		ID:        tpl.ID,
+		Name:      tpl.Name,
+		Category:  tpl.Category,
+		Variants:  tpl.Variants,
+		Languages: tpl.Languages(),
+		Actions:   toMacroActionsResponse(tpl.Actions),
+		CreatedBy: tpl.CreatedBy,
+		CreatedAt: tpl.CreatedAt,
+		UpdatedAt: tpl.UpdatedAt,
+	}
+}
+
+// toRenderedReplyResponse преобразует подготовленный ответ в DTO
+func toRenderedReplyResponse(reply *ports.RenderedReply) dto.RenderedReplyResponse {
+	return dto.RenderedReplyResponse{
+		TemplateID: reply.TemplateID,
+		TaskID:     reply.TaskID,
+		Language:   reply.Language,
+		Content:    reply.Content,
+		Actions:    toMacroActionsResponse(reply.Actions),
+	}
+}
+
+func toMacroActionsResponse(actions domain.MacroActions) dto.MacroActions {
+	return dto.MacroActions{
+		Status:     actions.Status,
+		Tags:       actions.Tags,
+		AssigneeID: actions.AssigneeID,
+	}
+}
+
+func toDomainMacroActions(actions dto.MacroActions) domain.MacroActions {
+	return domain.MacroActions{
+		Status:     actions.Status,
+		Tags:       actions.Tags,
+		AssigneeID: actions.AssigneeID,
+	}
+}
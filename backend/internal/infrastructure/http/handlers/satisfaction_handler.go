@@ -0,0 +1,259 @@
+// internal/infrastructure/http/handlers/satisfaction_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// SatisfactionHandler обслуживает опросы удовлетворенности клиентов (CSAT)
+type SatisfactionHandler struct {
+	satisfactionService ports.SatisfactionService
+	logger              ports.Logger
+}
+
+func NewSatisfactionHandler(satisfactionService ports.SatisfactionService, logger ports.Logger) *SatisfactionHandler {
+	return &SatisfactionHandler{
+		satisfactionService: satisfactionService,
+		logger:              logger,
+	}
+}
+
+// GetSurvey открывает ссылку опроса из письма. Оценка не записывается: ссылки
+// открывают сканеры почты и предзагрузка, поэтому клиент подтверждает выбор POST-запросом
+// @Summary Подтверждение оценки по ссылке из письма
+// @Description Проверяет ссылку и возвращает выбранную оценку для подтверждения
+// @Tags surveys
+// @Produce json
+// @Param token path string true "Подписанный токен опроса"
+// @Param rating query int false "Оценка 1-5, выбранная в письме"
+// @Success 200 {object} dto.BaseResponse{data=dto.SurveyConfirmationResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/public/surveys/{token} [get]
+func (h *SatisfactionHandler) GetSurvey(c *gin.Context) {
+	ctx := c.Request.Context()
+	var query dto.SurveyLinkQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Укажите оценку от 1 до 5",
+			err.Error(),
+		))
+		return
+	}
+
+	task, err := h.satisfactionService.GetSurvey(ctx, c.Param("token"))
+	if err != nil {
+		h.handleSurveyError(c, err)
+		return
+	}
+
+	response := dto.SurveyConfirmationResponse{
+		TaskID:   task.ID,
+		Rating:   query.Rating,
+		Answered: task.Satisfaction.IsAnswered(),
+		Message:  "Подтвердите оценку",
+	}
+	if response.Answered {
+		response.CurrentRating = task.Satisfaction.Rating
+	}
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(response))
+}
+
+// SubmitSurveyResponse записывает оценку клиента, подтвержденную после перехода по ссылке.
+// Публичный эндпоинт: доступ подтверждается подписью токена
+// @Summary Ответ на опрос удовлетворенности
+// @Description Записывает оценку и необязательный комментарий
+// @Tags surveys
+// @Accept json
+// @Produce json
+// @Param token path string true "Подписанный токен опроса"
+// @Param request body dto.SurveyResponseRequest true "Оценка и комментарий"
+// @Success 200 {object} dto.BaseResponse{data=dto.SurveyResultResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/public/surveys/{token} [post]
+func (h *SatisfactionHandler) SubmitSurveyResponse(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.SurveyResponseRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Укажите оценку от 1 до 5",
+			err.Error(),
+		))
+		return
+	}
+
+	task, err := h.satisfactionService.SubmitResponse(ctx, ports.SurveyResponseRequest{
+		Token:   c.Param("token"),
+		Rating:  req.Rating,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		h.handleSurveyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.SurveyResultResponse{
+		TaskID:  task.ID,
+		Rating:  task.Satisfaction.Rating,
+		Comment: task.Satisfaction.Comment,
+		Message: "Спасибо за оценку!",
+	}))
+}
+
+// handleSurveyError отвечает на ошибку публичного эндпоинта опроса
+func (h *SatisfactionHandler) handleSurveyError(c *gin.Context, err error) {
+	ctx := c.Request.Context()
+	if abortForbidden(c, err) {
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidSurveyToken) {
+		h.logger.Warn(ctx, "Invalid survey token", "error", err.Error())
+		c.JSON(http.StatusForbidden, dto.NewErrorResponse(
+			"INVALID_SURVEY_TOKEN",
+			"Ссылка недействительна или срок ее действия истек",
+			err.Error(),
+		))
+		return
+	}
+	h.logger.Error(ctx, "Failed to process survey response", "error", err.Error())
+	c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+		"SURVEY_RESPONSE_FAILED",
+		"Не удалось сохранить оценку",
+		err.Error(),
+	))
+}
+
+// SendSurvey отправляет клиенту опрос по решенной задаче
+// @Summary Отправить опрос удовлетворенности
+// @Description Повторно отправляет клиенту опрос, если он еще не ответил
+// @Tags surveys
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/survey [post]
+func (h *SatisfactionHandler) SendSurvey(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.satisfactionService.SendSurvey(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to send survey", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"SURVEY_SEND_FAILED",
+			"Не удалось отправить опрос",
+			err.Error(),
+		))
+		return
+	}
+
+	(&TaskHandler{logger: h.logger}).respondWithTask(c, http.StatusOK, task)
+}
+
+// GetSatisfactionReport возвращает отчет по удовлетворенности клиентов
+// @Summary Отчет по удовлетворенности (CSAT)
+// @Description Оценки клиентов за период с группировкой по операторам, клиентам, категориям или периодам
+// @Tags reports
+// @Produce json
+// @Param from query string true "Начало периода (YYYY-MM-DD)"
+// @Param to query string true "Конец периода включительно (YYYY-MM-DD)"
+// @Param group_by query string false "Группировка" Enums(operator, customer, category, day, week, month)
+// @Param operator_id query string false "ID оператора"
+// @Param customer_id query string false "ID клиента"
+// @Param category query string false "Категория"
+// @Success 200 {object} dto.BaseResponse{data=dto.SatisfactionReportResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/reports/satisfaction [get]
+func (h *SatisfactionHandler) GetSatisfactionReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.SatisfactionReportRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры отчета",
+			err.Error(),
+		))
+		return
+	}
+
+	from, errFrom := time.Parse(time.DateOnly, req.From)
+	to, errTo := time.Parse(time.DateOnly, req.To)
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_DATE",
+			"Неверный формат даты (ожидается YYYY-MM-DD)",
+			fmt.Sprintf("from=%q to=%q", req.From, req.To),
+		))
+		return
+	}
+
+	report, err := h.satisfactionService.GetSatisfactionReport(ctx, ports.SatisfactionQuery{
+		From:       from,
+		To:         to.AddDate(0, 0, 1), // Конец периода включительно
+		GroupBy:    ports.SatisfactionGroupBy(req.GroupBy),
+		OperatorID: req.OperatorID,
+		CustomerID: req.CustomerID,
+		Category:   req.Category,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to build satisfaction report", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"SATISFACTION_REPORT_FAILED",
+			"Не удалось построить отчет",
+			err.Error(),
+		))
+		return
+	}
+
+	rows := make([]dto.SatisfactionReportRowResponse, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = dto.SatisfactionReportRowResponse{
+			Key:                         row.Key,
+			Name:                        row.Name,
+			SatisfactionSummaryResponse: toSatisfactionSummaryResponse(row.SatisfactionSummary),
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.SatisfactionReportResponse{
+		From:         req.From,
+		To:           req.To,
+		GroupBy:      string(report.GroupBy),
+		Rows:         rows,
+		Total:        toSatisfactionSummaryResponse(report.Total),
+		SurveysSent:  report.SurveysSent,
+		ResponseRate: report.ResponseRate,
+	}))
+}
+
+func toSatisfactionSummaryResponse(summary ports.SatisfactionSummary) dto.SatisfactionSummaryResponse {
+	return dto.SatisfactionSummaryResponse{
+		Responses:     summary.Responses,
+		Positive:      summary.Positive,
+		AverageRating: summary.AverageRating,
+		CSAT:          summary.CSAT,
+		Distribution:  summary.Distribution,
+	}
+}
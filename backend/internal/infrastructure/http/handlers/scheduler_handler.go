@@ -0,0 +1,368 @@
+// internal/infrastructure/http/handlers/scheduler_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// SchedulerHandler обслуживает повторяющиеся задачи и запланированные ответы
+type SchedulerHandler struct {
+	schedulerService ports.SchedulerService
+	logger           ports.Logger
+}
+
+func NewSchedulerHandler(schedulerService ports.SchedulerService, logger ports.Logger) *SchedulerHandler {
+	return &SchedulerHandler{
+		schedulerService: schedulerService,
+		logger:           logger,
+	}
+}
+
+// ListRecurringTasks возвращает правила повторяющихся задач
+// @Summary Список повторяющихся задач
+// @Tags recurring-tasks
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=[]dto.RecurringTaskResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/recurring-tasks [get]
+func (h *SchedulerHandler) ListRecurringTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	rules, err := h.schedulerService.ListRecurringTasks(ctx)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to list recurring tasks", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"RECURRING_TASKS_FETCH_FAILED",
+			"Не удалось получить повторяющиеся задачи",
+			err.Error(),
+		))
+		return
+	}
+
+	responses := make([]dto.RecurringTaskResponse, len(rules))
+	for i := range rules {
+		responses[i] = toRecurringTaskResponse(&rules[i])
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// CreateRecurringTask создает правило повторяющейся задачи
+// @Summary Создать повторяющуюся задачу
+// @Description Создает правило, по которому внутренняя задача создается по расписанию cron или RRULE
+// @Tags recurring-tasks
+// @Accept json
+// @Produce json
+// @Param request body dto.RecurringTaskRequest true "Правило"
+// @Success 201 {object} dto.BaseResponse{data=dto.RecurringTaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/recurring-tasks [post]
+func (h *SchedulerHandler) CreateRecurringTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.RecurringTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid recurring task request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	rt, err := h.schedulerService.CreateRecurringTask(ctx, ports.RecurringTaskRequest{
+		Name:        req.Name,
+		Schedule:    req.Schedule,
+		Timezone:    req.Timezone,
+		Subject:     req.Subject,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Category:    req.Category,
+		Tags:        req.Tags,
+		AssigneeID:  req.AssigneeID,
+		ReporterID:  currentUserID(c),
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to create recurring task", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"RECURRING_TASK_CREATION_FAILED",
+			"Не удалось создать повторяющуюся задачу",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(toRecurringTaskResponse(rt)))
+}
+
+// GetRecurringTask возвращает правило по ID
+// @Summary Получить повторяющуюся задачу
+// @Tags recurring-tasks
+// @Produce json
+// @Param id path string true "ID правила"
+// @Success 200 {object} dto.BaseResponse{data=dto.RecurringTaskResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/recurring-tasks/{id} [get]
+func (h *SchedulerHandler) GetRecurringTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	rt, err := h.schedulerService.GetRecurringTask(ctx, id)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get recurring task", "recurring_task_id", id, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"RECURRING_TASK_NOT_FOUND",
+			"Повторяющаяся задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toRecurringTaskResponse(rt)))
+}
+
+// UpdateRecurringTask изменяет правило
+// @Summary Изменить повторяющуюся задачу
+// @Description Незаданные поля не меняются; enabled=false приостанавливает правило
+// @Tags recurring-tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID правила"
+// @Param request body dto.UpdateRecurringTaskRequest true "Изменения"
+// @Success 200 {object} dto.BaseResponse{data=dto.RecurringTaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/recurring-tasks/{id} [put]
+func (h *SchedulerHandler) UpdateRecurringTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var req dto.UpdateRecurringTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid recurring task update request", "recurring_task_id", id, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	rt, err := h.schedulerService.UpdateRecurringTask(ctx, id, ports.RecurringTaskRequest{
+		Name:        req.Name,
+		Schedule:    req.Schedule,
+		Timezone:    req.Timezone,
+		Subject:     req.Subject,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Category:    req.Category,
+		Tags:        req.Tags,
+		AssigneeID:  req.AssigneeID,
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to update recurring task", "recurring_task_id", id, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"RECURRING_TASK_UPDATE_FAILED",
+			"Не удалось изменить повторяющуюся задачу",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toRecurringTaskResponse(rt)))
+}
+
+// DeleteRecurringTask удаляет правило
+// @Summary Удалить повторяющуюся задачу
+// @Tags recurring-tasks
+// @Param id path string true "ID правила"
+// @Success 204
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/recurring-tasks/{id} [delete]
+func (h *SchedulerHandler) DeleteRecurringTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.schedulerService.DeleteRecurringTask(ctx, id); err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to delete recurring task", "recurring_task_id", id, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"RECURRING_TASK_NOT_FOUND",
+			"Повторяющаяся задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetScheduledReplies возвращает запланированные ответы задачи
+// @Summary Запланированные ответы задачи
+// @Tags scheduled-replies
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.ScheduledReplyResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/scheduled-replies [get]
+func (h *SchedulerHandler) GetScheduledReplies(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	replies, err := h.schedulerService.GetScheduledReplies(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get scheduled replies", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"SCHEDULED_REPLIES_FETCH_FAILED",
+			"Не удалось получить запланированные ответы",
+			err.Error(),
+		))
+		return
+	}
+
+	responses := make([]dto.ScheduledReplyResponse, len(replies))
+	for i := range replies {
+		responses[i] = toScheduledReplyResponse(&replies[i])
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// ScheduleReply планирует ответ по задаче
+// @Summary Запланировать ответ
+// @Description Ответ будет добавлен в задачу (и отправлен клиенту для email-задач) в момент send_at
+// @Tags scheduled-replies
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param request body dto.ScheduleReplyRequest true "Ответ"
+// @Success 201 {object} dto.BaseResponse{data=dto.ScheduledReplyResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/scheduled-replies [post]
+func (h *SchedulerHandler) ScheduleReply(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	var req dto.ScheduleReplyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid schedule reply request", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	reply, err := h.schedulerService.ScheduleReply(ctx, ports.ScheduleReplyRequest{
+		TaskID:    taskID,
+		AuthorID:  currentUserID(c),
+		Content:   req.Content,
+		IsPrivate: req.IsPrivate,
+		SendAt:    req.SendAt,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to schedule reply", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"SCHEDULE_REPLY_FAILED",
+			"Не удалось запланировать ответ",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(toScheduledReplyResponse(reply)))
+}
+
+// CancelScheduledReply отменяет запланированный ответ
+// @Summary Отменить запланированный ответ
+// @Tags scheduled-replies
+// @Produce json
+// @Param id path string true "ID запланированного ответа"
+// @Success 200 {object} dto.BaseResponse{data=dto.ScheduledReplyResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/scheduled-replies/{id} [delete]
+func (h *SchedulerHandler) CancelScheduledReply(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	reply, err := h.schedulerService.CancelScheduledReply(ctx, id)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to cancel scheduled reply", "scheduled_reply_id", id, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"SCHEDULED_REPLY_CANCEL_FAILED",
+			"Не удалось отменить запланированный ответ",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toScheduledReplyResponse(reply)))
+}
+
+func toRecurringTaskResponse(rt *domain.RecurringTask) dto.RecurringTaskResponse {
+	return dto.RecurringTaskResponse{
+		ID:          rt.ID,
+		Name:        rt.Name,
+		Schedule:    rt.Schedule,
+		Timezone:    rt.Timezone,
+		Subject:     rt.Subject,
+		Description: rt.Description,
+		Priority:    rt.Priority,
+		Category:    rt.Category,
+		Tags:        rt.Tags,
+		AssigneeID:  rt.AssigneeID,
+		ReporterID:  rt.ReporterID,
+		Enabled:     rt.Enabled,
+		NextRunAt:   rt.NextRunAt,
+		LastRunAt:   rt.LastRunAt,
+		LastTaskID:  rt.LastTaskID,
+		CreatedAt:   rt.CreatedAt,
+		UpdatedAt:   rt.UpdatedAt,
+	}
+}
+
+func toScheduledReplyResponse(reply *domain.ScheduledReply) dto.ScheduledReplyResponse {
+	return dto.ScheduledReplyResponse{
+		ID:        reply.ID,
+		TaskID:    reply.TaskID,
+		AuthorID:  reply.AuthorID,
+		Content:   reply.Content,
+		IsPrivate: reply.IsPrivate,
+		SendAt:    reply.SendAt,
+		Status:    reply.Status,
+		SentAt:    reply.SentAt,
+		Error:     reply.Error,
+		CreatedAt: reply.CreatedAt,
+	}
+}
@@ -0,0 +1,151 @@
+// internal/infrastructure/http/handlers/search_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSearchPageSize размер страницы результатов поиска по умолчанию
+const defaultSearchPageSize = 20
+
+type SearchHandler struct {
+	searchService ports.SearchService
+	logger        ports.Logger
+}
+
+func NewSearchHandler(searchService ports.SearchService, logger ports.Logger) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		logger:        logger,
+	}
+}
+
+// Search ищет по задачам, письмам и клиентам
+// @Summary Полнотекстовый поиск
+// @Description Ищет по теме, описанию и сообщениям задач, тексту и вложениям писем, имени, email и организации клиентов.
+// @Description Результаты ограничены правами пользователя; фасеты считаются без учета фильтров status, priority и category
+// @Tags search
+// @Produce json
+// @Param q query string true "Текст запроса"
+// @Param type query string false "Типы сущностей через запятую" Enums(task, email, customer)
+// @Param status query string false "Статусы через запятую"
+// @Param priority query string false "Приоритеты через запятую"
+// @Param category query string false "Категории через запятую"
+// @Param page query int false "Номер страницы"
+// @Param page_size query int false "Размер страницы (до 100)"
+// @Success 200 {object} dto.BaseResponse{data=dto.SearchResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 403 {object} dto.BaseResponse
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.SearchRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PageSize == 0 {
+		req.PageSize = defaultSearchPageSize
+	}
+
+	query := ports.SearchQuery{
+		Text:       req.Query,
+		Statuses:   splitListValues(req.Status),
+		Priorities: splitListValues(req.Priority),
+		Categories: splitListValues(req.Category),
+		Offset:     (req.Page - 1) * req.PageSize,
+		Limit:      req.PageSize,
+	}
+	for _, entityType := range splitListValues(req.Type) {
+		query.EntityTypes = append(query.EntityTypes, domain.SearchEntityType(entityType))
+	}
+
+	result, err := h.searchService.Search(ctx, query)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Warn(ctx, "Search failed", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"SEARCH_FAILED",
+			"Не удалось выполнить поиск",
+			err.Error(),
+		))
+		return
+	}
+
+	hits := make([]dto.SearchHitResponse, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = dto.SearchHitResponse{
+			EntityType: string(hit.EntityType),
+			EntityID:   hit.EntityID,
+			Title:      hit.Title,
+			Snippet:    hit.Snippet,
+			Rank:       hit.Rank,
+			Status:     hit.Status,
+			Priority:   hit.Priority,
+			Category:   hit.Category,
+			UpdatedAt:  hit.UpdatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.SearchResponse{
+		Hits: hits,
+		Facets: dto.SearchFacetsResponse{
+			EntityTypes: result.Facets.EntityTypes,
+			Statuses:    result.Facets.Statuses,
+			Priorities:  result.Facets.Priorities,
+			Categories:  result.Facets.Categories,
+		},
+		Pagination: dto.PageInfo{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: result.TotalCount,
+			TotalPages: (result.TotalCount + req.PageSize - 1) / req.PageSize,
+		},
+	}))
+}
+
+// Reindex заново строит поисковый индекс
+// @Summary Переиндексация поиска
+// @Description Индексирует все задачи, письма и клиентов заново. Требует права settings:manage
+// @Tags search
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.ReindexResponse}
+// @Failure 403 {object} dto.BaseResponse
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/v1/search/reindex [post]
+func (h *SearchHandler) Reindex(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	count, err := h.searchService.Reindex(ctx)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to rebuild search index", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"REINDEX_FAILED",
+			"Не удалось переиндексировать данные",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Search index rebuilt", "documents", count)
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.ReindexResponse{Documents: count}))
+}
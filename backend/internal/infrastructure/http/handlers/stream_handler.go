@@ -0,0 +1,195 @@
+// internal/infrastructure/http/handlers/stream_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// lastEventIDHeader заголовок, с которым EventSource переподключается к потоку
+const lastEventIDHeader = "Last-Event-ID"
+
+// streamResyncEvent событие SSE: часть изменений вытеснена из буфера, клиенту нужно перечитать данные
+const streamResyncEvent = "resync"
+
+// Параметры потока по умолчанию
+const (
+	defaultStreamHeartbeat = 15 * time.Second
+	defaultStreamRetry     = 3 * time.Second
+)
+
+// StreamHandler поток изменений задач в формате Server-Sent Events
+type StreamHandler struct {
+	streamService     ports.TaskStreamService
+	heartbeatInterval time.Duration
+	retryInterval     time.Duration
+	logger            ports.Logger
+}
+
+func NewStreamHandler(streamService ports.TaskStreamService, heartbeatInterval, retryInterval time.Duration, logger ports.Logger) *StreamHandler {
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultStreamHeartbeat
+	}
+	if retryInterval <= 0 {
+		retryInterval = defaultStreamRetry
+	}
+	return &StreamHandler{
+		streamService:     streamService,
+		heartbeatInterval: heartbeatInterval,
+		retryInterval:     retryInterval,
+		logger:            logger,
+	}
+}
+
+// Stream передает изменения задач до отключения клиента.
+// После переподключения с Last-Event-ID сначала повторяются пропущенные события из буфера;
+// если они уже вытеснены, клиент получает событие resync. Клиент, не успевающий читать,
+// отключается и продолжает с того же места после переподключения
+func (h *StreamHandler) Stream(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var query dto.TaskStreamQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры запроса",
+			err.Error(),
+		))
+		return
+	}
+	lastEventID := query.LastEventID
+	if header := c.GetHeader(lastEventIDHeader); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"INVALID_REQUEST",
+				"Неверный заголовок Last-Event-ID",
+				err.Error(),
+			))
+			return
+		}
+		lastEventID = id
+	}
+
+	subscription, err := h.streamService.Subscribe(ctx, ports.TaskStreamRequest{
+		Filter: domain.TaskStreamFilter{
+			Queues:  query.Queues,
+			TaskIDs: query.TaskIDs,
+		},
+		LastEventID: lastEventID,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to subscribe to task stream", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"INTERNAL_ERROR",
+			"Не удалось подписаться на изменения задач",
+			err.Error(),
+		))
+		return
+	}
+	defer subscription.Close()
+
+	// Поток длится дольше WriteTimeout сервера, поэтому срок записи снимается для этого соединения
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug(ctx, "Failed to reset write deadline for task stream", "error", err.Error())
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nginx не должен буферизовать поток
+	c.Status(http.StatusOK)
+
+	if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", h.retryInterval.Milliseconds()); err != nil {
+		return
+	}
+	if subscription.Incomplete() {
+		if err := writeSSE(c, "", streamResyncEvent, gin.H{"last_event_id": strconv.FormatUint(lastEventID, 10)}); err != nil {
+			return
+		}
+	}
+	for _, event := range subscription.Replay() {
+		if err := writeSSE(c, strconv.FormatUint(event.ID, 10), string(event.Type), toTaskStreamEventResponse(event)); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			// Комментарий SSE не виден клиенту и не дает прокси закрыть простаивающее соединение
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-subscription.Events():
+			if !ok {
+				if err := subscription.Err(); errors.Is(err, domain.ErrTaskStreamLagged) {
+					h.logger.Warn(ctx, "Task stream client is too slow, disconnecting",
+						"last_event_id", lastEventID)
+				}
+				return
+			}
+			if err := writeSSE(c, strconv.FormatUint(event.ID, 10), string(event.Type), toTaskStreamEventResponse(event)); err != nil {
+				return
+			}
+			lastEventID = event.ID
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeSSE записывает событие SSE с JSON в поле data
+func writeSSE(c *gin.Context, id, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(c.Writer, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
+
+func toTaskStreamEventResponse(event domain.TaskStreamEvent) dto.TaskStreamEventResponse {
+	tasks := &TaskHandler{}
+	task := tasks.toTaskResponse(event.Task)
+	// Сообщения и история передаются только при запросе задачи
+	task.Messages = nil
+	task.History = nil
+
+	response := dto.TaskStreamEventResponse{
+		ID:         strconv.FormatUint(event.ID, 10),
+		Type:       event.Type,
+		TaskID:     event.TaskID,
+		ActorID:    event.ActorID,
+		OccurredAt: event.OccurredAt,
+		Task:       task,
+	}
+	if message := event.Message(); message != nil {
+		messageResponse := toMessageResponse(*message)
+		response.Message = &messageResponse
+	}
+	return response
+}
@@ -0,0 +1,112 @@
+// internal/infrastructure/http/handlers/task_dashboard_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMyTasks возвращает задачи текущего пользователя
+// @Summary Мои задачи
+// @Description Возвращает назначенные, созданные, отслеживаемые задачи текущего пользователя
+// @Tags me
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.UserTasksResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/me/tasks [get]
+func (h *TaskHandler) GetMyTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := currentUserID(c)
+
+	userTasks, err := h.taskService.GetUserTasks(ctx, userID, currentUserRole(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get user tasks", "user_id", userID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"USER_TASKS_FAILED",
+			"Не удалось получить задачи пользователя",
+			err.Error(),
+		))
+		return
+	}
+
+	response := dto.UserTasksResponse{
+		AssignedTasks:   h.toTaskResponses(userTasks.AssignedTasks),
+		ReportedTasks:   h.toTaskResponses(userTasks.ReportedTasks),
+		ParticipatingIn: h.toTaskResponses(userTasks.ParticipatingIn),
+		Watching:        h.toTaskResponses(userTasks.Watching),
+		UnassignedTasks: h.toTaskResponses(userTasks.UnassignedTasks),
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(response))
+}
+
+// GetMyDashboard возвращает дашборд текущего пользователя
+// @Summary Мой дашборд
+// @Description Возвращает задачи в работе, счетчики, ленту активности и статистику
+// @Tags me
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=dto.DashboardResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/me/dashboard [get]
+func (h *TaskHandler) GetMyDashboard(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := currentUserID(c)
+
+	dashboard, err := h.taskService.GetDashboard(ctx, userID, currentUserRole(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get dashboard", "user_id", userID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"DASHBOARD_FAILED",
+			"Не удалось получить дашборд",
+			err.Error(),
+		))
+		return
+	}
+
+	response := dto.DashboardResponse{
+		MyTasks:         h.toTaskResponses(dashboard.MyTasks),
+		AssignedCount:   dashboard.AssignedCount,
+		UnassignedCount: dashboard.UnassignedCount,
+		SnoozedCount:    dashboard.SnoozedCount,
+		OverdueCount:    dashboard.OverdueCount,
+		RecentActivity:  make([]dto.DashboardActivityResponse, len(dashboard.RecentActivity)),
+	}
+
+	for i, activity := range dashboard.RecentActivity {
+		response.RecentActivity[i] = dto.DashboardActivityResponse{
+			Type:      activity.Type,
+			TaskID:    activity.TaskID,
+			Subject:   activity.Subject,
+			UserID:    activity.UserID,
+			Timestamp: activity.Timestamp,
+		}
+	}
+
+	if dashboard.Stats != nil {
+		response.Stats = dto.UserStatsResponse{
+			OpenTasks:         dashboard.Stats.OpenTasks,
+			ResolvedToday:     dashboard.Stats.ResolvedToday,
+			AvgResolutionTime: dashboard.Stats.AvgResolutionTime,
+			SatisfactionRate:  dashboard.Stats.SatisfactionRate,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(response))
+}
+
+func (h *TaskHandler) toTaskResponses(tasks []domain.Task) []dto.TaskResponse {
+	responses := make([]dto.TaskResponse, len(tasks))
+	for i := range tasks {
+		responses[i] = h.toTaskResponse(&tasks[i])
+	}
+	return responses
+}
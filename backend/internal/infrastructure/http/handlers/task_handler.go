@@ -2,7 +2,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
@@ -54,17 +56,22 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		Subject:     req.Subject,
 		Description: req.Description,
 		CustomerID:  req.CustomerID,
-		ReporterID:  "system", // TODO: Заменить на ID авторизованного пользователя
+		ReporterID:  currentUserID(c),
 		Source:      domain.SourceInternal,
 		Priority:    req.Priority,
 		Category:    req.Category,
 		Tags:        req.Tags,
 		ParentID:    req.ParentID,
 		ProjectID:   req.ProjectID,
+
+		CustomFields: req.CustomFields,
 	}
 
 	task, err := h.taskService.CreateTask(ctx, createReq)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to create task", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"TASK_CREATION_FAILED",
@@ -78,17 +85,23 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	if req.DueDate != nil {
 		dueDateStr := req.DueDate.Format(time.RFC3339) // СОЗДАЕМ ПЕРЕМЕННУЮ
 		updateReq := ports.UpdateTaskRequest{
+			UserID:  currentUserID(c),
 			DueDate: &dueDateStr, // ПЕРЕДАЕМ АДРЕС ПЕРЕМЕННОЙ
 		}
-		task, err = h.taskService.UpdateTask(ctx, task.ID, updateReq)
+		updated, err := h.taskService.UpdateTask(ctx, task.ID, updateReq)
 		if err != nil {
+			if abortForbidden(c, err) {
+				return
+			}
 			h.logger.Warn(ctx, "Failed to set due date for task",
 				"task_id", task.ID, "error", err.Error())
+		} else {
+			task = updated
 		}
 	}
 
 	h.logger.Info(ctx, "Task created successfully", "task_id", task.ID)
-	c.JSON(http.StatusCreated, dto.NewSuccessResponse(h.toTaskResponse(task)))
+	h.respondWithTask(c, http.StatusCreated, task)
 }
 
 // CreateSupportTask создает задачу поддержки
@@ -120,7 +133,7 @@ func (h *TaskHandler) CreateSupportTask(c *gin.Context) {
 		Subject:     req.Subject,
 		Description: req.Description,
 		CustomerID:  req.CustomerID,
-		ReporterID:  "system", // TODO: Заменить на ID авторизованного пользователя
+		ReporterID:  currentUserID(c),
 		Source:      domain.SourceInternal,
 		Priority:    req.Priority,
 		Category:    req.Category,
@@ -129,6 +142,9 @@ func (h *TaskHandler) CreateSupportTask(c *gin.Context) {
 
 	task, err := h.taskService.CreateSupportTask(ctx, createReq)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to create support task", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"SUPPORT_TASK_CREATION_FAILED",
@@ -139,7 +155,7 @@ func (h *TaskHandler) CreateSupportTask(c *gin.Context) {
 	}
 
 	h.logger.Info(ctx, "Support task created successfully", "task_id", task.ID)
-	c.JSON(http.StatusCreated, dto.NewSuccessResponse(h.toTaskResponse(task)))
+	h.respondWithTask(c, http.StatusCreated, task)
 }
 
 // GetTask возвращает задачу по ID
@@ -158,6 +174,9 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 
 	task, err := h.taskService.GetTask(ctx, taskID)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to get task", "task_id", taskID, "error", err.Error())
 		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
 			"TASK_NOT_FOUND",
@@ -167,7 +186,28 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.NewSuccessResponse(h.toTaskResponse(task)))
+	// Клиент с актуальной версией получает 304 без тела
+	etag := taskETag(task)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	response := h.toTaskResponse(task)
+
+	// Для родительских задач показываем прогресс выполнения подзадач
+	progress, err := h.taskService.GetSubtaskProgress(ctx, task.ID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Warn(ctx, "Failed to get subtask progress", "task_id", taskID, "error", err.Error())
+	} else if progress.Total > 0 {
+		response.SubtaskProgress = toSubtaskProgressResponse(progress)
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(response))
 }
 
 // UpdateTask обновляет задачу
@@ -200,11 +240,14 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 
 	// Преобразуем DTO в портовый запрос
 	updateReq := ports.UpdateTaskRequest{
+		UserID:      currentUserID(c),
 		Subject:     req.Subject,
 		Description: req.Description,
 		Priority:    req.Priority,
 		Category:    req.Category,
 		Tags:        req.Tags,
+
+		CustomFields: req.CustomFields,
 	}
 
 	if req.DueDate != nil {
@@ -214,7 +257,13 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 
 	task, err := h.taskService.UpdateTask(ctx, taskID, updateReq)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to update task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"TASK_UPDATE_FAILED",
 			"Не удалось обновить задачу",
@@ -224,7 +273,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	}
 
 	h.logger.Info(ctx, "Task updated successfully", "task_id", taskID)
-	c.JSON(http.StatusOK, dto.NewSuccessResponse(h.toTaskResponse(task)))
+	h.respondWithTask(c, http.StatusOK, task)
 }
 
 // DeleteTask удаляет задачу
@@ -243,7 +292,13 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 
 	err := h.taskService.DeleteTask(ctx, taskID)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to delete task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"TASK_DELETION_FAILED",
 			"Не удалось удалить задачу",
@@ -272,8 +327,14 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 // @Param search_text query string false "Поисковый запрос"
 // @Param page query int false "Номер страницы" default(1) minimum(1)
 // @Param page_size query int false "Размер страницы" default(20) minimum(1) maximum(100)
-// @Param sort_by query string false "Поле для сортировки"
+// @Param sort_by query string false "Поле для сортировки (custom_fields.<key> - по пользовательскому полю)"
 // @Param sort_order query string false "Порядок сортировки" Enums(asc, desc)
+// @Param sort query string false "Сортировка по нескольким полям, минус - по убыванию: -priority,created_at"
+// @Param cursor query string false "Курсор следующей страницы (pagination.next_cursor)"
+// @Param limit query int false "Размер страницы (синоним page_size)" minimum(1) maximum(100)
+// @Param filter query string false "Компактные фильтры: status!=closed, priority=high,critical, created>=2025-01-01, due<2025-02-01"
+// @Param cf.{key} query string false "Фильтр по пользовательскому полю"
+// @Param snoozed query string false "Отложенные задачи: false - скрыть (по умолчанию), true - только отложенные, all - все" Enums(true, false, all)
 // @Success 200 {object} dto.BaseResponse{data=dto.TaskListResponse}
 // @Failure 400 {object} dto.BaseResponse
 // @Failure 500 {object} dto.BaseResponse
@@ -292,13 +353,11 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 		return
 	}
 
-	// Устанавливаем значения по умолчанию
-	if req.Page == 0 {
-		req.Page = 1
-	}
-	if req.PageSize == 0 {
-		req.PageSize = 20
+	sort, ok := parseListSort(c, req.Sort, domain.TaskSortFields)
+	if !ok {
+		return
 	}
+	offset, limit := listPageInfo(req.Page, req.PageSize, req.Limit, req.Cursor)
 
 	// Преобразуем DTO в портовый запрос
 	query := ports.TaskQuery{
@@ -311,14 +370,35 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 		Category:   req.Category,
 		Tags:       req.Tags,
 		SearchText: req.SearchText,
-		Offset:     (req.Page - 1) * req.PageSize,
-		Limit:      req.PageSize,
+		Offset:     offset,
+		Limit:      limit,
+		Cursor:     req.Cursor,
+		Sort:       sort,
 		SortBy:     req.SortBy,
 		SortOrder:  req.SortOrder,
+
+		CustomFields: customFieldFilters(c),
+	}
+	if err := applyListFilters(c.Request.URL.RawQuery, taskListFilters(&query)); err != nil {
+		abortInvalidListFilter(c, err)
+		return
+	}
+
+	// Отложенные задачи не показываются в очередях, пока не вернутся в работу
+	switch req.Snoozed {
+	case "", "false":
+		snoozed := false
+		query.Snoozed = &snoozed
+	case "true":
+		snoozed := true
+		query.Snoozed = &snoozed
 	}
 
 	result, err := h.taskService.SearchTasks(ctx, query)
 	if err != nil {
+		if abortForbidden(c, err) || abortInvalidCursor(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to search tasks", "error", err.Error())
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"SEARCH_FAILED",
@@ -341,6 +421,7 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 			PageSize:   result.PageSize,
 			TotalCount: result.TotalCount,
 			TotalPages: result.TotalPages,
+			NextCursor: result.NextCursor,
 		},
 	}
 
@@ -375,9 +456,23 @@ func (h *TaskHandler) ChangeStatus(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskService.ChangeStatus(ctx, taskID, req.Status, "system") // TODO: Заменить на ID пользователя
+	task, err := h.taskService.ChangeStatus(ctx, taskID, req.Status, currentUserID(c))
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to change task status", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrTaskBlocked) {
+			c.JSON(http.StatusConflict, dto.NewErrorResponse(
+				"TASK_BLOCKED",
+				"Задача заблокирована незавершенными задачами",
+				err.Error(),
+			))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"STATUS_CHANGE_FAILED",
 			"Не удалось изменить статус задачи",
@@ -387,7 +482,7 @@ func (h *TaskHandler) ChangeStatus(c *gin.Context) {
 	}
 
 	h.logger.Info(ctx, "Task status changed", "task_id", taskID, "status", req.Status)
-	c.JSON(http.StatusOK, dto.NewSuccessResponse(h.toTaskResponse(task)))
+	h.respondWithTask(c, http.StatusOK, task)
 }
 
 // AssignTask назначает исполнителя задачи
@@ -418,9 +513,23 @@ func (h *TaskHandler) AssignTask(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskService.AssignTask(ctx, taskID, req.AssigneeID, "system") // TODO: Заменить на ID пользователя
+	task, err := h.taskService.AssignTask(ctx, taskID, req.AssigneeID, currentUserID(c))
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to assign task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrUserInactive) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"INVALID_ASSIGNEE",
+				"Исполнитель не найден или неактивен",
+				err.Error(),
+			))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"ASSIGNMENT_FAILED",
 			"Не удалось назначить исполнителя",
@@ -430,7 +539,7 @@ func (h *TaskHandler) AssignTask(c *gin.Context) {
 	}
 
 	h.logger.Info(ctx, "Task assigned", "task_id", taskID, "assignee_id", req.AssigneeID)
-	c.JSON(http.StatusOK, dto.NewSuccessResponse(h.toTaskResponse(task)))
+	h.respondWithTask(c, http.StatusOK, task)
 }
 
 // AddMessage добавляет сообщение в задачу
@@ -462,15 +571,29 @@ func (h *TaskHandler) AddMessage(c *gin.Context) {
 	}
 
 	messageReq := ports.AddMessageRequest{
-		AuthorID:  "system", // TODO: Заменить на ID авторизованного пользователя
+		AuthorID:  currentUserID(c),
 		Content:   req.Content,
 		Type:      req.Type,
 		IsPrivate: req.IsPrivate,
 	}
+	for _, attachment := range req.Attachments {
+		messageReq.Attachments = append(messageReq.Attachments, domain.Attachment{
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			Size:        int64(len(attachment.Data)),
+			Data:        attachment.Data,
+		})
+	}
 
 	task, err := h.taskService.AddMessage(ctx, taskID, messageReq)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to add message to task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
 			"MESSAGE_ADD_FAILED",
 			"Не удалось добавить сообщение",
@@ -480,7 +603,7 @@ func (h *TaskHandler) AddMessage(c *gin.Context) {
 	}
 
 	h.logger.Info(ctx, "Message added to task", "task_id", taskID)
-	c.JSON(http.StatusCreated, dto.NewSuccessResponse(h.toTaskResponse(task)))
+	h.respondWithTask(c, http.StatusCreated, task)
 }
 
 // GetTaskMessages возвращает сообщения задачи
@@ -499,6 +622,9 @@ func (h *TaskHandler) GetTaskMessages(c *gin.Context) {
 
 	task, err := h.taskService.GetTask(ctx, taskID)
 	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
 		h.logger.Error(ctx, "Failed to get task messages", "task_id", taskID, "error", err.Error())
 		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
 			"TASK_NOT_FOUND",
@@ -510,13 +636,7 @@ func (h *TaskHandler) GetTaskMessages(c *gin.Context) {
 
 	messages := make([]dto.MessageResponse, len(task.Messages))
 	for i, msg := range task.Messages {
-		messages[i] = dto.MessageResponse{
-			ID:        msg.ID,
-			Content:   msg.Content,
-			AuthorID:  msg.AuthorID,
-			Type:      msg.Type,
-			CreatedAt: msg.CreatedAt,
-		}
+		messages[i] = toMessageResponse(msg)
 	}
 
 	c.JSON(http.StatusOK, dto.NewSuccessResponse(messages))
@@ -524,50 +644,84 @@ func (h *TaskHandler) GetTaskMessages(c *gin.Context) {
 
 // Вспомогательные методы
 
+// toMessageResponse преобразует сообщение; содержимое вложений в ответ не входит
+func toMessageResponse(message domain.Message) dto.MessageResponse {
+	response := dto.MessageResponse{
+		ID:        message.ID,
+		Content:   message.Content,
+		AuthorID:  message.AuthorID,
+		Type:      message.Type,
+		IsPrivate: message.IsPrivate(),
+		CreatedAt: message.CreatedAt,
+	}
+	for _, attachment := range message.Attachments {
+		response.Attachments = append(response.Attachments, dto.MessageAttachmentResponse{
+			ID:          string(attachment.ID),
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+		})
+	}
+	return response
+}
+
 func (h *TaskHandler) toTaskResponse(task *domain.Task) dto.TaskResponse {
 	response := dto.TaskResponse{
-		ID:          task.ID,
-		Type:        task.Type,
-		Subject:     task.Subject,
-		Description: task.Description,
-		Status:      task.Status,
-		Priority:    task.Priority,
-		Category:    task.Category,
-		Tags:        task.Tags,
-		ParentID:    task.ParentID,
-		ProjectID:   task.ProjectID,
-		AssigneeID:  task.AssigneeID,
-		ReporterID:  task.ReporterID,
-		CustomerID:  task.CustomerID,
-		Source:      task.Source,
-		SourceMeta:  task.SourceMeta,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-		DueDate:     task.DueDate,
-		ResolvedAt:  task.ResolvedAt,
-		ClosedAt:    task.ClosedAt,
-	}
-
-	// Преобразуем участников
-	response.Participants = make([]dto.ParticipantResponse, len(task.Participants))
-	for i, participant := range task.Participants {
-		response.Participants[i] = dto.ParticipantResponse{
-			UserID:   participant.UserID,
-			Role:     participant.Role,
-			JoinedAt: participant.JoinedAt,
+		ID:           task.ID,
+		Type:         task.Type,
+		Subject:      task.Subject,
+		Description:  task.Description,
+		Status:       task.Status,
+		Priority:     task.Priority,
+		Category:     task.Category,
+		Tags:         task.Tags,
+		Version:      task.Version,
+		ParentID:     task.ParentID,
+		ProjectID:    task.ProjectID,
+		AssigneeID:   task.AssigneeID,
+		ReporterID:   task.ReporterID,
+		CustomerID:   task.CustomerID,
+		Source:       task.Source,
+		SourceMeta:   task.SourceMeta,
+		CustomFields: task.CustomFields,
+		CreatedAt:    task.CreatedAt,
+		UpdatedAt:    task.UpdatedAt,
+		DueDate:      task.DueDate,
+		ResolvedAt:   task.ResolvedAt,
+		ClosedAt:     task.ClosedAt,
+		SnoozedUntil: task.SnoozedUntil,
+	}
+
+	if task.Satisfaction != nil {
+		response.Satisfaction = &dto.SatisfactionResponse{
+			SentAt:      task.Satisfaction.SentAt,
+			Rating:      task.Satisfaction.Rating,
+			Comment:     task.Satisfaction.Comment,
+			OperatorID:  task.Satisfaction.OperatorID,
+			RespondedAt: task.Satisfaction.RespondedAt,
+		}
+	}
+
+	// Преобразуем участников и связи
+	response.Participants = toParticipantResponses(task.Participants)
+	response.Links = toTaskLinkResponses(task.Links)
+
+	// Учет времени
+	response.TimeSpentMinutes = int(task.TimeSpent().Minutes())
+	response.BillableMinutes = int(task.BillableTime().Minutes())
+	response.WorkLogs = toWorkLogResponses(task.WorkLogs)
+	response.Timers = make([]dto.TaskTimerResponse, len(task.Timers))
+	for i, timer := range task.Timers {
+		response.Timers[i] = dto.TaskTimerResponse{
+			UserID:    timer.UserID,
+			StartedAt: timer.StartedAt,
 		}
 	}
 
 	// Преобразуем сообщения (если нужны в ответе)
 	response.Messages = make([]dto.MessageResponse, len(task.Messages))
 	for i, message := range task.Messages {
-		response.Messages[i] = dto.MessageResponse{
-			ID:        message.ID,
-			Content:   message.Content,
-			AuthorID:  message.AuthorID,
-			Type:      message.Type,
-			CreatedAt: message.CreatedAt,
-		}
+		response.Messages[i] = toMessageResponse(message)
 	}
 
 	// Преобразуем историю (если нужна в ответе)
@@ -581,8 +735,90 @@ func (h *TaskHandler) toTaskResponse(task *domain.Task) dto.TaskResponse {
 			NewValue:  event.NewValue,
 			Timestamp: event.Timestamp,
 			Message:   event.Message,
+			Changes:   toFieldChangeResponses(event.Changes),
 		}
 	}
 
 	return response
 }
+
+// customFieldFilters извлекает фильтры по пользовательским полям из параметров вида cf.<key>=value
+// taskListFilters компактные фильтры списка задач
+func taskListFilters(query *ports.TaskQuery) listFilterSet {
+	return listFilterSet{
+		"type":     func(f listFilter) error { return filterValues(f, &query.Types, &query.ExcludeTypes) },
+		"status":   func(f listFilter) error { return filterValues(f, &query.Statuses, &query.ExcludeStatuses) },
+		"priority": func(f listFilter) error { return filterValues(f, &query.Priorities, &query.ExcludePriorities) },
+		"source":   func(f listFilter) error { return filterValues(f, &query.Source, &query.ExcludeSources) },
+		"tag":      func(f listFilter) error { return filterValues(f, &query.Tags, &query.ExcludeTags) },
+		"category": func(f listFilter) error { return filterValues(f, nil, &query.ExcludeCategories) },
+		"assignee": func(f listFilter) error {
+			var assignees []string
+			if err := filterValues(f, &assignees, &query.ExcludeAssigneeIDs); err != nil {
+				return err
+			}
+			if len(assignees) > 1 {
+				return errors.New("only one assignee can be required")
+			}
+			if len(assignees) == 1 {
+				query.AssigneeID = assignees[0]
+			}
+			return nil
+		},
+		"created":  func(f listFilter) error { return filterTimeRange(f, &query.CreatedAt) },
+		"updated":  func(f listFilter) error { return filterTimeRange(f, &query.UpdatedAt) },
+		"due":      func(f listFilter) error { return filterTimeRange(f, &query.DueDate) },
+		"resolved": func(f listFilter) error { return filterTimeRange(f, &query.ResolvedAt) },
+	}
+}
+
+func customFieldFilters(c *gin.Context) map[string]string {
+	filters := make(map[string]string)
+	for param, values := range c.Request.URL.Query() {
+		if key, ok := strings.CutPrefix(param, "cf."); ok && key != "" && len(values) > 0 {
+			filters[key] = values[0]
+		}
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	return filters
+}
+
+// currentUserID возвращает ID пользователя запроса: аутентифицированного пользователя
+// или "system" для системного контекста (аутентификация отключена, интеграции)
+func currentUserID(c *gin.Context) string {
+	ctx := c.Request.Context()
+	if user, ok := ports.AuthenticatedUser(ctx); ok {
+		return user.ID
+	}
+	if ports.IsSystemPrincipal(ctx) {
+		return "system"
+	}
+	return ""
+}
+
+// currentUserRole возвращает роль пользователя запроса.
+// Системный контекст имеет все права и видит задачи как администратор
+func currentUserRole(c *gin.Context) domain.UserRole {
+	ctx := c.Request.Context()
+	if user, ok := ports.AuthenticatedUser(ctx); ok {
+		return user.Role
+	}
+	if ports.IsSystemPrincipal(ctx) {
+		return domain.UserRoleAdmin
+	}
+	return ""
+}
+
+func toParticipantResponses(participants []domain.Participant) []dto.ParticipantResponse {
+	responses := make([]dto.ParticipantResponse, len(participants))
+	for i, participant := range participants {
+		responses[i] = dto.ParticipantResponse{
+			UserID:   participant.UserID,
+			Role:     participant.Role,
+			JoinedAt: participant.JoinedAt,
+		}
+	}
+	return responses
+}
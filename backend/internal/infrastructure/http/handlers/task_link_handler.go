@@ -0,0 +1,217 @@
+// internal/infrastructure/http/handlers/task_link_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// GetLinks возвращает связи задачи
+// @Summary Связи задачи
+// @Description Возвращает связи задачи с другими задачами (блокировки, дубликаты и т.д.)
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.TaskLinkResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/links [get]
+func (h *TaskHandler) GetLinks(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.taskService.GetTask(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get task links", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"TASK_NOT_FOUND",
+			"Задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toTaskLinkResponses(task.Links)))
+}
+
+// LinkTask создает связь между задачами
+// @Summary Связать задачи
+// @Description Создает связь между задачами. Связь duplicate_of закрывает задачу как дубликат
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param request body dto.LinkTaskRequest true "Связанная задача и тип связи"
+// @Success 201 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/links [post]
+func (h *TaskHandler) LinkTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	var req dto.LinkTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid link task request", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	task, err := h.taskService.LinkTasks(ctx, taskID, req.TargetID, req.Type, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to link tasks", "task_id", taskID, "target_id", req.TargetID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"TASK_LINK_FAILED",
+			"Не удалось связать задачи",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Tasks linked", "task_id", taskID, "target_id", req.TargetID, "type", req.Type)
+	h.respondWithTask(c, http.StatusCreated, task)
+}
+
+// UnlinkTask удаляет связь между задачами
+// @Summary Удалить связь
+// @Description Удаляет связь между задачами с обеих сторон
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param targetId path string true "ID связанной задачи"
+// @Param type query string true "Тип связи"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/links/{targetId} [delete]
+func (h *TaskHandler) UnlinkTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	targetID := c.Param("targetId")
+	linkType := domain.TaskLinkType(c.Query("type"))
+
+	if !linkType.IsValid() {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_LINK_TYPE",
+			"Неверный тип связи",
+			"query parameter 'type' is required",
+		))
+		return
+	}
+
+	task, err := h.taskService.UnlinkTasks(ctx, taskID, targetID, linkType, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to unlink tasks", "task_id", taskID, "target_id", targetID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"TASK_UNLINK_FAILED",
+			"Не удалось удалить связь",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Tasks unlinked", "task_id", taskID, "target_id", targetID, "type", linkType)
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// GetSubtasks возвращает подзадачи и прогресс их выполнения
+// @Summary Подзадачи
+// @Description Возвращает подзадачи задачи и процент их выполнения
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=dto.SubtasksResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/subtasks [get]
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	if _, err := h.taskService.GetTask(ctx, taskID); err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"TASK_NOT_FOUND",
+			"Задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	subtasks, err := h.taskService.GetSubtasks(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get subtasks", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"SUBTASKS_FETCH_FAILED",
+			"Не удалось получить подзадачи",
+			err.Error(),
+		))
+		return
+	}
+
+	progress, err := h.taskService.GetSubtaskProgress(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get subtask progress", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"SUBTASKS_FETCH_FAILED",
+			"Не удалось получить подзадачи",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.SubtasksResponse{
+		Subtasks: h.toTaskResponses(subtasks),
+		Progress: *toSubtaskProgressResponse(progress),
+	}))
+}
+
+// toTaskLinkResponses преобразует связи задачи в DTO
+func toTaskLinkResponses(links []domain.TaskLink) []dto.TaskLinkResponse {
+	responses := make([]dto.TaskLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = dto.TaskLinkResponse{
+			Type:      link.Type,
+			TaskID:    link.TaskID,
+			CreatedBy: link.CreatedBy,
+			CreatedAt: link.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// toSubtaskProgressResponse преобразует прогресс подзадач в DTO
+func toSubtaskProgressResponse(progress *ports.SubtaskProgress) *dto.SubtaskProgressResponse {
+	return &dto.SubtaskProgressResponse{
+		Total:     progress.Total,
+		Completed: progress.Completed,
+		Percent:   progress.Percent,
+	}
+}
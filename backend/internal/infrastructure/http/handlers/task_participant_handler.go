@@ -0,0 +1,237 @@
+// internal/infrastructure/http/handlers/task_participant_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// GetParticipants возвращает участников задачи
+// @Summary Участники задачи
+// @Description Возвращает список участников задачи с их ролями
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.ParticipantResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/participants [get]
+func (h *TaskHandler) GetParticipants(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.taskService.GetTask(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get task participants", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"TASK_NOT_FOUND",
+			"Задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toParticipantResponses(task.Participants)))
+}
+
+// AddParticipant добавляет участника в задачу
+// @Summary Добавить участника
+// @Description Добавляет участника (наблюдатель, рецензент, участник) в задачу
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param request body dto.AddParticipantRequest true "Участник и роль"
+// @Success 201 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/participants [post]
+func (h *TaskHandler) AddParticipant(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	var req dto.AddParticipantRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid add participant request", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	task, err := h.taskService.AddParticipant(ctx, taskID, req.UserID, req.Role, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to add participant", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"PARTICIPANT_ADD_FAILED",
+			"Не удалось добавить участника",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Participant added", "task_id", taskID, "participant_id", req.UserID)
+	h.respondWithTask(c, http.StatusCreated, task)
+}
+
+// ChangeParticipantRole изменяет роль участника
+// @Summary Изменить роль участника
+// @Description Изменяет роль участника задачи
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param userId path string true "ID участника"
+// @Param request body dto.ChangeParticipantRoleRequest true "Новая роль"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/participants/{userId} [put]
+func (h *TaskHandler) ChangeParticipantRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	participantID := c.Param("userId")
+	var req dto.ChangeParticipantRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid change participant role request", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	task, err := h.taskService.ChangeParticipantRole(ctx, taskID, participantID, req.Role, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to change participant role", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"PARTICIPANT_ROLE_CHANGE_FAILED",
+			"Не удалось изменить роль участника",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Participant role changed", "task_id", taskID, "participant_id", participantID, "role", req.Role)
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// RemoveParticipant удаляет участника из задачи
+// @Summary Удалить участника
+// @Description Удаляет участника из задачи (кроме автора и исполнителя)
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param userId path string true "ID участника"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/participants/{userId} [delete]
+func (h *TaskHandler) RemoveParticipant(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	participantID := c.Param("userId")
+
+	task, err := h.taskService.RemoveParticipant(ctx, taskID, participantID, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to remove participant", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"PARTICIPANT_REMOVE_FAILED",
+			"Не удалось удалить участника",
+			err.Error(),
+		))
+		return
+	}
+
+	h.logger.Info(ctx, "Participant removed", "task_id", taskID, "participant_id", participantID)
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// WatchTask подписывает текущего пользователя на задачу
+// @Summary Наблюдать за задачей
+// @Description Добавляет текущего пользователя в наблюдатели задачи
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/watch [post]
+func (h *TaskHandler) WatchTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.taskService.WatchTask(ctx, taskID, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to watch task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"WATCH_FAILED",
+			"Не удалось подписаться на задачу",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// UnwatchTask отписывает текущего пользователя от задачи
+// @Summary Прекратить наблюдение
+// @Description Удаляет текущего пользователя из наблюдателей задачи
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/watch [delete]
+func (h *TaskHandler) UnwatchTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.taskService.UnwatchTask(ctx, taskID, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to unwatch task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"UNWATCH_FAILED",
+			"Не удалось отписаться от задачи",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusOK, task)
+}
@@ -0,0 +1,87 @@
+// internal/infrastructure/http/handlers/task_snooze_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// SnoozeTask откладывает задачу до указанного момента
+// @Summary Отложить задачу
+// @Description Скрывает задачу из очередей до указанного момента; ответ клиента возвращает ее раньше
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param request body dto.SnoozeTaskRequest true "Момент возврата в работу"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/snooze [post]
+func (h *TaskHandler) SnoozeTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	var req dto.SnoozeTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid snooze request", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	task, err := h.taskService.SnoozeTask(ctx, taskID, req.Until, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to snooze task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"SNOOZE_FAILED",
+			"Не удалось отложить задачу",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// UnsnoozeTask возвращает отложенную задачу в работу
+// @Summary Вернуть задачу в работу
+// @Tags tasks
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/snooze [delete]
+func (h *TaskHandler) UnsnoozeTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.taskService.UnsnoozeTask(ctx, taskID, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to unsnooze task", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"UNSNOOZE_FAILED",
+			"Не удалось вернуть задачу в работу",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusOK, task)
+}
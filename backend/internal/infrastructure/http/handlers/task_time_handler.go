@@ -0,0 +1,365 @@
+// internal/infrastructure/http/handlers/task_time_handler.go
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// GetWorkLogs возвращает записи учета времени задачи
+// @Summary Учет времени задачи
+// @Description Возвращает записи о списанном на задачу времени
+// @Tags time-tracking
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.WorkLogResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/worklogs [get]
+func (h *TaskHandler) GetWorkLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.taskService.GetTask(ctx, taskID)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to get task work logs", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			"TASK_NOT_FOUND",
+			"Задача не найдена",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toWorkLogResponses(task.WorkLogs)))
+}
+
+// LogWork списывает время на задачу
+// @Summary Списать время
+// @Description Добавляет запись о затраченном на задачу времени
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param request body dto.LogWorkRequest true "Затраченное время"
+// @Success 201 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/worklogs [post]
+func (h *TaskHandler) LogWork(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	var req dto.LogWorkRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid log work request", "task_id", taskID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	var date time.Time
+	if req.Date != "" {
+		parsed, err := time.Parse(time.DateOnly, req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"INVALID_DATE",
+				"Неверный формат даты (ожидается YYYY-MM-DD)",
+				err.Error(),
+			))
+			return
+		}
+		date = parsed
+	}
+
+	task, err := h.taskService.LogWork(ctx, taskID, ports.LogWorkRequest{
+		UserID:   currentUserID(c),
+		Duration: time.Duration(req.Minutes) * time.Minute,
+		Date:     date,
+		Note:     req.Note,
+		Billable: req.Billable,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to log work", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"WORK_LOG_FAILED",
+			"Не удалось списать время",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusCreated, task)
+}
+
+// DeleteWorkLog удаляет запись учета времени
+// @Summary Удалить запись времени
+// @Tags time-tracking
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param logId path string true "ID записи"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/worklogs/{logId} [delete]
+func (h *TaskHandler) DeleteWorkLog(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	workLogID := c.Param("logId")
+
+	task, err := h.taskService.DeleteWorkLog(ctx, taskID, workLogID, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to delete work log", "task_id", taskID, "work_log_id", workLogID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"WORK_LOG_DELETE_FAILED",
+			"Не удалось удалить запись времени",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// StartTimer запускает таймер учета времени
+// @Summary Запустить таймер
+// @Description Запускает таймер учета времени текущего пользователя
+// @Tags time-tracking
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/timer/start [post]
+func (h *TaskHandler) StartTimer(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+
+	task, err := h.taskService.StartTimer(ctx, taskID, currentUserID(c))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to start timer", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"TIMER_START_FAILED",
+			"Не удалось запустить таймер",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// StopTimer останавливает таймер и списывает время
+// @Summary Остановить таймер
+// @Description Останавливает таймер текущего пользователя и создает запись учета времени
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Param request body dto.StopTimerRequest false "Комментарий и признак оплаты"
+// @Success 200 {object} dto.BaseResponse{data=dto.TaskResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/tasks/{id}/timer/stop [post]
+func (h *TaskHandler) StopTimer(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID := c.Param("id")
+	var req dto.StopTimerRequest
+
+	// Тело запроса необязательно
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"INVALID_REQUEST",
+				"Неверный формат запроса",
+				err.Error(),
+			))
+			return
+		}
+	}
+
+	task, err := h.taskService.StopTimer(ctx, taskID, ports.StopTimerRequest{
+		UserID:   currentUserID(c),
+		Note:     req.Note,
+		Billable: req.Billable,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to stop timer", "task_id", taskID, "error", err.Error())
+		if respondVersionConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"TIMER_STOP_FAILED",
+			"Не удалось остановить таймер",
+			err.Error(),
+		))
+		return
+	}
+
+	h.respondWithTask(c, http.StatusOK, task)
+}
+
+// GetTimeReport возвращает отчет по списанному времени
+// @Summary Отчет по времени
+// @Description Отчет по списанному времени за период с группировкой по клиентам, организациям или сотрудникам
+// @Tags time-tracking
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Начало периода (YYYY-MM-DD)"
+// @Param to query string true "Конец периода включительно (YYYY-MM-DD)"
+// @Param group_by query string false "Группировка" Enums(customer, organization, assignee)
+// @Param customer_id query string false "ID клиента"
+// @Param user_id query string false "ID сотрудника"
+// @Param billable_only query bool false "Только оплачиваемое время"
+// @Param format query string false "Формат ответа" Enums(json, csv)
+// @Success 200 {object} dto.BaseResponse{data=dto.TimeReportResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/reports/time [get]
+func (h *TaskHandler) GetTimeReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.TimeReportRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры отчета",
+			err.Error(),
+		))
+		return
+	}
+
+	from, errFrom := time.Parse(time.DateOnly, req.From)
+	to, errTo := time.Parse(time.DateOnly, req.To)
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_DATE",
+			"Неверный формат даты (ожидается YYYY-MM-DD)",
+			fmt.Sprintf("from=%q to=%q", req.From, req.To),
+		))
+		return
+	}
+
+	report, err := h.taskService.GetTimeReport(ctx, ports.TimeReportQuery{
+		From:         from,
+		To:           to.AddDate(0, 0, 1), // Конец периода включительно
+		GroupBy:      ports.TimeReportGroupBy(req.GroupBy),
+		CustomerID:   req.CustomerID,
+		UserID:       req.UserID,
+		BillableOnly: req.BillableOnly,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to build time report", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"TIME_REPORT_FAILED",
+			"Не удалось построить отчет",
+			err.Error(),
+		))
+		return
+	}
+
+	if req.Format == "csv" {
+		h.writeTimeReportCSV(c, report, req)
+		return
+	}
+
+	rows := make([]dto.TimeReportRowResponse, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = dto.TimeReportRowResponse{
+			Key:             row.Key,
+			Name:            row.Name,
+			TotalMinutes:    row.TotalMinutes,
+			BillableMinutes: row.BillableMinutes,
+			EntryCount:      row.EntryCount,
+			TaskCount:       row.TaskCount,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.TimeReportResponse{
+		From:            req.From,
+		To:              req.To,
+		GroupBy:         string(report.GroupBy),
+		Rows:            rows,
+		TotalMinutes:    report.TotalMinutes,
+		BillableMinutes: report.BillableMinutes,
+	}))
+}
+
+// writeTimeReportCSV выгружает отчет по времени в CSV
+func (h *TaskHandler) writeTimeReportCSV(c *gin.Context, report *ports.TimeReport, req dto.TimeReportRequest) {
+	filename := fmt.Sprintf("time-report-%s-%s.csv", req.From, req.To)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	records := [][]string{
+		{string(report.GroupBy), "name", "total_minutes", "billable_minutes", "entries", "tasks"},
+	}
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			row.Key,
+			row.Name,
+			strconv.Itoa(row.TotalMinutes),
+			strconv.Itoa(row.BillableMinutes),
+			strconv.Itoa(row.EntryCount),
+			strconv.Itoa(row.TaskCount),
+		})
+	}
+	records = append(records, []string{
+		"total", "", strconv.Itoa(report.TotalMinutes), strconv.Itoa(report.BillableMinutes), "", "",
+	})
+
+	if err := writer.WriteAll(records); err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to write time report CSV", "error", err.Error())
+	}
+}
+
+// toWorkLogResponses преобразует записи учета времени в DTO
+func toWorkLogResponses(workLogs []domain.WorkLog) []dto.WorkLogResponse {
+	responses := make([]dto.WorkLogResponse, len(workLogs))
+	for i, workLog := range workLogs {
+		responses[i] = dto.WorkLogResponse{
+			ID:        workLog.ID,
+			UserID:    workLog.UserID,
+			Minutes:   int(workLog.Duration.Minutes()),
+			Date:      workLog.Date.Format(time.DateOnly),
+			Note:      workLog.Note,
+			Billable:  workLog.Billable,
+			CreatedAt: workLog.CreatedAt,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,104 @@
+// internal/infrastructure/http/handlers/task_version.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// taskETag формирует ETag задачи по номеру ревизии
+func taskETag(task *domain.Task) string {
+	return `"` + strconv.Itoa(task.Version) + `"`
+}
+
+// respondWithTask отправляет задачу вместе с ее ETag
+func (h *TaskHandler) respondWithTask(c *gin.Context, status int, task *domain.Task) {
+	c.Header("ETag", taskETag(task))
+	c.JSON(status, dto.NewSuccessResponse(h.toTaskResponse(task)))
+}
+
+// VersionPrecondition проверяет заголовок If-Match для изменяющих запросов к задаче.
+// Несовпадение версии сразу дает 412; при совпадении версия передается в context,
+// и репозиторий повторно сверяет ее при записи, закрывая окно между проверкой и изменением
+func (h *TaskHandler) VersionPrecondition() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ifMatch := c.GetHeader("If-Match")
+		taskID := c.Param("id")
+		if ifMatch == "" || taskID == "" || c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		task, err := h.taskService.GetTask(ctx, taskID)
+		if err != nil {
+			if abortForbidden(c, err) {
+				return
+			}
+			// Отсутствие задачи обработает сам обработчик
+			c.Next()
+			return
+		}
+
+		if !etagMatches(ifMatch, task.Version) {
+			h.logger.Warn(ctx, "Task version precondition failed",
+				"task_id", taskID, "if_match", ifMatch, "version", task.Version)
+			c.Header("ETag", taskETag(task))
+			c.AbortWithStatusJSON(http.StatusPreconditionFailed, dto.NewErrorResponse(
+				"PRECONDITION_FAILED",
+				"Задача была изменена: обновите данные и повторите запрос",
+				"current version: "+strconv.Itoa(task.Version),
+			))
+			return
+		}
+
+		c.Request = c.Request.WithContext(ports.WithVersionPrecondition(ctx, taskID, task.Version))
+		c.Next()
+	}
+}
+
+// etagMatches проверяет значение If-Match: список ETag через запятую или "*"
+func etagMatches(ifMatch string, version int) bool {
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		tag = strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+		if v, err := strconv.Atoi(tag); err == nil && v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// respondVersionConflict отвечает 412, если запрос был условным (If-Match), или 409 при
+// конкурентном изменении задачи; для остальных ошибок возвращает false
+func respondVersionConflict(c *gin.Context, err error) bool {
+	if !errors.Is(err, ports.ErrVersionConflict) {
+		return false
+	}
+
+	if c.GetHeader("If-Match") != "" {
+		c.JSON(http.StatusPreconditionFailed, dto.NewErrorResponse(
+			"PRECONDITION_FAILED",
+			"Задача была изменена: обновите данные и повторите запрос",
+			err.Error(),
+		))
+		return true
+	}
+
+	c.JSON(http.StatusConflict, dto.NewErrorResponse(
+		"VERSION_CONFLICT",
+		"Задача одновременно изменена другим запросом, повторите попытку",
+		err.Error(),
+	))
+	return true
+}
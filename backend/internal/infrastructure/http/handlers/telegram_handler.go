@@ -0,0 +1,105 @@
+// internal/infrastructure/http/handlers/telegram_handler.go
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/audetv/urms/internal/infrastructure/telegram"
+	"github.com/gin-gonic/gin"
+)
+
+// telegramSecretHeader заголовок с секретом, указанным при setWebhook
+const telegramSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// TelegramHandler принимает обновления Bot API в режиме webhook
+type TelegramHandler struct {
+	telegramService ports.TelegramService
+	secret          string
+	logger          ports.Logger
+}
+
+func NewTelegramHandler(telegramService ports.TelegramService, secret string, logger ports.Logger) *TelegramHandler {
+	return &TelegramHandler{
+		telegramService: telegramService,
+		secret:          secret,
+		logger:          logger,
+	}
+}
+
+// ReceiveUpdate принимает обновление Telegram
+// @Summary Webhook Telegram Bot API
+// @Description Принимает обновление бота. Ошибка обработки возвращает 500, и Telegram повторит доставку
+// @Tags telegram
+// @Accept json
+// @Produce json
+// @Param X-Telegram-Bot-Api-Secret-Token header string true "Секрет вебхука"
+// @Success 200 {object} dto.BaseResponse{data=dto.InboundResultResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 401 {object} dto.BaseResponse
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/telegram/webhook [post]
+func (h *TelegramHandler) ReceiveUpdate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader(telegramSecretHeader)), []byte(h.secret)) != 1 {
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("INVALID_SECRET", "Неверный секрет вебхука", ""))
+		return
+	}
+	// Секрет подтверждает отправителя: сообщение обрабатывается от имени системы
+	ctx = ports.WithSystemPrincipal(ctx)
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxInboundPayloadSize))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.NewErrorResponse(
+			"PAYLOAD_TOO_LARGE",
+			"Слишком большой запрос",
+			err.Error(),
+		))
+		return
+	}
+
+	message, ok, err := telegram.ParseUpdate(body)
+	if err != nil {
+		h.logger.Warn(ctx, "Invalid telegram update", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_UPDATE", "Неверный формат обновления", err.Error()))
+		return
+	}
+	if !ok {
+		// Обновления без сообщений клиента подтверждаются, чтобы Telegram не повторял их
+		c.JSON(http.StatusOK, dto.NewSuccessResponse(nil))
+		return
+	}
+
+	result, err := h.telegramService.HandleMessage(ctx, message)
+	if errors.Is(err, domain.ErrInboundDeliveryInProgress) {
+		// То же сообщение обрабатывается параллельной доставкой; Telegram повторит обновление
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("TELEGRAM_IN_PROGRESS", "Сообщение уже обрабатывается", err.Error()))
+		return
+	}
+	if err != nil {
+		h.logger.Error(ctx, "Failed to process telegram message",
+			"chat_id", message.ChatID, "message_id", message.MessageID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"TELEGRAM_PROCESSING_FAILED",
+			"Не удалось обработать сообщение",
+			err.Error(),
+		))
+		return
+	}
+
+	response := dto.InboundResultResponse{
+		Action:     string(result.Action),
+		TaskID:     result.Task.ID,
+		TaskStatus: result.Task.Status,
+	}
+	if result.Task.CustomerID != nil {
+		response.CustomerID = *result.Task.CustomerID
+	}
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(response))
+}
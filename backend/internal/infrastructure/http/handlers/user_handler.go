@@ -0,0 +1,471 @@
+// internal/infrastructure/http/handlers/user_handler.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler управляет пользователями: приглашение, роли, профиль, распределение и отсутствие
+type UserHandler struct {
+	userService ports.UserService
+	logger      ports.Logger
+}
+
+func NewUserHandler(userService ports.UserService, logger ports.Logger) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+// ListUsers возвращает пользователей
+// @Summary Список пользователей
+// @Description Пользователи, упорядоченные по имени, с фильтрами по роли, статусу, навыку и категории
+// @Tags users
+// @Produce json
+// @Param role query string false "Роль" Enums(admin, manager, operator, viewer)
+// @Param status query string false "Статус" Enums(invited, active, deactivated)
+// @Param skill query string false "Навык"
+// @Param category query string false "Категория задач"
+// @Param available query bool false "Только доступные (true) или недоступные (false) для назначения"
+// @Success 200 {object} dto.BaseResponse{data=[]dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.UserListQuery
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	users, err := h.userService.ListUsers(ctx, ports.UserQuery{
+		Role:      domain.UserRole(req.Role),
+		Status:    domain.UserStatus(req.Status),
+		Skill:     req.Skill,
+		Category:  req.Category,
+		Available: req.Available,
+	})
+	if err != nil {
+		h.respondUserError(c, "Failed to list users", err)
+		return
+	}
+
+	now := time.Now()
+	responses := make([]dto.UserResponse, len(users))
+	for i := range users {
+		responses[i] = toUserResponse(&users[i], now)
+	}
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// CreateUser создает или приглашает пользователя
+// @Summary Создать пользователя
+// @Description С паролем пользователь сразу активен. Без пароля создается приглашение: токен возвращается только в этом ответе
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateUserRequest true "Данные пользователя"
+// @Success 201 {object} dto.BaseResponse{data=dto.InvitedUserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 409 {object} dto.BaseResponse
+// @Router /api/users [post]
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.CreateUserRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	user, token, err := h.userService.CreateUser(ctx, ports.CreateUserRequest{
+		Email:      req.Email,
+		Name:       req.Name,
+		Role:       domain.UserRole(req.Role),
+		Password:   req.Password,
+		Timezone:   req.Timezone,
+		Language:   domain.TemplateLanguage(req.Language),
+		Skills:     req.Skills,
+		Categories: req.Categories,
+	})
+	if err != nil {
+		h.respondUserError(c, "Failed to create user", err)
+		return
+	}
+
+	h.logger.Info(ctx, "User created", "user_id", user.ID, "invited", token != "")
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(toInvitedUserResponse(user, token)))
+}
+
+// AcceptInvitation принимает приглашение
+// @Summary Принять приглашение
+// @Description Задает пароль по токену приглашения и активирует пользователя. Не требует аутентификации
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptInvitationRequest true "Токен приглашения и пароль"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/users/invitations/accept [post]
+func (h *UserHandler) AcceptInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.AcceptInvitationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	user, err := h.userService.AcceptInvitation(ctx, req.Token, req.Password)
+	if err != nil {
+		h.respondUserError(c, "Failed to accept invitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// GetUser возвращает пользователя
+// @Summary Пользователь
+// @Tags users
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/users/{id} [get]
+func (h *UserHandler) GetUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, err := h.userService.GetUser(ctx, c.Param("id"))
+	if err != nil {
+		h.respondUserError(c, "Failed to get user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// UpdateProfile обновляет профиль пользователя
+// @Summary Обновить профиль
+// @Description Имя, часовой пояс, язык и подпись. Пользователь может менять свой профиль
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Param request body dto.UpdateUserProfileRequest true "Профиль"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/users/{id} [put]
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.UpdateUserProfileRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(ctx, c.Param("id"), ports.UpdateUserProfileRequest{
+		Name:      req.Name,
+		Timezone:  req.Timezone,
+		Language:  domain.TemplateLanguage(req.Language),
+		Signature: req.Signature,
+	})
+	if err != nil {
+		h.respondUserError(c, "Failed to update user profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// ChangeRole меняет роль пользователя
+// @Summary Сменить роль
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Param request body dto.ChangeUserRoleRequest true "Роль"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Failure 409 {object} dto.BaseResponse
+// @Router /api/users/{id}/role [put]
+func (h *UserHandler) ChangeRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.ChangeUserRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	user, err := h.userService.ChangeRole(ctx, c.Param("id"), domain.UserRole(req.Role))
+	if err != nil {
+		h.respondUserError(c, "Failed to change user role", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// SetRouting задает навыки и категории пользователя
+// @Summary Навыки и категории
+// @Description Навыки и категории задач, по которым пользователю распределяются задачи. Категории также ограничивают видимость задач оператора
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Param request body dto.UpdateUserRoutingRequest true "Навыки и категории"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/users/{id}/routing [put]
+func (h *UserHandler) SetRouting(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.UpdateUserRoutingRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	user, err := h.userService.SetRouting(ctx, c.Param("id"), req.Skills, req.Categories)
+	if err != nil {
+		h.respondUserError(c, "Failed to update user routing", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// SetOutOfOffice задает период отсутствия
+// @Summary Отсутствие
+// @Description Во время отсутствия пользователь не получает задачи автоматически. Пользователь может задать свое отсутствие
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Param request body dto.OutOfOfficeRequest true "Период отсутствия"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/users/{id}/out-of-office [put]
+func (h *UserHandler) SetOutOfOffice(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.OutOfOfficeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	from := time.Now()
+	if req.From != nil {
+		from = *req.From
+	}
+	user, err := h.userService.SetOutOfOffice(ctx, c.Param("id"), &domain.OutOfOffice{
+		From:    from,
+		Until:   req.Until,
+		Message: req.Message,
+	})
+	if err != nil {
+		h.respondUserError(c, "Failed to set out of office", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// ClearOutOfOffice отменяет отсутствие
+// @Summary Отменить отсутствие
+// @Tags users
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/users/{id}/out-of-office [delete]
+func (h *UserHandler) ClearOutOfOffice(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, err := h.userService.SetOutOfOffice(ctx, c.Param("id"), nil)
+	if err != nil {
+		h.respondUserError(c, "Failed to clear out of office", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// Deactivate отключает пользователя
+// @Summary Отключить пользователя
+// @Description Отключенный пользователь не может войти и получать задачи; назначенные задачи остаются за ним
+// @Tags users
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Failure 409 {object} dto.BaseResponse
+// @Router /api/users/{id}/deactivate [post]
+func (h *UserHandler) Deactivate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, err := h.userService.Deactivate(ctx, c.Param("id"))
+	if err != nil {
+		h.respondUserError(c, "Failed to deactivate user", err)
+		return
+	}
+
+	h.logger.Info(ctx, "User deactivated", "user_id", user.ID, "by", currentUserID(c))
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// Reactivate возвращает отключенного пользователя
+// @Summary Вернуть пользователя
+// @Description Пользователь, не принявший приглашение, снова становится приглашенным
+// @Tags users
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Success 200 {object} dto.BaseResponse{data=dto.UserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/users/{id}/reactivate [post]
+func (h *UserHandler) Reactivate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, err := h.userService.Reactivate(ctx, c.Param("id"))
+	if err != nil {
+		h.respondUserError(c, "Failed to reactivate user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toUserResponse(user, time.Now())))
+}
+
+// ResendInvitation выдает новое приглашение
+// @Summary Повторить приглашение
+// @Description Новый токен приглашения заменяет прежний и возвращается только в этом ответе
+// @Tags users
+// @Produce json
+// @Param id path string true "ID пользователя"
+// @Success 200 {object} dto.BaseResponse{data=dto.InvitedUserResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/users/{id}/invitation [post]
+func (h *UserHandler) ResendInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, token, err := h.userService.ResendInvitation(ctx, c.Param("id"))
+	if err != nil {
+		h.respondUserError(c, "Failed to resend invitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toInvitedUserResponse(user, token)))
+}
+
+func (h *UserHandler) respondUserError(c *gin.Context, message string, err error) {
+	if abortForbidden(c, err) {
+		return
+	}
+	ctx := c.Request.Context()
+	h.logger.Warn(ctx, message, "error", err.Error())
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("USER_NOT_FOUND", "Пользователь не найден", err.Error()))
+	case errors.Is(err, domain.ErrUserExists):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("USER_EXISTS", "Пользователь с таким email уже существует", err.Error()))
+	case errors.Is(err, domain.ErrLastAdmin):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("LAST_ADMIN", "Нужен хотя бы один активный администратор", err.Error()))
+	case errors.Is(err, domain.ErrInvalidInvitation):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_INVITATION", "Приглашение недействительно или истекло", err.Error()))
+	case errors.Is(err, domain.ErrPasswordTooShort):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_PASSWORD", "Слишком короткий пароль", err.Error()))
+	default:
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("USER_OPERATION_FAILED", "Не удалось выполнить операцию с пользователем", err.Error()))
+	}
+}
+
+func toUserResponse(user *domain.User, now time.Time) dto.UserResponse {
+	response := dto.UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		Role:          user.Role,
+		Status:        user.Status,
+		Available:     user.IsAvailable(now),
+		Timezone:      user.Timezone,
+		Language:      string(user.Language),
+		Signature:     user.Signature,
+		Skills:        nonNilStrings(user.Skills),
+		Categories:    nonNilStrings(user.Categories),
+		DeactivatedAt: user.DeactivatedAt,
+	}
+	if !user.CreatedAt.IsZero() {
+		response.CreatedAt = &user.CreatedAt
+	}
+	if user.OutOfOffice != nil {
+		response.OutOfOffice = &dto.OutOfOfficeResponse{
+			From:    user.OutOfOffice.From,
+			Until:   user.OutOfOffice.Until,
+			Message: user.OutOfOffice.Message,
+			Active:  user.OutOfOffice.IsActive(now),
+		}
+	}
+	return response
+}
+
+func toInvitedUserResponse(user *domain.User, token string) dto.InvitedUserResponse {
+	response := dto.InvitedUserResponse{UserResponse: toUserResponse(user, time.Now())}
+	if token != "" {
+		response.InvitationToken = token
+		response.InvitationExpiresAt = user.InvitationExpiresAt
+	}
+	return response
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,372 @@
+// internal/infrastructure/http/handlers/webhook_handler.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultWebhookDeliveryPageSize размер страницы журнала доставок по умолчанию
+const defaultWebhookDeliveryPageSize = 50
+
+// WebhookHandler управляет подписками на вебхуки и журналом доставок
+type WebhookHandler struct {
+	webhookService ports.WebhookService
+	logger         ports.Logger
+}
+
+func NewWebhookHandler(webhookService ports.WebhookService, logger ports.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// ListWebhooks возвращает подписки
+// @Summary Список вебхуков
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} dto.BaseResponse{data=[]dto.WebhookResponse}
+// @Failure 500 {object} dto.BaseResponse
+// @Router /api/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	subscriptions, err := h.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to list webhooks", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+			"WEBHOOKS_FETCH_FAILED",
+			"Не удалось получить вебхуки",
+			err.Error(),
+		))
+		return
+	}
+
+	responses := make([]dto.WebhookResponse, len(subscriptions))
+	for i := range subscriptions {
+		responses[i] = toWebhookResponse(&subscriptions[i], false)
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// CreateWebhook создает подписку
+// @Summary Создать вебхук
+// @Description Подписка на доменные события с фильтрами; секрет подписи возвращается только в этом ответе
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body dto.WebhookRequest true "Подписка"
+// @Success 201 {object} dto.BaseResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Router /api/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req dto.WebhookRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid create webhook request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(ctx, ports.CreateWebhookRequest{
+		Name:        req.Name,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		TaskTypes:   req.TaskTypes,
+		CustomerIDs: req.CustomerIDs,
+		CreatedBy:   currentUserID(c),
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.logger.Error(ctx, "Failed to create webhook", "url", req.URL, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"WEBHOOK_CREATION_FAILED",
+			"Не удалось создать вебхук",
+			err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(toWebhookResponse(subscription, true)))
+}
+
+// GetWebhook возвращает подписку
+// @Summary Получить вебхук
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID вебхука"
+// @Success 200 {object} dto.BaseResponse{data=dto.WebhookResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "WEBHOOK_FETCH_FAILED", "Не удалось получить вебхук")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toWebhookResponse(subscription, false)))
+}
+
+// UpdateWebhook изменяет подписку
+// @Summary Изменить вебхук
+// @Description Изменяет адрес, секрет, фильтры или включает/отключает подписку
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "ID вебхука"
+// @Param request body dto.UpdateWebhookRequest true "Изменения"
+// @Success 200 {object} dto.BaseResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	var req dto.UpdateWebhookRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn(ctx, "Invalid update webhook request", "webhook_id", id, "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверный формат запроса",
+			err.Error(),
+		))
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(ctx, id, ports.UpdateWebhookRequest{
+		Name:        req.Name,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		TaskTypes:   req.TaskTypes,
+		CustomerIDs: req.CustomerIDs,
+		Active:      req.Active,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "WEBHOOK_UPDATE_FAILED", "Не удалось обновить вебхук")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toWebhookResponse(subscription, false)))
+}
+
+// DeleteWebhook удаляет подписку
+// @Summary Удалить вебхук
+// @Tags webhooks
+// @Param id path string true "ID вебхука"
+// @Success 204
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), c.Param("id")); err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "WEBHOOK_DELETE_FAILED", "Не удалось удалить вебхук")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SendTestEvent отправляет тестовое событие
+// @Summary Отправить тестовое событие
+// @Description Синхронно отправляет подписчику событие webhook.test и возвращает результат попытки
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID вебхука"
+// @Success 200 {object} dto.BaseResponse{data=dto.WebhookDeliveryResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/webhooks/{id}/test [post]
+func (h *WebhookHandler) SendTestEvent(c *gin.Context) {
+	delivery, err := h.webhookService.SendTestEvent(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "WEBHOOK_TEST_FAILED", "Не удалось отправить тестовое событие")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toWebhookDeliveryResponse(delivery)))
+}
+
+// ListDeliveries возвращает журнал доставок подписки
+// @Summary Журнал доставок вебхука
+// @Description Доставки с журналом попыток; новые первыми
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID вебхука"
+// @Param status query string false "Статус" Enums(pending, succeeded, dead_letter)
+// @Param page query int false "Номер страницы"
+// @Param page_size query int false "Размер страницы"
+// @Success 200 {object} dto.BaseResponse{data=dto.WebhookDeliveryListResponse}
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	var req dto.WebhookDeliveryQueryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+			"INVALID_REQUEST",
+			"Неверные параметры запроса",
+			err.Error(),
+		))
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PageSize == 0 {
+		req.PageSize = defaultWebhookDeliveryPageSize
+	}
+
+	result, err := h.webhookService.ListDeliveries(c.Request.Context(), ports.WebhookDeliveryQuery{
+		SubscriptionID: c.Param("id"),
+		Status:         domain.WebhookDeliveryStatus(req.Status),
+		Offset:         (req.Page - 1) * req.PageSize,
+		Limit:          req.PageSize,
+	})
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "WEBHOOK_DELIVERIES_FETCH_FAILED", "Не удалось получить журнал доставок")
+		return
+	}
+
+	deliveries := make([]dto.WebhookDeliveryResponse, len(result.Deliveries))
+	for i := range result.Deliveries {
+		deliveries[i] = toWebhookDeliveryResponse(&result.Deliveries[i])
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		Pagination: dto.PageInfo{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalCount: result.TotalCount,
+			TotalPages: (result.TotalCount + req.PageSize - 1) / req.PageSize,
+		},
+	}))
+}
+
+// RetryDelivery повторяет доставку из dead letter
+// @Summary Повторить доставку
+// @Description Возвращает доставку из dead letter в очередь
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "ID вебхука"
+// @Param deliveryId path string true "ID доставки"
+// @Success 200 {object} dto.BaseResponse{data=dto.WebhookDeliveryResponse}
+// @Failure 400 {object} dto.BaseResponse
+// @Failure 404 {object} dto.BaseResponse
+// @Router /api/webhooks/{id}/deliveries/{deliveryId}/retry [post]
+func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
+	delivery, err := h.webhookService.RetryDelivery(c.Request.Context(), c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		if abortForbidden(c, err) {
+			return
+		}
+		h.respondError(c, err, "WEBHOOK_RETRY_FAILED", "Не удалось повторить доставку")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(toWebhookDeliveryResponse(delivery)))
+}
+
+// respondError отвечает 404 для отсутствующих подписки и доставки, иначе 400
+func (h *WebhookHandler) respondError(c *gin.Context, err error, code, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("WEBHOOK_NOT_FOUND", "Вебхук не найден", err.Error()))
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("WEBHOOK_DELIVERY_NOT_FOUND", "Доставка не найдена", err.Error()))
+	default:
+		h.logger.Error(ctx, message, "webhook_id", c.Param("id"), "error", err.Error())
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse(code, message, err.Error()))
+	}
+}
+
+func toWebhookResponse(subscription *domain.WebhookSubscription, withSecret bool) dto.WebhookResponse {
+	response := dto.WebhookResponse{
+		ID:          subscription.ID,
+		Name:        subscription.Name,
+		URL:         subscription.URL,
+		EventTypes:  subscription.EventTypes,
+		TaskTypes:   subscription.TaskTypes,
+		CustomerIDs: subscription.CustomerIDs,
+		Active:      subscription.Active,
+		CreatedBy:   subscription.CreatedBy,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+	if withSecret {
+		response.Secret = subscription.Secret
+	}
+	if response.EventTypes == nil {
+		response.EventTypes = []domain.DomainEventType{}
+	}
+	if response.TaskTypes == nil {
+		response.TaskTypes = []domain.TaskType{}
+	}
+	if response.CustomerIDs == nil {
+		response.CustomerIDs = []string{}
+	}
+	return response
+}
+
+func toWebhookDeliveryResponse(delivery *domain.WebhookDelivery) dto.WebhookDeliveryResponse {
+	response := dto.WebhookDeliveryResponse{
+		ID:          delivery.ID,
+		WebhookID:   delivery.SubscriptionID,
+		EventID:     delivery.EventID,
+		EventType:   delivery.EventType,
+		Status:      string(delivery.Status),
+		Attempts:    make([]dto.WebhookAttemptResponse, len(delivery.Attempts)),
+		DeliveredAt: delivery.DeliveredAt,
+		Payload:     delivery.Payload,
+		CreatedAt:   delivery.CreatedAt,
+		UpdatedAt:   delivery.UpdatedAt,
+	}
+	for i, attempt := range delivery.Attempts {
+		response.Attempts[i] = dto.WebhookAttemptResponse{
+			At:         attempt.At,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.Duration.Milliseconds(),
+		}
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	return response
+}
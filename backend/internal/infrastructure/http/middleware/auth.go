@@ -0,0 +1,117 @@
+// internal/infrastructure/http/middleware/auth.go
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader альтернативный заголовок для ключа API
+const APIKeyHeader = "X-API-Key"
+
+// StreamTokenParam параметр адреса с токеном потока изменений
+const StreamTokenParam = "stream_token"
+
+// AuthMiddleware требует access-токен или ключ API и помещает пользователя в context.
+// Маршруты с префиксами publicRoutes (шаблоны gin, например /api/v1/public/)
+// доступны без аутентификации: они защищены подписью или секретом
+func AuthMiddleware(authService ports.AuthService, logger ports.Logger, publicRoutes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		for _, prefix := range publicRoutes {
+			if strings.HasPrefix(route, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		ctx := c.Request.Context()
+		credential := bearerToken(c.GetHeader("Authorization"))
+		if credential == "" {
+			credential = strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		}
+		if credential == "" {
+			abortUnauthorized(c, domain.ErrUnauthenticated)
+			return
+		}
+
+		var user *domain.User
+		var err error
+		if strings.HasPrefix(credential, domain.APIKeyPrefix) {
+			user, err = authService.AuthenticateAPIKey(ctx, credential)
+		} else {
+			user, err = authService.AuthenticateToken(ctx, credential)
+		}
+		if err != nil {
+			logger.Warn(ctx, "Authentication failed",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"error", err.Error())
+			abortUnauthorized(c, domain.ErrInvalidToken)
+			return
+		}
+
+		c.Request = c.Request.WithContext(ports.WithAuthenticatedUser(ctx, user))
+		c.Next()
+	}
+}
+
+// StreamAuthMiddleware аутентифицирует подключение к потоку изменений. Браузерный
+// EventSource не передает заголовки, поэтому кроме заголовков, как в AuthMiddleware,
+// принимается токен потока в параметре stream_token. Access-токен и ключ API
+// в адресе не принимаются: адреса попадают в журналы прокси и историю браузера.
+// Маршрут потока должен входить в publicRoutes общего AuthMiddleware
+func StreamAuthMiddleware(authService ports.AuthService, logger ports.Logger) gin.HandlerFunc {
+	authenticateHeaders := AuthMiddleware(authService, logger)
+	return func(c *gin.Context) {
+		streamToken := strings.TrimSpace(c.Query(StreamTokenParam))
+		if streamToken == "" {
+			authenticateHeaders(c)
+			return
+		}
+
+		ctx := c.Request.Context()
+		user, err := authService.AuthenticateStreamToken(ctx, streamToken)
+		if err != nil {
+			logger.Warn(ctx, "Stream authentication failed",
+				"path", c.Request.URL.Path,
+				"error", err.Error())
+			abortUnauthorized(c, domain.ErrInvalidToken)
+			return
+		}
+
+		c.Request = c.Request.WithContext(ports.WithAuthenticatedUser(ctx, user))
+		c.Next()
+	}
+}
+
+// SystemPrincipal выполняет запросы от имени системы. Используется только
+// при отключенной аутентификации, когда API доступен без учетных записей
+func SystemPrincipal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(ports.WithSystemPrincipal(c.Request.Context()))
+		c.Next()
+	}
+}
+
+func bearerToken(header string) string {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func abortUnauthorized(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer realm="urms"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, dto.NewErrorResponse(
+		"UNAUTHORIZED",
+		"Требуется аутентификация",
+		err.Error(),
+	))
+}
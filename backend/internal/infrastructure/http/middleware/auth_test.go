@@ -0,0 +1,75 @@
+// internal/infrastructure/http/middleware/auth_test.go
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/auth"
+	"github.com/audetv/urms/internal/infrastructure/http/middleware"
+	authinmemory "github.com/audetv/urms/internal/infrastructure/persistence/auth/inmemory"
+	"github.com/audetv/urms/internal/infrastructure/persistence/task/inmemory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestStreamAuthMiddleware_QueryAcceptsOnlyStreamToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	logger := &services.MockLogger{}
+	signer, err := auth.NewJWTSigner("0123456789abcdef0123456789abcdef", "urms")
+	require.NoError(t, err)
+	authService := services.NewAuthService(
+		inmemory.NewUserRepository(logger),
+		authinmemory.NewAPIKeyRepository(logger),
+		auth.NewBcryptHasher(bcrypt.MinCost),
+		signer,
+		services.AuthConfig{AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: 24 * time.Hour},
+		logger,
+	)
+	require.NoError(t, authService.SetPassword(ctx, "user-1", "correct-horse"))
+	tokens, err := authService.Login(ctx, "admin@company.com", "correct-horse")
+	require.NoError(t, err)
+	streamToken, _, err := authService.IssueStreamToken(ctx, "user-1")
+	require.NoError(t, err)
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	api.Use(middleware.AuthMiddleware(authService, logger, "/api/v1/stream"))
+	api.GET("/stream", middleware.StreamAuthMiddleware(authService, logger), func(c *gin.Context) {
+		user, _ := ports.AuthenticatedUser(c.Request.Context())
+		c.String(http.StatusOK, user.ID)
+	})
+	api.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(target, authorization string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, target, nil)
+		if authorization != "" {
+			request.Header.Set("Authorization", "Bearer "+authorization)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	viaQuery := send("/api/v1/stream?"+middleware.StreamTokenParam+"="+streamToken, "")
+	assert.Equal(t, http.StatusOK, viaQuery.Code)
+	assert.Equal(t, "user-1", viaQuery.Body.String())
+
+	viaHeader := send("/api/v1/stream", tokens.AccessToken)
+	assert.Equal(t, http.StatusOK, viaHeader.Code)
+
+	assert.Equal(t, http.StatusUnauthorized, send("/api/v1/stream", "").Code)
+	assert.Equal(t, http.StatusUnauthorized,
+		send("/api/v1/stream?"+middleware.StreamTokenParam+"="+tokens.AccessToken, "").Code,
+		"access token is not accepted in the URL")
+	assert.Equal(t, http.StatusUnauthorized, send("/api/v1/tasks", streamToken).Code,
+		"stream token does not grant access to the rest of the API")
+}
@@ -2,21 +2,17 @@
 package middleware
 
 import (
-	"context"
-
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
 	"github.com/gin-gonic/gin"
 )
 
 // ContextMiddleware добавляет дополнительные поля в контекст
 func ContextMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Добавляем базовую информацию в контекст
-		ctx := c.Request.Context()
-
-		// Можно добавить аутентификацию пользователя и т.д.
-		// Пока используем "system" как дефолтного пользователя
-		ctx = context.WithValue(ctx, "user_id", "system")
-		ctx = context.WithValue(ctx, "user_role", "system")
+		// Пользователя запроса добавляют Auth или SystemPrincipal.
+		// Изменения через REST API помечаются источником api в журнале аудита
+		ctx := ports.WithAuditSource(c.Request.Context(), domain.AuditSourceAPI)
 
 		c.Request = c.Request.WithContext(ctx)
 		c.Next()
@@ -2,6 +2,10 @@
 package middleware
 
 import (
+	"errors"
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
 	"github.com/audetv/urms/internal/core/ports"
 	"github.com/audetv/urms/internal/infrastructure/http/dto"
 	"github.com/gin-gonic/gin"
@@ -19,6 +23,23 @@ func ErrorHandlerMiddleware(logger ports.Logger) gin.HandlerFunc {
 			// Берем последнюю ошибку
 			err := c.Errors.Last().Err
 
+			// Отказ сервиса в доступе: обработчик передает его сюда без ответа
+			if errors.Is(err, domain.ErrForbidden) {
+				logger.Warn(ctx, "Access denied",
+					"error", err.Error(),
+					"method", c.Request.Method,
+					"path", c.Request.URL.Path,
+				)
+				if !c.Writer.Written() {
+					c.JSON(http.StatusForbidden, dto.NewErrorResponse(
+						"FORBIDDEN",
+						"Недостаточно прав для выполнения операции",
+						err.Error(),
+					))
+				}
+				return
+			}
+
 			logger.Error(ctx, "HTTP request error",
 				"error", err.Error(),
 				"method", c.Request.Method,
@@ -0,0 +1,198 @@
+// internal/infrastructure/http/middleware/idempotency.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader заголовок с ключом идемпотентности запроса
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader помечает ответ, повторенный из хранилища
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// idempotencyRetryAfter через сколько секунд клиенту повторить запрос, пока первый выполняется
+const idempotencyRetryAfter = 1
+
+// replayedHeaders заголовки ответа, которые сохраняются и повторяются вместе с телом
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency повторяет первый ответ на запрос с заголовком Idempotency-Key.
+// Ключ действует в пределах пользователя, метода и маршрута; тот же ключ с другим
+// телом запроса отклоняется с 422, а дубль, пришедший до завершения первого запроса, - с 409:
+// блокировка ключа продлевается, пока обработчик работает.
+// Ответы 5xx не сохраняются: после ошибки сервера запрос можно повторить с тем же ключом.
+// Запросы без заголовка выполняются как обычно
+func Idempotency(service ports.IdempotencyService, logger ports.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := domain.ValidateIdempotencyKey(idempotencyKey); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"INVALID_IDEMPOTENCY_KEY",
+				"Некорректный заголовок Idempotency-Key",
+				err.Error(),
+			))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, dto.NewErrorResponse(
+				"INVALID_REQUEST",
+				"Не удалось прочитать тело запроса",
+				err.Error(),
+			))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		key := scopedIdempotencyKey(ctx, c.Request.Method, c.FullPath(), idempotencyKey)
+		record, err := service.Begin(ctx, key, requestHash(body))
+		switch {
+		case errors.Is(err, domain.ErrIdempotencyKeyReused):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, dto.NewErrorResponse(
+				"IDEMPOTENCY_KEY_REUSED",
+				"Ключ идемпотентности уже использован с другим телом запроса",
+				err.Error(),
+			))
+			return
+		case errors.Is(err, domain.ErrIdempotencyRequestInProgress):
+			c.Header("Retry-After", strconv.Itoa(idempotencyRetryAfter))
+			c.AbortWithStatusJSON(http.StatusConflict, dto.NewErrorResponse(
+				"IDEMPOTENCY_IN_PROGRESS",
+				"Запрос с этим ключом идемпотентности еще выполняется",
+				err.Error(),
+			))
+			return
+		case err != nil:
+			// Без хранилища повтор мог бы создать дубль, поэтому запрос не выполняется
+			logger.Error(ctx, "Idempotency check failed",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, dto.NewErrorResponse(
+				"INTERNAL_ERROR",
+				"Не удалось проверить ключ идемпотентности",
+				err.Error(),
+			))
+			return
+		case record != nil:
+			for name, value := range record.ResponseHeaders {
+				c.Header(name, value)
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Status(record.StatusCode)
+			_, _ = c.Writer.Write(record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		// Результат сохраняется и после отмены запроса клиентом: обработчик мог успеть изменить данные
+		storeCtx := context.WithoutCancel(ctx)
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		// Блокировка продлевается, пока обработчик работает, поэтому долгий запрос
+		// не теряет ключ и дубль получает 409, а не выполняется второй раз
+		stopKeepAlive := service.KeepAlive(ctx, key)
+		completed := false
+		defer func() {
+			stopKeepAlive()
+			if !completed {
+				// Паника в обработчике: ключ освобождается, паника передается recovery
+				if err := service.Abort(storeCtx, key); err != nil {
+					logger.Error(storeCtx, "Failed to release idempotency key", "error", err.Error())
+				}
+			}
+		}()
+
+		c.Next()
+		completed = true
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			if err := service.Abort(storeCtx, key); err != nil {
+				logger.Error(storeCtx, "Failed to release idempotency key", "error", err.Error())
+			}
+			return
+		}
+
+		headers := make(map[string]string)
+		for _, name := range replayedHeaders {
+			if value := writer.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		if err := service.Complete(storeCtx, key, status, headers, writer.body.Bytes()); err != nil {
+			logger.Error(storeCtx, "Failed to store idempotent response",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"error", err.Error())
+		}
+	}
+}
+
+// scopedIdempotencyKey ограничивает ключ клиента пользователем, методом и маршрутом,
+// чтобы одинаковые ключи разных клиентов не пересекались
+func scopedIdempotencyKey(ctx context.Context, method, route, key string) string {
+	userID := "anonymous"
+	if user, ok := ports.AuthenticatedUser(ctx); ok {
+		userID = user.ID
+	}
+
+	sum := sha256.Sum256([]byte(userID + "\n" + method + "\n" + route + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestHash хэш тела запроса. JSON приводится к каноническому виду, поэтому
+// повтор с другим порядком полей или форматированием считается тем же запросом
+func requestHash(body []byte) string {
+	canonical := body
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err == nil && !decoder.More() {
+		if encoded, err := json.Marshal(value); err == nil {
+			canonical = encoded
+		}
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// capturingWriter копирует тело ответа для сохранения
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,60 @@
+// internal/infrastructure/http/middleware/idempotency_test.go
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/audetv/urms/internal/core/services"
+	"github.com/audetv/urms/internal/infrastructure/http/middleware"
+	idempotencyinmemory "github.com/audetv/urms/internal/infrastructure/persistence/idempotency/inmemory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency_SlowHandlerKeepsKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := &services.MockLogger{}
+	lockTimeout := 30 * time.Millisecond
+	service := services.NewIdempotencyService(idempotencyinmemory.NewIdempotencyStore(logger),
+		services.IdempotencyConfig{TTL: time.Hour, LockTimeout: lockTimeout}, logger)
+
+	var executions atomic.Int32
+	started := make(chan struct{})
+	router := gin.New()
+	router.POST("/tasks", middleware.Idempotency(service, logger), func(c *gin.Context) {
+		if executions.Add(1) == 1 {
+			close(started)
+		}
+		// Обработчик работает в несколько раз дольше блокировки ключа
+		time.Sleep(5 * lockTimeout)
+		c.JSON(http.StatusCreated, gin.H{"id": "task-1"})
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"subject":"x"}`))
+		request.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- send() }()
+	<-started
+
+	// Блокировка первого запроса уже истекла бы без продления
+	time.Sleep(2 * lockTimeout)
+	duplicate := send()
+	assert.Equal(t, http.StatusConflict, duplicate.Code, "duplicate does not take over the key of a running request")
+
+	assert.Equal(t, http.StatusCreated, (<-first).Code)
+	replayed := send()
+	assert.Equal(t, http.StatusCreated, replayed.Code)
+	assert.Equal(t, "true", replayed.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, int32(1), executions.Load(), "handler runs once")
+}
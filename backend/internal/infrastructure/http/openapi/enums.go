@@ -0,0 +1,73 @@
+// internal/infrastructure/http/openapi/enums.go
+package openapi
+
+import (
+	"reflect"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// enumValues допустимые значения строковых перечислений домена; поля этих типов
+// описываются ссылкой на именованную схему с enum
+var enumValues = map[reflect.Type][]string{
+	reflect.TypeOf(domain.TaskStatus("")): enumOf(
+		domain.TaskStatusOpen, domain.TaskStatusInProgress, domain.TaskStatusReview,
+		domain.TaskStatusResolved, domain.TaskStatusClosed, domain.TaskStatusCancelled,
+	),
+	reflect.TypeOf(domain.Priority("")): enumOf(
+		domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityCritical,
+	),
+	reflect.TypeOf(domain.TaskType("")): enumOf(
+		domain.TaskTypeSupport, domain.TaskTypeInternal, domain.TaskTypeSubTask,
+	),
+	reflect.TypeOf(domain.TaskSource("")): enumOf(
+		domain.SourceEmail, domain.SourceTelegram, domain.SourceWebForm,
+		domain.SourceAPI, domain.SourceInternal, domain.SourceGitHub,
+	),
+	reflect.TypeOf(domain.MessageType("")): enumOf(
+		domain.MessageTypeCustomer, domain.MessageTypeInternal, domain.MessageTypeSystem,
+	),
+	reflect.TypeOf(domain.ParticipantRole("")): enumOf(
+		domain.RoleReporter, domain.RoleAssignee, domain.RoleReviewer,
+		domain.RoleWatcher, domain.RoleParticipant,
+	),
+	reflect.TypeOf(domain.TaskLinkType("")): enumOf(
+		domain.LinkBlocks, domain.LinkBlockedBy, domain.LinkRelatesTo, domain.LinkDuplicateOf,
+		domain.LinkDuplicatedBy, domain.LinkCausedBy, domain.LinkCauses,
+	),
+	reflect.TypeOf(domain.CustomFieldType("")): enumOf(
+		domain.CustomFieldText, domain.CustomFieldNumber, domain.CustomFieldEnum,
+		domain.CustomFieldDate, domain.CustomFieldUser, domain.CustomFieldCustomer,
+	),
+	reflect.TypeOf(domain.TemplateLanguage("")): enumOf(
+		domain.TemplateLanguageRU, domain.TemplateLanguageEN,
+	),
+	reflect.TypeOf(domain.ScheduledReplyStatus("")): enumOf(
+		domain.ScheduledReplyPending, domain.ScheduledReplySent,
+		domain.ScheduledReplyCancelled, domain.ScheduledReplyFailed,
+	),
+	reflect.TypeOf(domain.TaskStreamEventType("")): enumOf(
+		domain.TaskStreamTaskCreated, domain.TaskStreamTaskUpdated,
+		domain.TaskStreamMessageAdded, domain.TaskStreamTaskDeleted,
+	),
+	reflect.TypeOf(domain.UserRole("")): enumOf(
+		domain.UserRoleAdmin, domain.UserRoleManager, domain.UserRoleOperator, domain.UserRoleViewer,
+	),
+	reflect.TypeOf(domain.UserStatus("")): enumOf(
+		domain.UserStatusInvited, domain.UserStatusActive, domain.UserStatusDeactivated,
+	),
+}
+
+func enumOf[T ~string](values ...T) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
+
+// enumFor значения перечисления для описания параметров
+func enumFor[T ~string]() []string {
+	var zero T
+	return enumValues[reflect.TypeOf(zero)]
+}
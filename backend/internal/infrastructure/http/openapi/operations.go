@@ -0,0 +1,393 @@
+// internal/infrastructure/http/openapi/operations.go
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+)
+
+// Заголовки условных запросов к задаче (см. handlers.VersionPrecondition)
+var (
+	ifMatchHeader = Param{
+		Name:        "If-Match",
+		In:          "header",
+		Description: "ETag задачи; при несовпадении версии возвращается 412",
+	}
+	ifNoneMatchHeader = Param{
+		Name:        "If-None-Match",
+		In:          "header",
+		Description: "ETag задачи; при совпадении возвращается 304 без тела",
+	}
+	languageQuery = Param{Name: "language", In: "query", Description: "Язык ответа", Enum: []string{"ru", "en"}}
+)
+
+// lastEventIDHeader ID последнего полученного события потока (см. handlers.StreamHandler)
+var lastEventIDHeader = Param{
+	Name:        "Last-Event-ID",
+	In:          "header",
+	Description: "Пропущенные после этого события повторяются из буфера",
+}
+
+// idempotencyKeyHeader ключ повтора запроса (см. middleware.Idempotency)
+var idempotencyKeyHeader = Param{
+	Name:        "Idempotency-Key",
+	In:          "header",
+	Description: "Уникальный ключ запроса; повтор с тем же ключом и телом возвращает первый ответ с заголовком Idempotent-Replayed",
+}
+
+// taskVersionResponses ответы изменяющих запросов к задаче при конфликте версий
+var taskVersionResponses = []int{http.StatusConflict, http.StatusPreconditionFailed}
+
+// versioned дополняет изменяющую операцию задачи заголовком If-Match и ответами конфликта версий
+func versioned(op Operation) Operation {
+	op.Params = append(op.Params, ifMatchHeader)
+	op.Responses = append(op.Responses, taskVersionResponses...)
+	return op
+}
+
+// idempotent дополняет операцию создания заголовком Idempotency-Key и ответами повторного
+// использования ключа: 409 - запрос с ключом еще выполняется, 422 - ключ использован с другим телом
+func idempotent(op Operation) Operation {
+	op.Params = append(op.Params, idempotencyKeyHeader)
+	op.Responses = append(op.Responses, http.StatusConflict, http.StatusUnprocessableEntity)
+	return op
+}
+
+// Operations описания всех маршрутов API, регистрируемых в setupGinRouter.
+// Тест маршрутизатора падает, если маршрут добавлен без описания
+func Operations() []Operation {
+	return []Operation{
+		// Authentication
+		{Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: "auth", Summary: "Вход",
+			Body: dto.LoginRequest{}, Response: dto.AuthTokensResponse{}, Responses: []int{http.StatusUnauthorized}, Public: true},
+		{Method: http.MethodPost, Path: "/api/v1/auth/refresh", Tag: "auth", Summary: "Обновить токены",
+			Body: dto.RefreshTokenRequest{}, Response: dto.AuthTokensResponse{}, Responses: []int{http.StatusUnauthorized}, Public: true},
+		{Method: http.MethodGet, Path: "/api/v1/auth/me", Tag: "auth", Summary: "Текущий пользователь",
+			Response: dto.UserResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/auth/password", Tag: "auth", Summary: "Сменить пароль",
+			Body: dto.ChangePasswordRequest{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/stream-token", Tag: "auth", Summary: "Токен потока изменений",
+			Description: "Токен действует минуту и принимается только в параметре stream_token потока изменений: " +
+				"браузерный EventSource не передает заголовок Authorization",
+			Response: dto.StreamTokenResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/auth/api-keys", Tag: "auth", Summary: "Ключи API",
+			Response: []dto.APIKeyResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/auth/api-keys", Tag: "auth", Summary: "Создать ключ API",
+			Body: dto.CreateAPIKeyRequest{}, Response: dto.CreatedAPIKeyResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodDelete, Path: "/api/v1/auth/api-keys/:id", Tag: "auth", Summary: "Отозвать ключ API"},
+
+		// Users
+		{Method: http.MethodGet, Path: "/api/v1/users", Tag: "users", Summary: "Список пользователей",
+			Query: dto.UserListQuery{}, Response: []dto.UserResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users", Tag: "users", Summary: "Создать пользователя",
+			Body: dto.CreateUserRequest{}, Response: dto.InvitedUserResponse{}, Status: http.StatusCreated, Responses: []int{http.StatusConflict}},
+		{Method: http.MethodPost, Path: "/api/v1/users/invitations/accept", Tag: "users", Summary: "Принять приглашение",
+			Body: dto.AcceptInvitationRequest{}, Response: dto.UserResponse{}, Public: true},
+		{Method: http.MethodGet, Path: "/api/v1/users/:id", Tag: "users", Summary: "Пользователь",
+			Response: dto.UserResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/users/:id", Tag: "users", Summary: "Обновить профиль",
+			Body: dto.UpdateUserProfileRequest{}, Response: dto.UserResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/users/:id/role", Tag: "users", Summary: "Сменить роль",
+			Body: dto.ChangeUserRoleRequest{}, Response: dto.UserResponse{}, Responses: []int{http.StatusConflict}},
+		{Method: http.MethodPut, Path: "/api/v1/users/:id/routing", Tag: "users", Summary: "Навыки и категории",
+			Body: dto.UpdateUserRoutingRequest{}, Response: dto.UserResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/users/:id/out-of-office", Tag: "users", Summary: "Отсутствие",
+			Body: dto.OutOfOfficeRequest{}, Response: dto.UserResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/:id/out-of-office", Tag: "users", Summary: "Отменить отсутствие",
+			Response: dto.UserResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/:id/deactivate", Tag: "users", Summary: "Отключить пользователя",
+			Response: dto.UserResponse{}, Responses: []int{http.StatusBadRequest, http.StatusConflict}},
+		{Method: http.MethodPost, Path: "/api/v1/users/:id/reactivate", Tag: "users", Summary: "Вернуть пользователя",
+			Response: dto.UserResponse{}, Responses: []int{http.StatusBadRequest}},
+		{Method: http.MethodPost, Path: "/api/v1/users/:id/invitation", Tag: "users", Summary: "Повторить приглашение",
+			Response: dto.InvitedUserResponse{}, Responses: []int{http.StatusBadRequest}},
+
+		// Task stream
+		{Method: http.MethodGet, Path: "/api/v1/stream", Tag: "stream", Summary: "Поток изменений задач",
+			Description: "Server-Sent Events: события task.created, task.updated, task.message_added и task.deleted " +
+				"с полем data указанной схемы; id события передается в Last-Event-ID при переподключении. " +
+				"Событие resync означает, что часть изменений пропущена и данные нужно перечитать. " +
+				"Браузерный EventSource передает вместо заголовка Authorization параметр stream_token " +
+				"(POST /api/v1/auth/stream-token); токен проверяется только при подключении, " +
+				"поэтому перед переподключением нужно получить новый",
+			Query: dto.TaskStreamQuery{}, Params: []Param{lastEventIDHeader},
+			Response: dto.TaskStreamEventResponse{}, ContentType: "text/event-stream"},
+
+		// Tasks
+		{Method: http.MethodGet, Path: "/api/v1/tasks", Tag: "tasks", Summary: "Список задач",
+			Description: "Помимо параметров поддерживаются компактные фильтры: status=open,in_progress, priority!=low, created>=2025-01-01",
+			Query:       dto.TaskSearchRequest{}, Response: dto.TaskListResponse{}},
+		idempotent(Operation{Method: http.MethodPost, Path: "/api/v1/tasks", Tag: "tasks", Summary: "Создать задачу",
+			Body: dto.CreateTaskRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated}),
+		idempotent(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/support", Tag: "tasks", Summary: "Создать задачу поддержки",
+			Body: dto.CreateSupportTaskRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated}),
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id", Tag: "tasks", Summary: "Получить задачу",
+			Params: []Param{ifNoneMatchHeader}, Response: dto.TaskResponse{}, Responses: []int{http.StatusNotModified}},
+		versioned(Operation{Method: http.MethodPut, Path: "/api/v1/tasks/:id", Tag: "tasks", Summary: "Обновить задачу",
+			Body: dto.UpdateTaskRequest{}, Response: dto.TaskResponse{}}),
+		versioned(Operation{Method: http.MethodDelete, Path: "/api/v1/tasks/:id", Tag: "tasks", Summary: "Удалить задачу",
+			Status: http.StatusNoContent}),
+		versioned(Operation{Method: http.MethodPut, Path: "/api/v1/tasks/:id/status", Tag: "tasks", Summary: "Изменить статус задачи",
+			Body: dto.ChangeStatusRequest{}, Response: dto.TaskResponse{}}),
+		versioned(Operation{Method: http.MethodPut, Path: "/api/v1/tasks/:id/assign", Tag: "tasks", Summary: "Назначить исполнителя",
+			Body: dto.AssignTaskRequest{}, Response: dto.TaskResponse{}}),
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id/messages", Tag: "tasks", Summary: "Получить сообщения задачи",
+			Response: []dto.MessageResponse{}},
+		idempotent(versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/messages", Tag: "tasks", Summary: "Добавить сообщение",
+			Body: dto.AddMessageRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated})),
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id/participants", Tag: "tasks", Summary: "Участники задачи",
+			Response: []dto.ParticipantResponse{}},
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/participants", Tag: "tasks", Summary: "Добавить участника",
+			Body: dto.AddParticipantRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated}),
+		versioned(Operation{Method: http.MethodPut, Path: "/api/v1/tasks/:id/participants/:userId", Tag: "tasks", Summary: "Изменить роль участника",
+			Body: dto.ChangeParticipantRoleRequest{}, Response: dto.TaskResponse{}}),
+		versioned(Operation{Method: http.MethodDelete, Path: "/api/v1/tasks/:id/participants/:userId", Tag: "tasks", Summary: "Удалить участника",
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/watch", Tag: "tasks", Summary: "Наблюдать за задачей",
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		versioned(Operation{Method: http.MethodDelete, Path: "/api/v1/tasks/:id/watch", Tag: "tasks", Summary: "Прекратить наблюдение",
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id/links", Tag: "tasks", Summary: "Связи задачи",
+			Response: []dto.TaskLinkResponse{}},
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/links", Tag: "tasks", Summary: "Связать задачи",
+			Body: dto.LinkTaskRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated}),
+		versioned(Operation{Method: http.MethodDelete, Path: "/api/v1/tasks/:id/links/:targetId", Tag: "tasks", Summary: "Удалить связь",
+			Params:   []Param{{Name: "type", In: "query", Description: "Тип связи", Enum: enumFor[domain.TaskLinkType]()}},
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id/subtasks", Tag: "tasks", Summary: "Подзадачи",
+			Response: dto.SubtasksResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id/worklogs", Tag: "time-tracking", Summary: "Учет времени задачи",
+			Response: []dto.WorkLogResponse{}},
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/worklogs", Tag: "time-tracking", Summary: "Списать время",
+			Body: dto.LogWorkRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated}),
+		versioned(Operation{Method: http.MethodDelete, Path: "/api/v1/tasks/:id/worklogs/:logId", Tag: "time-tracking", Summary: "Удалить запись времени",
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/timer/start", Tag: "time-tracking", Summary: "Запустить таймер",
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/timer/stop", Tag: "time-tracking", Summary: "Остановить таймер",
+			Body: dto.StopTimerRequest{}, BodyOptional: true, Response: dto.TaskResponse{}}),
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/reply-templates/:templateId", Tag: "reply-templates", Summary: "Ответить по шаблону",
+			Body: dto.ApplyReplyTemplateRequest{}, BodyOptional: true, Response: dto.ApplyReplyTemplateResponse{}}),
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/snooze", Tag: "tasks", Summary: "Отложить задачу",
+			Body: dto.SnoozeTaskRequest{}, Response: dto.TaskResponse{}}),
+		versioned(Operation{Method: http.MethodDelete, Path: "/api/v1/tasks/:id/snooze", Tag: "tasks", Summary: "Вернуть задачу в работу",
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id/scheduled-replies", Tag: "scheduled-replies", Summary: "Запланированные ответы задачи",
+			Response: []dto.ScheduledReplyResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/tasks/:id/scheduled-replies", Tag: "scheduled-replies", Summary: "Запланировать ответ",
+			Body: dto.ScheduleReplyRequest{}, Response: dto.ScheduledReplyResponse{}, Status: http.StatusCreated},
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/survey", Tag: "surveys", Summary: "Отправить опрос удовлетворенности",
+			Response: dto.TaskResponse{}, Responses: []int{http.StatusBadRequest}}),
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:id/history", Tag: "audit", Summary: "История задачи",
+			Params: []Param{languageQuery}, Response: []dto.AuditEventResponse{}},
+		versioned(Operation{Method: http.MethodPost, Path: "/api/v1/tasks/:id/github-issue", Tag: "tasks", Summary: "Создать связанную задачу GitHub",
+			Body: dto.CreateGitHubIssueRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated, Responses: []int{http.StatusBadGateway}}),
+
+		// Audit log
+		{Method: http.MethodGet, Path: "/api/v1/audit", Tag: "audit", Summary: "Журнал аудита",
+			Query: dto.AuditQueryRequest{}, Response: dto.AuditLogResponse{}},
+
+		// Stored emails
+		{Method: http.MethodGet, Path: "/api/v1/emails", Tag: "emails", Summary: "Список писем",
+			Description: "Направление, источник и период задаются компактными фильтрами: direction=incoming, source!=smtp, created>=2025-01-01",
+			Query:       dto.EmailSearchRequest{}, Response: dto.EmailListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/emails/statistics", Tag: "emails", Summary: "Статистика писем",
+			Response: dto.EmailStatisticsResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/emails/:id", Tag: "emails", Summary: "Получить письмо",
+			Response: dto.EmailDetailResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/emails/:id/raw", Tag: "emails", Summary: "Скачать письмо (.eml)",
+			ContentType: "message/rfc822"},
+		{Method: http.MethodPost, Path: "/api/v1/emails/:id/reprocess", Tag: "emails", Summary: "Обработать письмо повторно",
+			Response: dto.EmailDetailResponse{}, Responses: []int{http.StatusUnprocessableEntity}},
+		{Method: http.MethodPut, Path: "/api/v1/emails/:id/task", Tag: "emails", Summary: "Привязать письмо к задаче",
+			Body: dto.LinkEmailRequest{}, Response: dto.EmailDetailResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/emails/:id/task", Tag: "emails", Summary: "Отвязать письмо от задачи",
+			Response: dto.EmailDetailResponse{}},
+
+		// Full-text search
+		{Method: http.MethodGet, Path: "/api/v1/search", Tag: "search", Summary: "Полнотекстовый поиск",
+			Query: dto.SearchRequest{}, Response: dto.SearchResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/search/reindex", Tag: "search", Summary: "Переиндексация поиска",
+			Response: dto.ReindexResponse{}},
+
+		// Recurring tasks
+		{Method: http.MethodGet, Path: "/api/v1/recurring-tasks", Tag: "recurring-tasks", Summary: "Список повторяющихся задач",
+			Response: []dto.RecurringTaskResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/recurring-tasks", Tag: "recurring-tasks", Summary: "Создать повторяющуюся задачу",
+			Body: dto.RecurringTaskRequest{}, Response: dto.RecurringTaskResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/recurring-tasks/:id", Tag: "recurring-tasks", Summary: "Получить повторяющуюся задачу",
+			Response: dto.RecurringTaskResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/recurring-tasks/:id", Tag: "recurring-tasks", Summary: "Изменить повторяющуюся задачу",
+			Body: dto.UpdateRecurringTaskRequest{}, Response: dto.RecurringTaskResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/recurring-tasks/:id", Tag: "recurring-tasks", Summary: "Удалить повторяющуюся задачу",
+			Status: http.StatusNoContent},
+
+		// Scheduled replies
+		{Method: http.MethodDelete, Path: "/api/v1/scheduled-replies/:id", Tag: "scheduled-replies", Summary: "Отменить запланированный ответ",
+			Response: dto.ScheduledReplyResponse{}, Responses: []int{http.StatusBadRequest}},
+
+		// Current user
+		{Method: http.MethodGet, Path: "/api/v1/me/tasks", Tag: "me", Summary: "Мои задачи",
+			Response: dto.UserTasksResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/me/dashboard", Tag: "me", Summary: "Мой дашборд",
+			Response: dto.DashboardResponse{}},
+
+		// Reports
+		{Method: http.MethodGet, Path: "/api/v1/reports/time", Tag: "time-tracking", Summary: "Отчет по времени",
+			Description: "С format=csv отчет возвращается файлом text/csv",
+			Query:       dto.TimeReportRequest{}, Response: dto.TimeReportResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/reports/satisfaction", Tag: "reports", Summary: "Отчет по удовлетворенности (CSAT)",
+			Query: dto.SatisfactionReportRequest{}, Response: dto.SatisfactionReportResponse{}},
+
+		// Custom fields
+		{Method: http.MethodGet, Path: "/api/v1/custom-fields", Tag: "custom-fields", Summary: "Список пользовательских полей",
+			Params: []Param{
+				{Name: "task_type", In: "query", Description: "Только поля для типа задачи", Enum: enumFor[domain.TaskType]()},
+				{Name: "category", In: "query", Description: "Категория задачи (вместе с task_type)"},
+			},
+			Response: []dto.CustomFieldResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/custom-fields", Tag: "custom-fields", Summary: "Создать пользовательское поле",
+			Body: dto.CustomFieldRequest{}, Response: dto.CustomFieldResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/custom-fields/:key", Tag: "custom-fields", Summary: "Получить пользовательское поле",
+			Response: dto.CustomFieldResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/custom-fields/:key", Tag: "custom-fields", Summary: "Изменить пользовательское поле",
+			Body: dto.UpdateCustomFieldRequest{}, Response: dto.CustomFieldResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/custom-fields/:key", Tag: "custom-fields", Summary: "Удалить пользовательское поле",
+			Status: http.StatusNoContent},
+
+		// Reply templates
+		{Method: http.MethodGet, Path: "/api/v1/reply-templates", Tag: "reply-templates", Summary: "Список шаблонов ответов",
+			Params: []Param{
+				{Name: "category", In: "query", Description: "Категория шаблона"},
+				languageQuery,
+				{Name: "search", In: "query", Description: "Поиск по названию и тексту"},
+			},
+			Response: []dto.ReplyTemplateResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/reply-templates", Tag: "reply-templates", Summary: "Создать шаблон ответа",
+			Body: dto.ReplyTemplateRequest{}, Response: dto.ReplyTemplateResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/reply-templates/:id", Tag: "reply-templates", Summary: "Получить шаблон ответа",
+			Response: dto.ReplyTemplateResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/reply-templates/:id", Tag: "reply-templates", Summary: "Изменить шаблон ответа",
+			Body: dto.UpdateReplyTemplateRequest{}, Response: dto.ReplyTemplateResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/reply-templates/:id", Tag: "reply-templates", Summary: "Удалить шаблон ответа",
+			Status: http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/api/v1/reply-templates/:id/preview", Tag: "reply-templates", Summary: "Предпросмотр шаблона для задачи",
+			Params: []Param{
+				{Name: "task_id", In: "query", Description: "Задача, по которой подставляются переменные", Required: true},
+				languageQuery,
+			},
+			Response: dto.RenderedReplyResponse{}, Responses: []int{http.StatusBadRequest}},
+
+		// Webhooks
+		{Method: http.MethodGet, Path: "/api/v1/webhooks", Tag: "webhooks", Summary: "Список вебхуков",
+			Response: []dto.WebhookResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/webhooks", Tag: "webhooks", Summary: "Создать вебхук",
+			Body: dto.WebhookRequest{}, Response: dto.WebhookResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/webhooks/:id", Tag: "webhooks", Summary: "Получить вебхук",
+			Response: dto.WebhookResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/webhooks/:id", Tag: "webhooks", Summary: "Изменить вебхук",
+			Body: dto.UpdateWebhookRequest{}, Response: dto.WebhookResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/webhooks/:id", Tag: "webhooks", Summary: "Удалить вебхук",
+			Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/v1/webhooks/:id/test", Tag: "webhooks", Summary: "Отправить тестовое событие",
+			Response: dto.WebhookDeliveryResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/webhooks/:id/deliveries", Tag: "webhooks", Summary: "Журнал доставок вебхука",
+			Query: dto.WebhookDeliveryQueryRequest{}, Response: dto.WebhookDeliveryListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/webhooks/:id/deliveries/:deliveryId/retry", Tag: "webhooks", Summary: "Повторить доставку",
+			Response: dto.WebhookDeliveryResponse{}, Responses: []int{http.StatusBadRequest}},
+
+		// Inbound channels
+		{Method: http.MethodGet, Path: "/api/v1/channels", Tag: "channels", Summary: "Список входящих каналов",
+			Response: []dto.InboundChannelResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/channels", Tag: "channels", Summary: "Создать входящий канал",
+			Body: dto.InboundChannelRequest{}, Response: dto.InboundChannelResponse{}, Status: http.StatusCreated, Responses: []int{http.StatusConflict}},
+		{Method: http.MethodGet, Path: "/api/v1/channels/:channelKey", Tag: "channels", Summary: "Получить входящий канал",
+			Response: dto.InboundChannelResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/channels/:channelKey", Tag: "channels", Summary: "Изменить входящий канал",
+			Body: dto.UpdateInboundChannelRequest{}, Response: dto.InboundChannelResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/channels/:channelKey", Tag: "channels", Summary: "Удалить входящий канал",
+			Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/v1/channels/:channelKey/inbound", Tag: "channels", Summary: "Принять запись входящего канала",
+			Description: "Новая запись создает задачу (201), повторная обновляет ее (200)",
+			Params:      []Param{{Name: domain.WebhookSignatureHeader, In: "header", Description: "Подпись тела запроса", Required: true}},
+			Body:        map[string]interface{}{}, Response: dto.InboundResultResponse{},
+			Responses: []int{http.StatusCreated, http.StatusUnauthorized, http.StatusConflict}, Public: true},
+
+		// Email settings
+		{Method: http.MethodGet, Path: "/api/v1/settings/email/channels", Tag: "email-settings", Summary: "Список почтовых каналов",
+			Response: []dto.EmailChannelResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/settings/email/channels/:id", Tag: "email-settings", Summary: "Получить почтовый канал",
+			Response: dto.EmailChannelResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/settings/email/channels/:id", Tag: "email-settings", Summary: "Сохранить почтовый канал",
+			Body: dto.EmailChannelRequest{}, Response: dto.EmailChannelResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/settings/email/channels/:id", Tag: "email-settings", Summary: "Удалить почтовый канал",
+			Status: http.StatusNoContent, Responses: []int{http.StatusConflict}},
+		{Method: http.MethodPost, Path: "/api/v1/settings/email/channels/:id/test", Tag: "email-settings", Summary: "Проверить подключение почтового канала",
+			Response: dto.EmailConnectionTestResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/settings/email/policy", Tag: "email-settings", Summary: "Политика обработки писем",
+			Response: dto.EmailPolicyResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/settings/email/policy", Tag: "email-settings", Summary: "Изменить политику обработки писем",
+			Body: dto.EmailPolicyRequest{}, Response: dto.EmailPolicyResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/settings/email/thread-search", Tag: "email-settings", Summary: "Окна поиска писем треда",
+			Response: dto.ThreadSearchConfigResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/settings/email/thread-search", Tag: "email-settings", Summary: "Изменить окна поиска писем треда",
+			Body: dto.ThreadSearchConfigRequest{}, Response: dto.ThreadSearchConfigResponse{}},
+
+		// Telegram и GitHub (регистрируются, если интеграции настроены)
+		{Method: http.MethodPost, Path: "/api/v1/telegram/webhook", Tag: "telegram", Summary: "Webhook Telegram Bot API",
+			Params: []Param{{Name: "X-Telegram-Bot-Api-Secret-Token", In: "header", Description: "Секрет вебхука", Required: true}},
+			Body:   map[string]interface{}{}, Response: dto.InboundResultResponse{}, Responses: []int{http.StatusUnauthorized, http.StatusConflict}, Public: true},
+		{Method: http.MethodPost, Path: "/api/v1/github/webhook", Tag: "github", Summary: "Webhook GitHub",
+			Params: []Param{
+				{Name: "X-Hub-Signature-256", In: "header", Description: "Подпись тела запроса", Required: true},
+				{Name: "X-GitHub-Event", In: "header", Description: "Тип события", Required: true},
+			},
+			Body: map[string]interface{}{}, Responses: []int{http.StatusUnauthorized}, Public: true},
+
+		// Public endpoints
+		{Method: http.MethodGet, Path: "/api/v1/public/surveys/:token", Tag: "surveys", Summary: "Подтверждение оценки по ссылке из письма",
+			Description: "Проверяет ссылку и возвращает выбранную оценку; оценка записывается только POST-запросом",
+			Query:       dto.SurveyLinkQuery{}, Response: dto.SurveyConfirmationResponse{}, Responses: []int{http.StatusForbidden}, Public: true},
+		{Method: http.MethodPost, Path: "/api/v1/public/surveys/:token", Tag: "surveys", Summary: "Ответ на опрос удовлетворенности",
+			Description: "Записывает подтвержденную оценку и комментарий",
+			Body:        dto.SurveyResponseRequest{}, Response: dto.SurveyResultResponse{}, Responses: []int{http.StatusForbidden}, Public: true},
+
+		// Customers
+		{Method: http.MethodGet, Path: "/api/v1/customers", Tag: "customers", Summary: "Список клиентов",
+			Query: dto.CustomerSearchRequest{}, Response: dto.CustomerListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/customers", Tag: "customers", Summary: "Создать клиента",
+			Body: dto.CreateCustomerRequest{}, Response: dto.CustomerResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/customers/find-or-create", Tag: "customers", Summary: "Найти или создать клиента",
+			Params: []Param{
+				{Name: "email", In: "query", Description: "Email клиента", Required: true},
+				{Name: "name", In: "query", Description: "Имя для нового клиента"},
+			},
+			Response: dto.CustomerResponse{}, Responses: []int{http.StatusBadRequest}},
+		{Method: http.MethodGet, Path: "/api/v1/customers/:id", Tag: "customers", Summary: "Получить клиента",
+			Response: dto.CustomerResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/customers/:id", Tag: "customers", Summary: "Обновить клиента",
+			Body: dto.UpdateCustomerRequest{}, Response: dto.CustomerResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/customers/:id", Tag: "customers", Summary: "Удалить клиента",
+			Status: http.StatusNoContent, Responses: []int{http.StatusBadRequest}},
+		{Method: http.MethodGet, Path: "/api/v1/customers/:id/profile", Tag: "customers", Summary: "Получить профиль клиента",
+			Response: dto.CustomerProfileResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/customers/:id/tasks", Tag: "customers", Summary: "Получить задачи клиента",
+			Response: []dto.TaskResponse{}},
+
+		// Спецификация API
+		{Method: http.MethodGet, Path: "/api/v1/openapi.json", Tag: "system", Summary: "Спецификация OpenAPI",
+			Response: map[string]interface{}{}, Raw: true, Public: true},
+
+		// System routes
+		{Method: http.MethodGet, Path: "/health", Tag: "system", Summary: "Health check",
+			Response: map[string]interface{}{}, Responses: []int{http.StatusServiceUnavailable}, Public: true},
+		{Method: http.MethodGet, Path: "/ready", Tag: "system", Summary: "Ready check",
+			Response: map[string]string{}, Responses: []int{http.StatusServiceUnavailable}, Public: true},
+		{Method: http.MethodGet, Path: "/live", Tag: "system", Summary: "Liveness check",
+			Response: map[string]string{}, Public: true},
+		{Method: http.MethodGet, Path: "/", Tag: "system", Summary: "Сведения о сервисе",
+			Response: map[string]string{}, Raw: true, Public: true},
+	}
+}
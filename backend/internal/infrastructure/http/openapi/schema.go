@@ -0,0 +1,257 @@
+// internal/infrastructure/http/openapi/schema.go
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema схема JSON Schema (диалект OpenAPI 3.1)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+	bytesType   = reflect.TypeOf([]byte{})
+)
+
+// schemaRegistry строит схемы по Go-типам DTO и собирает именованные схемы
+// структур и перечислений для раздела components
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaFor возвращает схему значения типа t; структуры и перечисления - ссылкой на components
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawJSONType:
+		return &Schema{}
+	case bytesType:
+		return &Schema{Type: "string", ContentEncoding: "base64"}
+	}
+
+	if values, ok := enumValues[t]; ok {
+		return r.ref(t, func() *Schema {
+			return &Schema{Type: "string", Enum: values}
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return r.ref(t, func() *Schema { return r.structSchema(t) })
+	default:
+		// interface{} - любое значение JSON
+		return &Schema{}
+	}
+}
+
+// ref регистрирует именованную схему типа и возвращает ссылку на нее
+func (r *schemaRegistry) ref(t reflect.Type, build func() *Schema) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = r.componentName(t)
+		r.names[t] = name
+		// Заглушка до построения: рекурсивные структуры ссылаются на себя
+		r.schemas[name] = &Schema{}
+		*r.schemas[name] = *build()
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// componentName имя схемы в components; при совпадении имен типов из разных пакетов
+// добавляется имя пакета
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	name := t.Name()
+	if _, taken := r.schemas[name]; !taken {
+		return name
+	}
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + name
+}
+
+// structSchema схема объекта по полям структуры: имена из тегов json,
+// обязательность и ограничения - из правил binding
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, field := range structFields(t, "json") {
+		property := r.schemaFor(field.field.Type)
+		if applyBindingRules(property, field.field.Type, field.binding()) {
+			schema.Required = append(schema.Required, field.name)
+		}
+		schema.Properties[field.name] = property
+	}
+	return schema
+}
+
+// taggedField поле структуры с именем из тега
+type taggedField struct {
+	name  string
+	field reflect.StructField
+}
+
+func (f taggedField) binding() string {
+	return f.field.Tag.Get("binding")
+}
+
+// structFields возвращает поля структуры с именами из тега tag (json или form).
+// Встроенные структуры без тега раскрываются, как это делают encoding/json и binding gin;
+// для json поля без тега называются по имени поля, для form - пропускаются
+func structFields(t reflect.Type, tag string) []taggedField {
+	var fields []taggedField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, structFields(embedded, tag)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			if tag != "json" {
+				continue
+			}
+			name = field.Name
+		}
+		fields = append(fields, taggedField{name: name, field: field})
+	}
+	return fields
+}
+
+// applyBindingRules переносит правила валидации binding в ограничения схемы
+// и сообщает, обязательно ли поле. Правила после dive относятся к элементам и пропускаются
+func applyBindingRules(schema *Schema, t reflect.Type, binding string) bool {
+	if binding == "" {
+		return false
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "oneof":
+			// Перечисление домена целиком остается ссылкой, подмножество описывается в поле
+			values := strings.Fields(param)
+			if schema.Ref == "" || !slices.Equal(enumValues[t], values) {
+				*schema = Schema{Type: "string", Enum: values}
+			}
+		case "min", "max":
+			if schema.Ref != "" {
+				continue
+			}
+			if value, err := strconv.Atoi(param); err == nil {
+				applyBound(schema, t, name == "min", value)
+			}
+		}
+	}
+	return required
+}
+
+// applyBound задает нижнюю или верхнюю границу в зависимости от вида значения:
+// длина строки, число, количество элементов или ключей
+func applyBound(schema *Schema, t reflect.Type, lower bool, value int) {
+	switch t.Kind() {
+	case reflect.String:
+		if lower {
+			schema.MinLength = &value
+		} else {
+			schema.MaxLength = &value
+		}
+	case reflect.Slice, reflect.Array:
+		if lower {
+			schema.MinItems = &value
+		} else {
+			schema.MaxItems = &value
+		}
+	case reflect.Map:
+		if lower {
+			schema.MinProperties = &value
+		} else {
+			schema.MaxProperties = &value
+		}
+	default:
+		bound := float64(value)
+		if lower {
+			schema.Minimum = &bound
+		} else {
+			schema.Maximum = &bound
+		}
+	}
+}
@@ -0,0 +1,359 @@
+// internal/infrastructure/http/openapi/spec.go
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/audetv/urms/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// Operation описание маршрута API. Пути и обработчики берутся из регистрации
+// маршрутов gin, схемы тел и параметров - из тегов json, form и binding DTO
+type Operation struct {
+	Method       string
+	Path         string // Путь в нотации gin: /api/v1/tasks/:id
+	Summary      string
+	Description  string
+	Tag          string
+	Body         interface{} // DTO тела запроса; nil - без тела
+	BodyOptional bool
+	Query        interface{} // DTO параметров query (теги form)
+	Params       []Param     // Параметры query и заголовки вне DTO
+	Response     interface{} // Значение data успешного ответа; nil - ответ без data
+	Status       int         // Код успешного ответа, по умолчанию 200
+	ContentType  string      // Тип содержимого успешного ответа, если это не JSON; Response - схема событий потока
+	Raw          bool        // Ответ JSON без конверта dto.BaseResponse
+	Responses    []int       // Коды ответов сверх выводимых автоматически (400, 401, 403, 404, 500)
+	Public       bool        // Доступна без аутентификации
+}
+
+// Param параметр query или заголовок запроса
+type Param struct {
+	Name        string
+	In          string // query или header
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+// Spec описания операций API, по которым строится документ OpenAPI
+type Spec struct {
+	info       Info
+	operations map[string]Operation
+}
+
+// NewSpec создает спецификацию из описаний операций
+func NewSpec(info Info, operations []Operation) *Spec {
+	spec := &Spec{info: info, operations: make(map[string]Operation, len(operations))}
+	for _, op := range operations {
+		spec.operations[routeKey(op.Method, op.Path)] = op
+	}
+	return spec
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// Missing возвращает зарегистрированные маршруты, у которых нет описания
+func (s *Spec) Missing(routes gin.RoutesInfo) []string {
+	var missing []string
+	for _, route := range routes {
+		key := routeKey(route.Method, route.Path)
+		if _, ok := s.operations[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Unused возвращает описания операций, для которых маршрут не зарегистрирован
+func (s *Spec) Unused(routes gin.RoutesInfo) []string {
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[routeKey(route.Method, route.Path)] = true
+	}
+
+	var unused []string
+	for key := range s.operations {
+		if !registered[key] {
+			unused = append(unused, key)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// Handler отдает документ по маршрутам engine. Документ строится при первом запросе,
+// когда регистрация маршрутов уже завершена
+func (s *Spec) Handler(engine *gin.Engine) gin.HandlerFunc {
+	var (
+		once    sync.Once
+		content []byte
+		err     error
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			content, err = json.Marshal(s.Document(engine.Routes()))
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse(
+				"OPENAPI_FAILED",
+				"Не удалось сформировать спецификацию API",
+				err.Error(),
+			))
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", content)
+	}
+}
+
+// Document строит документ OpenAPI по зарегистрированным маршрутам. Маршруты без
+// описания попадают в документ с выводимыми автоматически параметрами и ответами
+func (s *Spec) Document(routes gin.RoutesInfo) *Document {
+	registry := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    s.info,
+		Paths:   make(map[string]map[string]*PathOperation),
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				"apiKey":     {Type: "apiKey", In: "header", Name: middleware.APIKeyHeader},
+			},
+		},
+	}
+
+	errorSchema := &Schema{
+		Type:     "object",
+		Required: []string{"success", "error"},
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean", Const: false},
+			"error":   registry.schemaFor(reflect.TypeOf(dto.ErrorInfo{})),
+			"meta":    registry.schemaFor(reflect.TypeOf(dto.MetaInfo{})),
+		},
+	}
+
+	tags := make(map[string]bool)
+	operationIDs := make(map[string]bool)
+	for _, route := range routes {
+		op, ok := s.operations[routeKey(route.Method, route.Path)]
+		if !ok {
+			op = Operation{Method: route.Method, Path: route.Path}
+		}
+
+		pathOp := s.buildOperation(registry, op)
+		pathOp.OperationID = uniqueOperationID(operationIDs, route)
+		if op.Tag != "" {
+			tags[op.Tag] = true
+		}
+
+		path := openAPIPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*PathOperation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = pathOp
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+
+	registry.schemas["ErrorResponse"] = errorSchema
+	doc.Components.Schemas = registry.schemas
+	return doc
+}
+
+var pathParamPattern = regexp.MustCompile(`[:*](\w+)`)
+
+// openAPIPath переводит путь gin (/tasks/:id) в нотацию OpenAPI (/tasks/{id})
+func openAPIPath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+func (s *Spec) buildOperation(registry *schemaRegistry, op Operation) *PathOperation {
+	result := &PathOperation{
+		Summary:     op.Summary,
+		Description: op.Description,
+		Responses:   make(map[string]*Response),
+		Security:    []map[string][]string{},
+	}
+	if op.Tag != "" {
+		result.Tags = []string{op.Tag}
+	}
+	if !op.Public {
+		result.Security = []map[string][]string{{"bearerAuth": {}}, {"apiKey": {}}}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+		result.Parameters = append(result.Parameters, &Parameter{
+			Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+	if op.Query != nil {
+		for _, field := range structFields(indirect(reflect.TypeOf(op.Query)), "form") {
+			schema := registry.schemaFor(field.field.Type)
+			result.Parameters = append(result.Parameters, &Parameter{
+				Name:     field.name,
+				In:       "query",
+				Required: applyBindingRules(schema, field.field.Type, field.binding()),
+				Schema:   schema,
+			})
+		}
+	}
+	for _, param := range op.Params {
+		result.Parameters = append(result.Parameters, &Parameter{
+			Name:        param.Name,
+			In:          param.In,
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      &Schema{Type: "string", Enum: param.Enum},
+		})
+	}
+
+	if op.Body != nil {
+		result.RequestBody = &RequestBody{
+			Required: !op.BodyOptional,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: registry.schemaFor(reflect.TypeOf(op.Body))},
+			},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	result.Responses[strconv.Itoa(status)] = successResponse(registry, op, status)
+
+	codes := append([]int{http.StatusInternalServerError}, op.Responses...)
+	if op.Body != nil || op.Query != nil {
+		codes = append(codes, http.StatusBadRequest)
+	}
+	if !op.Public {
+		codes = append(codes, http.StatusUnauthorized, http.StatusForbidden)
+	}
+	if len(result.Parameters) > 0 && result.Parameters[0].In == "path" {
+		codes = append(codes, http.StatusNotFound)
+	}
+	for _, code := range codes {
+		response := &Response{Description: http.StatusText(code)}
+		if code >= http.StatusBadRequest {
+			response.Content = map[string]*MediaType{
+				"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}},
+			}
+		}
+		result.Responses[strconv.Itoa(code)] = response
+	}
+	return result
+}
+
+// successResponse описывает успешный ответ: конверт dto.BaseResponse с data нужного типа,
+// пустой ответ или содержимое другого типа (файл, CSV)
+func successResponse(registry *schemaRegistry, op Operation, status int) *Response {
+	response := &Response{Description: http.StatusText(status)}
+	switch {
+	case status == http.StatusNoContent:
+		return response
+	case op.ContentType != "":
+		schema := &Schema{Type: "string", Format: "binary"}
+		if op.Response != nil {
+			// Поток событий: схема описывает данные одного события
+			schema = registry.schemaFor(reflect.TypeOf(op.Response))
+		}
+		response.Content = map[string]*MediaType{op.ContentType: {Schema: schema}}
+		return response
+	case op.Raw:
+		response.Content = map[string]*MediaType{
+			"application/json": {Schema: registry.schemaFor(reflect.TypeOf(op.Response))},
+		}
+		return response
+	}
+
+	envelope := &Schema{
+		Type:     "object",
+		Required: []string{"success"},
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean", Const: true},
+			"meta":    registry.schemaFor(reflect.TypeOf(dto.MetaInfo{})),
+		},
+	}
+	if op.Response != nil {
+		envelope.Properties["data"] = registry.schemaFor(reflect.TypeOf(op.Response))
+		envelope.Required = append(envelope.Required, "data")
+	}
+	response.Content = map[string]*MediaType{"application/json": {Schema: envelope}}
+	return response
+}
+
+// uniqueOperationID формирует operationId из имени обработчика маршрута:
+// (*TaskHandler).ListTasks → taskListTasks; повторы различаются HTTP-методом
+func uniqueOperationID(used map[string]bool, route gin.RouteInfo) string {
+	id := handlerOperationID(route.Handler)
+	if id == "" {
+		id = pathOperationID(route.Method, route.Path)
+	}
+	if used[id] {
+		id += methodSuffix(route.Method)
+	}
+	used[id] = true
+	return id
+}
+
+var handlerNamePattern = regexp.MustCompile(`\(\*(\w+)\)\.(\w+)-fm$`)
+
+func handlerOperationID(handler string) string {
+	match := handlerNamePattern.FindStringSubmatch(handler)
+	if match == nil {
+		return ""
+	}
+	receiver := strings.TrimSuffix(match[1], "Handler")
+	return lowerFirst(receiver) + match[2]
+}
+
+// pathOperationID operationId для обработчиков-замыканий: GET /api/v1/openapi.json → getApiV1OpenapiJson
+func pathOperationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	words := strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		words = []string{"root"}
+	}
+	for _, word := range words {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+func methodSuffix(method string) string {
+	method = strings.ToLower(method)
+	return strings.ToUpper(method[:1]) + method[1:]
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
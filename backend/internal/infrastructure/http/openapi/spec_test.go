@@ -0,0 +1,99 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/audetv/urms/internal/infrastructure/http/dto"
+	"github.com/audetv/urms/internal/infrastructure/http/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRoutes() gin.RoutesInfo {
+	return gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/api/v1/tasks", Handler: "handlers.(*TaskHandler).ListTasks-fm"},
+		{Method: http.MethodPost, Path: "/api/v1/tasks", Handler: "handlers.(*TaskHandler).CreateTask-fm"},
+		{Method: http.MethodPut, Path: "/api/v1/tasks/:id/participants/:userId", Handler: "handlers.(*TaskHandler).ChangeParticipantRole-fm"},
+		{Method: http.MethodGet, Path: "/", Handler: "main.setupGinRouter.func1"},
+	}
+}
+
+func testSpec() *openapi.Spec {
+	return openapi.NewSpec(openapi.Info{Title: "test", Version: "1"}, []openapi.Operation{
+		{Method: http.MethodGet, Path: "/api/v1/tasks", Tag: "tasks",
+			Query: dto.TaskSearchRequest{}, Response: dto.TaskListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/tasks", Tag: "tasks",
+			Body: dto.CreateTaskRequest{}, Response: dto.TaskResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/api/v1/tasks/:id/participants/:userId", Tag: "tasks",
+			Body: dto.ChangeParticipantRoleRequest{}, Response: dto.TaskResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/tasks/:id", Tag: "tasks", Status: http.StatusNoContent},
+	})
+}
+
+func TestSpec_MissingAndUnused(t *testing.T) {
+	spec := testSpec()
+	assert.Equal(t, []string{"GET /"}, spec.Missing(testRoutes()))
+	assert.Equal(t, []string{"DELETE /api/v1/tasks/:id"}, spec.Unused(testRoutes()))
+}
+
+func TestSpec_Document(t *testing.T) {
+	doc := testSpec().Document(testRoutes())
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	schemas := doc.Components.Schemas
+
+	t.Run("binding rules become constraints", func(t *testing.T) {
+		request := schemas["CreateTaskRequest"]
+		require.NotNil(t, request)
+		assert.ElementsMatch(t, []string{"type", "subject", "description", "priority", "category"}, request.Required)
+
+		subject := request.Properties["subject"]
+		assert.Equal(t, 1, *subject.MinLength)
+		assert.Equal(t, 255, *subject.MaxLength)
+		assert.Equal(t, "date-time", request.Properties["due_date"].Format)
+	})
+
+	t.Run("domain enums are shared schemas", func(t *testing.T) {
+		assert.Equal(t, []string{"open", "in_progress", "review", "resolved", "closed", "cancelled"}, schemas["TaskStatus"].Enum)
+		assert.Equal(t, []string{"low", "medium", "high", "critical"}, schemas["Priority"].Enum)
+		assert.Equal(t, []string{"support", "internal", "subtask"}, schemas["TaskType"].Enum)
+
+		assert.Equal(t, "#/components/schemas/TaskStatus", schemas["TaskResponse"].Properties["status"].Ref)
+		assert.Equal(t, "#/components/schemas/Priority", schemas["CreateTaskRequest"].Properties["priority"].Ref,
+			"oneof with all values keeps the reference")
+
+		role := schemas["ChangeParticipantRoleRequest"].Properties["role"]
+		assert.Empty(t, role.Ref)
+		assert.Equal(t, []string{"reviewer", "watcher", "participant"}, role.Enum, "subset of values is inlined")
+	})
+
+	t.Run("operations", func(t *testing.T) {
+		list := doc.Paths["/api/v1/tasks"]["get"]
+		require.NotNil(t, list)
+		assert.Equal(t, "taskListTasks", list.OperationID)
+
+		params := make(map[string]*openapi.Parameter)
+		for _, param := range list.Parameters {
+			params[param.Name] = param
+		}
+		assert.Contains(t, params, "cursor", "embedded ListPageRequest is flattened")
+		assert.Equal(t, "array", params["statuses"].Schema.Type)
+		assert.Equal(t, []string{"asc", "desc"}, params["sort_order"].Schema.Enum)
+		assert.Equal(t, float64(100), *params["page_size"].Schema.Maximum)
+
+		participant := doc.Paths["/api/v1/tasks/{id}/participants/{userId}"]["put"]
+		require.NotNil(t, participant)
+		require.Len(t, participant.Parameters, 2)
+		assert.Equal(t, "userId", participant.Parameters[1].Name)
+		assert.Contains(t, participant.Responses, "404")
+		assert.Equal(t, "#/components/schemas/ErrorResponse", participant.Responses["400"].Content["application/json"].Schema.Ref)
+
+		created := doc.Paths["/api/v1/tasks"]["post"].Responses["201"].Content["application/json"].Schema
+		assert.Equal(t, "#/components/schemas/TaskResponse", created.Properties["data"].Ref)
+
+		root := doc.Paths["/"]["get"]
+		require.NotNil(t, root, "routes without description are still documented")
+		assert.Equal(t, "getRoot", root.OperationID)
+	})
+}
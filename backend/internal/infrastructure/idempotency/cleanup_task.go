@@ -0,0 +1,136 @@
+// internal/infrastructure/idempotency/cleanup_task.go
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// CleanupTask фоновая задача, удаляющая сохраненные ответы на идемпотентные запросы
+// и ключи принятых записей входящих каналов после истечения срока хранения.
+// Истекшие записи и без очистки не учитываются, задача только ограничивает размер хранилищ
+type CleanupTask struct {
+	idempotencyService ports.IdempotencyService
+	deliveryService    ports.InboundDeliveryService
+	interval           time.Duration
+	operationTimeout   time.Duration
+	logger             ports.Logger
+	cancelFunc         context.CancelFunc
+	isRunning          bool
+	lastRunAt          time.Time
+	mu                 sync.RWMutex
+}
+
+func NewCleanupTask(
+	idempotencyService ports.IdempotencyService,
+	deliveryService ports.InboundDeliveryService,
+	interval time.Duration,
+	operationTimeout time.Duration,
+	logger ports.Logger,
+) *CleanupTask {
+	return &CleanupTask{
+		idempotencyService: idempotencyService,
+		deliveryService:    deliveryService,
+		interval:           interval,
+		operationTimeout:   operationTimeout,
+		logger:             logger,
+		isRunning:          false,
+	}
+}
+
+func (t *CleanupTask) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isRunning {
+		return fmt.Errorf("idempotency cleanup task already running")
+	}
+	if t.interval <= 0 {
+		return fmt.Errorf("idempotency cleanup interval must be positive")
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	t.cancelFunc = cancel
+	t.isRunning = true
+
+	go t.runLoop(taskCtx)
+
+	t.logger.Info(ctx, "idempotency cleanup task started",
+		"interval", t.interval,
+		"operation_timeout", t.operationTimeout)
+
+	return nil
+}
+
+func (t *CleanupTask) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.isRunning {
+		return nil
+	}
+
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
+
+	t.isRunning = false
+	t.logger.Info(ctx, "idempotency cleanup task stopped")
+	return nil
+}
+
+func (t *CleanupTask) Name() string {
+	return "idempotency_cleanup"
+}
+
+func (t *CleanupTask) Health(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.isRunning {
+		return fmt.Errorf("idempotency cleanup task is not running")
+	}
+	if !t.lastRunAt.IsZero() && time.Since(t.lastRunAt) > 3*t.interval+t.operationTimeout {
+		return fmt.Errorf("idempotency cleanup has not run since %s", t.lastRunAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (t *CleanupTask) runLoop(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	t.executeRun(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.logger.Info(ctx, "idempotency cleanup loop stopped")
+			return
+		case <-ticker.C:
+			t.executeRun(ctx)
+		}
+	}
+}
+
+func (t *CleanupTask) executeRun(ctx context.Context) {
+	now := time.Now()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, t.operationTimeout)
+	defer cancel()
+
+	if _, err := t.idempotencyService.Cleanup(timeoutCtx); err != nil {
+		t.logger.Error(ctx, "idempotency cleanup run failed", "error", err)
+	}
+	if _, err := t.deliveryService.Cleanup(timeoutCtx); err != nil {
+		t.logger.Error(ctx, "inbound delivery cleanup run failed", "error", err)
+	}
+
+	t.mu.Lock()
+	t.lastRunAt = now
+	t.mu.Unlock()
+}
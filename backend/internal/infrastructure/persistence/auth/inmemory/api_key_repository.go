@@ -0,0 +1,80 @@
+// internal/infrastructure/persistence/auth/inmemory/api_key_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// APIKeyRepository ключи API в памяти
+type APIKeyRepository struct {
+	keys   map[string]*domain.APIKey
+	mu     sync.RWMutex
+	logger ports.Logger
+}
+
+func NewAPIKeyRepository(logger ports.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		keys:   make(map[string]*domain.APIKey),
+		logger: logger,
+	}
+}
+
+func (r *APIKeyRepository) Save(ctx context.Context, key *domain.APIKey) error {
+	if key == nil || key.ID == "" || key.KeyHash == "" {
+		return errors.New("api key ID and hash cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[key.ID] = cloneAPIKey(key)
+	r.logger.Info(ctx, "api key saved", "key_id", key.ID, "user_id", key.UserID)
+	return nil
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			return cloneAPIKey(key), nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (r *APIKeyRepository) FindByUserID(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []domain.APIKey
+	for _, key := range r.keys {
+		if key.UserID == userID {
+			result = append(result, *cloneAPIKey(key))
+		}
+	}
+	return result, nil
+}
+
+func (r *APIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.keys[key.ID]; !exists {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	r.keys[key.ID] = cloneAPIKey(key)
+	return nil
+}
+
+func cloneAPIKey(key *domain.APIKey) *domain.APIKey {
+	clone := *key
+	return &clone
+}
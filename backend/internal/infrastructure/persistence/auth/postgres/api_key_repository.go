@@ -0,0 +1,82 @@
+// internal/infrastructure/persistence/auth/postgres/api_key_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresAPIKeyRepository реализует ports.APIKeyRepository для PostgreSQL
+type PostgresAPIKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAPIKeyRepository создает репозиторий ключей API
+func NewPostgresAPIKeyRepository(db *sqlx.DB) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresAPIKeyRepository) Save(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (
+			id, user_id, name, prefix, key_hash, created_at, expires_at, last_used_at, revoked_at
+		) VALUES (
+			:id, :user_id, :name, :prefix, :key_hash, :created_at, :expires_at, :last_used_at, :revoked_at
+		)
+	`
+	if _, err := r.db.NamedExecContext(ctx, query, APIKeyFromDomain(key)); err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var model APIKeyModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM api_keys WHERE key_hash = $1`, keyHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *PostgresAPIKeyRepository) FindByUserID(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	var models []APIKeyModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC`, userID); err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+
+	keys := make([]domain.APIKey, 0, len(models))
+	for i := range models {
+		keys = append(keys, *models[i].ToDomain())
+	}
+	return keys, nil
+}
+
+func (r *PostgresAPIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		UPDATE api_keys SET
+			name = :name, expires_at = :expires_at, last_used_at = :last_used_at, revoked_at = :revoked_at
+		WHERE id = :id
+	`
+	result, err := r.db.NamedExecContext(ctx, query, APIKeyFromDomain(key))
+	if err != nil {
+		return fmt.Errorf("failed to update api key: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// internal/infrastructure/persistence/auth/postgres/models.go
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// APIKeyModel представляет ключ API в PostgreSQL
+type APIKeyModel struct {
+	ID         string       `db:"id"`
+	UserID     string       `db:"user_id"`
+	Name       string       `db:"name"`
+	Prefix     string       `db:"prefix"`
+	KeyHash    string       `db:"key_hash"`
+	CreatedAt  time.Time    `db:"created_at"`
+	ExpiresAt  sql.NullTime `db:"expires_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at"`
+}
+
+// APIKeyFromDomain конвертирует domain сущность в PostgreSQL модель
+func APIKeyFromDomain(key *domain.APIKey) *APIKeyModel {
+	return &APIKeyModel{
+		ID:         key.ID,
+		UserID:     key.UserID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		KeyHash:    key.KeyHash,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  nullTime(key.ExpiresAt),
+		LastUsedAt: nullTime(key.LastUsedAt),
+		RevokedAt:  nullTime(key.RevokedAt),
+	}
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *APIKeyModel) ToDomain() *domain.APIKey {
+	return &domain.APIKey{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Name:       m.Name,
+		Prefix:     m.Prefix,
+		KeyHash:    m.KeyHash,
+		CreatedAt:  m.CreatedAt,
+		ExpiresAt:  timePtr(m.ExpiresAt),
+		LastUsedAt: timePtr(m.LastUsedAt),
+		RevokedAt:  timePtr(m.RevokedAt),
+	}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
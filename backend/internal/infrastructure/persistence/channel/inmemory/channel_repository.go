@@ -0,0 +1,101 @@
+// internal/infrastructure/persistence/channel/inmemory/channel_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sort"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// InboundChannelRepository входящие каналы в памяти
+type InboundChannelRepository struct {
+	channels map[string]*domain.InboundChannel
+	mu       sync.RWMutex
+	logger   ports.Logger
+}
+
+func NewInboundChannelRepository(logger ports.Logger) *InboundChannelRepository {
+	return &InboundChannelRepository{
+		channels: make(map[string]*domain.InboundChannel),
+		logger:   logger,
+	}
+}
+
+func (r *InboundChannelRepository) Save(ctx context.Context, channel *domain.InboundChannel) error {
+	if channel == nil || channel.Key == "" {
+		return errors.New("inbound channel key cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.channels[channel.Key]; exists {
+		return domain.ErrInboundChannelExists
+	}
+
+	r.channels[channel.Key] = cloneChannel(channel)
+	r.logger.Info(ctx, "inbound channel saved", "channel", channel.Key)
+	return nil
+}
+
+func (r *InboundChannelRepository) FindByKey(ctx context.Context, key string) (*domain.InboundChannel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	channel, exists := r.channels[key]
+	if !exists {
+		return nil, domain.ErrInboundChannelNotFound
+	}
+	return cloneChannel(channel), nil
+}
+
+func (r *InboundChannelRepository) FindAll(ctx context.Context) ([]domain.InboundChannel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.InboundChannel, 0, len(r.channels))
+	for _, channel := range r.channels {
+		result = append(result, *cloneChannel(channel))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
+func (r *InboundChannelRepository) Update(ctx context.Context, channel *domain.InboundChannel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.channels[channel.Key]; !exists {
+		return domain.ErrInboundChannelNotFound
+	}
+
+	r.channels[channel.Key] = cloneChannel(channel)
+	return nil
+}
+
+func (r *InboundChannelRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.channels[key]; !exists {
+		return domain.ErrInboundChannelNotFound
+	}
+
+	delete(r.channels, key)
+	return nil
+}
+
+func cloneChannel(channel *domain.InboundChannel) *domain.InboundChannel {
+	clone := *channel
+	clone.PriorityMap = maps.Clone(channel.PriorityMap)
+	clone.DefaultTags = append([]string(nil), channel.DefaultTags...)
+	return &clone
+}
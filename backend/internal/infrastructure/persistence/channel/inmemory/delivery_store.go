@@ -0,0 +1,94 @@
+// internal/infrastructure/persistence/channel/inmemory/delivery_store.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// InboundDeliveryStore принятые записи входящих каналов в памяти.
+// Подходит для одного экземпляра API: ключи не видны другим процессам
+type InboundDeliveryStore struct {
+	deliveries map[string]*domain.InboundDelivery
+	mu         sync.Mutex
+	logger     ports.Logger
+}
+
+func NewInboundDeliveryStore(logger ports.Logger) *InboundDeliveryStore {
+	return &InboundDeliveryStore{
+		deliveries: make(map[string]*domain.InboundDelivery),
+		logger:     logger,
+	}
+}
+
+func (s *InboundDeliveryStore) Reserve(ctx context.Context, delivery *domain.InboundDelivery) (*domain.InboundDelivery, bool, error) {
+	if delivery == nil || delivery.Key == "" {
+		return nil, false, errors.New("inbound delivery key cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.deliveries[delivery.Key]; exists && !existing.Expired(delivery.CreatedAt) {
+		clone := *existing
+		return &clone, false, nil
+	}
+
+	reserved := *delivery
+	reserved.TaskID = ""
+	s.deliveries[delivery.Key] = &reserved
+	return nil, true, nil
+}
+
+func (s *InboundDeliveryStore) Complete(ctx context.Context, key, taskID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.deliveries[key]
+	if !exists || existing.Accepted() {
+		return errors.New("inbound delivery key is not reserved")
+	}
+
+	existing.TaskID = taskID
+	existing.ExpiresAt = expiresAt
+	return nil
+}
+
+func (s *InboundDeliveryStore) Extend(ctx context.Context, key string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.deliveries[key]; exists && !existing.Accepted() {
+		existing.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+func (s *InboundDeliveryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.deliveries[key]; exists && !existing.Accepted() {
+		delete(s.deliveries, key)
+	}
+	return nil
+}
+
+func (s *InboundDeliveryStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, delivery := range s.deliveries {
+		if delivery.Expired(now) {
+			delete(s.deliveries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,117 @@
+// internal/infrastructure/persistence/channel/postgres/channel_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresInboundChannelRepository реализует ports.InboundChannelRepository для PostgreSQL
+type PostgresInboundChannelRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresInboundChannelRepository создает репозиторий входящих каналов
+func NewPostgresInboundChannelRepository(db *sqlx.DB) *PostgresInboundChannelRepository {
+	return &PostgresInboundChannelRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresInboundChannelRepository) Save(ctx context.Context, channel *domain.InboundChannel) error {
+	model, err := InboundChannelFromDomain(channel)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO inbound_channels (
+			key, name, source, secret, mapping, priority_map,
+			default_priority, default_tags, active, created_at, updated_at
+		) VALUES (
+			:key, :name, :source, :secret, :mapping, :priority_map,
+			:default_priority, :default_tags, :active, :created_at, :updated_at
+		)
+	`
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrInboundChannelExists
+		}
+		return fmt.Errorf("failed to save inbound channel: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresInboundChannelRepository) FindByKey(ctx context.Context, key string) (*domain.InboundChannel, error) {
+	var model InboundChannelModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM inbound_channels WHERE key = $1`, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInboundChannelNotFound
+		}
+		return nil, fmt.Errorf("failed to find inbound channel: %w", err)
+	}
+	return model.ToDomain()
+}
+
+func (r *PostgresInboundChannelRepository) FindAll(ctx context.Context) ([]domain.InboundChannel, error) {
+	var models []InboundChannelModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM inbound_channels ORDER BY key`); err != nil {
+		return nil, fmt.Errorf("failed to find inbound channels: %w", err)
+	}
+
+	channels := make([]domain.InboundChannel, 0, len(models))
+	for _, model := range models {
+		channel, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, *channel)
+	}
+	return channels, nil
+}
+
+func (r *PostgresInboundChannelRepository) Update(ctx context.Context, channel *domain.InboundChannel) error {
+	model, err := InboundChannelFromDomain(channel)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE inbound_channels SET
+			name = :name, secret = :secret, mapping = :mapping, priority_map = :priority_map,
+			default_priority = :default_priority, default_tags = :default_tags,
+			active = :active, updated_at = :updated_at
+		WHERE key = :key
+	`
+	result, err := r.db.NamedExecContext(ctx, query, model)
+	if err != nil {
+		return fmt.Errorf("failed to update inbound channel: %w", err)
+	}
+	return requireAffected(result)
+}
+
+func (r *PostgresInboundChannelRepository) Delete(ctx context.Context, key string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM inbound_channels WHERE key = $1`, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete inbound channel: %w", err)
+	}
+	return requireAffected(result)
+}
+
+func requireAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrInboundChannelNotFound
+	}
+	return nil
+}
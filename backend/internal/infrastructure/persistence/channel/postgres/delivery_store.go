@@ -0,0 +1,111 @@
+// internal/infrastructure/persistence/channel/postgres/delivery_store.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// reserveAttempts сколько раз повторить резервирование, если занявшая ключ запись
+// исчезла между INSERT и SELECT (освобождена или удалена очисткой)
+const reserveAttempts = 3
+
+// PostgresInboundDeliveryStore реализует ports.InboundDeliveryStore для PostgreSQL.
+// Ключи общие для всех экземпляров API, атомарность резервирования обеспечивает первичный ключ
+type PostgresInboundDeliveryStore struct {
+	db *sqlx.DB
+}
+
+// NewPostgresInboundDeliveryStore создает хранилище принятых записей входящих каналов
+func NewPostgresInboundDeliveryStore(db *sqlx.DB) *PostgresInboundDeliveryStore {
+	return &PostgresInboundDeliveryStore{
+		db: db,
+	}
+}
+
+func (s *PostgresInboundDeliveryStore) Reserve(ctx context.Context, delivery *domain.InboundDelivery) (*domain.InboundDelivery, bool, error) {
+	// Запись с истекшим сроком заменяется; действующая остается, и RETURNING ничего не возвращает
+	query := `
+		INSERT INTO inbound_deliveries (key, task_id, created_at, expires_at)
+		VALUES ($1, '', $2, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			task_id = '',
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE inbound_deliveries.expires_at <= EXCLUDED.created_at
+		RETURNING key
+	`
+	for range reserveAttempts {
+		rows, err := s.db.QueryContext(ctx, query, delivery.Key, delivery.CreatedAt, delivery.ExpiresAt)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to reserve inbound delivery key: %w", err)
+		}
+		reserved := rows.Next()
+		if err := rows.Close(); err != nil {
+			return nil, false, fmt.Errorf("failed to reserve inbound delivery key: %w", err)
+		}
+		if reserved {
+			return nil, true, nil
+		}
+
+		var existing InboundDeliveryModel
+		err = s.db.GetContext(ctx, &existing, `SELECT * FROM inbound_deliveries WHERE key = $1`, delivery.Key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find inbound delivery: %w", err)
+		}
+		return existing.ToDomain(), false, nil
+	}
+	return nil, false, fmt.Errorf("failed to reserve inbound delivery key after %d attempts", reserveAttempts)
+}
+
+func (s *PostgresInboundDeliveryStore) Complete(ctx context.Context, key, taskID string, expiresAt time.Time) error {
+	query := `UPDATE inbound_deliveries SET task_id = $2, expires_at = $3 WHERE key = $1 AND task_id = ''`
+	result, err := s.db.ExecContext(ctx, query, key, taskID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to store inbound delivery: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("inbound delivery key is not reserved")
+	}
+	return nil
+}
+
+func (s *PostgresInboundDeliveryStore) Extend(ctx context.Context, key string, expiresAt time.Time) error {
+	query := `UPDATE inbound_deliveries SET expires_at = $2 WHERE key = $1 AND task_id = ''`
+	if _, err := s.db.ExecContext(ctx, query, key, expiresAt); err != nil {
+		return fmt.Errorf("failed to extend inbound delivery lock: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresInboundDeliveryStore) Release(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM inbound_deliveries WHERE key = $1 AND task_id = ''`, key); err != nil {
+		return fmt.Errorf("failed to release inbound delivery key: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresInboundDeliveryStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM inbound_deliveries WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired inbound deliveries: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	return int(affected), nil
+}
@@ -0,0 +1,126 @@
+// internal/infrastructure/persistence/channel/postgres/models.go
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// InboundChannelModel представляет входящий канал в PostgreSQL
+type InboundChannelModel struct {
+	Key             string          `db:"key"`
+	Name            string          `db:"name"`
+	Source          string          `db:"source"`
+	Secret          string          `db:"secret"`
+	Mapping         json.RawMessage `db:"mapping"`
+	PriorityMap     json.RawMessage `db:"priority_map"`
+	DefaultPriority string          `db:"default_priority"`
+	DefaultTags     json.RawMessage `db:"default_tags"`
+	Active          bool            `db:"active"`
+	CreatedAt       time.Time       `db:"created_at"`
+	UpdatedAt       time.Time       `db:"updated_at"`
+}
+
+// inboundMappingModel JSON-представление выражений сопоставления
+type inboundMappingModel struct {
+	Subject          string `json:"subject"`
+	Description      string `json:"description,omitempty"`
+	CustomerEmail    string `json:"customer_email"`
+	CustomerName     string `json:"customer_name,omitempty"`
+	Priority         string `json:"priority,omitempty"`
+	Tags             string `json:"tags,omitempty"`
+	ExternalID       string `json:"external_id"`
+	ExternalThreadID string `json:"external_thread_id,omitempty"`
+}
+
+// InboundChannelFromDomain конвертирует domain сущность в PostgreSQL модель
+func InboundChannelFromDomain(channel *domain.InboundChannel) (*InboundChannelModel, error) {
+	mapping, err := json.Marshal(inboundMappingModel(channel.Mapping))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mapping: %w", err)
+	}
+
+	priorityMap := channel.PriorityMap
+	if priorityMap == nil {
+		priorityMap = map[string]domain.Priority{}
+	}
+	priorityMapJSON, err := json.Marshal(priorityMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal priority map: %w", err)
+	}
+
+	defaultTags := channel.DefaultTags
+	if defaultTags == nil {
+		defaultTags = []string{}
+	}
+	defaultTagsJSON, err := json.Marshal(defaultTags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal default tags: %w", err)
+	}
+
+	return &InboundChannelModel{
+		Key:             channel.Key,
+		Name:            channel.Name,
+		Source:          string(channel.Source),
+		Secret:          channel.Secret,
+		Mapping:         mapping,
+		PriorityMap:     priorityMapJSON,
+		DefaultPriority: string(channel.DefaultPriority),
+		DefaultTags:     defaultTagsJSON,
+		Active:          channel.Active,
+		CreatedAt:       channel.CreatedAt,
+		UpdatedAt:       channel.UpdatedAt,
+	}, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *InboundChannelModel) ToDomain() (*domain.InboundChannel, error) {
+	var mapping inboundMappingModel
+	if err := json.Unmarshal(m.Mapping, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mapping: %w", err)
+	}
+
+	channel := &domain.InboundChannel{
+		Key:             m.Key,
+		Name:            m.Name,
+		Source:          domain.TaskSource(m.Source),
+		Secret:          m.Secret,
+		Mapping:         domain.InboundMapping(mapping),
+		DefaultPriority: domain.Priority(m.DefaultPriority),
+		Active:          m.Active,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+	if len(m.PriorityMap) > 0 {
+		if err := json.Unmarshal(m.PriorityMap, &channel.PriorityMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal priority map: %w", err)
+		}
+	}
+	if len(m.DefaultTags) > 0 {
+		if err := json.Unmarshal(m.DefaultTags, &channel.DefaultTags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal default tags: %w", err)
+		}
+	}
+	return channel, nil
+}
+
+// InboundDeliveryModel представляет принятую запись входящего канала в PostgreSQL
+type InboundDeliveryModel struct {
+	Key       string    `db:"key"`
+	TaskID    string    `db:"task_id"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *InboundDeliveryModel) ToDomain() *domain.InboundDelivery {
+	return &domain.InboundDelivery{
+		Key:       m.Key,
+		TaskID:    m.TaskID,
+		CreatedAt: m.CreatedAt,
+		ExpiresAt: m.ExpiresAt,
+	}
+}
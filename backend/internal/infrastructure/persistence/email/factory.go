@@ -32,3 +32,18 @@ func NewEmailRepository(repoType RepositoryType, db *sqlx.DB) (ports.EmailReposi
 		return inmemory.NewInMemoryEmailRepo(), nil // ✅ Без ошибки для InMemory
 	}
 }
+
+// NewEmailSettingsRepository создает репозиторий настроек email на основе конфигурации
+func NewEmailSettingsRepository(repoType RepositoryType, db *sqlx.DB) (ports.EmailSettingsRepository, error) {
+	switch repoType {
+	case RepositoryTypePostgres:
+		if db == nil {
+			return nil, fmt.Errorf("database connection is required for PostgreSQL repository")
+		}
+		return postgres.NewPostgresEmailSettingsRepository(db), nil
+	case RepositoryTypeInMemory:
+		fallthrough
+	default:
+		return inmemory.NewEmailSettingsRepository(), nil
+	}
+}
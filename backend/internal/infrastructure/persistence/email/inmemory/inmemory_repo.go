@@ -2,10 +2,13 @@ package inmemory
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
 )
 
 // InMemoryEmailRepo реализует ports.EmailRepository для тестирования
@@ -121,6 +124,28 @@ func (r *InMemoryEmailRepo) FindByPeriod(ctx context.Context, from, to time.Time
 	return result, nil
 }
 
+// FindByQuery находит страницу сообщений по фильтрам в порядке query.Sort
+func (r *InMemoryEmailRepo) FindByQuery(ctx context.Context, query ports.EmailQuery) (*ports.EmailQueryPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.EmailMessage, 0, len(r.messages))
+	for _, msg := range r.messages {
+		if matchesEmailQuery(msg, query) {
+			result = append(result, *msg)
+		}
+	}
+
+	listing := domain.EmailListing(query.Sort)
+	listing.SortItems(result)
+	page, start, err := listing.Window(result, query.Cursor, query.Offset, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ports.EmailQueryPage{Emails: page, TotalCount: len(result), Start: start}, nil
+}
+
 // FindByInReplyTo находит сообщения по In-Reply-To
 func (r *InMemoryEmailRepo) FindByInReplyTo(ctx context.Context, inReplyTo string) ([]domain.EmailMessage, error) {
 	r.mu.RLock()
@@ -170,3 +195,29 @@ func (r *InMemoryEmailRepo) FindByRelatedTicket(ctx context.Context, ticketID st
 
 	return result, nil
 }
+
+// matchesEmailQuery проверяет соответствие сообщения фильтрам
+func matchesEmailQuery(msg *domain.EmailMessage, query ports.EmailQuery) bool {
+	if query.Processed != nil && msg.Processed != *query.Processed {
+		return false
+	}
+	if len(query.Directions) > 0 && !slices.Contains(query.Directions, msg.Direction) {
+		return false
+	}
+	if slices.Contains(query.ExcludeDirections, msg.Direction) {
+		return false
+	}
+	if len(query.Sources) > 0 && !slices.Contains(query.Sources, msg.Source) {
+		return false
+	}
+	if slices.Contains(query.ExcludeSources, msg.Source) {
+		return false
+	}
+	if query.From != "" && !strings.EqualFold(string(msg.From), query.From) {
+		return false
+	}
+	if query.RelatedTicketID != nil && (msg.RelatedTicketID == nil || *msg.RelatedTicketID != *query.RelatedTicketID) {
+		return false
+	}
+	return query.CreatedAt.Includes(&msg.CreatedAt)
+}
@@ -0,0 +1,122 @@
+// internal/infrastructure/persistence/email/inmemory/settings_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// EmailSettingsRepository настройки email в памяти
+type EmailSettingsRepository struct {
+	mu           sync.RWMutex
+	channels     map[string]domain.EmailChannelConfig
+	policy       *domain.EmailProcessingPolicy
+	threadSearch *ports.ThreadSearchConfig
+}
+
+// NewEmailSettingsRepository создает пустое хранилище настроек
+func NewEmailSettingsRepository() *EmailSettingsRepository {
+	return &EmailSettingsRepository{
+		channels: make(map[string]domain.EmailChannelConfig),
+	}
+}
+
+func (r *EmailSettingsRepository) SaveChannel(ctx context.Context, config *domain.EmailChannelConfig) error {
+	if config == nil || config.ID == "" {
+		return errors.New("email channel id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.channels[config.ID] = *config
+	return nil
+}
+
+func (r *EmailSettingsRepository) FindChannel(ctx context.Context, id string) (*domain.EmailChannelConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	config, exists := r.channels[id]
+	if !exists {
+		return nil, domain.ErrEmailChannelNotFound
+	}
+	return &config, nil
+}
+
+func (r *EmailSettingsRepository) FindChannels(ctx context.Context) ([]domain.EmailChannelConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	configs := make([]domain.EmailChannelConfig, 0, len(r.channels))
+	for _, config := range r.channels {
+		configs = append(configs, config)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].ID < configs[j].ID })
+	return configs, nil
+}
+
+func (r *EmailSettingsRepository) DeleteChannel(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.channels[id]; !exists {
+		return domain.ErrEmailChannelNotFound
+	}
+	delete(r.channels, id)
+	return nil
+}
+
+func (r *EmailSettingsRepository) FindPolicy(ctx context.Context) (*domain.EmailProcessingPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.policy == nil {
+		return nil, domain.ErrEmailSettingsNotFound
+	}
+	policy := *r.policy
+	policy.AllowedSenders = slices.Clone(r.policy.AllowedSenders)
+	policy.BlockedSenders = slices.Clone(r.policy.BlockedSenders)
+	return &policy, nil
+}
+
+func (r *EmailSettingsRepository) SavePolicy(ctx context.Context, policy *domain.EmailProcessingPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *policy
+	saved.AllowedSenders = slices.Clone(policy.AllowedSenders)
+	saved.BlockedSenders = slices.Clone(policy.BlockedSenders)
+	r.policy = &saved
+	return nil
+}
+
+func (r *EmailSettingsRepository) FindThreadSearch(ctx context.Context) (*ports.ThreadSearchConfig, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.threadSearch == nil {
+		return nil, domain.ErrEmailSettingsNotFound
+	}
+	config := *r.threadSearch
+	config.SubjectPrefixes = slices.Clone(r.threadSearch.SubjectPrefixes)
+	return &config, nil
+}
+
+func (r *EmailSettingsRepository) SaveThreadSearch(ctx context.Context, config *ports.ThreadSearchConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *config
+	saved.SubjectPrefixes = slices.Clone(config.SubjectPrefixes)
+	r.threadSearch = &saved
+	return nil
+}
+
+var _ ports.EmailSettingsRepository = (*EmailSettingsRepository)(nil)
@@ -30,6 +30,7 @@ type EmailMessageModel struct {
 	Processed       bool            `db:"processed"`
 	ProcessedAt     sql.NullTime    `db:"processed_at"` // Меняем на sql.NullTime
 	RelatedTicketID *string         `db:"related_ticket_id"`
+	RawMessage      []byte          `db:"raw_message"`
 	CreatedAt       time.Time       `db:"created_at"`
 	UpdatedAt       time.Time       `db:"updated_at"`
 }
@@ -118,6 +119,7 @@ func (m *EmailMessageModel) ToDomain() (*domain.EmailMessage, error) {
 		Processed:       m.Processed,
 		ProcessedAt:     processedAt, // Теперь time.Time
 		RelatedTicketID: m.RelatedTicketID,
+		RawMessage:      m.RawMessage,
 		CreatedAt:       m.CreatedAt,
 		UpdatedAt:       m.UpdatedAt,
 	}
@@ -192,6 +194,7 @@ func FromDomain(msg *domain.EmailMessage) (*EmailMessageModel, error) {
 		Processed:       msg.Processed,
 		ProcessedAt:     processedAt,
 		RelatedTicketID: msg.RelatedTicketID,
+		RawMessage:      msg.RawMessage,
 		CreatedAt:       msg.CreatedAt,
 		UpdatedAt:       msg.UpdatedAt,
 	}
@@ -6,10 +6,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
 	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/persistence/transaction"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
@@ -39,9 +42,9 @@ func (r *PostgresEmailRepository) Save(ctx context.Context, msg *domain.EmailMes
 			id, message_id, in_reply_to, thread_id, from_email, to_emails, 
 			cc_emails, bcc_emails, subject, body_text, body_html, direction,
 			source, headers, processed, processed_at, related_ticket_id,
-			created_at, updated_at
+			created_at, updated_at, raw_message
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		)
 		ON CONFLICT (message_id) 
 		DO UPDATE SET
@@ -60,10 +63,11 @@ func (r *PostgresEmailRepository) Save(ctx context.Context, msg *domain.EmailMes
 			processed = EXCLUDED.processed,
 			processed_at = EXCLUDED.processed_at,
 			related_ticket_id = EXCLUDED.related_ticket_id,
-			updated_at = EXCLUDED.updated_at
+			updated_at = EXCLUDED.updated_at,
+			raw_message = COALESCE(EXCLUDED.raw_message, email_messages.raw_message)
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = transaction.Executor(ctx, r.db).ExecContext(ctx, query,
 		model.ID,
 		model.MessageID,
 		nullString(model.InReplyTo),
@@ -83,6 +87,7 @@ func (r *PostgresEmailRepository) Save(ctx context.Context, msg *domain.EmailMes
 		nullStringPtr(model.RelatedTicketID),
 		model.CreatedAt,
 		model.UpdatedAt,
+		model.RawMessage,
 	)
 
 	if err != nil {
@@ -167,7 +172,7 @@ func (r *PostgresEmailRepository) Update(ctx context.Context, msg *domain.EmailM
 		WHERE id = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := transaction.Executor(ctx, r.db).ExecContext(ctx, query,
 		model.ID,
 		nullString(model.InReplyTo),
 		nullString(model.ThreadID),
@@ -257,6 +262,171 @@ func (r *PostgresEmailRepository) FindByPeriod(ctx context.Context, from, to tim
 	return r.convertModelsToDomain(models)
 }
 
+// emailSortColumns колонки для полей сортировки писем (domain.EmailSortFields).
+// Выражения совпадают с domain.EmailSortKey: строки сравниваются побайтно (COLLATE "C"),
+// как ключи курсора, поэтому порядок в запросе и позиция курсора согласованы
+var emailSortColumns = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"processed_at": "processed_at",
+	"subject":      `LOWER(COALESCE(subject, '')) COLLATE "C"`,
+	"from":         `LOWER(from_email) COLLATE "C"`,
+}
+
+// emailTimeSortFields поля сортировки, ключи курсора которых - моменты времени
+var emailTimeSortFields = map[string]bool{
+	"created_at":   true,
+	"updated_at":   true,
+	"processed_at": true,
+}
+
+// FindByQuery находит страницу сообщений по фильтрам в порядке query.Sort.
+// Позиция курсора и LIMIT применяются в запросе, поэтому в память загружается только страница
+func (r *PostgresEmailRepository) FindByQuery(ctx context.Context, query ports.EmailQuery) (*ports.EmailQueryPage, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(expr string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(expr, len(args)))
+	}
+
+	if query.Processed != nil {
+		addCondition("processed = $%d", *query.Processed)
+	}
+	if len(query.Directions) > 0 {
+		addCondition("direction = ANY($%d)", pq.Array(directionStrings(query.Directions)))
+	}
+	if len(query.ExcludeDirections) > 0 {
+		addCondition("direction <> ALL($%d)", pq.Array(directionStrings(query.ExcludeDirections)))
+	}
+	if len(query.Sources) > 0 {
+		addCondition("source = ANY($%d)", pq.Array(query.Sources))
+	}
+	if len(query.ExcludeSources) > 0 {
+		addCondition("source <> ALL($%d)", pq.Array(query.ExcludeSources))
+	}
+	if query.From != "" {
+		addCondition("LOWER(from_email) = LOWER($%d)", query.From)
+	}
+	if query.RelatedTicketID != nil {
+		addCondition("related_ticket_id = $%d", *query.RelatedTicketID)
+	}
+	if query.CreatedAt.From != nil {
+		addCondition("created_at >= $%d", *query.CreatedAt.From)
+	}
+	if query.CreatedAt.To != nil {
+		addCondition("created_at <= $%d", *query.CreatedAt.To)
+	}
+
+	where := "TRUE"
+	if len(conditions) > 0 {
+		where = strings.Join(conditions, " AND ")
+	}
+
+	var order []string
+	for _, field := range query.Sort {
+		column, ok := emailSortColumns[field.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", domain.ErrInvalidSort, field.Field)
+		}
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		order = append(order, fmt.Sprintf("%s %s NULLS LAST", column, direction))
+	}
+	order = append(order, "id ASC")
+
+	// after - письма после курсора; без курсора страница начинается со смещения
+	after := "TRUE"
+	if query.Cursor != "" {
+		cursor, err := domain.DecodePageCursor(query.Cursor, query.Sort)
+		if err != nil {
+			return nil, err
+		}
+		if after, args, err = emailKeysetCondition(query.Sort, cursor, args); err != nil {
+			return nil, err
+		}
+	}
+
+	var counts struct {
+		Total     int `db:"total"`
+		Remaining int `db:"remaining"`
+	}
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE %s) AS remaining
+		FROM email_messages WHERE %s`, after, where)
+	if err := r.db.GetContext(ctx, &counts, countQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to count emails by query: %w", err)
+	}
+
+	page := &ports.EmailQueryPage{TotalCount: counts.Total, Start: counts.Total - counts.Remaining}
+	selectQuery := fmt.Sprintf("SELECT * FROM email_messages WHERE %s AND %s ORDER BY %s",
+		where, after, strings.Join(order, ", "))
+	if query.Cursor == "" {
+		page.Start = max(0, min(query.Offset, counts.Total))
+		args = append(args, page.Start)
+		selectQuery += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		selectQuery += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	var models []EmailMessageModel
+	if err := r.db.SelectContext(ctx, &models, selectQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to find emails by query: %w", err)
+	}
+
+	emails, err := r.convertModelsToDomain(models)
+	if err != nil {
+		return nil, err
+	}
+	page.Emails = emails
+	return page, nil
+}
+
+// emailKeysetCondition строит условие "после курсора" для порядка sort: письмо идет после
+// курсора, если совпадает с ним по первым ключам и следует за ним по очередному ключу.
+// Письма без значения ключа идут в конце (NULLS LAST), при равных ключах порядок определяет ID
+func emailKeysetCondition(sort []domain.SortField, cursor *domain.PageCursor, args []interface{}) (string, []interface{}, error) {
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	var alternatives, equal []string
+	for i, field := range sort {
+		column := emailSortColumns[field.Field]
+		key := cursor.Keys[i]
+		if key == nil {
+			// После письма без значения идут только письма без значения с большим ID
+			equal = append(equal, column+" IS NULL")
+			continue
+		}
+
+		var value interface{} = *key
+		if emailTimeSortFields[field.Field] {
+			t, err := domain.ParseSortKeyTime(*key)
+			if err != nil {
+				return "", nil, err
+			}
+			value = t
+		}
+		param := placeholder(value)
+		operator := ">"
+		if field.Desc {
+			operator = "<"
+		}
+		next := fmt.Sprintf("(%s %s %s OR %s IS NULL)", column, operator, param, column)
+		alternatives = append(alternatives, strings.Join(append(slices.Clone(equal), next), " AND "))
+		equal = append(equal, fmt.Sprintf("%s = %s", column, param))
+	}
+	alternatives = append(alternatives,
+		strings.Join(append(equal, "id > "+placeholder(cursor.ID)), " AND "))
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+}
+
 // FindByInReplyTo находит сообщения по In-Reply-To
 func (r *PostgresEmailRepository) FindByInReplyTo(ctx context.Context, inReplyTo string) ([]domain.EmailMessage, error) {
 	var models []EmailMessageModel
@@ -315,6 +485,14 @@ func (r *PostgresEmailRepository) convertModelsToDomain(models []EmailMessageMod
 	return result, nil
 }
 
+func directionStrings(directions []domain.Direction) []string {
+	result := make([]string, len(directions))
+	for i, direction := range directions {
+		result[i] = string(direction)
+	}
+	return result
+}
+
 // nullString возвращает sql.NullString для пустых строк
 func nullString(s string) sql.NullString {
 	if s == "" {
@@ -192,6 +192,42 @@ func (suite *PostgresEmailRepositoryTestSuite) TestQueryOperations() {
 	assert.Equal(t, "msg2@test.local", replies[0].MessageID)
 }
 
+// TestFindByQueryPagination тестирует выборку страниц по курсору в запросе
+func (suite *PostgresEmailRepositoryTestSuite) TestFindByQueryPagination() {
+	t := suite.T()
+	ctx := suite.ctx
+
+	for _, msg := range suite.testMessages {
+		require.NoError(t, suite.repo.Save(ctx, msg))
+	}
+
+	// processed_at есть только у одного письма: письма без значения идут в конце
+	sort := []domain.SortField{{Field: "processed_at", Desc: true}, {Field: "created_at", Desc: true}}
+	listing := domain.EmailListing(sort)
+
+	first, err := suite.repo.FindByQuery(ctx, ports.EmailQuery{Sort: sort, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, first.TotalCount)
+	assert.Equal(t, 0, first.Start)
+	require.Len(t, first.Emails, 2)
+	assert.Equal(t, suite.testMessages[1].ID, first.Emails[0].ID)
+	assert.Equal(t, suite.testMessages[2].ID, first.Emails[1].ID)
+
+	second, err := suite.repo.FindByQuery(ctx, ports.EmailQuery{
+		Sort:   sort,
+		Cursor: listing.Cursor(&first.Emails[1]),
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Start, "position is counted in SQL")
+	require.Len(t, second.Emails, 1)
+	assert.Equal(t, suite.testMessages[0].ID, second.Emails[0].ID)
+
+	bySubject := []domain.SortField{{Field: "subject"}}
+	_, err = suite.repo.FindByQuery(ctx, ports.EmailQuery{Sort: bySubject, Cursor: listing.Cursor(&first.Emails[1])})
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
 // TestEdgeCases тестирует граничные случаи
 func (suite *PostgresEmailRepositoryTestSuite) TestEdgeCases() {
 	t := suite.T()
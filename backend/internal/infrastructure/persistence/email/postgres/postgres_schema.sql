@@ -20,7 +20,8 @@ CREATE TABLE IF NOT EXISTS email_messages (
     processed_at TIMESTAMP WITH TIME ZONE,
     related_ticket_id VARCHAR(100),
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    raw_message BYTEA
 );
 
 -- Таблица для вложений
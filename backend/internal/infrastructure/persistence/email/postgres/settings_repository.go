@@ -0,0 +1,275 @@
+// internal/infrastructure/persistence/email/postgres/settings_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/jmoiron/sqlx"
+)
+
+// Ключи таблицы email_settings
+const (
+	emailSettingsPolicyKey       = "policy"
+	emailSettingsThreadSearchKey = "thread_search"
+)
+
+// PostgresEmailSettingsRepository реализует ports.EmailSettingsRepository для PostgreSQL
+type PostgresEmailSettingsRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresEmailSettingsRepository создает репозиторий настроек email
+func NewPostgresEmailSettingsRepository(db *sqlx.DB) *PostgresEmailSettingsRepository {
+	return &PostgresEmailSettingsRepository{
+		db: db,
+	}
+}
+
+// EmailChannelConfigModel представляет конфигурацию канала в PostgreSQL
+type EmailChannelConfigModel struct {
+	ID             string    `db:"id"`
+	Name           string    `db:"name"`
+	Provider       string    `db:"provider"`
+	Server         string    `db:"server"`
+	Port           int       `db:"port"`
+	Username       string    `db:"username"`
+	Password       string    `db:"password"`
+	Mailbox        string    `db:"mailbox"`
+	SSL            bool      `db:"ssl"`
+	PollIntervalMs int64     `db:"poll_interval_ms"`
+	Active         bool      `db:"active"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// emailPolicyModel JSON-представление политики обработки
+type emailPolicyModel struct {
+	ReadOnlyMode   bool     `json:"read_only_mode"`
+	AutoReply      bool     `json:"auto_reply"`
+	AutoReplyText  string   `json:"auto_reply_text,omitempty"`
+	SpamFilter     bool     `json:"spam_filter"`
+	MaxMessageSize int64    `json:"max_message_size"`
+	AllowedSenders []string `json:"allowed_senders"`
+	BlockedSenders []string `json:"blocked_senders"`
+}
+
+// threadSearchModel JSON-представление окон поиска тредов
+type threadSearchModel struct {
+	DefaultDaysBack     int      `json:"default_days_back"`
+	ExtendedDaysBack    int      `json:"extended_days_back"`
+	MaxDaysBack         int      `json:"max_days_back"`
+	FetchTimeoutMs      int64    `json:"fetch_timeout_ms"`
+	IncludeSeenMessages bool     `json:"include_seen_messages"`
+	SubjectPrefixes     []string `json:"subject_prefixes"`
+}
+
+func (r *PostgresEmailSettingsRepository) SaveChannel(ctx context.Context, config *domain.EmailChannelConfig) error {
+	model := EmailChannelConfigModel{
+		ID:             config.ID,
+		Name:           config.Name,
+		Provider:       config.Provider,
+		Server:         config.Server,
+		Port:           config.Port,
+		Username:       config.Username,
+		Password:       config.Password,
+		Mailbox:        config.Mailbox,
+		SSL:            config.SSL,
+		PollIntervalMs: config.PollInterval.Milliseconds(),
+		Active:         config.Active,
+		UpdatedAt:      config.UpdatedAt,
+	}
+
+	query := `
+		INSERT INTO email_channel_configs (
+			id, name, provider, server, port, username, password,
+			mailbox, ssl, poll_interval_ms, active, updated_at
+		) VALUES (
+			:id, :name, :provider, :server, :port, :username, :password,
+			:mailbox, :ssl, :poll_interval_ms, :active, :updated_at
+		)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			provider = EXCLUDED.provider,
+			server = EXCLUDED.server,
+			port = EXCLUDED.port,
+			username = EXCLUDED.username,
+			password = EXCLUDED.password,
+			mailbox = EXCLUDED.mailbox,
+			ssl = EXCLUDED.ssl,
+			poll_interval_ms = EXCLUDED.poll_interval_ms,
+			active = EXCLUDED.active,
+			updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save email channel config: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresEmailSettingsRepository) FindChannel(ctx context.Context, id string) (*domain.EmailChannelConfig, error) {
+	var model EmailChannelConfigModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM email_channel_configs WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrEmailChannelNotFound
+		}
+		return nil, fmt.Errorf("failed to find email channel config: %w", err)
+	}
+	config := model.toDomain()
+	return &config, nil
+}
+
+func (r *PostgresEmailSettingsRepository) FindChannels(ctx context.Context) ([]domain.EmailChannelConfig, error) {
+	var models []EmailChannelConfigModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM email_channel_configs ORDER BY id`); err != nil {
+		return nil, fmt.Errorf("failed to find email channel configs: %w", err)
+	}
+
+	configs := make([]domain.EmailChannelConfig, len(models))
+	for i, model := range models {
+		configs[i] = model.toDomain()
+	}
+	return configs, nil
+}
+
+func (r *PostgresEmailSettingsRepository) DeleteChannel(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM email_channel_configs WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete email channel config: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrEmailChannelNotFound
+	}
+	return nil
+}
+
+func (r *PostgresEmailSettingsRepository) FindPolicy(ctx context.Context) (*domain.EmailProcessingPolicy, error) {
+	var model emailPolicyModel
+	if err := r.findSetting(ctx, emailSettingsPolicyKey, &model); err != nil {
+		return nil, err
+	}
+
+	policy := &domain.EmailProcessingPolicy{
+		ReadOnlyMode:   model.ReadOnlyMode,
+		AutoReply:      model.AutoReply,
+		AutoReplyText:  model.AutoReplyText,
+		SpamFilter:     model.SpamFilter,
+		MaxMessageSize: model.MaxMessageSize,
+		AllowedSenders: toEmailAddresses(model.AllowedSenders),
+		BlockedSenders: toEmailAddresses(model.BlockedSenders),
+	}
+	return policy, nil
+}
+
+func (r *PostgresEmailSettingsRepository) SavePolicy(ctx context.Context, policy *domain.EmailProcessingPolicy) error {
+	return r.saveSetting(ctx, emailSettingsPolicyKey, emailPolicyModel{
+		ReadOnlyMode:   policy.ReadOnlyMode,
+		AutoReply:      policy.AutoReply,
+		AutoReplyText:  policy.AutoReplyText,
+		SpamFilter:     policy.SpamFilter,
+		MaxMessageSize: policy.MaxMessageSize,
+		AllowedSenders: fromEmailAddresses(policy.AllowedSenders),
+		BlockedSenders: fromEmailAddresses(policy.BlockedSenders),
+	})
+}
+
+func (r *PostgresEmailSettingsRepository) FindThreadSearch(ctx context.Context) (*ports.ThreadSearchConfig, error) {
+	var model threadSearchModel
+	if err := r.findSetting(ctx, emailSettingsThreadSearchKey, &model); err != nil {
+		return nil, err
+	}
+
+	return &ports.ThreadSearchConfig{
+		DefaultDaysBack:     model.DefaultDaysBack,
+		ExtendedDaysBack:    model.ExtendedDaysBack,
+		MaxDaysBack:         model.MaxDaysBack,
+		FetchTimeout:        time.Duration(model.FetchTimeoutMs) * time.Millisecond,
+		IncludeSeenMessages: model.IncludeSeenMessages,
+		SubjectPrefixes:     model.SubjectPrefixes,
+	}, nil
+}
+
+func (r *PostgresEmailSettingsRepository) SaveThreadSearch(ctx context.Context, config *ports.ThreadSearchConfig) error {
+	return r.saveSetting(ctx, emailSettingsThreadSearchKey, threadSearchModel{
+		DefaultDaysBack:     config.DefaultDaysBack,
+		ExtendedDaysBack:    config.ExtendedDaysBack,
+		MaxDaysBack:         config.MaxDaysBack,
+		FetchTimeoutMs:      config.FetchTimeout.Milliseconds(),
+		IncludeSeenMessages: config.IncludeSeenMessages,
+		SubjectPrefixes:     config.SubjectPrefixes,
+	})
+}
+
+func (r *PostgresEmailSettingsRepository) findSetting(ctx context.Context, key string, target interface{}) error {
+	var value []byte
+	if err := r.db.GetContext(ctx, &value, `SELECT value FROM email_settings WHERE key = $1`, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrEmailSettingsNotFound
+		}
+		return fmt.Errorf("failed to find email setting %s: %w", key, err)
+	}
+	if err := json.Unmarshal(value, target); err != nil {
+		return fmt.Errorf("failed to unmarshal email setting %s: %w", key, err)
+	}
+	return nil
+}
+
+func (r *PostgresEmailSettingsRepository) saveSetting(ctx context.Context, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal email setting %s: %w", key, err)
+	}
+
+	query := `
+		INSERT INTO email_settings (key, value, updated_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, key, data); err != nil {
+		return fmt.Errorf("failed to save email setting %s: %w", key, err)
+	}
+	return nil
+}
+
+func (m EmailChannelConfigModel) toDomain() domain.EmailChannelConfig {
+	return domain.EmailChannelConfig{
+		ID:           m.ID,
+		Name:         m.Name,
+		Provider:     m.Provider,
+		Server:       m.Server,
+		Port:         m.Port,
+		Username:     m.Username,
+		Password:     m.Password,
+		Mailbox:      m.Mailbox,
+		SSL:          m.SSL,
+		PollInterval: time.Duration(m.PollIntervalMs) * time.Millisecond,
+		Active:       m.Active,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func toEmailAddresses(values []string) []domain.EmailAddress {
+	addresses := make([]domain.EmailAddress, len(values))
+	for i, value := range values {
+		addresses[i] = domain.EmailAddress(value)
+	}
+	return addresses
+}
+
+func fromEmailAddresses(addresses []domain.EmailAddress) []string {
+	values := make([]string, len(addresses))
+	for i, address := range addresses {
+		values[i] = string(address)
+	}
+	return values
+}
+
+var _ ports.EmailSettingsRepository = (*PostgresEmailSettingsRepository)(nil)
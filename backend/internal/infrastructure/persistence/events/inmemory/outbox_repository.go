@@ -0,0 +1,152 @@
+// internal/infrastructure/persistence/events/inmemory/outbox_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// OutboxRepository outbox в памяти; порядок выдачи совпадает с порядком записи
+type OutboxRepository struct {
+	messages map[string]*domain.OutboxMessage
+	order    []string
+	mu       sync.RWMutex
+	logger   ports.Logger
+}
+
+func NewOutboxRepository(logger ports.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		messages: make(map[string]*domain.OutboxMessage),
+		logger:   logger,
+	}
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, messages ...domain.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, message := range messages {
+		if message.Event.ID == "" {
+			return errors.New("outbox event ID cannot be empty")
+		}
+		if _, exists := r.messages[message.Event.ID]; exists {
+			return fmt.Errorf("outbox event already exists: %s", message.Event.ID)
+		}
+	}
+
+	for _, message := range messages {
+		stored := cloneOutboxMessage(message)
+		r.messages[message.Event.ID] = &stored
+		r.order = append(r.order, message.Event.ID)
+	}
+
+	r.logger.Debug(ctx, "outbox events appended", "count", len(messages))
+	return nil
+}
+
+func (r *OutboxRepository) ClaimPending(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]domain.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	claimed := []domain.OutboxMessage{}
+	for _, id := range r.order {
+		if limit > 0 && len(claimed) >= limit {
+			break
+		}
+		message := r.messages[id]
+		if message.Status != domain.OutboxStatusPending || message.NextAttemptAt.After(now) {
+			continue
+		}
+		message.NextAttemptAt = leaseUntil
+		claimed = append(claimed, cloneOutboxMessage(*message))
+	}
+
+	return claimed, nil
+}
+
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, eventID string, at time.Time) error {
+	return r.update(eventID, func(message *domain.OutboxMessage) {
+		message.Status = domain.OutboxStatusDispatched
+		message.DispatchedAt = &at
+		message.LastError = ""
+	})
+}
+
+func (r *OutboxRepository) Reschedule(ctx context.Context, eventID string, lastError string, nextAttemptAt time.Time) error {
+	return r.update(eventID, func(message *domain.OutboxMessage) {
+		message.Attempts++
+		message.LastError = lastError
+		message.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, eventID string, lastError string) error {
+	return r.update(eventID, func(message *domain.OutboxMessage) {
+		message.Status = domain.OutboxStatusFailed
+		message.Attempts++
+		message.LastError = lastError
+	})
+}
+
+func (r *OutboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.order[:0]
+	deleted := 0
+	for _, id := range r.order {
+		message := r.messages[id]
+		if message.Status == domain.OutboxStatusDispatched && message.DispatchedAt != nil && message.DispatchedAt.Before(before) {
+			delete(r.messages, id)
+			deleted++
+			continue
+		}
+		kept = append(kept, id)
+	}
+	r.order = kept
+
+	return deleted, nil
+}
+
+// FindAll возвращает все события outbox в порядке записи
+func (r *OutboxRepository) FindAll(ctx context.Context) []domain.OutboxMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.OutboxMessage, 0, len(r.order))
+	for _, id := range r.order {
+		result = append(result, cloneOutboxMessage(*r.messages[id]))
+	}
+	return result
+}
+
+func (r *OutboxRepository) update(eventID string, apply func(message *domain.OutboxMessage)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, exists := r.messages[eventID]
+	if !exists {
+		return fmt.Errorf("outbox event not found: %s", eventID)
+	}
+	apply(message)
+	return nil
+}
+
+func cloneOutboxMessage(message domain.OutboxMessage) domain.OutboxMessage {
+	data := make(map[string]string, len(message.Event.Data))
+	for key, value := range message.Event.Data {
+		data[key] = value
+	}
+	message.Event.Data = data
+	if message.DispatchedAt != nil {
+		dispatchedAt := *message.DispatchedAt
+		message.DispatchedAt = &dispatchedAt
+	}
+	return message
+}
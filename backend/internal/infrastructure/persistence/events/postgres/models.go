@@ -0,0 +1,94 @@
+// internal/infrastructure/persistence/events/postgres/models.go
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// OutboxEventModel представляет событие outbox в PostgreSQL
+type OutboxEventModel struct {
+	ID            string          `db:"id"`
+	EventType     string          `db:"event_type"`
+	AggregateType string          `db:"aggregate_type"`
+	AggregateID   string          `db:"aggregate_id"`
+	ActorID       string          `db:"actor_id"`
+	CorrelationID string          `db:"correlation_id"`
+	Data          json.RawMessage `db:"data"`
+	OccurredAt    time.Time       `db:"occurred_at"`
+	Status        string          `db:"status"`
+	Attempts      int             `db:"attempts"`
+	LastError     string          `db:"last_error"`
+	NextAttemptAt time.Time       `db:"next_attempt_at"`
+	DispatchedAt  sql.NullTime    `db:"dispatched_at"`
+	CreatedAt     time.Time       `db:"created_at"`
+}
+
+// OutboxEventFromDomain конвертирует domain сущность в PostgreSQL модель
+func OutboxEventFromDomain(message domain.OutboxMessage) (*OutboxEventModel, error) {
+	data := message.Event.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	model := &OutboxEventModel{
+		ID:            message.Event.ID,
+		EventType:     string(message.Event.Type),
+		AggregateType: message.Event.AggregateType,
+		AggregateID:   message.Event.AggregateID,
+		ActorID:       message.Event.ActorID,
+		CorrelationID: message.Event.CorrelationID,
+		Data:          dataJSON,
+		OccurredAt:    message.Event.OccurredAt,
+		Status:        string(message.Status),
+		Attempts:      message.Attempts,
+		LastError:     message.LastError,
+		NextAttemptAt: message.NextAttemptAt,
+		CreatedAt:     message.CreatedAt,
+	}
+	if message.DispatchedAt != nil {
+		model.DispatchedAt = sql.NullTime{Time: *message.DispatchedAt, Valid: true}
+	}
+	return model, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *OutboxEventModel) ToDomain() (domain.OutboxMessage, error) {
+	data := map[string]string{}
+	if len(m.Data) > 0 {
+		if err := json.Unmarshal(m.Data, &data); err != nil {
+			return domain.OutboxMessage{}, fmt.Errorf("failed to unmarshal event data: %w", err)
+		}
+	}
+
+	message := domain.OutboxMessage{
+		Event: domain.DomainEvent{
+			ID:            m.ID,
+			Type:          domain.DomainEventType(m.EventType),
+			AggregateType: m.AggregateType,
+			AggregateID:   m.AggregateID,
+			ActorID:       m.ActorID,
+			CorrelationID: m.CorrelationID,
+			Data:          data,
+			OccurredAt:    m.OccurredAt,
+		},
+		Status:        domain.OutboxStatus(m.Status),
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		NextAttemptAt: m.NextAttemptAt,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.DispatchedAt.Valid {
+		dispatchedAt := m.DispatchedAt.Time
+		message.DispatchedAt = &dispatchedAt
+	}
+	return message, nil
+}
@@ -0,0 +1,142 @@
+// internal/infrastructure/persistence/events/postgres/outbox_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/infrastructure/persistence/transaction"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresOutboxRepository реализует ports.OutboxRepository для PostgreSQL.
+// Append пишет в транзакцию единицы работы из context, если она открыта
+type PostgresOutboxRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresOutboxRepository создает репозиторий outbox
+func NewPostgresOutboxRepository(db *sqlx.DB) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{
+		db: db,
+	}
+}
+
+// Append сохраняет события вместе с изменением, которое их породило
+func (r *PostgresOutboxRepository) Append(ctx context.Context, messages ...domain.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO outbox_events (
+			id, event_type, aggregate_type, aggregate_id, actor_id, correlation_id,
+			data, occurred_at, status, attempts, last_error, next_attempt_at,
+			dispatched_at, created_at
+		) VALUES (
+			:id, :event_type, :aggregate_type, :aggregate_id, :actor_id, :correlation_id,
+			:data, :occurred_at, :status, :attempts, :last_error, :next_attempt_at,
+			:dispatched_at, :created_at
+		)
+	`
+
+	return transaction.Run(ctx, r.db, func(tx sqlx.ExtContext) error {
+		for _, message := range messages {
+			model, err := OutboxEventFromDomain(message)
+			if err != nil {
+				return err
+			}
+			if _, err := sqlx.NamedExecContext(ctx, tx, query, model); err != nil {
+				return fmt.Errorf("failed to append outbox event: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimPending арендует пачку событий; SKIP LOCKED позволяет запускать несколько ретрансляторов
+func (r *PostgresOutboxRepository) ClaimPending(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]domain.OutboxMessage, error) {
+	query := `
+		UPDATE outbox_events SET next_attempt_at = $3
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY created_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`
+
+	var models []OutboxEventModel
+	if err := r.db.SelectContext(ctx, &models, query, now, limit, leaseUntil); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	messages := make([]domain.OutboxMessage, 0, len(models))
+	for _, model := range models {
+		message, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	sortOutboxMessages(messages)
+	return messages, nil
+}
+
+func (r *PostgresOutboxRepository) MarkDispatched(ctx context.Context, eventID string, at time.Time) error {
+	query := `UPDATE outbox_events SET status = 'dispatched', dispatched_at = $2, last_error = '' WHERE id = $1`
+	return r.exec(ctx, query, eventID, at)
+}
+
+func (r *PostgresOutboxRepository) Reschedule(ctx context.Context, eventID string, lastError string, nextAttemptAt time.Time) error {
+	query := `UPDATE outbox_events SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`
+	return r.exec(ctx, query, eventID, lastError, nextAttemptAt)
+}
+
+func (r *PostgresOutboxRepository) MarkFailed(ctx context.Context, eventID string, lastError string) error {
+	query := `UPDATE outbox_events SET status = 'failed', attempts = attempts + 1, last_error = $2 WHERE id = $1`
+	return r.exec(ctx, query, eventID, lastError)
+}
+
+func (r *PostgresOutboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM outbox_events WHERE status = 'dispatched' AND dispatched_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete dispatched outbox events: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func (r *PostgresOutboxRepository) exec(ctx context.Context, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox event: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("outbox event not found: %v", args[0])
+	}
+	return nil
+}
+
+func sortOutboxMessages(messages []domain.OutboxMessage) {
+	sort.Slice(messages, func(i, j int) bool {
+		if !messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
+			return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+		}
+		return messages[i].Event.ID < messages[j].Event.ID
+	})
+}
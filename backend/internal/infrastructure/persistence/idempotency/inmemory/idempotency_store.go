@@ -0,0 +1,105 @@
+// internal/infrastructure/persistence/idempotency/inmemory/idempotency_store.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// IdempotencyStore хранилище ответов на идемпотентные запросы в памяти.
+// Подходит для одного экземпляра API: ключи не видны другим процессам
+type IdempotencyStore struct {
+	records map[string]*domain.IdempotencyRecord
+	mu      sync.Mutex
+	logger  ports.Logger
+}
+
+func NewIdempotencyStore(logger ports.Logger) *IdempotencyStore {
+	return &IdempotencyStore{
+		records: make(map[string]*domain.IdempotencyRecord),
+		logger:  logger,
+	}
+}
+
+func (s *IdempotencyStore) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	if record == nil || record.Key == "" {
+		return nil, false, errors.New("idempotency key cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.records[record.Key]; exists && !existing.Expired(record.CreatedAt) {
+		return cloneRecord(existing), false, nil
+	}
+
+	reserved := cloneRecord(record)
+	reserved.StatusCode = 0
+	reserved.ResponseBody = nil
+	reserved.ResponseHeaders = nil
+	s.records[record.Key] = reserved
+	return nil, true, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.records[record.Key]
+	if !exists || existing.Completed() {
+		return errors.New("idempotency key is not reserved")
+	}
+
+	existing.StatusCode = record.StatusCode
+	existing.ResponseBody = append([]byte(nil), record.ResponseBody...)
+	existing.ResponseHeaders = maps.Clone(record.ResponseHeaders)
+	existing.ExpiresAt = record.ExpiresAt
+	return nil
+}
+
+func (s *IdempotencyStore) Extend(ctx context.Context, key string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.records[key]; exists && !existing.Completed() {
+		existing.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.records[key]; exists && !existing.Completed() {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, record := range s.records {
+		if record.Expired(now) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func cloneRecord(record *domain.IdempotencyRecord) *domain.IdempotencyRecord {
+	clone := *record
+	clone.ResponseBody = append([]byte(nil), record.ResponseBody...)
+	clone.ResponseHeaders = maps.Clone(record.ResponseHeaders)
+	return &clone
+}
@@ -0,0 +1,143 @@
+// internal/infrastructure/persistence/idempotency/postgres/idempotency_store.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// reserveAttempts сколько раз повторить резервирование, если занявшая ключ запись
+// исчезла между INSERT и SELECT (освобождена или удалена очисткой)
+const reserveAttempts = 3
+
+// PostgresIdempotencyStore реализует ports.IdempotencyStore для PostgreSQL.
+// Ключи общие для всех экземпляров API, атомарность резервирования обеспечивает первичный ключ
+type PostgresIdempotencyStore struct {
+	db *sqlx.DB
+}
+
+// NewPostgresIdempotencyStore создает хранилище ответов на идемпотентные запросы
+func NewPostgresIdempotencyStore(db *sqlx.DB) *PostgresIdempotencyStore {
+	return &PostgresIdempotencyStore{
+		db: db,
+	}
+}
+
+func (s *PostgresIdempotencyStore) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	model, err := IdempotencyRecordFromDomain(&domain.IdempotencyRecord{
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		CreatedAt:   record.CreatedAt,
+		ExpiresAt:   record.ExpiresAt,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Запись с истекшим сроком заменяется; действующая остается, и RETURNING ничего не возвращает
+	query := `
+		INSERT INTO idempotency_keys (
+			key, request_hash, status_code, response_body, response_headers, created_at, expires_at
+		) VALUES (
+			:key, :request_hash, 0, NULL, :response_headers, :created_at, :expires_at
+		)
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = 0,
+			response_body = NULL,
+			response_headers = EXCLUDED.response_headers,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		RETURNING key
+	`
+	for range reserveAttempts {
+		rows, err := s.db.NamedQueryContext(ctx, query, model)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		reserved := rows.Next()
+		if err := rows.Close(); err != nil {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if reserved {
+			return nil, true, nil
+		}
+
+		var existing IdempotencyRecordModel
+		err = s.db.GetContext(ctx, &existing, `SELECT * FROM idempotency_keys WHERE key = $1`, record.Key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find idempotency record: %w", err)
+		}
+		result, err := existing.ToDomain()
+		if err != nil {
+			return nil, false, err
+		}
+		return result, false, nil
+	}
+	return nil, false, fmt.Errorf("failed to reserve idempotency key after %d attempts", reserveAttempts)
+}
+
+func (s *PostgresIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	model, err := IdempotencyRecordFromDomain(record)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE idempotency_keys SET
+			status_code = :status_code,
+			response_body = :response_body,
+			response_headers = :response_headers,
+			expires_at = :expires_at
+		WHERE key = :key AND status_code = 0
+	`
+	result, err := s.db.NamedExecContext(ctx, query, model)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errors.New("idempotency key is not reserved")
+	}
+	return nil
+}
+
+func (s *PostgresIdempotencyStore) Extend(ctx context.Context, key string, expiresAt time.Time) error {
+	query := `UPDATE idempotency_keys SET expires_at = $2 WHERE key = $1 AND status_code = 0`
+	if _, err := s.db.ExecContext(ctx, query, key, expiresAt); err != nil {
+		return fmt.Errorf("failed to extend idempotency key lock: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresIdempotencyStore) Release(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE key = $1 AND status_code = 0`, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresIdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency records: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	return int(affected), nil
+}
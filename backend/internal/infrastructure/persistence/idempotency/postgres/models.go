@@ -0,0 +1,61 @@
+// internal/infrastructure/persistence/idempotency/postgres/models.go
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// IdempotencyRecordModel представляет ответ на идемпотентный запрос в PostgreSQL
+type IdempotencyRecordModel struct {
+	Key             string          `db:"key"`
+	RequestHash     string          `db:"request_hash"`
+	StatusCode      int             `db:"status_code"`
+	ResponseBody    []byte          `db:"response_body"`
+	ResponseHeaders json.RawMessage `db:"response_headers"`
+	CreatedAt       time.Time       `db:"created_at"`
+	ExpiresAt       time.Time       `db:"expires_at"`
+}
+
+// IdempotencyRecordFromDomain конвертирует domain сущность в PostgreSQL модель
+func IdempotencyRecordFromDomain(record *domain.IdempotencyRecord) (*IdempotencyRecordModel, error) {
+	headers := record.ResponseHeaders
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	headersJSON, err := json.Marshal(headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response headers: %w", err)
+	}
+
+	return &IdempotencyRecordModel{
+		Key:             record.Key,
+		RequestHash:     record.RequestHash,
+		StatusCode:      record.StatusCode,
+		ResponseBody:    record.ResponseBody,
+		ResponseHeaders: headersJSON,
+		CreatedAt:       record.CreatedAt,
+		ExpiresAt:       record.ExpiresAt,
+	}, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *IdempotencyRecordModel) ToDomain() (*domain.IdempotencyRecord, error) {
+	record := &domain.IdempotencyRecord{
+		Key:          m.Key,
+		RequestHash:  m.RequestHash,
+		StatusCode:   m.StatusCode,
+		ResponseBody: m.ResponseBody,
+		CreatedAt:    m.CreatedAt,
+		ExpiresAt:    m.ExpiresAt,
+	}
+	if len(m.ResponseHeaders) > 0 {
+		if err := json.Unmarshal(m.ResponseHeaders, &record.ResponseHeaders); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response headers: %w", err)
+		}
+	}
+	return record, nil
+}
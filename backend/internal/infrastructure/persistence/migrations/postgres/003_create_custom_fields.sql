@@ -0,0 +1,18 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/003_create_custom_fields.sql
+
+-- Migration: 003_create_custom_fields
+-- Description: Custom field definitions for tasks
+
+-- Описания пользовательских полей задач
+CREATE TABLE IF NOT EXISTS custom_field_definitions (
+    key VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('text', 'number', 'enum', 'date', 'user', 'customer')),
+    task_types JSONB NOT NULL DEFAULT '[]',
+    categories JSONB NOT NULL DEFAULT '[]',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    default_value JSONB,
+    options JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,45 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/004_create_scheduler_tables.sql
+
+-- Migration: 004_create_scheduler_tables
+-- Description: Recurring task rules and scheduled replies
+
+-- Правила повторяющихся внутренних задач
+CREATE TABLE IF NOT EXISTS recurring_tasks (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    schedule VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT '',
+    subject VARCHAR(500) NOT NULL,
+    description TEXT NOT NULL,
+    priority VARCHAR(20) NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    tags JSONB NOT NULL DEFAULT '[]',
+    assignee_id VARCHAR(64) NOT NULL DEFAULT '',
+    reporter_id VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP WITH TIME ZONE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_task_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_tasks_due ON recurring_tasks(next_run_at) WHERE enabled;
+
+-- Запланированные ответы по задачам
+CREATE TABLE IF NOT EXISTS scheduled_replies (
+    id VARCHAR(64) PRIMARY KEY,
+    task_id VARCHAR(64) NOT NULL,
+    author_id VARCHAR(64) NOT NULL,
+    content TEXT NOT NULL,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'sent', 'cancelled', 'failed')),
+    sent_at TIMESTAMP WITH TIME ZONE,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_replies_task_id ON scheduled_replies(task_id);
+CREATE INDEX IF NOT EXISTS idx_scheduled_replies_due ON scheduled_replies(send_at) WHERE status = 'pending';
@@ -0,0 +1,33 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/005_create_audit_events.sql
+
+-- Migration: 005_create_audit_events
+-- Description: Append-only audit log of entity changes
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id VARCHAR(64) PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    actor_id VARCHAR(64) NOT NULL,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('api', 'email', 'automation', 'system')),
+    correlation_id VARCHAR(100) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '[]',
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events(entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+
+-- Журнал только дополняется: изменение и удаление записей запрещены
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_no_modify ON audit_events;
+CREATE TRIGGER audit_events_no_modify
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,26 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/006_create_outbox_events.sql
+
+-- Migration: 006_create_outbox_events
+-- Description: Transactional outbox of domain events for at-least-once delivery
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id VARCHAR(64) PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL DEFAULT '',
+    correlation_id VARCHAR(100) NOT NULL DEFAULT '',
+    data JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'dispatched', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Выборка ожидающих событий ретранслятором
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(next_attempt_at, created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched_at ON outbox_events(dispatched_at) WHERE status = 'dispatched';
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate ON outbox_events(aggregate_type, aggregate_id, created_at);
@@ -0,0 +1,37 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/007_create_webhooks.sql
+
+-- Migration: 007_create_webhooks
+-- Description: Webhook subscriptions and delivery log with dead letter state
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    task_types JSONB NOT NULL DEFAULT '[]',
+    customer_ids JSONB NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Подписка может быть удалена, журнал ее доставок сохраняется
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id VARCHAR(64) PRIMARY KEY,
+    subscription_id VARCHAR(64) NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'dead_letter')),
+    attempts JSONB NOT NULL DEFAULT '[]',
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at, created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
@@ -0,0 +1,18 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/008_create_inbound_channels.sql
+
+-- Migration: 008_create_inbound_channels
+-- Description: Inbound API channels with JSONPath payload mapping
+
+CREATE TABLE IF NOT EXISTS inbound_channels (
+    key VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'api' CHECK (source IN ('api', 'web_form')),
+    secret VARCHAR(255) NOT NULL DEFAULT '',
+    mapping JSONB NOT NULL,
+    priority_map JSONB NOT NULL DEFAULT '{}',
+    default_priority VARCHAR(20) NOT NULL DEFAULT 'medium',
+    default_tags JSONB NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,32 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/010_create_search_documents.sql
+
+-- Migration: 010_create_search_documents
+-- Description: Full-text search index over tasks, emails and customers (russian + english)
+
+CREATE TABLE IF NOT EXISTS search_documents (
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('task', 'email', 'customer')),
+    entity_id VARCHAR(255) NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    private_body TEXT NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL DEFAULT '',
+    priority VARCHAR(20) NOT NULL DEFAULT '',
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    members TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', title), 'A') ||
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('russian', body), 'B') ||
+        setweight(to_tsvector('english', body), 'B')
+    ) STORED,
+    private_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', private_body), 'C') ||
+        setweight(to_tsvector('english', private_body), 'C')
+    ) STORED,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_documents_vector ON search_documents USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_search_documents_private_vector ON search_documents USING GIN (private_vector);
+CREATE INDEX IF NOT EXISTS idx_search_documents_members ON search_documents USING GIN (members);
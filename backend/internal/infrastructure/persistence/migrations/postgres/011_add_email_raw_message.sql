@@ -0,0 +1,6 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/011_add_email_raw_message.sql
+
+-- Migration: 011_add_email_raw_message
+-- Description: Original RFC 5322 source of received emails for .eml download
+
+ALTER TABLE email_messages ADD COLUMN IF NOT EXISTS raw_message BYTEA;
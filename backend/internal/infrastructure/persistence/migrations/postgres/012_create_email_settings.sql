@@ -0,0 +1,29 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/012_create_email_settings.sql
+
+-- Migration: 012_create_email_settings
+-- Description: Email channel configs, processing policy and thread search windows managed via admin API
+
+CREATE TABLE IF NOT EXISTS email_channel_configs (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    provider VARCHAR(20) NOT NULL DEFAULT 'imap',
+    server VARCHAR(255) NOT NULL,
+    port INTEGER NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    password TEXT NOT NULL DEFAULT '',
+    mailbox VARCHAR(255) NOT NULL DEFAULT 'INBOX',
+    ssl BOOLEAN NOT NULL DEFAULT TRUE,
+    poll_interval_ms BIGINT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Активен не более одного канала
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_channel_configs_active ON email_channel_configs (active) WHERE active;
+
+-- Политика обработки ('policy') и окна поиска тредов ('thread_search')
+CREATE TABLE IF NOT EXISTS email_settings (
+    key VARCHAR(64) PRIMARY KEY,
+    value JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,16 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/013_create_idempotency_keys.sql
+
+-- Migration: 013_create_idempotency_keys
+-- Description: Stored responses for requests with Idempotency-Key header
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key CHAR(64) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    response_body BYTEA,
+    response_headers JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,13 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/014_create_inbound_deliveries.sql
+
+-- Migration: 014_create_inbound_deliveries
+-- Description: Accepted records of inbound channels for redelivery protection
+
+CREATE TABLE IF NOT EXISTS inbound_deliveries (
+    key CHAR(64) PRIMARY KEY,
+    task_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbound_deliveries_expires_at ON inbound_deliveries(expires_at);
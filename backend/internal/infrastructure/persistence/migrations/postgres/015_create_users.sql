@@ -0,0 +1,29 @@
+-- backend/internal/infrastructure/persistence/migrations/postgres/015_create_users.sql
+
+-- Migration: 015_create_users
+-- Description: User accounts, roles, invitations and routing settings
+
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(255) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    categories JSONB NOT NULL DEFAULT '[]'::jsonb,
+    skills JSONB NOT NULL DEFAULT '[]'::jsonb,
+    signature TEXT NOT NULL DEFAULT '',
+    password_hash TEXT NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL DEFAULT '',
+    language VARCHAR(8) NOT NULL DEFAULT '',
+    out_of_office_from TIMESTAMP WITH TIME ZONE,
+    out_of_office_until TIMESTAMP WITH TIME ZONE,
+    out_of_office_message TEXT NOT NULL DEFAULT '',
+    invitation_hash CHAR(64),
+    invitation_expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deactivated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(LOWER(email));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_invitation_hash ON users(invitation_hash) WHERE invitation_hash IS NOT NULL;
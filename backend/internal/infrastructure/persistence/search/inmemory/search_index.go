@@ -0,0 +1,285 @@
+// internal/infrastructure/persistence/search/inmemory/search_index.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// snippetRadius число символов текста вокруг первого совпадения во фрагменте
+const snippetRadius = 80
+
+// SearchIndex полнотекстовый индекс в памяти. Без морфологии: слово запроса
+// совпадает с началом слова документа, у длинных слов отбрасывается окончание
+type SearchIndex struct {
+	docs   map[string]*indexedDocument
+	mu     sync.RWMutex
+	logger ports.Logger
+}
+
+// indexedDocument документ с разобранными на слова текстами
+type indexedDocument struct {
+	doc          domain.SearchDocument
+	titleWords   []string
+	bodyWords    []string
+	privateWords []string
+}
+
+func NewSearchIndex(logger ports.Logger) *SearchIndex {
+	return &SearchIndex{
+		docs:   make(map[string]*indexedDocument),
+		logger: logger,
+	}
+}
+
+func (i *SearchIndex) Index(ctx context.Context, docs ...domain.SearchDocument) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, doc := range docs {
+		if doc.EntityID == "" || !doc.EntityType.IsValid() {
+			return errors.New("search document must have valid entity type and ID")
+		}
+		doc.Members = slices.Clone(doc.Members)
+		i.docs[documentKey(doc.EntityType, doc.EntityID)] = &indexedDocument{
+			doc:          doc,
+			titleWords:   searchWords(doc.Title),
+			bodyWords:    searchWords(doc.Body),
+			privateWords: searchWords(doc.PrivateBody),
+		}
+	}
+	return nil
+}
+
+func (i *SearchIndex) Remove(ctx context.Context, entityType domain.SearchEntityType, entityID string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.docs, documentKey(entityType, entityID))
+	return nil
+}
+
+func (i *SearchIndex) Search(ctx context.Context, query ports.SearchQuery) (*ports.SearchResult, error) {
+	terms := searchTerms(query.Text)
+	if len(terms) == 0 {
+		return nil, errors.New("search text is required")
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	result := &ports.SearchResult{Facets: domain.NewSearchFacets()}
+	for _, indexed := range i.docs {
+		doc := &indexed.doc
+		if len(query.EntityTypes) > 0 && !slices.Contains(query.EntityTypes, doc.EntityType) {
+			continue
+		}
+		if !doc.VisibleIn(query.Scope) {
+			continue
+		}
+		rank, ok := indexed.rank(terms, query.IncludePrivate)
+		if !ok {
+			continue
+		}
+
+		result.Facets.Add(doc, 1)
+		if !matchesAttribute(query.Statuses, doc.Status) ||
+			!matchesAttribute(query.Priorities, doc.Priority) ||
+			!matchesAttribute(query.Categories, doc.Category) {
+			continue
+		}
+		result.Hits = append(result.Hits, domain.SearchHit{
+			EntityType: doc.EntityType,
+			EntityID:   doc.EntityID,
+			Title:      doc.Title,
+			Snippet:    domain.FormatSearchSnippet(indexed.snippet(terms, query.IncludePrivate)),
+			Rank:       rank,
+			Status:     doc.Status,
+			Priority:   doc.Priority,
+			Category:   doc.Category,
+			UpdatedAt:  doc.UpdatedAt,
+		})
+	}
+
+	sort.Slice(result.Hits, func(a, b int) bool {
+		hitA, hitB := result.Hits[a], result.Hits[b]
+		if hitA.Rank != hitB.Rank {
+			return hitA.Rank > hitB.Rank
+		}
+		if !hitA.UpdatedAt.Equal(hitB.UpdatedAt) {
+			return hitA.UpdatedAt.After(hitB.UpdatedAt)
+		}
+		return hitA.EntityID < hitB.EntityID
+	})
+
+	result.TotalCount = len(result.Hits)
+	start := min(max(query.Offset, 0), len(result.Hits))
+	end := len(result.Hits)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, end)
+	}
+	result.Hits = result.Hits[start:end]
+
+	i.logger.Debug(ctx, "search index queried", "text", query.Text, "total", result.TotalCount)
+	return result, nil
+}
+
+// rank проверяет, что каждое слово запроса есть в документе, и считает релевантность:
+// совпадение в заголовке весит больше, чем в тексте
+func (d *indexedDocument) rank(terms []string, includePrivate bool) (float64, bool) {
+	var rank float64
+	for _, term := range terms {
+		title := countMatches(d.titleWords, term)
+		body := countMatches(d.bodyWords, term)
+		if includePrivate {
+			body += countMatches(d.privateWords, term)
+		}
+		if title == 0 && body == 0 {
+			return 0, false
+		}
+		rank += float64(title) + 0.1*float64(min(body, 10))
+	}
+	return rank, true
+}
+
+// snippet фрагмент текста вокруг первого совпадения с выделенными словами запроса
+func (d *indexedDocument) snippet(terms []string, includePrivate bool) string {
+	texts := []string{d.doc.Body}
+	if includePrivate {
+		texts = append(texts, d.doc.PrivateBody)
+	}
+	texts = append(texts, d.doc.Title)
+
+	for _, text := range texts {
+		start, end, ok := firstMatch(text, terms)
+		if !ok {
+			continue
+		}
+		from, to := max(0, start-snippetRadius), min(len(text), end+snippetRadius)
+		for from > 0 && !utf8.RuneStart(text[from]) {
+			from--
+		}
+		for to < len(text) && !utf8.RuneStart(text[to]) {
+			to++
+		}
+		fragment := highlight(text[from:to], terms)
+		if from > 0 {
+			fragment = "…" + fragment
+		}
+		if to < len(text) {
+			fragment += "…"
+		}
+		return strings.Join(strings.Fields(fragment), " ")
+	}
+	return ""
+}
+
+// firstMatch позиция первого слова текста, совпадающего со словом запроса
+func firstMatch(text string, terms []string) (int, int, bool) {
+	for _, word := range wordSpans(text) {
+		if matchesAnyTerm(strings.ToLower(text[word[0]:word[1]]), terms) {
+			return word[0], word[1], true
+		}
+	}
+	return 0, 0, false
+}
+
+// highlight выделяет совпадающие слова метками domain.SearchHighlightStart/Stop
+func highlight(text string, terms []string) string {
+	var b strings.Builder
+	last := 0
+	for _, word := range wordSpans(text) {
+		if !matchesAnyTerm(strings.ToLower(text[word[0]:word[1]]), terms) {
+			continue
+		}
+		b.WriteString(text[last:word[0]])
+		b.WriteString(domain.SearchHighlightStart)
+		b.WriteString(text[word[0]:word[1]])
+		b.WriteString(domain.SearchHighlightStop)
+		last = word[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// wordSpans границы слов текста в байтах
+func wordSpans(text string) [][2]int {
+	var spans [][2]int
+	start := -1
+	for pos, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = pos
+		case !isWord && start >= 0:
+			spans = append(spans, [2]int{start, pos})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+func searchWords(text string) []string {
+	spans := wordSpans(text)
+	words := make([]string, len(spans))
+	for i, span := range spans {
+		words[i] = strings.ToLower(text[span[0]:span[1]])
+	}
+	return words
+}
+
+// searchTerms слова запроса без окончаний: у слов длиннее пяти букв отбрасываются
+// две последние, чтобы "ошибки" находило "ошибка", а "printers" - "printer"
+func searchTerms(text string) []string {
+	var terms []string
+	for _, word := range searchWords(text) {
+		if runes := []rune(word); len(runes) > 5 {
+			word = string(runes[:len(runes)-2])
+		}
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+func countMatches(words []string, term string) int {
+	count := 0
+	for _, word := range words {
+		if strings.HasPrefix(word, term) {
+			count++
+		}
+	}
+	return count
+}
+
+func matchesAnyTerm(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAttribute(values []string, value string) bool {
+	return len(values) == 0 || slices.Contains(values, value)
+}
+
+func documentKey(entityType domain.SearchEntityType, entityID string) string {
+	return string(entityType) + "/" + entityID
+}
+
+var _ ports.SearchIndex = (*SearchIndex)(nil)
@@ -0,0 +1,201 @@
+// internal/infrastructure/persistence/search/postgres/search_index.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// headlineOptions параметры ts_headline: метки совпадений заменяются на <mark> после экранирования
+var headlineOptions = fmt.Sprintf("StartSel=\"%s\", StopSel=\"%s\", MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=\" … \"",
+	domain.SearchHighlightStart, domain.SearchHighlightStop)
+
+// PostgresSearchIndex реализует ports.SearchIndex на tsvector с русской и английской конфигурациями
+type PostgresSearchIndex struct {
+	db *sqlx.DB
+}
+
+// NewPostgresSearchIndex создает индекс поверх таблицы search_documents
+func NewPostgresSearchIndex(db *sqlx.DB) *PostgresSearchIndex {
+	return &PostgresSearchIndex{
+		db: db,
+	}
+}
+
+// searchHitModel строка результата поиска
+type searchHitModel struct {
+	EntityType string    `db:"entity_type"`
+	EntityID   string    `db:"entity_id"`
+	Title      string    `db:"title"`
+	Status     string    `db:"status"`
+	Priority   string    `db:"priority"`
+	Category   string    `db:"category"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	Rank       float64   `db:"rank"`
+	Snippet    string    `db:"snippet"`
+}
+
+// searchFacetModel число документов с одинаковыми атрибутами
+type searchFacetModel struct {
+	EntityType string `db:"entity_type"`
+	Status     string `db:"status"`
+	Priority   string `db:"priority"`
+	Category   string `db:"category"`
+	Count      int    `db:"count"`
+}
+
+func (i *PostgresSearchIndex) Index(ctx context.Context, docs ...domain.SearchDocument) error {
+	query := `
+		INSERT INTO search_documents (
+			entity_type, entity_id, title, body, private_body,
+			status, priority, category, members, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (entity_type, entity_id) DO UPDATE SET
+			title = EXCLUDED.title,
+			body = EXCLUDED.body,
+			private_body = EXCLUDED.private_body,
+			status = EXCLUDED.status,
+			priority = EXCLUDED.priority,
+			category = EXCLUDED.category,
+			members = EXCLUDED.members,
+			updated_at = EXCLUDED.updated_at
+	`
+	for _, doc := range docs {
+		members := doc.Members
+		if members == nil {
+			members = []string{}
+		}
+		_, err := i.db.ExecContext(ctx, query,
+			string(doc.EntityType), doc.EntityID, doc.Title, doc.Body, doc.PrivateBody,
+			doc.Status, doc.Priority, doc.Category, pq.Array(members), doc.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to index %s %s: %w", doc.EntityType, doc.EntityID, err)
+		}
+	}
+	return nil
+}
+
+func (i *PostgresSearchIndex) Remove(ctx context.Context, entityType domain.SearchEntityType, entityID string) error {
+	_, err := i.db.ExecContext(ctx,
+		`DELETE FROM search_documents WHERE entity_type = $1 AND entity_id = $2`, string(entityType), entityID)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s %s from search index: %w", entityType, entityID, err)
+	}
+	return nil
+}
+
+func (i *PostgresSearchIndex) Search(ctx context.Context, query ports.SearchQuery) (*ports.SearchResult, error) {
+	if strings.TrimSpace(query.Text) == "" {
+		return nil, fmt.Errorf("search text is required")
+	}
+
+	// Совпадение ищется в обеих конфигурациях: русские слова приводятся к основе
+	// русским стеммером, английские - английским
+	args := []interface{}{query.Text}
+	vector, text := "d.search_vector", "d.body"
+	if query.IncludePrivate {
+		vector, text = "(d.search_vector || d.private_vector)", "concat_ws(E'\\n', d.body, d.private_body)"
+	}
+
+	var conditions []string
+	addCondition := func(expr string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(expr, len(args)))
+	}
+	conditions = append(conditions, vector+" @@ q.query")
+	if len(query.EntityTypes) > 0 {
+		types := make([]string, len(query.EntityTypes))
+		for idx, entityType := range query.EntityTypes {
+			types[idx] = string(entityType)
+		}
+		addCondition("d.entity_type = ANY($%d)", pq.Array(types))
+	}
+	if query.Scope != nil {
+		args = append(args, pq.Array(query.Scope.Categories), query.Scope.UserID)
+		conditions = append(conditions, fmt.Sprintf(
+			"(d.entity_type <> 'task' OR d.category = ANY($%d) OR ($%[2]d <> '' AND $%[2]d = ANY(d.members)))", len(args)-1, len(args)))
+	}
+
+	from := `FROM search_documents d,
+		(SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS query) q`
+	facetWhere := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Фасеты не учитывают фильтры по атрибутам
+	var facetModels []searchFacetModel
+	facetQuery := fmt.Sprintf(`SELECT d.entity_type, d.status, d.priority, d.category, COUNT(*) AS count %s %s
+		GROUP BY d.entity_type, d.status, d.priority, d.category`, from, facetWhere)
+	if err := i.db.SelectContext(ctx, &facetModels, facetQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to count search facets: %w", err)
+	}
+
+	if len(query.Statuses) > 0 {
+		addCondition("d.status = ANY($%d)", pq.Array(query.Statuses))
+	}
+	if len(query.Priorities) > 0 {
+		addCondition("d.priority = ANY($%d)", pq.Array(query.Priorities))
+	}
+	if len(query.Categories) > 0 {
+		addCondition("d.category = ANY($%d)", pq.Array(query.Categories))
+	}
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	result := &ports.SearchResult{Facets: domain.NewSearchFacets()}
+	for _, facet := range facetModels {
+		doc := domain.SearchDocument{
+			EntityType: domain.SearchEntityType(facet.EntityType),
+			Status:     facet.Status,
+			Priority:   facet.Priority,
+			Category:   facet.Category,
+		}
+		result.Facets.Add(&doc, facet.Count)
+	}
+
+	if err := i.db.GetContext(ctx, &result.TotalCount, fmt.Sprintf("SELECT COUNT(*) %s %s", from, where), args...); err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	args = append(args, headlineOptions)
+	selectQuery := fmt.Sprintf(`
+		SELECT d.entity_type, d.entity_id, d.title, d.status, d.priority, d.category, d.updated_at,
+			ts_rank_cd(%s, q.query) AS rank,
+			ts_headline('russian', CASE WHEN %s = '' THEN d.title ELSE %s END, q.query, $%d) AS snippet
+		%s %s
+		ORDER BY rank DESC, d.updated_at DESC, d.entity_id`, vector, text, text, len(args), from, where)
+	if query.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT %d", query.Limit)
+	}
+	if query.Offset > 0 {
+		selectQuery += fmt.Sprintf(" OFFSET %d", query.Offset)
+	}
+
+	var models []searchHitModel
+	if err := i.db.SelectContext(ctx, &models, selectQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+
+	result.Hits = make([]domain.SearchHit, len(models))
+	for idx, model := range models {
+		result.Hits[idx] = domain.SearchHit{
+			EntityType: domain.SearchEntityType(model.EntityType),
+			EntityID:   model.EntityID,
+			Title:      model.Title,
+			Snippet:    domain.FormatSearchSnippet(model.Snippet),
+			Rank:       model.Rank,
+			Status:     model.Status,
+			Priority:   model.Priority,
+			Category:   model.Category,
+			UpdatedAt:  model.UpdatedAt,
+		}
+	}
+	return result, nil
+}
+
+var _ ports.SearchIndex = (*PostgresSearchIndex)(nil)
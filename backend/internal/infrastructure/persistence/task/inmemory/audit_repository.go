@@ -0,0 +1,107 @@
+// internal/infrastructure/persistence/task/inmemory/audit_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// AuditRepository журнал аудита в памяти; записи только добавляются
+type AuditRepository struct {
+	events []domain.AuditEvent
+	mu     sync.RWMutex
+	logger ports.Logger
+}
+
+func NewAuditRepository(logger ports.Logger) *AuditRepository {
+	return &AuditRepository{
+		logger: logger,
+	}
+}
+
+func (r *AuditRepository) Append(ctx context.Context, events ...domain.AuditEvent) error {
+	for _, event := range events {
+		if event.ID == "" {
+			return errors.New("audit event ID cannot be empty")
+		}
+		if event.EntityID == "" {
+			return errors.New("audit event entity ID cannot be empty")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range events {
+		event.Changes = append([]domain.FieldChange(nil), event.Changes...)
+		r.events = append(r.events, event)
+	}
+
+	r.logger.Debug(ctx, "audit events appended", "count", len(events))
+	return nil
+}
+
+func (r *AuditRepository) Query(ctx context.Context, query ports.AuditQuery) ([]domain.AuditEvent, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := []domain.AuditEvent{}
+	for _, event := range r.events {
+		if matchesAuditQuery(event, query) {
+			matched = append(matched, event)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		if query.Newest {
+			return matched[i].Timestamp.After(matched[j].Timestamp)
+		}
+		return matched[i].Timestamp.Before(matched[j].Timestamp)
+	})
+
+	total := len(matched)
+	if query.Offset > 0 {
+		if query.Offset >= len(matched) {
+			return []domain.AuditEvent{}, total, nil
+		}
+		matched = matched[query.Offset:]
+	}
+	if query.Limit > 0 && len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, total, nil
+}
+
+func matchesAuditQuery(event domain.AuditEvent, query ports.AuditQuery) bool {
+	if query.EntityType != "" && event.EntityType != query.EntityType {
+		return false
+	}
+	if query.EntityID != "" && event.EntityID != query.EntityID {
+		return false
+	}
+	if query.ActorID != "" && event.ActorID != query.ActorID {
+		return false
+	}
+	if query.Type != "" && event.Type != query.Type {
+		return false
+	}
+	if query.Source != "" && event.Source != query.Source {
+		return false
+	}
+	if query.CorrelationID != "" && event.CorrelationID != query.CorrelationID {
+		return false
+	}
+	if query.From != nil && event.Timestamp.Before(*query.From) {
+		return false
+	}
+	if query.To != nil && event.Timestamp.After(*query.To) {
+		return false
+	}
+	return true
+}
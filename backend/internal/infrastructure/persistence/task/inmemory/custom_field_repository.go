@@ -0,0 +1,108 @@
+// internal/infrastructure/persistence/task/inmemory/custom_field_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+type CustomFieldRepository struct {
+	fields map[string]*domain.CustomFieldDefinition
+	mu     sync.RWMutex
+	logger ports.Logger
+}
+
+func NewCustomFieldRepository(logger ports.Logger) *CustomFieldRepository {
+	return &CustomFieldRepository{
+		fields: make(map[string]*domain.CustomFieldDefinition),
+		logger: logger,
+	}
+}
+
+func (r *CustomFieldRepository) Save(ctx context.Context, def *domain.CustomFieldDefinition) error {
+	if def == nil {
+		return errors.New("custom field cannot be nil")
+	}
+	if def.Key == "" {
+		return errors.New("custom field key cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.fields[def.Key]; exists {
+		return fmt.Errorf("custom field already exists: %s", def.Key)
+	}
+
+	r.fields[def.Key] = def
+	r.logger.Info(ctx, "custom field saved", "key", def.Key, "type", def.Type)
+	return nil
+}
+
+func (r *CustomFieldRepository) FindByKey(ctx context.Context, key string) (*domain.CustomFieldDefinition, error) {
+	if key == "" {
+		return nil, errors.New("custom field key cannot be empty")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, exists := r.fields[key]
+	if !exists {
+		return nil, fmt.Errorf("custom field not found: %s", key)
+	}
+
+	return def, nil
+}
+
+func (r *CustomFieldRepository) FindAll(ctx context.Context) ([]domain.CustomFieldDefinition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fields := make([]domain.CustomFieldDefinition, 0, len(r.fields))
+	for _, def := range r.fields {
+		fields = append(fields, *def)
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Key < fields[j].Key
+	})
+
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) Update(ctx context.Context, def *domain.CustomFieldDefinition) error {
+	if def == nil {
+		return errors.New("custom field cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.fields[def.Key]; !exists {
+		return fmt.Errorf("custom field not found: %s", def.Key)
+	}
+
+	r.fields[def.Key] = def
+	r.logger.Info(ctx, "custom field updated", "key", def.Key)
+	return nil
+}
+
+func (r *CustomFieldRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.fields[key]; !exists {
+		return fmt.Errorf("custom field not found: %s", key)
+	}
+
+	delete(r.fields, key)
+	r.logger.Info(ctx, "custom field deleted", "key", key)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/audetv/urms/internal/core/domain"
@@ -88,6 +89,24 @@ func (r *CustomerRepository) FindByOrganization(ctx context.Context, orgID strin
 	return customers, nil
 }
 
+func (r *CustomerRepository) FindByQuery(ctx context.Context, query ports.CustomerQuery) ([]domain.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	customers := make([]domain.Customer, 0, len(r.customers))
+	for _, customer := range r.customers {
+		if matchesCustomerQuery(customer, query) {
+			customers = append(customers, *customer)
+		}
+	}
+	if len(query.Sort) > 0 {
+		domain.CustomerListing(query.Sort).SortItems(customers)
+	}
+
+	r.logger.Debug(ctx, "customers found by query", "count", len(customers))
+	return customers, nil
+}
+
 func (r *CustomerRepository) Update(ctx context.Context, customer *domain.Customer) error {
 	if customer == nil {
 		return errors.New("customer cannot be nil")
@@ -121,3 +140,36 @@ func (r *CustomerRepository) Delete(ctx context.Context, id string) error {
 	r.logger.Info(ctx, "customer deleted", "customer_id", id)
 	return nil
 }
+
+// matchesCustomerQuery проверяет соответствие клиента фильтрам.
+// Организация сопоставляется по ID или названию без учета регистра
+func matchesCustomerQuery(customer *domain.Customer, query ports.CustomerQuery) bool {
+	if query.Email != "" && !strings.EqualFold(customer.Email, query.Email) {
+		return false
+	}
+	if query.Organization != "" && !customerInOrganization(customer, query.Organization) {
+		return false
+	}
+	for _, organization := range query.ExcludeOrganizations {
+		if customerInOrganization(customer, organization) {
+			return false
+		}
+	}
+	if !query.CreatedAt.Includes(&customer.CreatedAt) || !query.UpdatedAt.Includes(&customer.UpdatedAt) {
+		return false
+	}
+	if query.SearchText != "" {
+		text := strings.ToLower(query.SearchText)
+		if !strings.Contains(strings.ToLower(customer.Name), text) &&
+			!strings.Contains(strings.ToLower(customer.Email), text) &&
+			!strings.Contains(customer.Phone, text) {
+			return false
+		}
+	}
+	return true
+}
+
+func customerInOrganization(customer *domain.Customer, organization string) bool {
+	return customer.Organization != nil &&
+		(customer.Organization.ID == organization || strings.EqualFold(customer.Organization.Name, organization))
+}
@@ -0,0 +1,128 @@
+// internal/infrastructure/persistence/task/inmemory/recurring_task_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+type RecurringTaskRepository struct {
+	rules  map[string]*domain.RecurringTask
+	mu     sync.RWMutex
+	logger ports.Logger
+}
+
+func NewRecurringTaskRepository(logger ports.Logger) *RecurringTaskRepository {
+	return &RecurringTaskRepository{
+		rules:  make(map[string]*domain.RecurringTask),
+		logger: logger,
+	}
+}
+
+func (r *RecurringTaskRepository) Save(ctx context.Context, rt *domain.RecurringTask) error {
+	if rt == nil {
+		return errors.New("recurring task cannot be nil")
+	}
+	if rt.ID == "" {
+		return errors.New("recurring task ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rules[rt.ID]; exists {
+		return fmt.Errorf("recurring task already exists: %s", rt.ID)
+	}
+
+	r.rules[rt.ID] = rt
+	r.logger.Info(ctx, "recurring task saved", "recurring_task_id", rt.ID)
+	return nil
+}
+
+func (r *RecurringTaskRepository) FindByID(ctx context.Context, id string) (*domain.RecurringTask, error) {
+	if id == "" {
+		return nil, errors.New("recurring task ID cannot be empty")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rt, exists := r.rules[id]
+	if !exists {
+		return nil, fmt.Errorf("recurring task not found: %s", id)
+	}
+
+	return rt, nil
+}
+
+func (r *RecurringTaskRepository) FindAll(ctx context.Context) ([]domain.RecurringTask, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]domain.RecurringTask, 0, len(r.rules))
+	for _, rt := range r.rules {
+		rules = append(rules, *rt)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+
+	return rules, nil
+}
+
+// FindDue возвращает включенные правила, срок срабатывания которых наступил
+func (r *RecurringTaskRepository) FindDue(ctx context.Context, now time.Time) ([]domain.RecurringTask, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := []domain.RecurringTask{}
+	for _, rt := range r.rules {
+		if rt.IsDue(now) {
+			rules = append(rules, *rt)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].NextRunAt.Before(*rules[j].NextRunAt)
+	})
+
+	return rules, nil
+}
+
+func (r *RecurringTaskRepository) Update(ctx context.Context, rt *domain.RecurringTask) error {
+	if rt == nil {
+		return errors.New("recurring task cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rules[rt.ID]; !exists {
+		return fmt.Errorf("recurring task not found: %s", rt.ID)
+	}
+
+	r.rules[rt.ID] = rt
+	r.logger.Info(ctx, "recurring task updated", "recurring_task_id", rt.ID)
+	return nil
+}
+
+func (r *RecurringTaskRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rules[id]; !exists {
+		return fmt.Errorf("recurring task not found: %s", id)
+	}
+
+	delete(r.rules, id)
+	r.logger.Info(ctx, "recurring task deleted", "recurring_task_id", id)
+	return nil
+}
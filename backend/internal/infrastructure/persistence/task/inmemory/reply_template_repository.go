@@ -0,0 +1,111 @@
+// internal/infrastructure/persistence/task/inmemory/reply_template_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+type ReplyTemplateRepository struct {
+	templates map[string]*domain.ReplyTemplate
+	mu        sync.RWMutex
+	logger    ports.Logger
+}
+
+func NewReplyTemplateRepository(logger ports.Logger) *ReplyTemplateRepository {
+	return &ReplyTemplateRepository{
+		templates: make(map[string]*domain.ReplyTemplate),
+		logger:    logger,
+	}
+}
+
+func (r *ReplyTemplateRepository) Save(ctx context.Context, tpl *domain.ReplyTemplate) error {
+	if tpl == nil {
+		return errors.New("reply template cannot be nil")
+	}
+	if tpl.ID == "" {
+		return errors.New("reply template ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.templates[tpl.ID]; exists {
+		return fmt.Errorf("reply template already exists: %s", tpl.ID)
+	}
+
+	r.templates[tpl.ID] = tpl
+	r.logger.Info(ctx, "reply template saved", "template_id", tpl.ID, "category", tpl.Category)
+	return nil
+}
+
+func (r *ReplyTemplateRepository) FindByID(ctx context.Context, id string) (*domain.ReplyTemplate, error) {
+	if id == "" {
+		return nil, errors.New("reply template ID cannot be empty")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tpl, exists := r.templates[id]
+	if !exists {
+		return nil, fmt.Errorf("reply template not found: %s", id)
+	}
+
+	return tpl, nil
+}
+
+func (r *ReplyTemplateRepository) FindAll(ctx context.Context) ([]domain.ReplyTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]domain.ReplyTemplate, 0, len(r.templates))
+	for _, tpl := range r.templates {
+		templates = append(templates, *tpl)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Category != templates[j].Category {
+			return templates[i].Category < templates[j].Category
+		}
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
+
+func (r *ReplyTemplateRepository) Update(ctx context.Context, tpl *domain.ReplyTemplate) error {
+	if tpl == nil {
+		return errors.New("reply template cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.templates[tpl.ID]; !exists {
+		return fmt.Errorf("reply template not found: %s", tpl.ID)
+	}
+
+	r.templates[tpl.ID] = tpl
+	r.logger.Info(ctx, "reply template updated", "template_id", tpl.ID)
+	return nil
+}
+
+func (r *ReplyTemplateRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.templates[id]; !exists {
+		return fmt.Errorf("reply template not found: %s", id)
+	}
+
+	delete(r.templates, id)
+	r.logger.Info(ctx, "reply template deleted", "template_id", id)
+	return nil
+}
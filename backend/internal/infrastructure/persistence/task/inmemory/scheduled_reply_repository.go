@@ -0,0 +1,113 @@
+// internal/infrastructure/persistence/task/inmemory/scheduled_reply_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+type ScheduledReplyRepository struct {
+	replies map[string]*domain.ScheduledReply
+	mu      sync.RWMutex
+	logger  ports.Logger
+}
+
+func NewScheduledReplyRepository(logger ports.Logger) *ScheduledReplyRepository {
+	return &ScheduledReplyRepository{
+		replies: make(map[string]*domain.ScheduledReply),
+		logger:  logger,
+	}
+}
+
+func (r *ScheduledReplyRepository) Save(ctx context.Context, reply *domain.ScheduledReply) error {
+	if reply == nil {
+		return errors.New("scheduled reply cannot be nil")
+	}
+	if reply.ID == "" {
+		return errors.New("scheduled reply ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.replies[reply.ID]; exists {
+		return fmt.Errorf("scheduled reply already exists: %s", reply.ID)
+	}
+
+	r.replies[reply.ID] = reply
+	r.logger.Info(ctx, "scheduled reply saved", "scheduled_reply_id", reply.ID, "task_id", reply.TaskID)
+	return nil
+}
+
+func (r *ScheduledReplyRepository) FindByID(ctx context.Context, id string) (*domain.ScheduledReply, error) {
+	if id == "" {
+		return nil, errors.New("scheduled reply ID cannot be empty")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reply, exists := r.replies[id]
+	if !exists {
+		return nil, fmt.Errorf("scheduled reply not found: %s", id)
+	}
+
+	return reply, nil
+}
+
+func (r *ScheduledReplyRepository) FindByTaskID(ctx context.Context, taskID string) ([]domain.ScheduledReply, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	replies := []domain.ScheduledReply{}
+	for _, reply := range r.replies {
+		if reply.TaskID == taskID {
+			replies = append(replies, *reply)
+		}
+	}
+
+	return replies, nil
+}
+
+// FindDue возвращает ожидающие ответы, время отправки которых наступило
+func (r *ScheduledReplyRepository) FindDue(ctx context.Context, now time.Time) ([]domain.ScheduledReply, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	replies := []domain.ScheduledReply{}
+	for _, reply := range r.replies {
+		if reply.IsDue(now) {
+			replies = append(replies, *reply)
+		}
+	}
+
+	sort.Slice(replies, func(i, j int) bool {
+		return replies[i].SendAt.Before(replies[j].SendAt)
+	})
+
+	return replies, nil
+}
+
+func (r *ScheduledReplyRepository) Update(ctx context.Context, reply *domain.ScheduledReply) error {
+	if reply == nil {
+		return errors.New("scheduled reply cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.replies[reply.ID]; !exists {
+		return fmt.Errorf("scheduled reply not found: %s", reply.ID)
+	}
+
+	r.replies[reply.ID] = reply
+	r.logger.Info(ctx, "scheduled reply updated", "scheduled_reply_id", reply.ID, "status", reply.Status)
+	return nil
+}
@@ -5,7 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sort"
+	"slices"
+	"strconv"
 	"sync"
 	"time"
 
@@ -37,7 +38,10 @@ func (r *TaskRepository) Save(ctx context.Context, task *domain.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.tasks[task.ID] = task
+	if task.Version == 0 {
+		task.Version = 1
+	}
+	r.tasks[task.ID] = task.Clone()
 	r.logger.Info(ctx, "task saved", "task_id", task.ID, "type", task.Type)
 	return nil
 }
@@ -55,7 +59,7 @@ func (r *TaskRepository) FindByID(ctx context.Context, id string) (*domain.Task,
 		return nil, fmt.Errorf("task not found: %s", id)
 	}
 
-	return task, nil
+	return task.Clone(), nil
 }
 
 func (r *TaskRepository) FindByQuery(ctx context.Context, query ports.TaskQuery) ([]domain.Task, error) {
@@ -66,12 +70,14 @@ func (r *TaskRepository) FindByQuery(ctx context.Context, query ports.TaskQuery)
 
 	for _, task := range r.tasks {
 		if r.matchesQuery(task, query) {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
 	// Применяем сортировку
-	r.sortTasks(&tasks, query.SortBy, query.SortOrder)
+	if sortFields := query.SortFields(); len(sortFields) > 0 {
+		domain.TaskListing(sortFields).SortItems(tasks)
+	}
 
 	r.logger.Debug(ctx, "tasks found by query", "count", len(tasks))
 	return tasks, nil
@@ -85,12 +91,23 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.tasks[task.ID]; !exists {
+	stored, exists := r.tasks[task.ID]
+	if !exists {
 		return fmt.Errorf("task not found: %s", task.ID)
 	}
 
-	r.tasks[task.ID] = task
-	r.logger.Info(ctx, "task updated", "task_id", task.ID)
+	// Оптимистическая блокировка: задача должна быть основана на последней сохраненной версии
+	if err := ports.CheckVersionPrecondition(ctx, task.ID, task.Version); err != nil {
+		return err
+	}
+	if stored.Version != task.Version {
+		return fmt.Errorf("%w: task %s was modified (version %d, stored %d)",
+			ports.ErrVersionConflict, task.ID, task.Version, stored.Version)
+	}
+
+	task.Version++
+	r.tasks[task.ID] = task.Clone()
+	r.logger.Info(ctx, "task updated", "task_id", task.ID, "version", task.Version)
 	return nil
 }
 
@@ -102,9 +119,13 @@ func (r *TaskRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.tasks[id]; !exists {
+	stored, exists := r.tasks[id]
+	if !exists {
 		return fmt.Errorf("task not found: %s", id)
 	}
+	if err := ports.CheckVersionPrecondition(ctx, id, stored.Version); err != nil {
+		return err
+	}
 
 	delete(r.tasks, id)
 	r.logger.Info(ctx, "task deleted", "task_id", id)
@@ -118,7 +139,7 @@ func (r *TaskRepository) FindByCustomerID(ctx context.Context, customerID string
 	var tasks []domain.Task
 	for _, task := range r.tasks {
 		if task.CustomerID != nil && *task.CustomerID == customerID {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
@@ -133,7 +154,7 @@ func (r *TaskRepository) FindByAssigneeID(ctx context.Context, assigneeID string
 	var tasks []domain.Task
 	for _, task := range r.tasks {
 		if task.AssigneeID == assigneeID {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
@@ -148,7 +169,7 @@ func (r *TaskRepository) FindByStatus(ctx context.Context, status domain.TaskSta
 	var tasks []domain.Task
 	for _, task := range r.tasks {
 		if task.Status == status {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
@@ -163,7 +184,7 @@ func (r *TaskRepository) FindByType(ctx context.Context, taskType domain.TaskTyp
 	var tasks []domain.Task
 	for _, task := range r.tasks {
 		if task.Type == taskType {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
@@ -175,10 +196,14 @@ func (r *TaskRepository) FindOpenTasks(ctx context.Context) ([]domain.Task, erro
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	now := time.Now()
 	var tasks []domain.Task
 	for _, task := range r.tasks {
+		if task.IsSnoozed(now) {
+			continue
+		}
 		if task.Status == domain.TaskStatusOpen || task.Status == domain.TaskStatusInProgress {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
@@ -193,7 +218,7 @@ func (r *TaskRepository) FindSubtasks(ctx context.Context, parentID string) ([]d
 	var tasks []domain.Task
 	for _, task := range r.tasks {
 		if task.ParentID != nil && *task.ParentID == parentID {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
@@ -201,6 +226,21 @@ func (r *TaskRepository) FindSubtasks(ctx context.Context, parentID string) ([]d
 	return tasks, nil
 }
 
+func (r *TaskRepository) FindByParticipantID(ctx context.Context, userID string) ([]domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tasks []domain.Task
+	for _, task := range r.tasks {
+		if task.FindParticipant(userID) != nil {
+			tasks = append(tasks, *task.Clone())
+		}
+	}
+
+	r.logger.Debug(ctx, "tasks found by participant", "user_id", userID, "count", len(tasks))
+	return tasks, nil
+}
+
 func (r *TaskRepository) FindBySourceMeta(ctx context.Context, meta map[string]interface{}) ([]domain.Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -209,7 +249,7 @@ func (r *TaskRepository) FindBySourceMeta(ctx context.Context, meta map[string]i
 
 	for _, task := range r.tasks {
 		if r.matchesSourceMeta(task, meta) {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, *task.Clone())
 		}
 	}
 
@@ -332,6 +372,17 @@ func (r *TaskRepository) matchesQuery(task *domain.Task, query ports.TaskQuery)
 		return false
 	}
 
+	// Исключающие фильтры
+	if containsTaskType(query.ExcludeTypes, task.Type) ||
+		containsTaskStatus(query.ExcludeStatuses, task.Status) ||
+		containsPriority(query.ExcludePriorities, task.Priority) ||
+		containsTaskSource(query.ExcludeSources, task.Source) ||
+		slices.Contains(query.ExcludeCategories, task.Category) ||
+		slices.Contains(query.ExcludeAssigneeIDs, task.AssigneeID) ||
+		containsAnyTag(task.Tags, query.ExcludeTags) {
+		return false
+	}
+
 	// Фильтр по исполнителю
 	if query.AssigneeID != "" && task.AssigneeID != query.AssigneeID {
 		return false
@@ -362,6 +413,11 @@ func (r *TaskRepository) matchesQuery(task *domain.Task, query ports.TaskQuery)
 		return false
 	}
 
+	// Ограничение видимости пользователя
+	if !query.Scope.Includes(task) {
+		return false
+	}
+
 	// Фильтр по родительской задаче
 	if query.ParentID != nil && (task.ParentID == nil || *task.ParentID != *query.ParentID) {
 		return false
@@ -372,7 +428,29 @@ func (r *TaskRepository) matchesQuery(task *domain.Task, query ports.TaskQuery)
 		return false
 	}
 
-	// TODO: Реализовать фильтр по датам и поиск по тексту
+	// Фильтр по пользовательским полям
+	for key, value := range query.CustomFields {
+		fieldValue, exists := task.CustomFields[key]
+		if !exists || formatCustomFieldValue(fieldValue) != value {
+			return false
+		}
+	}
+
+	// Фильтр по откладыванию
+	if query.Snoozed != nil && task.IsSnoozed(time.Now()) != *query.Snoozed {
+		return false
+	}
+	if query.SnoozeExpiredBy != nil && (task.SnoozedUntil == nil || task.SnoozedUntil.After(*query.SnoozeExpiredBy)) {
+		return false
+	}
+
+	// Фильтры по датам
+	if !query.CreatedAt.Includes(&task.CreatedAt) || !query.UpdatedAt.Includes(&task.UpdatedAt) ||
+		!query.DueDate.Includes(task.DueDate) || !query.ResolvedAt.Includes(task.ResolvedAt) {
+		return false
+	}
+
+	// TODO: Реализовать поиск по тексту
 
 	return true
 }
@@ -384,6 +462,16 @@ func (r *TaskRepository) matchesSourceMeta(task *domain.Task, meta map[string]in
 		return false
 	}
 
+	// Входящие каналы сопоставляются по внешним ID в пределах канала
+	if _, exists := meta[domain.SourceMetaChannel]; exists {
+		return domain.MatchesInboundSourceMeta(task.SourceMeta, meta)
+	}
+
+	// Связанные задачи GitHub сопоставляются по ссылке на issue
+	if _, exists := meta[domain.SourceMetaGitHubIssue]; exists {
+		return domain.MatchesGitHubSourceMeta(task.SourceMeta, meta)
+	}
+
 	// ✅ ЛОГИРУЕМ ВСЕ КРИТЕРИИ ПОИСКА
 	r.logger.Debug(context.Background(), "Thread matching evaluation",
 		"task_id", task.ID,
@@ -475,44 +563,12 @@ func (r *TaskRepository) matchesStatsQuery(task *domain.Task, query ports.StatsQ
 	return true
 }
 
-func (r *TaskRepository) sortTasks(tasks *[]domain.Task, sortBy, sortOrder string) {
-	if sortBy == "" {
-		return
-	}
-
-	sort.Slice(*tasks, func(i, j int) bool {
-		taskA := (*tasks)[i]
-		taskB := (*tasks)[j]
-
-		var less bool
-		switch sortBy {
-		case "created_at":
-			less = taskA.CreatedAt.Before(taskB.CreatedAt)
-		case "updated_at":
-			less = taskA.UpdatedAt.Before(taskB.UpdatedAt)
-		case "priority":
-			less = r.priorityValue(taskA.Priority) < r.priorityValue(taskB.Priority)
-		case "status":
-			less = string(taskA.Status) < string(taskB.Status)
-		default:
-			return false
-		}
-
-		if sortOrder == "desc" {
-			return !less
-		}
-		return less
-	})
-}
-
-func (r *TaskRepository) priorityValue(priority domain.Priority) int {
-	priorityValues := map[domain.Priority]int{
-		domain.PriorityLow:      1,
-		domain.PriorityMedium:   2,
-		domain.PriorityHigh:     3,
-		domain.PriorityCritical: 4,
+// formatCustomFieldValue приводит значение поля к строке для фильтрации
+func formatCustomFieldValue(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
 	}
-	return priorityValues[priority]
+	return fmt.Sprint(value)
 }
 
 func (r *TaskRepository) calculateAverage(values []float64) float64 {
@@ -581,6 +637,15 @@ func containsTaskSource(sources []domain.TaskSource, target domain.TaskSource) b
 	return false
 }
 
+func containsAnyTag(taskTags, queryTags []string) bool {
+	for _, queryTag := range queryTags {
+		if slices.Contains(taskTags, queryTag) {
+			return true
+		}
+	}
+	return false
+}
+
 func containsAllTags(taskTags, queryTags []string) bool {
 	for _, queryTag := range queryTags {
 		found := false
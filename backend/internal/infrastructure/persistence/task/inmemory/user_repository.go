@@ -5,7 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/audetv/urms/internal/core/domain"
 	"github.com/audetv/urms/internal/core/ports"
@@ -26,24 +29,30 @@ func NewUserRepository(logger ports.Logger) *UserRepository {
 
 	// Добавляем тестовых пользователей
 	repo.users["user-1"] = &domain.User{
-		ID:    "user-1",
-		Email: "admin@company.com",
-		Name:  "Admin User",
-		Role:  domain.UserRoleAdmin,
+		ID:       "user-1",
+		Email:    "admin@company.com",
+		Name:     "Admin User",
+		Role:     domain.UserRoleAdmin,
+		Status:   domain.UserStatusActive,
+		Language: domain.DefaultTemplateLanguage,
 	}
 
 	repo.users["user-2"] = &domain.User{
-		ID:    "user-2",
-		Email: "manager@company.com",
-		Name:  "Manager User",
-		Role:  domain.UserRoleManager,
+		ID:       "user-2",
+		Email:    "manager@company.com",
+		Name:     "Manager User",
+		Role:     domain.UserRoleManager,
+		Status:   domain.UserStatusActive,
+		Language: domain.DefaultTemplateLanguage,
 	}
 
 	repo.users["user-3"] = &domain.User{
-		ID:    "user-3",
-		Email: "operator@company.com",
-		Name:  "Operator User",
-		Role:  domain.UserRoleOperator,
+		ID:       "user-3",
+		Email:    "operator@company.com",
+		Name:     "Operator User",
+		Role:     domain.UserRoleOperator,
+		Status:   domain.UserStatusActive,
+		Language: domain.DefaultTemplateLanguage,
 	}
 
 	return repo
@@ -59,7 +68,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*domain.User,
 
 	user, exists := r.users[id]
 	if !exists {
-		return nil, fmt.Errorf("user not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
 	}
 
 	return user, nil
@@ -74,7 +83,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*domain
 	defer r.mu.RUnlock()
 
 	for _, user := range r.users {
-		if user.Email == email {
+		if strings.EqualFold(user.Email, email) {
 			return user, nil
 		}
 	}
@@ -86,10 +95,15 @@ func (r *UserRepository) FindAssignees(ctx context.Context) ([]domain.User, erro
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	now := time.Now()
 	var assignees []domain.User
 	for _, user := range r.users {
 		// Считаем, что операторы и менеджеры могут быть исполнителями
-		if user.Role == domain.UserRoleOperator || user.Role == domain.UserRoleManager {
+		if user.Role != domain.UserRoleOperator && user.Role != domain.UserRoleManager {
+			continue
+		}
+		// Отключенные и отсутствующие пользователи не получают задачи автоматически
+		if user.IsAvailable(now) {
 			assignees = append(assignees, *user)
 		}
 	}
@@ -98,6 +112,39 @@ func (r *UserRepository) FindAssignees(ctx context.Context) ([]domain.User, erro
 	return assignees, nil
 }
 
+func (r *UserRepository) FindAll(ctx context.Context) ([]domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]domain.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, *user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Name != users[j].Name {
+			return users[i].Name < users[j].Name
+		}
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (r *UserRepository) FindByInvitationHash(ctx context.Context, hash string) (*domain.User, error) {
+	if hash == "" {
+		return nil, domain.ErrInvalidInvitation
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.InvitationHash == hash {
+			return user, nil
+		}
+	}
+	return nil, domain.ErrInvalidInvitation
+}
+
 func (r *UserRepository) Save(ctx context.Context, user *domain.User) error {
 	if user == nil {
 		return errors.New("user cannot be nil")
@@ -123,7 +170,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	defer r.mu.Unlock()
 
 	if _, exists := r.users[user.ID]; !exists {
-		return fmt.Errorf("user not found: %s", user.ID)
+		return fmt.Errorf("%w: %s", domain.ErrUserNotFound, user.ID)
 	}
 
 	r.users[user.ID] = user
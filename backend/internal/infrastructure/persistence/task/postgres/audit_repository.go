@@ -0,0 +1,130 @@
+// internal/infrastructure/persistence/task/postgres/audit_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/audetv/urms/internal/infrastructure/persistence/transaction"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresAuditRepository реализует ports.AuditRepository для PostgreSQL.
+// Таблица audit_events защищена триггером от изменения и удаления записей
+type PostgresAuditRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAuditRepository создает репозиторий журнала аудита
+func NewPostgresAuditRepository(db *sqlx.DB) *PostgresAuditRepository {
+	return &PostgresAuditRepository{
+		db: db,
+	}
+}
+
+// Append добавляет записи в журнал одной транзакцией
+func (r *PostgresAuditRepository) Append(ctx context.Context, events ...domain.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO audit_events (
+			id, entity_type, entity_id, event_type, actor_id, source,
+			correlation_id, changes, message, created_at
+		) VALUES (
+			:id, :entity_type, :entity_id, :event_type, :actor_id, :source,
+			:correlation_id, :changes, :message, :created_at
+		)
+	`
+
+	// В единице работы запись идет в общую транзакцию вместе с изменением задачи
+	return transaction.Run(ctx, r.db, func(tx sqlx.ExtContext) error {
+		for _, event := range events {
+			model, err := AuditEventFromDomain(event)
+			if err != nil {
+				return err
+			}
+			if _, err := sqlx.NamedExecContext(ctx, tx, query, model); err != nil {
+				return fmt.Errorf("failed to append audit event: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Query выполняет выборку из журнала и возвращает общее число подходящих записей
+func (r *PostgresAuditRepository) Query(ctx context.Context, query ports.AuditQuery) ([]domain.AuditEvent, int, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(expr string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(expr, len(args)))
+	}
+
+	if query.EntityType != "" {
+		addCondition("entity_type = $%d", string(query.EntityType))
+	}
+	if query.EntityID != "" {
+		addCondition("entity_id = $%d", query.EntityID)
+	}
+	if query.ActorID != "" {
+		addCondition("actor_id = $%d", query.ActorID)
+	}
+	if query.Type != "" {
+		addCondition("event_type = $%d", query.Type)
+	}
+	if query.Source != "" {
+		addCondition("source = $%d", string(query.Source))
+	}
+	if query.CorrelationID != "" {
+		addCondition("correlation_id = $%d", query.CorrelationID)
+	}
+	if query.From != nil {
+		addCondition("created_at >= $%d", *query.From)
+	}
+	if query.To != nil {
+		addCondition("created_at <= $%d", *query.To)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM audit_events "+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	order := "ASC"
+	if query.Newest {
+		order = "DESC"
+	}
+	selectQuery := fmt.Sprintf("SELECT * FROM audit_events %s ORDER BY created_at %s, id %s", where, order, order)
+	if query.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT %d", query.Limit)
+	}
+	if query.Offset > 0 {
+		selectQuery += fmt.Sprintf(" OFFSET %d", query.Offset)
+	}
+
+	var models []AuditEventModel
+	if err := r.db.SelectContext(ctx, &models, selectQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to find audit events: %w", err)
+	}
+
+	events := make([]domain.AuditEvent, 0, len(models))
+	for _, model := range models {
+		event, err := model.ToDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+
+	return events, total, nil
+}
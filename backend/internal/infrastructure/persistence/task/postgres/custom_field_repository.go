@@ -0,0 +1,133 @@
+// internal/infrastructure/persistence/task/postgres/custom_field_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresCustomFieldRepository реализует ports.CustomFieldRepository для PostgreSQL
+type PostgresCustomFieldRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresCustomFieldRepository создает репозиторий описаний пользовательских полей
+func NewPostgresCustomFieldRepository(db *sqlx.DB) *PostgresCustomFieldRepository {
+	return &PostgresCustomFieldRepository{
+		db: db,
+	}
+}
+
+// Save сохраняет новое описание поля
+func (r *PostgresCustomFieldRepository) Save(ctx context.Context, def *domain.CustomFieldDefinition) error {
+	model, err := CustomFieldFromDomain(def)
+	if err != nil {
+		return fmt.Errorf("failed to convert custom field to model: %w", err)
+	}
+
+	query := `
+		INSERT INTO custom_field_definitions (
+			key, name, type, task_types, categories, required,
+			default_value, options, created_at, updated_at
+		) VALUES (
+			:key, :name, :type, :task_types, :categories, :required,
+			:default_value, :options, :created_at, :updated_at
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save custom field: %w", err)
+	}
+
+	return nil
+}
+
+// FindByKey находит описание поля по ключу
+func (r *PostgresCustomFieldRepository) FindByKey(ctx context.Context, key string) (*domain.CustomFieldDefinition, error) {
+	var model CustomFieldModel
+
+	query := `SELECT * FROM custom_field_definitions WHERE key = $1`
+	if err := r.db.GetContext(ctx, &model, query, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("custom field not found: %s", key)
+		}
+		return nil, fmt.Errorf("failed to find custom field: %w", err)
+	}
+
+	return model.ToDomain()
+}
+
+// FindAll возвращает все описания полей
+func (r *PostgresCustomFieldRepository) FindAll(ctx context.Context) ([]domain.CustomFieldDefinition, error) {
+	var models []CustomFieldModel
+
+	query := `SELECT * FROM custom_field_definitions ORDER BY key`
+	if err := r.db.SelectContext(ctx, &models, query); err != nil {
+		return nil, fmt.Errorf("failed to find custom fields: %w", err)
+	}
+
+	fields := make([]domain.CustomFieldDefinition, 0, len(models))
+	for _, model := range models {
+		def, err := model.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to domain: %w", err)
+		}
+		fields = append(fields, *def)
+	}
+
+	return fields, nil
+}
+
+// Update обновляет описание поля
+func (r *PostgresCustomFieldRepository) Update(ctx context.Context, def *domain.CustomFieldDefinition) error {
+	model, err := CustomFieldFromDomain(def)
+	if err != nil {
+		return fmt.Errorf("failed to convert custom field to model: %w", err)
+	}
+
+	query := `
+		UPDATE custom_field_definitions SET
+			name = :name,
+			task_types = :task_types,
+			categories = :categories,
+			required = :required,
+			default_value = :default_value,
+			options = :options,
+			updated_at = :updated_at
+		WHERE key = :key
+	`
+
+	result, err := r.db.NamedExecContext(ctx, query, model)
+	if err != nil {
+		return fmt.Errorf("failed to update custom field: %w", err)
+	}
+
+	return checkRowsAffected(result, "custom field", def.Key)
+}
+
+// Delete удаляет описание поля
+func (r *PostgresCustomFieldRepository) Delete(ctx context.Context, key string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM custom_field_definitions WHERE key = $1`, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+
+	return checkRowsAffected(result, "custom field", key)
+}
+
+// checkRowsAffected возвращает ошибку "<entity> not found", если запрос не затронул строк
+func checkRowsAffected(result sql.Result, entity, key string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s not found: %s", entity, key)
+	}
+	return nil
+}
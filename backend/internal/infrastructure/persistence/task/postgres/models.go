@@ -0,0 +1,404 @@
+// internal/infrastructure/persistence/task/postgres/models.go
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// CustomFieldModel представляет описание пользовательского поля в PostgreSQL
+type CustomFieldModel struct {
+	Key          string          `db:"key"`
+	Name         string          `db:"name"`
+	Type         string          `db:"type"`
+	TaskTypes    json.RawMessage `db:"task_types"`
+	Categories   json.RawMessage `db:"categories"`
+	Required     bool            `db:"required"`
+	DefaultValue json.RawMessage `db:"default_value"`
+	Options      json.RawMessage `db:"options"`
+	CreatedAt    time.Time       `db:"created_at"`
+	UpdatedAt    time.Time       `db:"updated_at"`
+}
+
+// CustomFieldFromDomain конвертирует domain сущность в PostgreSQL модель
+func CustomFieldFromDomain(def *domain.CustomFieldDefinition) (*CustomFieldModel, error) {
+	taskTypes, err := marshalList(def.TaskTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task types: %w", err)
+	}
+	categories, err := marshalList(def.Categories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal categories: %w", err)
+	}
+	options, err := marshalList(def.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal options: %w", err)
+	}
+
+	var defaultValue json.RawMessage
+	if def.DefaultValue != nil {
+		defaultValue, err = json.Marshal(def.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal default value: %w", err)
+		}
+	}
+
+	return &CustomFieldModel{
+		Key:          def.Key,
+		Name:         def.Name,
+		Type:         string(def.Type),
+		TaskTypes:    taskTypes,
+		Categories:   categories,
+		Required:     def.Required,
+		DefaultValue: defaultValue,
+		Options:      options,
+		CreatedAt:    def.CreatedAt,
+		UpdatedAt:    def.UpdatedAt,
+	}, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *CustomFieldModel) ToDomain() (*domain.CustomFieldDefinition, error) {
+	def := &domain.CustomFieldDefinition{
+		Key:       m.Key,
+		Name:      m.Name,
+		Type:      domain.CustomFieldType(m.Type),
+		Required:  m.Required,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+
+	if err := unmarshalList(m.TaskTypes, &def.TaskTypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task types: %w", err)
+	}
+	if err := unmarshalList(m.Categories, &def.Categories); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal categories: %w", err)
+	}
+	if err := unmarshalList(m.Options, &def.Options); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal options: %w", err)
+	}
+
+	if len(m.DefaultValue) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(m.DefaultValue, &value); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal default value: %w", err)
+		}
+		def.DefaultValue = value
+	}
+
+	return def, nil
+}
+
+// marshalList сериализует список в JSONB, nil сохраняется как пустой массив
+func marshalList[T any](items []T) (json.RawMessage, error) {
+	if items == nil {
+		items = []T{}
+	}
+	return json.Marshal(items)
+}
+
+func unmarshalList[T any](data json.RawMessage, target *[]T) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// RecurringTaskModel представляет правило повторяющейся задачи в PostgreSQL
+type RecurringTaskModel struct {
+	ID          string          `db:"id"`
+	Name        string          `db:"name"`
+	Schedule    string          `db:"schedule"`
+	Timezone    string          `db:"timezone"`
+	Subject     string          `db:"subject"`
+	Description string          `db:"description"`
+	Priority    string          `db:"priority"`
+	Category    string          `db:"category"`
+	Tags        json.RawMessage `db:"tags"`
+	AssigneeID  string          `db:"assignee_id"`
+	ReporterID  string          `db:"reporter_id"`
+	Enabled     bool            `db:"enabled"`
+	NextRunAt   *time.Time      `db:"next_run_at"`
+	LastRunAt   *time.Time      `db:"last_run_at"`
+	LastTaskID  string          `db:"last_task_id"`
+	CreatedAt   time.Time       `db:"created_at"`
+	UpdatedAt   time.Time       `db:"updated_at"`
+}
+
+// RecurringTaskFromDomain конвертирует domain сущность в PostgreSQL модель
+func RecurringTaskFromDomain(rt *domain.RecurringTask) (*RecurringTaskModel, error) {
+	tags, err := marshalList(rt.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	return &RecurringTaskModel{
+		ID:          rt.ID,
+		Name:        rt.Name,
+		Schedule:    rt.Schedule,
+		Timezone:    rt.Timezone,
+		Subject:     rt.Subject,
+		Description: rt.Description,
+		Priority:    string(rt.Priority),
+		Category:    rt.Category,
+		Tags:        tags,
+		AssigneeID:  rt.AssigneeID,
+		ReporterID:  rt.ReporterID,
+		Enabled:     rt.Enabled,
+		NextRunAt:   rt.NextRunAt,
+		LastRunAt:   rt.LastRunAt,
+		LastTaskID:  rt.LastTaskID,
+		CreatedAt:   rt.CreatedAt,
+		UpdatedAt:   rt.UpdatedAt,
+	}, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *RecurringTaskModel) ToDomain() (*domain.RecurringTask, error) {
+	rt := &domain.RecurringTask{
+		ID:          m.ID,
+		Name:        m.Name,
+		Schedule:    m.Schedule,
+		Timezone:    m.Timezone,
+		Subject:     m.Subject,
+		Description: m.Description,
+		Priority:    domain.Priority(m.Priority),
+		Category:    m.Category,
+		Tags:        []string{},
+		AssigneeID:  m.AssigneeID,
+		ReporterID:  m.ReporterID,
+		Enabled:     m.Enabled,
+		NextRunAt:   m.NextRunAt,
+		LastRunAt:   m.LastRunAt,
+		LastTaskID:  m.LastTaskID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+
+	if err := unmarshalList(m.Tags, &rt.Tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+
+	return rt, nil
+}
+
+// ScheduledReplyModel представляет запланированный ответ в PostgreSQL
+type ScheduledReplyModel struct {
+	ID        string     `db:"id"`
+	TaskID    string     `db:"task_id"`
+	AuthorID  string     `db:"author_id"`
+	Content   string     `db:"content"`
+	IsPrivate bool       `db:"is_private"`
+	SendAt    time.Time  `db:"send_at"`
+	Status    string     `db:"status"`
+	SentAt    *time.Time `db:"sent_at"`
+	Error     string     `db:"error"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+// ScheduledReplyFromDomain конвертирует domain сущность в PostgreSQL модель
+func ScheduledReplyFromDomain(reply *domain.ScheduledReply) *ScheduledReplyModel {
+	return &ScheduledReplyModel{
+		ID:        reply.ID,
+		TaskID:    reply.TaskID,
+		AuthorID:  reply.AuthorID,
+		Content:   reply.Content,
+		IsPrivate: reply.IsPrivate,
+		SendAt:    reply.SendAt,
+		Status:    string(reply.Status),
+		SentAt:    reply.SentAt,
+		Error:     reply.Error,
+		CreatedAt: reply.CreatedAt,
+		UpdatedAt: reply.UpdatedAt,
+	}
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *ScheduledReplyModel) ToDomain() *domain.ScheduledReply {
+	return &domain.ScheduledReply{
+		ID:        m.ID,
+		TaskID:    m.TaskID,
+		AuthorID:  m.AuthorID,
+		Content:   m.Content,
+		IsPrivate: m.IsPrivate,
+		SendAt:    m.SendAt,
+		Status:    domain.ScheduledReplyStatus(m.Status),
+		SentAt:    m.SentAt,
+		Error:     m.Error,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// AuditEventModel представляет запись журнала аудита в PostgreSQL
+type AuditEventModel struct {
+	ID            string          `db:"id"`
+	EntityType    string          `db:"entity_type"`
+	EntityID      string          `db:"entity_id"`
+	EventType     string          `db:"event_type"`
+	ActorID       string          `db:"actor_id"`
+	Source        string          `db:"source"`
+	CorrelationID string          `db:"correlation_id"`
+	Changes       json.RawMessage `db:"changes"`
+	Message       string          `db:"message"`
+	CreatedAt     time.Time       `db:"created_at"`
+}
+
+// auditChangeModel JSON-представление изменения поля
+type auditChangeModel struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// AuditEventFromDomain конвертирует domain сущность в PostgreSQL модель
+func AuditEventFromDomain(event domain.AuditEvent) (*AuditEventModel, error) {
+	changes := make([]auditChangeModel, len(event.Changes))
+	for i, change := range event.Changes {
+		changes[i] = auditChangeModel(change)
+	}
+	changesJSON, err := marshalList(changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal changes: %w", err)
+	}
+
+	return &AuditEventModel{
+		ID:            event.ID,
+		EntityType:    string(event.EntityType),
+		EntityID:      event.EntityID,
+		EventType:     event.Type,
+		ActorID:       event.ActorID,
+		Source:        string(event.Source),
+		CorrelationID: event.CorrelationID,
+		Changes:       changesJSON,
+		Message:       event.Message,
+		CreatedAt:     event.Timestamp,
+	}, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *AuditEventModel) ToDomain() (domain.AuditEvent, error) {
+	var changes []auditChangeModel
+	if err := unmarshalList(m.Changes, &changes); err != nil {
+		return domain.AuditEvent{}, fmt.Errorf("failed to unmarshal changes: %w", err)
+	}
+
+	event := domain.AuditEvent{
+		ID:            m.ID,
+		EntityType:    domain.AuditEntityType(m.EntityType),
+		EntityID:      m.EntityID,
+		Type:          m.EventType,
+		ActorID:       m.ActorID,
+		Source:        domain.AuditSource(m.Source),
+		CorrelationID: m.CorrelationID,
+		Message:       m.Message,
+		Timestamp:     m.CreatedAt,
+	}
+	for _, change := range changes {
+		event.Changes = append(event.Changes, domain.FieldChange(change))
+	}
+	return event, nil
+}
+
+// UserModel представляет пользователя в PostgreSQL
+type UserModel struct {
+	ID                  string          `db:"id"`
+	Email               string          `db:"email"`
+	Name                string          `db:"name"`
+	Role                string          `db:"role"`
+	Status              string          `db:"status"`
+	Categories          json.RawMessage `db:"categories"`
+	Skills              json.RawMessage `db:"skills"`
+	Signature           string          `db:"signature"`
+	PasswordHash        string          `db:"password_hash"`
+	Timezone            string          `db:"timezone"`
+	Language            string          `db:"language"`
+	OutOfOfficeFrom     *time.Time      `db:"out_of_office_from"`
+	OutOfOfficeUntil    *time.Time      `db:"out_of_office_until"`
+	OutOfOfficeMessage  string          `db:"out_of_office_message"`
+	InvitationHash      *string         `db:"invitation_hash"`
+	InvitationExpiresAt *time.Time      `db:"invitation_expires_at"`
+	CreatedAt           time.Time       `db:"created_at"`
+	UpdatedAt           time.Time       `db:"updated_at"`
+	DeactivatedAt       *time.Time      `db:"deactivated_at"`
+}
+
+// UserFromDomain конвертирует domain сущность в PostgreSQL модель
+func UserFromDomain(user *domain.User) (*UserModel, error) {
+	categories, err := marshalList(user.Categories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal categories: %w", err)
+	}
+	skills, err := marshalList(user.Skills)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal skills: %w", err)
+	}
+
+	model := &UserModel{
+		ID:                  user.ID,
+		Email:               user.Email,
+		Name:                user.Name,
+		Role:                string(user.Role),
+		Status:              string(user.Status),
+		Categories:          categories,
+		Skills:              skills,
+		Signature:           user.Signature,
+		PasswordHash:        user.PasswordHash,
+		Timezone:            user.Timezone,
+		Language:            string(user.Language),
+		InvitationExpiresAt: user.InvitationExpiresAt,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+		DeactivatedAt:       user.DeactivatedAt,
+	}
+	if user.OutOfOffice != nil {
+		model.OutOfOfficeFrom = &user.OutOfOffice.From
+		model.OutOfOfficeUntil = &user.OutOfOffice.Until
+		model.OutOfOfficeMessage = user.OutOfOffice.Message
+	}
+	// Уникальность проверяется только для выданных приглашений
+	if user.InvitationHash != "" {
+		model.InvitationHash = &user.InvitationHash
+	}
+	return model, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *UserModel) ToDomain() (*domain.User, error) {
+	user := &domain.User{
+		ID:                  m.ID,
+		Email:               m.Email,
+		Name:                m.Name,
+		Role:                domain.UserRole(m.Role),
+		Status:              domain.UserStatus(m.Status),
+		Signature:           m.Signature,
+		PasswordHash:        m.PasswordHash,
+		Timezone:            m.Timezone,
+		Language:            domain.TemplateLanguage(m.Language),
+		InvitationExpiresAt: m.InvitationExpiresAt,
+		CreatedAt:           m.CreatedAt,
+		UpdatedAt:           m.UpdatedAt,
+		DeactivatedAt:       m.DeactivatedAt,
+	}
+	if err := unmarshalList(m.Categories, &user.Categories); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal categories: %w", err)
+	}
+	if err := unmarshalList(m.Skills, &user.Skills); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal skills: %w", err)
+	}
+	if m.OutOfOfficeFrom != nil && m.OutOfOfficeUntil != nil {
+		user.OutOfOffice = &domain.OutOfOffice{
+			From:    *m.OutOfOfficeFrom,
+			Until:   *m.OutOfOfficeUntil,
+			Message: m.OutOfOfficeMessage,
+		}
+	}
+	if m.InvitationHash != nil {
+		user.InvitationHash = *m.InvitationHash
+	}
+	return user, nil
+}
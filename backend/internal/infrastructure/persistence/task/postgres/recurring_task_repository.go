@@ -0,0 +1,143 @@
+// internal/infrastructure/persistence/task/postgres/recurring_task_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresRecurringTaskRepository реализует ports.RecurringTaskRepository для PostgreSQL
+type PostgresRecurringTaskRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRecurringTaskRepository создает репозиторий правил повторяющихся задач
+func NewPostgresRecurringTaskRepository(db *sqlx.DB) *PostgresRecurringTaskRepository {
+	return &PostgresRecurringTaskRepository{
+		db: db,
+	}
+}
+
+// Save сохраняет новое правило
+func (r *PostgresRecurringTaskRepository) Save(ctx context.Context, rt *domain.RecurringTask) error {
+	model, err := RecurringTaskFromDomain(rt)
+	if err != nil {
+		return fmt.Errorf("failed to convert recurring task to model: %w", err)
+	}
+
+	query := `
+		INSERT INTO recurring_tasks (
+			id, name, schedule, timezone, subject, description, priority, category,
+			tags, assignee_id, reporter_id, enabled, next_run_at, last_run_at,
+			last_task_id, created_at, updated_at
+		) VALUES (
+			:id, :name, :schedule, :timezone, :subject, :description, :priority, :category,
+			:tags, :assignee_id, :reporter_id, :enabled, :next_run_at, :last_run_at,
+			:last_task_id, :created_at, :updated_at
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save recurring task: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID находит правило по ID
+func (r *PostgresRecurringTaskRepository) FindByID(ctx context.Context, id string) (*domain.RecurringTask, error) {
+	var model RecurringTaskModel
+
+	query := `SELECT * FROM recurring_tasks WHERE id = $1`
+	if err := r.db.GetContext(ctx, &model, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("recurring task not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find recurring task: %w", err)
+	}
+
+	return model.ToDomain()
+}
+
+// FindAll возвращает все правила
+func (r *PostgresRecurringTaskRepository) FindAll(ctx context.Context) ([]domain.RecurringTask, error) {
+	return r.selectRules(ctx, `SELECT * FROM recurring_tasks ORDER BY name`)
+}
+
+// FindDue возвращает включенные правила, срок срабатывания которых наступил
+func (r *PostgresRecurringTaskRepository) FindDue(ctx context.Context, now time.Time) ([]domain.RecurringTask, error) {
+	query := `
+		SELECT * FROM recurring_tasks
+		WHERE enabled AND next_run_at IS NOT NULL AND next_run_at <= $1
+		ORDER BY next_run_at
+	`
+	return r.selectRules(ctx, query, now)
+}
+
+// Update обновляет правило
+func (r *PostgresRecurringTaskRepository) Update(ctx context.Context, rt *domain.RecurringTask) error {
+	model, err := RecurringTaskFromDomain(rt)
+	if err != nil {
+		return fmt.Errorf("failed to convert recurring task to model: %w", err)
+	}
+
+	query := `
+		UPDATE recurring_tasks SET
+			name = :name,
+			schedule = :schedule,
+			timezone = :timezone,
+			subject = :subject,
+			description = :description,
+			priority = :priority,
+			category = :category,
+			tags = :tags,
+			assignee_id = :assignee_id,
+			enabled = :enabled,
+			next_run_at = :next_run_at,
+			last_run_at = :last_run_at,
+			last_task_id = :last_task_id,
+			updated_at = :updated_at
+		WHERE id = :id
+	`
+
+	result, err := r.db.NamedExecContext(ctx, query, model)
+	if err != nil {
+		return fmt.Errorf("failed to update recurring task: %w", err)
+	}
+
+	return checkRowsAffected(result, "recurring task", rt.ID)
+}
+
+// Delete удаляет правило
+func (r *PostgresRecurringTaskRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recurring_tasks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring task: %w", err)
+	}
+
+	return checkRowsAffected(result, "recurring task", id)
+}
+
+func (r *PostgresRecurringTaskRepository) selectRules(ctx context.Context, query string, args ...interface{}) ([]domain.RecurringTask, error) {
+	var models []RecurringTaskModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find recurring tasks: %w", err)
+	}
+
+	rules := make([]domain.RecurringTask, 0, len(models))
+	for _, model := range models {
+		rt, err := model.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to domain: %w", err)
+		}
+		rules = append(rules, *rt)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,110 @@
+// internal/infrastructure/persistence/task/postgres/scheduled_reply_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresScheduledReplyRepository реализует ports.ScheduledReplyRepository для PostgreSQL
+type PostgresScheduledReplyRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresScheduledReplyRepository создает репозиторий запланированных ответов
+func NewPostgresScheduledReplyRepository(db *sqlx.DB) *PostgresScheduledReplyRepository {
+	return &PostgresScheduledReplyRepository{
+		db: db,
+	}
+}
+
+// Save сохраняет новый запланированный ответ
+func (r *PostgresScheduledReplyRepository) Save(ctx context.Context, reply *domain.ScheduledReply) error {
+	query := `
+		INSERT INTO scheduled_replies (
+			id, task_id, author_id, content, is_private, send_at,
+			status, sent_at, error, created_at, updated_at
+		) VALUES (
+			:id, :task_id, :author_id, :content, :is_private, :send_at,
+			:status, :sent_at, :error, :created_at, :updated_at
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, ScheduledReplyFromDomain(reply)); err != nil {
+		return fmt.Errorf("failed to save scheduled reply: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID находит запланированный ответ по ID
+func (r *PostgresScheduledReplyRepository) FindByID(ctx context.Context, id string) (*domain.ScheduledReply, error) {
+	var model ScheduledReplyModel
+
+	query := `SELECT * FROM scheduled_replies WHERE id = $1`
+	if err := r.db.GetContext(ctx, &model, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("scheduled reply not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find scheduled reply: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// FindByTaskID возвращает запланированные ответы задачи
+func (r *PostgresScheduledReplyRepository) FindByTaskID(ctx context.Context, taskID string) ([]domain.ScheduledReply, error) {
+	return r.selectReplies(ctx, `SELECT * FROM scheduled_replies WHERE task_id = $1 ORDER BY send_at`, taskID)
+}
+
+// FindDue возвращает ожидающие ответы, время отправки которых наступило
+func (r *PostgresScheduledReplyRepository) FindDue(ctx context.Context, now time.Time) ([]domain.ScheduledReply, error) {
+	query := `
+		SELECT * FROM scheduled_replies
+		WHERE status = 'pending' AND send_at <= $1
+		ORDER BY send_at
+	`
+	return r.selectReplies(ctx, query, now)
+}
+
+// Update обновляет запланированный ответ
+func (r *PostgresScheduledReplyRepository) Update(ctx context.Context, reply *domain.ScheduledReply) error {
+	query := `
+		UPDATE scheduled_replies SET
+			content = :content,
+			is_private = :is_private,
+			send_at = :send_at,
+			status = :status,
+			sent_at = :sent_at,
+			error = :error,
+			updated_at = :updated_at
+		WHERE id = :id
+	`
+
+	result, err := r.db.NamedExecContext(ctx, query, ScheduledReplyFromDomain(reply))
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled reply: %w", err)
+	}
+
+	return checkRowsAffected(result, "scheduled reply", reply.ID)
+}
+
+func (r *PostgresScheduledReplyRepository) selectReplies(ctx context.Context, query string, args ...interface{}) ([]domain.ScheduledReply, error) {
+	var models []ScheduledReplyModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find scheduled replies: %w", err)
+	}
+
+	replies := make([]domain.ScheduledReply, 0, len(models))
+	for _, model := range models {
+		replies = append(replies, *model.ToDomain())
+	}
+
+	return replies, nil
+}
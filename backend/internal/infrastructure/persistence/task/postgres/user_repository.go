@@ -0,0 +1,221 @@
+// internal/infrastructure/persistence/task/postgres/user_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresUserRepository реализует ports.UserRepository для PostgreSQL.
+// Email уникален без учета регистра, токен приглашения - среди выданных приглашений
+type PostgresUserRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresUserRepository создает репозиторий пользователей
+func NewPostgresUserRepository(db *sqlx.DB) *PostgresUserRepository {
+	return &PostgresUserRepository{
+		db: db,
+	}
+}
+
+// FindByID находит пользователя по ID
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	if id == "" {
+		return nil, errors.New("user ID cannot be empty")
+	}
+
+	user, err := r.findOne(ctx, `SELECT * FROM users WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+	}
+	return user, nil
+}
+
+// FindByEmail находит пользователя по email без учета регистра; nil, если не найден
+func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if email == "" {
+		return nil, errors.New("email cannot be empty")
+	}
+
+	return r.findOne(ctx, `SELECT * FROM users WHERE LOWER(email) = LOWER($1)`, email)
+}
+
+// FindAssignees возвращает активных и присутствующих операторов и менеджеров
+func (r *PostgresUserRepository) FindAssignees(ctx context.Context) ([]domain.User, error) {
+	query := `SELECT * FROM users WHERE role IN ($1, $2) AND status = $3 ORDER BY name, id`
+	users, err := r.findMany(ctx, query, domain.UserRoleOperator, domain.UserRoleManager, domain.UserStatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	// Период отсутствия проверяется в domain, чтобы правило было одним для всех хранилищ
+	now := time.Now()
+	assignees := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		if user.IsAvailable(now) {
+			assignees = append(assignees, user)
+		}
+	}
+	return assignees, nil
+}
+
+// FindAll возвращает всех пользователей, упорядоченных по имени
+func (r *PostgresUserRepository) FindAll(ctx context.Context) ([]domain.User, error) {
+	return r.findMany(ctx, `SELECT * FROM users ORDER BY name, id`)
+}
+
+// FindByInvitationHash находит пользователя по хешу токена приглашения
+func (r *PostgresUserRepository) FindByInvitationHash(ctx context.Context, hash string) (*domain.User, error) {
+	if hash == "" {
+		return nil, domain.ErrInvalidInvitation
+	}
+
+	user, err := r.findOne(ctx, `SELECT * FROM users WHERE invitation_hash = $1`, hash)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrInvalidInvitation
+	}
+	return user, nil
+}
+
+// Save сохраняет нового пользователя
+func (r *PostgresUserRepository) Save(ctx context.Context, user *domain.User) error {
+	if user == nil {
+		return errors.New("user cannot be nil")
+	}
+	if user.ID == "" {
+		return errors.New("user ID cannot be empty")
+	}
+
+	model, err := UserFromDomain(user)
+	if err != nil {
+		return fmt.Errorf("failed to convert user to model: %w", err)
+	}
+
+	query := `
+		INSERT INTO users (
+			id, email, name, role, status, categories, skills, signature, password_hash,
+			timezone, language, out_of_office_from, out_of_office_until, out_of_office_message,
+			invitation_hash, invitation_expires_at, created_at, updated_at, deactivated_at
+		) VALUES (
+			:id, :email, :name, :role, :status, :categories, :skills, :signature, :password_hash,
+			:timezone, :language, :out_of_office_from, :out_of_office_until, :out_of_office_message,
+			:invitation_hash, :invitation_expires_at, :created_at, :updated_at, :deactivated_at
+		)
+	`
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrUserExists
+		}
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
+
+// Update обновляет пользователя
+func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User) error {
+	if user == nil {
+		return errors.New("user cannot be nil")
+	}
+
+	model, err := UserFromDomain(user)
+	if err != nil {
+		return fmt.Errorf("failed to convert user to model: %w", err)
+	}
+
+	query := `
+		UPDATE users SET
+			email = :email,
+			name = :name,
+			role = :role,
+			status = :status,
+			categories = :categories,
+			skills = :skills,
+			signature = :signature,
+			password_hash = :password_hash,
+			timezone = :timezone,
+			language = :language,
+			out_of_office_from = :out_of_office_from,
+			out_of_office_until = :out_of_office_until,
+			out_of_office_message = :out_of_office_message,
+			invitation_hash = :invitation_hash,
+			invitation_expires_at = :invitation_expires_at,
+			updated_at = :updated_at,
+			deactivated_at = :deactivated_at
+		WHERE id = :id
+	`
+	result, err := r.db.NamedExecContext(ctx, query, model)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrUserExists
+		}
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrUserNotFound, user.ID)
+	}
+	return nil
+}
+
+// Delete удаляет пользователя
+func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("user ID cannot be empty")
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return checkRowsAffected(result, "user", id)
+}
+
+// findOne возвращает пользователя по запросу; nil, если не найден
+func (r *PostgresUserRepository) findOne(ctx context.Context, query string, args ...any) (*domain.User, error) {
+	var model UserModel
+	if err := r.db.GetContext(ctx, &model, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return model.ToDomain()
+}
+
+func (r *PostgresUserRepository) findMany(ctx context.Context, query string, args ...any) ([]domain.User, error) {
+	var models []UserModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	users := make([]domain.User, 0, len(models))
+	for _, model := range models {
+		user, err := model.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to domain: %w", err)
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
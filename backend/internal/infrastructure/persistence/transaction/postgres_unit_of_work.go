@@ -0,0 +1,83 @@
+// internal/infrastructure/persistence/transaction/postgres_unit_of_work.go
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type txKeyType struct{}
+
+var txKey = txKeyType{}
+
+// PostgresUnitOfWork реализует ports.UnitOfWork транзакцией PostgreSQL.
+// Транзакция передается репозиториям через context
+type PostgresUnitOfWork struct {
+	db *sqlx.DB
+}
+
+func NewPostgresUnitOfWork(db *sqlx.DB) *PostgresUnitOfWork {
+	return &PostgresUnitOfWork{
+		db: db,
+	}
+}
+
+// Do выполняет функцию в транзакции; вложенный вызов использует внешнюю транзакцию
+func (u *PostgresUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// TxFromContext возвращает транзакцию текущей единицы работы
+func TxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey).(*sqlx.Tx)
+	return tx, ok
+}
+
+// Executor возвращает транзакцию из context, если она есть, иначе само соединение
+func Executor(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// Run выполняет функцию в транзакции из context либо в собственной транзакции
+func Run(ctx context.Context, db *sqlx.DB, fn func(tx sqlx.ExtContext) error) error {
+	if tx, ok := TxFromContext(ctx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
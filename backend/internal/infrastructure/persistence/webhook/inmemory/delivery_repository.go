@@ -0,0 +1,138 @@
+// internal/infrastructure/persistence/webhook/inmemory/delivery_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// WebhookDeliveryRepository очередь и журнал доставок вебхуков в памяти
+type WebhookDeliveryRepository struct {
+	deliveries map[string]*domain.WebhookDelivery
+	byEvent    map[string]string // подписка + событие → ID доставки
+	mu         sync.RWMutex
+	logger     ports.Logger
+}
+
+func NewWebhookDeliveryRepository(logger ports.Logger) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		deliveries: make(map[string]*domain.WebhookDelivery),
+		byEvent:    make(map[string]string),
+		logger:     logger,
+	}
+}
+
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if delivery == nil || delivery.ID == "" {
+		return errors.New("webhook delivery ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := delivery.SubscriptionID + "/" + delivery.EventID
+	if _, exists := r.byEvent[key]; exists {
+		return domain.ErrWebhookDeliveryExists
+	}
+
+	r.deliveries[delivery.ID] = cloneDelivery(delivery)
+	r.byEvent[key] = delivery.ID
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, exists := r.deliveries[id]
+	if !exists {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	return cloneDelivery(delivery), nil
+}
+
+// FindDue возвращает ожидающие доставки, срок попытки которых наступил, в порядке постановки
+func (r *WebhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := []domain.WebhookDelivery{}
+	for _, delivery := range r.deliveries {
+		if delivery.IsDue(now) {
+			due = append(due, *cloneDelivery(delivery))
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *WebhookDeliveryRepository) Query(ctx context.Context, query ports.WebhookDeliveryQuery) ([]domain.WebhookDelivery, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := []domain.WebhookDelivery{}
+	for _, delivery := range r.deliveries {
+		if query.SubscriptionID != "" && delivery.SubscriptionID != query.SubscriptionID {
+			continue
+		}
+		if query.Status != "" && delivery.Status != query.Status {
+			continue
+		}
+		matched = append(matched, *cloneDelivery(delivery))
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	total := len(matched)
+	if query.Offset > 0 {
+		if query.Offset >= len(matched) {
+			return []domain.WebhookDelivery{}, total, nil
+		}
+		matched = matched[query.Offset:]
+	}
+	if query.Limit > 0 && len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, total, nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+
+	r.deliveries[delivery.ID] = cloneDelivery(delivery)
+	return nil
+}
+
+func cloneDelivery(delivery *domain.WebhookDelivery) *domain.WebhookDelivery {
+	clone := *delivery
+	clone.Payload = append([]byte(nil), delivery.Payload...)
+	clone.Attempts = append([]domain.WebhookAttempt(nil), delivery.Attempts...)
+	if delivery.DeliveredAt != nil {
+		deliveredAt := *delivery.DeliveredAt
+		clone.DeliveredAt = &deliveredAt
+	}
+	return &clone
+}
@@ -0,0 +1,115 @@
+// internal/infrastructure/persistence/webhook/inmemory/webhook_repository.go
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// WebhookRepository подписки на вебхуки в памяти
+type WebhookRepository struct {
+	subscriptions map[string]*domain.WebhookSubscription
+	mu            sync.RWMutex
+	logger        ports.Logger
+}
+
+func NewWebhookRepository(logger ports.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		subscriptions: make(map[string]*domain.WebhookSubscription),
+		logger:        logger,
+	}
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	if subscription == nil {
+		return errors.New("webhook subscription cannot be nil")
+	}
+	if subscription.ID == "" {
+		return errors.New("webhook subscription ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.subscriptions[subscription.ID]; exists {
+		return fmt.Errorf("webhook subscription already exists: %s", subscription.ID)
+	}
+
+	r.subscriptions[subscription.ID] = cloneSubscription(subscription)
+	r.logger.Info(ctx, "webhook subscription saved", "webhook_id", subscription.ID)
+	return nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, exists := r.subscriptions[id]
+	if !exists {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return cloneSubscription(subscription), nil
+}
+
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return r.find(func(*domain.WebhookSubscription) bool { return true }), nil
+}
+
+func (r *WebhookRepository) FindActive(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return r.find(func(s *domain.WebhookSubscription) bool { return s.Active }), nil
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.subscriptions[subscription.ID]; !exists {
+		return domain.ErrWebhookNotFound
+	}
+
+	r.subscriptions[subscription.ID] = cloneSubscription(subscription)
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.subscriptions[id]; !exists {
+		return domain.ErrWebhookNotFound
+	}
+
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *WebhookRepository) find(match func(*domain.WebhookSubscription) bool) []domain.WebhookSubscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []domain.WebhookSubscription{}
+	for _, subscription := range r.subscriptions {
+		if match(subscription) {
+			result = append(result, *cloneSubscription(subscription))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+func cloneSubscription(subscription *domain.WebhookSubscription) *domain.WebhookSubscription {
+	clone := *subscription
+	clone.EventTypes = append([]domain.DomainEventType(nil), subscription.EventTypes...)
+	clone.TaskTypes = append([]domain.TaskType(nil), subscription.TaskTypes...)
+	clone.CustomerIDs = append([]string(nil), subscription.CustomerIDs...)
+	return &clone
+}
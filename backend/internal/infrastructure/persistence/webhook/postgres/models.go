@@ -0,0 +1,184 @@
+// internal/infrastructure/persistence/webhook/postgres/models.go
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// WebhookSubscriptionModel представляет подписку на вебхуки в PostgreSQL
+type WebhookSubscriptionModel struct {
+	ID          string          `db:"id"`
+	Name        string          `db:"name"`
+	URL         string          `db:"url"`
+	Secret      string          `db:"secret"`
+	EventTypes  json.RawMessage `db:"event_types"`
+	TaskTypes   json.RawMessage `db:"task_types"`
+	CustomerIDs json.RawMessage `db:"customer_ids"`
+	Active      bool            `db:"active"`
+	CreatedBy   string          `db:"created_by"`
+	CreatedAt   time.Time       `db:"created_at"`
+	UpdatedAt   time.Time       `db:"updated_at"`
+}
+
+// WebhookSubscriptionFromDomain конвертирует domain сущность в PostgreSQL модель
+func WebhookSubscriptionFromDomain(subscription *domain.WebhookSubscription) (*WebhookSubscriptionModel, error) {
+	eventTypes, err := marshalList(subscription.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event types: %w", err)
+	}
+	taskTypes, err := marshalList(subscription.TaskTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task types: %w", err)
+	}
+	customerIDs, err := marshalList(subscription.CustomerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal customer IDs: %w", err)
+	}
+
+	return &WebhookSubscriptionModel{
+		ID:          subscription.ID,
+		Name:        subscription.Name,
+		URL:         subscription.URL,
+		Secret:      subscription.Secret,
+		EventTypes:  eventTypes,
+		TaskTypes:   taskTypes,
+		CustomerIDs: customerIDs,
+		Active:      subscription.Active,
+		CreatedBy:   subscription.CreatedBy,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *WebhookSubscriptionModel) ToDomain() (*domain.WebhookSubscription, error) {
+	subscription := &domain.WebhookSubscription{
+		ID:        m.ID,
+		Name:      m.Name,
+		URL:       m.URL,
+		Secret:    m.Secret,
+		Active:    m.Active,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if err := unmarshalList(m.EventTypes, &subscription.EventTypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event types: %w", err)
+	}
+	if err := unmarshalList(m.TaskTypes, &subscription.TaskTypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task types: %w", err)
+	}
+	if err := unmarshalList(m.CustomerIDs, &subscription.CustomerIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal customer IDs: %w", err)
+	}
+	return subscription, nil
+}
+
+// WebhookDeliveryModel представляет доставку вебхука в PostgreSQL
+type WebhookDeliveryModel struct {
+	ID             string          `db:"id"`
+	SubscriptionID string          `db:"subscription_id"`
+	EventID        string          `db:"event_id"`
+	EventType      string          `db:"event_type"`
+	Payload        json.RawMessage `db:"payload"`
+	Status         string          `db:"status"`
+	Attempts       json.RawMessage `db:"attempts"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at"`
+	DeliveredAt    sql.NullTime    `db:"delivered_at"`
+	CreatedAt      time.Time       `db:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at"`
+}
+
+// webhookAttemptModel JSON-представление попытки доставки
+type webhookAttemptModel struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// WebhookDeliveryFromDomain конвертирует domain сущность в PostgreSQL модель
+func WebhookDeliveryFromDomain(delivery *domain.WebhookDelivery) (*WebhookDeliveryModel, error) {
+	attempts := make([]webhookAttemptModel, len(delivery.Attempts))
+	for i, attempt := range delivery.Attempts {
+		attempts[i] = webhookAttemptModel{
+			At:         attempt.At,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.Duration.Milliseconds(),
+		}
+	}
+	attemptsJSON, err := marshalList(attempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal attempts: %w", err)
+	}
+
+	model := &WebhookDeliveryModel{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       attemptsJSON,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+	if delivery.DeliveredAt != nil {
+		model.DeliveredAt = sql.NullTime{Time: *delivery.DeliveredAt, Valid: true}
+	}
+	return model, nil
+}
+
+// ToDomain конвертирует PostgreSQL модель в domain сущность
+func (m *WebhookDeliveryModel) ToDomain() (*domain.WebhookDelivery, error) {
+	var attempts []webhookAttemptModel
+	if err := unmarshalList(m.Attempts, &attempts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attempts: %w", err)
+	}
+
+	delivery := &domain.WebhookDelivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		EventType:      domain.DomainEventType(m.EventType),
+		Payload:        []byte(m.Payload),
+		Status:         domain.WebhookDeliveryStatus(m.Status),
+		NextAttemptAt:  m.NextAttemptAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	for _, attempt := range attempts {
+		delivery.Attempts = append(delivery.Attempts, domain.WebhookAttempt{
+			At:         attempt.At,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			Duration:   time.Duration(attempt.DurationMs) * time.Millisecond,
+		})
+	}
+	if m.DeliveredAt.Valid {
+		deliveredAt := m.DeliveredAt.Time
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return delivery, nil
+}
+
+func marshalList[T any](items []T) (json.RawMessage, error) {
+	if items == nil {
+		items = []T{}
+	}
+	return json.Marshal(items)
+}
+
+func unmarshalList[T any](data json.RawMessage, target *[]T) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
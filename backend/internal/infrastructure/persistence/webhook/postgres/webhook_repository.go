@@ -0,0 +1,259 @@
+// internal/infrastructure/persistence/webhook/postgres/webhook_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresWebhookRepository реализует ports.WebhookRepository для PostgreSQL
+type PostgresWebhookRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresWebhookRepository создает репозиторий подписок на вебхуки
+func NewPostgresWebhookRepository(db *sqlx.DB) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresWebhookRepository) Save(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	model, err := WebhookSubscriptionFromDomain(subscription)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (
+			id, name, url, secret, event_types, task_types, customer_ids,
+			active, created_by, created_at, updated_at
+		) VALUES (
+			:id, :name, :url, :secret, :event_types, :task_types, :customer_ids,
+			:active, :created_by, :created_at, :updated_at
+		)
+	`
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWebhookRepository) FindByID(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	var model WebhookSubscriptionModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM webhook_subscriptions WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to find webhook subscription: %w", err)
+	}
+	return model.ToDomain()
+}
+
+func (r *PostgresWebhookRepository) FindAll(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return r.find(ctx, `SELECT * FROM webhook_subscriptions ORDER BY created_at`)
+}
+
+func (r *PostgresWebhookRepository) FindActive(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return r.find(ctx, `SELECT * FROM webhook_subscriptions WHERE active ORDER BY created_at`)
+}
+
+func (r *PostgresWebhookRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	model, err := WebhookSubscriptionFromDomain(subscription)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhook_subscriptions SET
+			name = :name, url = :url, secret = :secret, event_types = :event_types,
+			task_types = :task_types, customer_ids = :customer_ids, active = :active,
+			updated_at = :updated_at
+		WHERE id = :id
+	`
+	result, err := r.db.NamedExecContext(ctx, query, model)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return requireAffected(result, domain.ErrWebhookNotFound)
+}
+
+func (r *PostgresWebhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return requireAffected(result, domain.ErrWebhookNotFound)
+}
+
+func (r *PostgresWebhookRepository) find(ctx context.Context, query string) ([]domain.WebhookSubscription, error) {
+	var models []WebhookSubscriptionModel
+	if err := r.db.SelectContext(ctx, &models, query); err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+
+	subscriptions := make([]domain.WebhookSubscription, 0, len(models))
+	for _, model := range models {
+		subscription, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, nil
+}
+
+// PostgresWebhookDeliveryRepository реализует ports.WebhookDeliveryRepository для PostgreSQL
+type PostgresWebhookDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresWebhookDeliveryRepository создает репозиторий доставок вебхуков
+func NewPostgresWebhookDeliveryRepository(db *sqlx.DB) *PostgresWebhookDeliveryRepository {
+	return &PostgresWebhookDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresWebhookDeliveryRepository) Save(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	model, err := WebhookDeliveryFromDomain(delivery)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (
+			id, subscription_id, event_id, event_type, payload, status, attempts,
+			next_attempt_at, delivered_at, created_at, updated_at
+		) VALUES (
+			:id, :subscription_id, :event_id, :event_type, :payload, :status, :attempts,
+			:next_attempt_at, :delivered_at, :created_at, :updated_at
+		)
+	`
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrWebhookDeliveryExists
+		}
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	var model WebhookDeliveryModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM webhook_deliveries WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+	return model.ToDomain()
+}
+
+func (r *PostgresWebhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT * FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY created_at
+		LIMIT $2
+	`
+	var models []WebhookDeliveryModel
+	if err := r.db.SelectContext(ctx, &models, query, now, limit); err != nil {
+		return nil, fmt.Errorf("failed to find due webhook deliveries: %w", err)
+	}
+	return toDeliveries(models)
+}
+
+func (r *PostgresWebhookDeliveryRepository) Query(ctx context.Context, query ports.WebhookDeliveryQuery) ([]domain.WebhookDelivery, int, error) {
+	var conditions []string
+	var args []interface{}
+	if query.SubscriptionID != "" {
+		args = append(args, query.SubscriptionID)
+		conditions = append(conditions, fmt.Sprintf("subscription_id = $%d", len(args)))
+	}
+	if query.Status != "" {
+		args = append(args, string(query.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM webhook_deliveries "+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	selectQuery := fmt.Sprintf("SELECT * FROM webhook_deliveries %s ORDER BY created_at DESC, id DESC", where)
+	if query.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT %d", query.Limit)
+	}
+	if query.Offset > 0 {
+		selectQuery += fmt.Sprintf(" OFFSET %d", query.Offset)
+	}
+
+	var models []WebhookDeliveryModel
+	if err := r.db.SelectContext(ctx, &models, selectQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+
+	deliveries, err := toDeliveries(models)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	model, err := WebhookDeliveryFromDomain(delivery)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhook_deliveries SET
+			status = :status, attempts = :attempts, next_attempt_at = :next_attempt_at,
+			delivered_at = :delivered_at, updated_at = :updated_at
+		WHERE id = :id
+	`
+	result, err := r.db.NamedExecContext(ctx, query, model)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return requireAffected(result, domain.ErrWebhookDeliveryNotFound)
+}
+
+func toDeliveries(models []WebhookDeliveryModel) ([]domain.WebhookDelivery, error) {
+	deliveries := make([]domain.WebhookDelivery, 0, len(models))
+	for _, model := range models {
+		delivery, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, nil
+}
+
+func requireAffected(result sql.Result, notFound error) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return notFound
+	}
+	return nil
+}
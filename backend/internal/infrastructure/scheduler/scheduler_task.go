@@ -0,0 +1,141 @@
+// internal/infrastructure/scheduler/scheduler_task.go
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// SchedulerTask фоновая задача, периодически выполняющая наступившие задания
+// планировщика: пробуждение отложенных задач, повторяющиеся задачи и запланированные ответы.
+// Все задания хранятся в репозиториях, поэтому после перезапуска первый проход
+// сразу выполняет то, что наступило за время простоя
+type SchedulerTask struct {
+	schedulerService ports.SchedulerService
+	interval         time.Duration
+	operationTimeout time.Duration
+	logger           ports.Logger
+	cancelFunc       context.CancelFunc
+	isRunning        bool
+	lastRunAt        time.Time
+	mu               sync.RWMutex
+}
+
+func NewSchedulerTask(
+	schedulerService ports.SchedulerService,
+	interval time.Duration,
+	operationTimeout time.Duration,
+	logger ports.Logger,
+) *SchedulerTask {
+	return &SchedulerTask{
+		schedulerService: schedulerService,
+		interval:         interval,
+		operationTimeout: operationTimeout,
+		logger:           logger,
+		isRunning:        false,
+	}
+}
+
+func (t *SchedulerTask) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isRunning {
+		return fmt.Errorf("scheduler task already running")
+	}
+	if t.interval <= 0 {
+		return fmt.Errorf("scheduler interval must be positive")
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	t.cancelFunc = cancel
+	t.isRunning = true
+
+	go t.runLoop(taskCtx)
+
+	t.logger.Info(ctx, "scheduler task started",
+		"interval", t.interval,
+		"operation_timeout", t.operationTimeout)
+
+	return nil
+}
+
+func (t *SchedulerTask) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.isRunning {
+		return nil
+	}
+
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
+
+	t.isRunning = false
+	t.logger.Info(ctx, "scheduler task stopped")
+	return nil
+}
+
+func (t *SchedulerTask) Name() string {
+	return "task_scheduler"
+}
+
+func (t *SchedulerTask) Health(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.isRunning {
+		return fmt.Errorf("scheduler task is not running")
+	}
+	// Проход не выполнялся дольше трех интервалов - цикл завис
+	if !t.lastRunAt.IsZero() && time.Since(t.lastRunAt) > 3*t.interval+t.operationTimeout {
+		return fmt.Errorf("scheduler task has not run since %s", t.lastRunAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (t *SchedulerTask) runLoop(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	// Первый проход сразу после запуска догоняет задания, наступившие во время простоя
+	t.executeRun(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.logger.Info(ctx, "scheduler loop stopped")
+			return
+		case <-ticker.C:
+			t.executeRun(ctx)
+		}
+	}
+}
+
+func (t *SchedulerTask) executeRun(ctx context.Context) {
+	now := time.Now()
+	runCtx := context.WithValue(ctx, ports.CorrelationIDKey, fmt.Sprintf("scheduler-%d", now.UnixNano()))
+	runCtx = ports.WithAuditSource(runCtx, domain.AuditSourceAutomation)
+
+	timeoutCtx, cancel := context.WithTimeout(runCtx, t.operationTimeout)
+	defer cancel()
+
+	result, err := t.schedulerService.RunDueJobs(timeoutCtx, now)
+	if err != nil {
+		t.logger.Error(runCtx, "scheduler run failed", "error", err)
+	} else {
+		for _, jobErr := range result.Errors {
+			t.logger.Warn(runCtx, "scheduler job failed", "error", jobErr)
+		}
+	}
+
+	t.mu.Lock()
+	t.lastRunAt = now
+	t.mu.Unlock()
+}
@@ -0,0 +1,211 @@
+// internal/infrastructure/telegram/client.go
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+const (
+	// DefaultAPIBaseURL адрес Bot API по умолчанию
+	DefaultAPIBaseURL = "https://api.telegram.org"
+
+	// maxDownloadSize ограничение Bot API на скачивание файлов ботом
+	maxDownloadSize = 20 * 1024 * 1024
+	// maxPhotoSize ограничение sendPhoto: файлы крупнее отправляются документом
+	maxPhotoSize = 10 * 1024 * 1024
+)
+
+// Client реализует ports.TelegramGateway поверх HTTP Bot API.
+// Базовый адрес настраивается, поэтому тесты и локальный Bot API сервер
+// работают без обращения к api.telegram.org
+type Client struct {
+	baseURL        string
+	token          string
+	requestTimeout time.Duration
+	httpClient     *http.Client
+}
+
+// NewClient создает клиента; requestTimeout ограничивает каждый запрос,
+// для getUpdates к нему добавляется время ожидания long polling
+func NewClient(baseURL, token string, requestTimeout time.Duration) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIBaseURL
+	}
+	return &Client{
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		token:          token,
+		requestTimeout: requestTimeout,
+		httpClient:     &http.Client{},
+	}
+}
+
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]domain.TelegramMessage, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout+c.requestTimeout)
+	defer cancel()
+
+	params := map[string]interface{}{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}
+	var updates []Update
+	if err := c.call(ctx, "getUpdates", params, &updates); err != nil {
+		return nil, offset, err
+	}
+
+	next := offset
+	messages := make([]domain.TelegramMessage, 0, len(updates))
+	for i := range updates {
+		if updates[i].UpdateID >= next {
+			next = updates[i].UpdateID + 1
+		}
+		if message, ok := updates[i].ToDomain(); ok {
+			messages = append(messages, message)
+		}
+	}
+	return messages, next, nil
+}
+
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+
+	params := map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	}
+	return c.call(ctx, "sendMessage", params, nil)
+}
+
+func (c *Client) SendAttachment(ctx context.Context, chatID int64, attachment domain.Attachment) error {
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+
+	method, field := "sendDocument", "document"
+	if isPhoto(attachment) {
+		method, field = "sendPhoto", "photo"
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("chat_id", fmt.Sprintf("%d", chatID)); err != nil {
+		return fmt.Errorf("failed to build %s request: %w", method, err)
+	}
+	part, err := writer.CreateFormFile(field, attachment.Name)
+	if err != nil {
+		return fmt.Errorf("failed to build %s request: %w", method, err)
+	}
+	if _, err := part.Write(attachment.Data); err != nil {
+		return fmt.Errorf("failed to build %s request: %w", method, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to build %s request: %w", method, err)
+	}
+
+	return c.do(ctx, method, writer.FormDataContentType(), &body, nil)
+}
+
+func (c *Client) DownloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+
+	var info file
+	if err := c.call(ctx, "getFile", map[string]interface{}{"file_id": fileID}, &info); err != nil {
+		return nil, err
+	}
+	if info.FilePath == "" {
+		return nil, fmt.Errorf("telegram file %s is not available for download", fileID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/file/bot%s/%s", c.baseURL, c.token, info.FilePath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("telegram file download failed: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("telegram file download failed: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read telegram file: %w", err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("telegram file %s exceeds %d bytes", fileID, maxDownloadSize)
+	}
+	return data, nil
+}
+
+// call выполняет метод Bot API с JSON-параметрами
+func (c *Client) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+	return c.do(ctx, method, "application/json", bytes.NewReader(body), result)
+}
+
+func (c *Client) do(ctx context.Context, method, contentType string, body io.Reader, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/bot%s/%s", c.baseURL, c.token, method), body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Адрес запроса содержит токен бота: в ошибку попадает только метод
+		return fmt.Errorf("telegram %s request failed: %w", method, unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	var envelope apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("invalid telegram %s response (status %d): %w", method, resp.StatusCode, err)
+	}
+	if !envelope.OK {
+		return fmt.Errorf("telegram %s failed: %d %s", method, envelope.ErrorCode, envelope.Description)
+	}
+	if result != nil {
+		if err := json.Unmarshal(envelope.Result, result); err != nil {
+			return fmt.Errorf("invalid telegram %s result: %w", method, err)
+		}
+	}
+	return nil
+}
+
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// isPhoto проверяет, можно ли отправить вложение через sendPhoto
+func isPhoto(attachment domain.Attachment) bool {
+	switch attachment.ContentType {
+	case "image/jpeg", "image/png":
+		return len(attachment.Data) <= maxPhotoSize
+	default:
+		return false
+	}
+}
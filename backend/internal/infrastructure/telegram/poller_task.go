@@ -0,0 +1,135 @@
+// internal/infrastructure/telegram/poller_task.go
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// PollerTask фоновая задача, получающая сообщения бота через long polling.
+// Запросы getUpdates идут друг за другом без паузы: ожидание выполняет сам Bot API.
+// После ошибки следующий запрос выполняется через retryInterval
+type PollerTask struct {
+	telegramService  ports.TelegramService
+	retryInterval    time.Duration
+	operationTimeout time.Duration
+	logger           ports.Logger
+	cancelFunc       context.CancelFunc
+	isRunning        bool
+	lastRunAt        time.Time
+	mu               sync.RWMutex
+}
+
+func NewPollerTask(
+	telegramService ports.TelegramService,
+	retryInterval time.Duration,
+	operationTimeout time.Duration,
+	logger ports.Logger,
+) *PollerTask {
+	return &PollerTask{
+		telegramService:  telegramService,
+		retryInterval:    retryInterval,
+		operationTimeout: operationTimeout,
+		logger:           logger,
+		isRunning:        false,
+	}
+}
+
+func (t *PollerTask) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isRunning {
+		return fmt.Errorf("telegram poller task already running")
+	}
+	if t.retryInterval <= 0 {
+		return fmt.Errorf("telegram poll retry interval must be positive")
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	t.cancelFunc = cancel
+	t.isRunning = true
+
+	go t.runLoop(taskCtx)
+
+	t.logger.Info(ctx, "telegram poller task started",
+		"retry_interval", t.retryInterval,
+		"operation_timeout", t.operationTimeout)
+
+	return nil
+}
+
+func (t *PollerTask) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.isRunning {
+		return nil
+	}
+
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
+
+	t.isRunning = false
+	t.logger.Info(ctx, "telegram poller task stopped")
+	return nil
+}
+
+func (t *PollerTask) Name() string {
+	return "telegram_poller"
+}
+
+func (t *PollerTask) Health(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.isRunning {
+		return fmt.Errorf("telegram poller task is not running")
+	}
+	if !t.lastRunAt.IsZero() && time.Since(t.lastRunAt) > 3*(t.operationTimeout+t.retryInterval) {
+		return fmt.Errorf("telegram updates have not been polled since %s", t.lastRunAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (t *PollerTask) runLoop(ctx context.Context) {
+	for {
+		wait := time.Duration(0)
+		if err := t.executeRun(ctx); err != nil {
+			wait = t.retryInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			t.logger.Info(ctx, "telegram poller loop stopped")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (t *PollerTask) executeRun(ctx context.Context) error {
+	now := time.Now()
+	runCtx := context.WithValue(ctx, ports.CorrelationIDKey, fmt.Sprintf("telegram-%d", now.UnixNano()))
+
+	timeoutCtx, cancel := context.WithTimeout(runCtx, t.operationTimeout)
+	defer cancel()
+
+	handled, err := t.telegramService.PollUpdates(timeoutCtx)
+	if err != nil && ctx.Err() == nil {
+		t.logger.Error(runCtx, "telegram poll failed", "error", err)
+	} else if handled > 0 {
+		t.logger.Debug(runCtx, "telegram messages handled", "count", handled)
+	}
+
+	t.mu.Lock()
+	t.lastRunAt = now
+	t.mu.Unlock()
+
+	return err
+}
@@ -0,0 +1,149 @@
+// internal/infrastructure/telegram/types.go
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/audetv/urms/internal/core/domain"
+)
+
+// Типы Bot API, нужные для переписки: остальные поля обновлений игнорируются
+
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+// Update обновление getUpdates или тело запроса вебхука
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message"`
+}
+
+type Message struct {
+	MessageID int64       `json:"message_id"`
+	From      *User       `json:"from"`
+	Chat      Chat        `json:"chat"`
+	Date      int64       `json:"date"`
+	Text      string      `json:"text"`
+	Caption   string      `json:"caption"`
+	Photo     []PhotoSize `json:"photo"`
+	Document  *Document   `json:"document"`
+}
+
+type User struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+}
+
+type Chat struct {
+	ID        int64  `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type PhotoSize struct {
+	FileID       string `json:"file_id"`
+	FileUniqueID string `json:"file_unique_id"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	FileSize     int64  `json:"file_size"`
+}
+
+type Document struct {
+	FileID       string `json:"file_id"`
+	FileUniqueID string `json:"file_unique_id"`
+	FileName     string `json:"file_name"`
+	MimeType     string `json:"mime_type"`
+	FileSize     int64  `json:"file_size"`
+}
+
+type file struct {
+	FileID   string `json:"file_id"`
+	FileSize int64  `json:"file_size"`
+	FilePath string `json:"file_path"`
+}
+
+// ParseUpdate разбирает тело запроса вебхука Bot API.
+// Возвращает false для обновлений, не содержащих сообщения клиента
+func ParseUpdate(body []byte) (domain.TelegramMessage, bool, error) {
+	var update Update
+	if err := json.Unmarshal(body, &update); err != nil {
+		return domain.TelegramMessage{}, false, fmt.Errorf("invalid telegram update: %w", err)
+	}
+	message, ok := update.ToDomain()
+	return message, ok, nil
+}
+
+// ToDomain преобразует обновление в сообщение чата. Сообщения ботов, служебные
+// сообщения и сообщения без текста и файлов (стикеры, опросы) пропускаются
+func (u *Update) ToDomain() (domain.TelegramMessage, bool) {
+	m := u.Message
+	if m == nil || (m.From != nil && m.From.IsBot) {
+		return domain.TelegramMessage{}, false
+	}
+
+	message := domain.TelegramMessage{
+		UpdateID:  u.UpdateID,
+		ChatID:    m.Chat.ID,
+		MessageID: m.MessageID,
+		Username:  m.Chat.Username,
+		FirstName: m.Chat.FirstName,
+		LastName:  m.Chat.LastName,
+		Text:      m.Text,
+		SentAt:    time.Unix(m.Date, 0),
+	}
+	if m.From != nil {
+		message.Username = m.From.Username
+		message.FirstName = m.From.FirstName
+		message.LastName = m.From.LastName
+	}
+	if message.Text == "" {
+		message.Text = m.Caption
+	}
+
+	// Telegram присылает фото в нескольких размерах по возрастанию: берем самый крупный
+	if len(m.Photo) > 0 {
+		photo := m.Photo[len(m.Photo)-1]
+		message.Attachments = append(message.Attachments, domain.TelegramAttachment{
+			Kind:     domain.TelegramAttachmentPhoto,
+			FileID:   photo.FileID,
+			FileName: fmt.Sprintf("photo_%s.jpg", photo.FileUniqueID),
+			MimeType: "image/jpeg",
+			Size:     photo.FileSize,
+		})
+	}
+	if m.Document != nil {
+		name := m.Document.FileName
+		if name == "" {
+			name = "document_" + m.Document.FileUniqueID
+		}
+		mimeType := m.Document.MimeType
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		message.Attachments = append(message.Attachments, domain.TelegramAttachment{
+			Kind:     domain.TelegramAttachmentDocument,
+			FileID:   m.Document.FileID,
+			FileName: name,
+			MimeType: mimeType,
+			Size:     m.Document.FileSize,
+		})
+	}
+
+	if strings.TrimSpace(message.Text) == "" && len(message.Attachments) == 0 {
+		return domain.TelegramMessage{}, false
+	}
+	return message, true
+}
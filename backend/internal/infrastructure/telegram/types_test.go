@@ -0,0 +1,46 @@
+// internal/infrastructure/telegram/types_test.go
+package telegram
+
+import (
+	"testing"
+
+	"github.com/audetv/urms/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpdate(t *testing.T) {
+	message, ok, err := ParseUpdate([]byte(`{"update_id": 7, "message": {
+		"message_id": 42, "date": 1700000000,
+		"from": {"id": 1, "username": "client"},
+		"chat": {"id": -100500, "type": "group", "title": "ООО Ромашка"},
+		"document": {"file_id": "doc-1", "file_unique_id": "u1", "file_size": 2048}
+	}}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, int64(-100500), message.ChatID)
+	assert.Equal(t, "-100500:42", message.ExternalID())
+	assert.Equal(t, "@client", message.DisplayName())
+	assert.Equal(t, "Сообщение из Telegram от @client", message.Subject())
+	assert.Equal(t, []domain.TelegramAttachment{{
+		Kind:     domain.TelegramAttachmentDocument,
+		FileID:   "doc-1",
+		FileName: "document_u1",
+		MimeType: "application/octet-stream",
+		Size:     2048,
+	}}, message.Attachments)
+
+	skipped := []string{
+		`{"update_id": 8, "edited_message": {"message_id": 42, "chat": {"id": 1}, "text": "правка"}}`,
+		`{"update_id": 9, "message": {"message_id": 43, "from": {"id": 2, "is_bot": true}, "chat": {"id": 1}, "text": "бот"}}`,
+		`{"update_id": 10, "message": {"message_id": 44, "from": {"id": 1}, "chat": {"id": 1}, "sticker": {"file_id": "s"}}}`,
+	}
+	for _, body := range skipped {
+		_, ok, err := ParseUpdate([]byte(body))
+		require.NoError(t, err)
+		assert.False(t, ok, body)
+	}
+
+	_, _, err = ParseUpdate([]byte(`{"update_id":`))
+	assert.Error(t, err)
+}
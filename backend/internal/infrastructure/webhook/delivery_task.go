@@ -0,0 +1,131 @@
+// internal/infrastructure/webhook/delivery_task.go
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/audetv/urms/internal/core/ports"
+)
+
+// DeliveryTask фоновая задача, выполняющая наступившие попытки доставки вебхуков.
+// Очередь доставок хранится в репозитории, поэтому после перезапуска
+// ожидающие доставки продолжаются с того же места
+type DeliveryTask struct {
+	webhookService   ports.WebhookService
+	interval         time.Duration
+	operationTimeout time.Duration
+	logger           ports.Logger
+	cancelFunc       context.CancelFunc
+	isRunning        bool
+	lastRunAt        time.Time
+	mu               sync.RWMutex
+}
+
+func NewDeliveryTask(
+	webhookService ports.WebhookService,
+	interval time.Duration,
+	operationTimeout time.Duration,
+	logger ports.Logger,
+) *DeliveryTask {
+	return &DeliveryTask{
+		webhookService:   webhookService,
+		interval:         interval,
+		operationTimeout: operationTimeout,
+		logger:           logger,
+		isRunning:        false,
+	}
+}
+
+func (t *DeliveryTask) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isRunning {
+		return fmt.Errorf("webhook delivery task already running")
+	}
+	if t.interval <= 0 {
+		return fmt.Errorf("webhook delivery interval must be positive")
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	t.cancelFunc = cancel
+	t.isRunning = true
+
+	go t.runLoop(taskCtx)
+
+	t.logger.Info(ctx, "webhook delivery task started",
+		"interval", t.interval,
+		"operation_timeout", t.operationTimeout)
+
+	return nil
+}
+
+func (t *DeliveryTask) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.isRunning {
+		return nil
+	}
+
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
+
+	t.isRunning = false
+	t.logger.Info(ctx, "webhook delivery task stopped")
+	return nil
+}
+
+func (t *DeliveryTask) Name() string {
+	return "webhook_delivery"
+}
+
+func (t *DeliveryTask) Health(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.isRunning {
+		return fmt.Errorf("webhook delivery task is not running")
+	}
+	if !t.lastRunAt.IsZero() && time.Since(t.lastRunAt) > 3*t.interval+t.operationTimeout {
+		return fmt.Errorf("webhook delivery has not run since %s", t.lastRunAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (t *DeliveryTask) runLoop(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	t.executeRun(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.logger.Info(ctx, "webhook delivery loop stopped")
+			return
+		case <-ticker.C:
+			t.executeRun(ctx)
+		}
+	}
+}
+
+func (t *DeliveryTask) executeRun(ctx context.Context) {
+	now := time.Now()
+	runCtx := context.WithValue(ctx, ports.CorrelationIDKey, fmt.Sprintf("webhooks-%d", now.UnixNano()))
+
+	timeoutCtx, cancel := context.WithTimeout(runCtx, t.operationTimeout)
+	defer cancel()
+
+	if _, err := t.webhookService.DeliverDue(timeoutCtx, now); err != nil {
+		t.logger.Error(runCtx, "webhook delivery run failed", "error", err)
+	}
+
+	t.mu.Lock()
+	t.lastRunAt = now
+	t.mu.Unlock()
+}
@@ -0,0 +1,52 @@
+// internal/infrastructure/webhook/http_sender.go
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxResponseDrain сколько байт ответа дочитывается для повторного использования соединения
+const maxResponseDrain = 64 * 1024
+
+// HTTPSender реализует ports.WebhookSender поверх net/http
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender создает отправителя; таймаут попытки задается context вызова,
+// timeout клиента - верхняя граница на случай его отсутствия
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Перенаправления не выполняются: подпись привязана к адресу подписки
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseDrain))
+
+	return resp.StatusCode, nil
+}